@@ -1,3 +1,4 @@
 from app.agent.service.agent_service import AgentService, get_agent_service
+from app.agent.service.video_ai_service import VideoAIService, get_video_ai_service
 
-__all__ = ["AgentService", "get_agent_service"]
+__all__ = ["AgentService", "get_agent_service", "VideoAIService", "get_video_ai_service"]
@@ -0,0 +1,145 @@
+"""
+视频AI服务 - 根据标题、描述和抽帧生成标签、分类、摘要与关键时刻，并提供视频问答
+"""
+import json
+import logging
+import re
+from pathlib import Path
+from typing import AsyncIterator, Dict, List, Optional
+from langchain_core.messages import HumanMessage, SystemMessage
+from app.agent.infra.llm_factory import get_llm
+from app.core.config import settings
+
+logger = logging.getLogger(__name__)
+
+PROMPT_DIR = Path(__file__).parent.parent / "prompts"
+
+
+def _load_prompt(name: str) -> str:
+    """加载提示词文件"""
+    try:
+        return (PROMPT_DIR / name).read_text(encoding="utf-8")
+    except OSError as e:
+        logger.error(f"加载提示词文件失败: {e}")
+        return ""
+
+
+def _parse_json(text: str) -> dict:
+    """从模型回复中提取 JSON 对象（兼容 ```json 代码块）"""
+    match = re.search(r"\{.*\}", text, re.S)
+    if not match:
+        raise ValueError(f"模型回复中没有 JSON: {text[:200]}")
+    return json.loads(match.group(0))
+
+
+def _frame_content(title: str, description: str, duration: int, frames: List[Dict]) -> List[Dict]:
+    """构造多模态消息：文字信息 + 按时间顺序的抽帧（每帧前标注秒数）"""
+    content = [{"type": "text", "text": f"标题：{title}\n描述：{description or '无'}\n时长：{duration} 秒"}]
+    for frame in frames:
+        content.append({"type": "text", "text": f"第 {frame['time']} 秒："})
+        content.append({"type": "image_url", "image_url": {"url": frame["url"]}})
+    return content
+
+
+def _string_list(value) -> List[str]:
+    if not isinstance(value, list):
+        return []
+    return [str(v).strip() for v in value if str(v).strip()]
+
+
+class VideoAIService:
+    """视频AI服务类"""
+
+    def __init__(self):
+        self.vision_llm = get_llm(settings.LLM_VISION_MODEL)
+        self.llm = get_llm()
+        self.tag_prompt = _load_prompt("video_tag_prompt.md")
+        self.summary_prompt = _load_prompt("video_summary_prompt.md")
+        self.ask_prompt = _load_prompt("video_ask_prompt.md")
+
+    def is_available(self) -> bool:
+        """检查服务是否可用"""
+        return self.vision_llm is not None and self.llm is not None
+
+    async def _invoke_json(self, prompt: str, content: List[Dict]) -> dict:
+        result = await self.vision_llm.ainvoke([
+            SystemMessage(content=prompt),
+            HumanMessage(content=content),
+        ])
+        return _parse_json(str(result.content))
+
+    async def suggest_tags(self, title: str, description: str, duration: int, frames: List[Dict]) -> Dict[str, List[str]]:
+        """
+        生成视频标签与分类
+        
+        Args:
+            title: 视频标题
+            description: 视频描述
+            duration: 视频时长（秒）
+            frames: 抽帧 [{"url": ..., "time": 秒}]
+            
+        Returns:
+            {"tags": [...], "categories": [...]}
+        """
+        data = await self._invoke_json(self.tag_prompt, _frame_content(title, description, duration, frames))
+        return {
+            "tags": _string_list(data.get("tags")),
+            "categories": _string_list(data.get("categories")),
+        }
+
+    async def summarize(self, title: str, description: str, duration: int, frames: List[Dict]) -> Dict:
+        """
+        生成视频摘要与关键时刻
+        
+        Returns:
+            {"summary": "...", "key_moments": [{"time": 秒, "label": "..."}]}
+        """
+        data = await self._invoke_json(self.summary_prompt, _frame_content(title, description, duration, frames))
+        moments = []
+        for m in data.get("key_moments") or []:
+            try:
+                moments.append({"time": int(m["time"]), "label": str(m["label"]).strip()})
+            except (KeyError, TypeError, ValueError):
+                continue
+        return {"summary": str(data.get("summary") or "").strip(), "key_moments": moments}
+
+    async def ask(self, question: str, video: Dict) -> AsyncIterator[str]:
+        """
+        视频问答（流式）
+        
+        Args:
+            question: 用户问题
+            video: 视频信息（title、description、duration、summary、key_moments、tags）
+            
+        Yields:
+            回复文本片段
+        """
+        moments = "\n".join(
+            f"- {m['time'] // 60:02d}:{m['time'] % 60:02d} {m['label']}" for m in video.get("key_moments") or []
+        )
+        context = (
+            f"标题：{video.get('title', '')}\n"
+            f"描述：{video.get('description') or '无'}\n"
+            f"时长：{video.get('duration', 0)} 秒\n"
+            f"摘要：{video.get('summary') or '无'}\n"
+            f"关键时刻：\n{moments or '无'}\n"
+            f"标签：{'、'.join(video.get('tags') or []) or '无'}"
+        )
+        messages = [
+            SystemMessage(content=f"{self.ask_prompt}\n\n## 视频信息\n\n{context}"),
+            HumanMessage(content=question),
+        ]
+        async for chunk in self.llm.astream(messages):
+            if chunk.content:
+                yield str(chunk.content)
+
+
+_video_ai_service_instance: Optional[VideoAIService] = None
+
+
+def get_video_ai_service() -> VideoAIService:
+    """获取视频AI服务单例"""
+    global _video_ai_service_instance
+    if _video_ai_service_instance is None:
+        _video_ai_service_instance = VideoAIService()
+    return _video_ai_service_instance
@@ -0,0 +1,121 @@
+"""
+视频AI API
+供Go API服务调用：视频发布后生成标签、摘要与关键时刻，以及视频问答
+"""
+import json
+import logging
+from typing import List, Optional
+from fastapi import APIRouter
+from fastapi.responses import StreamingResponse
+from pydantic import BaseModel, Field
+
+from app.agent.service import get_video_ai_service
+
+logger = logging.getLogger(__name__)
+
+router = APIRouter(prefix="/api/v1/agent/videos", tags=["视频AI"])
+
+
+class Frame(BaseModel):
+    """视频抽帧"""
+    url: str = Field(..., description="图片地址")
+    time: int = Field(..., description="在视频中的位置（秒）")
+
+
+class VideoRequest(BaseModel):
+    """视频分析请求"""
+    video_id: int = Field(..., description="视频ID")
+    title: str = Field(..., description="视频标题")
+    description: str = Field("", description="视频描述")
+    duration: int = Field(0, description="视频时长（秒）")
+    frames: List[Frame] = Field(default_factory=list, description="按时间顺序的抽帧")
+
+
+class VideoAIResponse(BaseModel):
+    """视频分析响应"""
+    code: int = Field(200, description="状态码")
+    message: str = Field("success", description="状态消息")
+    data: Optional[dict] = Field(None, description="分析结果")
+
+
+@router.post("/tags", response_model=VideoAIResponse)
+async def suggest_tags(request: VideoRequest):
+    """
+    根据标题、描述和抽帧生成建议标签与分类
+    """
+    service = get_video_ai_service()
+    if not service.is_available():
+        return VideoAIResponse(code=503, message="视频AI服务暂不可用，请检查配置")
+
+    try:
+        frames = [f.model_dump() for f in request.frames]
+        data = await service.suggest_tags(request.title, request.description, request.duration, frames)
+        return VideoAIResponse(data=data)
+    except Exception as e:
+        logger.error(f"生成视频标签失败 video_id={request.video_id}: {e}", exc_info=True)
+        return VideoAIResponse(code=500, message=f"生成视频标签失败: {str(e)}")
+
+
+@router.post("/summary", response_model=VideoAIResponse)
+async def summarize(request: VideoRequest):
+    """
+    根据标题、描述和抽帧生成摘要与关键时刻
+    """
+    service = get_video_ai_service()
+    if not service.is_available():
+        return VideoAIResponse(code=503, message="视频AI服务暂不可用，请检查配置")
+
+    try:
+        frames = [f.model_dump() for f in request.frames]
+        data = await service.summarize(request.title, request.description, request.duration, frames)
+        return VideoAIResponse(data=data)
+    except Exception as e:
+        logger.error(f"生成视频摘要失败 video_id={request.video_id}: {e}", exc_info=True)
+        return VideoAIResponse(code=500, message=f"生成视频摘要失败: {str(e)}")
+
+
+class KeyMoment(BaseModel):
+    """关键时刻"""
+    time: int = Field(..., description="秒")
+    label: str = Field(..., description="说明")
+
+
+class AskRequest(BaseModel):
+    """视频问答请求（配额与权限由Go API服务校验）"""
+    video_id: int = Field(..., description="视频ID")
+    user_id: int = Field(..., description="提问用户ID")
+    question: str = Field(..., description="问题")
+    title: str = Field(..., description="视频标题")
+    description: str = Field("", description="视频描述")
+    duration: int = Field(0, description="视频时长（秒）")
+    summary: str = Field("", description="AI 摘要")
+    key_moments: List[KeyMoment] = Field(default_factory=list, description="关键时刻")
+    tags: List[str] = Field(default_factory=list, description="标签")
+
+
+def _sse(code: int, message: str, data: Optional[dict]) -> str:
+    chunk = {"code": code, "message": message, "data": data}
+    return f"data: {json.dumps(chunk, ensure_ascii=False)}\n\n"
+
+
+@router.post("/ask")
+async def ask(request: AskRequest):
+    """
+    视频问答，流式返回回复
+    """
+    service = get_video_ai_service()
+
+    async def generate_response():
+        if not service.is_available():
+            yield _sse(503, "视频AI服务暂不可用", None)
+            return
+        try:
+            video = request.model_dump(exclude={"question", "user_id", "video_id"})
+            async for content in service.ask(request.question.strip(), video):
+                yield _sse(200, "streaming", {"content": content})
+            yield _sse(200, "done", {"video_id": request.video_id})
+        except Exception as e:
+            logger.error(f"视频问答失败 video_id={request.video_id} user_id={request.user_id}: {e}", exc_info=True)
+            yield _sse(500, f"视频问答失败: {str(e)}", None)
+
+    return StreamingResponse(generate_response(), media_type="text/event-stream")
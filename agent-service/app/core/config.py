@@ -23,6 +23,7 @@ class Settings(BaseSettings):
     DASHSCOPE_API_KEY: Optional[str] = None
     LLM_BASE_URL: str = "https://dashscope.aliyuncs.com/compatible-mode/v1"
     LLM_MODEL: str = "qwen-max"
+    LLM_VISION_MODEL: str = "qwen-vl-max"  # 视频打标签等需要理解画面的任务
     
     # Memory Configuration
     MEMORY_MAX_TOKENS: int = 2000
@@ -63,6 +63,10 @@ async def health_check():
 from app.api.agent import router as agent_router
 app.include_router(agent_router)
 
+# 注册视频AI路由
+from app.api.video import router as video_router
+app.include_router(video_router)
+
 
 if __name__ == "__main__":
     import uvicorn
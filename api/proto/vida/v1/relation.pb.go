@@ -0,0 +1,536 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: vida/v1/relation.proto
+
+package vidav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RelationUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Avatar        *string                `protobuf:"bytes,3,opt,name=avatar,proto3,oneof" json:"avatar,omitempty"`
+	FollowCount   int64                  `protobuf:"varint,4,opt,name=follow_count,json=followCount,proto3" json:"follow_count,omitempty"`
+	FollowerCount int64                  `protobuf:"varint,5,opt,name=follower_count,json=followerCount,proto3" json:"follower_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationUser) Reset() {
+	*x = RelationUser{}
+	mi := &file_vida_v1_relation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationUser) ProtoMessage() {}
+
+func (x *RelationUser) ProtoReflect() protoreflect.Message {
+	mi := &file_vida_v1_relation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationUser.ProtoReflect.Descriptor instead.
+func (*RelationUser) Descriptor() ([]byte, []int) {
+	return file_vida_v1_relation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RelationUser) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RelationUser) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *RelationUser) GetAvatar() string {
+	if x != nil && x.Avatar != nil {
+		return *x.Avatar
+	}
+	return ""
+}
+
+func (x *RelationUser) GetFollowCount() int64 {
+	if x != nil {
+		return x.FollowCount
+	}
+	return 0
+}
+
+func (x *RelationUser) GetFollowerCount() int64 {
+	if x != nil {
+		return x.FollowerCount
+	}
+	return 0
+}
+
+type IsFollowingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    int64                  `protobuf:"varint,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsFollowingRequest) Reset() {
+	*x = IsFollowingRequest{}
+	mi := &file_vida_v1_relation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingRequest) ProtoMessage() {}
+
+func (x *IsFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vida_v1_relation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingRequest.ProtoReflect.Descriptor instead.
+func (*IsFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_vida_v1_relation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IsFollowingRequest) GetFollowerId() int64 {
+	if x != nil {
+		return x.FollowerId
+	}
+	return 0
+}
+
+func (x *IsFollowingRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type IsFollowingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Following     bool                   `protobuf:"varint,1,opt,name=following,proto3" json:"following,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsFollowingResponse) Reset() {
+	*x = IsFollowingResponse{}
+	mi := &file_vida_v1_relation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsFollowingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingResponse) ProtoMessage() {}
+
+func (x *IsFollowingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vida_v1_relation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingResponse.ProtoReflect.Descriptor instead.
+func (*IsFollowingResponse) Descriptor() ([]byte, []int) {
+	return file_vida_v1_relation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IsFollowingResponse) GetFollowing() bool {
+	if x != nil {
+		return x.Following
+	}
+	return false
+}
+
+type BatchIsFollowingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    int64                  `protobuf:"varint,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	UserIds       []int64                `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchIsFollowingRequest) Reset() {
+	*x = BatchIsFollowingRequest{}
+	mi := &file_vida_v1_relation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchIsFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchIsFollowingRequest) ProtoMessage() {}
+
+func (x *BatchIsFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vida_v1_relation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchIsFollowingRequest.ProtoReflect.Descriptor instead.
+func (*BatchIsFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_vida_v1_relation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchIsFollowingRequest) GetFollowerId() int64 {
+	if x != nil {
+		return x.FollowerId
+	}
+	return 0
+}
+
+func (x *BatchIsFollowingRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type BatchIsFollowingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Following     map[int64]bool         `protobuf:"bytes,1,rep,name=following,proto3" json:"following,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchIsFollowingResponse) Reset() {
+	*x = BatchIsFollowingResponse{}
+	mi := &file_vida_v1_relation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchIsFollowingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchIsFollowingResponse) ProtoMessage() {}
+
+func (x *BatchIsFollowingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vida_v1_relation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchIsFollowingResponse.ProtoReflect.Descriptor instead.
+func (*BatchIsFollowingResponse) Descriptor() ([]byte, []int) {
+	return file_vida_v1_relation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchIsFollowingResponse) GetFollowing() map[int64]bool {
+	if x != nil {
+		return x.Following
+	}
+	return nil
+}
+
+type ListRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsRequest) Reset() {
+	*x = ListRelationsRequest{}
+	mi := &file_vida_v1_relation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsRequest) ProtoMessage() {}
+
+func (x *ListRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vida_v1_relation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsRequest.ProtoReflect.Descriptor instead.
+func (*ListRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_vida_v1_relation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRelationsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListRelationsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRelationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListRelationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*RelationUser        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsResponse) Reset() {
+	*x = ListRelationsResponse{}
+	mi := &file_vida_v1_relation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsResponse) ProtoMessage() {}
+
+func (x *ListRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vida_v1_relation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsResponse.ProtoReflect.Descriptor instead.
+func (*ListRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_vida_v1_relation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRelationsResponse) GetUsers() []*RelationUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListRelationsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListRelationsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRelationsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRelationsResponse) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+var File_vida_v1_relation_proto protoreflect.FileDescriptor
+
+const file_vida_v1_relation_proto_rawDesc = "" +
+	"\n" +
+	"\x16vida/v1/relation.proto\x12\avida.v1\"\xad\x01\n" +
+	"\fRelationUser\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\x12\x1b\n" +
+	"\x06avatar\x18\x03 \x01(\tH\x00R\x06avatar\x88\x01\x01\x12!\n" +
+	"\ffollow_count\x18\x04 \x01(\x03R\vfollowCount\x12%\n" +
+	"\x0efollower_count\x18\x05 \x01(\x03R\rfollowerCountB\t\n" +
+	"\a_avatar\"N\n" +
+	"\x12IsFollowingRequest\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\x03R\n" +
+	"followerId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"3\n" +
+	"\x13IsFollowingResponse\x12\x1c\n" +
+	"\tfollowing\x18\x01 \x01(\bR\tfollowing\"U\n" +
+	"\x17BatchIsFollowingRequest\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\x03R\n" +
+	"followerId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\x03R\auserIds\"\xa8\x01\n" +
+	"\x18BatchIsFollowingResponse\x12N\n" +
+	"\tfollowing\x18\x01 \x03(\v20.vida.v1.BatchIsFollowingResponse.FollowingEntryR\tfollowing\x1a<\n" +
+	"\x0eFollowingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"`\n" +
+	"\x14ListRelationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xac\x01\n" +
+	"\x15ListRelationsResponse\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.vida.v1.RelationUserR\x05users\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x03R\n" +
+	"totalPages2\xd4\x02\n" +
+	"\x0fRelationService\x12H\n" +
+	"\vIsFollowing\x12\x1b.vida.v1.IsFollowingRequest\x1a\x1c.vida.v1.IsFollowingResponse\x12W\n" +
+	"\x10BatchIsFollowing\x12 .vida.v1.BatchIsFollowingRequest\x1a!.vida.v1.BatchIsFollowingResponse\x12N\n" +
+	"\rListFollowers\x12\x1d.vida.v1.ListRelationsRequest\x1a\x1e.vida.v1.ListRelationsResponse\x12N\n" +
+	"\rListFollowing\x12\x1d.vida.v1.ListRelationsRequest\x1a\x1e.vida.v1.ListRelationsResponseB\"Z vida-go/api/proto/vida/v1;vidav1b\x06proto3"
+
+var (
+	file_vida_v1_relation_proto_rawDescOnce sync.Once
+	file_vida_v1_relation_proto_rawDescData []byte
+)
+
+func file_vida_v1_relation_proto_rawDescGZIP() []byte {
+	file_vida_v1_relation_proto_rawDescOnce.Do(func() {
+		file_vida_v1_relation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vida_v1_relation_proto_rawDesc), len(file_vida_v1_relation_proto_rawDesc)))
+	})
+	return file_vida_v1_relation_proto_rawDescData
+}
+
+var file_vida_v1_relation_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_vida_v1_relation_proto_goTypes = []any{
+	(*RelationUser)(nil),             // 0: vida.v1.RelationUser
+	(*IsFollowingRequest)(nil),       // 1: vida.v1.IsFollowingRequest
+	(*IsFollowingResponse)(nil),      // 2: vida.v1.IsFollowingResponse
+	(*BatchIsFollowingRequest)(nil),  // 3: vida.v1.BatchIsFollowingRequest
+	(*BatchIsFollowingResponse)(nil), // 4: vida.v1.BatchIsFollowingResponse
+	(*ListRelationsRequest)(nil),     // 5: vida.v1.ListRelationsRequest
+	(*ListRelationsResponse)(nil),    // 6: vida.v1.ListRelationsResponse
+	nil,                              // 7: vida.v1.BatchIsFollowingResponse.FollowingEntry
+}
+var file_vida_v1_relation_proto_depIdxs = []int32{
+	7, // 0: vida.v1.BatchIsFollowingResponse.following:type_name -> vida.v1.BatchIsFollowingResponse.FollowingEntry
+	0, // 1: vida.v1.ListRelationsResponse.users:type_name -> vida.v1.RelationUser
+	1, // 2: vida.v1.RelationService.IsFollowing:input_type -> vida.v1.IsFollowingRequest
+	3, // 3: vida.v1.RelationService.BatchIsFollowing:input_type -> vida.v1.BatchIsFollowingRequest
+	5, // 4: vida.v1.RelationService.ListFollowers:input_type -> vida.v1.ListRelationsRequest
+	5, // 5: vida.v1.RelationService.ListFollowing:input_type -> vida.v1.ListRelationsRequest
+	2, // 6: vida.v1.RelationService.IsFollowing:output_type -> vida.v1.IsFollowingResponse
+	4, // 7: vida.v1.RelationService.BatchIsFollowing:output_type -> vida.v1.BatchIsFollowingResponse
+	6, // 8: vida.v1.RelationService.ListFollowers:output_type -> vida.v1.ListRelationsResponse
+	6, // 9: vida.v1.RelationService.ListFollowing:output_type -> vida.v1.ListRelationsResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_vida_v1_relation_proto_init() }
+func file_vida_v1_relation_proto_init() {
+	if File_vida_v1_relation_proto != nil {
+		return
+	}
+	file_vida_v1_relation_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vida_v1_relation_proto_rawDesc), len(file_vida_v1_relation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vida_v1_relation_proto_goTypes,
+		DependencyIndexes: file_vida_v1_relation_proto_depIdxs,
+		MessageInfos:      file_vida_v1_relation_proto_msgTypes,
+	}.Build()
+	File_vida_v1_relation_proto = out.File
+	file_vida_v1_relation_proto_goTypes = nil
+	file_vida_v1_relation_proto_depIdxs = nil
+}
@@ -3,28 +3,46 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	"vida-go/internal/api/handler"
 	"vida-go/internal/api/middleware"
 	"vida-go/internal/api/router"
+	"vida-go/internal/api/validation"
 	"vida-go/internal/config"
+	"vida-go/internal/eventbus"
+	"vida-go/internal/graph"
+	infraAgent "vida-go/internal/infra/agent"
 	"vida-go/internal/infra/database"
 	infraES "vida-go/internal/infra/elasticsearch"
+	"vida-go/internal/infra/geoip"
+	infraEmail "vida-go/internal/infra/email"
 	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/infra/logship"
+	"vida-go/internal/infra/metrics"
 	infraMinio "vida-go/internal/infra/minio"
+	infraPush "vida-go/internal/infra/push"
 	infraRedis "vida-go/internal/infra/redis"
+	infraSecrets "vida-go/internal/infra/secrets"
+	"vida-go/internal/infra/tracing"
 	"vida-go/internal/model"
+	"vida-go/internal/rbac"
 	"vida-go/internal/repository"
+	"vida-go/internal/rpc"
+	"vida-go/internal/scheduler"
 	"vida-go/internal/service"
+	"vida-go/pkg/i18n"
 	"vida-go/pkg/logger"
+	"vida-go/pkg/sensitive"
 
-	_ "vida-go/api/openapi"
+	"vida-go/api/openapi"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
 )
 
@@ -48,11 +66,15 @@ import (
 // @description 输入格式: Bearer {token}
 
 func main() {
-	// 加载配置文件
-	cfg, err := config.Load("configs/config.yaml")
+	// 解析命令行参数并加载配置文件
+	flags := config.ParseFlags("vida-api", true)
+	cfg, err := config.Load(flags.ConfigPath)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to load config: %v", err))
 	}
+	if err := flags.Apply(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid command-line flags: %v", err))
+	}
 
 	// 初始化日志系统
 	if err := logger.Init(
@@ -60,11 +82,65 @@ func main() {
 		cfg.Log.Format,
 		cfg.Log.Output,
 		cfg.Log.FilePath,
+		logger.Rotation{
+			MaxSizeMB:  cfg.Log.MaxSizeMB,
+			MaxAgeDays: cfg.Log.MaxAgeDays,
+			MaxBackups: cfg.Log.MaxBackups,
+			Compress:   cfg.Log.Compress,
+		},
 	); err != nil {
 		panic(fmt.Sprintf("Failed to init logger: %v", err))
 	}
 	defer logger.Sync()
 
+	// 集中式日志投递（Kafka / Elasticsearch / Logstash），关闭时先投递剩余日志
+	if cfg.Log.Ship.Enabled {
+		shipper, err := logship.New(&cfg.Log.Ship, &cfg.Kafka, &cfg.Elasticsearch)
+		if err != nil {
+			logger.Fatal("Failed to init log shipping", zap.Error(err))
+		}
+		logger.AddSink(shipper, cfg.Log.Ship.Level)
+		defer shipper.Close()
+	}
+
+	// 从密钥后端读取数据库、Redis、MinIO、JWT 密钥（需在初始化各客户端之前完成）
+	if err := infraSecrets.Init(&cfg.Secrets); err != nil {
+		logger.Fatal("Failed to init secrets backend", zap.Error(err))
+	}
+	if err := infraSecrets.Apply(context.Background(), cfg); err != nil {
+		logger.Fatal("Failed to load secrets", zap.Error(err))
+	}
+
+	// 加载多语言翻译目录
+	if err := i18n.Init(cfg.I18n.DefaultLanguage); err != nil {
+		logger.Fatal("Failed to init i18n", zap.Error(err))
+	}
+
+	// 注册参数校验错误翻译
+	if err := validation.Init(); err != nil {
+		logger.Fatal("Failed to init validation", zap.Error(err))
+	}
+
+	// 加载 IP 归属地数据库（视频地区限制）
+	if err := geoip.Init(&cfg.GeoIP); err != nil {
+		logger.Fatal("Failed to load GeoIP database", zap.Error(err))
+	}
+
+	// 加载私信敏感词库
+	if err := sensitive.Init(cfg.Message.SensitiveWordsFile); err != nil {
+		logger.Fatal("Failed to load sensitive words", zap.Error(err))
+	}
+
+	// 初始化链路追踪（需在各基础设施客户端之前完成）
+	if err := tracing.Init(&cfg.Tracing, cfg.App.Name, cfg.App.Version); err != nil {
+		logger.Fatal("Failed to init tracing", zap.Error(err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = tracing.Shutdown(ctx)
+	}()
+
 	// 初始化数据库
 	if err := database.Init(&cfg.Database); err != nil {
 		logger.Fatal("Failed to init database", zap.Error(err))
@@ -72,15 +148,50 @@ func main() {
 	defer database.Close()
 
 	// 自动迁移数据库表
+	if err := database.RenameLegacyPublishTime(); err != nil {
+		logger.Fatal("Failed to prepare publish_time migration", zap.Error(err))
+	}
 	if err := database.AutoMigrate(
 		&model.User{},
 		&model.Video{},
 		&model.Comment{},
 		&model.Favorite{},
 		&model.Relation{},
+		&model.AuditLog{},
+		&model.Notification{},
+		&model.UserSetting{},
+		&model.DeviceToken{},
+		&model.Conversation{},
+		&model.Message{},
+		&model.VideoDailyStat{},
+		&model.WatchHistory{},
+		&model.VideoTag{},
+		&model.VideoAccessGrant{},
+		&model.VideoPoll{}, &model.VideoPollOption{}, &model.VideoPollVote{},
+		&model.Wallet{}, &model.WalletTransaction{},
+		&model.MembershipTier{}, &model.Membership{},
+		&model.VideoFingerprint{},
+		&model.VideoDuplicate{},
+		&model.VideoRendition{},
+		&model.Report{},
+		&model.LoginEvent{},
+		&model.InviteCode{}, &model.ProfileImageReview{},
+		&model.LiveChannel{}, &model.LiveSession{},
+		&model.ExploreSlot{},
+		&model.OAuthClient{},
+		&model.OAuthAuthorizationCode{},
 	); err != nil {
 		logger.Fatal("Failed to auto migrate", zap.Error(err))
 	}
+	if err := database.MigrateSoftDelete(); err != nil {
+		logger.Fatal("Failed to migrate soft delete", zap.Error(err))
+	}
+	if err := database.MigratePublishTime(); err != nil {
+		logger.Fatal("Failed to migrate publish_time", zap.Error(err))
+	}
+	if err := database.MigrateReactions(); err != nil {
+		logger.Fatal("Failed to migrate reactions", zap.Error(err))
+	}
 
 	// 初始化Redis
 	if err := infraRedis.Init(&cfg.Redis); err != nil {
@@ -90,7 +201,10 @@ func main() {
 
 	// 初始化MinIO
 	if err := infraMinio.Init(&cfg.MinIO); err != nil {
-		logger.Fatal("Failed to init minio", zap.Error(err))
+		if !cfg.App.Standalone {
+			logger.Fatal("Failed to init minio", zap.Error(err))
+		}
+		logger.Warn("MinIO unavailable in standalone mode, uploads disabled", zap.Error(err))
 	}
 
 	// 初始化Kafka生产者
@@ -109,15 +223,43 @@ func main() {
 		}
 	}
 
+	// 初始化邮件发送（未启用时不发送任何邮件）
+	infraEmail.Init(&cfg.Email)
+
+	// 初始化移动端推送（可选，失败则不推送）
+	if err := infraPush.Init(&cfg.Push); err != nil {
+		logger.Warn("Push init failed, mobile push disabled", zap.Error(err))
+	}
+
+	// 初始化 Agent 服务客户端（AI 标签等）
+	infraAgent.Init(&cfg.Agent)
+
 	// 设置Gin模式
 	gin.SetMode(cfg.App.Mode)
 
 	// 创建Gin路由器（不使用默认中间件）
 	r := gin.New()
+	r.MaxMultipartMemory = cfg.RequestLimit.MultipartMemory()
+	// 客户端 IP 用于地区限制、登录地点与审计日志，只采信可信代理转发的来源地址
+	if err := r.SetTrustedProxies(cfg.App.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
 
 	// 使用自定义中间件
 	r.Use(middleware.Recovery())
-	r.Use(middleware.Logger())
+	r.Use(otelgin.Middleware(cfg.App.Name, otelgin.WithFilter(skipProbeTracing)))
+	r.Use(middleware.RequestContext())
+	r.Use(middleware.Logger(cfg.Log.SlowRequestThreshold()))
+	r.Use(middleware.Locale())
+	r.Use(middleware.GeoIP(cfg.GeoIP.CountryHeader))
+	r.Use(middleware.BodyLimit(cfg.RequestLimit.MaxBody()))
+	if cfg.OpenAPI.Validate {
+		spec, err := validation.ParseSpec([]byte(openapi.SwaggerInfo.ReadDoc()))
+		if err != nil {
+			logger.Fatal("Failed to load OpenAPI spec", zap.Error(err))
+		}
+		r.Use(middleware.OpenAPIValidation(validation.NewRequestValidator(spec, cfg.OpenAPI.Strict)))
+	}
 
 	// 初始化依赖（Repository -> Service -> Handler）
 	db := database.Get()
@@ -127,64 +269,315 @@ func main() {
 	videoRepo := repository.NewVideoRepository(db)
 	commentRepo := repository.NewCommentRepository(db)
 	favoriteRepo := repository.NewFavoriteRepository(db)
-
-	authService := service.NewAuthService(userRepo)
-	userService := service.NewUserService(userRepo)
-	relationService := service.NewRelationService(relationRepo, userRepo)
-	videoService := service.NewVideoService(videoRepo)
-	commentService := service.NewCommentService(commentRepo, videoRepo)
-	favoriteService := service.NewFavoriteService(favoriteRepo, videoRepo, userRepo)
-	searchService := service.NewSearchService(videoRepo)
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	userSettingRepo := repository.NewUserSettingRepository(db)
+	deviceTokenRepo := repository.NewDeviceTokenRepository(db)
+	messageRepo := repository.NewMessageRepository(db)
+	videoStatRepo := repository.NewVideoStatRepository(db)
+	watchHistoryRepo := repository.NewWatchHistoryRepository(db)
+	videoTagRepo := repository.NewVideoTagRepository(db)
+	videoAccessRepo := repository.NewVideoAccessRepository(db)
+	pollRepo := repository.NewPollRepository(db)
+	walletRepo := repository.NewWalletRepository(db)
+	membershipRepo := repository.NewMembershipRepository(db)
+	videoFingerprintRepo := repository.NewVideoFingerprintRepository(db)
+	videoDuplicateRepo := repository.NewVideoDuplicateRepository(db)
+	videoRenditionRepo := repository.NewVideoRenditionRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	loginEventRepo := repository.NewLoginEventRepository(db)
+	inviteCodeRepo := repository.NewInviteCodeRepository(db)
+	profileImageReviewRepo := repository.NewProfileImageReviewRepository(db)
+	liveChannelRepo := repository.NewLiveChannelRepository(db)
+	liveSessionRepo := repository.NewLiveSessionRepository(db)
+	exploreSlotRepo := repository.NewExploreSlotRepository(db)
+	oauthClientRepo := repository.NewOAuthClientRepository(db)
+	oauthCodeRepo := repository.NewOAuthCodeRepository(db)
+	purgeRepo := repository.NewPurgeRepository(db)
+	txManager := repository.NewTxManager(db)
+
+	eventService := service.NewEventService(infraRedis.Get())
+	eventBusTopic := ""
+	if !cfg.Events.InProcess() {
+		eventBusTopic = cfg.Kafka.Topics["domain_events"]
+	}
+	eventBus := eventbus.New(eventBusTopic)
+	emailService := service.NewEmailService(userRepo, userSettingRepo, infraRedis.Get())
+	pushService := service.NewPushService(deviceTokenRepo, userRepo)
+	notificationService := service.NewNotificationService(notificationRepo, userSettingRepo, videoRepo, eventService, emailService, pushService)
+	loginHistoryService := service.NewLoginHistoryService(loginEventRepo, userRepo, notificationService)
+	inviteService := service.NewInviteService(inviteCodeRepo, userRepo)
+	userCache := service.NewUserCache(infraRedis.Get())
+	profileImageService := service.NewProfileImageService(profileImageReviewRepo, userRepo, userCache, notificationService, txManager)
+	authService := service.NewAuthService(userRepo, loginHistoryService, inviteService, profileImageService, txManager)
+	userService := service.NewUserService(userRepo, relationRepo, userCache, notificationService, profileImageService)
+	relationService := service.NewRelationService(relationRepo, userRepo, eventBus, txManager)
+	videoAIService := service.NewVideoAIService(videoRepo, videoTagRepo, userRepo, videoAccessRepo, membershipRepo, infraRedis.Get())
+	duplicateService := service.NewDuplicateService(videoFingerprintRepo, videoDuplicateRepo, videoRepo)
+	coldStorageService := service.NewColdStorageService(videoRepo)
+	videoService := service.NewVideoService(videoRepo, userRepo, favoriteRepo, relationRepo, videoStatRepo, userSettingRepo, videoAccessRepo, pollRepo, membershipRepo, eventService, emailService, videoAIService, duplicateService, coldStorageService, eventBus)
+	commentService := service.NewCommentService(commentRepo, videoRepo, userRepo, videoStatRepo, membershipRepo, videoAccessRepo, eventBus, txManager)
+	favoriteService := service.NewFavoriteService(favoriteRepo, videoRepo, userRepo, videoStatRepo, videoAccessRepo, membershipRepo, notificationService, txManager)
+	videoStatService := service.NewVideoStatService(videoStatRepo, videoRepo)
+	analyticsService := service.NewAnalyticsService(videoRepo, videoStatRepo, watchHistoryRepo, infraRedis.Get())
+	creatorAnalyticsService := service.NewCreatorAnalyticsService(videoStatRepo, relationRepo, videoRepo, userRepo, infraRedis.Get())
+	recommendService := service.NewRecommendService(favoriteRepo, watchHistoryRepo, videoRepo, infraRedis.Get())
+	searchService := service.NewSearchService(videoRepo, userRepo, membershipRepo)
+	healthService := service.NewHealthService()
+	auditService := service.NewAuditService(auditLogRepo)
+	messageService := service.NewMessageService(messageRepo, relationRepo, userRepo, eventService)
+	moderationService := service.NewModerationService(videoRepo, commentRepo, videoDuplicateRepo, notificationService, txManager)
+	importService := service.NewImportService(relationRepo, favoriteRepo, userRepo, videoRepo, txManager)
+	counterService := service.NewCounterService(videoRepo, userRepo)
+	purgeService := service.NewPurgeService(purgeRepo, videoRepo, userRepo)
+	renditionService := service.NewRenditionService(videoRenditionRepo, videoRepo)
+	reportService := service.NewReportService(reportRepo, userRepo)
+	legalHoldService := service.NewLegalHoldService(videoRepo, userRepo, userCache)
+	videoAccessService := service.NewVideoAccessService(videoRepo, userRepo, videoAccessRepo, membershipRepo)
+	pollService := service.NewPollService(pollRepo, videoRepo, videoAccessRepo, txManager)
+	premiereService := service.NewPremiereService(videoRepo, userRepo, videoAccessRepo, eventBus, eventService, emailService, infraRedis.Get())
+	walletService := service.NewWalletService(walletRepo, videoRepo, userRepo, videoAccessRepo, notificationService, txManager)
+	membershipService := service.NewMembershipService(membershipRepo, userRepo, walletService, txManager)
+	digestService := service.NewDigestService(userRepo, videoRepo, commentRepo, userSettingRepo, notificationService, emailService, infraRedis.Get())
+	streamService := service.NewStreamService(videoRepo, videoRenditionRepo, userRepo, videoAccessRepo, membershipRepo, coldStorageService)
+	liveService := service.NewLiveService(liveChannelRepo, liveSessionRepo, userRepo, videoService)
+	profileService := service.NewProfileService(userService, relationService, videoService)
+	exploreService := service.NewExploreService(videoRepo, videoTagRepo, userRepo, exploreSlotRepo, videoService, userService, infraRedis.Get())
+	oauthService := service.NewOAuthService(oauthClientRepo, oauthCodeRepo, userRepo)
+
+	// 领域事件订阅：搜索同步、通知、创作者数据看板
+	eventbus.Subscribe(eventBus, "search", searchService.OnVideoPublished)
+	eventbus.Subscribe(eventBus, "creator_analytics", creatorAnalyticsService.OnVideoPublished)
+	eventbus.Subscribe(eventBus, "notification", notificationService.OnUserFollowed)
+	eventbus.Subscribe(eventBus, "creator_analytics", creatorAnalyticsService.OnUserFollowed)
+	eventbus.Subscribe(eventBus, "notification", notificationService.OnCommentCreated)
 
 	// 启动转码结果消费者（后台 goroutine）
 	consumerCtx, consumerCancel := context.WithCancel(context.Background())
 	defer consumerCancel()
 
 	if topic, ok := cfg.Kafka.Topics["video_uploaded"]; ok {
-		resultHandler := func(result *infraKafka.TranscodeResult) error {
-			if err := videoService.HandleTranscodeResult(result); err != nil {
-				return err
-			}
-			if result.Status == "published" {
-				_ = searchService.SyncVideoToES(result.VideoID)
-			}
-			return nil
-		}
 		go infraKafka.StartTranscodeResultConsumer(
 			consumerCtx,
 			cfg.Kafka.Brokers,
 			topic,
 			"vida-go-transcode-result",
-			resultHandler,
+			videoService.HandleTranscodeResult,
+		)
+	}
+
+	// 启动领域事件消费者，分发给各订阅方
+	if eventBusTopic != "" {
+		go infraKafka.StartJSONConsumer(
+			consumerCtx,
+			cfg.Kafka.Brokers,
+			eventBusTopic,
+			"vida-go-domain-events",
+			eventBus.Handle,
 		)
 	}
 
+	// 启动附加清晰度转码结果消费者
+	if topic, ok := cfg.Kafka.Topics["video_rendition_result"]; ok {
+		go infraKafka.StartJSONConsumer(
+			consumerCtx,
+			cfg.Kafka.Brokers,
+			topic,
+			"vida-go-rendition-result",
+			renditionService.HandleResult,
+		)
+	}
+
+	// 启动通知事件消费者（后台 goroutine）
+	if topic, ok := cfg.Kafka.Topics["notification"]; ok {
+		go infraKafka.StartJSONConsumer(
+			consumerCtx,
+			cfg.Kafka.Brokers,
+			topic,
+			"vida-go-notification",
+			notificationService.HandleEvent,
+		)
+	}
+
+	// 启动邮件发送消费者与新粉丝摘要任务
+	if topic, ok := cfg.Kafka.Topics["email"]; ok && cfg.Email.Enabled {
+		go infraKafka.StartJSONConsumer(
+			consumerCtx,
+			cfg.Kafka.Brokers,
+			topic,
+			"vida-go-email",
+			emailService.Deliver,
+		)
+	}
+	// 启动埋点事件消费者，聚合观众留存、完播率与观看时长
+	if topic, ok := cfg.Kafka.Topics["analytics"]; ok {
+		go infraKafka.StartJSONConsumer(
+			consumerCtx,
+			cfg.Kafka.Brokers,
+			topic,
+			"vida-go-analytics",
+			analyticsService.HandleEvent,
+		)
+	}
+
+	// 后台定时任务：执行时间由 scheduler.jobs 配置，未配置时按各自的执行间隔
+	jobScheduler := scheduler.New(infraRedis.Get())
+	jobService := service.NewJobService(jobScheduler)
+	registerJob := func(name string, interval time.Duration, fn scheduler.JobFunc) {
+		if err := jobScheduler.Register(name, cfg.Scheduler.Spec(name, interval), fn); err != nil {
+			logger.Fatal("Failed to register scheduled job", zap.String("job", name), zap.Error(err))
+		}
+	}
+	if cfg.Email.Enabled {
+		registerJob("follower_digest", cfg.Email.DigestInterval(), emailService.SendFollowerDigests)
+	}
+	if cfg.Recommend.Enabled {
+		registerJob("recommend_similarity", cfg.Recommend.Interval(), func(ctx context.Context) error {
+			return recommendService.RunSimilarity(ctx, &cfg.Recommend)
+		})
+	}
+	if cfg.Explore.Enabled {
+		registerJob("explore_curation", cfg.Explore.Interval(), func(ctx context.Context) error {
+			return exploreService.RunCuration(ctx, &cfg.Explore)
+		})
+	}
+	if cfg.CounterRepair.Enabled {
+		registerJob("counter_repair", cfg.CounterRepair.Interval(), func(ctx context.Context) error {
+			return counterService.RunRepair(ctx, &cfg.CounterRepair)
+		})
+	}
+	if cfg.Rendition.Backfill.Enabled {
+		registerJob("rendition_backfill", cfg.Rendition.Backfill.Interval(), func(ctx context.Context) error {
+			return renditionService.RunBackfill(ctx, &cfg.Rendition)
+		})
+	}
+	if cfg.ColdStorage.Enabled {
+		registerJob("cold_storage_archive", cfg.ColdStorage.Interval(), func(ctx context.Context) error {
+			return coldStorageService.RunArchive(ctx, &cfg.ColdStorage)
+		})
+	}
+	if cfg.Purge.Enabled {
+		registerJob("purge", cfg.Purge.Interval(), func(ctx context.Context) error {
+			return purgeService.RunPurge(ctx, &cfg.Purge)
+		})
+	}
+	if cfg.Premiere.Enabled {
+		registerJob("premiere_start", cfg.Premiere.Interval(), premiereService.StartDuePremieres)
+	}
+	if cfg.Digest.Enabled {
+		registerJob("weekly_digest", service.DigestCheckInterval, func(ctx context.Context) error {
+			return digestService.RunDigest(ctx, &cfg.Digest)
+		})
+	}
+	go jobScheduler.Run(consumerCtx)
+
+	go infraSecrets.StartRotation(consumerCtx)
+
 	authHandler := handler.NewAuthHandler(authService)
-	userHandler := handler.NewUserHandler(userService, authService)
+	userHandler := handler.NewUserHandler(userService, authService, auditService)
 	relationHandler := handler.NewRelationHandler(relationService)
-	videoHandler := handler.NewVideoHandler(videoService)
-	commentHandler := handler.NewCommentHandler(commentService)
+	videoHandler := handler.NewVideoHandler(videoService, videoStatService, auditService)
+	commentHandler := handler.NewCommentHandler(commentService, auditService)
 	favoriteHandler := handler.NewFavoriteHandler(favoriteService)
 	searchHandler := handler.NewSearchHandler(searchService)
+	healthHandler := handler.NewHealthHandler(healthService)
+	auditHandler := handler.NewAuditHandler(auditService)
+	moderationHandler := handler.NewModerationHandler(moderationService, auditService)
+	eventHandler := handler.NewEventHandler(eventService)
+	notificationHandler := handler.NewNotificationHandler(notificationService, emailService, pushService)
+	messageHandler := handler.NewMessageHandler(messageService)
+	graphQLHandler := handler.NewGraphQLHandler(
+		graph.NewResolver(userService, videoService, commentService, favoriteService, relationService),
+		cfg.App.Mode == gin.DebugMode,
+	)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	creatorHandler := handler.NewCreatorHandler(creatorAnalyticsService)
+	recommendHandler := handler.NewRecommendHandler(recommendService, videoService)
+	videoAIHandler := handler.NewVideoAIHandler(videoAIService)
+	v2Handler := handler.NewV2Handler(videoService, relationService, commentService, favoriteService, notificationService, messageService)
+	importHandler := handler.NewImportHandler(importService, auditService)
+	renditionHandler := handler.NewRenditionHandler(renditionService)
+	reportHandler := handler.NewReportHandler(reportService, auditService)
+	legalHoldHandler := handler.NewLegalHoldHandler(legalHoldService, auditService)
+	loginHistoryHandler := handler.NewLoginHistoryHandler(loginHistoryService)
+	inviteHandler := handler.NewInviteHandler(inviteService, auditService)
+	profileImageHandler := handler.NewProfileImageHandler(profileImageService, auditService)
+	streamHandler := handler.NewStreamHandler(streamService)
+	videoAccessHandler := handler.NewVideoAccessHandler(videoAccessService)
+	pollHandler := handler.NewPollHandler(pollService)
+	premiereHandler := handler.NewPremiereHandler(premiereService)
+	liveHandler := handler.NewLiveHandler(liveService)
+	profileHandler := handler.NewProfileHandler(profileService)
+	exploreHandler := handler.NewExploreHandler(exploreService, auditService)
+	walletHandler := handler.NewWalletHandler(walletService, auditService)
+	membershipHandler := handler.NewMembershipHandler(membershipService)
+	jobHandler := handler.NewJobHandler(jobService)
+	oauthHandler := handler.NewOAuthHandler(oauthService)
+
+	// 启动内部 gRPC 服务（后台 goroutine）
+	if cfg.GRPC.Enabled {
+		grpcServer := rpc.NewServer(
+			rpc.NewAuthServer(authService),
+			rpc.NewVideoServer(videoService),
+			rpc.NewRelationServer(relationService),
+			auditService,
+		)
+		grpcAddr := fmt.Sprintf(":%d", cfg.GRPC.Port)
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			logger.Fatal("Failed to listen grpc", zap.Error(err))
+		}
+		defer grpcServer.GracefulStop()
+		go func() {
+			logger.Info("gRPC server listening", zap.String("addr", grpcAddr))
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Error("gRPC server stopped", zap.Error(err))
+			}
+		}()
+	}
 
-	// 管理员中间件（需要查数据库获取角色）
-	adminMiddleware := middleware.AdminRequired(func(userID int64) (string, error) {
-		user, err := userRepo.GetByID(userID)
+	// 权限中间件（需要查数据库获取角色）
+	roleFetcher := func(ctx context.Context, userID int64) (string, error) {
+		user, err := userRepo.GetByID(ctx, userID)
 		if err != nil {
 			return "", err
 		}
 		return user.UserRole, nil
-	})
+	}
+	adminMiddleware := middleware.RequirePermission(roleFetcher, rbac.PermManageUsers)
+	moderatorMiddleware := middleware.RequirePermission(roleFetcher, rbac.PermModerateContent)
+	reportsMiddleware := middleware.RequirePermission(roleFetcher, rbac.PermHandleReports)
+
+	// 幂等中间件（上传、点赞、关注、评论等写操作支持 Idempotency-Key 重试）
+	idempotencyMiddleware := middleware.Idempotency(infraRedis.Get(), 24*time.Hour)
+
+	// 请求签名中间件（推荐流、搜索、发现页等公开目录接口防批量抓取），未开启时直接放行
+	signatureMiddleware := func(c *gin.Context) { c.Next() }
+	if cfg.Signing.Enabled {
+		signatureMiddleware = middleware.RequestSignature(infraRedis.Get(), middleware.SignatureOptions{
+			Secrets: cfg.Signing.Secrets(),
+			MaxSkew: cfg.Signing.MaxSkew(),
+			Enforce: cfg.Signing.Enforce,
+		})
+	}
+
+	// 管理员模拟登录期间的每个请求记入审计日志
+	r.Use(middleware.ImpersonationAudit(handler.RecordImpersonatedRequest(auditService)))
 
 	// 注册基础路由
 	r.GET("/healthz", healthCheckHandler)
+	r.GET("/livez", healthHandler.Livez)
+	r.GET("/readyz", healthHandler.Readyz)
 	r.GET("/", rootHandler)
 
+	// Prometheus 指标（HTTP 耗时直方图、慢请求数、运行时指标）
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	// Swagger 文档路由
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// 注册业务路由
-	router.Setup(r, authHandler, userHandler, relationHandler, videoHandler, commentHandler, favoriteHandler, searchHandler, adminMiddleware)
+	router.Setup(r, authHandler, userHandler, relationHandler, videoHandler, commentHandler, favoriteHandler, searchHandler, auditHandler, moderationHandler, eventHandler, notificationHandler, messageHandler, graphQLHandler, analyticsHandler, creatorHandler, recommendHandler, videoAIHandler, v2Handler, importHandler, renditionHandler, reportHandler, legalHoldHandler, loginHistoryHandler, inviteHandler, profileImageHandler, streamHandler, videoAccessHandler, pollHandler, premiereHandler, liveHandler, profileHandler, exploreHandler, oauthHandler, walletHandler, membershipHandler, jobHandler, adminMiddleware, moderatorMiddleware, reportsMiddleware, idempotencyMiddleware, signatureMiddleware)
 
 	// 启动服务器
 	addr := fmt.Sprintf(":%d", cfg.App.Port)
@@ -224,6 +617,15 @@ func healthCheckHandler(c *gin.Context) {
 	})
 }
 
+// skipProbeTracing 探针、指标抓取请求频繁且无业务意义，不生成 Span
+func skipProbeTracing(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/livez", "/readyz", "/metrics":
+		return false
+	}
+	return true
+}
+
 // rootHandler 根路径处理器
 func rootHandler(c *gin.Context) {
 	cfg := config.Get()
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"vida-go/internal/config"
+	"vida-go/internal/infra/database"
+	infraES "vida-go/internal/infra/elasticsearch"
+	infraMinio "vida-go/internal/infra/minio"
+	infraSecrets "vida-go/internal/infra/secrets"
+	"vida-go/internal/repository"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// 手动清理软删除数据：
+//
+//	vida-purge [-dry-run=false] [-retention-days 90] [-report-dir ./purge-reports]
+//
+// 默认只统计（dry run），加 -dry-run=false 才实际删除；报告（JSON）写入报告目录。
+// 保留天数、批大小、报告目录未指定时使用配置文件中 purge 的设置，配置中也没有报告目录时写入 ./purge-reports
+func main() {
+	dryRun := flag.Bool("dry-run", true, "只统计将被清理的数据，不实际删除")
+	retentionDays := flag.Int("retention-days", 0, "软删除后保留天数（默认使用配置）")
+	batchSize := flag.Int("batch", 0, "每批清理的用户或视频数（默认使用配置）")
+	reportDir := flag.String("report-dir", "", "报告写入目录（默认使用配置）")
+
+	flags := config.ParseFlags("vida-purge", false)
+	cfg, err := config.Load(flags.ConfigPath)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load config: %v", err))
+	}
+	if err := flags.Apply(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid command-line flags: %v", err))
+	}
+	purgeCfg := cfg.Purge
+	if *retentionDays > 0 {
+		purgeCfg.RetentionDays = *retentionDays
+	}
+	if *batchSize > 0 {
+		purgeCfg.BatchSize = *batchSize
+	}
+	if *reportDir != "" {
+		purgeCfg.ReportDir = *reportDir
+	}
+	if purgeCfg.ReportDir == "" {
+		purgeCfg.ReportDir = "./purge-reports"
+	}
+
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Format, "stdout", "", logger.Rotation{}); err != nil {
+		panic(fmt.Sprintf("Failed to init logger: %v", err))
+	}
+	defer logger.Sync()
+
+	if err := infraSecrets.Init(&cfg.Secrets); err != nil {
+		logger.Fatal("Failed to init secrets backend", zap.Error(err))
+	}
+	if err := infraSecrets.Apply(context.Background(), cfg); err != nil {
+		logger.Fatal("Failed to load secrets", zap.Error(err))
+	}
+
+	if err := database.Init(&cfg.Database); err != nil {
+		logger.Fatal("Failed to init database", zap.Error(err))
+	}
+	defer database.Close()
+
+	if err := infraMinio.Init(&cfg.MinIO); err != nil {
+		logger.Fatal("Failed to init minio", zap.Error(err))
+	}
+	// 搜索索引可选：未连接时不删除索引文档，可通过全量同步修复
+	if err := infraES.Init(&cfg.Elasticsearch); err != nil {
+		logger.Warn("Elasticsearch init failed, search documents will not be removed", zap.Error(err))
+	} else {
+		defer infraES.Close()
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db := database.Get()
+	purgeService := service.NewPurgeService(repository.NewPurgeRepository(db), repository.NewVideoRepository(db), repository.NewUserRepository(db))
+	report, purgeErr := purgeService.Purge(ctx, purgeCfg.Retention(), purgeCfg.Batch(), *dryRun)
+
+	path, err := service.WritePurgeReport(purgeCfg.ReportDir, report)
+	if err != nil {
+		logger.Error("Write purge report failed", zap.Error(err))
+	} else {
+		logger.Info("Purge report written", zap.String("path", path))
+	}
+	if purgeErr != nil {
+		logger.Fatal("Purge failed", zap.Error(purgeErr))
+	}
+}
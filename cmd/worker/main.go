@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"vida-go/internal/config"
 	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/infra/logship"
 	infraMinio "vida-go/internal/infra/minio"
+	infraSecrets "vida-go/internal/infra/secrets"
+	"vida-go/internal/infra/tracing"
 	"vida-go/internal/transcode"
 	"vida-go/pkg/logger"
 
@@ -20,16 +24,52 @@ import (
 )
 
 func main() {
-	cfg, err := config.Load("configs/config.yaml")
+	flags := config.ParseFlags("vida-worker", false)
+	cfg, err := config.Load(flags.ConfigPath)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to load config: %v", err))
 	}
+	if err := flags.Apply(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid command-line flags: %v", err))
+	}
 
-	if err := logger.Init(cfg.Log.Level, cfg.Log.Format, cfg.Log.Output, cfg.Log.FilePath); err != nil {
+	rotation := logger.Rotation{
+		MaxSizeMB:  cfg.Log.MaxSizeMB,
+		MaxAgeDays: cfg.Log.MaxAgeDays,
+		MaxBackups: cfg.Log.MaxBackups,
+		Compress:   cfg.Log.Compress,
+	}
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Format, cfg.Log.Output, cfg.Log.FilePath, rotation); err != nil {
 		panic(fmt.Sprintf("Failed to init logger: %v", err))
 	}
 	defer logger.Sync()
 
+	// 集中式日志投递（Kafka / Elasticsearch / Logstash），关闭时先投递剩余日志
+	if cfg.Log.Ship.Enabled {
+		shipper, err := logship.New(&cfg.Log.Ship, &cfg.Kafka, &cfg.Elasticsearch)
+		if err != nil {
+			logger.Fatal("Failed to init log shipping", zap.Error(err))
+		}
+		logger.AddSink(shipper, cfg.Log.Ship.Level)
+		defer shipper.Close()
+	}
+
+	if err := infraSecrets.Init(&cfg.Secrets); err != nil {
+		logger.Fatal("Failed to init secrets backend", zap.Error(err))
+	}
+	if err := infraSecrets.Apply(context.Background(), cfg); err != nil {
+		logger.Fatal("Failed to load secrets", zap.Error(err))
+	}
+
+	if err := tracing.Init(&cfg.Tracing, cfg.App.Name+"-worker", cfg.App.Version); err != nil {
+		logger.Fatal("Failed to init tracing", zap.Error(err))
+	}
+	defer func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		_ = tracing.Shutdown(shutdownCtx)
+	}()
+
 	if err := infraMinio.Init(&cfg.MinIO); err != nil {
 		logger.Fatal("Failed to init minio", zap.Error(err))
 	}
@@ -52,6 +92,17 @@ func main() {
 		cancel()
 	}()
 
+	// 附加清晰度转码任务由独立的消费者处理，退出时等待当前任务完成
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	if topic, ok := cfg.Kafka.Topics["video_rendition"]; ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			infraKafka.StartJSONConsumer(ctx, cfg.Kafka.Brokers, topic, "vida-go-rendition-worker", transcode.HandleRendition)
+		}()
+	}
+
 	transcodeTopic := cfg.Kafka.Topics["video_transcode"]
 	groupID := "vida-go-transcode-worker"
 
@@ -84,12 +135,16 @@ func main() {
 			continue
 		}
 
+		// 收到退出信号时不中断正在执行的转码任务
+		msgCtx, span := infraKafka.StartConsumeSpan(context.WithoutCancel(ctx), &msg)
+
 		var task infraKafka.TranscodeTask
 		if err := json.Unmarshal(msg.Value, &task); err != nil {
 			logger.Error("Failed to unmarshal transcode task",
 				zap.Error(err),
 				zap.ByteString("value", msg.Value),
 			)
+			span.End()
 			continue
 		}
 
@@ -98,7 +153,7 @@ func main() {
 			zap.String("object", task.ObjectName),
 		)
 
-		if err := transcode.HandleTask(&task); err != nil {
+		if err := transcode.HandleTask(msgCtx, &task); err != nil {
 			logger.Error("Transcode task failed",
 				zap.Int64("video_id", task.VideoID),
 				zap.Error(err),
@@ -108,5 +163,6 @@ func main() {
 				zap.Int64("video_id", task.VideoID),
 			)
 		}
+		span.End()
 	}
 }
@@ -0,0 +1,81 @@
+package dto
+
+// 客户端埋点事件类型
+const (
+	AnalyticsEventPlay       = "play"
+	AnalyticsEventPause      = "pause"
+	AnalyticsEventComplete   = "complete"
+	AnalyticsEventImpression = "impression"
+)
+
+// AnalyticsEventBatchRequest 批量上报埋点事件请求
+type AnalyticsEventBatchRequest struct {
+	DeviceID string           `json:"device_id" binding:"omitempty,max=128"`
+	Events   []AnalyticsEvent `json:"events" binding:"required,min=1,max=100,dive"`
+}
+
+// AnalyticsEvent 单条埋点事件
+// PositionMs 为事件发生时的播放进度，WatchTimeMs 为自上次上报以来的实际观看时长（pause、complete 时上报）
+type AnalyticsEvent struct {
+	Type        string `json:"type" binding:"required,oneof=play pause complete impression"`
+	VideoID     int64  `json:"video_id" binding:"required,min=1"`
+	SessionID   string `json:"session_id" binding:"omitempty,max=64"`
+	Source      string `json:"source" binding:"omitempty,max=32"`
+	PositionMs  int64  `json:"position_ms" binding:"omitempty,min=0"`
+	WatchTimeMs int64  `json:"watch_time_ms" binding:"omitempty,min=0,max=86400000"`
+	ClientTime  int64  `json:"client_time" binding:"required,min=1"` // 客户端时间，Unix 毫秒
+}
+
+// AnalyticsEventBatchResult 上报结果，超出时间窗口的事件会被丢弃
+type AnalyticsEventBatchResult struct {
+	Accepted int `json:"accepted"`
+	Dropped  int `json:"dropped"`
+}
+
+// CreatorDailyInfo 创作者单日数据
+type CreatorDailyInfo struct {
+	Date            string `json:"date"` // YYYY-MM-DD（UTC）
+	Views           int64  `json:"views"`
+	Likes           int64  `json:"likes"`
+	Comments        int64  `json:"comments"`
+	FollowersGained int64  `json:"followers_gained"`
+}
+
+// CreatorTopVideo 时间范围内表现最好的视频
+type CreatorTopVideo struct {
+	VideoID        int64   `json:"video_id"`
+	Title          string  `json:"title"`
+	CoverURL       string  `json:"cover_url"`
+	Views          int64   `json:"views"`
+	Likes          int64   `json:"likes"`
+	Comments       int64   `json:"comments"`
+	AvgWatchTimeMs int64   `json:"avg_watch_time_ms"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// CreatorOverviewData 创作者数据概览，Daily 按日期升序且不缺日
+type CreatorOverviewData struct {
+	Days            int                `json:"days"`
+	FollowerCount   int64              `json:"follower_count"`
+	TotalViews      int64              `json:"total_views"`
+	TotalLikes      int64              `json:"total_likes"`
+	TotalComments   int64              `json:"total_comments"`
+	FollowersGained int64              `json:"followers_gained"`
+	Daily           []CreatorDailyInfo `json:"daily"`
+	TopVideos       []CreatorTopVideo  `json:"top_videos"`
+	GeneratedAt     int64              `json:"generated_at"` // 数据生成时间（Unix 秒），缓存期间不变
+}
+
+// RetentionPoint 留存曲线上的一点：播放到 Percent% 进度的观看次数及占开始播放的比例
+type RetentionPoint struct {
+	Percent int     `json:"percent"`
+	Viewers int64   `json:"viewers"`
+	Rate    float64 `json:"rate"`
+}
+
+// VideoRetentionData 视频观众留存曲线（0%~100%，每 10% 一个点）
+type VideoRetentionData struct {
+	VideoID int64            `json:"video_id"`
+	Starts  int64            `json:"starts"`
+	Points  []RetentionPoint `json:"points"`
+}
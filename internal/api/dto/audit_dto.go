@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// AuditLogQuery 审计日志查询参数
+type AuditLogQuery struct {
+	ActorID    *int64  `form:"actor_id"`
+	Action     *string `form:"action"`
+	TargetType *string `form:"target_type"`
+	TargetID   *int64  `form:"target_id"`
+	StartTime  *int64  `form:"start_time"` // unix 秒
+	EndTime    *int64  `form:"end_time"`   // unix 秒
+}
+
+// AuditLogInfo 审计日志信息
+type AuditLogInfo struct {
+	ID         int64     `json:"id"`
+	ActorID    int64     `json:"actor_id"`
+	ActorRole  string    `json:"actor_role"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"target_type"`
+	TargetID   int64     `json:"target_id"`
+	Reason     string    `json:"reason"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -1,9 +1,27 @@
 package dto
 
+import "time"
+
 // LoginRequest 登录请求
 type LoginRequest struct {
 	Username string `json:"username" binding:"required,min=1,max=255"`
 	Password string `json:"password" binding:"required,min=6,max=255"`
+
+	// 由 Handler 根据请求填充，用于记录登录历史
+	Client LoginClient `json:"-"`
+}
+
+// LoginClient 登录请求的来源信息
+type LoginClient struct {
+	IP        string
+	UserAgent string
+	DeviceID  string // 客户端通过 X-Device-ID 请求头上报的设备 ID，可为空
+}
+
+// ChangePasswordRequest 修改密码请求
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required,min=6,max=255"`
+	NewPassword string `json:"new_password" binding:"required,min=6,max=255,nefield=OldPassword"`
 }
 
 // RegisterRequest 注册请求
@@ -13,6 +31,7 @@ type RegisterRequest struct {
 	Avatar          *string `json:"avatar" binding:"omitempty,max=500"`
 	BackgroundImage *string `json:"background_image" binding:"omitempty,max=500"`
 	UserRole        string  `json:"user_role" binding:"omitempty,oneof=user admin"`
+	InviteCode      string  `json:"invite_code" binding:"omitempty,max=32"` // 开启邀请注册时必填
 }
 
 // TokenData 登录成功返回的 Token 信息
@@ -33,4 +52,14 @@ type UserInfo struct {
 	FollowCount     int64   `json:"follow_count"`
 	FollowerCount   int64   `json:"follower_count"`
 	TotalFavorited  int64   `json:"total_favorited"`
+	FavoriteCount   int64   `json:"favorite_count"`
+
+	// 出生日期（YYYY-MM-DD），仅返回给本人，未设置时不返回
+	BirthDate *string `json:"birth_date,omitempty"`
+
+	// 仅在限制生效期间返回，用于提示用户
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	SuspendReason  string     `json:"suspend_reason,omitempty"`
+	MutedUntil     *time.Time `json:"muted_until,omitempty"`
+	MuteReason     string     `json:"mute_reason,omitempty"`
 }
@@ -27,6 +27,7 @@ type CommentInfo struct {
 	Avatar       *string   `json:"avatar"`
 	RepliesCount int64     `json:"replies_count"`
 	VideoTitle   *string   `json:"video_title,omitempty"`
+	MemberBadge  string    `json:"member_badge,omitempty"` // 评论者在视频作者处的会员徽章
 }
 
 // CommentListData 评论列表数据
@@ -0,0 +1,44 @@
+package dto
+
+// CursorList 游标分页结果，HasMore 为 true 时使用 NextCursor 获取下一页
+type CursorList[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// VideoViewerFlags 当前用户与视频的关系
+type VideoViewerFlags struct {
+	Favorited       bool `json:"favorited"`
+	FollowingAuthor bool `json:"following_author"`
+	IsAuthor        bool `json:"is_author"`
+}
+
+// VideoItem v2 视频列表项，登录时附带 viewer
+type VideoItem struct {
+	VideoInfo
+	Viewer *VideoViewerFlags `json:"viewer,omitempty"`
+}
+
+// UserViewerFlags 当前用户与列表中用户的关系
+type UserViewerFlags struct {
+	Following bool `json:"following"`
+	IsSelf    bool `json:"is_self"`
+}
+
+// UserItem v2 用户列表项，登录时附带 viewer
+type UserItem struct {
+	RelationUserInfo
+	Viewer *UserViewerFlags `json:"viewer,omitempty"`
+}
+
+// CommentViewerFlags 当前用户与评论的关系
+type CommentViewerFlags struct {
+	IsOwner bool `json:"is_owner"`
+}
+
+// CommentItem v2 评论列表项，登录时附带 viewer
+type CommentItem struct {
+	CommentInfo
+	Viewer *CommentViewerFlags `json:"viewer,omitempty"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// DeviceRegisterRequest 注册推送设备请求
+type DeviceRegisterRequest struct {
+	Platform string `json:"platform" binding:"required,oneof=ios android"`
+	Token    string `json:"token" binding:"required,max=512"`
+}
+
+// DeviceUnregisterRequest 注销推送设备请求
+type DeviceUnregisterRequest struct {
+	Token string `json:"token" binding:"required,max=512"`
+}
+
+// DeviceInfo 推送设备信息
+type DeviceInfo struct {
+	ID        int64     `json:"id"`
+	Platform  string    `json:"platform"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
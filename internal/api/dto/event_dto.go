@@ -0,0 +1,19 @@
+package dto
+
+import "encoding/json"
+
+// UserEvent 推送给用户的实时事件
+type UserEvent struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// UploadStatusEvent 视频上传/转码状态变化事件
+type UploadStatusEvent struct {
+	VideoID  int64  `json:"video_id"`
+	Status   string `json:"status"`
+	PlayURL  string `json:"play_url,omitempty"`
+	CoverURL string `json:"cover_url,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// ExploreData 发现页。Sections 按 featured、trending、new_creators、各分类的顺序返回，没有内容的栏目不返回
+type ExploreData struct {
+	Sections   []ExploreSection `json:"sections"`
+	Categories []string         `json:"categories"` // 分类标签页，与 category 栏目顺序一致
+	UpdatedAt  *time.Time       `json:"updated_at"` // 最近一次挑选时间
+}
+
+// ExploreSection 发现页栏目：视频栏目返回 Videos，新晋创作者栏目返回 Creators
+type ExploreSection struct {
+	Key      string          `json:"key"`                // featured/trending/new_creators/category
+	Category string          `json:"category,omitempty"` // 分类栏目的分类名
+	Videos   []VideoInfo     `json:"videos,omitempty"`
+	Creators []UserBriefInfo `json:"creators,omitempty"`
+}
+
+// ExploreSlotCreateRequest 创建发现页推荐位
+type ExploreSlotCreateRequest struct {
+	Section  string     `json:"section" binding:"required,oneof=featured trending category"`
+	Category string     `json:"category" binding:"required_if=Section category,max=50"`
+	VideoID  int64      `json:"video_id" binding:"required,min=1"`
+	Position int        `json:"position" binding:"min=0"`
+	Note     string     `json:"note" binding:"max=200"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// ExploreSlotInfo 发现页推荐位
+type ExploreSlotInfo struct {
+	ID        int64      `json:"id"`
+	Section   string     `json:"section"`
+	Category  string     `json:"category,omitempty"`
+	VideoID   int64      `json:"video_id"`
+	Position  int        `json:"position"`
+	Note      string     `json:"note"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	CreatedBy int64      `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ExploreSlotListData 推荐位列表
+type ExploreSlotListData struct {
+	Slots      []ExploreSlotInfo `json:"slots"`
+	Total      int64             `json:"total"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int64             `json:"total_pages"`
+}
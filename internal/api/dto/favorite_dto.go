@@ -7,9 +7,32 @@ type FavoriteInfo struct {
 	ID        int64     `json:"id"`
 	UserID    int64     `json:"user_id"`
 	VideoID   int64     `json:"video_id"`
+	Reaction  string    `json:"reaction"` // like / love / laugh / wow
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// ReactionQuery 点赞时指定的表态类型，默认 like
+type ReactionQuery struct {
+	Reaction string `form:"reaction" binding:"omitempty,oneof=like love laugh wow"`
+}
+
+// ReactionCounts 视频各类型表态数
+type ReactionCounts struct {
+	Like  int64 `json:"like"`
+	Love  int64 `json:"love"`
+	Laugh int64 `json:"laugh"`
+	Wow   int64 `json:"wow"`
+}
+
+// FavoriteStatus 当前用户对视频的表态状态
+type FavoriteStatus struct {
+	IsFavorited    bool           `json:"is_favorited"`
+	Reaction       string         `json:"reaction,omitempty"` // 未表态时不返回
+	VideoID        int64          `json:"video_id"`
+	TotalFavorites int64          `json:"total_favorites"`
+	Reactions      ReactionCounts `json:"reactions"`
+}
+
 // FavoriteListData 点赞列表数据
 type FavoriteListData struct {
 	Favorites  []FavoriteInfo `json:"favorites"`
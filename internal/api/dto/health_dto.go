@@ -0,0 +1,18 @@
+package dto
+
+// DependencyStatus 单个依赖的健康状态
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"` // up, down
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReadinessData 就绪检查结果
+type ReadinessData struct {
+	Status       string             `json:"status"` // ready, degraded, not_ready
+	Degraded     []string           `json:"degraded"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+	Timestamp    string             `json:"timestamp"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+// RelationImportItem 一条待导入的关注关系
+type RelationImportItem struct {
+	FollowerID int64 `json:"follower_id" binding:"required,min=1"`
+	FollowID   int64 `json:"follow_id" binding:"required,min=1"`
+	CreatedAt  int64 `json:"created_at"` // 原平台的关注时间（Unix 秒），为空时取导入时间
+}
+
+// RelationImportRequest 批量导入关注关系请求
+type RelationImportRequest struct {
+	Relations []RelationImportItem `json:"relations" binding:"required,min=1,max=5000,dive"`
+}
+
+// FavoriteImportItem 一条待导入的点赞记录
+type FavoriteImportItem struct {
+	UserID    int64 `json:"user_id" binding:"required,min=1"`
+	VideoID   int64 `json:"video_id" binding:"required,min=1"`
+	CreatedAt int64 `json:"created_at"` // 原平台的点赞时间（Unix 秒），为空时取导入时间
+}
+
+// FavoriteImportRequest 批量导入点赞记录请求
+type FavoriteImportRequest struct {
+	Favorites []FavoriteImportItem `json:"favorites" binding:"required,min=1,max=5000,dive"`
+}
+
+// ImportResult 批量导入结果
+type ImportResult struct {
+	Total    int   `json:"total"`    // 请求中的记录数
+	Imported int64 `json:"imported"` // 实际写入的记录数
+	Skipped  int64 `json:"skipped"`  // 已存在、重复或引用的用户/视频不存在而跳过的记录数
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// InviteCodeCreateRequest 批量生成邀请码请求
+type InviteCodeCreateRequest struct {
+	Count          int    `json:"count" binding:"omitempty,min=1,max=100"`              // 生成数量，默认 1
+	MaxUses        *int   `json:"max_uses" binding:"omitempty,min=0,max=100000"`        // 每个码最多使用次数，0 表示不限，默认 1
+	ExpiresInHours int    `json:"expires_in_hours" binding:"omitempty,min=1,max=87600"` // 有效期（小时），不填表示不过期
+	InviterID      int64  `json:"inviter_id" binding:"omitempty,min=1"`                 // 邀请人，不填为当前管理员
+	Note           string `json:"note" binding:"omitempty,max=200"`
+}
+
+// InviteCodeInfo 邀请码
+type InviteCodeInfo struct {
+	ID        int64         `json:"id"`
+	Code      string        `json:"code"`
+	Inviter   UserBriefInfo `json:"inviter"`
+	CreatedBy int64         `json:"created_by"`
+	MaxUses   int           `json:"max_uses"` // 0 表示不限
+	UsedCount int           `json:"used_count"`
+	Status    string        `json:"status"` // active / used_up / expired / revoked
+	Note      string        `json:"note,omitempty"`
+	ExpiresAt *time.Time    `json:"expires_at"`
+	RevokedAt *time.Time    `json:"revoked_at"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// InviteCodeListData 邀请码列表
+type InviteCodeListData struct {
+	Codes      []InviteCodeInfo `json:"codes"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int64            `json:"total_pages"`
+}
+
+// InviteeInfo 被邀请注册的用户
+type InviteeInfo struct {
+	UserBriefInfo
+	InviteCodeID int64 `json:"invite_code_id"`
+	Deleted      bool  `json:"deleted"`
+}
+
+// InviteeListData 被邀请用户列表
+type InviteeListData struct {
+	Users      []InviteeInfo `json:"users"`
+	Total      int64         `json:"total"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	TotalPages int64         `json:"total_pages"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// JobRunInfo 定时任务最近一次执行情况
+type JobRunInfo struct {
+	Instance   string     `json:"instance"` // 执行的实例（主机名-进程号）
+	Status     string     `json:"status"`   // running / success / failed
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// JobInfo 定时任务的调度配置与运行状态
+type JobInfo struct {
+	Name      string      `json:"name"`
+	Schedule  string      `json:"schedule"`    // cron 表达式或 @every 间隔
+	NextRunAt *time.Time  `json:"next_run_at"` // 本实例计算的下一次执行时间
+	Running   bool        `json:"running"`     // 集群中是否有实例正在执行
+	LastRun   *JobRunInfo `json:"last_run"`    // 从未执行过时为 null
+}
+
+// JobListData 定时任务列表
+type JobListData struct {
+	Jobs []JobInfo `json:"jobs"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// LegalHoldRequest 设置法律保全请求
+type LegalHoldRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"` // 保全原因，如案件编号、调查说明
+}
+
+// LegalHoldInfo 法律保全记录
+type LegalHoldInfo struct {
+	TargetType string    `json:"target_type"` // video / user
+	TargetID   int64     `json:"target_id"`
+	Title      string    `json:"title"` // 视频标题或用户名
+	AuthorID   int64     `json:"author_id,omitempty"`
+	Reason     string    `json:"reason"`
+	HeldAt     time.Time `json:"held_at"`
+}
+
+// LegalHoldListData 法律保全列表
+type LegalHoldListData struct {
+	Holds      []LegalHoldInfo `json:"holds"`
+	Total      int64           `json:"total"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+	TotalPages int64           `json:"total_pages"`
+}
@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+// LiveChannelInfo 直播频道（仅本人可见，含推流码）
+type LiveChannelInfo struct {
+	Title      string `json:"title"`
+	StreamKey  string `json:"stream_key,omitempty"` // 管理员代登录时不返回
+	RTMPURL    string `json:"rtmp_url"`             // OBS 等推流软件中的服务器地址，推流码填 stream_key
+	SRTURL     string `json:"srt_url,omitempty"`
+	PlaybackID string `json:"playback_id"`
+	PlayURL    string `json:"play_url"`
+}
+
+// LiveChannelUpdateRequest 更新直播频道请求
+type LiveChannelUpdateRequest struct {
+	Title string `json:"title" binding:"required,max=200"`
+}
+
+// LiveCallbackRequest 媒体服务器回调参数，兼容 nginx-rtmp（表单：name、addr、path）
+// 与 SRS（JSON：stream、ip、file）
+type LiveCallbackRequest struct {
+	Name   string `form:"name" json:"name"`
+	Stream string `form:"stream" json:"stream"`
+	Addr   string `form:"addr" json:"addr"`
+	IP     string `form:"ip" json:"ip"`
+	Path   string `form:"path" json:"path"`
+	File   string `form:"file" json:"file"`
+}
+
+// StreamKey 推流码
+func (r *LiveCallbackRequest) StreamKey() string {
+	if r.Stream != "" {
+		return r.Stream
+	}
+	return r.Name
+}
+
+// ClientIP 推流端 IP
+func (r *LiveCallbackRequest) ClientIP() string {
+	if r.IP != "" {
+		return r.IP
+	}
+	return r.Addr
+}
+
+// Recording 录像在 raw-videos 中的对象名
+func (r *LiveCallbackRequest) Recording() string {
+	if r.File != "" {
+		return r.File
+	}
+	return r.Path
+}
+
+// LiveSessionInfo 直播场次
+type LiveSessionInfo struct {
+	ID        int64         `json:"id"`
+	User      UserBriefInfo `json:"user"`
+	Title     string        `json:"title"`
+	Status    string        `json:"status"`             // live / ended
+	PlayURL   string        `json:"play_url,omitempty"` // 仅直播中返回
+	StartedAt time.Time     `json:"started_at"`
+	EndedAt   *time.Time    `json:"ended_at"`
+	VideoID   *int64        `json:"video_id"` // 下播后录像生成的视频
+}
+
+// LiveSessionListData 直播列表
+type LiveSessionListData struct {
+	Sessions   []LiveSessionInfo `json:"sessions"`
+	Total      int64             `json:"total"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int64             `json:"total_pages"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// LoginEventInfo 登录记录
+type LoginEventInfo struct {
+	ID          int64      `json:"id"`
+	IP          string     `json:"ip"`
+	UserAgent   string     `json:"user_agent"`
+	Country     string     `json:"country,omitempty"`
+	NewDevice   bool       `json:"new_device"`   // 首次在该设备登录
+	NewLocation bool       `json:"new_location"` // 首次在该国家/地区登录
+	ReportedAt  *time.Time `json:"reported_at"`  // 标记为非本人登录的时间
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// LoginEventListData 登录记录列表
+type LoginEventListData struct {
+	Logins     []LoginEventInfo `json:"logins"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int64            `json:"total_pages"`
+}
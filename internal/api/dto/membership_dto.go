@@ -0,0 +1,84 @@
+package dto
+
+import "time"
+
+// MembershipTierCreateRequest 创建会员等级。level 越大等级越高，高等级会员可观看低等级的会员专属视频
+type MembershipTierCreateRequest struct {
+	Level       int    `json:"level" binding:"required,min=1,max=10"`
+	Name        string `json:"name" binding:"required,max=50"`
+	Description string `json:"description" binding:"max=500"`
+	Price       int64  `json:"price" binding:"required,min=1,max=1000000"` // 每期价格（金币）
+	Badge       string `json:"badge" binding:"max=20"`                     // 会员在评论区显示的徽章
+}
+
+// MembershipTierUpdateRequest 更新会员等级，等级高低不可修改；价格调整从会员下次续费起生效
+type MembershipTierUpdateRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	Price       *int64  `json:"price" binding:"omitempty,min=1,max=1000000"`
+	Badge       *string `json:"badge" binding:"omitempty,max=20"`
+}
+
+// MembershipTierInfo 会员等级
+type MembershipTierInfo struct {
+	ID          int64      `json:"id"`
+	CreatorID   int64      `json:"creator_id"`
+	Level       int        `json:"level"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Price       int64      `json:"price"`
+	Badge       string     `json:"badge"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"` // 下架时间，下架后不能再加入
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// MembershipTierListData 创作者的会员等级，Membership 为当前用户在该创作者处的有效会员资格，未登录或不是会员时不返回
+type MembershipTierListData struct {
+	Tiers      []MembershipTierInfo `json:"tiers"`
+	Membership *MembershipInfo      `json:"membership,omitempty"`
+	PeriodDays int                  `json:"period_days"` // 每期会员的天数
+}
+
+// MembershipJoinRequest 加入或续费会员。request_id 由客户端生成，重试时保持不变，同一 request_id 只会扣款一次
+type MembershipJoinRequest struct {
+	RequestID string `json:"request_id" binding:"required,max=64"`
+}
+
+// MembershipInfo 会员资格
+type MembershipInfo struct {
+	CreatorID int64              `json:"creator_id"`
+	Tier      MembershipTierInfo `json:"tier"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	JoinedAt  time.Time          `json:"joined_at"`
+}
+
+// MembershipListData 当前用户的有效会员资格
+type MembershipListData struct {
+	Memberships []MembershipInfo `json:"memberships"`
+}
+
+// MemberInfo 创作者的会员
+type MemberInfo struct {
+	User      UserBriefInfo `json:"user"`
+	TierID    int64         `json:"tier_id"`
+	TierName  string        `json:"tier_name"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	JoinedAt  time.Time     `json:"joined_at"`
+}
+
+// MemberListData 会员列表
+type MemberListData struct {
+	Members    []MemberInfo `json:"members"`
+	Total      int64        `json:"total"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"page_size"`
+	TotalPages int64        `json:"total_pages"`
+}
+
+// MembersOnlyTier 会员专属视频要求的会员等级，用于受限占位信息中引导加入
+type MembersOnlyTier struct {
+	ID    int64  `json:"id"`
+	Level int    `json:"level"`
+	Name  string `json:"name"`
+	Price int64  `json:"price"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// MessageSendRequest 发送私信请求
+type MessageSendRequest struct {
+	Content string `json:"content" binding:"required,min=1,max=1000"`
+}
+
+// MessageInfo 私信消息
+type MessageInfo struct {
+	ID             int64     `json:"id"`
+	ConversationID int64     `json:"conversation_id"`
+	SenderID       int64     `json:"sender_id"`
+	Content        string    `json:"content"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ConversationInfo 私信会话
+type ConversationInfo struct {
+	ID                 int64        `json:"id"`
+	Peer               *AuthorBrief `json:"peer"`
+	LastSenderID       int64        `json:"last_sender_id"`
+	LastMessageContent string       `json:"last_message_content"`
+	LastMessageAt      *time.Time   `json:"last_message_at"`
+	UnreadCount        int64        `json:"unread_count"`
+}
+
+// ConversationListData 会话列表
+type ConversationListData struct {
+	Conversations []ConversationInfo `json:"conversations"`
+	Total         int64              `json:"total"`
+	Page          int                `json:"page"`
+	PageSize      int                `json:"page_size"`
+	TotalPages    int64              `json:"total_pages"`
+}
+
+// MessageListData 会话消息列表（按 ID 倒序，使用 next_before_id 加载更早的消息）
+type MessageListData struct {
+	Messages     []MessageInfo `json:"messages"`
+	HasMore      bool          `json:"has_more"`
+	NextBeforeID int64         `json:"next_before_id,omitempty"`
+}
+
+// MessageUnreadData 私信未读总数
+type MessageUnreadData struct {
+	UnreadCount int64 `json:"unread_count"`
+}
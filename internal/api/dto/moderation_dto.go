@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// VideoDuplicateInfo 近似重复记录
+type VideoDuplicateInfo struct {
+	ID          int64      `json:"id"`
+	Video       VideoInfo  `json:"video"`        // 新发布的视频
+	DuplicateOf VideoInfo  `json:"duplicate_of"` // 疑似原视频
+	Similarity  float64    `json:"similarity"`   // 匹配的抽帧比例
+	Status      string     `json:"status"`       // pending / linked / dismissed / hidden
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// VideoDuplicateListData 近似重复记录列表
+type VideoDuplicateListData struct {
+	Duplicates []VideoDuplicateInfo `json:"duplicates"`
+	Total      int64                `json:"total"`
+	Page       int                  `json:"page"`
+	PageSize   int                  `json:"page_size"`
+	TotalPages int64                `json:"total_pages"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// NotificationInfo 通知信息
+type NotificationInfo struct {
+	ID        int64        `json:"id"`
+	Type      string       `json:"type"`
+	Actor     *AuthorBrief `json:"actor,omitempty"`
+	VideoID   *int64       `json:"video_id,omitempty"`
+	CommentID *int64       `json:"comment_id,omitempty"`
+	Content   string       `json:"content,omitempty"`
+	IsRead    bool         `json:"is_read"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// NotificationListData 通知列表
+type NotificationListData struct {
+	Notifications []NotificationInfo `json:"notifications"`
+	Total         int64              `json:"total"`
+	Page          int                `json:"page"`
+	PageSize      int                `json:"page_size"`
+	TotalPages    int64              `json:"total_pages"`
+}
+
+// NotificationUnreadData 未读通知数
+type NotificationUnreadData struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// NotificationMarkReadRequest 标记已读请求
+type NotificationMarkReadRequest struct {
+	IDs []int64 `json:"ids" binding:"required,min=1,max=100"`
+}
+
+// NotificationMarkReadData 标记已读结果
+type NotificationMarkReadData struct {
+	Updated     int64 `json:"updated"`
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// NotificationPreferences 通知偏好
+type NotificationPreferences struct {
+	MutedTypes    []string `json:"muted_types"`
+	MutedVideoIDs []int64  `json:"muted_video_ids"`
+}
+
+// NotificationPreferencesUpdateRequest 更新屏蔽的通知类型（系统通知不可屏蔽）
+type NotificationPreferencesUpdateRequest struct {
+	MutedTypes []string `json:"muted_types" binding:"max=6,dive,oneof=like comment reply follow tip digest"`
+}
+
+// EmailPreferences 邮件通知设置
+type EmailPreferences struct {
+	Email string   `json:"email"`
+	Kinds []string `json:"kinds"`
+}
+
+// EmailPreferencesUpdateRequest 更新邮件通知设置（kinds 为空表示关闭全部邮件）
+type EmailPreferencesUpdateRequest struct {
+	Email string   `json:"email" binding:"omitempty,email,max=255"`
+	Kinds []string `json:"kinds" binding:"max=4,dive,oneof=follower_digest video_published moderation weekly_digest"`
+}
@@ -0,0 +1,75 @@
+package dto
+
+import "time"
+
+// OAuthClientCreateRequest 注册第三方应用请求。使用授权码模式时需登记回调地址
+type OAuthClientCreateRequest struct {
+	Name         string   `json:"name" binding:"required,min=1,max=100"`
+	RedirectURIs []string `json:"redirect_uris" binding:"max=10,dive,url,max=500"`
+	Scopes       []string `json:"scopes" binding:"required,min=1,dive,oneof=read:videos write:comments"`
+}
+
+// OAuthClientInfo 第三方应用信息
+type OAuthClientInfo struct {
+	ID           int64      `json:"id"`
+	ClientID     string     `json:"client_id"`
+	Name         string     `json:"name"`
+	RedirectURIs []string   `json:"redirect_uris"`
+	Scopes       []string   `json:"scopes"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// OAuthClientCreatedInfo 注册成功返回的应用信息，client_secret 只返回这一次
+type OAuthClientCreatedInfo struct {
+	OAuthClientInfo
+	ClientSecret string `json:"client_secret"`
+}
+
+// OAuthAuthorizeRequest 授权请求参数（授权码模式），可选 PKCE（仅支持 S256）
+type OAuthAuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type" binding:"required,eq=code"`
+	ClientID            string `form:"client_id" json:"client_id" binding:"required,max=64"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri" binding:"max=500"`
+	Scope               string `form:"scope" json:"scope" binding:"max=200"` // 空格分隔，为空时申请应用的全部 scope
+	State               string `form:"state" json:"state" binding:"max=500"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge" binding:"omitempty,min=43,max=128"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method" binding:"omitempty,eq=S256"`
+}
+
+// OAuthAuthorizeInfo 授权确认页展示的应用与申请的权限
+type OAuthAuthorizeInfo struct {
+	ClientID    string        `json:"client_id"`
+	Name        string        `json:"name"`
+	Owner       UserBriefInfo `json:"owner"`
+	RedirectURI string        `json:"redirect_uri"`
+	Scopes      []string      `json:"scopes"`
+}
+
+// OAuthAuthorizeResult 用户同意授权后返回，前端跳转到 redirect_url（已附带 code 与 state）
+type OAuthAuthorizeResult struct {
+	Code        string `json:"code"`
+	State       string `json:"state,omitempty"`
+	RedirectURL string `json:"redirect_url"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// OAuthTokenRequest 令牌请求（application/x-www-form-urlencoded），
+// 客户端凭证也可通过 HTTP Basic 认证传递
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type"` // client_credentials / authorization_code
+	Scope        string `form:"scope"`      // 仅客户端凭证模式，为空时为应用可申请的全部只读 scope
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// OAuthTokenResponse 令牌响应（RFC 6749 5.1）
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
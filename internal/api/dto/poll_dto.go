@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// VideoPollCreateRequest 创建视频投票
+type VideoPollCreateRequest struct {
+	Question string   `json:"question" binding:"required,max=200"`
+	Options  []string `json:"options" binding:"required,min=2,max=10,dive,required,max=80"`
+	Duration int      `json:"duration" binding:"required,min=5,max=10080"` // 投票时长（分钟），最长 7 天
+}
+
+// VideoPollVoteRequest 投票
+type VideoPollVoteRequest struct {
+	OptionID int64 `json:"option_id" binding:"required,min=1"`
+}
+
+// VideoPollOptionInfo 投票选项及当前票数
+type VideoPollOptionInfo struct {
+	ID    int64  `json:"id"`
+	Text  string `json:"text"`
+	Votes int64  `json:"votes"`
+}
+
+// VideoPollInfo 视频投票及实时结果
+type VideoPollInfo struct {
+	ID         int64                 `json:"id"`
+	VideoID    int64                 `json:"video_id"`
+	Question   string                `json:"question"`
+	Options    []VideoPollOptionInfo `json:"options"`
+	TotalVotes int64                 `json:"total_votes"`
+	EndsAt     time.Time             `json:"ends_at"`
+	Closed     bool                  `json:"closed"`
+	// 当前用户所投的选项，未投票时不返回
+	VotedOptionID *int64 `json:"voted_option_id,omitempty"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// PremiereScheduleRequest 预约首映
+type PremiereScheduleRequest struct {
+	PremiereAt time.Time `json:"premiere_at" binding:"required"` // RFC 3339 时间
+}
+
+// PremiereInfo 首映倒计时页及同步播放信息
+type PremiereInfo struct {
+	VideoID    int64        `json:"video_id"`
+	Title      string       `json:"title"`
+	CoverURL   string       `json:"cover_url"`
+	Duration   int          `json:"duration"`
+	Author     *AuthorBrief `json:"author,omitempty"`
+	PremiereAt time.Time    `json:"premiere_at"`
+	EndsAt     time.Time    `json:"ends_at"`
+	State      string       `json:"state"`       // upcoming 倒计时中 / live 首映中 / ended 已结束
+	ServerTime time.Time    `json:"server_time"` // 客户端据此校准本地时钟
+	StartsIn   int64        `json:"starts_in"`   // 距首映开始的秒数，首映开始后为 0
+	Position   float64      `json:"position"`    // 首映中时当前应播放到的位置（秒），所有观众同步
+	ChatOpen   bool         `json:"chat_open"`   // 首映聊天室是否开放
+}
+
+// PremiereChatMessage 首映聊天消息
+type PremiereChatMessage struct {
+	ID        string        `json:"id,omitempty"` // 即聊天室事件 ID，推送时在事件外层
+	User      UserBriefInfo `json:"user"`
+	Text      string        `json:"text"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// PremiereFrame 首映 WebSocket 推送的一帧：type 为 premiere.state / premiere.started / chat / error / ping
+type PremiereFrame struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// PremiereClientFrame 客户端通过首映 WebSocket 发送的一帧，目前只支持 type=chat
+type PremiereClientFrame struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// ProfileHomeData 用户主页聚合数据
+type ProfileHomeData struct {
+	User *UserFullInfo `json:"user"`
+
+	// 当前登录用户是否已关注，未登录或查看本人主页时不返回
+	IsFollowing *bool `json:"is_following,omitempty"`
+
+	// 置顶视频，未置顶时为 null
+	PinnedVideo *VideoInfo `json:"pinned_video"`
+
+	// 最新发布的视频（分页）
+	Videos *VideoListData `json:"videos"`
+}
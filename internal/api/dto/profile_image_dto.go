@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// ProfileImageReviewInfo 头像、主页背景图审核记录
+type ProfileImageReviewInfo struct {
+	ID          int64         `json:"id"`
+	User        UserBriefInfo `json:"user"`
+	Kind        string        `json:"kind"` // avatar / background
+	ImageURL    string        `json:"image_url"`
+	PreviousURL *string       `json:"previous_url"`
+	Status      string        `json:"status"` // pending / approved / rejected / superseded
+	Reason      string        `json:"reason,omitempty"`
+	ReviewedBy  *int64        `json:"reviewed_by"` // 为空且已处理表示由 Agent 服务自动处理
+	ReviewedAt  *time.Time    `json:"reviewed_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// ProfileImageReviewListData 审核记录列表
+type ProfileImageReviewListData struct {
+	Reviews    []ProfileImageReviewInfo `json:"reviews"`
+	Total      int64                    `json:"total"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	TotalPages int64                    `json:"total_pages"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// RenditionMissing 尚未投递过转码任务的档位状态
+const RenditionMissing = "missing"
+
+// VideoRenditionInfo 视频在某个档位下的产物
+type VideoRenditionInfo struct {
+	Profile   string     `json:"profile"`
+	Format    string     `json:"format"`
+	Status    string     `json:"status"` // missing / pending / ready / failed
+	URL       string     `json:"url,omitempty"`
+	Width     int        `json:"width,omitempty"`
+	Height    int        `json:"height,omitempty"`
+	Attempts  int        `json:"attempts"`
+	Error     string     `json:"error,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// VideoMediaInfo 默认播放文件的编码信息，转码完成时探测
+type VideoMediaInfo struct {
+	SourceFormat  string  `json:"source_format"` // 上传的原始文件格式
+	Duration      int     `json:"duration"`
+	Width         int     `json:"width"`
+	Height        int     `json:"height"`
+	VideoCodec    string  `json:"video_codec"`
+	AudioCodec    string  `json:"audio_codec"`
+	Bitrate       int64   `json:"bitrate"` // 总码率（bps）
+	FrameRate     float64 `json:"frame_rate"`
+	AudioChannels int     `json:"audio_channels"`
+}
+
+// VideoRenditionsData 视频各档位的完整度
+type VideoRenditionsData struct {
+	VideoID    int64                `json:"video_id"`
+	Complete   bool                 `json:"complete"` // 所有配置的档位均已生成
+	Media      VideoMediaInfo       `json:"media"`
+	Renditions []VideoRenditionInfo `json:"renditions"`
+}
+
+// RenditionProfileSummary 某档位在已发布视频中的覆盖情况
+type RenditionProfileSummary struct {
+	Profile      string  `json:"profile"`
+	Format       string  `json:"format"`
+	Ready        int64   `json:"ready"`
+	Pending      int64   `json:"pending"`
+	Failed       int64   `json:"failed"`
+	Missing      int64   `json:"missing"`
+	Completeness float64 `json:"completeness"` // ready / 已发布视频数
+}
+
+// RenditionSummaryData 各档位的补齐进度
+type RenditionSummaryData struct {
+	Published int64                     `json:"published"`
+	Profiles  []RenditionProfileSummary `json:"profiles"`
+}
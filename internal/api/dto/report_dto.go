@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// ReportUserRequest 举报用户请求
+type ReportUserRequest struct {
+	Reason string `json:"reason" binding:"required,oneof=spam harassment hate impersonation scam inappropriate underage other"`
+	Detail string `json:"detail" binding:"omitempty,max=500"`
+}
+
+// ReportInfo 举报记录（审核队列）
+type ReportInfo struct {
+	ID         int64         `json:"id"`
+	TargetType string        `json:"target_type"` // user / video / comment
+	TargetID   int64         `json:"target_id"`
+	TargetUser UserBriefInfo `json:"target_user"` // 被举报内容所属用户
+	Reporter   UserBriefInfo `json:"reporter"`
+	Reason     string        `json:"reason"`
+	Detail     string        `json:"detail,omitempty"`
+	Status     string        `json:"status"`
+	Escalated  bool          `json:"escalated"` // 该用户被多人举报，已升级为优先审核
+	ReviewedAt *time.Time    `json:"reviewed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// ReportListData 举报列表
+type ReportListData struct {
+	Reports    []ReportInfo `json:"reports"`
+	Total      int64        `json:"total"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"page_size"`
+	TotalPages int64        `json:"total_pages"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // SearchVideoRequest 搜索请求参数
 type SearchVideoRequest struct {
 	Q         string `form:"q"`
@@ -10,6 +12,13 @@ type SearchVideoRequest struct {
 	EndTime   *int64 `form:"end_time"`
 	Page      int    `form:"page"`
 	PageSize  int    `form:"page_size"`
+
+	// 画质筛选：最低高度（如 720、1080）与最低帧率
+	MinHeight    *int     `form:"min_height" binding:"omitempty,min=1"`
+	MinFrameRate *float64 `form:"min_frame_rate" binding:"omitempty,gt=0"`
+
+	// 付费推广筛选：true 只返回付费推广视频，false 排除付费推广视频，不传不筛选
+	Sponsored *bool `form:"sponsored"`
 }
 
 // SearchVideoInfo 搜索结果中的视频信息
@@ -24,8 +33,18 @@ type SearchVideoInfo struct {
 	ViewCount     int64              `json:"view_count"`
 	FavoriteCount int64              `json:"favorite_count"`
 	CommentCount  int64              `json:"comment_count"`
-	PublishTime   *int64             `json:"publish_time"`
+	PublishTime   *int64             `json:"publish_time"` // 已废弃：Unix 秒，兼容旧客户端，请使用 published_at
+	PublishedAt   *time.Time          `json:"published_at"`
 	Highlight     map[string][]string `json:"highlight,omitempty"`
+
+	// 付费推广标记与推广声明，规则同 VideoInfo
+	IsSponsored       bool   `json:"is_sponsored"`
+	SponsorDisclosure string `json:"sponsor_disclosure,omitempty"`
+
+	// 会员专属与年龄限制，规则同 VideoInfo
+	MembersOnlyTierID *int64                 `json:"members_only_tier_id,omitempty"`
+	AgeRestricted     bool                   `json:"age_restricted"`
+	Restricted        *RestrictedPlaceholder `json:"restricted,omitempty"`
 }
 
 // SearchVideoData 搜索结果
@@ -35,4 +54,27 @@ type SearchVideoData struct {
 	Page       int               `json:"page"`
 	PageSize   int               `json:"page_size"`
 	TotalPages int64             `json:"total_pages"`
+
+	// 关键词与某个用户名相同或非常接近时附带该作者的主页卡片（仅第一页）
+	Author *SearchAuthorCard `json:"author,omitempty"`
+
+	// ES 不可用时结果来自数据库降级搜索，Unavailable 列出此时不提供的能力
+	Degraded    bool     `json:"degraded"`
+	Unavailable []string `json:"unavailable,omitempty"`
 }
+
+// SearchAuthorCard 搜索结果中匹配到的作者（"你是不是要找"）
+type SearchAuthorCard struct {
+	UserBriefInfo
+	FollowerCount  int64             `json:"follower_count"`
+	TotalFavorited int64             `json:"total_favorited"`
+	ExactMatch     bool              `json:"exact_match"` // 关键词与用户名完全相同（不区分大小写）
+	TopVideos      []SearchVideoInfo `json:"top_videos"`  // 作者最热门的几个视频
+}
+
+// 降级搜索时不可用的能力
+const (
+	SearchCapabilityHighlight = "highlight" // 关键词高亮
+	SearchCapabilityRelevance = "relevance" // 相关度排序（降级时按创建时间排序）
+	SearchCapabilityAnalyzer  = "analyzer"  // 分词匹配（降级时为整词模糊匹配）
+)
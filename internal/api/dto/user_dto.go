@@ -1,23 +1,45 @@
 package dto
 
+import "time"
+
 // UserUpdateRequest 用户信息更新请求
 type UserUpdateRequest struct {
 	Username        *string `json:"username" binding:"omitempty,min=1,max=255"`
 	Avatar          *string `json:"avatar" binding:"omitempty,max=500"`
 	BackgroundImage *string `json:"background_image" binding:"omitempty,max=500"`
+	BirthDate       *string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"` // 设置后仅管理员可修改
 }
 
 // UserFullInfo 用户完整公开信息（含收藏统计）
+// PendingAvatar、PendingBackgroundImage 为审核中的新图片，仅返回给本人和管理员
 type UserFullInfo struct {
-	ID              int64   `json:"id"`
-	Username        string  `json:"user_name"`
-	Avatar          *string `json:"avatar"`
-	BackgroundImage *string `json:"background_image"`
-	UserRole        string  `json:"user_role"`
-	FollowCount     int64   `json:"follow_count"`
-	FollowerCount   int64   `json:"follower_count"`
-	TotalFavorited  int64   `json:"total_favorited"`
-	FavoriteCount   int64   `json:"favorite_count"`
+	ID                     int64   `json:"id"`
+	Username               string  `json:"user_name"`
+	Avatar                 *string `json:"avatar"`
+	BackgroundImage        *string `json:"background_image"`
+	UserRole               string  `json:"user_role"`
+	FollowCount            int64   `json:"follow_count"`
+	FollowerCount          int64   `json:"follower_count"`
+	TotalFavorited         int64   `json:"total_favorited"`
+	FavoriteCount          int64   `json:"favorite_count"`
+	IsVerified             bool    `json:"is_verified"`
+	PendingAvatar          *string `json:"pending_avatar,omitempty"`
+	PendingBackgroundImage *string `json:"pending_background_image,omitempty"`
+}
+
+// UserBatchRequest 批量获取用户请求
+type UserBatchRequest struct {
+	UserIDs []int64 `json:"user_ids" binding:"required,min=1,max=100"`
+}
+
+// UserBriefInfo 用户简要信息，用于列表中批量展示作者、评论者等
+// IsFollowing 仅在登录时返回
+type UserBriefInfo struct {
+	ID          int64   `json:"id"`
+	Username    string  `json:"user_name"`
+	Avatar      *string `json:"avatar"`
+	IsVerified  bool    `json:"is_verified"`
+	IsFollowing *bool   `json:"is_following,omitempty"`
 }
 
 // PaginationMeta 分页元数据
@@ -33,3 +55,28 @@ type PaginatedData struct {
 	Items interface{}    `json:"items"`
 	Meta  PaginationMeta `json:"meta"`
 }
+
+// SetRoleRequest 设置用户角色请求
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user moderator admin"`
+}
+
+// UserRestrictionRequest 封禁 / 禁言请求
+type UserRestrictionRequest struct {
+	DurationHours int    `json:"duration_hours" binding:"required,min=1,max=87600"`
+	Reason        string `json:"reason" binding:"omitempty,max=500"`
+}
+
+// ImpersonateRequest 管理员模拟登录请求
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// UserRestrictionInfo 用户当前的账号限制状态
+type UserRestrictionInfo struct {
+	UserID         int64      `json:"user_id"`
+	SuspendedUntil *time.Time `json:"suspended_until"`
+	SuspendReason  string     `json:"suspend_reason,omitempty"`
+	MutedUntil     *time.Time `json:"muted_until"`
+	MuteReason     string     `json:"mute_reason,omitempty"`
+}
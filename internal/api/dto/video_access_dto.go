@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// VideoAccessGrantRequest 授权用户观看私密视频
+type VideoAccessGrantRequest struct {
+	UserIDs []int64 `json:"user_ids" binding:"required,min=1,max=100,dive,min=1"`
+}
+
+// VideoShareLinkRedeemRequest 通过分享链接领取观看权限
+type VideoShareLinkRedeemRequest struct {
+	Token string `json:"token" binding:"required,max=128"`
+}
+
+// VideoAccessGrantInfo 私密视频的被授权用户
+type VideoAccessGrantInfo struct {
+	User      UserBriefInfo `json:"user"`
+	Via       string        `json:"via"` // direct 作者直接授权 / link 通过分享链接领取
+	GrantedAt time.Time     `json:"granted_at"`
+}
+
+// VideoAccessListData 私密视频的授权列表
+type VideoAccessListData struct {
+	Visibility string                 `json:"visibility"`
+	ShareLink  bool                   `json:"share_link"` // 是否已开启链接分享
+	Grants     []VideoAccessGrantInfo `json:"grants"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	TotalPages int64                  `json:"total_pages"`
+}
+
+// VideoAccessGrantResult 批量授权结果
+type VideoAccessGrantResult struct {
+	Granted int64 `json:"granted"` // 新增授权的用户数，已授权的用户不重复计算
+}
+
+// VideoShareLinkData 分享链接令牌，仅在生成时返回一次
+type VideoShareLinkData struct {
+	VideoID int64  `json:"video_id"`
+	Token   string `json:"token"`
+}
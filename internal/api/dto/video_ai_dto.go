@@ -0,0 +1,25 @@
+package dto
+
+// VideoTagInfo 视频标签
+type VideoTagInfo struct {
+	Name   string `json:"name"`
+	Source string `json:"source"` // machine: AI 生成, author: 作者添加
+}
+
+// VideoTagsData 视频标签与分类
+type VideoTagsData struct {
+	VideoID    int64          `json:"video_id"`
+	Tags       []VideoTagInfo `json:"tags"`
+	Categories []VideoTagInfo `json:"categories"`
+}
+
+// VideoTagsUpdateRequest 作者编辑标签与分类（整体替换）
+type VideoTagsUpdateRequest struct {
+	Tags       []string `json:"tags" binding:"max=20,dive,min=1,max=50"`
+	Categories []string `json:"categories" binding:"max=3,dive,min=1,max=50"`
+}
+
+// VideoAskRequest 视频问答请求
+type VideoAskRequest struct {
+	Question string `json:"question" binding:"required,min=1,max=500"`
+}
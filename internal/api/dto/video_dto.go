@@ -6,6 +6,14 @@ import "time"
 type VideoUploadRequest struct {
 	Title       string `form:"title" binding:"required,min=1,max=200"`
 	Description string `form:"description" binding:"omitempty"`
+
+	// 合拍、拼接或二创时引用的原视频，remix_type 默认为 remix
+	RemixOfID int64  `form:"remix_of_id" binding:"omitempty,min=1"`
+	RemixType string `form:"remix_type" binding:"omitempty,oneof=duet stitch remix"`
+
+	// 付费推广：含商业合作时设为 true 并填写推广声明（如合作品牌）
+	IsSponsored       bool   `form:"is_sponsored"`
+	SponsorDisclosure string `form:"sponsor_disclosure" binding:"max=200"`
 }
 
 // VideoUpdateRequest 视频更新请求
@@ -13,6 +21,26 @@ type VideoUpdateRequest struct {
 	Title       *string `json:"title" binding:"omitempty,min=1,max=200"`
 	Description *string `json:"description"`
 	Status      *string `json:"status" binding:"omitempty,oneof=pending processing published failed deleted"`
+
+	// 标记或取消年龄限制；审核员设置的限制作者不能取消
+	AgeRestricted *bool `json:"age_restricted"`
+
+	// 可见范围：private 仅作者及被授权的用户可见
+	Visibility *string `json:"visibility" binding:"omitempty,oneof=public private"`
+
+	// 设为会员专属：值为作者本人上架中的会员等级ID，该等级及以上的会员可以观看；0 表示取消
+	MembersOnlyTierID *int64 `json:"members_only_tier_id" binding:"omitempty,min=0"`
+
+	// 付费推广标记与推广声明：标记为付费推广时必须有推广声明，取消标记时清空声明
+	IsSponsored       *bool   `json:"is_sponsored"`
+	SponsorDisclosure *string `json:"sponsor_disclosure" binding:"omitempty,max=200"`
+}
+
+// VideoRegionsRequest 设置视频地区限制（整体替换），代码为 ISO 3166-1 alpha-2，如 CN、US。
+// 两个列表都为空表示取消限制；同时设置时屏蔽列表优先
+type VideoRegionsRequest struct {
+	AllowedRegions []string `json:"allowed_regions" binding:"max=250,dive,len=2,alpha"`
+	BlockedRegions []string `json:"blocked_regions" binding:"max=250,dive,len=2,alpha"`
 }
 
 // AuthorBrief 视频中嵌套的作者简要信息
@@ -39,10 +67,95 @@ type VideoInfo struct {
 	ViewCount     int64        `json:"view_count"`
 	FavoriteCount int64        `json:"favorite_count"`
 	CommentCount  int64        `json:"comment_count"`
-	PublishTime   *int64       `json:"publish_time"`
+	PublishTime   *int64       `json:"publish_time"` // 已废弃：Unix 秒，兼容旧客户端，请使用 published_at
+	PublishedAt   *time.Time   `json:"published_at"`
 	CreatedAt     time.Time    `json:"created_at"`
 	UpdatedAt     time.Time    `json:"updated_at"`
 	Author        *AuthorBrief `json:"author,omitempty"`
+
+	// 默认播放文件的编码信息，转码完成前为空
+	VideoCodec    string  `json:"video_codec"`
+	AudioCodec    string  `json:"audio_codec"`
+	Bitrate       int64   `json:"bitrate"` // 总码率（bps）
+	FrameRate     float64 `json:"frame_rate"`
+	AudioChannels int     `json:"audio_channels"`
+
+	// 各类型表态数，favorite_count 为合计
+	Reactions ReactionCounts `json:"reactions"`
+
+	// 是否置顶在作者主页
+	IsPinned bool `json:"is_pinned"`
+
+	// 可见范围：public / private（仅作者及被授权的用户可见）
+	Visibility string `json:"visibility"`
+
+	// 播放状态：ready 可直接播放；preparing 表示视频正从冷存储恢复，播放地址暂不可用，稍后重新获取详情；
+	// premiere 表示首映尚未开始，播放地址在首映开始后返回
+	PlaybackState string `json:"playback_state"`
+
+	// AI 生成的摘要与关键时刻，用于预览卡片，未生成时不返回
+	Summary    string          `json:"summary,omitempty"`
+	KeyMoments []KeyMomentInfo `json:"key_moments,omitempty"`
+
+	// 与已有视频高度相似时自动关联的原视频ID
+	DuplicateOfID *int64 `json:"duplicate_of_id,omitempty"`
+
+	// 引用的原视频及引用方式（duet/stitch/remix），非引用视频不返回
+	RemixOfID *int64 `json:"remix_of_id,omitempty"`
+	RemixType string `json:"remix_type,omitempty"`
+
+	// 引用了该视频的已发布视频数量及最新几条，仅视频详情返回
+	RemixCount *int64      `json:"remix_count,omitempty"`
+	Remixes    []VideoInfo `json:"remixes,omitempty"`
+
+	// 首映时间，未预约首映时不返回；首映倒计时、同步播放位置见首映接口
+	PremiereAt *time.Time `json:"premiere_at,omitempty"`
+
+	// 视频附带的投票及实时结果，仅视频详情返回，没有投票时不返回
+	Poll *VideoPollInfo `json:"poll,omitempty"`
+
+	// 地区限制（国家/地区代码），未设置时不返回
+	AllowedRegions []string `json:"allowed_regions,omitempty"`
+	BlockedRegions []string `json:"blocked_regions,omitempty"`
+
+	// 会员专属视频要求的会员等级ID，非会员只能看到标题、封面等预览信息并返回 Restricted 占位信息
+	MembersOnlyTierID *int64 `json:"members_only_tier_id,omitempty"`
+
+	// 付费推广视频需向观众展示推广声明，非推广视频不返回声明
+	IsSponsored       bool   `json:"is_sponsored"`
+	SponsorDisclosure string `json:"sponsor_disclosure,omitempty"`
+
+	// 年龄限制：观看者不满足条件时清空播放地址、封面、简介等内容并返回 Restricted 占位信息
+	AgeRestricted bool                   `json:"age_restricted"`
+	Restricted    *RestrictedPlaceholder `json:"restricted,omitempty"`
+
+	// 当前登录用户是否已点赞该视频、是否已关注作者，未登录时不返回；Reaction 为当前用户的表态类型，未表态时不返回
+	IsFavorited *bool  `json:"is_favorited,omitempty"`
+	IsFollowing *bool  `json:"is_following,omitempty"`
+	Reaction    string `json:"reaction,omitempty"`
+}
+
+// 内容受限占位原因
+const (
+	RestrictedCodeLoginRequired      = "login_required"      // 未登录
+	RestrictedCodeBirthDateRequired  = "birth_date_required" // 未填写出生日期
+	RestrictedCodeUnderage           = "underage"            // 未达到最低年龄
+	RestrictedCodeMembershipRequired = "membership_required" // 不是会员或会员等级不够
+)
+
+// RestrictedPlaceholder 内容受限时替代播放信息返回的占位说明
+type RestrictedPlaceholder struct {
+	Reason  string           `json:"reason"` // age_restricted 年龄限制 / members_only 会员专属
+	Code    string           `json:"code"`
+	MinAge  int              `json:"min_age,omitempty"`
+	Tier    *MembersOnlyTier `json:"tier,omitempty"` // 会员专属视频要求的会员等级
+	Message string           `json:"message"`
+}
+
+// KeyMomentInfo 视频关键时刻
+type KeyMomentInfo struct {
+	Time  int    `json:"time"` // 秒
+	Label string `json:"label"`
 }
 
 // VideoListData 视频列表响应数据
@@ -53,3 +166,18 @@ type VideoListData struct {
 	PageSize   int         `json:"page_size"`
 	TotalPages int64       `json:"total_pages"`
 }
+
+// VideoRecommendData 推荐视频列表（相关视频、为你推荐）
+type VideoRecommendData struct {
+	Videos []VideoInfo `json:"videos"`
+}
+
+// RemixSettings 是否允许他人引用自己的视频合拍、拼接或二创
+type RemixSettings struct {
+	AllowRemix bool `json:"allow_remix"`
+}
+
+// RemixSettingsUpdateRequest 更新二创设置，只影响之后的上传，已发布的引用视频不受影响
+type RemixSettingsUpdateRequest struct {
+	AllowRemix *bool `json:"allow_remix" binding:"required"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+// VideoDailyStatInfo 单日统计
+// Plays、WatchTimeMs 与完播率来自客户端埋点，Views 为服务端记录的详情访问次数
+type VideoDailyStatInfo struct {
+	Date           string  `json:"date"` // YYYY-MM-DD（UTC）
+	Views          int64   `json:"views"`
+	Likes          int64   `json:"likes"`
+	Comments       int64   `json:"comments"`
+	Plays          int64   `json:"plays"`
+	WatchTimeMs    int64   `json:"watch_time_ms"`
+	AvgWatchTimeMs int64   `json:"avg_watch_time_ms"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// VideoStatsData 视频统计响应，Daily 按日期升序且不缺日（无数据的日期为 0）
+type VideoStatsData struct {
+	VideoID          int64                `json:"video_id"`
+	Days             int                  `json:"days"`
+	TotalViews       int64                `json:"total_views"`
+	TotalLikes       int64                `json:"total_likes"`
+	TotalComments    int64                `json:"total_comments"`
+	TotalPlays       int64                `json:"total_plays"`
+	TotalWatchTimeMs int64                `json:"total_watch_time_ms"`
+	AvgWatchTimeMs   int64                `json:"avg_watch_time_ms"`
+	CompletionRate   float64              `json:"completion_rate"`
+	Daily            []VideoDailyStatInfo `json:"daily"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// WalletInfo 金币钱包
+type WalletInfo struct {
+	Balance int64 `json:"balance"`
+}
+
+// TipRequest 打赏视频。request_id 由客户端生成，重试时保持不变，同一 request_id 只会扣款一次
+type TipRequest struct {
+	Amount    int64  `json:"amount" binding:"required,min=1,max=100000"`
+	Message   string `json:"message" binding:"max=100"`
+	RequestID string `json:"request_id" binding:"required,max=64"`
+}
+
+// TipResult 打赏结果，重复提交同一 request_id 时返回首次记账的流水
+type TipResult struct {
+	Transaction WalletTransactionInfo `json:"transaction"` // 付款方流水
+	Balance     int64                 `json:"balance"`     // 当前余额
+}
+
+// WalletAdjustRequest 管理员调整用户余额，amount 为负表示扣减
+type WalletAdjustRequest struct {
+	Amount    int64  `json:"amount" binding:"required,min=-10000000,max=10000000"`
+	Reason    string `json:"reason" binding:"required,max=200"`
+	RequestID string `json:"request_id" binding:"required,max=64"`
+}
+
+// WalletTransactionQuery 金币流水查询条件（管理员）
+type WalletTransactionQuery struct {
+	UserID     *int64  `form:"user_id"`
+	Kind       *string `form:"kind" binding:"omitempty,oneof=tip_sent tip_received admin_adjust membership_paid membership_received"`
+	VideoID    *int64  `form:"video_id"`
+	TransferID *string `form:"transfer_id"`
+}
+
+// WalletTransactionInfo 金币流水
+type WalletTransactionInfo struct {
+	ID             int64     `json:"id"`
+	UserID         int64     `json:"user_id"`
+	Kind           string    `json:"kind"`          // tip_sent 打赏支出 / tip_received 收到打赏 / admin_adjust 管理员调整 / membership_paid 购买会员 / membership_received 会员收入
+	Amount         int64     `json:"amount"`        // 入账为正，出账为负
+	BalanceAfter   int64     `json:"balance_after"` // 记账后余额
+	TransferID     string    `json:"transfer_id"`   // 同一次转账的两条流水相同
+	RequestID      string    `json:"request_id,omitempty"`
+	CounterpartyID *int64    `json:"counterparty_id,omitempty"`
+	VideoID        *int64    `json:"video_id,omitempty"`
+	OperatorID     *int64    `json:"operator_id,omitempty"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// WalletTransactionListData 金币流水列表
+type WalletTransactionListData struct {
+	Transactions []WalletTransactionInfo `json:"transactions"`
+	Total        int64                   `json:"total"`
+	Page         int                     `json:"page"`
+	PageSize     int                     `json:"page_size"`
+	TotalPages   int64                   `json:"total_pages"`
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AnalyticsHandler struct {
+	analyticsService *service.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService}
+}
+
+// Ingest 批量上报客户端埋点事件
+// @Summary 上报埋点事件
+// @Description 批量上报播放、暂停、完播、曝光事件（每批最多 100 条），登录可选，未登录时需提供 device_id。超过 24 小时或时间超前的事件会被丢弃
+// @Tags 埋点
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AnalyticsEventBatchRequest true "埋点事件"
+// @Success 200 {object} response.Response{data=dto.AnalyticsEventBatchResult} "上报成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Router /events [post]
+func (h *AnalyticsHandler) Ingest(c *gin.Context) {
+	var req dto.AnalyticsEventBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	result, err := h.analyticsService.Ingest(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrAnalyticsIdentityRequired) {
+			respondServiceError(c, http.StatusBadRequest, err)
+			return
+		}
+		logger.FromContext(c.Request.Context()).Error("Ingest analytics events failed", zap.Int("count", len(req.Events)), zap.Error(err))
+		response.InternalError(c, "事件上报失败")
+		return
+	}
+
+	response.OK(c, "上报成功", result)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/rbac"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const auditReasonMaxLen = 500
+
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// ListAuditLogs 查询审计日志
+// @Summary 查询审计日志（管理员）
+// @Description 按操作人、操作类型、目标、时间范围分页查询审计日志
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query int false "操作人ID"
+// @Param action query string false "操作类型，如 user.delete"
+// @Param target_type query string false "目标类型: user, video, comment"
+// @Param target_id query int false "目标ID"
+// @Param start_time query int false "开始时间戳"
+// @Param end_time query int false "结束时间戳"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.PaginatedData} "获取成功"
+// @Router /admin/audit-logs [get]
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	var query dto.AuditLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	page, pageSize := parsePagination(c)
+
+	data, err := h.auditService.List(c.Request.Context(), &query, page, pageSize)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("List audit logs failed", zap.Error(err))
+		response.InternalError(c, "获取审计日志失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// recordAudit 记录当前请求发起的审计事件
+func recordAudit(c *gin.Context, auditService *service.AuditService, action, targetType string, targetID int64, reason string) {
+	actorID, _ := middleware.GetCurrentUserID(c)
+	reason = strings.TrimSpace(reason)
+	if r := []rune(reason); len(r) > auditReasonMaxLen {
+		reason = string(r[:auditReasonMaxLen])
+	}
+
+	auditService.Record(c.Request.Context(), &service.AuditEntry{
+		ActorID:    actorID,
+		ActorRole:  middleware.GetCurrentUserRole(c),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		IP:         c.ClientIP(),
+	})
+}
+
+// RecordImpersonatedRequest 返回记录模拟登录期间请求的函数，操作人为实际操作的管理员
+func RecordImpersonatedRequest(auditService *service.AuditService) middleware.ImpersonationRecorder {
+	return func(c *gin.Context, impersonatorID, userID int64) {
+		auditService.Record(c.Request.Context(), &service.AuditEntry{
+			ActorID:    impersonatorID,
+			ActorRole:  rbac.RoleAdmin,
+			Action:     service.AuditActionImpersonatedCall,
+			TargetType: service.AuditTargetUser,
+			TargetID:   userID,
+			Reason:     fmt.Sprintf("%s %s %d", c.Request.Method, c.Request.URL.Path, c.Writer.Status()),
+			IP:         c.ClientIP(),
+		})
+	}
+}
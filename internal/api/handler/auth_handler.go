@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 
 	"vida-go/internal/api/dto"
 	"vida-go/internal/api/middleware"
@@ -23,28 +24,34 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 
 // Register 用户注册
 // @Summary 用户注册
-// @Description 注册新用户账号
+// @Description 注册新用户账号。开启邀请注册（registration.invite_only）时必须填写有效的 invite_code；开启图片审核时，填写的头像与背景图审核通过后才会生效
 // @Tags 认证
 // @Accept json
 // @Produce json
 // @Param request body dto.RegisterRequest true "注册信息"
 // @Success 201 {object} response.Response{data=dto.UserInfo} "注册成功"
-// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效或邀请码无效"
+// @Failure 403 {object} response.ErrorResponse "仅支持邀请注册"
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
-	userInfo, err := h.authService.Register(&req)
+	userInfo, err := h.authService.Register(c.Request.Context(), &req)
 	if err != nil {
-		if errors.Is(err, service.ErrUsernameExists) {
-			response.BadRequest(c, err.Error())
+		if errors.Is(err, service.ErrUsernameExists) || errors.Is(err, service.ErrUsernameReserved) ||
+			errors.Is(err, service.ErrUsernameInappropriate) || errors.Is(err, service.ErrInvalidInviteCode) {
+			respondServiceError(c, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, service.ErrInviteCodeRequired) {
+			respondServiceError(c, http.StatusForbidden, err)
 			return
 		}
-		logger.Error("Register failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Register failed", zap.Error(err))
 		response.InternalError(c, "注册失败，请稍后重试")
 		return
 	}
@@ -54,33 +61,44 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login 用户登录
 // @Summary 用户登录
-// @Description 用户登录获取 JWT Token
+// @Description 用户登录获取 JWT Token。每次登录都会记录 IP、设备与归属地，新设备或新地点登录时发送系统通知
 // @Tags 认证
 // @Accept json
 // @Produce json
+// @Param X-Device-ID header string false "客户端设备 ID，未提供时按 User-Agent 区分设备"
 // @Param request body dto.LoginRequest true "登录信息"
 // @Success 200 {object} response.Response{data=dto.TokenData} "登录成功"
 // @Failure 400 {object} response.ErrorResponse "请求参数无效"
 // @Failure 401 {object} response.ErrorResponse "用户名或密码错误"
+// @Failure 403 {object} response.ErrorResponse "账号被封禁或需要修改密码"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
+	req.Client = dto.LoginClient{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		DeviceID:  c.GetHeader("X-Device-ID"),
+	}
 
-	tokenData, err := h.authService.Login(&req)
+	tokenData, err := h.authService.Login(c.Request.Context(), &req)
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCredential) {
-			response.Unauthorized(c, err.Error())
+			respondServiceError(c, http.StatusUnauthorized, err)
 			return
 		}
 		if errors.Is(err, service.ErrUserDeleted) {
-			response.Unauthorized(c, err.Error())
+			respondServiceError(c, http.StatusUnauthorized, err)
+			return
+		}
+		if errors.Is(err, service.ErrUserSuspended) || errors.Is(err, service.ErrPasswordResetRequired) {
+			respondServiceError(c, http.StatusForbidden, err)
 			return
 		}
-		logger.Error("Login failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Login failed", zap.Error(err))
 		response.InternalError(c, "登录失败，请稍后重试")
 		return
 	}
@@ -88,6 +106,42 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	response.OK(c, "登录成功", tokenData)
 }
 
+// ChangePassword 修改密码
+// @Summary 修改密码
+// @Description 校验原密码后修改密码；标记过非本人登录的账号修改后恢复正常登录
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ChangePasswordRequest true "原密码与新密码"
+// @Success 200 {object} response.Response "修改成功"
+// @Failure 400 {object} response.ErrorResponse "原密码错误"
+// @Router /auth/password [put]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if err := h.authService.ChangePassword(c.Request.Context(), userID, &req); err != nil {
+		switch {
+		case errors.Is(err, service.ErrWrongPassword):
+			respondServiceError(c, http.StatusBadRequest, err)
+		case errors.Is(err, service.ErrUserNotFound):
+			respondServiceError(c, http.StatusUnauthorized, err)
+		default:
+			logger.FromContext(c.Request.Context()).Error("Change password failed", zap.Int64("user_id", userID), zap.Error(err))
+			response.InternalError(c, "修改密码失败，请稍后重试")
+		}
+		return
+	}
+
+	response.OK(c, "密码修改成功", nil)
+}
+
 // Logout 用户登出
 // @Summary 用户登出
 // @Description 用户登出（当前仅返回成功）
@@ -118,13 +172,13 @@ func (h *AuthHandler) Me(c *gin.Context) {
 		return
 	}
 
-	userInfo, err := h.authService.GetCurrentUser(userID)
+	userInfo, err := h.authService.GetCurrentUser(c.Request.Context(), userID)
 	if err != nil {
 		if errors.Is(err, service.ErrUserNotFound) || errors.Is(err, service.ErrUserDeleted) {
-			response.Unauthorized(c, err.Error())
+			respondServiceError(c, http.StatusUnauthorized, err)
 			return
 		}
-		logger.Error("Get current user failed", zap.Error(err), zap.Int64("user_id", userID))
+		logger.FromContext(c.Request.Context()).Error("Get current user failed", zap.Error(err), zap.Int64("user_id", userID))
 		response.InternalError(c, "获取用户信息失败")
 		return
 	}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 	"strconv"
 
 	"vida-go/internal/api/dto"
@@ -16,10 +17,11 @@ import (
 
 type CommentHandler struct {
 	commentService *service.CommentService
+	auditService   *service.AuditService
 }
 
-func NewCommentHandler(commentService *service.CommentService) *CommentHandler {
-	return &CommentHandler{commentService: commentService}
+func NewCommentHandler(commentService *service.CommentService, auditService *service.AuditService) *CommentHandler {
+	return &CommentHandler{commentService: commentService, auditService: auditService}
 }
 
 // Create 发表评论
@@ -43,13 +45,13 @@ func (h *CommentHandler) Create(c *gin.Context) {
 
 	var req dto.CommentCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
 	userID, _ := middleware.GetCurrentUserID(c)
 
-	info, err := h.commentService.Create(userID, videoID, &req)
+	info, err := h.commentService.Create(c.Request.Context(), userID, videoID, &req)
 	if err != nil {
 		handleCommentError(c, err)
 		return
@@ -80,13 +82,13 @@ func (h *CommentHandler) Update(c *gin.Context) {
 
 	var req dto.CommentUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
 	userID, _ := middleware.GetCurrentUserID(c)
 
-	info, err := h.commentService.Update(commentID, userID, &req)
+	info, err := h.commentService.Update(c.Request.Context(), commentID, userID, &req)
 	if err != nil {
 		handleCommentError(c, err)
 		return
@@ -115,11 +117,12 @@ func (h *CommentHandler) Delete(c *gin.Context) {
 
 	userID, _ := middleware.GetCurrentUserID(c)
 
-	_, err = h.commentService.Delete(commentID, userID)
+	_, err = h.commentService.Delete(c.Request.Context(), commentID, userID)
 	if err != nil {
 		handleCommentError(c, err)
 		return
 	}
+	recordAudit(c, h.auditService, service.AuditActionCommentDelete, service.AuditTargetComment, commentID, c.Query("reason"))
 
 	response.OK(c, "删除评论成功", nil)
 }
@@ -153,7 +156,8 @@ func (h *CommentHandler) ListByVideo(c *gin.Context) {
 		}
 	}
 
-	data, err := h.commentService.ListByVideo(videoID, parentID, page, pageSize)
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.commentService.ListByVideo(c.Request.Context(), videoID, currentUserID, parentID, page, pageSize)
 	if err != nil {
 		handleCommentError(c, err)
 		return
@@ -182,7 +186,8 @@ func (h *CommentHandler) ListReplies(c *gin.Context) {
 
 	page, pageSize := parsePagination(c)
 
-	data, err := h.commentService.ListReplies(commentID, page, pageSize)
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.commentService.ListReplies(c.Request.Context(), commentID, currentUserID, page, pageSize)
 	if err != nil {
 		handleCommentError(c, err)
 		return
@@ -205,9 +210,9 @@ func (h *CommentHandler) ListMyComments(c *gin.Context) {
 	userID, _ := middleware.GetCurrentUserID(c)
 	page, pageSize := parsePagination(c)
 
-	data, err := h.commentService.ListByUser(userID, page, pageSize)
+	data, err := h.commentService.ListByUser(c.Request.Context(), userID, page, pageSize)
 	if err != nil {
-		logger.Error("Get my comments failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Get my comments failed", zap.Error(err))
 		response.InternalError(c, "获取我的评论列表失败")
 		return
 	}
@@ -218,17 +223,21 @@ func (h *CommentHandler) ListMyComments(c *gin.Context) {
 func handleCommentError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrCommentNotFound):
-		response.NotFound(c, err.Error())
+		respondServiceError(c, http.StatusNotFound, err)
 	case errors.Is(err, service.ErrCommentNoPermission):
-		response.Forbidden(c, err.Error())
+		respondServiceError(c, http.StatusForbidden, err)
 	case errors.Is(err, service.ErrVideoNotFound):
-		response.NotFound(c, err.Error())
+		respondServiceError(c, http.StatusNotFound, err)
 	case errors.Is(err, service.ErrParentNotFound):
-		response.NotFound(c, err.Error())
+		respondServiceError(c, http.StatusNotFound, err)
 	case errors.Is(err, service.ErrParentVideoMismatch):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrUserMuted):
+		respondServiceError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrLegalHold):
+		respondServiceError(c, http.StatusLocked, err)
 	default:
-		logger.Error("Comment operation failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Comment operation failed", zap.Error(err))
 		response.InternalError(c, "操作失败，请稍后重试")
 	}
 }
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"strconv"
+
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// creatorMaxDays 创作者看板单次查询的最大天数
+const creatorMaxDays = 90
+
+type CreatorHandler struct {
+	creatorAnalyticsService *service.CreatorAnalyticsService
+}
+
+func NewCreatorHandler(creatorAnalyticsService *service.CreatorAnalyticsService) *CreatorHandler {
+	return &CreatorHandler{creatorAnalyticsService: creatorAnalyticsService}
+}
+
+// GetOverview 创作者数据概览
+// @Summary 创作者数据概览
+// @Description 获取当前用户作为创作者最近 N 天（按 UTC 日期）的每日播放、点赞、评论、新增粉丝及播放最多的视频，结果缓存 10 分钟
+// @Tags 创作者
+// @Produce json
+// @Security BearerAuth
+// @Param range query string false "统计范围，如 7d、30d，最多 90d" default(30d)
+// @Success 200 {object} response.Response{data=dto.CreatorOverviewData} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "无效的统计范围"
+// @Router /creator/analytics/overview [get]
+func (h *CreatorHandler) GetOverview(c *gin.Context) {
+	days, ok := parseDayRange(c.DefaultQuery("range", "30d"), creatorMaxDays)
+	if !ok {
+		response.BadRequest(c, "无效的统计范围")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.creatorAnalyticsService.GetOverview(c.Request.Context(), userID, days)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// GetRetention 视频观众留存曲线
+// @Summary 视频观众留存曲线
+// @Description 按播放进度每 10% 统计仍在观看的次数及占开始播放的比例，数据来自客户端埋点，仅作者本人可查看
+// @Tags 创作者
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoRetentionData} "获取成功"
+// @Failure 403 {object} response.ErrorResponse "无权限"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /creator/analytics/videos/{id}/retention [get]
+func (h *CreatorHandler) GetRetention(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.creatorAnalyticsService.GetRetention(c.Request.Context(), videoID, userID)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/response"
+	"vida-go/internal/api/validation"
+	"vida-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serviceErrorCodes Service 层错误到对外错误码的映射
+var serviceErrorCodes = []struct {
+	err  error
+	code string
+}{
+	{service.ErrUserNotFound, response.CodeUserNotFound},
+	{service.ErrUsernameExists, response.CodeUsernameExists},
+	{service.ErrUsernameReserved, response.CodeUsernameReserved},
+	{service.ErrUsernameInappropriate, response.CodeUsernameInappropriate},
+	{service.ErrInvalidCredential, response.CodeInvalidCredential},
+	{service.ErrUserDeleted, response.CodeUserDeleted},
+	{service.ErrUserNoPermission, response.CodeUserNoPermission},
+	{service.ErrUserSuspended, response.CodeUserSuspended},
+	{service.ErrUserMuted, response.CodeUserMuted},
+	{service.ErrVideoNotFound, response.CodeVideoNotFound},
+	{service.ErrUnsupportedFormat, response.CodeUnsupportedFormat},
+	{service.ErrInvalidFileSize, response.CodeInvalidFileSize},
+	{service.ErrDailyUploadLimit, response.CodeDailyUploadLimit},
+	{service.ErrStreamPreparing, response.CodeVideoPreparing},
+	{service.ErrVideoNoPermission, response.CodeVideoNoPermission},
+	{service.ErrNoFieldsToUpdate, response.CodeNoFieldsToUpdate},
+	{service.ErrSponsorDisclosureRequired, response.CodeSponsorDisclosureRequired},
+	{service.ErrVideoHidden, response.CodeVideoHidden},
+	{service.ErrVideoNotHideable, response.CodeVideoNotHideable},
+	{service.ErrVideoNotHidden, response.CodeVideoNotHidden},
+	{service.ErrCommentHidden, response.CodeCommentHidden},
+	{service.ErrCommentNotHidden, response.CodeCommentNotHidden},
+	{service.ErrDuplicateNotFound, response.CodeDuplicateNotFound},
+	{service.ErrDuplicateResolved, response.CodeDuplicateResolved},
+	{service.ErrInvalidDuplicateStatus, response.CodeInvalidDuplicateStatus},
+	{service.ErrVideoAccessSelf, response.CodeVideoAccessSelf},
+	{service.ErrVideoGrantLimit, response.CodeVideoGrantLimit},
+	{service.ErrVideoGrantNotFound, response.CodeVideoGrantNotFound},
+	{service.ErrShareLinkInvalid, response.CodeShareLinkInvalid},
+	{service.ErrPollNotFound, response.CodePollNotFound},
+	{service.ErrPollExists, response.CodePollExists},
+	{service.ErrPollClosed, response.CodePollClosed},
+	{service.ErrPollAlreadyVoted, response.CodePollAlreadyVoted},
+	{service.ErrPollOptionInvalid, response.CodePollOptionInvalid},
+	{service.ErrPollInvalid, response.CodePollInvalid},
+	{service.ErrPremiereDisabled, response.CodePremiereDisabled},
+	{service.ErrPremiereNotFound, response.CodePremiereNotFound},
+	{service.ErrPremiereNotAllowed, response.CodePremiereNotAllowed},
+	{service.ErrPremiereTimeInvalid, response.CodePremiereTimeInvalid},
+	{service.ErrPremiereStarted, response.CodePremiereStarted},
+	{service.ErrPremiereScheduled, response.CodePremiereScheduled},
+	{service.ErrPremiereChatClosed, response.CodePremiereChatClosed},
+	{service.ErrPremiereChatInvalid, response.CodePremiereChatInvalid},
+	{service.ErrPremiereChatRateLimit, response.CodePremiereChatRateLimit},
+	{service.ErrWalletInsufficient, response.CodeWalletInsufficient},
+	{service.ErrWalletRequestConflict, response.CodeWalletRequestConflict},
+	{service.ErrCannotTipSelf, response.CodeCannotTipSelf},
+	{service.ErrMembershipTierNotFound, response.CodeMembershipTierNotFound},
+	{service.ErrMembershipTierArchived, response.CodeMembershipTierArchived},
+	{service.ErrMembershipTierLimit, response.CodeMembershipTierLimit},
+	{service.ErrMembershipLevelTaken, response.CodeMembershipLevelTaken},
+	{service.ErrCannotJoinOwnMembership, response.CodeCannotJoinOwnMembership},
+	{service.ErrStreamMembersOnly, response.CodeVideoMembersOnly},
+	{service.ErrInvalidRole, response.CodeInvalidRole},
+	{service.ErrCannotImpersonate, response.CodeCannotImpersonate},
+	{service.ErrCommentNotFound, response.CodeCommentNotFound},
+	{service.ErrCommentNoPermission, response.CodeCommentNoPermission},
+	{service.ErrParentNotFound, response.CodeParentNotFound},
+	{service.ErrParentVideoMismatch, response.CodeParentVideoMismatch},
+	{service.ErrCannotFollowSelf, response.CodeCannotFollowSelf},
+	{service.ErrAlreadyFollowed, response.CodeAlreadyFollowed},
+	{service.ErrNotFollowed, response.CodeNotFollowed},
+	{service.ErrAlreadyFavorited, response.CodeAlreadyFavorited},
+	{service.ErrNotFavorited, response.CodeNotFavorited},
+	{service.ErrTooManyMutedVideos, response.CodeTooManyMutedVideos},
+	{service.ErrEmailRequired, response.CodeEmailRequired},
+	{service.ErrDeviceNotFound, response.CodeDeviceNotFound},
+	{service.ErrCannotMessageSelf, response.CodeCannotMessageSelf},
+	{service.ErrNotMutualFollow, response.CodeNotMutualFollow},
+	{service.ErrConversationNotFound, response.CodeConversationNotFound},
+	{service.ErrInvalidCursor, response.CodeInvalidCursor},
+	{service.ErrAnalyticsIdentityRequired, response.CodeDeviceIDRequired},
+	{service.ErrAgentUnavailable, response.CodeAgentUnavailable},
+	{service.ErrAskRateLimited, response.CodeAskRateLimited},
+	{service.ErrAskQuotaExceeded, response.CodeAskQuotaExceeded},
+	{service.ErrLegalHold, response.CodeLegalHold},
+	{service.ErrAlreadyOnLegalHold, response.CodeAlreadyOnLegalHold},
+	{service.ErrNotOnLegalHold, response.CodeNotOnLegalHold},
+	{service.ErrInvalidLegalHoldType, response.CodeInvalidLegalHoldType},
+	{service.ErrVideoRegionRestricted, response.CodeVideoRegionRestricted},
+	{service.ErrStreamAgeRestricted, response.CodeVideoAgeRestricted},
+	{service.ErrAgeRestrictionLocked, response.CodeAgeRestrictionLocked},
+	{service.ErrAlreadyAgeRestricted, response.CodeAlreadyAgeRestricted},
+	{service.ErrNotAgeRestricted, response.CodeNotAgeRestricted},
+	{service.ErrInvalidBirthDate, response.CodeInvalidBirthDate},
+	{service.ErrBirthDateLocked, response.CodeBirthDateLocked},
+	{service.ErrCannotReportSelf, response.CodeCannotReportSelf},
+	{service.ErrReportExists, response.CodeReportExists},
+	{service.ErrReportNotFound, response.CodeReportNotFound},
+	{service.ErrReportResolved, response.CodeReportResolved},
+	{service.ErrInvalidReportStatus, response.CodeInvalidReportStatus},
+	{service.ErrInvalidReportTarget, response.CodeInvalidReportTarget},
+	{service.ErrStreamProfileInvalid, response.CodeStreamProfileInvalid},
+	{service.ErrOAuthClientNotFound, response.CodeOAuthClientNotFound},
+	{service.ErrOAuthClientLimit, response.CodeOAuthClientLimit},
+	{service.ErrInvalidOAuthScope, response.CodeInvalidOAuthScope},
+	{service.ErrInvalidRedirectURI, response.CodeInvalidRedirectURI},
+	{service.ErrProfileImageReviewNotFound, response.CodeProfileImageReviewNotFound},
+	{service.ErrProfileImageReviewed, response.CodeProfileImageReviewed},
+	{service.ErrInvalidProfileImageStatus, response.CodeInvalidProfileImageStatus},
+	{service.ErrLoginEventNotFound, response.CodeLoginEventNotFound},
+	{service.ErrLoginAlreadyReported, response.CodeLoginAlreadyReported},
+}
+
+// respondServiceError 以业务错误码返回 Service 层的已知错误
+func respondServiceError(c *gin.Context, statusCode int, err error) {
+	response.FailWithCode(c, statusCode, serviceErrorCode(err), err.Error())
+}
+
+// serviceErrorCode 返回 Service 层错误对应的业务错误码，未知错误返回空串
+func serviceErrorCode(err error) string {
+	for _, m := range serviceErrorCodes {
+		if errors.Is(err, m.err) {
+			return m.code
+		}
+	}
+	return ""
+}
+
+// respondBindError 返回参数绑定失败的响应，校验错误按字段给出本地化提示
+func respondBindError(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		response.FailWithCode(c, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge, "请求体过大")
+		return
+	}
+	fields := validation.FieldErrors(err, response.Locale(c))
+	if fields == nil {
+		response.BadRequest(c, "请求参数无效: "+err.Error())
+		return
+	}
+	response.ValidationFailed(c, fields)
+}
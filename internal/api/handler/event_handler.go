@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	sseBlockTimeout = 15 * time.Second // 无事件时的心跳间隔
+	sseRetryMillis  = 3000
+	wsWriteTimeout  = 10 * time.Second
+)
+
+// Redis Stream 消息 ID 格式：毫秒时间戳-序号
+var streamIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+type EventHandler struct {
+	eventService *service.EventService
+}
+
+func NewEventHandler(eventService *service.EventService) *EventHandler {
+	return &EventHandler{eventService: eventService}
+}
+
+// Stream 当前用户的实时事件流（SSE）
+// @Summary 实时事件流（SSE）
+// @Description 以 Server-Sent Events 推送当前用户的通知、私信与上传状态事件，支持 Last-Event-ID 断线续传。浏览器原生 EventSource 无法设置请求头时可使用 access_token 查询参数传递 Token
+// @Tags 事件
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param Last-Event-ID header string false "最后收到的事件ID，用于续传"
+// @Param last_event_id query string false "同 Last-Event-ID 请求头"
+// @Param access_token query string false "访问令牌（无法设置 Authorization 头时使用）"
+// @Success 200 {string} string "事件流"
+// @Router /events/stream [get]
+func (h *EventHandler) Stream(c *gin.Context) {
+	userID, lastID, ok := h.prepare(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetryMillis)
+	c.Writer.Flush()
+
+	h.pump(c.Request.Context(), userID, lastID, func(events []dto.UserEvent) error {
+		if len(events) == 0 {
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		}
+		for _, e := range events {
+			fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+		}
+		c.Writer.Flush()
+		return nil
+	})
+}
+
+// WebSocket 当前用户的实时事件流（WebSocket）
+// @Summary 实时事件流（WebSocket）
+// @Description 以 WebSocket 推送当前用户的通知、私信与上传状态事件，每帧为一个 JSON 事件 {id, type, data}，无事件时发送 {"type":"ping"}。断线重连时通过 last_event_id 续传
+// @Tags 事件
+// @Security BearerAuth
+// @Param last_event_id query string false "最后收到的事件ID，用于续传"
+// @Param access_token query string false "访问令牌（无法设置 Authorization 头时使用）"
+// @Success 101 {string} string "切换协议"
+// @Router /events/ws [get]
+func (h *EventHandler) WebSocket(c *gin.Context) {
+	userID, lastID, ok := h.prepare(c)
+	if !ok {
+		return
+	}
+
+	// 认证基于 Token 而非 Cookie，不校验 Origin
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		// 客户端只需保持连接，读到错误即视为断开
+		go func() {
+			defer cancel()
+			var discard []byte
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+		}()
+
+		h.pump(ctx, userID, lastID, func(events []dto.UserEvent) error {
+			_ = ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if len(events) == 0 {
+				return websocket.JSON.Send(ws, gin.H{"type": "ping"})
+			}
+			for _, e := range events {
+				if err := websocket.JSON.Send(ws, e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// prepare 校验登录状态并确定续传起点，失败时已写入错误响应
+func (h *EventHandler) prepare(c *gin.Context) (int64, string, bool) {
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		response.Unauthorized(c, "请先登录")
+		return 0, "", false
+	}
+
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("last_event_id")
+	}
+	if lastID != "" && !streamIDPattern.MatchString(lastID) {
+		response.BadRequest(c, "无效的 Last-Event-ID")
+		return 0, "", false
+	}
+	if lastID == "" {
+		// 新连接只推送之后产生的事件
+		latest, err := h.eventService.LatestID(c.Request.Context(), userID)
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Error("Get latest event id failed", zap.Int64("user_id", userID), zap.Error(err))
+			response.InternalError(c, "事件流暂不可用")
+			return 0, "", false
+		}
+		lastID = latest
+	}
+	return userID, lastID, true
+}
+
+// pump 持续读取用户事件并交给 write 输出，无事件时以空切片调用用于心跳
+// ctx 结束或 write 返回错误时退出
+func (h *EventHandler) pump(ctx context.Context, userID int64, lastID string, write func([]dto.UserEvent) error) {
+	for ctx.Err() == nil {
+		events, err := h.eventService.Read(ctx, userID, lastID, sseBlockTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.FromContext(ctx).Warn("Read user events failed", zap.Int64("user_id", userID), zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		if err := write(events); err != nil {
+			return
+		}
+		if len(events) > 0 {
+			lastID = events[len(events)-1].ID
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ExploreHandler struct {
+	exploreService *service.ExploreService
+	auditService   *service.AuditService
+}
+
+func NewExploreHandler(exploreService *service.ExploreService, auditService *service.AuditService) *ExploreHandler {
+	return &ExploreHandler{exploreService: exploreService, auditService: auditService}
+}
+
+// GetExplore 发现页
+// @Summary 获取发现页
+// @Description 返回编辑推荐、热门视频、新晋创作者与各分类热门视频栏目，categories 为分类标签页。内容由后台任务定期挑选（见 explore 配置），管理员推荐位排在各栏目最前。登录可选，登录后附带点赞、关注状态
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param X-App-Key header string false "签名应用 Key（开启请求签名时必填，见 signing 配置）"
+// @Param X-Timestamp header int false "签名时间戳（Unix 秒）"
+// @Param X-Nonce header string false "签名随机串（8-64 字符，有效期内不可重复使用）"
+// @Param X-Signature header string false "请求签名"
+// @Success 200 {object} response.Response{data=dto.ExploreData} "获取成功"
+// @Failure 401 {object} response.ErrorResponse "请求签名无效"
+// @Router /explore [get]
+func (h *ExploreHandler) GetExplore(c *gin.Context) {
+	viewerID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.exploreService.GetExplore(c.Request.Context(), viewerID)
+	if err != nil {
+		handleExploreError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// CreateSlot 创建推荐位
+// @Summary 创建发现页推荐位（管理员）
+// @Description 将视频放到 featured（编辑推荐）、trending（热门）或某个分类栏目的最前，position 越小越靠前，可设置展示时间范围
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ExploreSlotCreateRequest true "栏目、视频与展示时间"
+// @Success 201 {object} response.Response{data=dto.ExploreSlotInfo} "创建成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /admin/explore/slots [post]
+func (h *ExploreHandler) CreateSlot(c *gin.Context) {
+	adminID, _ := middleware.GetCurrentUserID(c)
+
+	var req dto.ExploreSlotCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	slot, err := h.exploreService.CreateSlot(c.Request.Context(), adminID, &req)
+	if err != nil {
+		handleExploreError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionSlotCreate, service.AuditTargetExploreSlot, slot.ID, req.Note)
+
+	response.Created(c, "创建成功", slot)
+}
+
+// ListSlots 推荐位列表
+// @Summary 发现页推荐位列表（管理员）
+// @Description 包含未生效与已过期的推荐位
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.ExploreSlotListData} "获取成功"
+// @Router /admin/explore/slots [get]
+func (h *ExploreHandler) ListSlots(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	data, err := h.exploreService.ListSlots(c.Request.Context(), page, pageSize)
+	if err != nil {
+		handleExploreError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// DeleteSlot 删除推荐位
+// @Summary 删除发现页推荐位（管理员）
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "推荐位ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 404 {object} response.ErrorResponse "推荐位不存在"
+// @Router /admin/explore/slots/{id} [delete]
+func (h *ExploreHandler) DeleteSlot(c *gin.Context) {
+	slotID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的推荐位ID")
+		return
+	}
+
+	if err := h.exploreService.DeleteSlot(c.Request.Context(), slotID); err != nil {
+		handleExploreError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionSlotDelete, service.AuditTargetExploreSlot, slotID, c.Query("reason"))
+
+	response.OK(c, "删除成功", nil)
+}
+
+func handleExploreError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrExploreSlotNotFound), errors.Is(err, service.ErrVideoNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidExploreSlot):
+		respondServiceError(c, http.StatusBadRequest, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Explore operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
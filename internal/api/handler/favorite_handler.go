@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 	"strconv"
 
 	"vida-go/internal/api/dto"
@@ -24,11 +25,12 @@ func NewFavoriteHandler(favoriteService *service.FavoriteService) *FavoriteHandl
 
 // Favorite 点赞视频
 // @Summary 点赞视频
-// @Description 对指定视频点赞
+// @Description 对指定视频点赞，可指定表态类型；已用其他类型表态时改为新的类型
 // @Tags 点赞
 // @Produce json
 // @Security BearerAuth
 // @Param video_id path int true "视频ID"
+// @Param reaction query string false "表态类型" Enums(like, love, laugh, wow) default(like)
 // @Success 200 {object} response.Response "点赞成功"
 // @Failure 400 {object} response.ErrorResponse "已点赞"
 // @Failure 404 {object} response.ErrorResponse "视频不存在"
@@ -40,19 +42,26 @@ func (h *FavoriteHandler) Favorite(c *gin.Context) {
 		return
 	}
 
+	var query dto.ReactionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
 	userID, _ := middleware.GetCurrentUserID(c)
 
-	info, totalFav, err := h.favoriteService.Favorite(userID, videoID)
+	info, totalFav, err := h.favoriteService.Favorite(c.Request.Context(), userID, videoID, query.Reaction)
 	if err != nil {
 		handleFavoriteError(c, err)
 		return
 	}
 
 	response.OK(c, "点赞成功", gin.H{
-		"favorite_id":    info.ID,
-		"user_id":        info.UserID,
-		"video_id":       info.VideoID,
-		"created_at":     info.CreatedAt,
+		"favorite_id":     info.ID,
+		"user_id":         info.UserID,
+		"video_id":        info.VideoID,
+		"reaction":        info.Reaction,
+		"created_at":      info.CreatedAt,
 		"total_favorites": totalFav,
 	})
 }
@@ -76,27 +85,27 @@ func (h *FavoriteHandler) Unfavorite(c *gin.Context) {
 
 	userID, _ := middleware.GetCurrentUserID(c)
 
-	totalFav, err := h.favoriteService.Unfavorite(userID, videoID)
+	totalFav, err := h.favoriteService.Unfavorite(c.Request.Context(), userID, videoID)
 	if err != nil {
 		handleFavoriteError(c, err)
 		return
 	}
 
 	response.OK(c, "取消点赞成功", gin.H{
-		"user_id":        userID,
-		"video_id":       videoID,
+		"user_id":         userID,
+		"video_id":        videoID,
 		"total_favorites": totalFav,
 	})
 }
 
 // GetStatus 获取点赞状态
 // @Summary 获取点赞状态
-// @Description 查询是否点赞了指定视频
+// @Description 查询是否点赞了指定视频、表态类型及视频各类型表态数
 // @Tags 点赞
 // @Produce json
 // @Security BearerAuth
 // @Param video_id path int true "视频ID"
-// @Success 200 {object} response.Response "查询成功"
+// @Success 200 {object} response.Response{data=dto.FavoriteStatus} "查询成功"
 // @Router /favorites/{video_id}/status [get]
 func (h *FavoriteHandler) GetStatus(c *gin.Context) {
 	videoID, err := strconv.ParseInt(c.Param("video_id"), 10, 64)
@@ -107,17 +116,13 @@ func (h *FavoriteHandler) GetStatus(c *gin.Context) {
 
 	userID, _ := middleware.GetCurrentUserID(c)
 
-	isFav, total, err := h.favoriteService.GetStatus(userID, videoID)
+	status, err := h.favoriteService.GetStatus(c.Request.Context(), userID, videoID)
 	if err != nil {
 		handleFavoriteError(c, err)
 		return
 	}
 
-	response.OK(c, "查询点赞状态成功", gin.H{
-		"is_favorited":   isFav,
-		"video_id":       videoID,
-		"total_favorites": total,
-	})
+	response.OK(c, "查询点赞状态成功", status)
 }
 
 // ListMyFavorites 获取我的点赞列表
@@ -134,9 +139,9 @@ func (h *FavoriteHandler) ListMyFavorites(c *gin.Context) {
 	userID, _ := middleware.GetCurrentUserID(c)
 	page, pageSize := parsePagination(c)
 
-	data, err := h.favoriteService.ListByUser(userID, page, pageSize)
+	data, err := h.favoriteService.ListByUser(c.Request.Context(), userID, page, pageSize)
 	if err != nil {
-		logger.Error("Get my favorites failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Get my favorites failed", zap.Error(err))
 		response.InternalError(c, "获取我的点赞列表失败")
 		return
 	}
@@ -164,7 +169,7 @@ func (h *FavoriteHandler) ListVideoFavorites(c *gin.Context) {
 
 	page, pageSize := parsePagination(c)
 
-	data, err := h.favoriteService.ListByVideo(videoID, page, pageSize)
+	data, err := h.favoriteService.ListByVideo(c.Request.Context(), videoID, page, pageSize)
 	if err != nil {
 		handleFavoriteError(c, err)
 		return
@@ -188,13 +193,13 @@ func (h *FavoriteHandler) BatchStatus(c *gin.Context) {
 
 	var req dto.BatchFavoriteStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
-	statusMap, err := h.favoriteService.BatchCheckStatus(userID, req.VideoIDs)
+	statusMap, err := h.favoriteService.BatchCheckStatus(c.Request.Context(), userID, req.VideoIDs)
 	if err != nil {
-		logger.Error("Batch favorite status failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Batch favorite status failed", zap.Error(err))
 		response.InternalError(c, "批量查询点赞状态失败")
 		return
 	}
@@ -218,9 +223,9 @@ func (h *FavoriteHandler) GetMyFavoritedVideos(c *gin.Context) {
 	userID, _ := middleware.GetCurrentUserID(c)
 	page, pageSize := parsePagination(c)
 
-	data, err := h.favoriteService.GetFavoritedVideos(userID, page, pageSize)
+	data, err := h.favoriteService.GetFavoritedVideos(c.Request.Context(), userID, page, pageSize)
 	if err != nil {
-		logger.Error("Get my favorited videos failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Get my favorited videos failed", zap.Error(err))
 		response.InternalError(c, "获取点赞视频列表失败")
 		return
 	}
@@ -231,13 +236,13 @@ func (h *FavoriteHandler) GetMyFavoritedVideos(c *gin.Context) {
 func handleFavoriteError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrAlreadyFavorited):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusBadRequest, err)
 	case errors.Is(err, service.ErrNotFavorited):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusBadRequest, err)
 	case errors.Is(err, service.ErrVideoNotFound):
-		response.NotFound(c, err.Error())
+		respondServiceError(c, http.StatusNotFound, err)
 	default:
-		logger.Error("Favorite operation failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Favorite operation failed", zap.Error(err))
 		response.InternalError(c, "操作失败，请稍后重试")
 	}
 }
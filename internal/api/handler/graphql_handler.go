@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/graph"
+	"vida-go/pkg/i18n"
+	"vida-go/pkg/logger"
+
+	"github.com/99designs/gqlgen/graphql"
+	gqlhandler "github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+)
+
+// graphQLComplexityLimit 单次查询的复杂度上限，防止过深或过宽的嵌套查询
+const graphQLComplexityLimit = 500
+
+type localeKey struct{}
+
+type GraphQLHandler struct {
+	resolver *graph.Resolver
+	server   *gqlhandler.Server
+}
+
+// NewGraphQLHandler introspection 为 true 时允许 Schema 内省（仅建议在开发环境开启）
+func NewGraphQLHandler(resolver *graph.Resolver, introspection bool) *GraphQLHandler {
+	server := gqlhandler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.Use(extension.FixedComplexityLimit(graphQLComplexityLimit))
+	if introspection {
+		server.Use(extension.Introspection{})
+	}
+	server.SetErrorPresenter(presentGraphQLError)
+	server.SetRecoverFunc(func(ctx context.Context, err any) error {
+		logger.FromContext(ctx).Error("Panic recovered", zap.Any("error", err), zap.String("path", "graphql"))
+		return errors.New("操作失败，请稍后重试")
+	})
+
+	return &GraphQLHandler{resolver: resolver, server: server}
+}
+
+// Query GraphQL 查询入口
+// @Summary GraphQL 查询
+// @Description 一次请求获取视频、作者、热门评论与当前用户状态，Schema 见 internal/graph/schema.graphqls。未登录时 viewerState、isFollowing、me 返回 null
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "GraphQL 响应"
+// @Router /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	ctx := context.WithValue(c.Request.Context(), localeKey{}, response.Locale(c))
+	if userID, ok := middleware.GetCurrentUserID(c); ok {
+		ctx = graph.WithViewer(ctx, userID)
+	}
+	ctx = h.resolver.WithLoaders(ctx)
+	h.server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
+
+// presentGraphQLError 与 REST 接口一致：已知业务错误返回错误码与本地化信息，未知错误不暴露细节
+func presentGraphQLError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	lang, _ := ctx.Value(localeKey{}).(string)
+
+	// 查询解析、校验等框架错误没有包装的原始错误，原样返回
+	cause := gqlErr.Unwrap()
+	if cause == nil {
+		return gqlErr
+	}
+
+	code := ""
+	if errors.Is(cause, graph.ErrTooManyIDs) {
+		code = response.CodeBadRequest
+	}
+	for _, m := range serviceErrorCodes {
+		if errors.Is(cause, m.err) {
+			code = m.code
+			break
+		}
+	}
+	if code == "" {
+		logger.FromContext(ctx).Error("GraphQL resolver failed", zap.Error(cause), zap.String("path", gqlErr.Path.String()))
+		code = response.CodeInternalError
+		gqlErr.Message = "操作失败，请稍后重试"
+	}
+
+	gqlErr.Message = i18n.T(lang, gqlErr.Message)
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["code"] = code
+	return gqlErr
+}
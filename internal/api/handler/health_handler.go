@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HealthHandler struct {
+	healthService *service.HealthService
+}
+
+func NewHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// Livez 存活探针
+// @Summary 存活探针
+// @Description 进程存活即返回 200，不检查外部依赖
+// @Tags 健康检查
+// @Produce json
+// @Success 200 {object} map[string]string "存活"
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "ok",
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// Readyz 就绪探针
+// @Summary 就绪探针
+// @Description 检查 Postgres、Redis、MinIO、Kafka、ES 连通性；关键依赖不可用返回 503，非关键依赖不可用返回 200 并标记降级
+// @Tags 健康检查
+// @Produce json
+// @Success 200 {object} dto.ReadinessData "就绪（可能降级）"
+// @Failure 503 {object} dto.ReadinessData "未就绪"
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	data := h.healthService.Readiness(c.Request.Context())
+
+	statusCode := http.StatusOK
+	if data.Status == service.ReadinessNotReady {
+		statusCode = http.StatusServiceUnavailable
+		logger.FromContext(c.Request.Context()).Warn("Readiness check failed", zap.Any("dependencies", data.Dependencies))
+	}
+
+	c.JSON(statusCode, data)
+}
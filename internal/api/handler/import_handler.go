@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ImportHandler struct {
+	importService *service.ImportService
+	auditService  *service.AuditService
+}
+
+func NewImportHandler(importService *service.ImportService, auditService *service.AuditService) *ImportHandler {
+	return &ImportHandler{importService: importService, auditService: auditService}
+}
+
+// ImportRelations 批量导入关注关系
+// @Summary 批量导入关注关系（管理员）
+// @Description 从其他平台迁移关注数据，单次最多 5000 条；已存在的关系、关注自己或用户不存在的记录跳过，导入后重新计算相关用户的关注数、粉丝数，不发送通知
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.RelationImportRequest true "关注关系列表"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response{data=dto.ImportResult} "导入成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Router /admin/import/relations [post]
+func (h *ImportHandler) ImportRelations(c *gin.Context) {
+	var req dto.RelationImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.importService.ImportRelations(c.Request.Context(), req.Relations)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Import relations failed", zap.Error(err))
+		response.InternalError(c, "导入关注关系失败")
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionRelationImport, service.AuditTargetRelation, 0, c.Query("reason"))
+
+	response.OK(c, "导入成功", result)
+}
+
+// ImportFavorites 批量导入点赞记录
+// @Summary 批量导入点赞记录（管理员）
+// @Description 从其他平台迁移点赞数据，单次最多 5000 条；已存在的记录、用户或视频不存在的记录跳过，导入后重新计算相关视频的点赞数与作者获赞数，不发送通知
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.FavoriteImportRequest true "点赞记录列表"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response{data=dto.ImportResult} "导入成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Router /admin/import/favorites [post]
+func (h *ImportHandler) ImportFavorites(c *gin.Context) {
+	var req dto.FavoriteImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.importService.ImportFavorites(c.Request.Context(), req.Favorites)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Import favorites failed", zap.Error(err))
+		response.InternalError(c, "导入点赞记录失败")
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionFavoriteImport, service.AuditTargetFavorite, 0, c.Query("reason"))
+
+	response.OK(c, "导入成功", result)
+}
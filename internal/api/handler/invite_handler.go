@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/repository"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type InviteHandler struct {
+	inviteService *service.InviteService
+	auditService  *service.AuditService
+}
+
+func NewInviteHandler(inviteService *service.InviteService, auditService *service.AuditService) *InviteHandler {
+	return &InviteHandler{inviteService: inviteService, auditService: auditService}
+}
+
+// CreateCodes 生成邀请码
+// @Summary 生成邀请码（管理员）
+// @Description 批量生成注册邀请码。开启 registration.invite_only 后必须持有效邀请码才能注册；使用该码注册的用户记为由 inviter_id 邀请
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.InviteCodeCreateRequest true "生成数量、使用次数、有效期、邀请人"
+// @Success 201 {object} response.Response{data=[]dto.InviteCodeInfo} "生成成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 404 {object} response.ErrorResponse "邀请人不存在"
+// @Router /admin/invite-codes [post]
+func (h *InviteHandler) CreateCodes(c *gin.Context) {
+	adminID, _ := middleware.GetCurrentUserID(c)
+
+	var req dto.InviteCodeCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	codes, err := h.inviteService.CreateCodes(c.Request.Context(), adminID, &req)
+	if err != nil {
+		handleInviteError(c, err)
+		return
+	}
+	for i := range codes {
+		recordAudit(c, h.auditService, service.AuditActionInviteCreate, service.AuditTargetInviteCode, codes[i].ID, req.Note)
+	}
+
+	response.Created(c, "生成成功", codes)
+}
+
+// ListCodes 邀请码列表
+// @Summary 邀请码列表（管理员）
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "状态：active/used_up/expired/revoked，为空返回全部"
+// @Param inviter_id query int false "邀请人ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.InviteCodeListData} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "无效的状态"
+// @Router /admin/invite-codes [get]
+func (h *InviteHandler) ListCodes(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+	filter := &repository.InviteCodeFilter{Status: c.Query("status")}
+	if v := c.Query("inviter_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "无效的用户ID")
+			return
+		}
+		filter.InviterID = id
+	}
+
+	data, err := h.inviteService.ListCodes(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		handleInviteError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// RevokeCode 作废邀请码
+// @Summary 作废邀请码（管理员）
+// @Description 作废后该码不能再用于注册，已注册的用户不受影响
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "邀请码ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "作废成功"
+// @Failure 404 {object} response.ErrorResponse "邀请码不存在"
+// @Failure 409 {object} response.ErrorResponse "邀请码已作废"
+// @Router /admin/invite-codes/{id} [delete]
+func (h *InviteHandler) RevokeCode(c *gin.Context) {
+	codeID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的邀请码ID")
+		return
+	}
+
+	if err := h.inviteService.RevokeCode(c.Request.Context(), codeID); err != nil {
+		handleInviteError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionInviteRevoke, service.AuditTargetInviteCode, codeID, c.Query("reason"))
+
+	response.OK(c, "作废成功", nil)
+}
+
+// ListInvitees 被邀请用户列表
+// @Summary 某用户邀请注册的用户（管理员）
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "邀请人ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.InviteeListData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /admin/users/{id}/invitees [get]
+func (h *InviteHandler) ListInvitees(c *gin.Context) {
+	inviterID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+	page, pageSize := parsePagination(c)
+
+	data, err := h.inviteService.ListInvitees(c.Request.Context(), inviterID, page, pageSize)
+	if err != nil {
+		handleInviteError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+func handleInviteError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInviteCodeNotFound), errors.Is(err, service.ErrInviterNotFound),
+		errors.Is(err, service.ErrUserNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidInviteStatus):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrInviteCodeRevoked):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Invite code operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type JobHandler struct {
+	jobService *service.JobService
+}
+
+func NewJobHandler(jobService *service.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+// ListJobs 定时任务运行状态
+// @Summary 定时任务运行状态（管理员）
+// @Description 返回本实例启用的定时任务、调度表达式与下一次执行时间，以及集群中是否正在执行、最近一次执行的实例、结果、耗时与错误信息
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.JobListData} "获取成功"
+// @Router /admin/jobs [get]
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	data, err := h.jobService.List(c.Request.Context())
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("List scheduled jobs failed", zap.Error(err))
+		response.InternalError(c, "获取定时任务状态失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
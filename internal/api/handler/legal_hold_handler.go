@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type LegalHoldHandler struct {
+	legalHoldService *service.LegalHoldService
+	auditService     *service.AuditService
+}
+
+func NewLegalHoldHandler(legalHoldService *service.LegalHoldService, auditService *service.AuditService) *LegalHoldHandler {
+	return &LegalHoldHandler{legalHoldService: legalHoldService, auditService: auditService}
+}
+
+// HoldVideo 保全视频
+// @Summary 法律保全视频（管理员）
+// @Description 为合规调查冻结视频：内容保留，不出现在详情、视频流和搜索中，作者不能修改或删除，直到解除保全
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.LegalHoldRequest true "保全原因"
+// @Success 200 {object} response.Response{data=dto.LegalHoldInfo} "保全成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 409 {object} response.ErrorResponse "已处于法律保全中"
+// @Router /admin/videos/{id}/legal-hold [post]
+func (h *LegalHoldHandler) HoldVideo(c *gin.Context) {
+	h.hold(c, h.legalHoldService.HoldVideo, service.AuditActionVideoLegalHold, service.AuditTargetVideo, "无效的视频ID")
+}
+
+// ReleaseVideo 解除视频保全
+// @Summary 解除视频法律保全（管理员）
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "解除成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 409 {object} response.ErrorResponse "未处于法律保全中"
+// @Router /admin/videos/{id}/legal-hold [delete]
+func (h *LegalHoldHandler) ReleaseVideo(c *gin.Context) {
+	h.release(c, h.legalHoldService.ReleaseVideo, service.AuditActionVideoHoldRelease, service.AuditTargetVideo, "无效的视频ID")
+}
+
+// HoldUser 保全账号
+// @Summary 法律保全账号（管理员）
+// @Description 为合规调查冻结账号：主页对外隐藏，账号及其视频、评论不能修改或删除，直到解除保全
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param request body dto.LegalHoldRequest true "保全原因"
+// @Success 200 {object} response.Response{data=dto.LegalHoldInfo} "保全成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Failure 409 {object} response.ErrorResponse "已处于法律保全中"
+// @Router /admin/users/{id}/legal-hold [post]
+func (h *LegalHoldHandler) HoldUser(c *gin.Context) {
+	h.hold(c, h.legalHoldService.HoldUser, service.AuditActionUserLegalHold, service.AuditTargetUser, "无效的用户ID")
+}
+
+// ReleaseUser 解除账号保全
+// @Summary 解除账号法律保全（管理员）
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "解除成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Failure 409 {object} response.ErrorResponse "未处于法律保全中"
+// @Router /admin/users/{id}/legal-hold [delete]
+func (h *LegalHoldHandler) ReleaseUser(c *gin.Context) {
+	h.release(c, h.legalHoldService.ReleaseUser, service.AuditActionUserHoldRelease, service.AuditTargetUser, "无效的用户ID")
+}
+
+// ListLegalHolds 法律保全列表
+// @Summary 法律保全列表（管理员）
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "保全对象类型：video/user" default(video)
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.LegalHoldListData} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "无效的保全对象类型"
+// @Router /admin/legal-holds [get]
+func (h *LegalHoldHandler) ListLegalHolds(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	data, err := h.legalHoldService.ListLegalHolds(c.Request.Context(), c.DefaultQuery("type", service.LegalHoldTargetVideo), page, pageSize)
+	if err != nil {
+		handleLegalHoldError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+type holdFunc func(ctx context.Context, id int64, reason string) (*dto.LegalHoldInfo, error)
+
+func (h *LegalHoldHandler) hold(c *gin.Context, hold holdFunc, auditAction, targetType, invalidIDMsg string) {
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, invalidIDMsg)
+		return
+	}
+
+	var req dto.LegalHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	info, err := hold(c.Request.Context(), targetID, req.Reason)
+	if err != nil {
+		handleLegalHoldError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, auditAction, targetType, targetID, req.Reason)
+
+	response.OK(c, "保全成功", info)
+}
+
+func (h *LegalHoldHandler) release(c *gin.Context, release moderateFunc, auditAction, targetType, invalidIDMsg string) {
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, invalidIDMsg)
+		return
+	}
+
+	if err := release(c.Request.Context(), targetID); err != nil {
+		handleLegalHoldError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, auditAction, targetType, targetID, c.Query("reason"))
+
+	response.OK(c, "解除成功", nil)
+}
+
+func handleLegalHoldError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVideoNotFound), errors.Is(err, service.ErrUserNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidLegalHoldType):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrAlreadyOnLegalHold), errors.Is(err, service.ErrNotOnLegalHold):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Legal hold operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
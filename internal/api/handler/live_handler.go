@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/config"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type LiveHandler struct {
+	liveService *service.LiveService
+}
+
+func NewLiveHandler(liveService *service.LiveService) *LiveHandler {
+	return &LiveHandler{liveService: liveService}
+}
+
+// Feed 直播列表
+// @Summary 正在直播列表
+// @Description 按开播时间倒序返回正在直播的场次
+// @Tags 直播
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.LiveSessionListData} "获取成功"
+// @Router /live/feed [get]
+func (h *LiveHandler) Feed(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	data, err := h.liveService.Feed(c.Request.Context(), page, pageSize)
+	if err != nil {
+		handleLiveError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// GetSession 直播详情
+// @Summary 直播场次详情
+// @Description 直播中返回播放地址；已结束的场次在录像转码后返回 video_id
+// @Tags 直播
+// @Produce json
+// @Param id path int true "场次ID"
+// @Success 200 {object} response.Response{data=dto.LiveSessionInfo} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "直播不存在"
+// @Router /live/sessions/{id} [get]
+func (h *LiveHandler) GetSession(c *gin.Context) {
+	sessionID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的直播ID")
+		return
+	}
+
+	info, err := h.liveService.GetSession(c.Request.Context(), sessionID)
+	if err != nil {
+		handleLiveError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", info)
+}
+
+// GetChannel 我的直播频道
+// @Summary 获取我的直播频道
+// @Description 首次获取时创建频道并签发推流码。推流码仅本人可见，泄露后请重置；管理员代登录时不返回推流码
+// @Tags 直播
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.LiveChannelInfo} "获取成功"
+// @Router /live/channel [get]
+func (h *LiveHandler) GetChannel(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	info, err := h.liveService.GetChannel(c.Request.Context(), userID)
+	if err != nil {
+		handleLiveError(c, err)
+		return
+	}
+	withholdStreamKey(c, info)
+
+	response.OK(c, "获取成功", info)
+}
+
+// UpdateChannel 更新直播频道
+// @Summary 修改直播标题
+// @Description 下次开播时生效
+// @Tags 直播
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.LiveChannelUpdateRequest true "直播标题"
+// @Success 200 {object} response.Response{data=dto.LiveChannelInfo} "更新成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Router /live/channel [put]
+func (h *LiveHandler) UpdateChannel(c *gin.Context) {
+	var req dto.LiveChannelUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	info, err := h.liveService.UpdateChannel(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleLiveError(c, err)
+		return
+	}
+	withholdStreamKey(c, info)
+
+	response.OK(c, "更新成功", info)
+}
+
+// RotateStreamKey 重置推流码
+// @Summary 重置推流码
+// @Description 旧推流码立即失效，正在进行的直播不受影响
+// @Tags 直播
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.LiveChannelInfo} "重置成功"
+// @Router /live/channel/stream-key [post]
+func (h *LiveHandler) RotateStreamKey(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	info, err := h.liveService.RotateStreamKey(c.Request.Context(), userID)
+	if err != nil {
+		handleLiveError(c, err)
+		return
+	}
+
+	response.OK(c, "重置成功", info)
+}
+
+// OnPublish 媒体服务器开播回调
+// @Summary 开播回调（媒体服务器）
+// @Description 兼容 nginx-rtmp on_publish（表单）与 SRS on_publish（JSON）。返回非 2xx 时媒体服务器拒绝推流
+// @Tags 直播
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param X-Live-Secret header string false "回调密钥（也可通过 secret 查询参数传递）"
+// @Param request body dto.LiveCallbackRequest true "回调参数"
+// @Success 200 {object} map[string]int "{\"code\": 0}"
+// @Failure 403 {object} response.ErrorResponse "推流码无效或主播被封禁、禁言"
+// @Router /live/callbacks/publish [post]
+func (h *LiveHandler) OnPublish(c *gin.Context) {
+	h.callback(c, h.liveService.OnPublish)
+}
+
+// OnUnpublish 媒体服务器下播回调
+// @Summary 下播回调（媒体服务器）
+// @Description 兼容 nginx-rtmp on_publish_done 与 SRS on_unpublish
+// @Tags 直播
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param X-Live-Secret header string false "回调密钥（也可通过 secret 查询参数传递）"
+// @Param request body dto.LiveCallbackRequest true "回调参数"
+// @Success 200 {object} map[string]int "{\"code\": 0}"
+// @Router /live/callbacks/unpublish [post]
+func (h *LiveHandler) OnUnpublish(c *gin.Context) {
+	h.callback(c, h.liveService.OnUnpublish)
+}
+
+// OnRecord 媒体服务器录制完成回调
+// @Summary 录制完成回调（媒体服务器）
+// @Description 兼容 nginx-rtmp on_record_done（path）与 SRS on_dvr（file），录像需已上传到 raw-videos，路径为对象名。
+// @Description 开启 auto_vod 时将录像创建为视频并投递转码
+// @Tags 直播
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param X-Live-Secret header string false "回调密钥（也可通过 secret 查询参数传递）"
+// @Param request body dto.LiveCallbackRequest true "回调参数"
+// @Success 200 {object} map[string]int "{\"code\": 0}"
+// @Router /live/callbacks/record [post]
+func (h *LiveHandler) OnRecord(c *gin.Context) {
+	h.callback(c, h.liveService.OnRecord)
+}
+
+func (h *LiveHandler) callback(c *gin.Context, action func(ctx context.Context, req *dto.LiveCallbackRequest) error) {
+	if !liveCallbackAuthorized(c) {
+		response.Forbidden(c, "回调密钥无效")
+		return
+	}
+
+	var req dto.LiveCallbackRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if err := action(c.Request.Context(), &req); err != nil {
+		handleLiveError(c, err)
+		return
+	}
+
+	// SRS 要求回调返回 code 为 0，nginx-rtmp 只看状态码
+	c.JSON(http.StatusOK, gin.H{"code": 0})
+}
+
+// liveCallbackAuthorized 校验回调共享密钥，未配置密钥时拒绝所有回调
+func liveCallbackAuthorized(c *gin.Context) bool {
+	secret := config.GetLive().CallbackSecret
+	if secret == "" {
+		return false
+	}
+	got := c.GetHeader("X-Live-Secret")
+	if got == "" {
+		got = c.Query("secret")
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(secret)) == 1
+}
+
+func handleLiveError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrLiveSessionNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidStreamKey), errors.Is(err, service.ErrUserSuspended),
+		errors.Is(err, service.ErrUserMuted):
+		respondServiceError(c, http.StatusForbidden, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Live operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
+
+// withholdStreamKey 管理员代登录时不下发推流码（SRT 地址中也含推流码），避免被用于冒名开播
+func withholdStreamKey(c *gin.Context, info *dto.LiveChannelInfo) {
+	if _, ok := middleware.GetImpersonatorID(c); ok {
+		info.StreamKey = ""
+		info.SRTURL = ""
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type LoginHistoryHandler struct {
+	loginHistoryService *service.LoginHistoryService
+}
+
+func NewLoginHistoryHandler(loginHistoryService *service.LoginHistoryService) *LoginHistoryHandler {
+	return &LoginHistoryHandler{loginHistoryService: loginHistoryService}
+}
+
+// ListMyLogins 我的登录记录
+// @Summary 我的登录记录
+// @Description 最近的登录记录（IP、设备、归属地），new_device、new_location 表示首次在该设备或国家/地区登录，此类登录会收到系统通知
+// @Tags 用户
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.LoginEventListData} "获取成功"
+// @Router /users/me/logins [get]
+func (h *LoginHistoryHandler) ListMyLogins(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	page, pageSize := parsePagination(c)
+
+	data, err := h.loginHistoryService.ListMyLogins(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("List login events failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "获取登录记录失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// ReportLogin 标记非本人登录
+// @Summary 标记非本人登录
+// @Description 将某次登录标记为非本人操作，账号将强制修改密码：修改前无法再登录，请在当前设备上通过 PUT /auth/password 修改
+// @Tags 用户
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "登录记录ID"
+// @Success 200 {object} response.Response "已标记"
+// @Failure 404 {object} response.ErrorResponse "登录记录不存在"
+// @Failure 409 {object} response.ErrorResponse "已标记过"
+// @Router /users/me/logins/{id}/report [post]
+func (h *LoginHistoryHandler) ReportLogin(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	eventID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的登录记录ID")
+		return
+	}
+
+	if err := h.loginHistoryService.ReportLogin(c.Request.Context(), userID, eventID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrLoginEventNotFound):
+			respondServiceError(c, http.StatusNotFound, err)
+		case errors.Is(err, service.ErrLoginAlreadyReported):
+			respondServiceError(c, http.StatusConflict, err)
+		default:
+			logger.FromContext(c.Request.Context()).Error("Report login failed", zap.Int64("user_id", userID), zap.Error(err))
+			response.InternalError(c, "操作失败，请稍后重试")
+		}
+		return
+	}
+
+	response.OK(c, "已标记，请立即修改密码", nil)
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MembershipHandler struct {
+	membershipService *service.MembershipService
+}
+
+func NewMembershipHandler(membershipService *service.MembershipService) *MembershipHandler {
+	return &MembershipHandler{membershipService: membershipService}
+}
+
+// ListTiers 创作者的会员等级
+// @Summary 获取创作者的会员等级
+// @Description 按等级从低到高返回上架中的会员等级，创作者本人查看时包含已下架的等级；登录用户是该创作者的会员时同时返回其会员资格
+// @Tags 会员
+// @Produce json
+// @Param id path int true "创作者用户ID"
+// @Success 200 {object} response.Response{data=dto.MembershipTierListData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/membership-tiers [get]
+func (h *MembershipHandler) ListTiers(c *gin.Context) {
+	creatorID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	viewerID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.membershipService.ListTiers(c.Request.Context(), creatorID, viewerID)
+	if err != nil {
+		handleMembershipError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// CreateTier 创建会员等级
+// @Summary 创建会员等级
+// @Description 创作者新增付费会员等级，上架中的等级数量有上限且 level 不能重复。会员可观看所需等级不高于自身等级的会员专属视频
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MembershipTierCreateRequest true "会员等级"
+// @Success 200 {object} response.Response{data=dto.MembershipTierInfo} "创建成功"
+// @Failure 409 {object} response.ErrorResponse "等级数量已达上限或等级重复"
+// @Router /memberships/tiers [post]
+func (h *MembershipHandler) CreateTier(c *gin.Context) {
+	var req dto.MembershipTierCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.membershipService.CreateTier(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleMembershipError(c, err)
+		return
+	}
+	response.OK(c, "创建成功", data)
+}
+
+// UpdateTier 更新会员等级
+// @Summary 更新会员等级
+// @Description 修改名称、说明、价格与徽章，已下架的等级不能修改
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会员等级ID"
+// @Param request body dto.MembershipTierUpdateRequest true "更新内容"
+// @Success 200 {object} response.Response{data=dto.MembershipTierInfo} "更新成功"
+// @Failure 404 {object} response.ErrorResponse "会员等级不存在"
+// @Failure 409 {object} response.ErrorResponse "会员等级已下架"
+// @Router /memberships/tiers/{id} [put]
+func (h *MembershipHandler) UpdateTier(c *gin.Context) {
+	tierID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的会员等级ID")
+		return
+	}
+	var req dto.MembershipTierUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.membershipService.UpdateTier(c.Request.Context(), userID, tierID, &req)
+	if err != nil {
+		handleMembershipError(c, err)
+		return
+	}
+	response.OK(c, "更新成功", data)
+}
+
+// ArchiveTier 下架会员等级
+// @Summary 下架会员等级
+// @Description 下架后不能再加入或续费，已有会员到期前不受影响
+// @Tags 会员
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会员等级ID"
+// @Success 200 {object} response.Response "下架成功"
+// @Failure 404 {object} response.ErrorResponse "会员等级不存在"
+// @Failure 409 {object} response.ErrorResponse "会员等级已下架"
+// @Router /memberships/tiers/{id} [delete]
+func (h *MembershipHandler) ArchiveTier(c *gin.Context) {
+	tierID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的会员等级ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	if err := h.membershipService.ArchiveTier(c.Request.Context(), userID, tierID); err != nil {
+		handleMembershipError(c, err)
+		return
+	}
+	response.OK(c, "下架成功", nil)
+}
+
+// Join 加入或续费会员
+// @Summary 加入或续费会员
+// @Description 从金币余额扣除一期的价格记入创作者钱包。续费同一等级从当前到期时间顺延一期；换到其他等级时从现在起算，原等级剩余时长不退还。
+// @Description request_id 由客户端生成，重试时保持不变，同一 request_id 只会扣款一次
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会员等级ID"
+// @Param request body dto.MembershipJoinRequest true "幂等键"
+// @Success 200 {object} response.Response{data=dto.MembershipInfo} "加入成功"
+// @Failure 400 {object} response.ErrorResponse "不能加入自己的会员"
+// @Failure 404 {object} response.ErrorResponse "会员等级不存在"
+// @Failure 409 {object} response.ErrorResponse "会员等级已下架、金币余额不足或 request_id 已用于其他交易"
+// @Router /memberships/tiers/{id}/join [post]
+func (h *MembershipHandler) Join(c *gin.Context) {
+	tierID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的会员等级ID")
+		return
+	}
+	var req dto.MembershipJoinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.membershipService.Join(c.Request.Context(), userID, tierID, req.RequestID)
+	if err != nil {
+		handleMembershipError(c, err)
+		return
+	}
+	response.OK(c, "加入成功", data)
+}
+
+// ListMine 我的会员资格
+// @Summary 获取我的会员资格
+// @Description 按到期时间正序返回当前用户的有效会员资格
+// @Tags 会员
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.MembershipListData} "获取成功"
+// @Router /memberships/me [get]
+func (h *MembershipHandler) ListMine(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.membershipService.ListMine(c.Request.Context(), userID)
+	if err != nil {
+		handleMembershipError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// ListMembers 我的会员
+// @Summary 获取我的会员列表
+// @Description 创作者按加入时间倒序查看自己的有效会员，可按会员等级筛选
+// @Tags 会员
+// @Produce json
+// @Security BearerAuth
+// @Param tier_id query int false "会员等级ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.MemberListData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "会员等级不存在"
+// @Router /memberships/members [get]
+func (h *MembershipHandler) ListMembers(c *gin.Context) {
+	var tierID *int64
+	if v := c.Query("tier_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "无效的会员等级ID")
+			return
+		}
+		tierID = &id
+	}
+	page, pageSize := parsePagination(c)
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.membershipService.ListMembers(c.Request.Context(), userID, tierID, page, pageSize)
+	if err != nil {
+		handleMembershipError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+func handleMembershipError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCannotJoinOwnMembership):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrMembershipTierNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrMembershipTierArchived), errors.Is(err, service.ErrMembershipTierLimit),
+		errors.Is(err, service.ErrMembershipLevelTaken):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		handleWalletError(c, err)
+	}
+}
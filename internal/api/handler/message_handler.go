@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MessageHandler struct {
+	messageService *service.MessageService
+}
+
+func NewMessageHandler(messageService *service.MessageService) *MessageHandler {
+	return &MessageHandler{messageService: messageService}
+}
+
+// Send 发送私信
+// @Summary 发送私信
+// @Description 向互相关注的用户发送私信，敏感词会被替换为 *。接收方通过 /events/stream 或 /events/ws 实时收到 message 事件
+// @Tags 私信
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "接收用户ID"
+// @Param request body dto.MessageSendRequest true "消息内容"
+// @Success 201 {object} response.Response{data=dto.MessageInfo} "发送成功"
+// @Failure 400 {object} response.ErrorResponse "不能给自己发私信"
+// @Failure 403 {object} response.ErrorResponse "未互相关注或已被禁言"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /messages/users/{id} [post]
+func (h *MessageHandler) Send(c *gin.Context) {
+	recipientID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	var req dto.MessageSendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	info, err := h.messageService.Send(c.Request.Context(), userID, recipientID, req.Content)
+	if err != nil {
+		handleMessageError(c, err)
+		return
+	}
+
+	response.Created(c, "发送成功", info)
+}
+
+// ListConversations 获取会话列表
+// @Summary 获取私信会话列表
+// @Description 按最后消息时间倒序返回会话，包含对方信息、最后一条消息摘要与未读数
+// @Tags 私信
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.ConversationListData} "获取成功"
+// @Router /messages/conversations [get]
+func (h *MessageHandler) ListConversations(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	page, pageSize := parsePagination(c)
+
+	data, err := h.messageService.ListConversations(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		handleMessageError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// ListMessages 获取会话消息
+// @Summary 获取会话消息
+// @Description 按时间倒序返回会话中的消息，使用 before_id 加载更早的消息
+// @Tags 私信
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会话ID"
+// @Param before_id query int false "只返回 ID 小于该值的消息"
+// @Param limit query int false "每次数量" default(20)
+// @Success 200 {object} response.Response{data=dto.MessageListData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "会话不存在"
+// @Router /messages/conversations/{id} [get]
+func (h *MessageHandler) ListMessages(c *gin.Context) {
+	conversationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的会话ID")
+		return
+	}
+	beforeID, _ := strconv.ParseInt(c.Query("before_id"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.messageService.ListMessages(c.Request.Context(), userID, conversationID, beforeID, limit)
+	if err != nil {
+		handleMessageError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// MarkRead 标记会话已读
+// @Summary 标记会话已读
+// @Tags 私信
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会话ID"
+// @Success 200 {object} response.Response "标记成功"
+// @Failure 404 {object} response.ErrorResponse "会话不存在"
+// @Router /messages/conversations/{id}/read [post]
+func (h *MessageHandler) MarkRead(c *gin.Context) {
+	conversationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的会话ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	if err := h.messageService.MarkRead(c.Request.Context(), userID, conversationID); err != nil {
+		handleMessageError(c, err)
+		return
+	}
+
+	response.OK(c, "标记成功", nil)
+}
+
+// UnreadCount 获取私信未读总数
+// @Summary 获取私信未读总数
+// @Tags 私信
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.MessageUnreadData} "获取成功"
+// @Router /messages/unread-count [get]
+func (h *MessageHandler) UnreadCount(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	count, err := h.messageService.UnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		handleMessageError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", dto.MessageUnreadData{UnreadCount: count})
+}
+
+func handleMessageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCannotMessageSelf):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotMutualFollow):
+		respondServiceError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrUserMuted):
+		respondServiceError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrUserNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrConversationNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Message operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败")
+	}
+}
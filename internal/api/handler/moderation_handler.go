@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ModerationHandler struct {
+	moderationService *service.ModerationService
+	auditService      *service.AuditService
+}
+
+func NewModerationHandler(moderationService *service.ModerationService, auditService *service.AuditService) *ModerationHandler {
+	return &ModerationHandler{moderationService: moderationService, auditService: auditService}
+}
+
+// HideVideo 隐藏视频
+// @Summary 隐藏视频（版主）
+// @Description 隐藏已发布视频，使其不出现在视频流、搜索和详情中
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "隐藏成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /moderation/videos/{id}/hide [post]
+func (h *ModerationHandler) HideVideo(c *gin.Context) {
+	h.moderate(c, h.moderationService.HideVideo, service.AuditActionVideoHide, service.AuditTargetVideo, "无效的视频ID", "隐藏成功")
+}
+
+// UnhideVideo 取消隐藏视频
+// @Summary 取消隐藏视频（版主）
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "取消隐藏成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /moderation/videos/{id}/unhide [post]
+func (h *ModerationHandler) UnhideVideo(c *gin.Context) {
+	h.moderate(c, h.moderationService.UnhideVideo, service.AuditActionVideoUnhide, service.AuditTargetVideo, "无效的视频ID", "取消隐藏成功")
+}
+
+// AgeRestrictVideo 设置年龄限制
+// @Summary 设置视频年龄限制（版主）
+// @Description 未登录、未填写出生日期或未满最低年龄的用户只能看到受限占位信息；版主设置的限制作者不能取消
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "设置成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 409 {object} response.ErrorResponse "已设置年龄限制"
+// @Router /moderation/videos/{id}/age-restrict [post]
+func (h *ModerationHandler) AgeRestrictVideo(c *gin.Context) {
+	h.moderate(c, h.moderationService.AgeRestrictVideo, service.AuditActionVideoAgeRestrict, service.AuditTargetVideo, "无效的视频ID", "设置成功")
+}
+
+// AgeUnrestrictVideo 取消年龄限制
+// @Summary 取消视频年龄限制（版主）
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "取消成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 409 {object} response.ErrorResponse "未设置年龄限制"
+// @Router /moderation/videos/{id}/age-unrestrict [post]
+func (h *ModerationHandler) AgeUnrestrictVideo(c *gin.Context) {
+	h.moderate(c, h.moderationService.AgeUnrestrictVideo, service.AuditActionVideoAgeLift, service.AuditTargetVideo, "无效的视频ID", "取消成功")
+}
+
+// HideComment 隐藏评论
+// @Summary 隐藏评论（版主）
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "评论ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "隐藏成功"
+// @Failure 404 {object} response.ErrorResponse "评论不存在"
+// @Router /moderation/comments/{id}/hide [post]
+func (h *ModerationHandler) HideComment(c *gin.Context) {
+	h.moderate(c, h.moderationService.HideComment, service.AuditActionCommentHide, service.AuditTargetComment, "无效的评论ID", "隐藏成功")
+}
+
+// UnhideComment 取消隐藏评论
+// @Summary 取消隐藏评论（版主）
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "评论ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "取消隐藏成功"
+// @Failure 404 {object} response.ErrorResponse "评论不存在"
+// @Router /moderation/comments/{id}/unhide [post]
+func (h *ModerationHandler) UnhideComment(c *gin.Context) {
+	h.moderate(c, h.moderationService.UnhideComment, service.AuditActionCommentUnhide, service.AuditTargetComment, "无效的评论ID", "取消隐藏成功")
+}
+
+// ListHiddenVideos 审核队列：被隐藏的视频
+// @Summary 被隐藏的视频列表（版主）
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.VideoListData} "获取成功"
+// @Router /moderation/videos/hidden [get]
+func (h *ModerationHandler) ListHiddenVideos(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	data, err := h.moderationService.ListHiddenVideos(c.Request.Context(), page, pageSize)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("List hidden videos failed", zap.Error(err))
+		response.InternalError(c, "获取审核队列失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// ListHiddenComments 审核队列：被隐藏的评论
+// @Summary 被隐藏的评论列表（版主）
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.CommentListData} "获取成功"
+// @Router /moderation/comments/hidden [get]
+func (h *ModerationHandler) ListHiddenComments(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	data, err := h.moderationService.ListHiddenComments(c.Request.Context(), page, pageSize)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("List hidden comments failed", zap.Error(err))
+		response.InternalError(c, "获取审核队列失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// ListDuplicates 审核队列：近似重复视频
+// @Summary 近似重复视频列表（版主）
+// @Description 发布时抽帧指纹与已有视频相似的记录，status 为 pending 时为待审核，linked 为已自动关联到原视频
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "状态：pending/linked/dismissed/hidden，为空返回全部" default(pending)
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.VideoDuplicateListData} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "无效的状态"
+// @Router /moderation/duplicates [get]
+func (h *ModerationHandler) ListDuplicates(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	data, err := h.moderationService.ListDuplicates(c.Request.Context(), c.DefaultQuery("status", "pending"), page, pageSize)
+	if err != nil {
+		handleModerationError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// DismissDuplicate 忽略重复记录
+// @Summary 忽略近似重复记录（版主）
+// @Description 认定不是重复视频，记录不再出现在待审核队列中
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "重复记录ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "已忽略"
+// @Failure 404 {object} response.ErrorResponse "重复记录不存在"
+// @Failure 409 {object} response.ErrorResponse "该重复记录已处理"
+// @Router /moderation/duplicates/{id}/dismiss [post]
+func (h *ModerationHandler) DismissDuplicate(c *gin.Context) {
+	h.moderate(c, h.moderationService.DismissDuplicate, service.AuditActionDuplicateDismiss, service.AuditTargetVideoDuplicate, "无效的重复记录ID", "已忽略")
+}
+
+// HideDuplicate 隐藏重复视频
+// @Summary 隐藏近似重复视频（版主）
+// @Description 认定为重复视频，隐藏后发布的视频
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "重复记录ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "隐藏成功"
+// @Failure 404 {object} response.ErrorResponse "重复记录不存在"
+// @Failure 409 {object} response.ErrorResponse "该重复记录已处理"
+// @Router /moderation/duplicates/{id}/hide [post]
+func (h *ModerationHandler) HideDuplicate(c *gin.Context) {
+	h.moderate(c, h.moderationService.HideDuplicate, service.AuditActionDuplicateHide, service.AuditTargetVideoDuplicate, "无效的重复记录ID", "隐藏成功")
+}
+
+type moderateFunc func(ctx context.Context, id int64) error
+
+func (h *ModerationHandler) moderate(c *gin.Context, action moderateFunc, auditAction, targetType, invalidIDMsg, successMsg string) {
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, invalidIDMsg)
+		return
+	}
+
+	if err := action(c.Request.Context(), targetID); err != nil {
+		handleModerationError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, auditAction, targetType, targetID, c.Query("reason"))
+
+	response.OK(c, successMsg, nil)
+}
+
+func handleModerationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVideoNotFound), errors.Is(err, service.ErrCommentNotFound),
+		errors.Is(err, service.ErrDuplicateNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidDuplicateStatus):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrVideoNotHideable), errors.Is(err, service.ErrVideoNotHidden),
+		errors.Is(err, service.ErrCommentHidden), errors.Is(err, service.ErrCommentNotHidden),
+		errors.Is(err, service.ErrDuplicateResolved), errors.Is(err, service.ErrAlreadyAgeRestricted),
+		errors.Is(err, service.ErrNotAgeRestricted):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Moderation operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
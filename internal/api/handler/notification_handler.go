@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	notificationService *service.NotificationService
+	emailService        *service.EmailService
+	pushService         *service.PushService
+}
+
+func NewNotificationHandler(notificationService *service.NotificationService, emailService *service.EmailService, pushService *service.PushService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService, emailService: emailService, pushService: pushService}
+}
+
+// List 获取我的通知列表
+// @Summary 获取我的通知列表
+// @Description 分页获取当前用户的通知（点赞、评论、回复、关注、系统消息），按时间倒序
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Param unread_only query bool false "仅返回未读"
+// @Param type query string false "通知类型: like, comment, reply, follow, tip, digest, system"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.NotificationListData} "获取成功"
+// @Router /notifications [get]
+func (h *NotificationHandler) List(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	page, pageSize := parsePagination(c)
+	unreadOnly := c.Query("unread_only") == "true"
+
+	data, err := h.notificationService.List(c.Request.Context(), userID, unreadOnly, c.Query("type"), page, pageSize)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("List notifications failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "获取通知列表失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// UnreadCount 获取未读通知数
+// @Summary 获取未读通知数
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.NotificationUnreadData} "获取成功"
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	count, err := h.notificationService.UnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Count unread notifications failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "获取未读通知数失败")
+		return
+	}
+
+	response.OK(c, "获取成功", dto.NotificationUnreadData{UnreadCount: count})
+}
+
+// MarkRead 标记通知为已读
+// @Summary 标记通知为已读
+// @Tags 通知
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.NotificationMarkReadRequest true "通知ID列表"
+// @Success 200 {object} response.Response{data=dto.NotificationMarkReadData} "标记成功"
+// @Failure 400 {object} response.ErrorResponse "参数错误"
+// @Router /notifications/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	var req dto.NotificationMarkReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.notificationService.MarkRead(c.Request.Context(), userID, req.IDs)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Mark notifications read failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "标记已读失败")
+		return
+	}
+
+	response.OK(c, "标记成功", data)
+}
+
+// MarkAllRead 标记全部通知为已读
+// @Summary 标记全部通知为已读
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.NotificationMarkReadData} "标记成功"
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.notificationService.MarkAllRead(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Mark all notifications read failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "标记已读失败")
+		return
+	}
+
+	response.OK(c, "标记成功", data)
+}
+
+// GetPreferences 获取通知偏好
+// @Summary 获取通知偏好
+// @Description 获取当前用户屏蔽的通知类型与屏蔽通知的视频
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.NotificationPreferences} "获取成功"
+// @Router /notifications/preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.notificationService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Get notification preferences failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "获取通知偏好失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// UpdatePreferences 更新屏蔽的通知类型
+// @Summary 更新屏蔽的通知类型
+// @Description 整体替换屏蔽的通知类型（like, comment, reply, follow, tip, digest 每周摘要），系统通知不可屏蔽
+// @Tags 通知
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.NotificationPreferencesUpdateRequest true "屏蔽的通知类型"
+// @Success 200 {object} response.Response{data=dto.NotificationPreferences} "更新成功"
+// @Failure 400 {object} response.ErrorResponse "参数错误"
+// @Router /notifications/preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var req dto.NotificationPreferencesUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.notificationService.UpdateMutedTypes(c.Request.Context(), userID, req.MutedTypes)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Update notification preferences failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "更新通知偏好失败")
+		return
+	}
+
+	response.OK(c, "更新成功", data)
+}
+
+// MuteVideo 屏蔽视频通知
+// @Summary 屏蔽视频通知
+// @Description 不再接收该视频相关的点赞、评论、回复通知
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Param video_id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.NotificationPreferences} "屏蔽成功"
+// @Failure 400 {object} response.ErrorResponse "屏蔽数量已达上限"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /notifications/preferences/videos/{video_id} [post]
+func (h *NotificationHandler) MuteVideo(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("video_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.notificationService.MuteVideo(c.Request.Context(), userID, videoID)
+	if err != nil {
+		handleNotificationError(c, err)
+		return
+	}
+
+	response.OK(c, "屏蔽成功", data)
+}
+
+// UnmuteVideo 取消屏蔽视频通知
+// @Summary 取消屏蔽视频通知
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Param video_id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.NotificationPreferences} "取消屏蔽成功"
+// @Router /notifications/preferences/videos/{video_id} [delete]
+func (h *NotificationHandler) UnmuteVideo(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("video_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.notificationService.UnmuteVideo(c.Request.Context(), userID, videoID)
+	if err != nil {
+		handleNotificationError(c, err)
+		return
+	}
+
+	response.OK(c, "取消屏蔽成功", data)
+}
+
+// GetEmailPreferences 获取邮件通知设置
+// @Summary 获取邮件通知设置
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.EmailPreferences} "获取成功"
+// @Router /notifications/email-preferences [get]
+func (h *NotificationHandler) GetEmailPreferences(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.emailService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Get email preferences failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "获取通知偏好失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// UpdateEmailPreferences 更新邮件通知设置
+// @Summary 更新邮件通知设置
+// @Description 设置通知邮箱与开启的邮件类别（follower_digest 新粉丝摘要, video_published 视频发布, moderation 审核结果, weekly_digest 每周摘要），kinds 为空表示关闭全部邮件
+// @Tags 通知
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.EmailPreferencesUpdateRequest true "邮件通知设置"
+// @Success 200 {object} response.Response{data=dto.EmailPreferences} "更新成功"
+// @Failure 400 {object} response.ErrorResponse "参数错误"
+// @Router /notifications/email-preferences [put]
+func (h *NotificationHandler) UpdateEmailPreferences(c *gin.Context) {
+	var req dto.EmailPreferencesUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.emailService.UpdatePreferences(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleNotificationError(c, err)
+		return
+	}
+
+	response.OK(c, "更新成功", data)
+}
+
+// RegisterDevice 注册推送设备
+// @Summary 注册推送设备
+// @Description 登录后上报移动端推送令牌（iOS 为 APNs 令牌，Android 为 FCM 令牌），用于推送点赞、评论、回复、关注通知
+// @Tags 通知
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.DeviceRegisterRequest true "设备信息"
+// @Success 200 {object} response.Response{data=dto.DeviceInfo} "注册成功"
+// @Failure 400 {object} response.ErrorResponse "参数错误"
+// @Router /notifications/devices [post]
+func (h *NotificationHandler) RegisterDevice(c *gin.Context) {
+	var req dto.DeviceRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.pushService.RegisterDevice(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleNotificationError(c, err)
+		return
+	}
+
+	response.OK(c, "注册成功", data)
+}
+
+// UnregisterDevice 注销推送设备
+// @Summary 注销推送设备
+// @Description 退出登录时注销设备令牌，不再向该设备推送
+// @Tags 通知
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.DeviceUnregisterRequest true "设备令牌"
+// @Success 200 {object} response.Response "注销成功"
+// @Failure 404 {object} response.ErrorResponse "设备不存在"
+// @Router /notifications/devices [delete]
+func (h *NotificationHandler) UnregisterDevice(c *gin.Context) {
+	var req dto.DeviceUnregisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	if err := h.pushService.UnregisterDevice(c.Request.Context(), userID, req.Token); err != nil {
+		handleNotificationError(c, err)
+		return
+	}
+
+	response.OK(c, "注销成功", nil)
+}
+
+// ListDevices 获取已注册的推送设备
+// @Summary 获取已注册的推送设备
+// @Tags 通知
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]dto.DeviceInfo} "获取成功"
+// @Router /notifications/devices [get]
+func (h *NotificationHandler) ListDevices(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.pushService.ListDevices(c.Request.Context(), userID)
+	if err != nil {
+		handleNotificationError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+func handleNotificationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVideoNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrTooManyMutedVideos):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrEmailRequired):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrDeviceNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Notification operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败")
+	}
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type OAuthHandler struct {
+	oauthService *service.OAuthService
+}
+
+func NewOAuthHandler(oauthService *service.OAuthService) *OAuthHandler {
+	return &OAuthHandler{oauthService: oauthService}
+}
+
+// CreateClient 注册第三方应用
+// @Summary 注册第三方应用
+// @Description 注册后获得 client_id 与 client_secret（只返回一次）。scopes 为应用可申请的权限：read:videos（读取视频、搜索）、write:comments（代用户发表、编辑、删除评论，需用户授权）
+// @Tags OAuth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.OAuthClientCreateRequest true "应用名称、回调地址与权限范围"
+// @Success 201 {object} response.Response{data=dto.OAuthClientCreatedInfo} "注册成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效或应用数已达上限"
+// @Router /oauth/clients [post]
+func (h *OAuthHandler) CreateClient(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req dto.OAuthClientCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	client, err := h.oauthService.CreateClient(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleOAuthError(c, err)
+		return
+	}
+
+	response.Created(c, "注册成功", client)
+}
+
+// ListClients 我的应用
+// @Summary 获取我注册的第三方应用
+// @Tags OAuth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]dto.OAuthClientInfo} "获取成功"
+// @Router /oauth/clients [get]
+func (h *OAuthHandler) ListClients(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	clients, err := h.oauthService.ListClients(c.Request.Context(), userID)
+	if err != nil {
+		handleOAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", clients)
+}
+
+// RevokeClient 作废应用
+// @Summary 作废第三方应用
+// @Description 作废后不再签发新令牌，已签发的令牌在有效期内仍可使用
+// @Tags OAuth
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "应用ID"
+// @Success 200 {object} response.Response "作废成功"
+// @Failure 404 {object} response.ErrorResponse "应用不存在"
+// @Router /oauth/clients/{id} [delete]
+func (h *OAuthHandler) RevokeClient(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	clientID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的应用ID")
+		return
+	}
+
+	if err := h.oauthService.RevokeClient(c.Request.Context(), userID, clientID); err != nil {
+		handleOAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "作废成功", nil)
+}
+
+// GetAuthorize 授权确认信息
+// @Summary 获取授权确认信息
+// @Description 授权码模式第一步：校验授权请求，返回授权确认页需要展示的应用与权限
+// @Tags OAuth
+// @Produce json
+// @Security BearerAuth
+// @Param response_type query string true "固定为 code"
+// @Param client_id query string true "应用 client_id"
+// @Param redirect_uri query string false "回调地址，应用只登记了一个时可省略"
+// @Param scope query string false "空格分隔的权限范围，为空时申请应用的全部权限"
+// @Param state query string false "原样带回的状态值"
+// @Param code_challenge query string false "PKCE code_challenge"
+// @Param code_challenge_method query string false "PKCE 方法，仅支持 S256"
+// @Success 200 {object} response.Response{data=dto.OAuthAuthorizeInfo} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "回调地址或权限范围无效"
+// @Failure 404 {object} response.ErrorResponse "应用不存在"
+// @Router /oauth/authorize [get]
+func (h *OAuthHandler) GetAuthorize(c *gin.Context) {
+	var req dto.OAuthAuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	info, err := h.oauthService.GetAuthorization(c.Request.Context(), &req)
+	if err != nil {
+		handleOAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", info)
+}
+
+// Authorize 同意授权
+// @Summary 同意授权
+// @Description 当前用户同意授权后签发一次性授权码，前端跳转到返回的 redirect_url
+// @Tags OAuth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.OAuthAuthorizeRequest true "授权请求参数"
+// @Success 200 {object} response.Response{data=dto.OAuthAuthorizeResult} "授权成功"
+// @Failure 400 {object} response.ErrorResponse "回调地址或权限范围无效"
+// @Failure 404 {object} response.ErrorResponse "应用不存在"
+// @Router /oauth/authorize [post]
+func (h *OAuthHandler) Authorize(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req dto.OAuthAuthorizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.oauthService.Authorize(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleOAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "授权成功", result)
+}
+
+// Token 令牌接口
+// @Summary 获取访问令牌
+// @Description 支持 client_credentials 与 authorization_code 两种授权类型，客户端凭证可放在表单或 HTTP Basic 认证中。按 RFC 6749 返回，不使用统一响应结构
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "client_credentials 或 authorization_code"
+// @Param scope formData string false "空格分隔的权限范围（客户端凭证模式）"
+// @Param code formData string false "授权码"
+// @Param redirect_uri formData string false "授权时使用的回调地址"
+// @Param code_verifier formData string false "PKCE code_verifier"
+// @Param client_id formData string false "应用 client_id"
+// @Param client_secret formData string false "应用 client_secret"
+// @Success 200 {object} dto.OAuthTokenResponse "签发成功"
+// @Router /oauth/token [post]
+func (h *OAuthHandler) Token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var req dto.OAuthTokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "error_description": err.Error()})
+		return
+	}
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		req.ClientID, req.ClientSecret = id, secret
+	}
+
+	token, err := h.oauthService.Token(c.Request.Context(), &req)
+	if err != nil {
+		var oauthErr *service.OAuthError
+		if !errors.As(err, &oauthErr) {
+			logger.FromContext(c.Request.Context()).Error("OAuth token failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error", "error_description": "签发令牌失败，请稍后重试"})
+			return
+		}
+		status := http.StatusBadRequest
+		if oauthErr.Code == "invalid_client" {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{"error": oauthErr.Code, "error_description": oauthErr.Description})
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+func handleOAuthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrOAuthClientNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrOAuthClientLimit),
+		errors.Is(err, service.ErrInvalidOAuthScope),
+		errors.Is(err, service.ErrInvalidRedirectURI):
+		respondServiceError(c, http.StatusBadRequest, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("OAuth operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PollHandler struct {
+	pollService *service.PollService
+}
+
+func NewPollHandler(pollService *service.PollService) *PollHandler {
+	return &PollHandler{pollService: pollService}
+}
+
+// Get 获取视频投票
+// @Summary 获取视频投票及实时结果
+// @Description 返回各选项当前票数；登录用户同时返回自己所投的选项
+// @Tags 视频
+// @Produce json
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoPollInfo} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "该视频没有投票"
+// @Router /videos/{id}/poll [get]
+func (h *PollHandler) Get(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.pollService.Get(c.Request.Context(), videoID, userID)
+	if err != nil {
+		handlePollError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// Create 创建视频投票
+// @Summary 为视频创建投票
+// @Description 作者为视频附加一个限时投票（2-10 个选项），每个视频最多一个投票
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.VideoPollCreateRequest true "投票内容"
+// @Success 200 {object} response.Response{data=dto.VideoPollInfo} "创建成功"
+// @Failure 400 {object} response.ErrorResponse "投票问题和选项不能为空，选项不能重复"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Failure 409 {object} response.ErrorResponse "该视频已有投票"
+// @Router /videos/{id}/poll [post]
+func (h *PollHandler) Create(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	var req dto.VideoPollCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.pollService.Create(c.Request.Context(), videoID, userID, &req)
+	if err != nil {
+		handlePollError(c, err)
+		return
+	}
+	response.OK(c, "创建成功", data)
+}
+
+// Vote 投票
+// @Summary 为视频投票中的选项投票
+// @Description 每人只能投一次且不能更改，截止后不再接受投票；返回投票后的实时结果
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.VideoPollVoteRequest true "选项"
+// @Success 200 {object} response.Response{data=dto.VideoPollInfo} "投票成功"
+// @Failure 400 {object} response.ErrorResponse "无效的投票选项"
+// @Failure 404 {object} response.ErrorResponse "该视频没有投票"
+// @Failure 409 {object} response.ErrorResponse "已投过票或投票已截止"
+// @Router /videos/{id}/poll/vote [post]
+func (h *PollHandler) Vote(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	var req dto.VideoPollVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.pollService.Vote(c.Request.Context(), videoID, userID, req.OptionID)
+	if err != nil {
+		handlePollError(c, err)
+		return
+	}
+	response.OK(c, "投票成功", data)
+}
+
+// Close 提前结束投票
+// @Summary 提前结束视频投票
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoPollInfo} "投票已结束"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Failure 409 {object} response.ErrorResponse "投票已截止"
+// @Router /videos/{id}/poll/close [post]
+func (h *PollHandler) Close(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.pollService.Close(c.Request.Context(), videoID, userID)
+	if err != nil {
+		handlePollError(c, err)
+		return
+	}
+	response.OK(c, "投票已结束", data)
+}
+
+// Delete 删除投票
+// @Summary 删除视频投票
+// @Description 投票及全部投票记录一并删除
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Failure 404 {object} response.ErrorResponse "该视频没有投票"
+// @Router /videos/{id}/poll [delete]
+func (h *PollHandler) Delete(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	if err := h.pollService.Delete(c.Request.Context(), videoID, userID); err != nil {
+		handlePollError(c, err)
+		return
+	}
+	response.OK(c, "删除成功", nil)
+}
+
+func handlePollError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPollInvalid), errors.Is(err, service.ErrPollOptionInvalid):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrPollNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrPollExists), errors.Is(err, service.ErrPollClosed), errors.Is(err, service.ErrPollAlreadyVoted):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		handleVideoError(c, err)
+	}
+}
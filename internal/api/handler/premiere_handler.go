@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/i18n"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+type PremiereHandler struct {
+	premiereService *service.PremiereService
+}
+
+func NewPremiereHandler(premiereService *service.PremiereService) *PremiereHandler {
+	return &PremiereHandler{premiereService: premiereService}
+}
+
+// Get 首映倒计时页
+// @Summary 获取首映信息
+// @Description 返回首映时间、当前阶段（upcoming/live/ended）与服务器时间；首映中时返回所有观众同步的播放位置（秒）
+// @Tags 视频
+// @Produce json
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.PremiereInfo} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "该视频没有预约首映"
+// @Router /videos/{id}/premiere [get]
+func (h *PremiereHandler) Get(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.premiereService.Get(c.Request.Context(), videoID, userID)
+	if err != nil {
+		handlePremiereError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// Schedule 预约首映
+// @Summary 预约或修改首映时间
+// @Description 只能为尚未发布的视频预约首映。转码完成后视频处于 scheduled 状态，首映时间到达时自动发布；取消前不能通过修改状态提前发布
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.PremiereScheduleRequest true "首映时间"
+// @Success 200 {object} response.Response{data=dto.PremiereInfo} "预约成功"
+// @Failure 400 {object} response.ErrorResponse "首映时间不在可预约的范围内"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Failure 409 {object} response.ErrorResponse "只能为尚未发布的视频预约首映"
+// @Router /videos/{id}/premiere [put]
+func (h *PremiereHandler) Schedule(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	var req dto.PremiereScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.premiereService.Schedule(c.Request.Context(), videoID, userID, req.PremiereAt)
+	if err != nil {
+		handlePremiereError(c, err)
+		return
+	}
+	response.OK(c, "预约成功", data)
+}
+
+// Cancel 取消首映
+// @Summary 取消首映
+// @Description 已转码等待首映的视频立即发布；尚未转码完成的视频转码后按普通视频发布
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response "已取消首映"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Failure 404 {object} response.ErrorResponse "该视频没有预约首映"
+// @Failure 409 {object} response.ErrorResponse "首映已开始"
+// @Router /videos/{id}/premiere [delete]
+func (h *PremiereHandler) Cancel(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	if err := h.premiereService.Cancel(c.Request.Context(), videoID, userID); err != nil {
+		handlePremiereError(c, err)
+		return
+	}
+	response.OK(c, "已取消首映", nil)
+}
+
+// SendChat 首映聊天
+// @Summary 在首映聊天室发言
+// @Description 聊天室在首映开始前的等候时段及首映中开放，消息通过首映 WebSocket 推送给所有观众
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.PremiereClientFrame true "聊天内容（type 可省略）"
+// @Success 200 {object} response.Response{data=dto.PremiereChatMessage} "发送成功"
+// @Failure 400 {object} response.ErrorResponse "聊天内容不能为空或过长"
+// @Failure 409 {object} response.ErrorResponse "首映聊天室未开放"
+// @Failure 429 {object} response.ErrorResponse "发送过于频繁"
+// @Router /videos/{id}/premiere/chat [post]
+func (h *PremiereHandler) SendChat(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	var req dto.PremiereClientFrame
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.premiereService.SendChat(c.Request.Context(), videoID, userID, req.Text)
+	if err != nil {
+		handlePremiereError(c, err)
+		return
+	}
+	response.OK(c, "发送成功", data)
+}
+
+// WebSocket 首映实时通道
+// @Summary 首映实时通道（WebSocket）
+// @Description 连接后先推送 {"type":"premiere.state","data":首映信息} 与最近的聊天消息，之后推送聊天消息 {id,type:"chat",data}
+// @Description 与首映开始事件 {id,type:"premiere.started",data:首映信息}；无事件时发送 {"type":"ping","data":{"server_time"}} 供客户端校准同步播放位置。
+// @Description 登录用户可发送 {"type":"chat","text":"..."} 发言，失败时收到 {"type":"error","data":{"code","message"}}
+// @Tags 视频
+// @Param id path int true "视频ID"
+// @Param access_token query string false "访问令牌（无法设置 Authorization 头时使用）"
+// @Success 101 {string} string "切换协议"
+// @Failure 404 {object} response.ErrorResponse "该视频没有预约首映"
+// @Router /videos/{id}/premiere/ws [get]
+func (h *PremiereHandler) WebSocket(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+	info, err := h.premiereService.Get(c.Request.Context(), videoID, userID)
+	if err != nil {
+		handlePremiereError(c, err)
+		return
+	}
+	history, lastID, err := h.premiereService.RecentEvents(c.Request.Context(), videoID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Read premiere chat failed", zap.Int64("video_id", videoID), zap.Error(err))
+		response.InternalError(c, "首映聊天室暂不可用")
+		return
+	}
+	lang := response.Locale(c)
+
+	// 认证基于 Token 而非 Cookie，不校验 Origin
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		// 推送与发言回执在不同 goroutine 中写入，需串行化
+		var mu sync.Mutex
+		send := func(v interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			_ = ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			return websocket.JSON.Send(ws, v)
+		}
+
+		// 接收客户端发言，读到错误即视为断开
+		go func() {
+			defer cancel()
+			for {
+				var frame dto.PremiereClientFrame
+				if err := websocket.JSON.Receive(ws, &frame); err != nil {
+					return
+				}
+				if frame.Type != service.PremiereEventChat {
+					continue
+				}
+				err := errUnauthorizedChat
+				if userID != 0 {
+					_, err = h.premiereService.SendChat(ctx, videoID, userID, frame.Text)
+				}
+				if err != nil {
+					_ = send(dto.PremiereFrame{Type: "error", Data: gin.H{
+						"code":    serviceErrorCode(err),
+						"message": i18n.T(lang, premiereChatErrorMessage(err)),
+					}})
+				}
+			}
+		}()
+
+		if err := send(dto.PremiereFrame{Type: "premiere.state", Data: info}); err != nil {
+			return
+		}
+		for _, e := range history {
+			if err := send(e); err != nil {
+				return
+			}
+		}
+
+		for ctx.Err() == nil {
+			events, err := h.premiereService.ReadEvents(ctx, videoID, lastID, sseBlockTimeout)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logger.FromContext(ctx).Warn("Read premiere chat failed", zap.Int64("video_id", videoID), zap.Error(err))
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(time.Second):
+				}
+				continue
+			}
+			if len(events) == 0 {
+				if err := send(dto.PremiereFrame{Type: "ping", Data: gin.H{"server_time": time.Now()}}); err != nil {
+					return
+				}
+				continue
+			}
+			for _, e := range events {
+				if err := send(e); err != nil {
+					return
+				}
+			}
+			lastID = events[len(events)-1].ID
+		}
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// errUnauthorizedChat 未登录用户通过 WebSocket 发言
+var errUnauthorizedChat = errors.New("请先登录")
+
+// premiereChatErrorMessage 发言失败的提示，未知错误不向客户端暴露细节
+func premiereChatErrorMessage(err error) string {
+	if err == errUnauthorizedChat || serviceErrorCode(err) != "" {
+		return err.Error()
+	}
+	return "发送失败，请稍后重试"
+}
+
+func handlePremiereError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPremiereTimeInvalid), errors.Is(err, service.ErrPremiereChatInvalid):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrPremiereNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrPremiereNotAllowed), errors.Is(err, service.ErrPremiereStarted),
+		errors.Is(err, service.ErrPremiereChatClosed):
+		respondServiceError(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrPremiereChatRateLimit):
+		respondServiceError(c, http.StatusTooManyRequests, err)
+	case errors.Is(err, service.ErrPremiereDisabled):
+		respondServiceError(c, http.StatusServiceUnavailable, err)
+	case errors.Is(err, service.ErrUserMuted):
+		respondServiceError(c, http.StatusForbidden, err)
+	default:
+		handleVideoError(c, err)
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileHandler struct {
+	profileService *service.ProfileService
+}
+
+func NewProfileHandler(profileService *service.ProfileService) *ProfileHandler {
+	return &ProfileHandler{profileService: profileService}
+}
+
+// GetHome 用户主页
+// @Summary 获取用户主页
+// @Description 一次返回用户公开信息、当前用户的关注状态、置顶视频与最新发布的视频（分页）。登录可选，登录后附带关注、点赞状态
+// @Tags 用户
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param page query int false "视频页码" default(1)
+// @Param page_size query int false "每页视频数量" default(10)
+// @Success 200 {object} response.Response{data=dto.ProfileHomeData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/home [get]
+func (h *ProfileHandler) GetHome(c *gin.Context) {
+	userID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+	page, pageSize := parsePagination(c)
+
+	viewerID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.profileService.GetHome(c.Request.Context(), userID, viewerID, page, pageSize)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
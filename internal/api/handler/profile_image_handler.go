@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"unicode/utf8"
+
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ProfileImageHandler struct {
+	profileImageService *service.ProfileImageService
+	auditService        *service.AuditService
+}
+
+func NewProfileImageHandler(profileImageService *service.ProfileImageService, auditService *service.AuditService) *ProfileImageHandler {
+	return &ProfileImageHandler{profileImageService: profileImageService, auditService: auditService}
+}
+
+// ListReviews 审核队列：头像与主页背景图
+// @Summary 头像、背景图审核列表（版主）
+// @Description Agent 服务无法判定的图片会停留在待审核状态，最早提交的排在前面
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "状态：pending/approved/rejected/superseded" default(pending)
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.ProfileImageReviewListData} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "无效的状态"
+// @Router /moderation/profile-images [get]
+func (h *ProfileImageHandler) ListReviews(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	data, err := h.profileImageService.ListReviews(c.Request.Context(), c.Query("status"), page, pageSize)
+	if err != nil {
+		handleProfileImageError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// ApproveImage 通过图片
+// @Summary 通过头像、背景图（版主）
+// @Description 通过后图片立即在用户主页上线
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "审核记录ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "已通过"
+// @Failure 404 {object} response.ErrorResponse "审核记录不存在"
+// @Failure 409 {object} response.ErrorResponse "该图片已处理"
+// @Router /moderation/profile-images/{id}/approve [post]
+func (h *ProfileImageHandler) ApproveImage(c *gin.Context) {
+	reviewID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的审核记录ID")
+		return
+	}
+
+	moderatorID, _ := middleware.GetCurrentUserID(c)
+	if err := h.profileImageService.Approve(c.Request.Context(), reviewID, moderatorID); err != nil {
+		handleProfileImageError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionImageApprove, service.AuditTargetProfileImage, reviewID, c.Query("reason"))
+
+	response.OK(c, "已通过", nil)
+}
+
+// RejectImage 驳回图片
+// @Summary 驳回头像、背景图（版主）
+// @Description 待审核的图片不会上线；已上线的图片恢复为用户提交前的图片（用户之后又更换过的除外）。驳回原因会通知用户并记入审计日志
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "审核记录ID"
+// @Param reason query string false "驳回原因"
+// @Success 200 {object} response.Response "已驳回"
+// @Failure 404 {object} response.ErrorResponse "审核记录不存在"
+// @Failure 409 {object} response.ErrorResponse "该图片已处理"
+// @Router /moderation/profile-images/{id}/reject [post]
+func (h *ProfileImageHandler) RejectImage(c *gin.Context) {
+	reviewID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的审核记录ID")
+		return
+	}
+
+	reason := c.Query("reason")
+	if utf8.RuneCountInString(reason) > 500 {
+		response.BadRequest(c, "驳回原因不能超过 500 个字符")
+		return
+	}
+
+	moderatorID, _ := middleware.GetCurrentUserID(c)
+	if err := h.profileImageService.Reject(c.Request.Context(), reviewID, moderatorID, reason); err != nil {
+		handleProfileImageError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionImageReject, service.AuditTargetProfileImage, reviewID, reason)
+
+	response.OK(c, "已驳回", nil)
+}
+
+func handleProfileImageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrProfileImageReviewNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidProfileImageStatus):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrProfileImageReviewed):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Profile image review failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
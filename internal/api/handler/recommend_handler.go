@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	recommendDefaultLimit = 20
+	recommendMaxLimit     = 50
+)
+
+type RecommendHandler struct {
+	recommendService *service.RecommendService
+	videoService     *service.VideoService
+}
+
+func NewRecommendHandler(recommendService *service.RecommendService, videoService *service.VideoService) *RecommendHandler {
+	return &RecommendHandler{recommendService: recommendService, videoService: videoService}
+}
+
+// GetRelated 相关视频
+// @Summary 相关视频
+// @Description 基于共同点赞、共同观看计算的相似视频（公开接口），不足时用同作者的视频补齐，登录时附带 is_favorited、is_following
+// @Tags 推荐
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param limit query int false "数量，最多 50" default(20)
+// @Success 200 {object} response.Response{data=dto.VideoRecommendData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /videos/{id}/related [get]
+func (h *RecommendHandler) GetRelated(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	data, err := h.recommendService.GetRelated(c.Request.Context(), videoID, parseRecommendLimit(c))
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+	if !h.fillViewerState(c, data) {
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// GetForYou 为你推荐
+// @Summary 为你推荐
+// @Description 根据当前用户最近点赞、观看的视频推荐相似视频，排除已看过和自己发布的视频，不足时用最新视频补齐
+// @Tags 推荐
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "数量，最多 50" default(20)
+// @Success 200 {object} response.Response{data=dto.VideoRecommendData} "获取成功"
+// @Router /videos/for-you [get]
+func (h *RecommendHandler) GetForYou(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.recommendService.GetForYou(c.Request.Context(), userID, parseRecommendLimit(c))
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Get for-you videos failed", zap.Int64("user_id", userID), zap.Error(err))
+		response.InternalError(c, "获取推荐失败")
+		return
+	}
+	if !h.fillViewerState(c, data) {
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// fillViewerState 执行年龄限制，登录时填充点赞、关注状态
+func (h *RecommendHandler) fillViewerState(c *gin.Context, data *dto.VideoRecommendData) bool {
+	viewerID, ok := middleware.GetCurrentUserID(c)
+	if err := h.videoService.ApplyContentGate(c.Request.Context(), viewerID, data.Videos); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Apply age gate failed", zap.Error(err))
+		response.InternalError(c, "获取推荐失败")
+		return false
+	}
+	if !ok {
+		return true
+	}
+	if err := h.videoService.FillViewerState(c.Request.Context(), viewerID, data.Videos); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Fill viewer state failed", zap.Int64("user_id", viewerID), zap.Error(err))
+		response.InternalError(c, "获取推荐失败")
+		return false
+	}
+	return true
+}
+
+func parseRecommendLimit(c *gin.Context) int {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(recommendDefaultLimit)))
+	if limit < 1 || limit > recommendMaxLimit {
+		limit = recommendDefaultLimit
+	}
+	return limit
+}
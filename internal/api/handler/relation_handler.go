@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 	"strconv"
 
 	"vida-go/internal/api/dto"
@@ -40,7 +41,7 @@ func (h *RelationHandler) Follow(c *gin.Context) {
 		return
 	}
 
-	result, err := h.relationService.Follow(currentUserID, targetID)
+	result, err := h.relationService.Follow(c.Request.Context(), currentUserID, targetID)
 	if err != nil {
 		handleRelationError(c, err)
 		return
@@ -67,7 +68,7 @@ func (h *RelationHandler) Unfollow(c *gin.Context) {
 		return
 	}
 
-	result, err := h.relationService.Unfollow(currentUserID, targetID)
+	result, err := h.relationService.Unfollow(c.Request.Context(), currentUserID, targetID)
 	if err != nil {
 		handleRelationError(c, err)
 		return
@@ -96,7 +97,7 @@ func (h *RelationHandler) GetFollowing(c *gin.Context) {
 
 	page, pageSize := parsePagination(c)
 
-	data, err := h.relationService.GetFollowingList(userID, page, pageSize)
+	data, err := h.relationService.GetFollowingList(c.Request.Context(), userID, page, pageSize)
 	if err != nil {
 		handleRelationError(c, err)
 		return
@@ -125,7 +126,7 @@ func (h *RelationHandler) GetFollowers(c *gin.Context) {
 
 	page, pageSize := parsePagination(c)
 
-	data, err := h.relationService.GetFollowerList(userID, page, pageSize)
+	data, err := h.relationService.GetFollowerList(c.Request.Context(), userID, page, pageSize)
 	if err != nil {
 		handleRelationError(c, err)
 		return
@@ -148,7 +149,7 @@ func (h *RelationHandler) GetMyFollowing(c *gin.Context) {
 	currentUserID, _ := middleware.GetCurrentUserID(c)
 	page, pageSize := parsePagination(c)
 
-	data, err := h.relationService.GetFollowingList(currentUserID, page, pageSize)
+	data, err := h.relationService.GetFollowingList(c.Request.Context(), currentUserID, page, pageSize)
 	if err != nil {
 		handleRelationError(c, err)
 		return
@@ -171,7 +172,7 @@ func (h *RelationHandler) GetMyFollowers(c *gin.Context) {
 	currentUserID, _ := middleware.GetCurrentUserID(c)
 	page, pageSize := parsePagination(c)
 
-	data, err := h.relationService.GetFollowerList(currentUserID, page, pageSize)
+	data, err := h.relationService.GetFollowerList(c.Request.Context(), currentUserID, page, pageSize)
 	if err != nil {
 		handleRelationError(c, err)
 		return
@@ -197,9 +198,9 @@ func (h *RelationHandler) GetFollowStatus(c *gin.Context) {
 		return
 	}
 
-	isFollowing, err := h.relationService.GetFollowStatus(currentUserID, targetID)
+	isFollowing, err := h.relationService.GetFollowStatus(c.Request.Context(), currentUserID, targetID)
 	if err != nil {
-		logger.Error("Get follow status failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Get follow status failed", zap.Error(err))
 		response.InternalError(c, "查询关注状态失败")
 		return
 	}
@@ -224,9 +225,9 @@ func (h *RelationHandler) GetMutualFollows(c *gin.Context) {
 	currentUserID, _ := middleware.GetCurrentUserID(c)
 	page, pageSize := parsePagination(c)
 
-	data, err := h.relationService.GetMutualFollows(currentUserID, page, pageSize)
+	data, err := h.relationService.GetMutualFollows(c.Request.Context(), currentUserID, page, pageSize)
 	if err != nil {
-		logger.Error("Get mutual follows failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Get mutual follows failed", zap.Error(err))
 		response.InternalError(c, "获取互相关注列表失败")
 		return
 	}
@@ -249,13 +250,13 @@ func (h *RelationHandler) BatchFollowStatus(c *gin.Context) {
 
 	var req dto.BatchFollowStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
-	statusMap, err := h.relationService.BatchCheckFollowStatus(currentUserID, req.UserIDs)
+	statusMap, err := h.relationService.BatchCheckFollowStatus(c.Request.Context(), currentUserID, req.UserIDs)
 	if err != nil {
-		logger.Error("Batch follow status failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Batch follow status failed", zap.Error(err))
 		response.InternalError(c, "批量查询关注状态失败")
 		return
 	}
@@ -280,15 +281,15 @@ func parsePagination(c *gin.Context) (int, int) {
 func handleRelationError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrCannotFollowSelf):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusBadRequest, err)
 	case errors.Is(err, service.ErrAlreadyFollowed):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusBadRequest, err)
 	case errors.Is(err, service.ErrNotFollowed):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusBadRequest, err)
 	case errors.Is(err, service.ErrUserNotFound):
-		response.NotFound(c, err.Error())
+		respondServiceError(c, http.StatusNotFound, err)
 	default:
-		logger.Error("Relation operation failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Relation operation failed", zap.Error(err))
 		response.InternalError(c, "操作失败，请稍后重试")
 	}
 }
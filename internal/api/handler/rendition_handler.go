@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"strconv"
+
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RenditionHandler struct {
+	renditionService *service.RenditionService
+}
+
+func NewRenditionHandler(renditionService *service.RenditionService) *RenditionHandler {
+	return &RenditionHandler{renditionService: renditionService}
+}
+
+// Summary 附加清晰度补齐进度
+// @Summary 附加清晰度补齐进度（管理员）
+// @Description 按配置的转码档位统计已发布视频中已生成、等待中、失败和未投递的数量
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.RenditionSummaryData} "获取成功"
+// @Router /admin/renditions [get]
+func (h *RenditionHandler) Summary(c *gin.Context) {
+	data, err := h.renditionService.Summary(c.Request.Context())
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Get rendition summary failed", zap.Error(err))
+		response.InternalError(c, "获取补齐进度失败")
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// GetVideoRenditions 视频各档位的完整度
+// @Summary 视频各档位的完整度（管理员）
+// @Description 返回默认播放文件的编码信息（编码、码率、帧率、声道数），以及视频在每个配置档位下的状态、播放地址、尝试次数与失败原因，未投递过的档位为 missing
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoRenditionsData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /admin/videos/{id}/renditions [get]
+func (h *RenditionHandler) GetVideoRenditions(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	data, err := h.renditionService.GetVideoRenditions(c.Request.Context(), videoID)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/repository"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	reportService *service.ReportService
+	auditService  *service.AuditService
+}
+
+func NewReportHandler(reportService *service.ReportService, auditService *service.AuditService) *ReportHandler {
+	return &ReportHandler{reportService: reportService, auditService: auditService}
+}
+
+// ReportUser 举报用户
+// @Summary 举报用户
+// @Description 举报用户账号本身（冒充、骚扰、违规资料等），视频、评论内容请分别举报。同一用户被多人举报时自动升级为优先审核
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param request body dto.ReportUserRequest true "举报原因：spam/harassment/hate/impersonation/scam/inappropriate/underage/other"
+// @Success 200 {object} response.Response "举报成功"
+// @Failure 400 {object} response.ErrorResponse "不能举报自己"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Failure 409 {object} response.ErrorResponse "已举报过该用户"
+// @Router /users/{id}/report [post]
+func (h *ReportHandler) ReportUser(c *gin.Context) {
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	var req dto.ReportUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if err := h.reportService.ReportUser(c.Request.Context(), currentUserID, targetID, &req); err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	response.OK(c, "举报成功，我们会尽快处理", nil)
+}
+
+// ListReports 审核队列：举报
+// @Summary 举报列表（版主）
+// @Description 已升级（同一用户被多人举报）的举报排在前面
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "状态：pending/resolved/dismissed，为空返回全部" default(pending)
+// @Param target_type query string false "举报对象类型：user/video/comment"
+// @Param target_user_id query int false "被举报用户ID"
+// @Param escalated query bool false "只看已升级的举报"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.ReportListData} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "无效的筛选条件"
+// @Router /moderation/reports [get]
+func (h *ReportHandler) ListReports(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	filter := &repository.ReportFilter{
+		Status:     c.DefaultQuery("status", "pending"),
+		TargetType: c.Query("target_type"),
+	}
+	if v := c.Query("target_user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "无效的用户ID")
+			return
+		}
+		filter.TargetUserID = id
+	}
+	filter.Escalated, _ = strconv.ParseBool(c.Query("escalated"))
+
+	data, err := h.reportService.ListReports(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// ResolveReport 举报成立
+// @Summary 处理举报：举报成立（版主）
+// @Description 只更新举报状态，对被举报用户的处置请使用封禁、禁言等接口
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "举报ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "处理成功"
+// @Failure 404 {object} response.ErrorResponse "举报不存在"
+// @Failure 409 {object} response.ErrorResponse "该举报已处理"
+// @Router /moderation/reports/{id}/resolve [post]
+func (h *ReportHandler) ResolveReport(c *gin.Context) {
+	h.review(c, h.reportService.ResolveReport, service.AuditActionReportResolve, "处理成功")
+}
+
+// DismissReport 驳回举报
+// @Summary 处理举报：驳回（版主）
+// @Description 驳回的举报不再计入升级统计
+// @Tags 审核
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "举报ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response "已驳回"
+// @Failure 404 {object} response.ErrorResponse "举报不存在"
+// @Failure 409 {object} response.ErrorResponse "该举报已处理"
+// @Router /moderation/reports/{id}/dismiss [post]
+func (h *ReportHandler) DismissReport(c *gin.Context) {
+	h.review(c, h.reportService.DismissReport, service.AuditActionReportDismiss, "已驳回")
+}
+
+func (h *ReportHandler) review(c *gin.Context, action func(ctx context.Context, id int64) error, auditAction, successMsg string) {
+	reportID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的举报ID")
+		return
+	}
+
+	if err := action(c.Request.Context(), reportID); err != nil {
+		handleReportError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, auditAction, service.AuditTargetReport, reportID, c.Query("reason"))
+
+	response.OK(c, successMsg, nil)
+}
+
+func handleReportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrReportNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrCannotReportSelf), errors.Is(err, service.ErrInvalidReportStatus),
+		errors.Is(err, service.ErrInvalidReportTarget):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrReportExists), errors.Is(err, service.ErrReportResolved):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Report operation failed", zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
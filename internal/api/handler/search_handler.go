@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
 	"vida-go/internal/api/response"
 	"vida-go/internal/service"
 	"vida-go/pkg/logger"
@@ -22,9 +23,10 @@ func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
 
 // SearchVideos 搜索视频
 // @Summary 搜索视频
-// @Description 根据关键词搜索视频，支持多种筛选条件
+// @Description 根据关键词搜索视频，支持多种筛选条件。第一页且关键词与某个用户名相同或非常接近时，author 返回该作者的主页卡片与热门视频。Elasticsearch 不可用时降级为数据库搜索，返回 degraded=true，unavailable 列出不可用的能力（高亮、相关度排序、分词匹配）。年龄限制视频对未登录、未填写出生日期或未满最低年龄的用户只返回 restricted 占位信息
 // @Tags 搜索
 // @Produce json
+// @Security BearerAuth
 // @Param q query string false "搜索关键词"
 // @Param author_id query int false "作者ID"
 // @Param video_id query int false "视频ID"
@@ -32,15 +34,23 @@ func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
 // @Param sort query string false "排序方式: relevance, latest, hot" default(relevance)
 // @Param start_time query int false "开始时间戳"
 // @Param end_time query int false "结束时间戳"
+// @Param min_height query int false "最低画面高度（如 720、1080）"
+// @Param min_frame_rate query number false "最低帧率"
+// @Param sponsored query bool false "付费推广筛选：true 只看推广视频，false 排除推广视频"
 // @Param page query int false "页码" default(1)
 // @Param page_size query int false "每页数量" default(20)
+// @Param X-App-Key header string false "签名应用 Key（开启请求签名时必填，见 signing 配置）"
+// @Param X-Timestamp header int false "签名时间戳（Unix 秒）"
+// @Param X-Nonce header string false "签名随机串（8-64 字符，有效期内不可重复使用）"
+// @Param X-Signature header string false "请求签名"
 // @Success 200 {object} response.Response{data=dto.SearchVideoData} "搜索成功"
 // @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 401 {object} response.ErrorResponse "请求签名无效"
 // @Router /search/videos [get]
 func (h *SearchHandler) SearchVideos(c *gin.Context) {
 	var req dto.SearchVideoRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
@@ -72,9 +82,16 @@ func (h *SearchHandler) SearchVideos(c *gin.Context) {
 		}
 	}
 
-	data, err := h.searchService.SearchVideos(&req)
+	data, err := h.searchService.SearchVideos(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("Search videos failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Search videos failed", zap.Error(err))
+		response.InternalError(c, "搜索失败")
+		return
+	}
+
+	viewerID, _ := middleware.GetCurrentUserID(c)
+	if err := h.searchService.ApplyContentGate(c.Request.Context(), viewerID, data); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Apply age gate failed", zap.Error(err))
 		response.InternalError(c, "搜索失败")
 		return
 	}
@@ -82,24 +99,33 @@ func (h *SearchHandler) SearchVideos(c *gin.Context) {
 	response.OK(c, "搜索成功", data)
 }
 
-// SyncVideosToES 同步视频到ES
-// @Summary 同步视频到ES
-// @Description 将数据库中的视频同步到 Elasticsearch
+// SyncVideosToES 同步视频和用户到ES
+// @Summary 同步视频和用户到ES
+// @Description 将数据库中的已发布视频和用户同步到 Elasticsearch（用户索引用于搜索时匹配作者）
 // @Tags 搜索
 // @Produce json
 // @Success 200 {object} response.Response "同步成功"
 // @Failure 500 {object} response.ErrorResponse "同步失败"
 // @Router /search/sync [post]
 func (h *SearchHandler) SyncVideosToES(c *gin.Context) {
-	success, failed, err := h.searchService.SyncVideosToES()
+	success, failed, err := h.searchService.SyncVideosToES(c.Request.Context())
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Sync videos to ES failed", zap.Error(err))
+		response.InternalError(c, "同步失败")
+		return
+	}
+
+	userSuccess, userFailed, err := h.searchService.SyncUsersToES(c.Request.Context())
 	if err != nil {
-		logger.Error("Sync videos to ES failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Sync users to ES failed", zap.Error(err))
 		response.InternalError(c, "同步失败")
 		return
 	}
 
 	response.OK(c, "同步完成", gin.H{
-		"success": success,
-		"failed":  failed,
+		"success":       success,
+		"failed":        failed,
+		"users_success": userSuccess,
+		"users_failed":  userFailed,
 	})
 }
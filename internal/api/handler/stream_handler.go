@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"path"
+
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	infraMinio "vida-go/internal/infra/minio"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type StreamHandler struct {
+	streamService *service.StreamService
+}
+
+func NewStreamHandler(streamService *service.StreamService) *StreamHandler {
+	return &StreamHandler{streamService: streamService}
+}
+
+// Stream 视频播放代理
+// @Summary 视频播放代理
+// @Description 每次请求都校验观看权限（可见性、地区、年龄限制、会员专属）后从对象存储读取视频，支持 Range 分段请求与 HEAD。
+// @Description <video> 标签无法设置请求头时可通过 access_token 查询参数传递 Token
+// @Tags 视频
+// @Produce video/mp4
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param profile query string false "清晰度档位（如 720p），为空播放默认文件；仅支持 MP4 档位"
+// @Param access_token query string false "认证令牌"
+// @Success 200 {file} binary "视频内容"
+// @Success 206 {file} binary "部分内容"
+// @Failure 403 {object} response.ErrorResponse "不满足年龄要求或仅限会员观看"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 416 {string} string "Range 无效"
+// @Failure 451 {object} response.ErrorResponse "所在地区不可观看"
+// @Failure 503 {object} response.ErrorResponse "视频正在从冷存储恢复，按 Retry-After 稍后重试"
+// @Router /stream/{id} [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	videoID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	viewerID, _ := middleware.GetCurrentUserID(c)
+	src, err := h.streamService.Resolve(c.Request.Context(), videoID, viewerID, c.Query("profile"))
+	if err != nil {
+		handleStreamError(c, err)
+		return
+	}
+
+	obj, info, err := infraMinio.OpenObject(c.Request.Context(), src.Bucket, src.Object)
+	if err != nil {
+		if errors.Is(err, infraMinio.ErrObjectNotFound) {
+			handleStreamError(c, service.ErrStreamNotReady)
+			return
+		}
+		logger.FromContext(c.Request.Context()).Error("Open stream object failed",
+			zap.Int64("video_id", videoID), zap.String("object", src.Object), zap.Error(err))
+		response.InternalError(c, "播放失败，请稍后重试")
+		return
+	}
+	defer obj.Close()
+
+	// 权限按请求校验，响应不能被共享缓存复用
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Content-Type-Options", "nosniff")
+	if info.ContentType != "" {
+		c.Header("Content-Type", info.ContentType)
+	}
+	if info.ETag != "" {
+		c.Header("ETag", `"`+info.ETag+`"`)
+	}
+	http.ServeContent(c.Writer, c.Request, path.Base(src.Object), info.LastModified, obj)
+}
+
+// streamPreparingRetryAfter 视频从冷存储恢复期间建议客户端的重试间隔（秒）
+const streamPreparingRetryAfter = "30"
+
+func handleStreamError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVideoNotFound), errors.Is(err, service.ErrStreamNotReady),
+		errors.Is(err, service.ErrStreamProfileInvalid):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrStreamAgeRestricted), errors.Is(err, service.ErrStreamMembersOnly):
+		respondServiceError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrVideoRegionRestricted):
+		respondServiceError(c, http.StatusUnavailableForLegalReasons, err)
+	case errors.Is(err, service.ErrStreamPreparing):
+		c.Header("Retry-After", streamPreparingRetryAfter)
+		respondServiceError(c, http.StatusServiceUnavailable, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("Stream video failed", zap.Error(err))
+		response.InternalError(c, "播放失败，请稍后重试")
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+
+	"vida-go/internal/api/response"
+	"vida-go/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// UploadOverheadBytes 上传请求中文件以外部分（表单字段、分隔符）的上限
+	UploadOverheadBytes = 1 << 20
+	// AvatarMaxSize 头像文件大小上限
+	AvatarMaxSize = 2 << 20
+)
+
+var (
+	errUploadTooLarge = errors.New("upload too large")
+	errUploadMissing  = errors.New("upload file missing")
+)
+
+// streamedFile 流式写入临时文件的上传文件，Close 时删除临时文件
+type streamedFile struct {
+	*os.File
+	Filename string
+	Size     int64
+}
+
+func (f *streamedFile) Close() error {
+	_ = f.File.Close()
+	return os.Remove(f.File.Name())
+}
+
+// streamUpload 逐个读取 multipart 表单：文件字段 field 直接写入临时文件（不超过 maxSize），
+// 其余字段放入请求表单供 ShouldBind 绑定，整个请求体不会经 ParseMultipartForm 缓冲在内存中。
+// 同名的多余文件丢弃
+func streamUpload(c *gin.Context, field string, maxSize int64) (file *streamedFile, err error) {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && file != nil {
+			_ = file.Close()
+			file = nil
+		}
+	}()
+
+	values := make(url.Values)
+	remaining := int64(UploadOverheadBytes)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return file, err
+		}
+
+		switch {
+		case part.FileName() == "":
+			data, err := io.ReadAll(io.LimitReader(part, remaining+1))
+			if err != nil {
+				return file, err
+			}
+			remaining -= int64(len(data))
+			if remaining < 0 {
+				return file, errUploadTooLarge
+			}
+			values.Add(part.FormName(), string(data))
+		case part.FormName() == field && file == nil:
+			file, err = writeTempFile(part, maxSize)
+			if err != nil {
+				return file, err
+			}
+		default:
+			if _, err := io.Copy(io.Discard, part); err != nil {
+				return file, err
+			}
+		}
+	}
+
+	c.Request.MultipartForm = &multipart.Form{Value: values}
+	c.Request.PostForm = values
+	if file == nil {
+		return nil, errUploadMissing
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return file, err
+	}
+	return file, nil
+}
+
+// writeTempFile 将文件部分写入暂存目录，超过 maxSize 时返回 errUploadTooLarge
+func writeTempFile(part *multipart.Part, maxSize int64) (*streamedFile, error) {
+	tmp, err := os.CreateTemp(config.GetRequestLimit().TempDir, "upload-*")
+	if err != nil {
+		return nil, err
+	}
+	file := &streamedFile{File: tmp, Filename: part.FileName()}
+	file.Size, err = io.Copy(tmp, io.LimitReader(part, maxSize+1))
+	if err == nil && file.Size > maxSize {
+		err = errUploadTooLarge
+	}
+	return file, err
+}
+
+// respondUploadError 文件或请求体超出上限返回 413，未上传文件返回 missingMsg
+func respondUploadError(c *gin.Context, err error, missingMsg string) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, errUploadTooLarge), errors.As(err, &maxBytesErr):
+		response.FailWithCode(c, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge, "上传文件过大")
+	case errors.Is(err, errUploadMissing), errors.Is(err, http.ErrNotMultipart):
+		response.BadRequest(c, missingMsg)
+	default:
+		response.BadRequest(c, "读取上传文件失败")
+	}
+}
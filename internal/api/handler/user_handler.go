@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -21,14 +22,16 @@ import (
 )
 
 type UserHandler struct {
-	userService *service.UserService
-	authService *service.AuthService
+	userService  *service.UserService
+	authService  *service.AuthService
+	auditService *service.AuditService
 }
 
-func NewUserHandler(userService *service.UserService, authService *service.AuthService) *UserHandler {
+func NewUserHandler(userService *service.UserService, authService *service.AuthService, auditService *service.AuditService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		authService: authService,
+		userService:  userService,
+		authService:  authService,
+		auditService: auditService,
 	}
 }
 
@@ -48,7 +51,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	info, err := h.userService.GetUserByID(targetID)
+	info, err := h.userService.GetProfile(c.Request.Context(), targetID)
 	if err != nil {
 		handleUserError(c, err)
 		return
@@ -57,9 +60,37 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 	response.OK(c, "获取成功", info)
 }
 
+// BatchGet 批量获取用户简要信息
+// @Summary 批量获取用户简要信息
+// @Description 按 ID 列表获取用户名、头像与认证状态（最多 100 个），登录时附带是否已关注，已删除或不存在的用户不返回
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UserBatchRequest true "用户ID列表"
+// @Success 200 {object} response.Response{data=[]dto.UserBriefInfo} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Router /users/batch [post]
+func (h *UserHandler) BatchGet(c *gin.Context) {
+	var req dto.UserBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	viewerID, _ := middleware.GetCurrentUserID(c)
+	users, err := h.userService.BatchGetBriefs(c.Request.Context(), viewerID, req.UserIDs)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", users)
+}
+
 // UploadAvatar 上传用户头像
 // @Summary 上传用户头像
-// @Description 上传头像图片，支持 jpg/png/gif/webp
+// @Description 上传头像图片，支持 jpg/png/gif/webp。开启图片审核时新头像审核通过后才对外展示（见 pending_avatar）
 // @Tags 用户
 // @Accept multipart/form-data
 // @Produce json
@@ -67,6 +98,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 // @Param avatar formData file true "头像文件"
 // @Success 200 {object} response.Response{data=dto.UserFullInfo} "上传成功"
 // @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 413 {object} response.ErrorResponse "请求体过大"
 // @Router /users/me/avatar [post]
 func (h *UserHandler) UploadAvatar(c *gin.Context) {
 	userID, ok := middleware.GetCurrentUserID(c)
@@ -87,7 +119,7 @@ func (h *UserHandler) UploadAvatar(c *gin.Context) {
 		response.BadRequest(c, "仅支持 jpg、png、gif、webp 格式")
 		return
 	}
-	if file.Size > 2*1024*1024 {
+	if file.Size > AvatarMaxSize {
 		response.BadRequest(c, "头像大小不能超过 2MB")
 		return
 	}
@@ -113,7 +145,7 @@ func (h *UserHandler) UploadAvatar(c *gin.Context) {
 	}
 
 	if _, err := minio.UploadFile(ctx, "user-avatars", objectName, f, file.Size, contentType); err != nil {
-		logger.Error("Upload avatar failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Upload avatar failed", zap.Error(err))
 		response.InternalError(c, "上传头像失败")
 		return
 	}
@@ -121,14 +153,18 @@ func (h *UserHandler) UploadAvatar(c *gin.Context) {
 	minioCfg := config.GetMinIO()
 	avatarURL := minio.GetPublicURL(minioCfg.Endpoint, minioCfg.UseSSL, "user-avatars", objectName)
 
-	currentUser, _ := h.authService.GetCurrentUser(userID)
+	currentUser, _ := h.authService.GetCurrentUser(c.Request.Context(), userID)
 	req := dto.UserUpdateRequest{Avatar: &avatarURL}
-	info, err := h.userService.UpdateUser(userID, currentUser, &req)
+	info, err := h.userService.UpdateUser(c.Request.Context(), userID, currentUser, &req)
 	if err != nil {
 		handleUserError(c, err)
 		return
 	}
 
+	if info.PendingAvatar != nil {
+		response.OK(c, "头像已提交审核，审核通过后生效", info)
+		return
+	}
 	response.OK(c, "头像上传成功", info)
 }
 
@@ -148,7 +184,7 @@ func (h *UserHandler) GetMe(c *gin.Context) {
 		return
 	}
 
-	info, err := h.userService.GetUserByID(userID)
+	info, err := h.userService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
 		handleUserError(c, err)
 		return
@@ -176,7 +212,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	}
 
 	currentUserID, _ := middleware.GetCurrentUserID(c)
-	currentUser, _ := h.authService.GetCurrentUser(currentUserID)
+	currentUser, _ := h.authService.GetCurrentUser(c.Request.Context(), currentUserID)
 	if currentUser == nil {
 		response.Unauthorized(c, "无法获取用户信息")
 		return
@@ -187,7 +223,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
-	info, err := h.userService.GetUserByID(targetID)
+	info, err := h.userService.GetUserByID(c.Request.Context(), targetID)
 	if err != nil {
 		handleUserError(c, err)
 		return
@@ -198,7 +234,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 // UpdateUser 更新用户信息
 // @Summary 更新用户信息
-// @Description 更新指定用户的信息
+// @Description 更新指定用户的信息。出生日期（birth_date，YYYY-MM-DD）用于观看年龄限制视频，设置后仅管理员可修改
 // @Tags 用户
 // @Accept json
 // @Produce json
@@ -218,22 +254,27 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	var req dto.UserUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
 	currentUserID, _ := middleware.GetCurrentUserID(c)
-	currentUser, _ := h.authService.GetCurrentUser(currentUserID)
+	currentUser, _ := h.authService.GetCurrentUser(c.Request.Context(), currentUserID)
 	if currentUser == nil {
 		response.Unauthorized(c, "无法获取用户信息")
 		return
 	}
 
-	info, err := h.userService.UpdateUser(targetID, currentUser, &req)
+	info, err := h.userService.UpdateUser(c.Request.Context(), targetID, currentUser, &req)
 	if err != nil {
 		handleUserError(c, err)
 		return
 	}
+	// 管理员修改他人资料需要审计
+	if targetID != currentUserID {
+		c.Set(middleware.ContextKeyUserRole, currentUser.UserRole)
+		recordAudit(c, h.auditService, service.AuditActionUserUpdate, service.AuditTargetUser, targetID, c.Query("reason"))
+	}
 
 	response.OK(c, "更新成功", info)
 }
@@ -245,6 +286,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "用户ID"
+// @Param reason query string false "操作原因（记入审计日志）"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 404 {object} response.ErrorResponse "用户不存在"
 // @Router /users/{id} [delete]
@@ -255,10 +297,11 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.SoftDeleteUser(targetID); err != nil {
+	if err := h.userService.SoftDeleteUser(c.Request.Context(), targetID); err != nil {
 		handleUserError(c, err)
 		return
 	}
+	recordAudit(c, h.auditService, service.AuditActionUserDelete, service.AuditTargetUser, targetID, c.Query("reason"))
 
 	response.OK(c, "删除成功", nil)
 }
@@ -270,6 +313,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "用户ID"
+// @Param reason query string false "操作原因（记入审计日志）"
 // @Success 200 {object} response.Response "恢复成功"
 // @Failure 404 {object} response.ErrorResponse "用户不存在"
 // @Router /users/{id}/restore [post]
@@ -280,10 +324,11 @@ func (h *UserHandler) RestoreUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.RestoreUser(targetID); err != nil {
+	if err := h.userService.RestoreUser(c.Request.Context(), targetID); err != nil {
 		handleUserError(c, err)
 		return
 	}
+	recordAudit(c, h.auditService, service.AuditActionUserRestore, service.AuditTargetUser, targetID, c.Query("reason"))
 
 	response.OK(c, "恢复成功", nil)
 }
@@ -295,6 +340,7 @@ func (h *UserHandler) RestoreUser(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "用户ID"
+// @Param reason query string false "操作原因（记入审计日志）"
 // @Success 200 {object} response.Response{data=dto.UserInfo} "设置成功"
 // @Failure 404 {object} response.ErrorResponse "用户不存在"
 // @Router /users/{id}/set-admin [post]
@@ -305,15 +351,193 @@ func (h *UserHandler) SetAdmin(c *gin.Context) {
 		return
 	}
 
-	info, err := h.userService.SetAdminRole(targetID)
+	info, err := h.userService.SetAdminRole(c.Request.Context(), targetID)
 	if err != nil {
 		handleUserError(c, err)
 		return
 	}
+	recordAudit(c, h.auditService, service.AuditActionUserSetAdmin, service.AuditTargetUser, targetID, c.Query("reason"))
 
 	response.OK(c, "设置管理员角色成功", info)
 }
 
+// SetRole 设置用户角色
+// @Summary 设置用户角色（管理员）
+// @Description 设置指定用户的角色：user、moderator、admin
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param request body dto.SetRoleRequest true "角色"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response{data=dto.UserFullInfo} "设置成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/set-role [post]
+func (h *UserHandler) SetRole(c *gin.Context) {
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	var req dto.SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	info, err := h.userService.SetRole(c.Request.Context(), targetID, req.Role)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionUserSetRole, service.AuditTargetUser, targetID, c.Query("reason"))
+
+	response.OK(c, "设置成功", info)
+}
+
+// SuspendUser 封禁用户
+// @Summary 封禁用户（管理员）
+// @Description 封禁指定用户一段时间，封禁期间不能登录，到期自动解除
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param request body dto.UserRestrictionRequest true "封禁时长与原因"
+// @Success 200 {object} response.Response{data=dto.UserRestrictionInfo} "封禁成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/suspend [post]
+func (h *UserHandler) SuspendUser(c *gin.Context) {
+	h.applyRestriction(c, h.userService.SuspendUser, service.AuditActionUserSuspend, "封禁成功")
+}
+
+// UnsuspendUser 解除封禁
+// @Summary 解除封禁（管理员）
+// @Tags 用户
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response{data=dto.UserRestrictionInfo} "解除封禁成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/unsuspend [post]
+func (h *UserHandler) UnsuspendUser(c *gin.Context) {
+	h.liftRestriction(c, h.userService.UnsuspendUser, service.AuditActionUserUnsuspend, "解除封禁成功")
+}
+
+// MuteUser 禁言用户
+// @Summary 禁言用户（管理员）
+// @Description 禁言指定用户一段时间，禁言期间不能上传视频和发表评论，到期自动解除
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param request body dto.UserRestrictionRequest true "禁言时长与原因"
+// @Success 200 {object} response.Response{data=dto.UserRestrictionInfo} "禁言成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/mute [post]
+func (h *UserHandler) MuteUser(c *gin.Context) {
+	h.applyRestriction(c, h.userService.MuteUser, service.AuditActionUserMute, "禁言成功")
+}
+
+// UnmuteUser 解除禁言
+// @Summary 解除禁言（管理员）
+// @Tags 用户
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Success 200 {object} response.Response{data=dto.UserRestrictionInfo} "解除禁言成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/unmute [post]
+func (h *UserHandler) UnmuteUser(c *gin.Context) {
+	h.liftRestriction(c, h.userService.UnmuteUser, service.AuditActionUserUnmute, "解除禁言成功")
+}
+
+type restrictFunc func(ctx context.Context, userID int64, duration time.Duration, reason string) (*dto.UserRestrictionInfo, error)
+type liftRestrictionFunc func(ctx context.Context, userID int64) (*dto.UserRestrictionInfo, error)
+
+// Impersonate 模拟登录
+// @Summary 模拟登录（管理员）
+// @Description 签发以指定用户身份访问的短期令牌，用于排查账号问题。模拟期间的每个请求都会记入审计日志，
+// @Description 修改密码与账号信息、上传头像、注册推送设备、举报异常登录、删除视频和评论、打赏、授权第三方应用等敏感操作以及管理接口均不可用，也不返回推流码
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param request body dto.ImpersonateRequest true "模拟原因"
+// @Success 200 {object} response.Response{data=dto.TokenData} "签发成功"
+// @Failure 403 {object} response.ErrorResponse "不能模拟该用户"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /users/{id}/impersonate [post]
+func (h *UserHandler) Impersonate(c *gin.Context) {
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	var req dto.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	adminID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.authService.Impersonate(c.Request.Context(), adminID, targetID)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionUserImpersonate, service.AuditTargetUser, targetID, req.Reason)
+
+	response.OK(c, "签发成功", data)
+}
+
+func (h *UserHandler) applyRestriction(c *gin.Context, restrict restrictFunc, action, message string) {
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	var req dto.UserRestrictionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	info, err := restrict(c.Request.Context(), targetID, time.Duration(req.DurationHours)*time.Hour, req.Reason)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, action, service.AuditTargetUser, targetID, req.Reason)
+
+	response.OK(c, message, info)
+}
+
+func (h *UserHandler) liftRestriction(c *gin.Context, lift liftRestrictionFunc, action, message string) {
+	targetID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	info, err := lift(c.Request.Context(), targetID)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, action, service.AuditTargetUser, targetID, c.Query("reason"))
+
+	response.OK(c, message, info)
+}
+
 // ListUsers 获取用户列表
 // @Summary 获取用户列表（管理员）
 // @Description 分页获取用户列表
@@ -344,9 +568,9 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 		userRole = &v
 	}
 
-	data, err := h.userService.ListUsers(page, pageSize, username, userRole)
+	data, err := h.userService.ListUsers(c.Request.Context(), page, pageSize, username, userRole)
 	if err != nil {
-		logger.Error("List users failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("List users failed", zap.Error(err))
 		response.InternalError(c, "获取用户列表失败")
 		return
 	}
@@ -362,17 +586,21 @@ func parseIDParam(c *gin.Context) (int64, error) {
 func handleUserError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrUserNotFound):
-		response.NotFound(c, err.Error())
-	case errors.Is(err, service.ErrUsernameExists):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrUsernameExists), errors.Is(err, service.ErrUsernameReserved),
+		errors.Is(err, service.ErrUsernameInappropriate):
+		respondServiceError(c, http.StatusBadRequest, err)
 	case errors.Is(err, service.ErrUserDeleted):
-		response.Unauthorized(c, err.Error())
+		respondServiceError(c, http.StatusUnauthorized, err)
+	case errors.Is(err, service.ErrUserNoPermission), errors.Is(err, service.ErrBirthDateLocked),
+		errors.Is(err, service.ErrCannotImpersonate):
+		respondServiceError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrInvalidRole), errors.Is(err, service.ErrInvalidBirthDate):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrLegalHold):
+		respondServiceError(c, http.StatusLocked, err)
 	default:
-		if err.Error() == "没有权限修改该用户信息" {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		logger.Error("User operation failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("User operation failed", zap.Error(err))
 		response.InternalError(c, "操作失败，请稍后重试")
 	}
 }
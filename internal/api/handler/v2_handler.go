@@ -0,0 +1,501 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	v2DefaultLimit = 20
+	v2MaxLimit     = 100
+)
+
+// V2Handler /api/v2 列表接口：统一游标分页与响应格式，复用 v1 的 Service
+type V2Handler struct {
+	videoService        *service.VideoService
+	relationService     *service.RelationService
+	commentService      *service.CommentService
+	favoriteService     *service.FavoriteService
+	notificationService *service.NotificationService
+	messageService      *service.MessageService
+}
+
+func NewV2Handler(
+	videoService *service.VideoService,
+	relationService *service.RelationService,
+	commentService *service.CommentService,
+	favoriteService *service.FavoriteService,
+	notificationService *service.NotificationService,
+	messageService *service.MessageService,
+) *V2Handler {
+	return &V2Handler{
+		videoService:        videoService,
+		relationService:     relationService,
+		commentService:      commentService,
+		favoriteService:     favoriteService,
+		notificationService: notificationService,
+		messageService:      messageService,
+	}
+}
+
+// Feed 视频流
+// @Summary 视频流（v2）
+// @Description 游标分页获取已发布视频，登录时每项附带 viewer（是否点赞、是否关注作者、是否作者本人）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Param X-App-Key header string false "签名应用 Key（开启请求签名时必填，见 signing 配置）"
+// @Param X-Timestamp header int false "签名时间戳（Unix 秒）"
+// @Param X-Nonce header string false "签名随机串（8-64 字符，有效期内不可重复使用）"
+// @Param X-Signature header string false "请求签名"
+// @Success 200 {object} response.ListResponse{data=[]dto.VideoItem} "获取视频流成功"
+// @Failure 400 {object} response.ErrorResponse "无效的分页游标"
+// @Failure 401 {object} response.ErrorResponse "请求签名无效"
+// @Router /v2/videos/feed [get]
+func (h *V2Handler) Feed(c *gin.Context) {
+	cursor, limit := parseCursorPagination(c)
+	list, err := h.videoService.ListFeedByCursor(c.Request.Context(), cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	h.respondVideos(c, "获取视频流成功", list, limit)
+}
+
+// MyVideos 我的视频
+// @Summary 我的视频（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "视频状态"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.VideoItem} "获取成功"
+// @Router /v2/videos/my [get]
+func (h *V2Handler) MyVideos(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	cursor, limit := parseCursorPagination(c)
+
+	var status *string
+	if s := c.Query("status"); s != "" {
+		status = &s
+	}
+
+	list, err := h.videoService.ListMyVideosByCursor(c.Request.Context(), userID, status, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	h.respondVideos(c, "获取成功", list, limit)
+}
+
+// MyFavoritedVideos 我点赞的视频
+// @Summary 我点赞的视频（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.VideoItem} "获取成功"
+// @Router /v2/favorites/my/videos [get]
+func (h *V2Handler) MyFavoritedVideos(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	cursor, limit := parseCursorPagination(c)
+
+	list, err := h.favoriteService.ListFavoritedVideosByCursor(c.Request.Context(), userID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	h.respondVideos(c, "获取成功", list, limit)
+}
+
+// VideoFavorites 视频的点赞记录
+// @Summary 视频点赞记录（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.FavoriteInfo} "获取成功"
+// @Router /v2/videos/{id}/favorites [get]
+func (h *V2Handler) VideoFavorites(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	cursor, limit := parseCursorPagination(c)
+
+	list, err := h.favoriteService.ListByVideoByCursor(c.Request.Context(), videoID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	respondCursorList(c, "获取成功", list, limit)
+}
+
+// Following 用户的关注列表
+// @Summary 关注列表（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.UserItem} "获取关注列表成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /v2/users/{id}/following [get]
+func (h *V2Handler) Following(c *gin.Context) {
+	h.listUsers(c, "获取关注列表成功", h.relationService.ListFollowingByCursor)
+}
+
+// Followers 用户的粉丝列表
+// @Summary 粉丝列表（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.UserItem} "获取粉丝列表成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Router /v2/users/{id}/followers [get]
+func (h *V2Handler) Followers(c *gin.Context) {
+	h.listUsers(c, "获取粉丝列表成功", h.relationService.ListFollowersByCursor)
+}
+
+// Mutual 我的互相关注列表
+// @Summary 互相关注列表（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.UserItem} "获取成功"
+// @Router /v2/relations/mutual [get]
+func (h *V2Handler) Mutual(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	cursor, limit := parseCursorPagination(c)
+
+	list, err := h.relationService.ListMutualByCursor(c.Request.Context(), userID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	h.respondUsers(c, "获取成功", list, limit)
+}
+
+// VideoComments 视频的一级评论
+// @Summary 视频评论（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.CommentItem} "获取评论列表成功"
+// @Router /v2/videos/{id}/comments [get]
+func (h *V2Handler) VideoComments(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	cursor, limit := parseCursorPagination(c)
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	list, err := h.commentService.ListByVideoByCursor(c.Request.Context(), videoID, userID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	respondComments(c, "获取评论列表成功", list, limit)
+}
+
+// CommentReplies 评论的回复
+// @Summary 评论回复（v2）
+// @Description 回复按时间正序返回
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "评论ID"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.CommentItem} "获取回复列表成功"
+// @Failure 404 {object} response.ErrorResponse "评论不存在"
+// @Router /v2/comments/{id}/replies [get]
+func (h *V2Handler) CommentReplies(c *gin.Context) {
+	commentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的评论ID")
+		return
+	}
+	cursor, limit := parseCursorPagination(c)
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	list, err := h.commentService.ListRepliesByCursor(c.Request.Context(), commentID, userID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	respondComments(c, "获取回复列表成功", list, limit)
+}
+
+// MyComments 我的评论
+// @Summary 我的评论（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.CommentItem} "获取成功"
+// @Router /v2/comments/my [get]
+func (h *V2Handler) MyComments(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	cursor, limit := parseCursorPagination(c)
+
+	list, err := h.commentService.ListByUserByCursor(c.Request.Context(), userID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	respondComments(c, "获取成功", list, limit)
+}
+
+// Notifications 我的通知
+// @Summary 通知列表（v2）
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param unread_only query bool false "仅返回未读"
+// @Param type query string false "通知类型: like, comment, reply, follow, tip, digest, system"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.NotificationInfo} "获取成功"
+// @Router /v2/notifications [get]
+func (h *V2Handler) Notifications(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	cursor, limit := parseCursorPagination(c)
+	unreadOnly := c.Query("unread_only") == "true"
+
+	list, err := h.notificationService.ListByCursor(c.Request.Context(), userID, unreadOnly, c.Query("type"), cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	respondCursorList(c, "获取成功", list, limit)
+}
+
+// Conversations 我的会话
+// @Summary 会话列表（v2）
+// @Description 按最后一条消息时间倒序
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.ConversationInfo} "获取成功"
+// @Router /v2/messages/conversations [get]
+func (h *V2Handler) Conversations(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	cursor, limit := parseCursorPagination(c)
+
+	list, err := h.messageService.ListConversationsByCursor(c.Request.Context(), userID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	respondCursorList(c, "获取成功", list, limit)
+}
+
+// Messages 会话消息
+// @Summary 会话消息（v2）
+// @Description 按消息 ID 倒序
+// @Tags v2
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会话ID"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页数量" default(20)
+// @Success 200 {object} response.ListResponse{data=[]dto.MessageInfo} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "会话不存在"
+// @Router /v2/messages/conversations/{id} [get]
+func (h *V2Handler) Messages(c *gin.Context) {
+	conversationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的会话ID")
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+	cursor, limit := parseCursorPagination(c)
+
+	list, err := h.messageService.ListMessagesByCursor(c.Request.Context(), userID, conversationID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	respondCursorList(c, "获取成功", list, limit)
+}
+
+type cursorUserLister func(ctx context.Context, userID int64, after string, limit int) (*dto.CursorList[dto.RelationUserInfo], error)
+
+func (h *V2Handler) listUsers(c *gin.Context, message string, list cursorUserLister) {
+	userID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+	cursor, limit := parseCursorPagination(c)
+
+	data, err := list(c.Request.Context(), userID, cursor, limit)
+	if err != nil {
+		handleV2Error(c, err)
+		return
+	}
+	h.respondUsers(c, message, data, limit)
+}
+
+// respondVideos 对视频列表执行年龄限制，并附加当前用户的点赞、关注作者状态
+func (h *V2Handler) respondVideos(c *gin.Context, message string, list *dto.CursorList[dto.VideoInfo], limit int) {
+	viewerID, _ := middleware.GetCurrentUserID(c)
+	if err := h.videoService.ApplyContentGate(c.Request.Context(), viewerID, list.Items); err != nil {
+		handleV2Error(c, err)
+		return
+	}
+
+	items := make([]dto.VideoItem, len(list.Items))
+	for i := range list.Items {
+		items[i].VideoInfo = list.Items[i]
+	}
+
+	if viewerID != 0 && len(items) > 0 {
+		ctx := c.Request.Context()
+		videoIDs := make([]int64, len(items))
+		authorIDs := make([]int64, len(items))
+		for i, item := range items {
+			videoIDs[i] = item.ID
+			authorIDs[i] = item.AuthorID
+		}
+
+		favorited, err := h.favoriteService.BatchCheckStatus(ctx, viewerID, videoIDs)
+		if err != nil {
+			handleV2Error(c, err)
+			return
+		}
+		following, err := h.relationService.BatchCheckFollowStatus(ctx, viewerID, authorIDs)
+		if err != nil {
+			handleV2Error(c, err)
+			return
+		}
+
+		for i := range items {
+			items[i].Viewer = &dto.VideoViewerFlags{
+				Favorited:       favorited[items[i].ID],
+				FollowingAuthor: following[items[i].AuthorID],
+				IsAuthor:        items[i].AuthorID == viewerID,
+			}
+		}
+	}
+
+	respondPage(c, message, items, list.NextCursor, list.HasMore, limit)
+}
+
+// respondUsers 为用户列表附加当前用户的关注状态
+func (h *V2Handler) respondUsers(c *gin.Context, message string, list *dto.CursorList[dto.RelationUserInfo], limit int) {
+	items := make([]dto.UserItem, len(list.Items))
+	for i := range list.Items {
+		items[i].RelationUserInfo = list.Items[i]
+	}
+
+	if viewerID, ok := middleware.GetCurrentUserID(c); ok && len(items) > 0 {
+		userIDs := make([]int64, len(items))
+		for i, item := range items {
+			userIDs[i] = item.ID
+		}
+
+		following, err := h.relationService.BatchCheckFollowStatus(c.Request.Context(), viewerID, userIDs)
+		if err != nil {
+			handleV2Error(c, err)
+			return
+		}
+
+		for i := range items {
+			items[i].Viewer = &dto.UserViewerFlags{
+				Following: following[items[i].ID],
+				IsSelf:    items[i].ID == viewerID,
+			}
+		}
+	}
+
+	respondPage(c, message, items, list.NextCursor, list.HasMore, limit)
+}
+
+// respondComments 为评论列表附加当前用户是否为评论作者
+func respondComments(c *gin.Context, message string, list *dto.CursorList[dto.CommentInfo], limit int) {
+	viewerID, loggedIn := middleware.GetCurrentUserID(c)
+
+	items := make([]dto.CommentItem, len(list.Items))
+	for i := range list.Items {
+		items[i].CommentInfo = list.Items[i]
+		if loggedIn {
+			items[i].Viewer = &dto.CommentViewerFlags{IsOwner: list.Items[i].UserID == viewerID}
+		}
+	}
+
+	respondPage(c, message, items, list.NextCursor, list.HasMore, limit)
+}
+
+func respondCursorList[T any](c *gin.Context, message string, list *dto.CursorList[T], limit int) {
+	items := list.Items
+	if items == nil {
+		items = []T{}
+	}
+	respondPage(c, message, items, list.NextCursor, list.HasMore, limit)
+}
+
+func respondPage(c *gin.Context, message string, items interface{}, nextCursor string, hasMore bool, limit int) {
+	response.List(c, message, items, response.PageInfo{
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+		Limit:      limit,
+	})
+}
+
+// parseCursorPagination 解析 cursor 与 limit，limit 越界时使用默认值
+func parseCursorPagination(c *gin.Context) (string, int) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(v2DefaultLimit)))
+	if limit < 1 || limit > v2MaxLimit {
+		limit = v2DefaultLimit
+	}
+	return c.Query("cursor"), limit
+}
+
+func handleV2Error(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCursor):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, service.ErrVideoNotFound),
+		errors.Is(err, service.ErrCommentNotFound),
+		errors.Is(err, service.ErrConversationNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	default:
+		logger.FromContext(c.Request.Context()).Error("V2 list failed", zap.String("path", c.FullPath()), zap.Error(err))
+		response.InternalError(c, "操作失败，请稍后重试")
+	}
+}
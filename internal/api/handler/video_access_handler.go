@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VideoAccessHandler struct {
+	videoAccessService *service.VideoAccessService
+}
+
+func NewVideoAccessHandler(videoAccessService *service.VideoAccessService) *VideoAccessHandler {
+	return &VideoAccessHandler{videoAccessService: videoAccessService}
+}
+
+// ListGrants 私密视频授权列表
+// @Summary 获取私密视频的授权列表
+// @Description 作者分页查看可观看该视频的用户（直接授权或通过分享链接领取），以及是否已开启链接分享
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Success 200 {object} response.Response{data=dto.VideoAccessListData} "获取成功"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Router /videos/{id}/access [get]
+func (h *VideoAccessHandler) ListGrants(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	page, pageSize := parsePagination(c)
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.videoAccessService.ListGrants(c.Request.Context(), videoID, userID, page, pageSize)
+	if err != nil {
+		handleVideoAccessError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// Grant 授权用户观看私密视频
+// @Summary 授权用户观看私密视频
+// @Description 作者将视频授权给指定用户，视频设为私密后这些用户仍可查看详情和播放；不存在的用户忽略，已授权的用户不重复授权
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.VideoAccessGrantRequest true "被授权用户"
+// @Success 200 {object} response.Response{data=dto.VideoAccessGrantResult} "授权成功"
+// @Failure 400 {object} response.ErrorResponse "不能授权给作者本人"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Failure 409 {object} response.ErrorResponse "授权人数已达上限"
+// @Router /videos/{id}/access [post]
+func (h *VideoAccessHandler) Grant(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	var req dto.VideoAccessGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.videoAccessService.Grant(c.Request.Context(), videoID, userID, req.UserIDs)
+	if err != nil {
+		handleVideoAccessError(c, err)
+		return
+	}
+	response.OK(c, "授权成功", data)
+}
+
+// Revoke 取消授权
+// @Summary 取消用户的观看权限
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param user_id path int true "用户ID"
+// @Success 200 {object} response.Response "已取消授权"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Failure 404 {object} response.ErrorResponse "该用户未被授权"
+// @Router /videos/{id}/access/{user_id} [delete]
+func (h *VideoAccessHandler) Revoke(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	targetID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	if err := h.videoAccessService.Revoke(c.Request.Context(), videoID, userID, targetID); err != nil {
+		handleVideoAccessError(c, err)
+		return
+	}
+	response.OK(c, "已取消授权", nil)
+}
+
+// CreateShareLink 生成分享链接
+// @Summary 生成私密视频的分享链接
+// @Description 生成新的分享令牌，原有链接随之失效（已领取权限的用户不受影响）。令牌只在此时返回一次，登录用户凭令牌领取观看权限
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoShareLinkData} "生成成功"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Router /videos/{id}/access/link [post]
+func (h *VideoAccessHandler) CreateShareLink(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.videoAccessService.CreateShareLink(c.Request.Context(), videoID, userID)
+	if err != nil {
+		handleVideoAccessError(c, err)
+		return
+	}
+	response.OK(c, "生成成功", data)
+}
+
+// RevokeShareLink 关闭链接分享
+// @Summary 关闭私密视频的链接分享
+// @Description 分享链接失效后无法再领取权限，已领取权限的用户需单独取消授权
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response "已关闭链接分享"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Router /videos/{id}/access/link [delete]
+func (h *VideoAccessHandler) RevokeShareLink(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	if err := h.videoAccessService.RevokeShareLink(c.Request.Context(), videoID, userID); err != nil {
+		handleVideoAccessError(c, err)
+		return
+	}
+	response.OK(c, "已关闭链接分享", nil)
+}
+
+// RedeemShareLink 领取观看权限
+// @Summary 凭分享链接领取私密视频的观看权限
+// @Description 令牌有效时将当前用户加入授权列表并返回视频详情
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.VideoShareLinkRedeemRequest true "分享令牌"
+// @Success 200 {object} response.Response{data=dto.VideoInfo} "领取成功"
+// @Failure 404 {object} response.ErrorResponse "分享链接无效或已失效"
+// @Failure 409 {object} response.ErrorResponse "授权人数已达上限"
+// @Router /videos/{id}/access/redeem [post]
+func (h *VideoAccessHandler) RedeemShareLink(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	var req dto.VideoShareLinkRedeemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	info, err := h.videoAccessService.RedeemShareLink(c.Request.Context(), videoID, userID, req.Token)
+	if err != nil {
+		handleVideoAccessError(c, err)
+		return
+	}
+	response.OK(c, "领取成功", info)
+}
+
+func handleVideoAccessError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVideoAccessSelf):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrVideoGrantNotFound), errors.Is(err, service.ErrShareLinkInvalid):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrVideoGrantLimit):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		handleVideoError(c, err)
+	}
+}
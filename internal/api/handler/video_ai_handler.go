@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+	"vida-go/pkg/i18n"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type VideoAIHandler struct {
+	videoAIService *service.VideoAIService
+}
+
+func NewVideoAIHandler(videoAIService *service.VideoAIService) *VideoAIHandler {
+	return &VideoAIHandler{videoAIService: videoAIService}
+}
+
+// GetTags 获取视频标签
+// @Summary 获取视频标签
+// @Description 获取视频的标签与分类（公开接口），source 为 machine 表示发布后由 AI 生成，author 表示作者添加
+// @Tags 视频
+// @Produce json
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoTagsData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /videos/{id}/tags [get]
+func (h *VideoAIHandler) GetTags(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	data, err := h.videoAIService.GetTags(c.Request.Context(), videoID)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// UpdateTags 编辑视频标签
+// @Summary 编辑视频标签
+// @Description 作者整体替换视频的标签（最多 20 个）与分类（最多 3 个），保留的 AI 标签仍标记为 machine
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param body body dto.VideoTagsUpdateRequest true "标签与分类"
+// @Success 200 {object} response.Response{data=dto.VideoTagsData} "更新成功"
+// @Failure 403 {object} response.ErrorResponse "无权限"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /videos/{id}/tags [put]
+func (h *VideoAIHandler) UpdateTags(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	var req dto.VideoTagsUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.videoAIService.UpdateTags(c.Request.Context(), videoID, userID, &req)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "更新成功", data)
+}
+
+// Ask 视频问答
+// @Summary 视频问答（SSE）
+// @Description 针对视频提问，结合视频标题、描述、AI 摘要、关键时刻与标签由 Agent 服务流式回答。
+// @Description 回复以 Server-Sent Events 返回：message 事件 data 为 {"content": "片段"}，结束时发送 done 事件，出错时发送 error 事件 {"message": "..."}。
+// @Description 每个用户每分钟、每天的提问次数有限制，响应头 X-Ask-Quota-Remaining 为今日剩余次数
+// @Tags 视频
+// @Accept json
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param body body dto.VideoAskRequest true "问题"
+// @Success 200 {string} string "回答流"
+// @Failure 403 {object} response.ErrorResponse "年龄限制或会员专属视频"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 451 {object} response.ErrorResponse "所在地区不可观看"
+// @Failure 429 {object} response.ErrorResponse "提问过于频繁或今日次数已用完"
+// @Failure 503 {object} response.ErrorResponse "AI 服务暂不可用"
+// @Router /videos/{id}/ask [post]
+func (h *VideoAIHandler) Ask(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	var req dto.VideoAskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	askReq, remaining, err := h.videoAIService.PrepareAsk(c.Request.Context(), videoID, userID, req.Question)
+	if err != nil {
+		handleVideoAIError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Header("X-Ask-Quota-Remaining", strconv.Itoa(remaining))
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	writeEvent := func(event string, data interface{}) error {
+		payload, _ := json.Marshal(data)
+		if _, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	err = h.videoAIService.Ask(c.Request.Context(), askReq, func(content string) error {
+		return writeEvent("message", gin.H{"content": content})
+	})
+	if err != nil {
+		if c.Request.Context().Err() != nil {
+			return
+		}
+		logger.FromContext(c.Request.Context()).Error("Ask video failed", zap.Int64("video_id", videoID), zap.Int64("user_id", userID), zap.Error(err))
+		_ = writeEvent("error", gin.H{"message": i18n.T(response.Locale(c), "AI 服务暂不可用")})
+		return
+	}
+	_ = writeEvent("done", gin.H{})
+}
+
+func handleVideoAIError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAskRateLimited), errors.Is(err, service.ErrAskQuotaExceeded):
+		respondServiceError(c, http.StatusTooManyRequests, err)
+	case errors.Is(err, service.ErrAgentUnavailable):
+		respondServiceError(c, http.StatusServiceUnavailable, err)
+	case errors.Is(err, service.ErrStreamAgeRestricted), errors.Is(err, service.ErrStreamMembersOnly):
+		respondServiceError(c, http.StatusForbidden, err)
+	default:
+		handleVideoError(c, err)
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newETagTestVideo() *dto.VideoInfo {
+	return &dto.VideoInfo{
+		ID:            1,
+		Status:        "published",
+		PlayURL:       "https://cdn.example.com/1.mp4",
+		UpdatedAt:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		FavoriteCount: 3,
+		Reactions:     dto.ReactionCounts{Like: 2, Love: 1},
+		Reaction:      "like",
+		PlaybackState: "ready",
+		Poll: &dto.VideoPollInfo{
+			ID:         7,
+			VideoID:    1,
+			Options:    []dto.VideoPollOptionInfo{{ID: 101, Votes: 4}, {ID: 102, Votes: 1}},
+			TotalVotes: 5,
+			EndsAt:     time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+// serveVideo 与 GetDetail、Feed 一样通过 NotModified 处理条件请求，返回状态码与 ETag
+func serveVideo(t *testing.T, etag string, ifNoneMatch string) (int, string) {
+	t.Helper()
+	r := gin.New()
+	r.GET("/video", func(c *gin.Context) {
+		if response.NotModified(c, etag) {
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/video", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code, w.Header().Get("ETag")
+}
+
+func TestVideoETagNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		mutate func(v *dto.VideoInfo)
+		want   int
+	}{
+		{name: "unchanged", mutate: func(v *dto.VideoInfo) {}, want: http.StatusNotModified},
+		{name: "view count only", mutate: func(v *dto.VideoInfo) { v.ViewCount++ }, want: http.StatusNotModified},
+		{name: "favorited", mutate: func(v *dto.VideoInfo) { v.FavoriteCount++ }, want: http.StatusOK},
+		{name: "viewer favorited", mutate: func(v *dto.VideoInfo) {
+			favorited := true
+			v.IsFavorited = &favorited
+		}, want: http.StatusOK},
+		{name: "edited", mutate: func(v *dto.VideoInfo) { v.UpdatedAt = v.UpdatedAt.Add(time.Second) }, want: http.StatusOK},
+		{name: "poll vote", mutate: func(v *dto.VideoInfo) {
+			v.Poll.Options[1].Votes++
+			v.Poll.TotalVotes++
+		}, want: http.StatusOK},
+		{name: "viewer votes", mutate: func(v *dto.VideoInfo) {
+			optionID := int64(102)
+			v.Poll.VotedOptionID = &optionID
+		}, want: http.StatusOK},
+		{name: "poll closed", mutate: func(v *dto.VideoInfo) { v.Poll.Closed = true }, want: http.StatusOK},
+		{name: "poll replaced", mutate: func(v *dto.VideoInfo) { v.Poll.ID = 8 }, want: http.StatusOK},
+		{name: "reaction switched", mutate: func(v *dto.VideoInfo) {
+			// 切换表态类型：合计数与 updated_at 不变
+			v.Reactions.Like--
+			v.Reactions.Love++
+			v.Reaction = "love"
+		}, want: http.StatusOK},
+		{name: "other viewer switched reaction", mutate: func(v *dto.VideoInfo) {
+			v.Reactions.Like--
+			v.Reactions.Wow++
+		}, want: http.StatusOK},
+		{name: "viewer reaction only", mutate: func(v *dto.VideoInfo) { v.Reaction = "wow" }, want: http.StatusOK},
+		{name: "rehydrating", mutate: func(v *dto.VideoInfo) {
+			// 冷存储转换不更新 updated_at
+			v.PlaybackState = "preparing"
+			v.PlayURL = ""
+		}, want: http.StatusOK},
+		{name: "play url changed", mutate: func(v *dto.VideoInfo) { v.PlayURL = "https://cdn.example.com/1-hot.mp4" }, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newETagTestVideo()
+			code, etag := serveVideo(t, videoETag("zh-CN", v), "")
+			if code != http.StatusOK || etag == "" {
+				t.Fatalf("first request = %d with ETag %q, want 200 with an ETag", code, etag)
+			}
+
+			tt.mutate(v)
+			if code, _ := serveVideo(t, videoETag("zh-CN", v), etag); code != tt.want {
+				t.Errorf("conditional request = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoListETagNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	data := &dto.VideoListData{Page: 1, PageSize: 20, Total: 1, Videos: []dto.VideoInfo{*newETagTestVideo()}}
+	_, etag := serveVideo(t, videoListETag("zh-CN", data), "")
+
+	if code, _ := serveVideo(t, videoListETag("zh-CN", data), etag); code != http.StatusNotModified {
+		t.Fatalf("unchanged list = %d, want 304", code)
+	}
+	if code, _ := serveVideo(t, videoListETag("en-US", data), etag); code != http.StatusOK {
+		t.Errorf("list in another locale = %d, want 200", code)
+	}
+}
+
+func TestVideoListETagTracksReactions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	data := &dto.VideoListData{Page: 1, PageSize: 20, Total: 1, Videos: []dto.VideoInfo{*newETagTestVideo()}}
+	_, etag := serveVideo(t, videoListETag("zh-CN", data), "")
+
+	data.Videos[0].Reactions.Like--
+	data.Videos[0].Reactions.Laugh++
+	data.Videos[0].Reaction = "laugh"
+	if code, _ := serveVideo(t, videoListETag("zh-CN", data), etag); code != http.StatusOK {
+		t.Errorf("list after reaction switch = %d, want 200", code)
+	}
+}
+
+// 播放状态为 preparing 时客户端会反复获取详情，恢复完成后不能再返回 304
+func TestVideoETagAfterRehydration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	v := newETagTestVideo()
+	v.PlaybackState = "preparing"
+	v.PlayURL = ""
+	_, etag := serveVideo(t, videoETag("zh-CN", v), "")
+	if code, _ := serveVideo(t, videoETag("zh-CN", v), etag); code != http.StatusNotModified {
+		t.Fatalf("still preparing = %d, want 304", code)
+	}
+
+	v.PlaybackState = "ready"
+	v.PlayURL = "https://cdn.example.com/1.mp4"
+	if code, _ := serveVideo(t, videoETag("zh-CN", v), etag); code != http.StatusOK {
+		t.Errorf("after rehydration = %d, want 200", code)
+	}
+}
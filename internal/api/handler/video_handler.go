@@ -2,7 +2,10 @@ package handler
 
 import (
 	"errors"
+	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"vida-go/internal/api/dto"
 	"vida-go/internal/api/middleware"
@@ -15,83 +18,85 @@ import (
 )
 
 type VideoHandler struct {
-	videoService *service.VideoService
+	videoService     *service.VideoService
+	videoStatService *service.VideoStatService
+	auditService     *service.AuditService
 }
 
-func NewVideoHandler(videoService *service.VideoService) *VideoHandler {
-	return &VideoHandler{videoService: videoService}
+func NewVideoHandler(videoService *service.VideoService, videoStatService *service.VideoStatService, auditService *service.AuditService) *VideoHandler {
+	return &VideoHandler{videoService: videoService, videoStatService: videoStatService, auditService: auditService}
 }
 
 // Upload 上传视频
 // @Summary 上传视频
-// @Description 上传视频文件，支持 mp4, avi, mov, mkv, flv, webm 格式
+// @Description 上传视频文件。允许的格式、文件大小、视频时长和每日上传数量按用户角色配置（见 upload.limits），超出时长的视频转码失败
 // @Tags 视频
 // @Accept multipart/form-data
 // @Produce json
 // @Security BearerAuth
 // @Param title formData string true "视频标题"
 // @Param description formData string false "视频描述"
+// @Param remix_of_id formData int false "合拍、拼接或二创时引用的原视频ID"
+// @Param remix_type formData string false "引用方式：duet（合拍）、stitch（拼接）、remix（二创，默认）"
+// @Param is_sponsored formData bool false "是否含付费推广"
+// @Param sponsor_disclosure formData string false "推广声明（如合作品牌），is_sponsored 为 true 时必填"
 // @Param video_file formData file true "视频文件"
 // @Success 200 {object} response.Response "上传成功"
-// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效或付费推广视频未填写推广声明"
 // @Failure 401 {object} response.ErrorResponse "未授权"
+// @Failure 403 {object} response.ErrorResponse "原视频作者不允许合拍或二创"
+// @Failure 413 {object} response.ErrorResponse "文件超出上传者角色的大小上限"
+// @Failure 429 {object} response.ErrorResponse "今日上传数量已达上限"
 // @Router /videos/upload [post]
 func (h *VideoHandler) Upload(c *gin.Context) {
-	var req dto.VideoUploadRequest
-	if err := c.ShouldBind(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
-		return
-	}
+	currentUserID, _ := middleware.GetCurrentUserID(c)
 
-	file, err := c.FormFile("video_file")
+	// 按上传者角色的文件大小上限在读取请求体前拒绝过大的上传
+	limit, err := h.videoService.UploadLimitFor(c.Request.Context(), currentUserID)
 	if err != nil {
-		response.BadRequest(c, "请上传视频文件")
-		return
-	}
-
-	allowedFormats := map[string]bool{
-		".mp4": true, ".avi": true, ".mov": true,
-		".mkv": true, ".flv": true, ".webm": true,
-	}
-
-	ext := ""
-	if len(file.Filename) > 0 {
-		for i := len(file.Filename) - 1; i >= 0; i-- {
-			if file.Filename[i] == '.' {
-				ext = file.Filename[i:]
-				break
-			}
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondServiceError(c, http.StatusNotFound, err)
+			return
 		}
-	}
-
-	if !allowedFormats[ext] {
-		response.BadRequest(c, "不支持的文件格式，支持: mp4, avi, mov, mkv, flv, webm")
+		response.InternalError(c, "上传视频失败")
 		return
 	}
-
-	maxSize := int64(500 * 1024 * 1024) // 500MB
-	if file.Size > maxSize || file.Size == 0 {
-		response.BadRequest(c, "文件大小无效（不能为空，最大 500MB）")
+	if !middleware.LimitBody(c, limit.MaxSize()+UploadOverheadBytes) {
 		return
 	}
 
-	currentUserID, _ := middleware.GetCurrentUserID(c)
-
-	fileFormat := ext
-	if len(fileFormat) > 0 && fileFormat[0] == '.' {
-		fileFormat = fileFormat[1:]
+	// 视频文件流式写入临时文件，不在内存中缓冲
+	file, err := streamUpload(c, "video_file", limit.MaxSize())
+	if err != nil {
+		respondUploadError(c, err, "请上传视频文件")
+		return
 	}
+	defer file.Close()
 
-	f, err := file.Open()
-	if err != nil {
-		response.InternalError(c, "打开上传文件失败")
+	var req dto.VideoUploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondBindError(c, err)
 		return
 	}
-	defer f.Close()
 
-	info, err := h.videoService.Upload(currentUserID, &req, f, file.Size, fileFormat)
+	// 格式、大小和每日数量按用户角色在 Service 层校验
+	fileFormat := strings.TrimPrefix(filepath.Ext(file.Filename), ".")
+
+	info, err := h.videoService.Upload(c.Request.Context(), currentUserID, &req, file, file.Size, fileFormat)
 	if err != nil {
-		logger.Error("Upload video failed", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrUserMuted), errors.Is(err, service.ErrRemixNotAllowed):
+			respondServiceError(c, http.StatusForbidden, err)
+			return
+		case errors.Is(err, service.ErrUnsupportedFormat), errors.Is(err, service.ErrInvalidFileSize),
+			errors.Is(err, service.ErrRemixSourceNotFound), errors.Is(err, service.ErrSponsorDisclosureRequired):
+			respondServiceError(c, http.StatusBadRequest, err)
+			return
+		case errors.Is(err, service.ErrDailyUploadLimit):
+			respondServiceError(c, http.StatusTooManyRequests, err)
+			return
+		}
+		logger.FromContext(c.Request.Context()).Error("Upload video failed", zap.Error(err))
 		response.InternalError(c, "上传视频失败: "+err.Error())
 		return
 	}
@@ -104,35 +109,64 @@ func (h *VideoHandler) Upload(c *gin.Context) {
 
 // GetFeed 获取视频流
 // @Summary 获取视频流
-// @Description 获取视频列表（公开接口，不需要登录）
+// @Description 获取视频列表（公开接口，不需要登录），登录时每个视频附带 is_favorited、is_following。年龄限制视频对未登录、未填写出生日期或未满最低年龄的用户只返回 restricted 占位信息
 // @Tags 视频
 // @Produce json
+// @Security BearerAuth
 // @Param page query int false "页码" default(1)
 // @Param page_size query int false "每页数量" default(10)
+// @Param If-None-Match header string false "上次响应的 ETag"
+// @Param X-App-Key header string false "签名应用 Key（开启请求签名时必填，见 signing 配置）"
+// @Param X-Timestamp header int false "签名时间戳（Unix 秒）"
+// @Param X-Nonce header string false "签名随机串（8-64 字符，有效期内不可重复使用）"
+// @Param X-Signature header string false "请求签名"
 // @Success 200 {object} response.Response{data=dto.VideoListData} "获取成功"
+// @Success 304 "内容未变化"
+// @Failure 401 {object} response.ErrorResponse "请求签名无效"
 // @Router /videos/feed [get]
 func (h *VideoHandler) GetFeed(c *gin.Context) {
 	page, pageSize := parsePagination(c)
 
-	data, err := h.videoService.GetFeed(page, pageSize)
+	data, err := h.videoService.GetFeed(c.Request.Context(), page, pageSize)
 	if err != nil {
-		logger.Error("Get video feed failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Get video feed failed", zap.Error(err))
 		response.InternalError(c, "获取视频流失败")
 		return
 	}
 
+	viewerID, ok := middleware.GetCurrentUserID(c)
+	if err := h.videoService.ApplyContentGate(c.Request.Context(), viewerID, data.Videos); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Apply age gate failed", zap.Error(err))
+		response.InternalError(c, "获取视频流失败")
+		return
+	}
+	if ok {
+		if err := h.videoService.FillViewerState(c.Request.Context(), viewerID, data.Videos); err != nil {
+			logger.FromContext(c.Request.Context()).Error("Fill viewer state failed", zap.Int64("user_id", viewerID), zap.Error(err))
+			response.InternalError(c, "获取视频流失败")
+			return
+		}
+	}
+
+	if response.NotModified(c, videoListETag(response.Locale(c), data)) {
+		return
+	}
+
 	response.OK(c, "获取视频流成功", data)
 }
 
 // GetDetail 获取视频详情
 // @Summary 获取视频详情
-// @Description 根据视频ID获取视频详细信息
+// @Description 根据视频ID获取视频详细信息，附带当前用户的 is_favorited、is_following，以及引用了该视频的视频数量和最新几条（remix_count、remixes）。视频在请求来源国家/地区不可见时返回 451（作者本人不受限制）。年龄限制视频对不满足条件的观看者清空播放地址等内容并返回 restricted 占位信息
 // @Tags 视频
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "视频ID"
+// @Param If-None-Match header string false "上次响应的 ETag"
 // @Success 200 {object} response.Response{data=dto.VideoInfo} "获取成功"
+// @Success 304 "内容未变化"
 // @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 451 {object} response.ErrorResponse "所在地区不可观看"
 // @Router /videos/{id} [get]
 func (h *VideoHandler) GetDetail(c *gin.Context) {
 	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -141,15 +175,194 @@ func (h *VideoHandler) GetDetail(c *gin.Context) {
 		return
 	}
 
-	info, err := h.videoService.GetDetail(videoID)
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	info, err := h.videoService.GetDetail(c.Request.Context(), videoID, currentUserID)
 	if err != nil {
 		handleVideoError(c, err)
 		return
 	}
 
+	videos := []dto.VideoInfo{*info}
+	if err := h.videoService.FillViewerState(c.Request.Context(), currentUserID, videos); err != nil {
+		handleVideoError(c, err)
+		return
+	}
+	info = &videos[0]
+
+	if response.NotModified(c, videoETag(response.Locale(c), info)) {
+		return
+	}
+
 	response.OK(c, "获取视频详情成功", info)
 }
 
+// ListRemixes 获取引用了该视频的视频
+// @Summary 获取合拍、二创列表
+// @Description 分页获取引用该视频合拍、拼接或二创的已发布视频，按发布时间倒序
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Success 200 {object} response.Response{data=dto.VideoListData} "获取成功"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /videos/{id}/remixes [get]
+func (h *VideoHandler) ListRemixes(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	page, pageSize := parsePagination(c)
+
+	viewerID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.videoService.ListRemixes(c.Request.Context(), videoID, viewerID, page, pageSize)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// PinVideo 置顶视频
+// @Summary 置顶视频到主页
+// @Description 将自己已发布的视频置顶到主页视频列表最前，每人最多置顶一个视频，置顶新视频时替换之前的置顶
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoInfo} "置顶成功"
+// @Failure 400 {object} response.ErrorResponse "只能置顶已发布的视频"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Router /videos/{id}/pin [post]
+func (h *VideoHandler) PinVideo(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	info, err := h.videoService.Pin(c.Request.Context(), videoID, currentUserID)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "置顶成功", info)
+}
+
+// UnpinVideo 取消置顶
+// @Summary 取消视频置顶
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=dto.VideoInfo} "已取消置顶"
+// @Failure 403 {object} response.ErrorResponse "没有权限"
+// @Router /videos/{id}/pin [delete]
+func (h *VideoHandler) UnpinVideo(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	info, err := h.videoService.Unpin(c.Request.Context(), videoID, currentUserID)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "已取消置顶", info)
+}
+
+// GetRemixSettings 获取二创设置
+// @Summary 获取二创设置
+// @Description 是否允许他人引用自己的视频合拍、拼接或二创
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.RemixSettings} "获取成功"
+// @Router /users/me/remix-settings [get]
+func (h *VideoHandler) GetRemixSettings(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.videoService.GetRemixSettings(c.Request.Context(), userID)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
+// UpdateRemixSettings 更新二创设置
+// @Summary 更新二创设置
+// @Description 关闭后他人不能再引用自己的视频上传，已发布的引用视频不受影响
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.RemixSettingsUpdateRequest true "是否允许二创"
+// @Success 200 {object} response.Response{data=dto.RemixSettings} "更新成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Router /users/me/remix-settings [put]
+func (h *VideoHandler) UpdateRemixSettings(c *gin.Context) {
+	var req dto.RemixSettingsUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.videoService.UpdateRemixSettings(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "更新成功", data)
+}
+
+// GetStats 获取视频每日统计
+// @Summary 获取视频统计
+// @Description 获取视频最近 N 天（含今天，按 UTC 日期）的播放、点赞、评论与平均观看时长，仅作者本人可查看
+// @Tags 视频
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param range query string false "统计范围，如 7d、30d，最多 90d" default(30d)
+// @Success 200 {object} response.Response{data=dto.VideoStatsData} "获取成功"
+// @Failure 400 {object} response.ErrorResponse "无效的统计范围"
+// @Failure 403 {object} response.ErrorResponse "无权限"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /videos/{id}/stats [get]
+func (h *VideoHandler) GetStats(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	days, ok := parseDayRange(c.DefaultQuery("range", "30d"), service.MaxVideoStatDays)
+	if !ok {
+		response.BadRequest(c, "无效的统计范围")
+		return
+	}
+
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.videoStatService.GetVideoStats(c.Request.Context(), videoID, currentUserID, days)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "获取成功", data)
+}
+
 // GetMyVideos 获取我的视频列表
 // @Summary 获取我的视频列表
 // @Description 获取当前用户上传的视频列表
@@ -171,9 +384,9 @@ func (h *VideoHandler) GetMyVideos(c *gin.Context) {
 		status = &v
 	}
 
-	data, err := h.videoService.GetMyVideos(currentUserID, page, pageSize, status)
+	data, err := h.videoService.GetMyVideos(c.Request.Context(), currentUserID, page, pageSize, status)
 	if err != nil {
-		logger.Error("Get my videos failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Get my videos failed", zap.Error(err))
 		response.InternalError(c, "获取我的视频列表失败")
 		return
 	}
@@ -183,7 +396,7 @@ func (h *VideoHandler) GetMyVideos(c *gin.Context) {
 
 // UpdateVideo 更新视频信息
 // @Summary 更新视频信息
-// @Description 更新视频的标题、描述等信息
+// @Description 更新视频的标题、描述、可见范围等信息，设为私密后仅作者及被授权的用户可见。标记为付费推广时必须填写推广声明
 // @Tags 视频
 // @Accept json
 // @Produce json
@@ -191,7 +404,7 @@ func (h *VideoHandler) GetMyVideos(c *gin.Context) {
 // @Param id path int true "视频ID"
 // @Param request body dto.VideoUpdateRequest true "更新信息"
 // @Success 200 {object} response.Response{data=dto.VideoInfo} "更新成功"
-// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效或付费推广视频未填写推广声明"
 // @Failure 403 {object} response.ErrorResponse "无权限"
 // @Failure 404 {object} response.ErrorResponse "视频不存在"
 // @Router /videos/{id} [put]
@@ -204,13 +417,13 @@ func (h *VideoHandler) UpdateVideo(c *gin.Context) {
 
 	var req dto.VideoUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求参数无效: "+err.Error())
+		respondBindError(c, err)
 		return
 	}
 
 	currentUserID, _ := middleware.GetCurrentUserID(c)
 
-	info, err := h.videoService.Update(videoID, currentUserID, &req)
+	info, err := h.videoService.Update(c.Request.Context(), videoID, currentUserID, &req)
 	if err != nil {
 		handleVideoError(c, err)
 		return
@@ -219,6 +432,79 @@ func (h *VideoHandler) UpdateVideo(c *gin.Context) {
 	response.OK(c, "更新视频成功", info)
 }
 
+// UpdateRegions 设置视频地区限制
+// @Summary 设置视频地区限制
+// @Description 作者设置视频可见的国家/地区（整体替换）：allowed_regions 非空时仅这些地区可见，blocked_regions 中的地区不可见。限制对视频详情、视频流和搜索生效，作者本人不受限制
+// @Tags 视频
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.VideoRegionsRequest true "地区代码（ISO 3166-1 alpha-2）"
+// @Success 200 {object} response.Response{data=dto.VideoInfo} "设置成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 403 {object} response.ErrorResponse "无权限"
+// @Router /videos/{id}/regions [put]
+func (h *VideoHandler) UpdateRegions(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	var req dto.VideoRegionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	currentUserID, _ := middleware.GetCurrentUserID(c)
+	info, err := h.videoService.UpdateRegions(c.Request.Context(), videoID, currentUserID, &req)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+
+	response.OK(c, "设置成功", info)
+}
+
+// SetRegions 管理员设置视频地区限制
+// @Summary 设置视频地区限制（管理员）
+// @Description 因版权、合规要求限制视频可见的国家/地区，规则同作者设置
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param reason query string false "操作原因（记入审计日志）"
+// @Param request body dto.VideoRegionsRequest true "地区代码（ISO 3166-1 alpha-2）"
+// @Success 200 {object} response.Response{data=dto.VideoInfo} "设置成功"
+// @Failure 400 {object} response.ErrorResponse "请求参数无效"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Router /admin/videos/{id}/regions [put]
+func (h *VideoHandler) SetRegions(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+
+	var req dto.VideoRegionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	info, err := h.videoService.SetRegions(c.Request.Context(), videoID, &req)
+	if err != nil {
+		handleVideoError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionVideoSetRegions, service.AuditTargetVideo, videoID, c.Query("reason"))
+
+	response.OK(c, "设置成功", info)
+}
+
 // DeleteVideo 删除视频
 // @Summary 删除视频
 // @Description 删除指定的视频
@@ -239,24 +525,104 @@ func (h *VideoHandler) DeleteVideo(c *gin.Context) {
 
 	currentUserID, _ := middleware.GetCurrentUserID(c)
 
-	if err := h.videoService.Delete(videoID, currentUserID); err != nil {
+	if err := h.videoService.Delete(c.Request.Context(), videoID, currentUserID); err != nil {
 		handleVideoError(c, err)
 		return
 	}
+	recordAudit(c, h.auditService, service.AuditActionVideoDelete, service.AuditTargetVideo, videoID, c.Query("reason"))
 
 	response.OK(c, "删除视频成功", nil)
 }
 
+// parseDayRange 解析 "30d" 形式的天数范围，要求 1 <= 天数 <= maxDays
+func parseDayRange(value string, maxDays int) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil || !strings.HasSuffix(value, "d") || n < 1 || n > maxDays {
+		return 0, false
+	}
+	return n, true
+}
+
+// videoVersionParts 视频的版本字段（不含播放量，避免每次访问都使缓存失效）
+func videoVersionParts(v *dto.VideoInfo) []interface{} {
+	parts := []interface{}{v.ID, v.Status, v.UpdatedAt.UnixNano(), v.FavoriteCount, v.CommentCount, v.IsPinned}
+	// 冷存储转换只更新存储层级，不改变 updated_at；恢复完成后播放状态与地址变化需使缓存失效
+	parts = append(parts, v.PlaybackState, v.PlayURL)
+	// 切换表态类型不改变合计数与 updated_at，需单独计入各类型表态数
+	parts = append(parts, v.Reactions.Like, v.Reactions.Love, v.Reactions.Laugh, v.Reactions.Wow)
+	if v.Author != nil {
+		avatar := ""
+		if v.Author.Avatar != nil {
+			avatar = *v.Author.Avatar
+		}
+		parts = append(parts, v.Author.Username, avatar)
+	}
+	// 点赞、关注状态及表态类型因人而异，也需参与 ETag 计算
+	if v.IsFavorited != nil {
+		parts = append(parts, *v.IsFavorited)
+	}
+	if v.IsFollowing != nil {
+		parts = append(parts, *v.IsFollowing)
+	}
+	if v.Reaction != "" {
+		parts = append(parts, v.Reaction)
+	}
+	// 年龄限制占位因观看者而异
+	if v.Restricted != nil {
+		parts = append(parts, v.Restricted.Code)
+	}
+	// 投票实时结果与当前用户所投的选项，投票不会更新视频的 updated_at
+	if v.Poll != nil {
+		parts = append(parts, v.Poll.ID, v.Poll.TotalVotes, v.Poll.Closed, v.Poll.EndsAt.Unix())
+		for _, o := range v.Poll.Options {
+			parts = append(parts, o.ID, o.Votes)
+		}
+		if v.Poll.VotedOptionID != nil {
+			parts = append(parts, *v.Poll.VotedOptionID)
+		}
+	}
+	// 详情附带的引用视频
+	if v.RemixCount != nil {
+		parts = append(parts, *v.RemixCount)
+		for i := range v.Remixes {
+			parts = append(parts, videoVersionParts(&v.Remixes[i])...)
+		}
+	}
+	return parts
+}
+
+// 响应信息随语言变化，ETag 需按语言区分
+func videoETag(lang string, v *dto.VideoInfo) string {
+	return response.WeakETag(append([]interface{}{lang}, videoVersionParts(v)...)...)
+}
+
+func videoListETag(lang string, data *dto.VideoListData) string {
+	parts := []interface{}{lang, data.Page, data.PageSize, data.Total}
+	for i := range data.Videos {
+		parts = append(parts, videoVersionParts(&data.Videos[i])...)
+	}
+	return response.WeakETag(parts...)
+}
+
 func handleVideoError(c *gin.Context, err error) {
 	switch {
-	case errors.Is(err, service.ErrVideoNotFound):
-		response.NotFound(c, err.Error())
+	case errors.Is(err, service.ErrVideoNotFound), errors.Is(err, service.ErrMembershipTierNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
 	case errors.Is(err, service.ErrVideoNoPermission):
-		response.Forbidden(c, err.Error())
-	case errors.Is(err, service.ErrNoFieldsToUpdate):
-		response.BadRequest(c, err.Error())
+		respondServiceError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrNoFieldsToUpdate), errors.Is(err, service.ErrVideoNotPublished),
+		errors.Is(err, service.ErrSponsorDisclosureRequired):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrVideoHidden), errors.Is(err, service.ErrAgeRestrictionLocked):
+		respondServiceError(c, http.StatusForbidden, err)
+	case errors.Is(err, service.ErrVideoRegionRestricted):
+		respondServiceError(c, http.StatusUnavailableForLegalReasons, err)
+	case errors.Is(err, service.ErrLegalHold):
+		respondServiceError(c, http.StatusLocked, err)
+	case errors.Is(err, service.ErrPremiereScheduled):
+		respondServiceError(c, http.StatusConflict, err)
 	default:
-		logger.Error("Video operation failed", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Video operation failed", zap.Error(err))
 		response.InternalError(c, "操作失败，请稍后重试")
 	}
 }
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/api/middleware"
+	"vida-go/internal/api/response"
+	"vida-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WalletHandler struct {
+	walletService *service.WalletService
+	auditService  *service.AuditService
+}
+
+func NewWalletHandler(walletService *service.WalletService, auditService *service.AuditService) *WalletHandler {
+	return &WalletHandler{walletService: walletService, auditService: auditService}
+}
+
+// GetWallet 我的钱包
+// @Summary 获取金币余额
+// @Tags 钱包
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.WalletInfo} "获取成功"
+// @Router /wallet [get]
+func (h *WalletHandler) GetWallet(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	data, err := h.walletService.GetWallet(c.Request.Context(), userID)
+	if err != nil {
+		handleWalletError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// ListMyTransactions 我的金币流水
+// @Summary 获取金币流水
+// @Description 按时间倒序返回当前用户的流水，可按类型、视频筛选
+// @Tags 钱包
+// @Produce json
+// @Security BearerAuth
+// @Param kind query string false "类型：tip_sent / tip_received / admin_adjust / membership_paid / membership_received"
+// @Param video_id query int false "视频ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.WalletTransactionListData} "获取成功"
+// @Router /wallet/transactions [get]
+func (h *WalletHandler) ListMyTransactions(c *gin.Context) {
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var query dto.WalletTransactionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	query.UserID = &userID
+	page, pageSize := parsePagination(c)
+
+	data, err := h.walletService.ListTransactions(c.Request.Context(), &query, page, pageSize)
+	if err != nil {
+		handleWalletError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// Tip 打赏视频
+// @Summary 打赏视频
+// @Description 从当前用户余额扣除金币记入视频作者的钱包，扣款与入账在同一事务中完成。
+// @Description request_id 由客户端生成，网络失败重试时保持不变，同一 request_id 只会扣款一次并返回首次的结果
+// @Tags 钱包
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "视频ID"
+// @Param request body dto.TipRequest true "打赏金额、留言与幂等键"
+// @Success 200 {object} response.Response{data=dto.TipResult} "打赏成功"
+// @Failure 400 {object} response.ErrorResponse "不能打赏自己的视频"
+// @Failure 404 {object} response.ErrorResponse "视频不存在"
+// @Failure 409 {object} response.ErrorResponse "金币余额不足或 request_id 已用于其他交易"
+// @Router /videos/{id}/tip [post]
+func (h *WalletHandler) Tip(c *gin.Context) {
+	videoID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的视频ID")
+		return
+	}
+	var req dto.TipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.walletService.Tip(c.Request.Context(), videoID, userID, &req)
+	if err != nil {
+		handleWalletError(c, err)
+		return
+	}
+	response.OK(c, "打赏成功", data)
+}
+
+// GetUserWallet 用户钱包
+// @Summary 获取用户金币余额（管理员）
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Success 200 {object} response.Response{data=dto.WalletInfo} "获取成功"
+// @Router /admin/users/{id}/wallet [get]
+func (h *WalletHandler) GetUserWallet(c *gin.Context) {
+	userID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	data, err := h.walletService.GetWallet(c.Request.Context(), userID)
+	if err != nil {
+		handleWalletError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+// AdjustWallet 调整用户余额
+// @Summary 调整用户金币余额（管理员）
+// @Description amount 为正增加、为负扣减，扣减后余额不能为负；同一用户的同一 request_id 只会调整一次。调整记入金币流水与审计日志，并通知用户
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "用户ID"
+// @Param request body dto.WalletAdjustRequest true "调整金额、原因与幂等键"
+// @Success 200 {object} response.Response{data=dto.WalletTransactionInfo} "调整成功"
+// @Failure 404 {object} response.ErrorResponse "用户不存在"
+// @Failure 409 {object} response.ErrorResponse "金币余额不足或 request_id 已用于其他交易"
+// @Router /admin/users/{id}/wallet/adjust [post]
+func (h *WalletHandler) AdjustWallet(c *gin.Context) {
+	userID, err := parseIDParam(c)
+	if err != nil {
+		response.BadRequest(c, "无效的用户ID")
+		return
+	}
+	var req dto.WalletAdjustRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	adminID, _ := middleware.GetCurrentUserID(c)
+	data, err := h.walletService.Adjust(c.Request.Context(), adminID, userID, &req)
+	if err != nil {
+		handleWalletError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, service.AuditActionWalletAdjust, service.AuditTargetUser, userID, req.Reason)
+
+	response.OK(c, "调整成功", data)
+}
+
+// ListTransactions 金币流水
+// @Summary 查询金币流水（管理员）
+// @Description 按时间倒序返回全站流水，可按用户、类型、视频、转账ID筛选；同一次转账的付款与收款流水 transfer_id 相同
+// @Tags 管理
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "用户ID"
+// @Param kind query string false "类型：tip_sent / tip_received / admin_adjust / membership_paid / membership_received"
+// @Param video_id query int false "视频ID"
+// @Param transfer_id query string false "转账ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response{data=dto.WalletTransactionListData} "获取成功"
+// @Router /admin/wallets/transactions [get]
+func (h *WalletHandler) ListTransactions(c *gin.Context) {
+	var query dto.WalletTransactionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	page, pageSize := parsePagination(c)
+
+	data, err := h.walletService.ListTransactions(c.Request.Context(), &query, page, pageSize)
+	if err != nil {
+		handleWalletError(c, err)
+		return
+	}
+	response.OK(c, "获取成功", data)
+}
+
+func handleWalletError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCannotTipSelf):
+		respondServiceError(c, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrUserNotFound):
+		respondServiceError(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrWalletInsufficient), errors.Is(err, service.ErrWalletRequestConflict):
+		respondServiceError(c, http.StatusConflict, err)
+	default:
+		handleVideoError(c, err)
+	}
+}
@@ -1,38 +1,98 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
 	"strings"
 
 	"vida-go/internal/api/response"
+	"vida-go/internal/rbac"
+	"vida-go/pkg/logger"
 	"vida-go/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 const (
-	ContextKeyUserID   = "currentUserID"
-	ContextKeyUserRole = "currentUserRole"
+	ContextKeyUserID         = "currentUserID"
+	ContextKeyUserRole       = "currentUserRole"
+	ContextKeyImpersonatorID = "currentImpersonatorID"
 )
 
 // AuthRequired JWT 认证中间件，要求请求必须携带有效 Token
 func AuthRequired() gin.HandlerFunc {
+	return authenticate(false, false)
+}
+
+// AuthRequiredWithQueryToken 与 AuthRequired 相同，但允许通过 access_token 查询参数传递 Token
+// 仅用于浏览器原生 EventSource 等无法设置请求头的场景
+func AuthRequiredWithQueryToken() gin.HandlerFunc {
+	return authenticate(true, false)
+}
+
+// AuthOptional 可选认证：未携带 Token 时以匿名身份继续，携带了无效 Token 仍返回 401
+func AuthOptional() gin.HandlerFunc {
+	return authenticate(false, true)
+}
+
+// AuthOptionalWithQueryToken 与 AuthOptional 相同，但允许通过 access_token 查询参数传递 Token
+// 仅用于 <video> 标签等无法设置请求头的场景
+func AuthOptionalWithQueryToken() gin.HandlerFunc {
+	return authenticate(true, true)
+}
+
+func authenticate(allowQueryToken, optional bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := extractToken(c)
+		if token == "" && allowQueryToken {
+			token = c.Query("access_token")
+		}
+		if token == "" && optional {
+			c.Next()
+			return
+		}
 		if token == "" {
-			response.Unauthorized(c, "缺少认证令牌")
+			response.FailWithCode(c, http.StatusUnauthorized, response.CodeTokenMissing, "缺少认证令牌")
 			c.Abort()
 			return
 		}
 
-		claims, err := utils.ParseToken(token)
+		claims, err := utils.ParseAccessToken(token)
 		if err != nil {
-			response.Unauthorized(c, "无效或过期的认证令牌")
+			response.FailWithCode(c, http.StatusUnauthorized, response.CodeTokenInvalid, "无效或过期的认证令牌")
 			c.Abort()
 			return
 		}
 
+		if claims.IsOAuth() {
+			if !scopeGranted(c, claims) {
+				response.FailWithCode(c, http.StatusForbidden, response.CodeInsufficientScope, "第三方应用无权访问该接口")
+				c.Abort()
+				return
+			}
+			c.Set(ContextKeyClientID, claims.ClientID)
+			// 客户端凭证令牌不代表任何用户，只能访问登录可选的接口
+			if claims.UserID == 0 {
+				if !optional {
+					response.FailWithCode(c, http.StatusForbidden, response.CodeInsufficientScope, "该接口需要用户授权")
+					c.Abort()
+					return
+				}
+				c.Next()
+				return
+			}
+		}
+
 		// 将用户 ID 存入上下文，后续 Handler 可通过 c.GetInt64() 获取
 		c.Set(ContextKeyUserID, claims.UserID)
+		fields := []zap.Field{zap.Int64("user_id", claims.UserID)}
+		// 模拟登录的请求在日志中标出实际操作的管理员
+		if claims.IsImpersonation() {
+			c.Set(ContextKeyImpersonatorID, claims.ImpersonatorID)
+			fields = append(fields, zap.Int64("impersonator_id", claims.ImpersonatorID))
+		}
+		c.Request = c.Request.WithContext(logger.WithFields(c.Request.Context(), fields...))
 		c.Next()
 	}
 }
@@ -47,12 +107,56 @@ func GetCurrentUserID(c *gin.Context) (int64, bool) {
 	return userID, ok
 }
 
+// GetImpersonatorID 当前请求为管理员模拟登录时返回管理员 ID
+func GetImpersonatorID(c *gin.Context) (int64, bool) {
+	val, exists := c.Get(ContextKeyImpersonatorID)
+	if !exists {
+		return 0, false
+	}
+	impersonatorID, ok := val.(int64)
+	return impersonatorID, ok
+}
+
+// DenyImpersonation 禁止模拟登录期间执行的敏感操作（修改密码、删除内容、资金相关等）
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetImpersonatorID(c); ok {
+			response.FailWithCode(c, http.StatusForbidden, response.CodeImpersonationDenied, "模拟登录期间不能执行该操作")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// ImpersonationRecorder 记录一次模拟登录期间的请求
+type ImpersonationRecorder func(c *gin.Context, impersonatorID, userID int64)
+
+// ImpersonationAudit 在请求结束后记录模拟登录期间的每个请求（全局中间件，认证在其后的路由中间件完成）
+func ImpersonationAudit(record ImpersonationRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		impersonatorID, ok := GetImpersonatorID(c)
+		if !ok {
+			return
+		}
+		userID, _ := GetCurrentUserID(c)
+		record(c, impersonatorID, userID)
+	}
+}
+
+// GetCurrentUserRole 从 Gin Context 中获取当前用户角色（仅经过角色校验的路由可用）
+func GetCurrentUserRole(c *gin.Context) string {
+	return c.GetString(ContextKeyUserRole)
+}
+
 // UserRoleFetcher 用于获取用户角色的函数类型
-type UserRoleFetcher func(userID int64) (string, error)
+type UserRoleFetcher func(ctx context.Context, userID int64) (string, error)
 
-// AdminRequired 管理员权限中间件（必须在 AuthRequired 之后使用）
-// roleFetcher 用于从数据库查询用户角色
-func AdminRequired(roleFetcher UserRoleFetcher) gin.HandlerFunc {
+// RequirePermission 权限校验中间件（必须在 AuthRequired 之后使用）
+// roleFetcher 用于从数据库查询用户角色，角色与权限的对应关系见 rbac 包
+func RequirePermission(roleFetcher UserRoleFetcher, perm rbac.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID, ok := GetCurrentUserID(c)
 		if !ok {
@@ -60,16 +164,22 @@ func AdminRequired(roleFetcher UserRoleFetcher) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		// 模拟登录只用于以普通用户视角排查问题，不能借此访问管理接口
+		if _, impersonating := GetImpersonatorID(c); impersonating {
+			response.FailWithCode(c, http.StatusForbidden, response.CodeImpersonationDenied, "模拟登录期间不能执行该操作")
+			c.Abort()
+			return
+		}
 
-		role, err := roleFetcher(userID)
+		role, err := roleFetcher(c.Request.Context(), userID)
 		if err != nil {
-			response.Unauthorized(c, "用户不存在")
+			response.FailWithCode(c, http.StatusUnauthorized, response.CodeUserNotFound, "用户不存在")
 			c.Abort()
 			return
 		}
 
-		if role != "admin" {
-			response.Forbidden(c, "需要管理员权限")
+		if !rbac.HasPermission(role, perm) {
+			response.FailWithCode(c, http.StatusForbidden, response.CodePermissionDenied, "权限不足")
 			c.Abort()
 			return
 		}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+
+	"vida-go/internal/api/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeBodyLimits 单独登记请求体上限的接口（方法 + 完整路由模板），如视频、头像上传
+var routeBodyLimits = make(map[string]int64)
+
+// AllowBodySize 为接口登记单独的请求体上限（字节），path 为完整路由模板。需在启动注册路由时调用
+func AllowBodySize(method, path string, maxBytes int64) {
+	routeBodyLimits[method+" "+path] = maxBytes
+}
+
+// BodyLimit 请求体大小限制中间件（需在读取请求体的中间件之前使用）。
+// 接口未单独登记上限时使用 defaultMax；声明的长度超出上限时不读取请求体直接返回 413，
+// 未声明长度（分块传输）时读取超出上限即中断
+func BodyLimit(defaultMax int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		maxBytes, ok := routeBodyLimits[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			maxBytes = defaultMax
+		}
+		if LimitBody(c, maxBytes) {
+			c.Next()
+		}
+	}
+}
+
+// LimitBody 将请求体限制在 maxBytes 以内，可在处理函数中按用户进一步收紧上限。
+// 声明的长度已超出时返回 413 并中止请求，返回 false
+func LimitBody(c *gin.Context, maxBytes int64) bool {
+	if c.Request.ContentLength > maxBytes {
+		// 不读取剩余请求体，关闭连接避免客户端继续发送
+		c.Header("Connection", "close")
+		response.FailWithCode(c, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge, "请求体过大")
+		c.Abort()
+		return false
+	}
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+	}
+	return true
+}
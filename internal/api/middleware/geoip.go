@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"vida-go/internal/infra/geoip"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextKeyCountry Gin Context 中请求来源国家/地区代码的键名
+const ContextKeyCountry = "country"
+
+// GeoIP 解析请求来源的国家/地区：优先读取可信 CDN 注入的请求头（countryHeader 为空时不读取），
+// 否则按客户端 IP 查询 IP 段数据库。结果放入请求 context 供视频地区限制使用，无法确定时为空
+func GeoIP(countryHeader string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		country := ""
+		if countryHeader != "" {
+			country, _ = geoip.NormalizeCountry(c.GetHeader(countryHeader))
+		}
+		if country == "" {
+			country = geoip.Lookup(c.ClientIP())
+		}
+
+		c.Set(ContextKeyCountry, country)
+		c.Request = c.Request.WithContext(geoip.NewContext(c.Request.Context(), country))
+		c.Next()
+	}
+}
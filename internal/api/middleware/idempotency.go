@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/response"
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyKeyMaxLen     = 255
+	idempotencyMaxBodyBytes  = 1 << 20 // 参与指纹计算的请求体上限
+	idempotencyProcessingTTL = 10 * time.Minute
+
+	idempotencyStateProcessing = "processing"
+	idempotencyStateDone       = "done"
+)
+
+// idempotencyRecord Redis 中保存的幂等记录
+type idempotencyRecord struct {
+	State       string `json:"state"`
+	Fingerprint string `json:"fingerprint"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Idempotency 幂等中间件（必须在 AuthRequired 之后使用）
+// 请求携带 Idempotency-Key 时，同一用户使用相同 Key 的重试会直接回放首次响应，
+// 不会重复执行业务逻辑；未携带该请求头时不做任何处理
+func Idempotency(client *redis.Client, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idemKey := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if idemKey == "" || client == nil {
+			c.Next()
+			return
+		}
+		if len(idemKey) > idempotencyKeyMaxLen {
+			response.BadRequest(c, "Idempotency-Key 过长")
+			c.Abort()
+			return
+		}
+
+		userID, _ := GetCurrentUserID(c)
+		fingerprint, err := requestFingerprint(c)
+		if err != nil {
+			response.BadRequest(c, "读取请求体失败")
+			c.Abort()
+			return
+		}
+
+		ctx := c.Request.Context()
+		redisKey := fmt.Sprintf("idempotency:%d:%s", userID, idemKey)
+
+		processing, _ := json.Marshal(idempotencyRecord{State: idempotencyStateProcessing, Fingerprint: fingerprint})
+		acquired, err := client.SetNX(ctx, redisKey, processing, idempotencyProcessingTTL).Result()
+		if err != nil {
+			// Redis 不可用时降级为非幂等处理，不阻断业务
+			logger.FromContext(c.Request.Context()).Warn("Idempotency store unavailable, skipping", zap.Error(err))
+			c.Next()
+			return
+		}
+
+		if !acquired {
+			replayIdempotentResponse(c, client, redisKey, fingerprint)
+			return
+		}
+
+		// 业务处理 panic 时释放处理中标记，否则同一 Key 的重试在标记过期前都会返回处理中；
+		// 之后继续抛出，交给 Recovery 中间件返回 500
+		defer func() {
+			if r := recover(); r != nil {
+				_ = client.Del(context.WithoutCancel(ctx), redisKey).Err()
+				panic(r)
+			}
+		}()
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			// 服务端错误不缓存，允许客户端使用同一 Key 重试
+			_ = client.Del(ctx, redisKey).Err()
+			return
+		}
+
+		record, _ := json.Marshal(idempotencyRecord{
+			State:       idempotencyStateDone,
+			Fingerprint: fingerprint,
+			StatusCode:  status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		if err := client.Set(ctx, redisKey, record, ttl).Err(); err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Save idempotency record failed", zap.String("key", redisKey), zap.Error(err))
+		}
+	}
+}
+
+func replayIdempotentResponse(c *gin.Context, client *redis.Client, redisKey, fingerprint string) {
+	raw, err := client.Get(c.Request.Context(), redisKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			response.FailWithCode(c, http.StatusConflict, response.CodeIdempotencyInProgress, "请求正在处理中，请稍后重试")
+		} else {
+			logger.FromContext(c.Request.Context()).Error("Load idempotency record failed", zap.String("key", redisKey), zap.Error(err))
+			response.InternalError(c, "操作失败，请稍后重试")
+		}
+		c.Abort()
+		return
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		response.InternalError(c, "操作失败，请稍后重试")
+		c.Abort()
+		return
+	}
+
+	switch {
+	case record.Fingerprint != fingerprint:
+		response.FailWithCode(c, http.StatusUnprocessableEntity, response.CodeIdempotencyKeyReused, "Idempotency-Key 已用于其他请求")
+	case record.State == idempotencyStateProcessing:
+		response.FailWithCode(c, http.StatusConflict, response.CodeIdempotencyInProgress, "请求正在处理中，请稍后重试")
+	default:
+		c.Header(idempotencyReplayedHeader, "true")
+		c.Data(record.StatusCode, record.ContentType, record.Body)
+	}
+	c.Abort()
+}
+
+// requestFingerprint 计算请求指纹：方法 + 路径 + 请求体
+// multipart 请求（如视频上传）体积大且 boundary 每次不同，仅使用 Content-Length
+func requestFingerprint(c *gin.Context) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", c.Request.Method, c.Request.URL.Path)
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fmt.Fprintf(h, "content-length:%d", c.Request.ContentLength)
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	if c.Request.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, idempotencyMaxBodyBytes))
+		if err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// bodyCaptureWriter 在写出响应的同时保留一份响应体
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
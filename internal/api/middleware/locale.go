@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"vida-go/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale 根据 Accept-Language 解析请求语言，供响应信息本地化使用
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Match(c.GetHeader("Accept-Language"))
+		c.Set(i18n.ContextKey, lang)
+		c.Header("Content-Language", lang)
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
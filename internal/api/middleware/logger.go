@@ -1,16 +1,21 @@
 package middleware
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
+	"vida-go/internal/infra/metrics"
+	"vida-go/internal/infra/tracing"
 	"vida-go/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-// Logger Gin日志中间件
-func Logger() gin.HandlerFunc {
+// Logger Gin 访问日志中间件：记录状态码、耗时、响应字节数与来源 IP，并上报耗时直方图；
+// 耗时超过 slowThreshold（大于 0 时生效）的请求以 WARN 级别记录
+func Logger(slowThreshold time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 开始时间
 		start := time.Now()
@@ -19,24 +24,42 @@ func Logger() gin.HandlerFunc {
 		c.Next()
 
 		// 结束时间
-		duration := time.Since(start)
+		latency := time.Since(start)
+		status := c.Writer.Status()
 
-		// 记录日志
-		logger.Info("HTTP Request",
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(status)).Observe(latency.Seconds())
+
+		// 记录日志（请求级 Logger 已带 request_id、route、user_id）
+		log := logger.FromContext(c.Request.Context())
+		fields := []zap.Field{
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.String("query", c.Request.URL.RawQuery),
-			zap.Int("status", c.Writer.Status()),
+			zap.Int("status", status),
 			zap.String("ip", c.ClientIP()),
+			zap.String("upstream_ip", c.RemoteIP()), // 直连的上游（如 Nginx）地址
 			zap.String("user_agent", c.Request.UserAgent()),
-			zap.Duration("duration", duration),
-			zap.Int("body_size", c.Writer.Size()),
-		)
+			zap.Duration("latency", latency),
+			zap.Int("bytes", c.Writer.Size()),
+			zap.String("trace_id", tracing.TraceID(c.Request.Context())),
+		}
+		// SSE、WebSocket 长连接的耗时是连接时长，不按慢请求告警
+		streaming := c.IsWebsocket() || strings.HasPrefix(c.Writer.Header().Get("Content-Type"), "text/event-stream")
+		if slowThreshold > 0 && latency > slowThreshold && !streaming {
+			metrics.HTTPSlowRequests.WithLabelValues(c.Request.Method, route).Inc()
+			log.Warn("Slow HTTP Request", append(fields, zap.Duration("threshold", slowThreshold))...)
+		} else {
+			log.Info("HTTP Request", fields...)
+		}
 
 		// 如果有错误，记录错误日志
 		if len(c.Errors) > 0 {
 			for _, e := range c.Errors {
-				logger.Error("Request Error",
+				log.Error("Request Error",
 					zap.String("error", e.Error()),
 					zap.Any("type", e.Type),
 				)
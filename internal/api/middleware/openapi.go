@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+
+	"vida-go/internal/api/response"
+	"vida-go/internal/api/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openAPIMaxBodyBytes 参与校验的 JSON 请求体上限，超出时不校验请求体
+const openAPIMaxBodyBytes = 1 << 20
+
+// OpenAPIValidation 按接口文档校验请求的参数、Content-Type 与 JSON 请求体，
+// 文档未收录的接口直接放行。需注册在路由之前（依赖 c.FullPath()）
+func OpenAPIValidation(validator *validation.RequestValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op := validator.Operation(c.Request.Method, c.FullPath())
+		if op == nil {
+			c.Next()
+			return
+		}
+
+		contentType := c.GetHeader("Content-Type")
+		hasBody := c.Request.ContentLength > 0 || len(c.Request.TransferEncoding) > 0
+		if hasBody && op.HasBody() && !op.Accepts(contentType) {
+			response.FailWithCode(c, http.StatusUnsupportedMediaType, response.CodeUnsupportedMedia, "不支持的请求内容类型")
+			c.Abort()
+			return
+		}
+
+		in := &validation.RequestInput{
+			PathParams: make(map[string]string, len(c.Params)),
+			Query:      c.Request.URL.Query(),
+			Header:     c.GetHeader,
+		}
+		for _, p := range c.Params {
+			in.PathParams[p.Key] = p.Value
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		switch {
+		case mediaType == gin.MIMEPOSTForm:
+			if err := c.Request.ParseForm(); err != nil {
+				response.BadRequest(c, "请求参数无效: "+err.Error())
+				c.Abort()
+				return
+			}
+			in.Form = c.Request.PostForm
+		case hasBody && mediaType != gin.MIMEMultipartPOSTForm:
+			body, err := io.ReadAll(io.LimitReader(c.Request.Body, openAPIMaxBodyBytes+1))
+			if err != nil {
+				response.BadRequest(c, "读取请求体失败")
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+			in.Body = body
+			in.SkipBody = len(body) > openAPIMaxBodyBytes
+		}
+
+		if fields := validator.Validate(op, in, response.Locale(c)); fields != nil {
+			response.ValidationFailed(c, fields)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
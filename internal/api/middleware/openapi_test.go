@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"vida-go/internal/api/response"
+	"vida-go/internal/api/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openAPITestSpec 仿照 swag 生成的文档：评论创建接口带 JSON 请求体，列表接口带查询参数
+const openAPITestSpec = `{
+	"basePath": "/api/v1",
+	"paths": {
+		"/videos/{id}/comments": {
+			"post": {
+				"consumes": ["application/json"],
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "type": "integer"},
+					{"name": "request", "in": "body", "required": true, "schema": {"$ref": "#/definitions/dto.CommentCreateRequest"}}
+				]
+			},
+			"get": {
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "type": "integer"},
+					{"name": "page", "in": "query", "type": "integer", "minimum": 1},
+					{"name": "sort", "in": "query", "type": "string", "enum": ["hot", "new"]}
+				]
+			}
+		}
+	},
+	"definitions": {
+		"dto.CommentCreateRequest": {
+			"type": "object",
+			"required": ["content"],
+			"properties": {
+				"content": {"type": "string", "minLength": 1, "maxLength": 10},
+				"parent_id": {"type": "integer"},
+				"extra": {"type": "object", "properties": {"source": {"type": "string"}}}
+			}
+		}
+	}
+}`
+
+func newOpenAPIRouter(t *testing.T, strict bool) *gin.Engine {
+	t.Helper()
+	spec, err := validation.ParseSpec([]byte(openAPITestSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(OpenAPIValidation(validation.NewRequestValidator(spec, strict)))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/api/v1/videos/:id/comments", ok)
+	r.GET("/api/v1/videos/:id/comments", ok)
+	r.GET("/api/v1/undocumented", ok)
+	return r
+}
+
+// serveOpenAPI 返回状态码与校验失败的字段名
+func serveOpenAPI(r *gin.Engine, method, target, contentType, body string) (int, []string) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp response.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	fields := make([]string, 0, len(resp.Error.Errors))
+	for field := range resp.Error.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return w.Code, fields
+}
+
+func TestOpenAPIValidation(t *testing.T) {
+	const jsonType = "application/json"
+	tests := []struct {
+		name        string
+		strict      bool
+		method      string
+		target      string
+		contentType string
+		body        string
+		want        int
+		wantFields  []string
+	}{
+		{name: "valid body", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":"hi"}`, want: http.StatusOK},
+		{name: "missing required field", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"parent_id":2}`, want: http.StatusBadRequest, wantFields: []string{"content"}},
+		{name: "null required field", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":null}`, want: http.StatusBadRequest, wantFields: []string{"content"}},
+		{name: "missing body", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, want: http.StatusBadRequest, wantFields: []string{"body"}},
+		{name: "invalid json", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":`, want: http.StatusBadRequest, wantFields: []string{"body"}},
+		{name: "wrong field type", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":"hi","parent_id":"2"}`, want: http.StatusBadRequest, wantFields: []string{"parent_id"}},
+		{name: "field too long", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":"这条评论超过了十个字的长度限制"}`, want: http.StatusBadRequest, wantFields: []string{"content"}},
+		{name: "invalid path param", method: http.MethodPost, target: "/api/v1/videos/abc/comments", contentType: jsonType, body: `{"content":"hi"}`, want: http.StatusBadRequest, wantFields: []string{"id"}},
+		{name: "unsupported content type", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: "text/plain", body: "hi", want: http.StatusUnsupportedMediaType},
+		{name: "unknown field allowed when lenient", method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":"hi","mood":"happy"}`, want: http.StatusOK},
+		{name: "unknown field rejected when strict", strict: true, method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":"hi","mood":"happy"}`, want: http.StatusBadRequest, wantFields: []string{"mood"}},
+		{name: "unknown nested field rejected when strict", strict: true, method: http.MethodPost, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"content":"hi","extra":{"source":"app","device":"ios"}}`, want: http.StatusBadRequest, wantFields: []string{"extra.device"}},
+		{name: "valid query", strict: true, method: http.MethodGet, target: "/api/v1/videos/1/comments?page=2&sort=hot", want: http.StatusOK},
+		{name: "query below minimum", method: http.MethodGet, target: "/api/v1/videos/1/comments?page=0", want: http.StatusBadRequest, wantFields: []string{"page"}},
+		{name: "query not in enum", method: http.MethodGet, target: "/api/v1/videos/1/comments?sort=old", want: http.StatusBadRequest, wantFields: []string{"sort"}},
+		{name: "unknown query allowed when lenient", method: http.MethodGet, target: "/api/v1/videos/1/comments?limit=5", want: http.StatusOK},
+		{name: "unknown query rejected when strict", strict: true, method: http.MethodGet, target: "/api/v1/videos/1/comments?limit=5", want: http.StatusBadRequest, wantFields: []string{"limit"}},
+		{name: "access token query ignored when strict", strict: true, method: http.MethodGet, target: "/api/v1/videos/1/comments?access_token=x", want: http.StatusOK},
+		{name: "body rejected when strict", strict: true, method: http.MethodGet, target: "/api/v1/videos/1/comments", contentType: jsonType, body: `{"page":1}`, want: http.StatusBadRequest, wantFields: []string{"body"}},
+		{name: "undocumented route passes", strict: true, method: http.MethodGet, target: "/api/v1/undocumented?anything=1", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newOpenAPIRouter(t, tt.strict)
+			code, fields := serveOpenAPI(r, tt.method, tt.target, tt.contentType, tt.body)
+			if code != tt.want {
+				t.Fatalf("status = %d, want %d (fields %v)", code, tt.want, fields)
+			}
+			if tt.wantFields != nil && !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -15,7 +15,7 @@ func Recovery() gin.HandlerFunc {
 		defer func() {
 			if err := recover(); err != nil {
 				// 记录panic日志
-				logger.Error("Panic recovered",
+				logger.FromContext(c.Request.Context()).Error("Panic recovered",
 					zap.Any("error", err),
 					zap.String("path", c.Request.URL.Path),
 					zap.String("method", c.Request.Method),
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"vida-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// HeaderRequestID 请求 ID 头，客户端或网关传入时沿用，否则由服务端生成
+const HeaderRequestID = "X-Request-ID"
+
+// ContextKeyRequestID Gin Context 中请求 ID 的键名
+const ContextKeyRequestID = "requestID"
+
+// maxRequestIDLen 外部传入的请求 ID 超长时视为无效，重新生成
+const maxRequestIDLen = 128
+
+// RequestContext 为每个请求分配请求 ID 并写入响应头，同时把请求 ID、路由挂到请求级 Logger 上，
+// 后续 Handler、Service 通过 logger.FromContext(ctx) 打出的日志都带这些字段
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" || len(requestID) > maxRequestIDLen {
+			requestID = uuid.NewString()
+		}
+		c.Set(ContextKeyRequestID, requestID)
+		c.Header(HeaderRequestID, requestID)
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		ctx := logger.WithFields(c.Request.Context(),
+			zap.String("request_id", requestID),
+			zap.String("route", route),
+		)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	testAppKey    = "ios"
+	testAppSecret = "s3cret"
+)
+
+type signedRequest struct {
+	method    string
+	target    string
+	body      string
+	timestamp time.Time
+	nonce     string
+	secret    string // 为空时使用 testAppSecret
+}
+
+// newSignedRequest 按客户端规则签名：方法、路径、排序后的查询参数、时间戳、随机串与请求体摘要
+func newSignedRequest(r signedRequest) *http.Request {
+	req := httptest.NewRequest(r.method, r.target, strings.NewReader(r.body))
+	secret := r.secret
+	if secret == "" {
+		secret = testAppSecret
+	}
+	ts := strconv.FormatInt(r.timestamp.Unix(), 10)
+	bodySum := sha256.Sum256([]byte(r.body))
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s\n%s",
+		req.Method, req.URL.EscapedPath(), req.URL.Query().Encode(), ts, r.nonce, hex.EncodeToString(bodySum[:]))
+
+	req.Header.Set(SignatureAppKeyHeader, testAppKey)
+	req.Header.Set(SignatureTimestampHeader, ts)
+	req.Header.Set(SignatureNonceHeader, r.nonce)
+	req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func newSignatureRouter(client *redis.Client) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestSignature(client, SignatureOptions{
+		Secrets: map[string]string{testAppKey: testAppSecret},
+		MaxSkew: 5 * time.Minute,
+		Enforce: true,
+	}))
+	handler := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	r.GET("/feed", handler)
+	r.POST("/search", handler)
+	return r
+}
+
+func TestRequestSignature(t *testing.T) {
+	r := newSignatureRouter(nil)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		req    signedRequest
+		tamper func(req *http.Request)
+		want   int
+	}{
+		{name: "valid", req: signedRequest{method: http.MethodGet, target: "/feed?page=1&size=20", timestamp: now, nonce: "nonce-0001"}, want: http.StatusOK},
+		{name: "valid with body", req: signedRequest{method: http.MethodPost, target: "/search", body: `{"q":"cat"}`, timestamp: now, nonce: "nonce-0002"}, want: http.StatusOK},
+		{name: "query order does not matter", req: signedRequest{method: http.MethodGet, target: "/feed?size=20&page=1", timestamp: now, nonce: "nonce-0003"}, want: http.StatusOK},
+		{name: "uppercase signature", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-0004"}, tamper: func(req *http.Request) {
+			req.Header.Set(SignatureHeader, strings.ToUpper(req.Header.Get(SignatureHeader)))
+		}, want: http.StatusOK},
+		{name: "skew within window", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now.Add(-4 * time.Minute), nonce: "nonce-0005"}, want: http.StatusOK},
+		{name: "clock ahead within window", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now.Add(4 * time.Minute), nonce: "nonce-0006"}, want: http.StatusOK},
+		{name: "expired timestamp", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now.Add(-6 * time.Minute), nonce: "nonce-0007"}, want: http.StatusUnauthorized},
+		{name: "timestamp too far ahead", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now.Add(6 * time.Minute), nonce: "nonce-0008"}, want: http.StatusUnauthorized},
+		{name: "wrong secret", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-0009", secret: "other"}, want: http.StatusUnauthorized},
+		{name: "unknown app key", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-0010"}, tamper: func(req *http.Request) {
+			req.Header.Set(SignatureAppKeyHeader, "android")
+		}, want: http.StatusUnauthorized},
+		{name: "query tampered", req: signedRequest{method: http.MethodGet, target: "/feed?page=1", timestamp: now, nonce: "nonce-0011"}, tamper: func(req *http.Request) {
+			req.URL.RawQuery = "page=2"
+		}, want: http.StatusUnauthorized},
+		{name: "body tampered", req: signedRequest{method: http.MethodPost, target: "/search", body: `{"q":"cat"}`, timestamp: now, nonce: "nonce-0012"}, tamper: func(req *http.Request) {
+			req.Body = io.NopCloser(strings.NewReader(`{"q":"dog"}`))
+		}, want: http.StatusUnauthorized},
+		{name: "timestamp tampered", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-0013"}, tamper: func(req *http.Request) {
+			req.Header.Set(SignatureTimestampHeader, strconv.FormatInt(now.Unix()+1, 10))
+		}, want: http.StatusUnauthorized},
+		{name: "invalid timestamp", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-0014"}, tamper: func(req *http.Request) {
+			req.Header.Set(SignatureTimestampHeader, "yesterday")
+		}, want: http.StatusUnauthorized},
+		{name: "nonce too short", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "short"}, want: http.StatusUnauthorized},
+		{name: "nonce too long", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: strings.Repeat("n", signatureNonceMaxLen+1)}, want: http.StatusUnauthorized},
+		{name: "missing signature", req: signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-0015"}, tamper: func(req *http.Request) {
+			req.Header.Del(SignatureHeader)
+		}, want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newSignedRequest(tt.req)
+			if tt.tamper != nil {
+				tt.tamper(req)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequestSignatureBodyPassedThrough(t *testing.T) {
+	r := newSignatureRouter(nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, newSignedRequest(signedRequest{method: http.MethodPost, target: "/search", body: `{"q":"cat"}`, timestamp: time.Now(), nonce: "nonce-body"}))
+	if w.Code != http.StatusOK || w.Body.String() != `{"q":"cat"}` {
+		t.Errorf("got %d %q, want 200 with the original body", w.Code, w.Body.String())
+	}
+}
+
+func TestRequestSignatureReplay(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	r := newSignatureRouter(client)
+	now := time.Now()
+
+	serve := func(req *http.Request) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+	signed := signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-replay"}
+
+	if code := serve(newSignedRequest(signed)); code != http.StatusOK {
+		t.Fatalf("first request = %d, want 200", code)
+	}
+	if code := serve(newSignedRequest(signed)); code != http.StatusUnauthorized {
+		t.Errorf("replayed request = %d, want 401", code)
+	}
+
+	// 伪造的签名不能占用合法客户端的随机串
+	forged := newSignedRequest(signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-forged", secret: "other"})
+	if code := serve(forged); code != http.StatusUnauthorized {
+		t.Fatalf("forged request = %d, want 401", code)
+	}
+	if code := serve(newSignedRequest(signedRequest{method: http.MethodGet, target: "/feed", timestamp: now, nonce: "nonce-forged"})); code != http.StatusOK {
+		t.Errorf("request after forged nonce = %d, want 200", code)
+	}
+
+	// 随机串在 2 倍时间偏差内保留，过期后时间戳本身已超出允许范围
+	if ttl := mr.TTL(signatureNonceKeyPrefix + testAppKey + ":nonce-replay"); ttl != 10*time.Minute {
+		t.Errorf("nonce TTL = %s, want 10m", ttl)
+	}
+	mr.FastForward(10 * time.Minute)
+	if mr.Exists(signatureNonceKeyPrefix + testAppKey + ":nonce-replay") {
+		t.Error("nonce still stored after the replay window")
+	}
+}
+
+func TestRequestSignatureRedisUnavailable(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	r := newSignatureRouter(client)
+	mr.Close()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, newSignedRequest(signedRequest{method: http.MethodGet, target: "/feed", timestamp: time.Now(), nonce: "nonce-offline"}))
+	if w.Code != http.StatusOK {
+		t.Errorf("status with Redis down = %d, want 200", w.Code)
+	}
+}
+
+func TestRequestSignatureReportOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestSignature(nil, SignatureOptions{Secrets: map[string]string{testAppKey: testAppSecret}, MaxSkew: time.Minute}))
+	r.GET("/feed", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/feed", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("unsigned request without enforcement = %d, want 200", w.Code)
+	}
+}
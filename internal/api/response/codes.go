@@ -0,0 +1,212 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+)
+
+// 业务错误码：对外稳定的机器可读标识，客户端应基于错误码而非 message 做分支判断
+const (
+	// 通用错误码
+	CodeBadRequest       = "BAD_REQUEST"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeUnprocessable    = "UNPROCESSABLE_ENTITY"
+	CodeInternalError    = "INTERNAL_ERROR"
+	CodeUnavailable      = "SERVICE_UNAVAILABLE"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInvalidCursor    = "INVALID_CURSOR"
+	CodeTooManyRequests  = "TOO_MANY_REQUESTS"
+	CodeUnsupportedMedia = "UNSUPPORTED_MEDIA_TYPE"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+
+	// 认证
+	CodeTokenMissing          = "TOKEN_MISSING"
+	CodeTokenInvalid          = "TOKEN_INVALID"
+	CodeInvalidCredential     = "INVALID_CREDENTIAL"
+	CodePermissionDenied      = "PERMISSION_DENIED"
+	CodeUserNotFound          = "USER_NOT_FOUND"
+	CodeUserDeleted           = "USER_DELETED"
+	CodeUsernameExists        = "USERNAME_EXISTS"
+	CodeUsernameReserved      = "USERNAME_RESERVED"
+	CodeUsernameInappropriate = "USERNAME_INAPPROPRIATE"
+	CodeUserNoPermission      = "USER_NO_PERMISSION"
+	CodeUserSuspended         = "USER_SUSPENDED"
+	CodeUserMuted             = "USER_MUTED"
+	CodeInvalidRole           = "INVALID_ROLE"
+	CodeInsufficientScope     = "INSUFFICIENT_SCOPE"
+	CodeImpersonationDenied   = "IMPERSONATION_DENIED"
+	CodeCannotImpersonate     = "CANNOT_IMPERSONATE"
+
+	// 视频
+	CodeVideoNotFound     = "VIDEO_NOT_FOUND"
+	CodeVideoNoPermission = "VIDEO_NO_PERMISSION"
+	CodeNoFieldsToUpdate  = "NO_FIELDS_TO_UPDATE"
+	CodeVideoHidden       = "VIDEO_HIDDEN"
+	CodeVideoNotHideable  = "VIDEO_NOT_HIDEABLE"
+	CodeVideoNotHidden    = "VIDEO_NOT_HIDDEN"
+	CodeUnsupportedFormat = "UNSUPPORTED_FILE_FORMAT"
+	CodeInvalidFileSize   = "INVALID_FILE_SIZE"
+	CodeDailyUploadLimit  = "DAILY_UPLOAD_LIMIT"
+	CodeVideoPreparing    = "VIDEO_PREPARING"
+
+	// 付费推广
+	CodeSponsorDisclosureRequired = "SPONSOR_DISCLOSURE_REQUIRED"
+
+	// 重复视频
+	CodeDuplicateNotFound      = "DUPLICATE_NOT_FOUND"
+	CodeDuplicateResolved      = "DUPLICATE_RESOLVED"
+	CodeInvalidDuplicateStatus = "INVALID_DUPLICATE_STATUS"
+
+	// 私密视频授权
+	CodeVideoAccessSelf    = "VIDEO_ACCESS_SELF"
+	CodeVideoGrantLimit    = "VIDEO_GRANT_LIMIT"
+	CodeVideoGrantNotFound = "VIDEO_GRANT_NOT_FOUND"
+	CodeShareLinkInvalid   = "SHARE_LINK_INVALID"
+
+	// 视频投票
+	CodePollNotFound      = "POLL_NOT_FOUND"
+	CodePollExists        = "POLL_EXISTS"
+	CodePollClosed        = "POLL_CLOSED"
+	CodePollAlreadyVoted  = "POLL_ALREADY_VOTED"
+	CodePollOptionInvalid = "POLL_OPTION_INVALID"
+	CodePollInvalid       = "POLL_INVALID"
+
+	// 首映
+	CodePremiereDisabled      = "PREMIERE_DISABLED"
+	CodePremiereNotFound      = "PREMIERE_NOT_FOUND"
+	CodePremiereNotAllowed    = "PREMIERE_NOT_ALLOWED"
+	CodePremiereTimeInvalid   = "PREMIERE_TIME_INVALID"
+	CodePremiereStarted       = "PREMIERE_STARTED"
+	CodePremiereScheduled     = "PREMIERE_SCHEDULED"
+	CodePremiereChatClosed    = "PREMIERE_CHAT_CLOSED"
+	CodePremiereChatInvalid   = "PREMIERE_CHAT_INVALID"
+	CodePremiereChatRateLimit = "PREMIERE_CHAT_RATE_LIMITED"
+
+	// 金币与打赏
+	CodeWalletInsufficient    = "WALLET_INSUFFICIENT_BALANCE"
+	CodeWalletRequestConflict = "WALLET_REQUEST_CONFLICT"
+	CodeCannotTipSelf         = "CANNOT_TIP_SELF"
+
+	// 创作者会员
+	CodeMembershipTierNotFound  = "MEMBERSHIP_TIER_NOT_FOUND"
+	CodeMembershipTierArchived  = "MEMBERSHIP_TIER_ARCHIVED"
+	CodeMembershipTierLimit     = "MEMBERSHIP_TIER_LIMIT"
+	CodeMembershipLevelTaken    = "MEMBERSHIP_LEVEL_TAKEN"
+	CodeCannotJoinOwnMembership = "CANNOT_JOIN_OWN_MEMBERSHIP"
+	CodeVideoMembersOnly        = "VIDEO_MEMBERS_ONLY"
+
+	// 评论
+	CodeCommentNotFound     = "COMMENT_NOT_FOUND"
+	CodeCommentNoPermission = "COMMENT_NO_PERMISSION"
+	CodeParentNotFound      = "PARENT_COMMENT_NOT_FOUND"
+	CodeParentVideoMismatch = "PARENT_COMMENT_VIDEO_MISMATCH"
+	CodeCommentHidden       = "COMMENT_HIDDEN"
+	CodeCommentNotHidden    = "COMMENT_NOT_HIDDEN"
+
+	// 关注 / 点赞
+	CodeCannotFollowSelf = "CANNOT_FOLLOW_SELF"
+	CodeAlreadyFollowed  = "ALREADY_FOLLOWED"
+	CodeNotFollowed      = "NOT_FOLLOWED"
+	CodeAlreadyFavorited = "ALREADY_FAVORITED"
+	CodeNotFavorited     = "NOT_FAVORITED"
+
+	// 通知
+	CodeTooManyMutedVideos = "TOO_MANY_MUTED_VIDEOS"
+	CodeEmailRequired      = "EMAIL_REQUIRED"
+	CodeDeviceNotFound     = "DEVICE_NOT_FOUND"
+
+	// 私信
+	CodeCannotMessageSelf    = "CANNOT_MESSAGE_SELF"
+	CodeNotMutualFollow      = "NOT_MUTUAL_FOLLOW"
+	CodeConversationNotFound = "CONVERSATION_NOT_FOUND"
+
+	// 埋点
+	CodeDeviceIDRequired = "DEVICE_ID_REQUIRED"
+
+	// 幂等
+	CodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+	CodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+
+	// 请求签名
+	CodeSignatureInvalid = "SIGNATURE_INVALID"
+
+	// AI
+	CodeAgentUnavailable = "AGENT_UNAVAILABLE"
+	CodeAskRateLimited   = "ASK_RATE_LIMITED"
+	CodeAskQuotaExceeded = "ASK_QUOTA_EXCEEDED"
+
+	// 法律保全
+	CodeLegalHold            = "LEGAL_HOLD"
+	CodeAlreadyOnLegalHold   = "ALREADY_ON_LEGAL_HOLD"
+	CodeNotOnLegalHold       = "NOT_ON_LEGAL_HOLD"
+	CodeInvalidLegalHoldType = "INVALID_LEGAL_HOLD_TYPE"
+
+	// 地区限制
+	CodeVideoRegionRestricted = "VIDEO_REGION_RESTRICTED"
+
+	// 年龄限制
+	CodeVideoAgeRestricted   = "VIDEO_AGE_RESTRICTED"
+	CodeAgeRestrictionLocked = "AGE_RESTRICTION_LOCKED"
+	CodeAlreadyAgeRestricted = "ALREADY_AGE_RESTRICTED"
+	CodeNotAgeRestricted     = "NOT_AGE_RESTRICTED"
+	CodeInvalidBirthDate     = "INVALID_BIRTH_DATE"
+	CodeBirthDateLocked      = "BIRTH_DATE_LOCKED"
+
+	// 举报
+	CodeCannotReportSelf    = "CANNOT_REPORT_SELF"
+	CodeReportExists        = "REPORT_EXISTS"
+	CodeReportNotFound      = "REPORT_NOT_FOUND"
+	CodeReportResolved      = "REPORT_RESOLVED"
+	CodeInvalidReportStatus = "INVALID_REPORT_STATUS"
+	CodeInvalidReportTarget = "INVALID_REPORT_TARGET"
+
+	// 清晰度
+	CodeStreamProfileInvalid = "STREAM_PROFILE_INVALID"
+
+	// 第三方应用
+	CodeOAuthClientNotFound = "OAUTH_CLIENT_NOT_FOUND"
+	CodeOAuthClientLimit    = "OAUTH_CLIENT_LIMIT"
+	CodeInvalidOAuthScope   = "INVALID_OAUTH_SCOPE"
+	CodeInvalidRedirectURI  = "INVALID_REDIRECT_URI"
+
+	// 头像与背景图审核
+	CodeProfileImageReviewNotFound = "PROFILE_IMAGE_REVIEW_NOT_FOUND"
+	CodeProfileImageReviewed       = "PROFILE_IMAGE_REVIEWED"
+	CodeInvalidProfileImageStatus  = "INVALID_PROFILE_IMAGE_STATUS"
+
+	// 登录记录
+	CodeLoginEventNotFound   = "LOGIN_EVENT_NOT_FOUND"
+	CodeLoginAlreadyReported = "LOGIN_ALREADY_REPORTED"
+)
+
+// defaultErrorCodes HTTP 状态码对应的通用错误码
+var defaultErrorCodes = map[int]string{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusConflict:              CodeConflict,
+	http.StatusUnprocessableEntity:   CodeUnprocessable,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternalError,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+func defaultErrorCode(statusCode int) string {
+	if code, ok := defaultErrorCodes[statusCode]; ok {
+		return code
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return CodeInternalError
+	}
+	return CodeBadRequest
+}
+
+// errorType 由状态码生成错误类型，如 404 -> NotFound
+func errorType(statusCode int) string {
+	return strings.ReplaceAll(http.StatusText(statusCode), " ", "")
+}
@@ -0,0 +1,51 @@
+package response
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeakETag 根据版本信息生成弱 ETag（W/"..."）
+// parts 应只包含能代表资源语义变化的字段（如 updated_at、计数器）
+func WeakETag(parts ...interface{}) string {
+	h := sha1.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%v|", p)
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil))[:16] + `"`
+}
+
+// NotModified 设置 ETag 响应头；若请求的 If-None-Match 命中则直接返回 304
+// 返回 true 表示已响应，调用方应直接 return
+func NotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// etagMatches 按 RFC 7232 弱比较规则判断 If-None-Match 是否命中
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == target {
+			return true
+		}
+	}
+	return false
+}
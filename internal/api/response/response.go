@@ -3,6 +3,8 @@ package response
 import (
 	"net/http"
 
+	"vida-go/pkg/i18n"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -13,11 +15,28 @@ type Response struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// PageInfo v2 列表分页信息
+type PageInfo struct {
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+	Limit      int    `json:"limit"`
+}
+
+// ListResponse v2 列表响应：data 固定为数组，分页信息统一放在 page 中
+type ListResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+	Page    PageInfo    `json:"page"`
+}
+
 // ErrorInfo 错误详情
 type ErrorInfo struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Type    string `json:"type"`
+	Code      int               `json:"code"`
+	ErrorCode string            `json:"error_code"`
+	Message   string            `json:"message"`
+	Type      string            `json:"type"`
+	Errors    map[string]string `json:"errors,omitempty"` // 参数校验失败时的字段级错误
 }
 
 // ErrorResponse 统一错误响应
@@ -28,7 +47,7 @@ type ErrorResponse struct {
 func OK(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusOK, Response{
 		Success: true,
-		Message: message,
+		Message: localize(c, message),
 		Data:    data,
 	})
 }
@@ -36,17 +55,56 @@ func OK(c *gin.Context, message string, data interface{}) {
 func Created(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusCreated, Response{
 		Success: true,
-		Message: message,
+		Message: localize(c, message),
 		Data:    data,
 	})
 }
 
+// List 返回 v2 列表响应
+func List(c *gin.Context, message string, items interface{}, page PageInfo) {
+	c.JSON(http.StatusOK, ListResponse{
+		Success: true,
+		Message: localize(c, message),
+		Data:    items,
+		Page:    page,
+	})
+}
+
 func Fail(c *gin.Context, statusCode int, errType string, message string) {
 	c.JSON(statusCode, ErrorResponse{
 		Error: ErrorInfo{
-			Code:    statusCode,
-			Message: message,
-			Type:    errType,
+			Code:      statusCode,
+			ErrorCode: defaultErrorCode(statusCode),
+			Message:   localize(c, message),
+			Type:      errType,
+		},
+	})
+}
+
+// FailWithCode 返回携带业务错误码的错误响应，errorCode 为空时使用状态码对应的通用错误码
+func FailWithCode(c *gin.Context, statusCode int, errorCode string, message string) {
+	if errorCode == "" {
+		errorCode = defaultErrorCode(statusCode)
+	}
+	c.JSON(statusCode, ErrorResponse{
+		Error: ErrorInfo{
+			Code:      statusCode,
+			ErrorCode: errorCode,
+			Message:   localize(c, message),
+			Type:      errorType(statusCode),
+		},
+	})
+}
+
+// ValidationFailed 参数校验失败，fields 为 字段 -> 已本地化的提示信息
+func ValidationFailed(c *gin.Context, fields map[string]string) {
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error: ErrorInfo{
+			Code:      http.StatusBadRequest,
+			ErrorCode: CodeValidationFailed,
+			Message:   localize(c, "请求参数无效"),
+			Type:      errorType(http.StatusBadRequest),
+			Errors:    fields,
 		},
 	})
 }
@@ -70,3 +128,13 @@ func NotFound(c *gin.Context, message string) {
 func InternalError(c *gin.Context, message string) {
 	Fail(c, http.StatusInternalServerError, "InternalServerError", message)
 }
+
+// Locale 返回当前请求的响应语言
+func Locale(c *gin.Context) string {
+	return c.GetString(i18n.ContextKey)
+}
+
+// localize 按请求语言翻译提示信息
+func localize(c *gin.Context, message string) string {
+	return i18n.T(Locale(c), message)
+}
@@ -1,8 +1,12 @@
 package router
 
 import (
+	"net/http"
+
 	"vida-go/internal/api/handler"
 	"vida-go/internal/api/middleware"
+	"vida-go/internal/config"
+	"vida-go/internal/rbac"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,7 +21,40 @@ func Setup(
 	commentHandler *handler.CommentHandler,
 	favoriteHandler *handler.FavoriteHandler,
 	searchHandler *handler.SearchHandler,
+	auditHandler *handler.AuditHandler,
+	moderationHandler *handler.ModerationHandler,
+	eventHandler *handler.EventHandler,
+	notificationHandler *handler.NotificationHandler,
+	messageHandler *handler.MessageHandler,
+	graphQLHandler *handler.GraphQLHandler,
+	analyticsHandler *handler.AnalyticsHandler,
+	creatorHandler *handler.CreatorHandler,
+	recommendHandler *handler.RecommendHandler,
+	videoAIHandler *handler.VideoAIHandler,
+	v2Handler *handler.V2Handler,
+	importHandler *handler.ImportHandler,
+	renditionHandler *handler.RenditionHandler,
+	reportHandler *handler.ReportHandler,
+	legalHoldHandler *handler.LegalHoldHandler,
+	loginHistoryHandler *handler.LoginHistoryHandler,
+	inviteHandler *handler.InviteHandler,
+	profileImageHandler *handler.ProfileImageHandler,
+	streamHandler *handler.StreamHandler,
+	videoAccessHandler *handler.VideoAccessHandler,
+	pollHandler *handler.PollHandler,
+	premiereHandler *handler.PremiereHandler,
+	liveHandler *handler.LiveHandler,
+	profileHandler *handler.ProfileHandler,
+	exploreHandler *handler.ExploreHandler,
+	oauthHandler *handler.OAuthHandler,
+	walletHandler *handler.WalletHandler,
+	membershipHandler *handler.MembershipHandler,
+	jobHandler *handler.JobHandler,
 	adminMiddleware gin.HandlerFunc,
+	moderatorMiddleware gin.HandlerFunc,
+	reportsMiddleware gin.HandlerFunc,
+	idempotencyMiddleware gin.HandlerFunc,
+	signatureMiddleware gin.HandlerFunc,
 ) {
 	v1 := r.Group("/api/v1")
 
@@ -31,18 +68,27 @@ func Setup(
 		{
 			authRequired.POST("/logout", authHandler.Logout)
 			authRequired.GET("/me", authHandler.Me)
+			authRequired.PUT("/password", middleware.DenyImpersonation(), authHandler.ChangePassword)
 		}
 	}
 
 	// --- 用户模块 ---
 	// 公开接口：查看用户主页（头像、昵称、关注/粉丝数）
 	v1.GET("/users/:id/profile", userHandler.GetProfile)
+	v1.GET("/users/:id/home", middleware.AuthOptional(), profileHandler.GetHome)
+	v1.POST("/users/batch", middleware.AuthOptional(), userHandler.BatchGet)
+	v1.GET("/users/:id/membership-tiers", middleware.AuthOptional(), membershipHandler.ListTiers)
 	users := v1.Group("/users", middleware.AuthRequired())
 	{
 		users.GET("/me", userHandler.GetMe)
 		users.GET("/:id", userHandler.GetUser)
-		users.PUT("/:id", userHandler.UpdateUser)
-		users.POST("/me/avatar", userHandler.UploadAvatar)
+		users.PUT("/:id", middleware.DenyImpersonation(), userHandler.UpdateUser)
+		users.POST("/me/avatar", middleware.DenyImpersonation(), userHandler.UploadAvatar)
+		users.GET("/me/logins", loginHistoryHandler.ListMyLogins)
+		users.GET("/me/remix-settings", videoHandler.GetRemixSettings)
+		users.PUT("/me/remix-settings", videoHandler.UpdateRemixSettings)
+		users.POST("/me/logins/:id/report", middleware.DenyImpersonation(), loginHistoryHandler.ReportLogin)
+		users.POST("/:id/report", reportHandler.ReportUser)
 
 		// 管理员接口
 		admin := users.Group("", adminMiddleware)
@@ -51,13 +97,19 @@ func Setup(
 			admin.DELETE("/:id", userHandler.DeleteUser)
 			admin.POST("/:id/restore", userHandler.RestoreUser)
 			admin.POST("/:id/set-admin", userHandler.SetAdmin)
+			admin.POST("/:id/set-role", userHandler.SetRole)
+			admin.POST("/:id/suspend", userHandler.SuspendUser)
+			admin.POST("/:id/unsuspend", userHandler.UnsuspendUser)
+			admin.POST("/:id/mute", userHandler.MuteUser)
+			admin.POST("/:id/unmute", userHandler.UnmuteUser)
+			admin.POST("/:id/impersonate", userHandler.Impersonate)
 		}
 	}
 
 	// --- 关注关系模块 ---
 	relations := v1.Group("/relations", middleware.AuthRequired())
 	{
-		relations.POST("/follow/:id", relationHandler.Follow)
+		relations.POST("/follow/:id", idempotencyMiddleware, relationHandler.Follow)
 		relations.POST("/unfollow/:id", relationHandler.Unfollow)
 
 		relations.GET("/following/:id", relationHandler.GetFollowing)
@@ -74,17 +126,95 @@ func Setup(
 	// --- 视频模块 ---
 	videos := v1.Group("/videos")
 	{
-		// 公开接口（不需要登录）
-		videos.GET("/feed", videoHandler.GetFeed)
+		// 公开接口（登录可选，登录后附带点赞、关注状态）
+		videos.GET("/feed", signatureMiddleware, middleware.AuthOptional(), videoHandler.GetFeed)
+		videos.GET("/:id/related", middleware.AuthOptional(), recommendHandler.GetRelated)
+		videos.GET("/:id/tags", videoAIHandler.GetTags)
+		videos.GET("/:id/poll", middleware.AuthOptional(), pollHandler.Get)
+		videos.GET("/:id/premiere", middleware.AuthOptional(), premiereHandler.Get)
+		videos.GET("/:id/premiere/ws", middleware.AuthOptionalWithQueryToken(), premiereHandler.WebSocket)
 
 		// 需要登录的接口
 		videosAuth := videos.Group("", middleware.AuthRequired())
 		{
-			videosAuth.POST("/upload", videoHandler.Upload)
+			videosAuth.POST("/upload", idempotencyMiddleware, videoHandler.Upload)
 			videosAuth.GET("/my/list", videoHandler.GetMyVideos)
+			videosAuth.GET("/for-you", recommendHandler.GetForYou)
 			videosAuth.GET("/:id", videoHandler.GetDetail)
+			videosAuth.GET("/:id/stats", videoHandler.GetStats)
+			videosAuth.GET("/:id/remixes", videoHandler.ListRemixes)
+			videosAuth.PUT("/:id/tags", videoAIHandler.UpdateTags)
+			videosAuth.POST("/:id/ask", videoAIHandler.Ask)
 			videosAuth.PUT("/:id", videoHandler.UpdateVideo)
-			videosAuth.DELETE("/:id", videoHandler.DeleteVideo)
+			videosAuth.PUT("/:id/regions", videoHandler.UpdateRegions)
+			videosAuth.POST("/:id/pin", videoHandler.PinVideo)
+			videosAuth.DELETE("/:id/pin", videoHandler.UnpinVideo)
+			videosAuth.GET("/:id/access", videoAccessHandler.ListGrants)
+			videosAuth.POST("/:id/access", videoAccessHandler.Grant)
+			videosAuth.DELETE("/:id/access/:user_id", videoAccessHandler.Revoke)
+			videosAuth.POST("/:id/access/link", videoAccessHandler.CreateShareLink)
+			videosAuth.DELETE("/:id/access/link", videoAccessHandler.RevokeShareLink)
+			videosAuth.POST("/:id/access/redeem", videoAccessHandler.RedeemShareLink)
+			videosAuth.POST("/:id/poll", pollHandler.Create)
+			videosAuth.POST("/:id/poll/vote", pollHandler.Vote)
+			videosAuth.POST("/:id/poll/close", pollHandler.Close)
+			videosAuth.DELETE("/:id/poll", pollHandler.Delete)
+			videosAuth.PUT("/:id/premiere", premiereHandler.Schedule)
+			videosAuth.DELETE("/:id/premiere", premiereHandler.Cancel)
+			videosAuth.POST("/:id/premiere/chat", premiereHandler.SendChat)
+			videosAuth.POST("/:id/tip", middleware.DenyImpersonation(), idempotencyMiddleware, walletHandler.Tip)
+			videosAuth.DELETE("/:id", middleware.DenyImpersonation(), videoHandler.DeleteVideo)
+		}
+	}
+
+	// --- 金币钱包 ---
+	wallet := v1.Group("/wallet", middleware.AuthRequired())
+	{
+		wallet.GET("", walletHandler.GetWallet)
+		wallet.GET("/transactions", walletHandler.ListMyTransactions)
+	}
+
+	// --- 创作者会员 ---
+	memberships := v1.Group("/memberships", middleware.AuthRequired())
+	{
+		memberships.POST("/tiers", membershipHandler.CreateTier)
+		memberships.PUT("/tiers/:id", membershipHandler.UpdateTier)
+		memberships.DELETE("/tiers/:id", membershipHandler.ArchiveTier)
+		memberships.POST("/tiers/:id/join", middleware.DenyImpersonation(), idempotencyMiddleware, membershipHandler.Join)
+		memberships.GET("/me", membershipHandler.ListMine)
+		memberships.GET("/members", membershipHandler.ListMembers)
+	}
+
+	// --- 发现页 ---
+	v1.GET("/explore", signatureMiddleware, middleware.AuthOptional(), exploreHandler.GetExplore)
+
+	// --- 播放代理 ---
+	// 逐次鉴权后代理对象存储中的视频，支持 Range；登录可选，Token 可放在 access_token 查询参数中
+	stream := v1.Group("/stream", middleware.AuthOptionalWithQueryToken())
+	{
+		stream.GET("/:id", streamHandler.Stream)
+		stream.HEAD("/:id", streamHandler.Stream)
+	}
+
+	// --- 直播模块 ---
+	live := v1.Group("/live")
+	{
+		live.GET("/feed", liveHandler.Feed)
+		live.GET("/sessions/:id", liveHandler.GetSession)
+
+		liveAuth := live.Group("/channel", middleware.AuthRequired())
+		{
+			liveAuth.GET("", liveHandler.GetChannel)
+			liveAuth.PUT("", liveHandler.UpdateChannel)
+			liveAuth.POST("/stream-key", middleware.DenyImpersonation(), liveHandler.RotateStreamKey)
+		}
+
+		// 媒体服务器回调，以共享密钥鉴权
+		callbacks := live.Group("/callbacks")
+		{
+			callbacks.POST("/publish", liveHandler.OnPublish)
+			callbacks.POST("/unpublish", liveHandler.OnUnpublish)
+			callbacks.POST("/record", liveHandler.OnRecord)
 		}
 	}
 
@@ -93,9 +223,9 @@ func Setup(
 	{
 		commentsAuth := comments.Group("", middleware.AuthRequired())
 		{
-			commentsAuth.POST("/:video_id", commentHandler.Create)
+			commentsAuth.POST("/:video_id", idempotencyMiddleware, commentHandler.Create)
 			commentsAuth.PUT("/:id", commentHandler.Update)
-			commentsAuth.DELETE("/:id", commentHandler.Delete)
+			commentsAuth.DELETE("/:id", middleware.DenyImpersonation(), commentHandler.Delete)
 			commentsAuth.GET("/video/:video_id", commentHandler.ListByVideo)
 			commentsAuth.GET("/:id/replies", commentHandler.ListReplies)
 			commentsAuth.GET("/my/list", commentHandler.ListMyComments)
@@ -105,7 +235,7 @@ func Setup(
 	// --- 点赞模块 ---
 	favorites := v1.Group("/favorites", middleware.AuthRequired())
 	{
-		favorites.POST("/:video_id", favoriteHandler.Favorite)
+		favorites.POST("/:video_id", idempotencyMiddleware, favoriteHandler.Favorite)
 		favorites.DELETE("/:video_id", favoriteHandler.Unfavorite)
 		favorites.GET("/:video_id/status", favoriteHandler.GetStatus)
 		favorites.GET("/my/list", favoriteHandler.ListMyFavorites)
@@ -114,10 +244,157 @@ func Setup(
 		favorites.POST("/batch/status", favoriteHandler.BatchStatus)
 	}
 
+	// --- 内容审核（版主及以上） ---
+	moderation := v1.Group("/moderation", middleware.AuthRequired(), moderatorMiddleware)
+	{
+		moderation.GET("/videos/hidden", moderationHandler.ListHiddenVideos)
+		moderation.POST("/videos/:id/hide", moderationHandler.HideVideo)
+		moderation.POST("/videos/:id/unhide", moderationHandler.UnhideVideo)
+		moderation.POST("/videos/:id/age-restrict", moderationHandler.AgeRestrictVideo)
+		moderation.POST("/videos/:id/age-unrestrict", moderationHandler.AgeUnrestrictVideo)
+		moderation.GET("/comments/hidden", moderationHandler.ListHiddenComments)
+		moderation.POST("/comments/:id/hide", moderationHandler.HideComment)
+		moderation.POST("/comments/:id/unhide", moderationHandler.UnhideComment)
+		moderation.GET("/duplicates", moderationHandler.ListDuplicates)
+		moderation.POST("/duplicates/:id/dismiss", moderationHandler.DismissDuplicate)
+		moderation.POST("/duplicates/:id/hide", moderationHandler.HideDuplicate)
+		moderation.GET("/profile-images", profileImageHandler.ListReviews)
+		moderation.POST("/profile-images/:id/approve", profileImageHandler.ApproveImage)
+		moderation.POST("/profile-images/:id/reject", profileImageHandler.RejectImage)
+	}
+
+	// 举报审核队列（需要处理举报权限）
+	reports := v1.Group("/moderation/reports", middleware.AuthRequired(), reportsMiddleware)
+	{
+		reports.GET("", reportHandler.ListReports)
+		reports.POST("/:id/resolve", reportHandler.ResolveReport)
+		reports.POST("/:id/dismiss", reportHandler.DismissReport)
+	}
+
+	// --- 管理后台 ---
+	adminGroup := v1.Group("/admin", middleware.AuthRequired(), adminMiddleware)
+	{
+		adminGroup.GET("/audit-logs", auditHandler.ListAuditLogs)
+		adminGroup.POST("/import/relations", importHandler.ImportRelations)
+		adminGroup.POST("/import/favorites", importHandler.ImportFavorites)
+		adminGroup.GET("/renditions", renditionHandler.Summary)
+		adminGroup.GET("/videos/:id/renditions", renditionHandler.GetVideoRenditions)
+		adminGroup.PUT("/videos/:id/regions", videoHandler.SetRegions)
+		adminGroup.GET("/legal-holds", legalHoldHandler.ListLegalHolds)
+		adminGroup.POST("/videos/:id/legal-hold", legalHoldHandler.HoldVideo)
+		adminGroup.DELETE("/videos/:id/legal-hold", legalHoldHandler.ReleaseVideo)
+		adminGroup.POST("/users/:id/legal-hold", legalHoldHandler.HoldUser)
+		adminGroup.DELETE("/users/:id/legal-hold", legalHoldHandler.ReleaseUser)
+		adminGroup.POST("/invite-codes", inviteHandler.CreateCodes)
+		adminGroup.GET("/invite-codes", inviteHandler.ListCodes)
+		adminGroup.DELETE("/invite-codes/:id", inviteHandler.RevokeCode)
+		adminGroup.GET("/users/:id/invitees", inviteHandler.ListInvitees)
+		adminGroup.GET("/explore/slots", exploreHandler.ListSlots)
+		adminGroup.POST("/explore/slots", exploreHandler.CreateSlot)
+		adminGroup.DELETE("/explore/slots/:id", exploreHandler.DeleteSlot)
+		adminGroup.GET("/users/:id/wallet", walletHandler.GetUserWallet)
+		adminGroup.POST("/users/:id/wallet/adjust", walletHandler.AdjustWallet)
+		adminGroup.GET("/wallets/transactions", walletHandler.ListTransactions)
+		adminGroup.GET("/jobs", jobHandler.ListJobs)
+	}
+
+	// --- 实时事件 ---
+	v1.GET("/events/stream", middleware.AuthRequiredWithQueryToken(), eventHandler.Stream)
+	v1.GET("/events/ws", middleware.AuthRequiredWithQueryToken(), eventHandler.WebSocket)
+	v1.POST("/events", middleware.AuthOptional(), analyticsHandler.Ingest)
+
+	// --- 创作者数据看板 ---
+	creator := v1.Group("/creator", middleware.AuthRequired())
+	{
+		creator.GET("/analytics/overview", creatorHandler.GetOverview)
+		creator.GET("/analytics/videos/:id/retention", creatorHandler.GetRetention)
+	}
+
+	// --- GraphQL（登录可选，登录后返回当前用户相关字段） ---
+	v1.GET("/graphql", middleware.AuthOptional(), graphQLHandler.Query)
+	v1.POST("/graphql", middleware.AuthOptional(), graphQLHandler.Query)
+
+	// --- 私信模块（仅互相关注的用户之间） ---
+	messages := v1.Group("/messages", middleware.AuthRequired())
+	{
+		messages.POST("/users/:id", idempotencyMiddleware, messageHandler.Send)
+		messages.GET("/conversations", messageHandler.ListConversations)
+		messages.GET("/conversations/:id", messageHandler.ListMessages)
+		messages.POST("/conversations/:id/read", messageHandler.MarkRead)
+		messages.GET("/unread-count", messageHandler.UnreadCount)
+	}
+
+	// --- 通知模块 ---
+	notifications := v1.Group("/notifications", middleware.AuthRequired())
+	{
+		notifications.GET("", notificationHandler.List)
+		notifications.GET("/unread-count", notificationHandler.UnreadCount)
+		notifications.POST("/read", notificationHandler.MarkRead)
+		notifications.POST("/read-all", notificationHandler.MarkAllRead)
+		notifications.GET("/preferences", notificationHandler.GetPreferences)
+		notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		notifications.POST("/preferences/videos/:video_id", notificationHandler.MuteVideo)
+		notifications.DELETE("/preferences/videos/:video_id", notificationHandler.UnmuteVideo)
+		notifications.GET("/email-preferences", notificationHandler.GetEmailPreferences)
+		notifications.PUT("/email-preferences", notificationHandler.UpdateEmailPreferences)
+		notifications.GET("/devices", notificationHandler.ListDevices)
+		notifications.POST("/devices", middleware.DenyImpersonation(), notificationHandler.RegisterDevice)
+		notifications.DELETE("/devices", notificationHandler.UnregisterDevice)
+	}
+
 	// --- 搜索模块 ---
 	search := v1.Group("/search")
 	{
-		search.GET("/videos", searchHandler.SearchVideos)
+		search.GET("/videos", signatureMiddleware, middleware.AuthOptional(), searchHandler.SearchVideos)
 		search.POST("/sync", searchHandler.SyncVideosToES)
 	}
+
+	// --- 第三方应用 OAuth ---
+	oauth := v1.Group("/oauth")
+	{
+		oauth.POST("/token", oauthHandler.Token)
+
+		oauthAuth := oauth.Group("", middleware.AuthRequired())
+		{
+			oauthAuth.GET("/authorize", oauthHandler.GetAuthorize)
+			oauthAuth.POST("/authorize", middleware.DenyImpersonation(), oauthHandler.Authorize)
+			oauthAuth.GET("/clients", oauthHandler.ListClients)
+			oauthAuth.POST("/clients", middleware.DenyImpersonation(), oauthHandler.CreateClient)
+			oauthAuth.DELETE("/clients/:id", middleware.DenyImpersonation(), oauthHandler.RevokeClient)
+		}
+	}
+
+	// 上传接口的请求体上限（视频上传在处理时还会按上传者角色收紧），其余接口使用默认上限
+	middleware.AllowBodySize(http.MethodPost, "/api/v1/users/me/avatar", handler.AvatarMaxSize+handler.UploadOverheadBytes)
+	middleware.AllowBodySize(http.MethodPost, "/api/v1/videos/upload", config.GetUpload().MaxSize()+handler.UploadOverheadBytes)
+
+	// 第三方应用的 OAuth 令牌可访问的接口
+	middleware.AllowScope(http.MethodGet, "/api/v1/videos/feed", rbac.ScopeReadVideos)
+	middleware.AllowScope(http.MethodGet, "/api/v1/videos/:id", rbac.ScopeReadVideos)
+	middleware.AllowScope(http.MethodGet, "/api/v1/videos/:id/related", rbac.ScopeReadVideos)
+	middleware.AllowScope(http.MethodGet, "/api/v1/search/videos", rbac.ScopeReadVideos)
+	middleware.AllowScope(http.MethodGet, "/api/v2/videos/feed", rbac.ScopeReadVideos)
+	middleware.AllowScope(http.MethodPost, "/api/v1/comments/:video_id", rbac.ScopeWriteComments)
+	middleware.AllowScope(http.MethodPut, "/api/v1/comments/:id", rbac.ScopeWriteComments)
+	middleware.AllowScope(http.MethodDelete, "/api/v1/comments/:id", rbac.ScopeWriteComments)
+
+	// --- v2：列表统一游标分页，响应带 page 信息与 viewer 状态，与 v1 共用 Service ---
+	v2 := r.Group("/api/v2")
+	v2.GET("/videos/feed", signatureMiddleware, middleware.AuthOptional(), v2Handler.Feed)
+
+	v2Auth := v2.Group("", middleware.AuthRequired())
+	{
+		v2Auth.GET("/videos/my", v2Handler.MyVideos)
+		v2Auth.GET("/videos/:id/comments", v2Handler.VideoComments)
+		v2Auth.GET("/videos/:id/favorites", v2Handler.VideoFavorites)
+		v2Auth.GET("/users/:id/following", v2Handler.Following)
+		v2Auth.GET("/users/:id/followers", v2Handler.Followers)
+		v2Auth.GET("/relations/mutual", v2Handler.Mutual)
+		v2Auth.GET("/comments/my", v2Handler.MyComments)
+		v2Auth.GET("/comments/:id/replies", v2Handler.CommentReplies)
+		v2Auth.GET("/favorites/my/videos", v2Handler.MyFavoritedVideos)
+		v2Auth.GET("/notifications", v2Handler.Notifications)
+		v2Auth.GET("/messages/conversations", v2Handler.Conversations)
+		v2Auth.GET("/messages/conversations/:id", v2Handler.Messages)
+	}
 }
@@ -0,0 +1,464 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Spec swag 生成的 Swagger 2.0 文档中请求校验用到的部分
+type Spec struct {
+	BasePath    string                           `json:"basePath"`
+	Paths       map[string]map[string]*Operation `json:"paths"`
+	Definitions map[string]*Schema               `json:"definitions"`
+}
+
+// Operation 接口文档中的一个操作（路径 + 方法）
+type Operation struct {
+	Consumes   []string     `json:"consumes"`
+	Parameters []*Parameter `json:"parameters"`
+}
+
+// Parameter 操作参数，in 为 path / query / header / formData / body
+type Parameter struct {
+	Name      string   `json:"name"`
+	In        string   `json:"in"`
+	Required  bool     `json:"required"`
+	Type      string   `json:"type"`
+	Format    string   `json:"format"`
+	Enum      []any    `json:"enum"`
+	Minimum   *float64 `json:"minimum"`
+	Maximum   *float64 `json:"maximum"`
+	MinLength *int     `json:"minLength"`
+	MaxLength *int     `json:"maxLength"`
+	Items     *Schema  `json:"items"`
+	Schema    *Schema  `json:"schema"`
+}
+
+// Schema JSON Schema 子集
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	AllOf                []*Schema          `json:"allOf"`
+	Enum                 []any              `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+}
+
+// ParseSpec 解析 swag 生成的 swagger.json
+func ParseSpec(doc []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return nil, fmt.Errorf("parse openapi spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// Operation 按 gin 的完整路由模板（如 /api/v1/videos/:id）查找接口文档中的操作，未收录时返回 nil。
+// 注解中的路径相对 basePath（/api/v1），v2 接口写作 /v2/...
+func (s *Spec) Operation(method, fullPath string) *Operation {
+	path, ok := strings.CutPrefix(fullPath, s.BasePath)
+	if !ok {
+		if path, ok = strings.CutPrefix(fullPath, "/api"); !ok {
+			return nil
+		}
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return s.Paths[strings.Join(segments, "/")][strings.ToLower(method)]
+}
+
+// Accepts 请求的 Content-Type 是否在操作声明的 consumes 中，未声明时不限制
+func (op *Operation) Accepts(contentType string) bool {
+	if len(op.Consumes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(op.Consumes, mediaType)
+}
+
+// HasBody 操作是否声明了请求体（body 或 formData 参数）
+func (op *Operation) HasBody() bool {
+	return slices.ContainsFunc(op.Parameters, func(p *Parameter) bool {
+		return p.In == "body" || p.In == "formData"
+	})
+}
+
+// RequestValidator 按接口文档校验请求参数与 JSON 请求体，strict 时拒绝文档中未声明的查询参数与字段
+type RequestValidator struct {
+	spec   *Spec
+	strict bool
+}
+
+func NewRequestValidator(spec *Spec, strict bool) *RequestValidator {
+	return &RequestValidator{spec: spec, strict: strict}
+}
+
+// Operation 查找请求对应的接口文档操作，见 Spec.Operation
+func (v *RequestValidator) Operation(method, fullPath string) *Operation {
+	return v.spec.Operation(method, fullPath)
+}
+
+// RequestInput 待校验的请求内容。Form 为已解析的 urlencoded 表单，Body 为 JSON 请求体，
+// multipart 请求不在此读取，避免提前落盘上传的文件
+type RequestInput struct {
+	PathParams map[string]string
+	Query      url.Values
+	Header     func(name string) string
+	Form       url.Values
+	Body       []byte
+	SkipBody   bool // 请求体过大未读取，不校验请求体
+}
+
+// Validate 校验请求，返回 字段 -> 提示信息，通过时返回 nil。lang 为请求语言
+func (v *RequestValidator) Validate(op *Operation, in *RequestInput, lang string) map[string]string {
+	r := &reporter{lang: lang, fields: make(map[string]string)}
+
+	documented := map[string]map[string]bool{"query": {}, "formData": {}}
+	var body *Parameter
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			v.checkParam(r, p, []string{in.PathParams[p.Name]})
+		case "query":
+			documented["query"][p.Name] = true
+			v.checkParam(r, p, in.Query[p.Name])
+		case "header":
+			if value := in.Header(p.Name); value != "" {
+				v.checkParam(r, p, []string{value})
+			} else if p.Required {
+				r.add(p.Name, "%s为必填字段", "%s is a required field", p.Name)
+			}
+		case "formData":
+			documented["formData"][p.Name] = true
+			if in.Form != nil && p.Type != "file" {
+				v.checkParam(r, p, in.Form[p.Name])
+			}
+		case "body":
+			body = p
+		}
+	}
+
+	if v.strict {
+		for key := range in.Query {
+			if !documented["query"][key] && !ignoredQuery[key] {
+				r.add(key, "%s未在接口文档中定义", "%s is not defined in the API spec", key)
+			}
+		}
+		for key := range in.Form {
+			if !documented["formData"][key] {
+				r.add(key, "%s未在接口文档中定义", "%s is not defined in the API spec", key)
+			}
+		}
+	}
+
+	switch {
+	case in.SkipBody:
+	case body != nil:
+		v.checkBody(r, body, in.Body)
+	case v.strict && len(bytes.TrimSpace(in.Body)) > 0 && !op.HasBody():
+		r.add("body", "该接口不接受请求体", "this endpoint does not accept a request body")
+	}
+
+	if len(r.fields) == 0 {
+		return nil
+	}
+	return r.fields
+}
+
+// ignoredQuery 由中间件统一处理、不在各接口文档中声明的查询参数
+var ignoredQuery = map[string]bool{
+	"access_token": true, // 流媒体等接口可通过查询参数传递 Token
+}
+
+func (v *RequestValidator) checkParam(r *reporter, p *Parameter, values []string) {
+	if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+		if p.Required {
+			r.add(p.Name, "%s为必填字段", "%s is a required field", p.Name)
+		}
+		return
+	}
+	if p.Type != "array" {
+		values = values[len(values)-1:]
+	}
+	schema := &Schema{
+		Type: p.Type, Format: p.Format, Enum: p.Enum,
+		Minimum: p.Minimum, Maximum: p.Maximum, MinLength: p.MinLength, MaxLength: p.MaxLength,
+	}
+	if p.Type == "array" {
+		if p.Items == nil {
+			return
+		}
+		schema = p.Items
+	}
+	for _, raw := range values {
+		value, ok := parseScalar(schema.Type, raw)
+		if !ok {
+			r.add(p.Name, "%s类型错误，应为%s", "%s must be of type %s", p.Name, schema.Type)
+			return
+		}
+		v.checkSchema(r, schema, value, p.Name)
+	}
+}
+
+func (v *RequestValidator) checkBody(r *reporter, p *Parameter, body []byte) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if p.Required {
+			r.add("body", "请求体不能为空", "request body is required")
+		}
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		r.add("body", "请求体不是有效的 JSON", "request body must be valid JSON")
+		return
+	}
+	if p.Schema != nil {
+		v.checkSchema(r, p.Schema, value, "")
+	}
+}
+
+// checkSchema 按 schema 校验 JSON 值，field 为字段路径（如 items[0].name），根对象为空
+func (v *RequestValidator) checkSchema(r *reporter, schema *Schema, value any, field string) {
+	schema = v.resolve(schema)
+	if schema == nil || value == nil {
+		return
+	}
+	name := field
+	if name == "" {
+		name = "body"
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			r.add(name, "%s类型错误，应为%s", "%s must be of type %s", name, "object")
+			return
+		}
+		v.checkObject(r, schema, obj, field)
+		return
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			r.add(name, "%s类型错误，应为%s", "%s must be of type %s", name, "array")
+			return
+		}
+		if schema.MinItems != nil && len(arr) < *schema.MinItems {
+			r.add(name, "%s必须至少包含%d项", "%s must contain at least %d items", name, *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(arr) > *schema.MaxItems {
+			r.add(name, "%s最多只能包含%d项", "%s must contain at maximum %d items", name, *schema.MaxItems)
+		}
+		for i, item := range arr {
+			v.checkSchema(r, schema.Items, item, fmt.Sprintf("%s[%d]", name, i))
+		}
+		return
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			r.add(name, "%s类型错误，应为%s", "%s must be of type %s", name, "string")
+			return
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				r.add(name, "%s必须是 RFC 3339 格式的时间", "%s must be an RFC 3339 date-time", name)
+				return
+			}
+		}
+		n := utf8.RuneCountInString(s)
+		if schema.MinLength != nil && n < *schema.MinLength {
+			r.add(name, "%s长度必须至少为%d个字符", "%s must be at least %d characters in length", name, *schema.MinLength)
+		}
+		if schema.MaxLength != nil && n > *schema.MaxLength {
+			r.add(name, "%s长度不能超过%d个字符", "%s must be a maximum of %d characters in length", name, *schema.MaxLength)
+		}
+	case "integer", "number":
+		num, ok := value.(json.Number)
+		if !ok {
+			r.add(name, "%s类型错误，应为%s", "%s must be of type %s", name, schema.Type)
+			return
+		}
+		f, err := num.Float64()
+		if err != nil {
+			r.add(name, "%s类型错误，应为%s", "%s must be of type %s", name, schema.Type)
+			return
+		}
+		if schema.Type == "integer" {
+			if _, err := num.Int64(); err != nil {
+				r.add(name, "%s类型错误，应为%s", "%s must be of type %s", name, schema.Type)
+				return
+			}
+		}
+		if schema.Minimum != nil && f < *schema.Minimum {
+			r.add(name, "%s必须大于或等于%v", "%s must be %v or greater", name, *schema.Minimum)
+		}
+		if schema.Maximum != nil && f > *schema.Maximum {
+			r.add(name, "%s必须小于或等于%v", "%s must be %v or less", name, *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			r.add(name, "%s类型错误，应为%s", "%s must be of type %s", name, "boolean")
+			return
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		options := make([]string, len(schema.Enum))
+		for i, e := range schema.Enum {
+			options[i] = fmt.Sprint(e)
+		}
+		r.add(name, "%s必须是[%s]中的一个", "%s must be one of [%s]", name, strings.Join(options, " "))
+	}
+}
+
+func (v *RequestValidator) checkObject(r *reporter, schema *Schema, obj map[string]any, field string) {
+	prefix := ""
+	if field != "" {
+		prefix = field + "."
+	}
+	for _, key := range schema.Required {
+		if obj[key] == nil {
+			r.add(prefix+key, "%s为必填字段", "%s is a required field", prefix+key)
+		}
+	}
+
+	extra := v.additional(schema)
+	for key, value := range obj {
+		if prop, ok := schema.Properties[key]; ok {
+			v.checkSchema(r, prop, value, prefix+key)
+			continue
+		}
+		switch {
+		case extra != nil:
+			v.checkSchema(r, extra, value, prefix+key)
+		case v.strict && !allowsAdditional(schema):
+			r.add(prefix+key, "%s未在接口文档中定义", "%s is not defined in the API spec", prefix+key)
+		}
+	}
+}
+
+// resolve 展开 $ref 与 allOf，返回可直接校验的 schema
+func (v *RequestValidator) resolve(schema *Schema) *Schema {
+	for depth := 0; schema != nil && schema.Ref != ""; depth++ {
+		if depth > 32 {
+			return nil
+		}
+		schema = v.spec.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+	}
+	if schema == nil || len(schema.AllOf) == 0 {
+		return schema
+	}
+
+	merged := *schema
+	merged.AllOf = nil
+	merged.Properties = make(map[string]*Schema, len(schema.Properties))
+	for k, p := range schema.Properties {
+		merged.Properties[k] = p
+	}
+	for _, part := range schema.AllOf {
+		part = v.resolve(part)
+		if part == nil {
+			continue
+		}
+		if merged.Type == "" {
+			merged.Type = part.Type
+		}
+		merged.Required = append(merged.Required, part.Required...)
+		for k, p := range part.Properties {
+			merged.Properties[k] = p
+		}
+	}
+	return &merged
+}
+
+// additional 返回 additionalProperties 声明的值 schema（map 类型字段），未声明或为布尔值时返回 nil
+func (v *RequestValidator) additional(schema *Schema) *Schema {
+	raw := bytes.TrimSpace(schema.AdditionalProperties)
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil
+	}
+	var extra Schema
+	if err := json.Unmarshal(raw, &extra); err != nil {
+		return nil
+	}
+	return &extra
+}
+
+// allowsAdditional 是否允许文档未声明的字段：显式 additionalProperties: true，
+// 或未声明任何属性的 object（如 map[string]any）
+func allowsAdditional(schema *Schema) bool {
+	switch string(bytes.TrimSpace(schema.AdditionalProperties)) {
+	case "true":
+		return true
+	case "":
+		return len(schema.Properties) == 0
+	default:
+		return false
+	}
+}
+
+func parseScalar(typ, raw string) (any, bool) {
+	switch typ {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(raw), true
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		return b, err == nil
+	default:
+		return raw, true
+	}
+}
+
+func inEnum(enum []any, value any) bool {
+	s := fmt.Sprint(value)
+	return slices.ContainsFunc(enum, func(e any) bool {
+		return fmt.Sprint(e) == s
+	})
+}
+
+// reporter 收集字段错误，每个字段只保留第一条
+type reporter struct {
+	lang   string
+	fields map[string]string
+}
+
+func (r *reporter) add(field, zhFormat, enFormat string, args ...any) {
+	if _, ok := r.fields[field]; ok {
+		return
+	}
+	format := zhFormat
+	if strings.HasPrefix(r.lang, "en") {
+		format = enFormat
+	}
+	r.fields[field] = fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+var uni *ut.UniversalTranslator
+
+// Init 为 gin 的参数校验器注册字段名解析与多语言错误翻译，需在启动时调用
+func Init() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("unexpected validator engine")
+	}
+
+	// 错误中的字段名使用 json / form 标签，与客户端提交的字段保持一致
+	v.RegisterTagNameFunc(fieldName)
+
+	zhLocale, enLocale := zh.New(), en.New()
+	uni = ut.New(zhLocale, zhLocale, enLocale)
+
+	zhTrans, _ := uni.GetTranslator("zh")
+	if err := zhTranslations.RegisterDefaultTranslations(v, zhTrans); err != nil {
+		return fmt.Errorf("register zh translations: %w", err)
+	}
+	enTrans, _ := uni.GetTranslator("en")
+	if err := enTranslations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		return fmt.Errorf("register en translations: %w", err)
+	}
+	return nil
+}
+
+// FieldErrors 将参数绑定错误转换为 字段 -> 提示信息 的映射
+// lang 为请求语言（如 zh-CN、en-US）；无法按字段拆分的错误返回 nil
+func FieldErrors(err error, lang string) map[string]string {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		trans := translator(lang)
+		fields := make(map[string]string, len(validationErrs))
+		for _, fe := range validationErrs {
+			if trans != nil {
+				fields[fe.Field()] = fe.Translate(trans)
+			} else {
+				fields[fe.Field()] = fe.Error()
+			}
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		msg := fmt.Sprintf("%s类型错误，应为%s", typeErr.Field, typeErr.Type.String())
+		if strings.HasPrefix(lang, "en") {
+			msg = fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type.String())
+		}
+		return map[string]string{typeErr.Field: msg}
+	}
+	return nil
+}
+
+func translator(lang string) ut.Translator {
+	if uni == nil {
+		return nil
+	}
+	base, _, _ := strings.Cut(lang, "-")
+	trans, _ := uni.FindTranslator(strings.ToLower(base))
+	return trans
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
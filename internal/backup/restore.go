@@ -0,0 +1,231 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConflictMode 恢复时主键或唯一键冲突的处理方式
+type ConflictMode string
+
+const (
+	ConflictSkip      ConflictMode = "skip"      // 保留库中已有记录，跳过备份中的冲突行
+	ConflictOverwrite ConflictMode = "overwrite" // 按主键用备份覆盖已有记录
+	ConflictFail      ConflictMode = "fail"      // 遇到冲突时中止并回滚
+)
+
+// ParseConflictMode 解析冲突处理方式
+func ParseConflictMode(s string) (ConflictMode, error) {
+	switch mode := ConflictMode(s); mode {
+	case ConflictSkip, ConflictOverwrite, ConflictFail:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid conflict mode %q (skip/overwrite/fail)", s)
+	}
+}
+
+// ErrUnsupportedVersion 备份格式版本高于当前程序支持的版本
+var ErrUnsupportedVersion = errors.New("unsupported backup format version")
+
+// TableResult 单张表的恢复结果，Written 为实际写入（新增或覆盖）的行数
+type TableResult struct {
+	Name    string
+	Rows    int64
+	Written int64
+}
+
+// Restorer 恢复备份
+type Restorer struct {
+	db        *gorm.DB
+	batchSize int
+}
+
+func NewRestorer(db *gorm.DB, batchSize int) *Restorer {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &Restorer{db: db, batchSize: batchSize}
+}
+
+// ReadManifest 读取并校验备份目录中的清单
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, manifest.FormatVersion)
+	}
+	return &manifest, nil
+}
+
+// Restore 校验各文件的校验和后，在一个事务中按依赖顺序写入各表；任一表失败时全部回滚。
+// 备份中的计数字段原样写入，skip 模式与已有数据合并后由计数修复任务重新校准
+func (r *Restorer) Restore(ctx context.Context, dir string, mode ConflictMode) ([]TableResult, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	dumps := make(map[string]TableDump, len(manifest.Tables))
+	for _, dump := range manifest.Tables {
+		if err := verifyChecksum(filepath.Join(dir, dump.File), dump.SHA256); err != nil {
+			return nil, fmt.Errorf("verify %s: %w", dump.File, err)
+		}
+		dumps[dump.Name] = dump
+	}
+
+	var results []TableResult
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, t := range tables {
+			dump, ok := dumps[t.name]
+			if !ok {
+				continue
+			}
+			result, err := r.restoreTable(ctx, tx, dir, t, dump, mode)
+			if err != nil {
+				return fmt.Errorf("restore %s: %w", t.name, err)
+			}
+			results = append(results, result)
+		}
+		return resetSequences(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *Restorer) restoreTable(ctx context.Context, tx *gorm.DB, dir string, t table, dump TableDump, mode ConflictMode) (TableResult, error) {
+	f, err := os.Open(filepath.Join(dir, dump.File))
+	if err != nil {
+		return TableResult{}, err
+	}
+	defer f.Close()
+
+	result, err := t.restore(ctx, tx, f, mode, r.batchSize)
+	if err != nil {
+		return result, err
+	}
+	if result.Rows != dump.Rows {
+		return result, fmt.Errorf("row count mismatch: manifest %d, file %d", dump.Rows, result.Rows)
+	}
+	result.Name = t.name
+	return result, nil
+}
+
+// restoreRows 逐行解析 JSONL 并分批写入。未知列忽略（兼容旧版本导出后删除的字段），
+// 缺少的列取零值；不触发模型钩子、不写关联
+func restoreRows[T any](ctx context.Context, tx *gorm.DB, r io.Reader, mode ConflictMode, batchSize int) (TableResult, error) {
+	var result TableResult
+	sch, err := parseSchema[T](tx)
+	if err != nil {
+		return result, err
+	}
+
+	db := tx.Session(&gorm.Session{SkipHooks: true}).Omit(clause.Associations)
+	switch mode {
+	case ConflictSkip:
+		db = db.Clauses(clause.OnConflict{DoNothing: true})
+	case ConflictOverwrite:
+		db = db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, UpdateAll: true})
+	}
+
+	batch := make([]T, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		res := db.Create(&batch)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Written += res.RowsAffected
+		batch = batch[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var row map[string]json.RawMessage
+		if err := json.Unmarshal(line, &row); err != nil {
+			return result, fmt.Errorf("line %d: %w", result.Rows+1, err)
+		}
+
+		var item T
+		rv := reflect.ValueOf(&item).Elem()
+		for name, raw := range row {
+			field, ok := sch.FieldsByDBName[name]
+			if !ok {
+				continue
+			}
+			if err := json.Unmarshal(raw, field.ReflectValueOf(ctx, rv).Addr().Interface()); err != nil {
+				return result, fmt.Errorf("line %d column %s: %w", result.Rows+1, name, err)
+			}
+		}
+		batch = append(batch, item)
+		result.Rows++
+
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+	return result, flush()
+}
+
+func verifyChecksum(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// resetSequences 按显式主键写入后，PostgreSQL 的自增序列不会前进，需对齐到当前最大 ID；
+// MySQL 与 SQLite 自动调整
+func resetSequences(tx *gorm.DB) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+	for _, t := range tables {
+		sql := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE((SELECT MAX(id) FROM %[1]s), 0) + 1, false)", t.name)
+		if err := tx.Exec(sql).Error; err != nil {
+			return fmt.Errorf("reset sequence of %s: %w", t.name, err)
+		}
+	}
+	return nil
+}
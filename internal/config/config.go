@@ -2,9 +2,11 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 // Config 全局配置结构体
@@ -18,18 +20,56 @@ type Config struct {
 	Agent         AgentConfig         `mapstructure:"agent"`
 	JWT           JWTConfig           `mapstructure:"jwt"`
 	Log           LogConfig           `mapstructure:"log"`
+	Tracing       TracingConfig       `mapstructure:"tracing"`
+	I18n          I18nConfig          `mapstructure:"i18n"`
+	Email         EmailConfig         `mapstructure:"email"`
+	Push          PushConfig          `mapstructure:"push"`
+	Message       MessageConfig       `mapstructure:"message"`
+	GRPC          GRPCConfig          `mapstructure:"grpc"`
+	Recommend     RecommendConfig     `mapstructure:"recommend"`
+	Duplicate     DuplicateConfig     `mapstructure:"duplicate"`
+	Upload        UploadConfig        `mapstructure:"upload"`
+	Secrets       SecretsConfig       `mapstructure:"secrets"`
+	CounterRepair CounterRepairConfig `mapstructure:"counter_repair"`
+	Rendition     RenditionConfig     `mapstructure:"rendition"`
+	Report        ReportConfig        `mapstructure:"report"`
+	GeoIP         GeoIPConfig         `mapstructure:"geoip"`
+	AgeGate       AgeGateConfig       `mapstructure:"age_gate"`
+	Registration  RegistrationConfig  `mapstructure:"registration"`
+	Username      UsernameConfig      `mapstructure:"username"`
+	Live          LiveConfig          `mapstructure:"live"`
+	Explore       ExploreConfig       `mapstructure:"explore"`
+	HotScore      HotScoreConfig      `mapstructure:"hot_score"`
+	OAuth         OAuthConfig         `mapstructure:"oauth"`
+	OpenAPI       OpenAPIConfig       `mapstructure:"openapi"`
+	ColdStorage   ColdStorageConfig   `mapstructure:"cold_storage"`
+	Purge         PurgeConfig         `mapstructure:"purge"`
+	Signing       SigningConfig       `mapstructure:"signing"`
+	RequestLimit  RequestLimitConfig  `mapstructure:"request_limit"`
+	Premiere      PremiereConfig      `mapstructure:"premiere"`
+	Membership    MembershipConfig    `mapstructure:"membership"`
+	Digest        DigestConfig        `mapstructure:"digest"`
+	Scheduler     SchedulerConfig     `mapstructure:"scheduler"`
+	Events        EventsConfig        `mapstructure:"events"`
 }
 
 // AppConfig 应用配置
 type AppConfig struct {
-	Name    string `mapstructure:"name"`
-	Version string `mapstructure:"version"`
-	Mode    string `mapstructure:"mode"`
-	Port    int    `mapstructure:"port"`
+	Name       string `mapstructure:"name"`
+	Version    string `mapstructure:"version"`
+	Mode       string `mapstructure:"mode"`
+	Port       int    `mapstructure:"port"`
+	Standalone bool   `mapstructure:"standalone"` // 单机开发模式：MinIO 不可用时只告警（上传、转码不可用）
+
+	// 可信反向代理的 IP 或网段，只有来自这些地址的请求才采信 X-Forwarded-For / X-Real-IP；
+	// 为空时一律使用连接的对端地址，避免客户端伪造来源 IP 绕过地区限制
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
+	Driver          string `mapstructure:"driver"` // postgres / mysql / sqlite，默认 postgres
+	Path            string `mapstructure:"path"`   // sqlite 数据库文件路径
 	Host            string `mapstructure:"host"`
 	Port            int    `mapstructure:"port"`
 	User            string `mapstructure:"user"`
@@ -39,10 +79,65 @@ type DatabaseConfig struct {
 	MaxOpenConns    int    `mapstructure:"max_open_conns"`
 	MaxIdleConns    int    `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime"` // 秒
+
+	// SQL 日志
+	LogLevel      string `mapstructure:"log_level"`         // silent / error / warn / info，默认 warn（记录错误和慢查询）
+	SlowThreshold int    `mapstructure:"slow_threshold_ms"` // 慢查询阈值（毫秒），默认 200
+	LogParams     bool   `mapstructure:"log_params"`        // 日志中的 SQL 是否带参数值，默认不带（脱敏）
+
+	// 只读副本连接字符串（与主库同一驱动的 DSN 格式），列表、视频流等读多的查询走副本
+	Replicas []string `mapstructure:"replicas"`
+}
+
+// DriverName 返回数据库驱动，未配置时默认 postgres
+func (d *DatabaseConfig) DriverName() string {
+	if d.Driver == "" {
+		return "postgres"
+	}
+	return d.Driver
+}
+
+// GormLogLevel 返回 GORM 日志级别，未配置时默认 warn
+func (d *DatabaseConfig) GormLogLevel() gormlogger.LogLevel {
+	switch d.LogLevel {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
+
+// SlowQueryThreshold 返回慢查询阈值，未配置时默认 200ms
+func (d *DatabaseConfig) SlowQueryThreshold() time.Duration {
+	if d.SlowThreshold <= 0 {
+		return 200 * time.Millisecond
+	}
+	return time.Duration(d.SlowThreshold) * time.Millisecond
 }
 
-// DSN 返回PostgreSQL连接字符串
+// SQLitePath 返回 sqlite 数据库文件路径，未配置时默认 data/vida.db
+func (d *DatabaseConfig) SQLitePath() string {
+	if d.Path == "" {
+		return "data/vida.db"
+	}
+	return d.Path
+}
+
+// DSN 返回当前驱动的连接字符串；MySQL 时间按 UTC 读写，与 PostgreSQL 的统计口径一致
 func (d *DatabaseConfig) DSN() string {
+	if d.DriverName() == "sqlite" {
+		return fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on", d.SQLitePath())
+	}
+	if d.DriverName() == "mysql" {
+		return fmt.Sprintf(
+			"%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=true&loc=UTC",
+			d.User, d.Password, d.Host, d.Port, d.DBName,
+		)
+	}
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		d.Host, d.Port, d.User, d.Password, d.DBName, d.SSLMode,
@@ -56,6 +151,7 @@ type RedisConfig struct {
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db"`
 	PoolSize int    `mapstructure:"pool_size"`
+	Embedded bool   `mapstructure:"embedded"` // 使用进程内的内存 Redis（本地开发），数据不持久化
 }
 
 // Addr 返回Redis地址
@@ -86,8 +182,17 @@ type ElasticsearchConfig struct {
 
 // AgentConfig Agent服务配置
 type AgentConfig struct {
-	URL     string `mapstructure:"url"`
-	Timeout int    `mapstructure:"timeout"` // 秒
+	URL         string `mapstructure:"url"`
+	Timeout     int    `mapstructure:"timeout"`      // 秒
+	AutoTag     bool   `mapstructure:"auto_tag"`     // 视频发布后自动生成 AI 标签
+	AutoSummary bool   `mapstructure:"auto_summary"` // 视频发布后自动生成摘要与关键时刻
+
+	// 头像、主页背景图先审核再上线；Agent 未配置或无法判定时等待版主人工审核
+	ModerateProfileImages bool `mapstructure:"moderate_profile_images"`
+
+	// 视频问答：每个用户每分钟、每天可提问的次数
+	AskPerMinute int `mapstructure:"ask_per_minute"`
+	AskPerDay    int `mapstructure:"ask_per_day"`
 }
 
 // TimeoutDuration 返回超时时间
@@ -95,10 +200,29 @@ func (a *AgentConfig) TimeoutDuration() time.Duration {
 	return time.Duration(a.Timeout) * time.Second
 }
 
+// AskMinuteLimit 每分钟提问次数上限，默认 5
+func (a *AgentConfig) AskMinuteLimit() int {
+	if a.AskPerMinute <= 0 {
+		return 5
+	}
+	return a.AskPerMinute
+}
+
+// AskDailyLimit 每日提问次数上限，默认 50
+func (a *AgentConfig) AskDailyLimit() int {
+	if a.AskPerDay <= 0 {
+		return 50
+	}
+	return a.AskPerDay
+}
+
 // JWTConfig JWT配置
 type JWTConfig struct {
 	Secret      string `mapstructure:"secret"`
+	KeyID       string `mapstructure:"key_id"` // 签名密钥 ID，写入 Token 头部的 kid，为空时由密钥计算
 	ExpireHours int    `mapstructure:"expire_hours"`
+
+	ImpersonationMinutes int `mapstructure:"impersonation_minutes"` // 管理员模拟登录令牌有效期（分钟）
 }
 
 // ExpireDuration 返回过期时间
@@ -106,12 +230,919 @@ func (j *JWTConfig) ExpireDuration() time.Duration {
 	return time.Duration(j.ExpireHours) * time.Hour
 }
 
+// ImpersonationDuration 返回模拟登录令牌有效期，默认 30 分钟
+func (j *JWTConfig) ImpersonationDuration() time.Duration {
+	if j.ImpersonationMinutes <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(j.ImpersonationMinutes) * time.Minute
+}
+
 // LogConfig 日志配置
 type LogConfig struct {
 	Level    string `mapstructure:"level"`
 	Format   string `mapstructure:"format"`
-	Output   string `mapstructure:"output"`
+	Output   string `mapstructure:"output"` // stdout / file / both
 	FilePath string `mapstructure:"file_path"`
+
+	// 日志文件切割与保留（输出到文件时生效）
+	MaxSizeMB  int  `mapstructure:"max_size_mb"`  // 单个文件大小上限（MB），默认 100
+	MaxAgeDays int  `mapstructure:"max_age_days"` // 旧文件保留天数，0 表示不按时间清理
+	MaxBackups int  `mapstructure:"max_backups"`  // 旧文件保留个数，0 表示不按个数清理
+	Compress   bool `mapstructure:"compress"`     // 是否 gzip 压缩旧文件
+
+	Ship LogShipConfig `mapstructure:"ship"` // 集中式日志投递
+
+	SlowRequestMs int `mapstructure:"slow_request_ms"` // 访问日志慢请求阈值（毫秒），超过时记 WARN，默认 1000，负数关闭
+}
+
+// SlowRequestThreshold 返回慢请求阈值，未配置时默认 1 秒，负数时返回 0 表示不告警
+func (c *LogConfig) SlowRequestThreshold() time.Duration {
+	if c.SlowRequestMs < 0 {
+		return 0
+	}
+	if c.SlowRequestMs == 0 {
+		return time.Second
+	}
+	return time.Duration(c.SlowRequestMs) * time.Millisecond
+}
+
+// LogShipConfig 将 JSON 日志批量投递到 Kafka、Elasticsearch 或 Logstash，
+// 缓冲区满时丢弃新日志，不阻塞业务
+type LogShipConfig struct {
+	Enabled         bool     `mapstructure:"enabled"`
+	Type            string   `mapstructure:"type"`              // kafka / elasticsearch / logstash
+	Level           string   `mapstructure:"level"`             // 投递的最低级别，默认与 log.level 相同
+	Brokers         []string `mapstructure:"brokers"`           // kafka：未配置时使用 kafka.brokers
+	Topic           string   `mapstructure:"topic"`             // kafka：默认 vida-logs
+	Hosts           []string `mapstructure:"hosts"`             // elasticsearch：未配置时使用 elasticsearch.hosts
+	Index           string   `mapstructure:"index"`             // elasticsearch：索引前缀，按天追加日期，默认 vida-logs
+	URL             string   `mapstructure:"url"`               // logstash：http input 地址（json_lines 编解码）
+	BatchSize       int      `mapstructure:"batch_size"`        // 每批条数，默认 500
+	FlushIntervalMs int      `mapstructure:"flush_interval_ms"` // 未攒满一批时的最长等待，默认 1000
+	BufferSize      int      `mapstructure:"buffer_size"`       // 待投递日志缓冲条数，默认 10000
+}
+
+// Batch 返回每批条数，未配置时默认 500
+func (c *LogShipConfig) Batch() int {
+	if c.BatchSize <= 0 {
+		return 500
+	}
+	return c.BatchSize
+}
+
+// FlushInterval 返回最长攒批时间，未配置时默认 1 秒
+func (c *LogShipConfig) FlushInterval() time.Duration {
+	if c.FlushIntervalMs <= 0 {
+		return time.Second
+	}
+	return time.Duration(c.FlushIntervalMs) * time.Millisecond
+}
+
+// Buffer 返回缓冲条数，未配置时默认 10000
+func (c *LogShipConfig) Buffer() int {
+	if c.BufferSize <= 0 {
+		return 10000
+	}
+	return c.BufferSize
+}
+
+// TopicName 返回 Kafka 主题，未配置时默认 vida-logs
+func (c *LogShipConfig) TopicName() string {
+	if c.Topic == "" {
+		return "vida-logs"
+	}
+	return c.Topic
+}
+
+// IndexPrefix 返回 Elasticsearch 索引前缀，未配置时默认 vida-logs
+func (c *LogShipConfig) IndexPrefix() string {
+	if c.Index == "" {
+		return "vida-logs"
+	}
+	return c.Index
+}
+
+// TracingConfig 链路追踪配置（OpenTelemetry）
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Endpoint    string  `mapstructure:"endpoint"` // OTLP/HTTP 地址，如 otel-collector:4318
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 采样率 0~1
+}
+
+// I18nConfig 多语言配置
+type I18nConfig struct {
+	DefaultLanguage string `mapstructure:"default_language"` // 无法匹配 Accept-Language 时的回退语言
+}
+
+// EmailConfig 邮件发送配置（SMTP）
+type EmailConfig struct {
+	Enabled             bool   `mapstructure:"enabled"`
+	Host                string `mapstructure:"host"`
+	Port                int    `mapstructure:"port"`
+	Username            string `mapstructure:"username"`
+	Password            string `mapstructure:"password"`
+	From                string `mapstructure:"from"`                  // 发件人地址
+	FromName            string `mapstructure:"from_name"`             // 发件人显示名称
+	DigestIntervalHours int    `mapstructure:"digest_interval_hours"` // 新粉丝摘要发送间隔（小时）
+}
+
+// Addr 返回SMTP地址
+func (e *EmailConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", e.Host, e.Port)
+}
+
+// DigestInterval 返回摘要发送间隔，未配置时默认 24 小时
+func (e *EmailConfig) DigestInterval() time.Duration {
+	if e.DigestIntervalHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(e.DigestIntervalHours) * time.Hour
+}
+
+// PushConfig 移动端推送配置
+type PushConfig struct {
+	Enabled bool       `mapstructure:"enabled"`
+	FCM     FCMConfig  `mapstructure:"fcm"`
+	APNs    APNsConfig `mapstructure:"apns"`
+}
+
+// FCMConfig Firebase Cloud Messaging 配置（Android）
+type FCMConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`
+	ProjectID       string `mapstructure:"project_id"`       // 为空时使用服务账号中的 project_id
+	CredentialsFile string `mapstructure:"credentials_file"` // 服务账号密钥 JSON 文件
+}
+
+// APNsConfig Apple Push Notification service 配置（iOS）
+type APNsConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	KeyFile    string `mapstructure:"key_file"` // .p8 认证密钥
+	KeyID      string `mapstructure:"key_id"`
+	TeamID     string `mapstructure:"team_id"`
+	Topic      string `mapstructure:"topic"` // App 的 Bundle ID
+	Production bool   `mapstructure:"production"`
+}
+
+// MessageConfig 私信配置
+type MessageConfig struct {
+	SensitiveWordsFile string `mapstructure:"sensitive_words_file"` // 敏感词库，为空时不过滤
+}
+
+// GRPCConfig 内部 gRPC 服务配置
+type GRPCConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	Port    int  `mapstructure:"port"`
+}
+
+// RecommendConfig 协同过滤推荐配置
+type RecommendConfig struct {
+	Enabled       bool `mapstructure:"enabled"`
+	IntervalHours int  `mapstructure:"interval_hours"` // 相似度计算间隔（小时）
+	LookbackDays  int  `mapstructure:"lookback_days"`  // 参与计算的点赞、观看记录时间范围（天）
+	TopK          int  `mapstructure:"top_k"`          // 每个视频保留的相似视频数
+}
+
+// Interval 返回相似度计算间隔，未配置时默认 6 小时
+func (r *RecommendConfig) Interval() time.Duration {
+	if r.IntervalHours <= 0 {
+		return 6 * time.Hour
+	}
+	return time.Duration(r.IntervalHours) * time.Hour
+}
+
+// Lookback 返回参与计算的记录时间范围，未配置时默认 30 天
+func (r *RecommendConfig) Lookback() time.Duration {
+	if r.LookbackDays <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(r.LookbackDays) * 24 * time.Hour
+}
+
+// K 返回每个视频保留的相似视频数，未配置时默认 50
+func (r *RecommendConfig) K() int {
+	if r.TopK <= 0 {
+		return 50
+	}
+	return r.TopK
+}
+
+// DuplicateConfig 近似重复视频检测配置
+type DuplicateConfig struct {
+	Enabled        bool    `mapstructure:"enabled"`
+	MaxDistance    int     `mapstructure:"max_distance"`    // 两帧哈希的汉明距离不超过该值视为相同画面
+	FlagSimilarity float64 `mapstructure:"flag_similarity"` // 匹配帧比例达到该值时标记待审核
+	LinkSimilarity float64 `mapstructure:"link_similarity"` // 匹配帧比例达到该值时自动关联到原视频
+}
+
+// Distance 返回相同画面的最大汉明距离，未配置时默认 6
+func (d *DuplicateConfig) Distance() int {
+	if d.MaxDistance <= 0 {
+		return 6
+	}
+	return d.MaxDistance
+}
+
+// FlagThreshold 返回标记待审核的相似度，未配置时默认 0.75
+func (d *DuplicateConfig) FlagThreshold() float64 {
+	if d.FlagSimilarity <= 0 {
+		return 0.75
+	}
+	return d.FlagSimilarity
+}
+
+// LinkThreshold 返回自动关联的相似度，未配置时默认 0.95
+func (d *DuplicateConfig) LinkThreshold() float64 {
+	if d.LinkSimilarity <= 0 {
+		return 0.95
+	}
+	return d.LinkSimilarity
+}
+
+// GeoIPConfig IP 归属地解析配置，用于按国家/地区限制视频可见范围
+type GeoIPConfig struct {
+	Database      string `mapstructure:"database"`       // IP 段数据库 CSV（start_ip,end_ip,country 或 cidr,country），为空时不按 IP 解析
+	CountryHeader string `mapstructure:"country_header"` // 可信 CDN 注入的国家代码请求头（如 CF-IPCountry），优先于 IP 解析；为空时不读取
+}
+
+// AgeGateConfig 年龄限制内容配置
+type AgeGateConfig struct {
+	MinAge int `mapstructure:"min_age"` // 观看年龄限制视频的最低周岁
+}
+
+// MinimumAge 返回最低观看年龄，未配置时默认 18
+func (a *AgeGateConfig) MinimumAge() int {
+	if a.MinAge <= 0 {
+		return 18
+	}
+	return a.MinAge
+}
+
+// RegistrationConfig 注册配置
+type RegistrationConfig struct {
+	InviteOnly bool `mapstructure:"invite_only"` // 仅允许持有效邀请码注册
+}
+
+// UsernameConfig 用户名校验配置（内置保留名之外的补充）
+type UsernameConfig struct {
+	Reserved []string `mapstructure:"reserved"` // 额外的保留用户名
+}
+
+// LiveConfig 直播配置。推流、播放由外部媒体服务器（SRS、nginx-rtmp 等）负责，
+// 媒体服务器通过 HTTP 回调通知开播、下播与录制完成
+type LiveConfig struct {
+	CallbackSecret  string `mapstructure:"callback_secret"`   // 回调共享密钥（X-Live-Secret 头或 secret 查询参数），为空时拒绝所有回调
+	RTMPURL         string `mapstructure:"rtmp_url"`          // RTMP 推流地址（不含推流码）
+	SRTURLTemplate  string `mapstructure:"srt_url_template"`  // SRT 推流地址，{stream_key} 替换为推流码
+	PlayURLTemplate string `mapstructure:"play_url_template"` // 播放地址，{playback_id} 替换为频道的公开播放 ID
+	AutoVOD         bool   `mapstructure:"auto_vod"`          // 下播后将录像转码为普通视频
+}
+
+// ExploreConfig 发现页配置：后台任务定期挑选热门视频、新晋创作者与各分类热门视频
+type ExploreConfig struct {
+	Enabled         bool     `mapstructure:"enabled"`
+	IntervalMinutes int      `mapstructure:"interval_minutes"` // 重新挑选间隔（分钟）
+	TrendingDays    int      `mapstructure:"trending_days"`    // 热门视频、分类热门视频的发布时间范围（天）
+	NewCreatorDays  int      `mapstructure:"new_creator_days"` // 首个视频在该天数内发布的作者视为新晋创作者
+	SectionSize     int      `mapstructure:"section_size"`     // 每个栏目的条目数
+	Categories      []string `mapstructure:"categories"`       // 分类栏目，为空时取近期视频最多的分类
+	MaxCategories   int      `mapstructure:"max_categories"`   // 自动选取分类时的最多分类数
+}
+
+// Interval 返回重新挑选间隔，未配置时默认 30 分钟
+func (e *ExploreConfig) Interval() time.Duration {
+	if e.IntervalMinutes <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(e.IntervalMinutes) * time.Minute
+}
+
+// TrendingWindow 返回热门视频的发布时间范围，未配置时默认 7 天
+func (e *ExploreConfig) TrendingWindow() time.Duration {
+	if e.TrendingDays <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(e.TrendingDays) * 24 * time.Hour
+}
+
+// NewCreatorWindow 返回新晋创作者的判定范围，未配置时默认 30 天
+func (e *ExploreConfig) NewCreatorWindow() time.Duration {
+	if e.NewCreatorDays <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(e.NewCreatorDays) * 24 * time.Hour
+}
+
+// Size 返回每个栏目的条目数，未配置时默认 20
+func (e *ExploreConfig) Size() int {
+	if e.SectionSize <= 0 {
+		return 20
+	}
+	return e.SectionSize
+}
+
+// CategoryLimit 返回自动选取的最多分类数，未配置时默认 8
+func (e *ExploreConfig) CategoryLimit() int {
+	if e.MaxCategories <= 0 {
+		return 8
+	}
+	return e.MaxCategories
+}
+
+// HotScoreConfig 热度计算配置：播放、点赞、评论加权求和，完播率越高加成越多（最多翻倍），
+// 另按累计观看分钟数加分；设置半衰期后热度随发布时长指数衰减。ES 同步、搜索与数据库热度排序共用
+type HotScoreConfig struct {
+	ViewWeight        *float64 `mapstructure:"view_weight"`         // 每次播放的权重，默认 0.5
+	FavoriteWeight    *float64 `mapstructure:"favorite_weight"`     // 每个点赞的权重，默认 2.0
+	CommentWeight     *float64 `mapstructure:"comment_weight"`      // 每条评论的权重，默认 1.5
+	WatchMinuteWeight *float64 `mapstructure:"watch_minute_weight"` // 每分钟累计观看时长的权重，默认 0.2
+	HalfLifeHours     float64  `mapstructure:"half_life_hours"`     // 热度减半所需的发布时长（小时），0 表示不衰减
+}
+
+// View 返回播放权重
+func (h *HotScoreConfig) View() float64 {
+	return weightOr(h.ViewWeight, 0.5)
+}
+
+// Favorite 返回点赞权重
+func (h *HotScoreConfig) Favorite() float64 {
+	return weightOr(h.FavoriteWeight, 2.0)
+}
+
+// Comment 返回评论权重
+func (h *HotScoreConfig) Comment() float64 {
+	return weightOr(h.CommentWeight, 1.5)
+}
+
+// WatchMinute 返回观看分钟数权重
+func (h *HotScoreConfig) WatchMinute() float64 {
+	return weightOr(h.WatchMinuteWeight, 0.2)
+}
+
+// HalfLife 返回热度半衰期，未配置时为 0（不衰减）
+func (h *HotScoreConfig) HalfLife() time.Duration {
+	if h.HalfLifeHours <= 0 {
+		return 0
+	}
+	return time.Duration(h.HalfLifeHours * float64(time.Hour))
+}
+
+// weightOr 未配置或为负数时使用默认权重，配置为 0 表示不计入
+func weightOr(w *float64, def float64) float64 {
+	if w == nil || *w < 0 {
+		return def
+	}
+	return *w
+}
+
+// OAuthConfig 第三方应用接入配置（OAuth2 客户端凭证与授权码模式）
+type OAuthConfig struct {
+	AccessTokenMinutes int `mapstructure:"access_token_minutes"` // 访问令牌有效期（分钟）
+	CodeMinutes        int `mapstructure:"code_minutes"`         // 授权码有效期（分钟）
+	MaxClientsPerUser  int `mapstructure:"max_clients_per_user"` // 每个用户最多可注册的应用数
+}
+
+// AccessTokenTTL 返回访问令牌有效期，未配置时默认 1 小时
+func (o *OAuthConfig) AccessTokenTTL() time.Duration {
+	if o.AccessTokenMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(o.AccessTokenMinutes) * time.Minute
+}
+
+// CodeTTL 返回授权码有效期，未配置时默认 10 分钟
+func (o *OAuthConfig) CodeTTL() time.Duration {
+	if o.CodeMinutes <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(o.CodeMinutes) * time.Minute
+}
+
+// ClientLimit 返回每个用户最多可注册的应用数，未配置时默认 10
+func (o *OAuthConfig) ClientLimit() int {
+	if o.MaxClientsPerUser <= 0 {
+		return 10
+	}
+	return o.MaxClientsPerUser
+}
+
+// OpenAPIConfig 按生成的 OpenAPI（Swagger）文档校验请求：参数、请求体与 Content-Type。
+// 严格模式下还会拒绝文档中未声明的查询参数与请求体字段
+type OpenAPIConfig struct {
+	Validate bool `mapstructure:"validate"`
+	Strict   bool `mapstructure:"strict"`
+}
+
+// ColdStorageConfig 冷存储归档：发布超过 UnwatchedDays 天且期间无人观看的视频，播放文件（默认文件与各清晰度，
+// 不含封面、预览帧）移入冷存储 Bucket；有人请求播放时按需恢复，恢复期间播放状态为 preparing
+type ColdStorageConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`
+	Bucket          string `mapstructure:"bucket"`           // 冷存储 Bucket
+	StorageClass    string `mapstructure:"storage_class"`    // 写入冷存储时使用的存储类型（如 REDUCED_REDUNDANCY），为空使用 Bucket 默认
+	UnwatchedDays   int    `mapstructure:"unwatched_days"`   // 多少天无人观看后归档
+	IntervalMinutes int    `mapstructure:"interval_minutes"` // 归档任务执行间隔（分钟）
+	BatchSize       int    `mapstructure:"batch_size"`       // 每轮最多归档的视频数
+}
+
+// ColdBucket 返回冷存储 Bucket，未配置时默认 cold-videos
+func (c *ColdStorageConfig) ColdBucket() string {
+	if c.Bucket == "" {
+		return "cold-videos"
+	}
+	return c.Bucket
+}
+
+// UnwatchedFor 返回归档前的无人观看时长，未配置时默认 180 天
+func (c *ColdStorageConfig) UnwatchedFor() time.Duration {
+	if c.UnwatchedDays <= 0 {
+		return 180 * 24 * time.Hour
+	}
+	return time.Duration(c.UnwatchedDays) * 24 * time.Hour
+}
+
+// Interval 返回执行间隔，未配置时默认 60 分钟
+func (c *ColdStorageConfig) Interval() time.Duration {
+	if c.IntervalMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.IntervalMinutes) * time.Minute
+}
+
+// Batch 返回每轮最多归档的视频数，未配置时默认 50
+func (c *ColdStorageConfig) Batch() int {
+	if c.BatchSize <= 0 {
+		return 50
+	}
+	return c.BatchSize
+}
+
+// ReportConfig 举报配置：同一用户（本人及其视频、评论）在时间窗口内被足够多的不同用户举报时，
+// 该用户的待处理举报升级为优先审核
+type ReportConfig struct {
+	EscalationReporters   int `mapstructure:"escalation_reporters"`    // 触发升级的不同举报人数
+	EscalationWindowHours int `mapstructure:"escalation_window_hours"` // 统计窗口（小时）
+}
+
+// EscalationThreshold 返回触发升级的举报人数，未配置时默认 5
+func (r *ReportConfig) EscalationThreshold() int {
+	if r.EscalationReporters <= 0 {
+		return 5
+	}
+	return r.EscalationReporters
+}
+
+// EscalationWindow 返回统计窗口，未配置时默认 7 天
+func (r *ReportConfig) EscalationWindow() time.Duration {
+	if r.EscalationWindowHours <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(r.EscalationWindowHours) * time.Hour
+}
+
+// CounterRepairConfig 计数修复任务配置：定期按明细表重新计算视频点赞数、评论数，
+// 用户关注数、粉丝数、获赞数，修正冗余计数的偏差
+type CounterRepairConfig struct {
+	Enabled       bool `mapstructure:"enabled"`
+	IntervalHours int  `mapstructure:"interval_hours"` // 执行间隔（小时）
+	BatchSize     int  `mapstructure:"batch_size"`     // 每批重新计算的记录数
+}
+
+// Interval 返回执行间隔，未配置时默认 24 小时
+func (c *CounterRepairConfig) Interval() time.Duration {
+	if c.IntervalHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.IntervalHours) * time.Hour
+}
+
+// Batch 返回每批记录数，未配置时默认 500
+func (c *CounterRepairConfig) Batch() int {
+	if c.BatchSize <= 0 {
+		return 500
+	}
+	return c.BatchSize
+}
+
+// PurgeConfig 软删除数据清理任务配置：删除超过 RetentionDays 天的用户、视频被永久删除，
+// 连同关联记录、搜索索引文档与对象存储文件；处于法律保全中的不清理
+type PurgeConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	RetentionDays int    `mapstructure:"retention_days"` // 软删除后保留天数
+	IntervalHours int    `mapstructure:"interval_hours"` // 执行间隔（小时）
+	BatchSize     int    `mapstructure:"batch_size"`     // 每批清理的用户或视频数
+	DryRun        bool   `mapstructure:"dry_run"`        // 只统计将被清理的数据，不实际删除
+	ReportDir     string `mapstructure:"report_dir"`     // 每次执行的报告（JSON）写入该目录，为空只记录日志
+}
+
+// Retention 返回软删除后的保留时长，未配置时默认 90 天
+func (c *PurgeConfig) Retention() time.Duration {
+	if c.RetentionDays <= 0 {
+		return 90 * 24 * time.Hour
+	}
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
+// Interval 返回执行间隔，未配置时默认 24 小时
+func (c *PurgeConfig) Interval() time.Duration {
+	if c.IntervalHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.IntervalHours) * time.Hour
+}
+
+// Batch 返回每批清理数，未配置时默认 100
+func (c *PurgeConfig) Batch() int {
+	if c.BatchSize <= 0 {
+		return 100
+	}
+	return c.BatchSize
+}
+
+// SigningConfig 公开目录接口（推荐流、搜索等）的请求签名：客户端以应用密钥对方法、路径、查询参数、
+// 时间戳、随机串与请求体做 HMAC-SHA256 签名，随机串在有效期内只能使用一次，用于防止批量抓取
+type SigningConfig struct {
+	Enabled        bool         `mapstructure:"enabled"`
+	Enforce        bool         `mapstructure:"enforce"`          // 为 false 时只记录校验失败的请求，不拒绝
+	MaxSkewSeconds int          `mapstructure:"max_skew_seconds"` // 时间戳允许的最大偏差（秒）
+	Apps           []SigningApp `mapstructure:"apps"`
+}
+
+// SigningApp 一个客户端应用的签名密钥
+type SigningApp struct {
+	Key    string `mapstructure:"key"`
+	Secret string `mapstructure:"secret"`
+}
+
+// MaxSkew 返回时间戳允许的最大偏差，未配置时默认 5 分钟
+func (c *SigningConfig) MaxSkew() time.Duration {
+	if c.MaxSkewSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.MaxSkewSeconds) * time.Second
+}
+
+// Secrets 返回 应用 Key -> 密钥，跳过未填写完整的应用
+func (c *SigningConfig) Secrets() map[string]string {
+	secrets := make(map[string]string, len(c.Apps))
+	for _, app := range c.Apps {
+		if app.Key != "" && app.Secret != "" {
+			secrets[app.Key] = app.Secret
+		}
+	}
+	return secrets
+}
+
+// RequestLimitConfig 请求体大小限制：声明的长度超出上限的请求在读取请求体前直接拒绝（413），
+// 未声明长度的请求读取超出上限时中断。上传接口的上限由上传限制单独计算，不受 MaxBodyMB 约束
+type RequestLimitConfig struct {
+	MaxBodyMB         int    `mapstructure:"max_body_mb"`         // 默认请求体上限（MB）
+	MultipartMemoryMB int    `mapstructure:"multipart_memory_mb"` // 解析 multipart 表单时在内存中保留的上限（MB），超出部分写入临时文件
+	TempDir           string `mapstructure:"temp_dir"`            // 上传文件暂存目录，为空使用系统临时目录
+}
+
+// MaxBody 返回默认请求体上限（字节），未配置时默认 2MB
+func (c *RequestLimitConfig) MaxBody() int64 {
+	if c.MaxBodyMB <= 0 {
+		return 2 << 20
+	}
+	return int64(c.MaxBodyMB) << 20
+}
+
+// MultipartMemory 返回 multipart 表单内存上限（字节），未配置时默认 8MB
+func (c *RequestLimitConfig) MultipartMemory() int64 {
+	if c.MultipartMemoryMB <= 0 {
+		return 8 << 20
+	}
+	return int64(c.MultipartMemoryMB) << 20
+}
+
+// PremiereConfig 首映配置：预约首映的视频转码完成后暂不发布，定时任务在首映时间到达时发布
+type PremiereConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalSeconds int  `mapstructure:"interval_seconds"` // 检查到期首映的间隔（秒）
+	MaxLeadDays     int  `mapstructure:"max_lead_days"`    // 首映时间最多提前预约的天数
+	LobbyMinutes    int  `mapstructure:"lobby_minutes"`    // 首映开始前提前开放聊天的分钟数
+}
+
+// Interval 返回检查间隔，未配置时默认 30 秒
+func (c *PremiereConfig) Interval() time.Duration {
+	if c.IntervalSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// MaxLead 返回首映时间最多提前预约的时长，未配置时默认 30 天
+func (c *PremiereConfig) MaxLead() time.Duration {
+	if c.MaxLeadDays <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.MaxLeadDays) * 24 * time.Hour
+}
+
+// Lobby 返回首映开始前提前开放聊天的时长，未配置时默认 15 分钟
+func (c *PremiereConfig) Lobby() time.Duration {
+	if c.LobbyMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.LobbyMinutes) * time.Minute
+}
+
+// MembershipConfig 创作者会员配置：会员按期购买，每期价格由创作者为各等级设置
+type MembershipConfig struct {
+	PeriodDays int `mapstructure:"period_days"` // 每期会员的天数
+	MaxTiers   int `mapstructure:"max_tiers"`   // 每位创作者最多同时上架的会员等级数
+}
+
+// Period 返回每期会员的时长，未配置时默认 30 天
+func (c *MembershipConfig) Period() time.Duration {
+	if c.PeriodDays <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.PeriodDays) * 24 * time.Hour
+}
+
+// TierLimit 返回每位创作者最多上架的会员等级数，未配置时默认 5
+func (c *MembershipConfig) TierLimit() int {
+	if c.MaxTiers <= 0 {
+		return 5
+	}
+	return c.MaxTiers
+}
+
+// DigestConfig 每周摘要：每周固定时间为用户汇总关注的创作者发布的新视频与自己视频下的热门评论，
+// 以站内通知发送，开启了 weekly_digest 邮件的用户同时收到邮件
+type DigestConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Weekday     string `mapstructure:"weekday"`      // 发送日（monday … sunday，服务器本地时间）
+	Hour        int    `mapstructure:"hour"`         // 发送时刻（0-23 点）
+	BatchSize   int    `mapstructure:"batch_size"`   // 每批处理的用户数
+	MaxVideos   int    `mapstructure:"max_videos"`   // 摘要中最多列出的新视频数
+	MaxComments int    `mapstructure:"max_comments"` // 摘要中最多列出的热门评论数
+}
+
+// SendWeekday 返回发送日，未配置或无法识别时默认周一
+func (c *DigestConfig) SendWeekday() time.Weekday {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(c.Weekday, d.String()) {
+			return d
+		}
+	}
+	return time.Monday
+}
+
+// SendHour 返回发送时刻，超出范围时按 0 点处理
+func (c *DigestConfig) SendHour() int {
+	if c.Hour < 0 || c.Hour > 23 {
+		return 0
+	}
+	return c.Hour
+}
+
+// Batch 返回每批处理的用户数，未配置时默认 200
+func (c *DigestConfig) Batch() int {
+	if c.BatchSize <= 0 {
+		return 200
+	}
+	return c.BatchSize
+}
+
+// VideoLimit 返回摘要中最多列出的新视频数，未配置时默认 10
+func (c *DigestConfig) VideoLimit() int {
+	if c.MaxVideos <= 0 {
+		return 10
+	}
+	return c.MaxVideos
+}
+
+// CommentLimit 返回摘要中最多列出的热门评论数，未配置时默认 5
+func (c *DigestConfig) CommentLimit() int {
+	if c.MaxComments <= 0 {
+		return 5
+	}
+	return c.MaxComments
+}
+
+// SchedulerConfig 后台定时任务调度。jobs 中按任务名指定 cron 表达式（分 时 日 月 周，服务器本地时间），
+// 也可以写 @daily、@every 30m 等；未指定的任务按各自配置中的执行间隔运行
+type SchedulerConfig struct {
+	Jobs map[string]string `mapstructure:"jobs"`
+}
+
+// Spec 返回任务的调度表达式，未配置时按 interval 固定间隔执行
+func (c *SchedulerConfig) Spec(job string, interval time.Duration) string {
+	if spec := strings.TrimSpace(c.Jobs[job]); spec != "" {
+		return spec
+	}
+	return "@every " + interval.String()
+}
+
+// EventsConfig 领域事件总线：transport 为 kafka 时经 kafka.topics.domain_events 投递，
+// 为 local 时在本进程内同步分发（适合本地开发，多实例部署时各实例只处理自己发布的事件）
+type EventsConfig struct {
+	Transport string `mapstructure:"transport"` // kafka / local，默认 kafka
+}
+
+// InProcess 是否在本进程内分发事件
+func (c *EventsConfig) InProcess() bool {
+	return strings.EqualFold(c.Transport, "local")
+}
+
+// RenditionConfig 附加清晰度（转码档位）配置。上传时只转出原分辨率 mp4，
+// 其余档位由补齐任务遍历已发布视频异步生成，新增档位后无需重新上传
+type RenditionConfig struct {
+	Profiles []RenditionProfile      `mapstructure:"profiles"`
+	Backfill RenditionBackfillConfig `mapstructure:"backfill"`
+}
+
+// RenditionProfile 一个转码档位
+type RenditionProfile struct {
+	Name   string `mapstructure:"name"`   // 唯一名称，如 720p、1080p、hls
+	Format string `mapstructure:"format"` // mp4 / hls
+	Height int    `mapstructure:"height"` // 目标高度，0 表示保持原分辨率；源视频低于该高度时不放大
+	CRF    int    `mapstructure:"crf"`    // 画质参数，默认 23
+}
+
+// Quality 返回 CRF，未配置时默认 23
+func (p RenditionProfile) Quality() int {
+	if p.CRF <= 0 {
+		return 23
+	}
+	return p.CRF
+}
+
+// Profile 按名称查找档位
+func (c *RenditionConfig) Profile(name string) (RenditionProfile, bool) {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return RenditionProfile{}, false
+}
+
+// RenditionBackfillConfig 档位补齐任务：按 ID 遍历已发布视频，为缺少档位的视频投递转码任务，
+// 按速率限制投递，避免挤占新上传视频的转码资源
+type RenditionBackfillConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalMinutes int  `mapstructure:"interval_minutes"` // 执行间隔（分钟），默认 60
+	RatePerMinute   int  `mapstructure:"rate_per_minute"`  // 每分钟最多投递的任务数，默认 30
+	MaxPerRun       int  `mapstructure:"max_per_run"`      // 每轮最多投递的任务数，默认 1000
+	BatchSize       int  `mapstructure:"batch_size"`       // 每批扫描的视频数，默认 200
+	MaxAttempts     int  `mapstructure:"max_attempts"`     // 单个档位最多尝试次数，默认 3
+	PendingTimeout  int  `mapstructure:"pending_timeout"`  // 投递后超过该时间（分钟）仍无结果视为丢失并重新投递，默认 360
+}
+
+// Interval 返回执行间隔，未配置时默认 1 小时
+func (c *RenditionBackfillConfig) Interval() time.Duration {
+	if c.IntervalMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.IntervalMinutes) * time.Minute
+}
+
+// Rate 返回每分钟投递数，未配置时默认 30
+func (c *RenditionBackfillConfig) Rate() int {
+	if c.RatePerMinute <= 0 {
+		return 30
+	}
+	return c.RatePerMinute
+}
+
+// MaxTasks 返回每轮最多投递数，未配置时默认 1000
+func (c *RenditionBackfillConfig) MaxTasks() int {
+	if c.MaxPerRun <= 0 {
+		return 1000
+	}
+	return c.MaxPerRun
+}
+
+// Batch 返回每批扫描的视频数，未配置时默认 200
+func (c *RenditionBackfillConfig) Batch() int {
+	if c.BatchSize <= 0 {
+		return 200
+	}
+	return c.BatchSize
+}
+
+// Attempts 返回最多尝试次数，未配置时默认 3
+func (c *RenditionBackfillConfig) Attempts() int {
+	if c.MaxAttempts <= 0 {
+		return 3
+	}
+	return c.MaxAttempts
+}
+
+// PendingTTL 返回等待结果的超时时间，未配置时默认 6 小时
+func (c *RenditionBackfillConfig) PendingTTL() time.Duration {
+	if c.PendingTimeout <= 0 {
+		return 6 * time.Hour
+	}
+	return time.Duration(c.PendingTimeout) * time.Minute
+}
+
+// UploadLimit 一类用户的上传限制，数值为 0 时表示不限制（文件大小和格式除外）
+type UploadLimit struct {
+	MaxSizeMB   int64    `mapstructure:"max_size_mb"`  // 单个文件大小上限，未配置时默认 500MB
+	MaxDuration int      `mapstructure:"max_duration"` // 视频时长上限（秒）
+	Formats     []string `mapstructure:"formats"`      // 允许的文件格式，未配置时使用默认格式
+	DailyCount  int      `mapstructure:"daily_count"`  // 每天最多上传的视频数
+}
+
+// UploadConfig 上传限制配置，按用户角色（user/moderator/admin）配置，
+// 认证用户使用 verified，未配置的角色使用 user 的限制
+type UploadConfig struct {
+	Limits map[string]UploadLimit `mapstructure:"limits"`
+}
+
+// defaultUploadFormats 未配置允许格式时使用的默认格式
+var defaultUploadFormats = []string{"mp4", "avi", "mov", "mkv", "flv", "webm"}
+
+// LimitFor 返回指定角色的上传限制
+func (u *UploadConfig) LimitFor(role string, verified bool) UploadLimit {
+	var (
+		limit UploadLimit
+		ok    bool
+	)
+	if role == "user" && verified {
+		limit, ok = u.Limits["verified"]
+	}
+	if !ok {
+		limit, ok = u.Limits[role]
+	}
+	if !ok {
+		limit = u.Limits["user"]
+	}
+
+	if limit.MaxSizeMB <= 0 {
+		limit.MaxSizeMB = 500
+	}
+	if len(limit.Formats) == 0 {
+		limit.Formats = defaultUploadFormats
+	}
+	return limit
+}
+
+// MaxSize 返回所有角色中最大的文件大小上限（字节），作为上传接口请求体的整体上限
+func (u *UploadConfig) MaxSize() int64 {
+	maxSize := u.LimitFor("user", false).MaxSize()
+	for role := range u.Limits {
+		if size := u.LimitFor(role, false).MaxSize(); size > maxSize {
+			maxSize = size
+		}
+	}
+	return maxSize
+}
+
+// MaxSize 返回文件大小上限（字节）
+func (l UploadLimit) MaxSize() int64 {
+	return l.MaxSizeMB * 1024 * 1024
+}
+
+// AllowsFormat 文件格式是否在允许列表中（不区分大小写）
+func (l UploadLimit) AllowsFormat(format string) bool {
+	for _, f := range l.Formats {
+		if strings.EqualFold(f, format) {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretsConfig 密钥后端配置：启动时从 Vault 或挂载的密钥文件读取敏感配置，覆盖 YAML 中的值
+type SecretsConfig struct {
+	Provider       string      `mapstructure:"provider"`        // vault / file，为空时不启用
+	RotateInterval int         `mapstructure:"rotate_interval"` // 重新读取 JWT 签名密钥的间隔（分钟），0 表示不轮换
+	Vault          VaultConfig `mapstructure:"vault"`
+	Dir            string      `mapstructure:"dir"` // file：每个密钥一个文件，文件名为密钥名（云厂商密钥管理的 CSI 挂载、Docker secrets）
+}
+
+// VaultConfig Vault KV v2 配置
+type VaultConfig struct {
+	Address   string `mapstructure:"address"`
+	Token     string `mapstructure:"token"` // 为空时读取环境变量 VAULT_TOKEN
+	Namespace string `mapstructure:"namespace"`
+	Mount     string `mapstructure:"mount"` // KV 引擎挂载路径，默认 secret
+	Path      string `mapstructure:"path"`
+}
+
+// Enabled 是否启用密钥后端
+func (s *SecretsConfig) Enabled() bool {
+	return s.Provider != ""
+}
+
+// RotateDuration 返回 JWT 签名密钥的轮换检查间隔，0 表示不轮换
+func (s *SecretsConfig) RotateDuration() time.Duration {
+	return time.Duration(s.RotateInterval) * time.Minute
+}
+
+// MountPath 返回 KV 引擎挂载路径，未配置时默认 secret
+func (v *VaultConfig) MountPath() string {
+	if v.Mount == "" {
+		return "secret"
+	}
+	return strings.Trim(v.Mount, "/")
 }
 
 // 全局配置实例
@@ -199,3 +1230,158 @@ func GetJWT() *JWTConfig {
 func GetLog() *LogConfig {
 	return &Get().Log
 }
+
+// GetTracing 获取链路追踪配置
+func GetTracing() *TracingConfig {
+	return &Get().Tracing
+}
+
+// GetI18n 获取多语言配置
+func GetI18n() *I18nConfig {
+	return &Get().I18n
+}
+
+// GetEmail 获取邮件配置
+func GetEmail() *EmailConfig {
+	return &Get().Email
+}
+
+// GetPush 获取推送配置
+func GetPush() *PushConfig {
+	return &Get().Push
+}
+
+// GetMessage 获取私信配置
+func GetMessage() *MessageConfig {
+	return &Get().Message
+}
+
+// GetGRPC 获取gRPC配置
+func GetGRPC() *GRPCConfig {
+	return &Get().GRPC
+}
+
+// GetRecommend 获取推荐配置
+func GetRecommend() *RecommendConfig {
+	return &Get().Recommend
+}
+
+// GetDuplicate 获取近似重复检测配置
+func GetDuplicate() *DuplicateConfig {
+	return &Get().Duplicate
+}
+
+// GetUpload 获取上传限制配置
+func GetUpload() *UploadConfig {
+	return &Get().Upload
+}
+
+// GetSecrets 获取密钥后端配置
+func GetSecrets() *SecretsConfig {
+	return &Get().Secrets
+}
+
+// GetCounterRepair 获取计数修复任务配置
+func GetCounterRepair() *CounterRepairConfig {
+	return &Get().CounterRepair
+}
+
+// GetRendition 获取附加清晰度配置
+func GetRendition() *RenditionConfig {
+	return &Get().Rendition
+}
+
+// GetReport 获取举报配置
+func GetReport() *ReportConfig {
+	return &Get().Report
+}
+
+// GetGeoIP 获取 IP 归属地解析配置
+func GetGeoIP() *GeoIPConfig {
+	return &Get().GeoIP
+}
+
+// GetAgeGate 获取年龄限制内容配置
+func GetAgeGate() *AgeGateConfig {
+	return &Get().AgeGate
+}
+
+// GetRegistration 获取注册配置
+func GetRegistration() *RegistrationConfig {
+	return &Get().Registration
+}
+
+// GetUsername 获取用户名校验配置
+func GetUsername() *UsernameConfig {
+	return &Get().Username
+}
+
+// GetLive 获取直播配置
+func GetLive() *LiveConfig {
+	return &Get().Live
+}
+
+// GetExplore 获取发现页配置
+func GetExplore() *ExploreConfig {
+	return &Get().Explore
+}
+
+// GetHotScore 获取热度计算配置
+func GetHotScore() *HotScoreConfig {
+	return &Get().HotScore
+}
+
+// GetOAuth 获取第三方应用接入配置
+func GetOAuth() *OAuthConfig {
+	return &Get().OAuth
+}
+
+// GetOpenAPI 获取 OpenAPI 请求校验配置
+func GetOpenAPI() *OpenAPIConfig {
+	return &Get().OpenAPI
+}
+
+// GetColdStorage 获取冷存储归档配置
+func GetColdStorage() *ColdStorageConfig {
+	return &Get().ColdStorage
+}
+
+// GetPurge 获取软删除数据清理配置
+func GetPurge() *PurgeConfig {
+	return &Get().Purge
+}
+
+// GetSigning 获取请求签名配置
+func GetSigning() *SigningConfig {
+	return &Get().Signing
+}
+
+// GetRequestLimit 获取请求体大小限制配置
+func GetRequestLimit() *RequestLimitConfig {
+	return &Get().RequestLimit
+}
+
+// GetPremiere 获取首映配置
+func GetPremiere() *PremiereConfig {
+	return &Get().Premiere
+}
+
+// GetMembership 获取创作者会员配置
+func GetMembership() *MembershipConfig {
+	return &Get().Membership
+}
+
+// GetDigest 获取每周摘要配置
+func GetDigest() *DigestConfig {
+	return &Get().Digest
+}
+
+// GetScheduler 获取定时任务调度配置
+func GetScheduler() *SchedulerConfig {
+	return &Get().Scheduler
+}
+
+// GetEvents 获取领域事件总线配置
+func GetEvents() *EventsConfig {
+	return &Get().Events
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"vida-go/pkg/version"
+)
+
+// defaultConfigPath 未指定 --config 时使用的配置文件
+const defaultConfigPath = "configs/config.yaml"
+
+// Flags 命令行参数，非零值覆盖配置文件中的对应项
+type Flags struct {
+	ConfigPath string
+	Port       int
+	Mode       string
+}
+
+// ParseFlags 解析命令行参数，withPort 为 false 时不提供 --port（没有 HTTP 服务的进程）；
+// 指定 --version 时打印版本信息后退出
+func ParseFlags(name string, withPort bool) *Flags {
+	f := &Flags{}
+	showVersion := false
+
+	flag.StringVar(&f.ConfigPath, "config", defaultConfigPath, "配置文件路径")
+	if withPort {
+		flag.IntVar(&f.Port, "port", 0, "HTTP 端口，覆盖 app.port")
+	}
+	flag.StringVar(&f.Mode, "mode", "", "运行模式 debug/release/test，覆盖 app.mode")
+	flag.BoolVar(&showVersion, "version", false, "打印版本信息并退出")
+	flag.Parse()
+
+	if showVersion {
+		fmt.Println(version.String(name))
+		os.Exit(0)
+	}
+	return f
+}
+
+// Apply 用命令行参数覆盖配置，编译时注入了版本号时同时覆盖 app.version
+func (f *Flags) Apply(c *Config) error {
+	if f.Port < 0 || f.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", f.Port)
+	}
+	if f.Port > 0 {
+		c.App.Port = f.Port
+	}
+
+	switch f.Mode {
+	case "":
+	case "debug", "release", "test":
+		c.App.Mode = f.Mode
+	default:
+		return fmt.Errorf("invalid mode: %s", f.Mode)
+	}
+
+	if version.Version != "dev" {
+		c.App.Version = version.Version
+	}
+	return nil
+}
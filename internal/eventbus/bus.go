@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Envelope 事件在 Kafka 中的消息体
+type Envelope struct {
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+type subscriber struct {
+	name   string
+	handle func(ctx context.Context, payload json.RawMessage) error
+}
+
+// Bus 领域事件总线：业务服务发布类型化事件，搜索同步、通知、数据看板等订阅方各自处理，
+// 发布方不感知订阅方。配置了 topic 时经 Kafka 投递，由 Handle 消费后分发；
+// 未配置 topic（进程内模式，适合本地开发）或 Kafka 发送失败时直接在本进程内分发
+type Bus struct {
+	topic string
+
+	mu          sync.RWMutex
+	subscribers map[string][]subscriber
+}
+
+func New(topic string) *Bus {
+	return &Bus{topic: topic, subscribers: make(map[string][]subscriber)}
+}
+
+// Subscribe 订阅 T 类型的事件，name 用于日志中区分订阅方。须在开始发布、消费之前调用
+func Subscribe[T any, PT interface {
+	*T
+	Event
+}](b *Bus, name string, handler func(ctx context.Context, event PT) error) {
+	var zero T
+	eventType := PT(&zero).EventType()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventType] = append(b.subscribers[eventType], subscriber{
+		name: name,
+		handle: func(ctx context.Context, payload json.RawMessage) error {
+			event := PT(new(T))
+			if err := json.Unmarshal(payload, event); err != nil {
+				return fmt.Errorf("decode %s event: %w", eventType, err)
+			}
+			return handler(ctx, event)
+		},
+	})
+}
+
+// Publish 发布事件（尽力而为，不影响主流程）
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.FromContext(ctx).Error("Marshal domain event failed", zap.String("type", event.EventType()), zap.Error(err))
+		return
+	}
+	envelope := &Envelope{Type: event.EventType(), OccurredAt: time.Now(), Payload: payload}
+
+	ctx = context.WithoutCancel(ctx)
+	if b.topic != "" {
+		data, err := json.Marshal(envelope)
+		if err == nil {
+			err = infraKafka.SendRaw(ctx, b.topic, event.EventKey(), data)
+		}
+		if err == nil {
+			return
+		}
+		logger.FromContext(ctx).Warn("Send domain event failed, dispatching in process",
+			zap.String("type", envelope.Type), zap.Error(err))
+	}
+
+	if err := b.Handle(ctx, envelope); err != nil {
+		logger.FromContext(ctx).Error("Handle domain event failed", zap.String("type", envelope.Type), zap.Error(err))
+	}
+}
+
+// Handle 将事件依次分发给所有订阅方（Kafka 消费端调用）。
+// 某个订阅方失败不影响其他订阅方，返回所有失败合并后的错误
+func (b *Bus) Handle(ctx context.Context, envelope *Envelope) error {
+	b.mu.RLock()
+	subscribers := b.subscribers[envelope.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subscribers {
+		if err := sub.handle(ctx, envelope.Payload); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
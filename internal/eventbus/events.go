@@ -0,0 +1,54 @@
+package eventbus
+
+import (
+	"fmt"
+	"time"
+)
+
+// 领域事件类型
+const (
+	TypeVideoPublished = "video.published"
+	TypeUserFollowed   = "user.followed"
+	TypeCommentCreated = "comment.created"
+)
+
+// Event 领域事件。EventKey 作为 Kafka 消息的 key，同一 key 的事件按发布顺序消费
+type Event interface {
+	EventType() string
+	EventKey() string
+}
+
+// VideoPublished 视频对外发布：转码完成、首映开始或取消首映后立即发布
+type VideoPublished struct {
+	VideoID     int64     `json:"video_id"`
+	AuthorID    int64     `json:"author_id"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (e *VideoPublished) EventType() string { return TypeVideoPublished }
+func (e *VideoPublished) EventKey() string  { return fmt.Sprintf("video-%d", e.VideoID) }
+
+// UserFollowed 用户关注了另一个用户
+type UserFollowed struct {
+	FollowerID int64     `json:"follower_id"`
+	FolloweeID int64     `json:"followee_id"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+func (e *UserFollowed) EventType() string { return TypeUserFollowed }
+func (e *UserFollowed) EventKey() string  { return fmt.Sprintf("user-%d", e.FolloweeID) }
+
+// CommentCreated 发表了评论或回复；ParentUserID 为被回复评论的作者，顶级评论为 0
+type CommentCreated struct {
+	CommentID     int64     `json:"comment_id"`
+	VideoID       int64     `json:"video_id"`
+	VideoAuthorID int64     `json:"video_author_id"`
+	UserID        int64     `json:"user_id"`
+	ParentID      *int64    `json:"parent_id,omitempty"`
+	ParentUserID  int64     `json:"parent_user_id,omitempty"`
+	Content       string    `json:"content"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (e *CommentCreated) EventType() string { return TypeCommentCreated }
+func (e *CommentCreated) EventKey() string  { return fmt.Sprintf("video-%d", e.VideoID) }
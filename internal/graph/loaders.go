@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/api/dto"
+
+	"github.com/vikstrous/dataloadgen"
+)
+
+// ErrTooManyIDs 批量查询的 ID 数超过上限
+var ErrTooManyIDs = errors.New("请求参数无效")
+
+// maxTopComments 每个视频预取的热门评论数，topComments(first) 在此范围内截取
+const maxTopComments = 10
+
+type viewerKey struct{}
+
+type loadersKey struct{}
+
+// loaders 单次请求内的批量加载器，避免列表中逐条查询作者、评论与关注状态
+type loaders struct {
+	users       *dataloadgen.Loader[int64, *dto.UserFullInfo]
+	topComments *dataloadgen.Loader[int64, []dto.CommentInfo]
+	favorited   *dataloadgen.Loader[int64, bool]
+	following   *dataloadgen.Loader[int64, bool]
+}
+
+// WithViewer 将当前登录用户写入请求上下文
+func WithViewer(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, viewerKey{}, userID)
+}
+
+func viewerID(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(viewerKey{}).(int64)
+	return userID, ok
+}
+
+// WithLoaders 为本次请求创建批量加载器，需在 WithViewer 之后调用
+func (r *Resolver) WithLoaders(ctx context.Context) context.Context {
+	viewer, _ := viewerID(ctx)
+	l := &loaders{
+		users:       dataloadgen.NewLoader(r.fetchUsers),
+		topComments: dataloadgen.NewLoader(r.fetchTopComments),
+		favorited: dataloadgen.NewLoader(func(ctx context.Context, videoIDs []int64) ([]bool, []error) {
+			return fetchStatus(ctx, viewer, videoIDs, r.favoriteService.BatchCheckStatus)
+		}),
+		following: dataloadgen.NewLoader(func(ctx context.Context, userIDs []int64) ([]bool, []error) {
+			return fetchStatus(ctx, viewer, userIDs, r.relationService.BatchCheckFollowStatus)
+		}),
+	}
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func (r *Resolver) fetchUsers(ctx context.Context, ids []int64) ([]*dto.UserFullInfo, []error) {
+	users, err := r.userService.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, []error{err}
+	}
+	result := make([]*dto.UserFullInfo, len(ids))
+	for i, id := range ids {
+		result[i] = users[id]
+	}
+	return result, nil
+}
+
+func (r *Resolver) fetchTopComments(ctx context.Context, videoIDs []int64) ([][]dto.CommentInfo, []error) {
+	comments, err := r.commentService.TopCommentsByVideos(ctx, videoIDs, maxTopComments)
+	if err != nil {
+		return nil, []error{err}
+	}
+	result := make([][]dto.CommentInfo, len(videoIDs))
+	for i, id := range videoIDs {
+		result[i] = comments[id]
+	}
+	return result, nil
+}
+
+// fetchStatus 按 ID 顺序展开批量状态查询的结果
+func fetchStatus(ctx context.Context, viewer int64, ids []int64, batch func(context.Context, int64, []int64) (map[int64]bool, error)) ([]bool, []error) {
+	statusMap, err := batch(ctx, viewer, ids)
+	if err != nil {
+		return nil, []error{err}
+	}
+	result := make([]bool, len(ids))
+	for i, id := range ids {
+		result[i] = statusMap[id]
+	}
+	return result, nil
+}
@@ -0,0 +1,11 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+type Query struct {
+}
+
+type VideoViewerState struct {
+	Favorited       bool `json:"favorited"`
+	FollowingAuthor bool `json:"followingAuthor"`
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"context"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/service"
+)
+
+// maxBatchIDs 单次批量查询的 ID 数上限
+const maxBatchIDs = 100
+
+// Resolver GraphQL 根解析器，持有各业务 Service
+type Resolver struct {
+	userService     *service.UserService
+	videoService    *service.VideoService
+	commentService  *service.CommentService
+	favoriteService *service.FavoriteService
+	relationService *service.RelationService
+}
+
+func NewResolver(userService *service.UserService, videoService *service.VideoService, commentService *service.CommentService, favoriteService *service.FavoriteService, relationService *service.RelationService) *Resolver {
+	return &Resolver{
+		userService:     userService,
+		videoService:    videoService,
+		commentService:  commentService,
+		favoriteService: favoriteService,
+		relationService: relationService,
+	}
+}
+
+// normalizePage 缺省或越界时回退为第 1 页、每页 20 条（上限 100）
+func normalizePage(page, pageSize *int) (int, int) {
+	p, size := 1, 20
+	if page != nil && *page >= 1 {
+		p = *page
+	}
+	if pageSize != nil && *pageSize >= 1 && *pageSize <= 100 {
+		size = *pageSize
+	}
+	return p, size
+}
+
+// applyContentGate 对不满足年龄条件或不是会员的观看者清空年龄限制、会员专属视频的播放地址等内容
+func (r *Resolver) applyContentGate(ctx context.Context, videos []dto.VideoInfo) error {
+	viewer, _ := viewerID(ctx)
+	return r.videoService.ApplyContentGate(ctx, viewer, videos)
+}
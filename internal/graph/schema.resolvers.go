@@ -0,0 +1,146 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.81
+
+import (
+	"context"
+	"errors"
+	"vida-go/internal/api/dto"
+	"vida-go/internal/graph/model"
+	"vida-go/internal/service"
+)
+
+// User is the resolver for the user field.
+func (r *commentResolver) User(ctx context.Context, obj *dto.CommentInfo) (*dto.UserFullInfo, error) {
+	return loadersFrom(ctx).users.Load(ctx, obj.UserID)
+}
+
+// Video is the resolver for the video field.
+func (r *queryResolver) Video(ctx context.Context, id int64) (*dto.VideoInfo, error) {
+	video, err := r.videoService.GetInfo(ctx, id)
+	if errors.Is(err, service.ErrVideoNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	videos := []dto.VideoInfo{*video}
+	if err := r.applyContentGate(ctx, videos); err != nil {
+		return nil, err
+	}
+	return &videos[0], nil
+}
+
+// Videos is the resolver for the videos field.
+func (r *queryResolver) Videos(ctx context.Context, ids []int64) ([]dto.VideoInfo, error) {
+	if len(ids) > maxBatchIDs {
+		return nil, ErrTooManyIDs
+	}
+	videos, err := r.videoService.BatchGetInfo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.applyContentGate(ctx, videos); err != nil {
+		return nil, err
+	}
+	return videos, nil
+}
+
+// Feed is the resolver for the feed field.
+func (r *queryResolver) Feed(ctx context.Context, page *int, pageSize *int) (*dto.VideoListData, error) {
+	p, size := normalizePage(page, pageSize)
+	data, err := r.videoService.GetFeed(ctx, p, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.applyContentGate(ctx, data.Videos); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id int64) (*dto.UserFullInfo, error) {
+	return loadersFrom(ctx).users.Load(ctx, id)
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*dto.UserFullInfo, error) {
+	userID, ok := viewerID(ctx)
+	if !ok {
+		return nil, nil
+	}
+	return loadersFrom(ctx).users.Load(ctx, userID)
+}
+
+// IsFollowing is the resolver for the isFollowing field.
+func (r *userResolver) IsFollowing(ctx context.Context, obj *dto.UserFullInfo) (*bool, error) {
+	if _, ok := viewerID(ctx); !ok {
+		return nil, nil
+	}
+	following, err := loadersFrom(ctx).following.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &following, nil
+}
+
+// Author is the resolver for the author field.
+func (r *videoResolver) Author(ctx context.Context, obj *dto.VideoInfo) (*dto.UserFullInfo, error) {
+	return loadersFrom(ctx).users.Load(ctx, obj.AuthorID)
+}
+
+// TopComments is the resolver for the topComments field.
+func (r *videoResolver) TopComments(ctx context.Context, obj *dto.VideoInfo, first *int) ([]dto.CommentInfo, error) {
+	limit := 3
+	if first != nil {
+		limit = min(max(*first, 0), maxTopComments)
+	}
+	comments, err := loadersFrom(ctx).topComments.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(comments) > limit {
+		comments = comments[:limit]
+	}
+	if comments == nil {
+		comments = []dto.CommentInfo{}
+	}
+	return comments, nil
+}
+
+// ViewerState is the resolver for the viewerState field.
+func (r *videoResolver) ViewerState(ctx context.Context, obj *dto.VideoInfo) (*model.VideoViewerState, error) {
+	if _, ok := viewerID(ctx); !ok {
+		return nil, nil
+	}
+	l := loadersFrom(ctx)
+	favorited, err := l.favorited.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	following, err := l.following.Load(ctx, obj.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.VideoViewerState{Favorited: favorited, FollowingAuthor: following}, nil
+}
+
+// Comment returns CommentResolver implementation.
+func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
+// Video returns VideoResolver implementation.
+func (r *Resolver) Video() VideoResolver { return &videoResolver{r} }
+
+type commentResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
+type videoResolver struct{ *Resolver }
@@ -0,0 +1,241 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+var (
+	baseURL string
+	client  *http.Client
+	// 流式响应的时长不固定，超时由调用方的 ctx 控制
+	streamClient = &http.Client{}
+)
+
+// Init 初始化 Agent 服务客户端，未配置地址时 Enabled 返回 false
+func Init(cfg *config.AgentConfig) {
+	baseURL = strings.TrimRight(cfg.URL, "/")
+	client = &http.Client{Timeout: cfg.TimeoutDuration()}
+	if !Enabled() {
+		logger.Info("Agent service disabled")
+		return
+	}
+	logger.Info("Agent service client initialized", zap.String("url", baseURL))
+}
+
+// Enabled 是否配置了 Agent 服务
+func Enabled() bool {
+	return baseURL != ""
+}
+
+// Frame 视频抽帧
+type Frame struct {
+	URL  string `json:"url"`
+	Time int    `json:"time"` // 在视频中的位置（秒）
+}
+
+// VideoRequest 视频分析请求（打标签、生成摘要）
+type VideoRequest struct {
+	VideoID     int64   `json:"video_id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Duration    int     `json:"duration"`
+	Frames      []Frame `json:"frames"`
+}
+
+// TagSuggestion 模型建议的标签与分类
+type TagSuggestion struct {
+	Tags       []string `json:"tags"`
+	Categories []string `json:"categories"`
+}
+
+// KeyMoment 关键时刻
+type KeyMoment struct {
+	Time  int    `json:"time"` // 秒
+	Label string `json:"label"`
+}
+
+// VideoSummary 视频摘要与关键时刻
+type VideoSummary struct {
+	Summary    string      `json:"summary"`
+	KeyMoments []KeyMoment `json:"key_moments"`
+}
+
+// SuggestTags 根据标题、描述和抽帧生成建议标签
+func SuggestTags(ctx context.Context, req *VideoRequest) (*TagSuggestion, error) {
+	var result TagSuggestion
+	if err := post(ctx, "/api/v1/agent/videos/tags", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Summarize 根据标题、描述和抽帧生成摘要与关键时刻
+func Summarize(ctx context.Context, req *VideoRequest) (*VideoSummary, error) {
+	var result VideoSummary
+	if err := post(ctx, "/api/v1/agent/videos/summary", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 图片审核结论
+const (
+	ImageDecisionApprove = "approve"
+	ImageDecisionReject  = "reject"
+	ImageDecisionReview  = "review" // 无法判定，转人工审核
+)
+
+// ImageRequest 图片审核请求（头像、主页背景图）
+type ImageRequest struct {
+	UserID int64  `json:"user_id"`
+	Kind   string `json:"kind"`
+	URL    string `json:"url"`
+}
+
+// ImageModeration 图片审核结果
+type ImageModeration struct {
+	Decision string   `json:"decision"`
+	Reason   string   `json:"reason"`
+	Labels   []string `json:"labels"`
+}
+
+// ModerateImage 审核用户上传的图片
+func ModerateImage(ctx context.Context, req *ImageRequest) (*ImageModeration, error) {
+	var result ImageModeration
+	if err := post(ctx, "/api/v1/agent/images/moderate", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AskRequest 视频问答请求，视频内容以摘要、关键时刻、标签等文字形式提供
+type AskRequest struct {
+	VideoID     int64       `json:"video_id"`
+	UserID      int64       `json:"user_id"`
+	Question    string      `json:"question"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Duration    int         `json:"duration"`
+	Summary     string      `json:"summary"`
+	KeyMoments  []KeyMoment `json:"key_moments"`
+	Tags        []string    `json:"tags"`
+}
+
+// AskStream 流式视频问答，每收到一段回复调用一次 onChunk，onChunk 返回错误时中止
+func AskStream(ctx context.Context, req *AskRequest, onChunk func(content string) error) error {
+	if !Enabled() {
+		return fmt.Errorf("agent service disabled")
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/api/v1/agent/videos/ask", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	resp, err := streamClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("call agent service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("agent service ask returned %d: %s", resp.StatusCode, raw)
+	}
+
+	// 每个 SSE 事件为一行 data: {"code": 200, "message": "streaming|done", "data": {"content": "..."}}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var chunk struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Data    struct {
+				Content string `json:"content"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return fmt.Errorf("decode agent stream: %w", err)
+		}
+		if chunk.Code != http.StatusOK {
+			return fmt.Errorf("agent service ask failed: %d %s", chunk.Code, chunk.Message)
+		}
+		if chunk.Message == "done" {
+			return nil
+		}
+		if chunk.Data.Content != "" {
+			if err := onChunk(chunk.Data.Content); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("agent stream ended unexpectedly")
+}
+
+// post 调用 Agent 服务，响应格式为 {"code": 200, "message": "...", "data": {...}}
+func post(ctx context.Context, path string, body, out interface{}) error {
+	if !Enabled() {
+		return fmt.Errorf("agent service disabled")
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call agent service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("agent service %s returned %d: %s", path, resp.StatusCode, raw)
+	}
+
+	var envelope struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("decode agent response: %w", err)
+	}
+	if envelope.Code != http.StatusOK {
+		return fmt.Errorf("agent service %s failed: %d %s", path, envelope.Code, envelope.Message)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
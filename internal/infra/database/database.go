@@ -0,0 +1,280 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
+)
+
+var DB *gorm.DB
+
+// Init 初始化数据库连接（PostgreSQL、MySQL 或 SQLite）
+func Init(cfg *config.DatabaseConfig) error {
+	if cfg.DriverName() == "sqlite" {
+		if err := os.MkdirAll(filepath.Dir(cfg.SQLitePath()), 0755); err != nil {
+			return fmt.Errorf("failed to create sqlite dir: %w", err)
+		}
+	}
+	dialector, err := openDialector(cfg.DriverName(), cfg.DSN())
+	if err != nil {
+		return err
+	}
+
+	DB, err = gorm.Open(dialector, &gorm.Config{Logger: newZapLogger(cfg)})
+	if err != nil {
+		return fmt.Errorf("failed to connect database: %w", err)
+	}
+
+	// 注册 OpenTelemetry 插件（SQL 参数不上报，避免泄露敏感数据）
+	if err := DB.Use(otelgorm.NewPlugin(otelgorm.WithoutQueryVariables(), otelgorm.WithoutMetrics())); err != nil {
+		return fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
+	if len(cfg.Replicas) > 0 {
+		if err := useReplicas(cfg); err != nil {
+			return err
+		}
+	}
+
+	// 获取底层sql.DB来配置连接池
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	if cfg.DriverName() == "sqlite" {
+		// SQLite 同一时间只允许一个写入者，单连接避免 database is locked
+		sqlDB.SetMaxOpenConns(1)
+	}
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+
+	// 测试连接
+	if err := sqlDB.Ping(); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	logger.Info("Database connected",
+		zap.String("driver", cfg.DriverName()),
+		zap.String("host", cfg.Host),
+		zap.Int("port", cfg.Port),
+		zap.String("dbname", cfg.DBName),
+		zap.Int("max_open_conns", cfg.MaxOpenConns),
+		zap.Int("max_idle_conns", cfg.MaxIdleConns),
+		zap.Int("replicas", len(cfg.Replicas)),
+	)
+
+	return nil
+}
+
+func openDialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case "postgres":
+		return postgres.Open(dsn), nil
+	case "mysql":
+		return mysql.Open(dsn), nil
+	case "sqlite":
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+	}
+}
+
+// useReplicas 注册只读副本。默认所有查询仍走主库，保证写后读一致；
+// 只有显式标记为 dbresolver.Read 的查询（见 repository.replica）才路由到副本
+func useReplicas(cfg *config.DatabaseConfig) error {
+	replicas := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for _, dsn := range cfg.Replicas {
+		dialector, err := openDialector(cfg.DriverName(), dsn)
+		if err != nil {
+			return err
+		}
+		replicas = append(replicas, dialector)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	if err := DB.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register read replicas: %w", err)
+	}
+
+	DB = DB.Clauses(dbresolver.Write).Session(&gorm.Session{})
+	return nil
+}
+
+// AutoMigrate 自动迁移数据库表结构
+func AutoMigrate(models ...interface{}) error {
+	if err := DB.AutoMigrate(models...); err != nil {
+		return fmt.Errorf("failed to auto migrate: %w", err)
+	}
+	logger.Info("Database auto migration completed")
+	return nil
+}
+
+// MigrateSoftDelete 把旧版删除标记迁移为 deleted_at：users.is_delete 非 0 和
+// videos.status = 'deleted' 的记录视为已删除，迁移完成后删除 users.is_delete 列。
+// 需在 AutoMigrate 之后调用，可重复执行
+func MigrateSoftDelete() error {
+	m := DB.Migrator()
+	if m.HasColumn("users", "is_delete") {
+		if err := DB.Exec("UPDATE users SET deleted_at = ? WHERE is_delete <> 0 AND deleted_at IS NULL", time.Now()).Error; err != nil {
+			return fmt.Errorf("failed to migrate deleted users: %w", err)
+		}
+		if err := DB.Exec("ALTER TABLE users DROP COLUMN is_delete").Error; err != nil {
+			return fmt.Errorf("failed to drop users.is_delete: %w", err)
+		}
+	}
+
+	result := DB.Exec("UPDATE videos SET deleted_at = updated_at WHERE status = 'deleted' AND deleted_at IS NULL")
+	if result.Error != nil {
+		return fmt.Errorf("failed to migrate deleted videos: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		logger.Info("Migrated legacy deleted videos", zap.Int64("count", result.RowsAffected))
+	}
+	return nil
+}
+
+// MigrateReactions 点赞扩展为多种表态前的点赞均为 like（新增的 favorites.reaction 列默认值），
+// 把这些视频原有的点赞数回填到 like_count。需在 AutoMigrate 之后调用，可重复执行
+func MigrateReactions() error {
+	result := DB.Exec("UPDATE videos SET like_count = favorite_count " +
+		"WHERE favorite_count > 0 AND like_count = 0 AND love_count = 0 AND laugh_count = 0 AND wow_count = 0")
+	if result.Error != nil {
+		return fmt.Errorf("failed to migrate reaction counts: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		logger.Info("Migrated favorite counts to like reactions", zap.Int64("count", result.RowsAffected))
+	}
+	return nil
+}
+
+// legacyPublishTimeColumn 旧版 videos.publish_time 为 Unix 秒整数，迁移期间暂存在该列
+const legacyPublishTimeColumn = "publish_time_unix"
+
+// RenameLegacyPublishTime 旧版 videos.publish_time 为 Unix 秒整数，改为时间类型前先把旧列改名暂存，
+// 由 AutoMigrate 创建新列后再通过 MigratePublishTime 回填。需在 AutoMigrate 之前调用，可重复执行
+func RenameLegacyPublishTime() error {
+	m := DB.Migrator()
+	if !m.HasTable("videos") || m.HasColumn("videos", legacyPublishTimeColumn) {
+		return nil
+	}
+	columns, err := m.ColumnTypes("videos")
+	if err != nil {
+		return fmt.Errorf("failed to inspect videos columns: %w", err)
+	}
+	for _, col := range columns {
+		if col.Name() != "publish_time" || !strings.Contains(strings.ToLower(col.DatabaseTypeName()), "int") {
+			continue
+		}
+		// 索引随列改名，需先删除，AutoMigrate 会在新列上重建同名索引
+		if m.HasIndex("videos", "idx_publish_time") {
+			if err := m.DropIndex("videos", "idx_publish_time"); err != nil {
+				return fmt.Errorf("failed to drop idx_publish_time: %w", err)
+			}
+		}
+		if err := DB.Exec("ALTER TABLE videos RENAME COLUMN publish_time TO " + legacyPublishTimeColumn).Error; err != nil {
+			return fmt.Errorf("failed to rename videos.publish_time: %w", err)
+		}
+		logger.Info("Renamed legacy videos.publish_time for migration")
+	}
+	return nil
+}
+
+// MigratePublishTime 把暂存的 Unix 秒发布时间回填到新的 publish_time 列，完成后删除暂存列。
+// 需在 AutoMigrate 之后调用，可重复执行
+func MigratePublishTime() error {
+	m := DB.Migrator()
+	if !m.HasColumn("videos", legacyPublishTimeColumn) {
+		return nil
+	}
+
+	type legacyRow struct {
+		ID              int64
+		PublishTimeUnix int64
+	}
+	var migrated int64
+	lastID := int64(0)
+	for {
+		var rows []legacyRow
+		err := DB.Table("videos").Select("id, "+legacyPublishTimeColumn+" AS publish_time_unix").
+			Where("id > ? AND "+legacyPublishTimeColumn+" IS NOT NULL", lastID).
+			Order("id ASC").Limit(500).Scan(&rows).Error
+		if err != nil {
+			return fmt.Errorf("failed to load legacy publish_time: %w", err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+		err = DB.Transaction(func(tx *gorm.DB) error {
+			for _, row := range rows {
+				if err := tx.Table("videos").Where("id = ? AND publish_time IS NULL", row.ID).
+					Update("publish_time", time.Unix(row.PublishTimeUnix, 0)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate publish_time: %w", err)
+		}
+		migrated += int64(len(rows))
+		lastID = rows[len(rows)-1].ID
+	}
+
+	if err := DB.Exec("ALTER TABLE videos DROP COLUMN " + legacyPublishTimeColumn).Error; err != nil {
+		return fmt.Errorf("failed to drop videos.%s: %w", legacyPublishTimeColumn, err)
+	}
+	logger.Info("Migrated videos.publish_time to timestamp", zap.Int64("count", migrated))
+	return nil
+}
+
+// Ping 检查数据库连通性
+func Ping(ctx context.Context) error {
+	if DB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close 关闭数据库连接
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	logger.Info("Database connection closed")
+	return sqlDB.Close()
+}
+
+// Get 获取数据库实例
+func Get() *gorm.DB {
+	return DB
+}
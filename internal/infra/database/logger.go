@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"vida-go/internal/config"
+	"vida-go/internal/infra/tracing"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// zapLogger 通过 zap 输出 GORM 日志：出错和超过阈值的慢查询总会记录，
+// info 级别时记录所有 SQL；日志带 trace_id 与请求级字段，便于与请求日志关联
+type zapLogger struct {
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+	logParams     bool
+}
+
+func newZapLogger(cfg *config.DatabaseConfig) *zapLogger {
+	return &zapLogger{
+		level:         cfg.GormLogLevel(),
+		slowThreshold: cfg.SlowQueryThreshold(),
+		logParams:     cfg.LogParams,
+	}
+}
+
+func (l *zapLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	cp := *l
+	cp.level = level
+	return &cp
+}
+
+func (l *zapLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log(ctx).Info(fmt.Sprintf(msg, args...), l.fields(ctx)...)
+	}
+}
+
+func (l *zapLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log(ctx).Warn(fmt.Sprintf(msg, args...), l.fields(ctx)...)
+	}
+}
+
+func (l *zapLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log(ctx).Error(fmt.Sprintf(msg, args...), l.fields(ctx)...)
+	}
+}
+
+// Trace 每条 SQL 执行后调用，查不到记录（ErrRecordNotFound）不算错误
+func (l *zapLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+
+	switch {
+	case failed && l.level >= gormlogger.Error:
+		l.log(ctx).Error("SQL error", l.traceFields(ctx, elapsed, fc, zap.Error(err))...)
+	case slow && l.level >= gormlogger.Warn:
+		l.log(ctx).Warn("Slow SQL", l.traceFields(ctx, elapsed, fc, zap.Duration("threshold", l.slowThreshold))...)
+	case l.level >= gormlogger.Info:
+		l.log(ctx).Info("SQL", l.traceFields(ctx, elapsed, fc)...)
+	}
+}
+
+// ParamsFilter 未开启 log_params 时不把参数拼进日志中的 SQL，避免记录密码、手机号等敏感数据
+func (l *zapLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if !l.logParams {
+		return sql, nil
+	}
+	return sql, params
+}
+
+func (l *zapLogger) traceFields(ctx context.Context, elapsed time.Duration, fc func() (string, int64), extra ...zap.Field) []zap.Field {
+	sql, rows := fc()
+	fields := append(l.fields(ctx),
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", elapsed),
+		zap.String("source", sqlSource()),
+	)
+	return append(fields, extra...)
+}
+
+// sqlSource 返回发起 SQL 的业务代码位置（跳过 GORM 及其插件、本文件）
+func sqlSource() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "gorm.io/") && !strings.HasSuffix(frame.File, "/infra/database/logger.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// log 返回请求级 Logger（带 request_id、user_id 等字段），不带 caller，SQL 的调用位置记录在 source 字段
+func (l *zapLogger) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx).WithOptions(zap.WithCaller(false))
+}
+
+func (l *zapLogger) fields(ctx context.Context) []zap.Field {
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		return []zap.Field{zap.String("trace_id", traceID)}
+	}
+	return nil
+}
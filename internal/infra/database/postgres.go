@@ -1,77 +0,0 @@
-package database
-
-import (
-	"fmt"
-	"time"
-
-	"vida-go/internal/config"
-	"vida-go/pkg/logger"
-
-	"go.uber.org/zap"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-)
-
-var DB *gorm.DB
-
-// Init 初始化PostgreSQL数据库连接
-func Init(cfg *config.DatabaseConfig) error {
-	var err error
-
-	DB, err = gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to connect database: %w", err)
-	}
-
-	// 获取底层sql.DB来配置连接池
-	sqlDB, err := DB.DB()
-	if err != nil {
-		return fmt.Errorf("failed to get sql.DB: %w", err)
-	}
-
-	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
-	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
-	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
-
-	// 测试连接
-	if err := sqlDB.Ping(); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	logger.Info("Database connected",
-		zap.String("host", cfg.Host),
-		zap.Int("port", cfg.Port),
-		zap.String("dbname", cfg.DBName),
-		zap.Int("max_open_conns", cfg.MaxOpenConns),
-		zap.Int("max_idle_conns", cfg.MaxIdleConns),
-	)
-
-	return nil
-}
-
-// AutoMigrate 自动迁移数据库表结构
-func AutoMigrate(models ...interface{}) error {
-	if err := DB.AutoMigrate(models...); err != nil {
-		return fmt.Errorf("failed to auto migrate: %w", err)
-	}
-	logger.Info("Database auto migration completed")
-	return nil
-}
-
-// Close 关闭数据库连接
-func Close() error {
-	if DB == nil {
-		return nil
-	}
-	sqlDB, err := DB.DB()
-	if err != nil {
-		return err
-	}
-	logger.Info("Database connection closed")
-	return sqlDB.Close()
-}
-
-// Get 获取数据库实例
-func Get() *gorm.DB {
-	return DB
-}
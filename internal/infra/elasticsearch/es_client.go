@@ -12,6 +12,7 @@ import (
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 )
 
@@ -37,6 +38,8 @@ func Init(cfg *config.ElasticsearchConfig) error {
 		RetryOnStatus: []int{502, 503, 504},
 		MaxRetries:    3,
 		RetryBackoff:  func(i int) time.Duration { return time.Duration(i) * time.Second },
+		// 请求体不上报，避免 Span 过大
+		Instrumentation: elasticsearch.NewOpenTelemetryInstrumentation(otel.GetTracerProvider(), false),
 	})
 	if err != nil {
 		return fmt.Errorf("create elasticsearch client: %w", err)
@@ -63,6 +66,22 @@ func Get() *elasticsearch.Client {
 	return client
 }
 
+// Ping 检查 ES 连通性
+func Ping(ctx context.Context) error {
+	if client == nil {
+		return fmt.Errorf("elasticsearch client not initialized")
+	}
+	resp, err := client.Ping(client.Ping.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.IsError() {
+		return fmt.Errorf("elasticsearch ping failed: %s", resp.String())
+	}
+	return nil
+}
+
 // Search 执行搜索（body 为 JSON 字符串）
 func Search(ctx context.Context, index string, body io.Reader) (*esapi.Response, error) {
 	if client == nil {
@@ -112,6 +131,18 @@ func IndicesCreate(ctx context.Context, index string, body io.Reader) (*esapi.Re
 	)
 }
 
+// IndicesPutMapping 为已有索引新增字段映射
+func IndicesPutMapping(ctx context.Context, index string, body io.Reader) (*esapi.Response, error) {
+	if client == nil {
+		return nil, fmt.Errorf("elasticsearch client not initialized")
+	}
+	return client.Indices.PutMapping(
+		[]string{index},
+		body,
+		client.Indices.PutMapping.WithContext(ctx),
+	)
+}
+
 // IndicesExists 检查索引是否存在
 func IndicesExists(ctx context.Context, index string) (bool, error) {
 	if client == nil {
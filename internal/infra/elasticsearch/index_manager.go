@@ -50,19 +50,37 @@ func GetVideosIndexMapping() string {
 					"search_analyzer": "ik_smart"
 				},
 				"status": {"type": "keyword"},
-				"publish_time": {"type": "long"},
+				"publish_time": {"type": "date", "format": "epoch_second||strict_date_optional_time"},
 				"view_count": {"type": "long"},
 				"favorite_count": {"type": "long"},
 				"comment_count": {"type": "long"},
 				"hot_score": {"type": "float"},
+				"completion_rate": {"type": "float"},
+				"watch_time_ms": {"type": "long"},
 				"duration": {"type": "integer"},
+				"height": {"type": "integer"},
+				"frame_rate": {"type": "float"},
+				"is_sponsored": {"type": "boolean"},
 				"created_at": {"type": "date", "format": "strict_date_optional_time||epoch_millis"},
-				"updated_at": {"type": "date", "format": "strict_date_optional_time||epoch_millis"}
+				"updated_at": {"type": "date", "format": "strict_date_optional_time||epoch_millis"},
+				"allowed_regions": {"type": "keyword"},
+				"blocked_regions": {"type": "keyword"}
 			}
 		}
 	}`
 }
 
+// videosIndexAddedFields 索引创建后新增的字段，已有索引启动时通过 put mapping 补齐
+const videosIndexAddedFields = `{
+	"properties": {
+		"allowed_regions": {"type": "keyword"},
+		"blocked_regions": {"type": "keyword"},
+		"height": {"type": "integer"},
+		"frame_rate": {"type": "float"},
+		"is_sponsored": {"type": "boolean"}
+	}
+}`
+
 // EnsureVideosIndex 确保 videos 索引存在，不存在则创建
 func EnsureVideosIndex(ctx context.Context) error {
 	cfg := config.GetElasticsearch()
@@ -77,6 +95,14 @@ func EnsureVideosIndex(ctx context.Context) error {
 	}
 	if exists {
 		logger.Info("Elasticsearch videos index already exists", zap.String("index", indexName))
+		resp, err := IndicesPutMapping(ctx, indexName, bytes.NewReader([]byte(videosIndexAddedFields)))
+		if err != nil {
+			return fmt.Errorf("update index mapping: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.IsError() {
+			return fmt.Errorf("update index mapping failed: %s", resp.String())
+		}
 		return nil
 	}
 
@@ -99,5 +125,8 @@ func EnsureVideosIndex(ctx context.Context) error {
 func InitIndexes() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	return EnsureVideosIndex(ctx)
+	if err := EnsureVideosIndex(ctx); err != nil {
+		return err
+	}
+	return EnsureUsersIndex(ctx)
 }
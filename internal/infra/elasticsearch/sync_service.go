@@ -17,46 +17,73 @@ import (
 
 // ESVideoDoc ES 视频文档结构
 type ESVideoDoc struct {
-	ID            int64   `json:"id"`
-	AuthorID      int64   `json:"author_id"`
-	AuthorName    string  `json:"author_name"`
-	Title         string  `json:"title"`
-	Description   string  `json:"description"`
-	Status        string  `json:"status"`
-	PublishTime   int64   `json:"publish_time"`
-	ViewCount     int64   `json:"view_count"`
-	FavoriteCount int64   `json:"favorite_count"`
-	CommentCount  int64   `json:"comment_count"`
-	HotScore      float64 `json:"hot_score"`
-	Duration      int     `json:"duration"`
-	CreatedAt     string  `json:"created_at"`
-	UpdatedAt     string  `json:"updated_at"`
+	ID             int64   `json:"id"`
+	AuthorID       int64   `json:"author_id"`
+	AuthorName     string  `json:"author_name"`
+	Title          string  `json:"title"`
+	Description    string  `json:"description"`
+	Status         string  `json:"status"`
+	PublishTime    int64   `json:"publish_time"` // Unix 秒，新索引映射为 date（epoch_second），旧索引的 long 映射同样兼容
+	ViewCount      int64   `json:"view_count"`
+	FavoriteCount  int64   `json:"favorite_count"`
+	CommentCount   int64   `json:"comment_count"`
+	HotScore       float64 `json:"hot_score"`
+	CompletionRate float64 `json:"completion_rate"`
+	WatchTimeMs    int64   `json:"watch_time_ms"`
+	Duration       int     `json:"duration"`
+	Height         int     `json:"height"`
+	FrameRate      float64 `json:"frame_rate"`
+	IsSponsored    bool    `json:"is_sponsored"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+
+	AllowedRegions []string `json:"allowed_regions"`
+	BlockedRegions []string `json:"blocked_regions"`
 }
 
-func hotScore(view, fav, comment int64) float64 {
-	return (float64(view)*0.5 + float64(fav)*2.0 + float64(comment)*1.5) / 1000
+// completionRate 完播率（完播次数 / 播放次数），无播放数据时为 0
+func completionRate(v *model.Video) float64 {
+	if v.PlayCount <= 0 {
+		return 0
+	}
+	return min(float64(v.CompleteCount)/float64(v.PlayCount), 1)
+}
+
+// hotScore 互动热度，权重见 config.HotScoreConfig。不含时间衰减，衰减在搜索时按 publish_time 计算
+func hotScore(v *model.Video) float64 {
+	w := config.GetHotScore()
+	engagement := float64(v.ViewCount)*w.View() + float64(v.FavoriteCount)*w.Favorite() + float64(v.CommentCount)*w.Comment()
+	watchMinutes := float64(v.WatchTimeMs) / float64(time.Minute/time.Millisecond)
+	return (engagement*(1+completionRate(v)) + watchMinutes*w.WatchMinute()) / 1000
 }
 
 func videoToESDoc(v *model.Video, authorName string) *ESVideoDoc {
 	pubTime := int64(0)
-	if v.PublishTime != nil {
-		pubTime = *v.PublishTime
+	if t := v.PublishUnix(); t != nil {
+		pubTime = *t
 	}
 	return &ESVideoDoc{
-		ID:            v.ID,
-		AuthorID:      v.AuthorID,
-		AuthorName:    authorName,
-		Title:         v.Title,
-		Description:   v.Description,
-		Status:        v.Status,
-		PublishTime:   pubTime,
-		ViewCount:     v.ViewCount,
-		FavoriteCount: v.FavoriteCount,
-		CommentCount:  v.CommentCount,
-		HotScore:      hotScore(v.ViewCount, v.FavoriteCount, v.CommentCount),
-		Duration:      v.Duration,
-		CreatedAt:     v.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     v.UpdatedAt.Format(time.RFC3339),
+		ID:             v.ID,
+		AuthorID:       v.AuthorID,
+		AuthorName:     authorName,
+		Title:          v.Title,
+		Description:    v.Description,
+		Status:         v.Status,
+		PublishTime:    pubTime,
+		ViewCount:      v.ViewCount,
+		FavoriteCount:  v.FavoriteCount,
+		CommentCount:   v.CommentCount,
+		HotScore:       hotScore(v),
+		CompletionRate: completionRate(v),
+		WatchTimeMs:    v.WatchTimeMs,
+		Duration:       v.Duration,
+		Height:         v.Height,
+		FrameRate:      v.FrameRate,
+		IsSponsored:    v.IsSponsored,
+		CreatedAt:      v.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      v.UpdatedAt.Format(time.RFC3339),
+		AllowedRegions: model.SplitRegions(v.AllowedRegions),
+		BlockedRegions: model.SplitRegions(v.BlockedRegions),
 	}
 }
 
@@ -0,0 +1,176 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"vida-go/internal/config"
+	"vida-go/internal/model"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ESUserDoc ES 用户文档结构，仅用于搜索时匹配作者，详情从数据库读取
+type ESUserDoc struct {
+	ID            int64  `json:"id"`
+	UserName      string `json:"user_name"`
+	FollowerCount int64  `json:"follower_count"`
+	IsVerified    bool   `json:"is_verified"`
+}
+
+// GetUsersIndexMapping 返回 users 索引的 mapping：user_name 按标准分词支持模糊匹配，
+// keyword 子字段统一小写用于精确匹配
+func GetUsersIndexMapping() string {
+	return `{
+		"settings": {
+			"number_of_shards": 1,
+			"number_of_replicas": 0,
+			"analysis": {
+				"normalizer": {
+					"lowercase_normalizer": {
+						"type": "custom",
+						"filter": ["lowercase"]
+					}
+				}
+			}
+		},
+		"mappings": {
+			"properties": {
+				"id": {"type": "long"},
+				"user_name": {
+					"type": "text",
+					"analyzer": "standard",
+					"fields": {"keyword": {"type": "keyword", "normalizer": "lowercase_normalizer", "ignore_above": 255}}
+				},
+				"follower_count": {"type": "long"},
+				"is_verified": {"type": "boolean"}
+			}
+		}
+	}`
+}
+
+// UsersIndexName 返回 users 索引名
+func UsersIndexName() string {
+	if name := config.GetElasticsearch().Index["users"]; name != "" {
+		return name
+	}
+	return "users"
+}
+
+// EnsureUsersIndex 确保 users 索引存在，不存在则创建
+func EnsureUsersIndex(ctx context.Context) error {
+	indexName := UsersIndexName()
+	exists, err := IndicesExists(ctx, indexName)
+	if err != nil {
+		return fmt.Errorf("check index exists: %w", err)
+	}
+	if exists {
+		logger.Info("Elasticsearch users index already exists", zap.String("index", indexName))
+		return nil
+	}
+
+	resp, err := IndicesCreate(ctx, indexName, strings.NewReader(GetUsersIndexMapping()))
+	if err != nil {
+		return fmt.Errorf("create index: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return fmt.Errorf("create index failed: %s", resp.String())
+	}
+
+	logger.Info("Elasticsearch users index created", zap.String("index", indexName))
+	return nil
+}
+
+func userToESDoc(u *model.User) *ESUserDoc {
+	return &ESUserDoc{
+		ID:            u.ID,
+		UserName:      u.UserName,
+		FollowerCount: u.FollowerCount,
+		IsVerified:    u.IsVerified,
+	}
+}
+
+// SyncUser 同步单个用户到 ES
+func SyncUser(ctx context.Context, u *model.User) error {
+	body, err := json.Marshal(userToESDoc(u))
+	if err != nil {
+		return err
+	}
+
+	resp, err := Index(ctx, UsersIndexName(), fmt.Sprintf("%d", u.ID), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return fmt.Errorf("index document failed: %s", resp.String())
+	}
+	return nil
+}
+
+// DeleteUser 从 ES 删除用户
+func DeleteUser(ctx context.Context, userID int64) error {
+	resp, err := Delete(ctx, UsersIndexName(), fmt.Sprintf("%d", userID))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() && resp.StatusCode != 404 {
+		return fmt.Errorf("delete document failed: %s", resp.String())
+	}
+	return nil
+}
+
+// BulkSyncUsers 批量同步用户到 ES
+func BulkSyncUsers(ctx context.Context, users []model.User) (success, failed int, err error) {
+	indexName := UsersIndexName()
+
+	var buf strings.Builder
+	for i := range users {
+		docBody, _ := json.Marshal(userToESDoc(&users[i]))
+		buf.WriteString(fmt.Sprintf(`{"index":{"_index":"%s","_id":"%d"}}`, indexName, users[i].ID))
+		buf.WriteString("\n")
+		buf.Write(docBody)
+		buf.WriteString("\n")
+	}
+	if buf.Len() == 0 {
+		return 0, 0, nil
+	}
+
+	resp, err := Bulk(ctx, strings.NewReader(buf.String()))
+	if err != nil {
+		return 0, len(users), err
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return 0, len(users), fmt.Errorf("bulk failed: %s", resp.String())
+	}
+
+	var bulkResp struct {
+		Items []struct {
+			Index struct {
+				Status int `json:"status"`
+			} `json:"index"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&bulkResp); err != nil {
+		return len(users), 0, nil
+	}
+	for _, item := range bulkResp.Items {
+		if item.Index.Status >= 200 && item.Index.Status < 300 {
+			success++
+		} else {
+			failed++
+		}
+	}
+	return success, failed, nil
+}
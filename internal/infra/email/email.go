@@ -0,0 +1,71 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+var cfg *config.EmailConfig
+
+// Init 初始化邮件发送配置，未启用时 Send 直接返回
+func Init(c *config.EmailConfig) {
+	cfg = c
+	if !Enabled() {
+		logger.Info("Email delivery disabled")
+		return
+	}
+	logger.Info("Email delivery enabled", zap.String("smtp", c.Addr()), zap.String("from", c.From))
+}
+
+// Enabled 是否启用邮件发送
+func Enabled() bool {
+	return cfg != nil && cfg.Enabled
+}
+
+// Send 通过 SMTP 发送一封 HTML 邮件（服务器支持时自动使用 STARTTLS）
+func Send(ctx context.Context, to, subject, htmlBody string) error {
+	if !Enabled() {
+		return fmt.Errorf("email delivery disabled")
+	}
+
+	from := mail.Address{Name: cfg.FromName, Address: cfg.From}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(htmlBody)
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	// net/smtp 不支持 context，超时由 SMTP 服务器连接超时兜底
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(cfg.Addr(), auth, cfg.From, []string{to}, msg.Bytes())
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
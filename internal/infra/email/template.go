@@ -0,0 +1,34 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// 每个模板文件需定义 subject 与 body 两个子模板
+var templates = template.Must(template.New("").ParseFS(templateFS, "templates/*.html"))
+
+// Render 渲染邮件模板，返回主题与 HTML 正文
+func Render(name string, data interface{}) (string, string, error) {
+	tmpl := templates.Lookup(name + ".html")
+	if tmpl == nil {
+		return "", "", fmt.Errorf("email template %q not found", name)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return "", "", fmt.Errorf("render email subject %q: %w", name, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, name+".body", data); err != nil {
+		return "", "", fmt.Errorf("render email body %q: %w", name, err)
+	}
+	// 主题为纯文本，还原 html/template 的转义
+	return strings.TrimSpace(html.UnescapeString(subject.String())), body.String(), nil
+}
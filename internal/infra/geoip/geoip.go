@@ -0,0 +1,173 @@
+package geoip
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ipRange 一段 IP 地址及其所属国家/地区
+type ipRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+var ranges []ipRange
+
+// Init 加载 IP 段数据库，未配置时 Lookup 始终返回空
+func Init(cfg *config.GeoIPConfig) error {
+	if cfg.Database == "" {
+		return nil
+	}
+	f, err := os.Open(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	loaded, err := load(f)
+	if err != nil {
+		return fmt.Errorf("load geoip database %s: %w", cfg.Database, err)
+	}
+	ranges = loaded
+	logger.Info("GeoIP database loaded", zap.String("path", cfg.Database), zap.Int("ranges", len(ranges)))
+	return nil
+}
+
+// load 解析 CSV：每行 start_ip,end_ip,country 或 cidr,country，忽略空行、# 注释和无法解析的表头
+func load(r io.Reader) ([]ipRange, error) {
+	var result []ipRange
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, ",")
+		for i := range fields {
+			fields[i] = strings.Trim(strings.TrimSpace(fields[i]), `"`)
+		}
+
+		var rg ipRange
+		var ok bool
+		switch len(fields) {
+		case 2:
+			rg, ok = parseCIDR(fields[0], fields[1])
+		case 3:
+			rg, ok = parseRange(fields[0], fields[1], fields[2])
+		}
+		if !ok {
+			if line == 1 {
+				continue // 表头
+			}
+			return nil, fmt.Errorf("invalid line %d: %q", line, text)
+		}
+		result = append(result, rg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].start.Less(result[j].start) })
+	return result, nil
+}
+
+func parseCIDR(cidr, country string) (ipRange, bool) {
+	prefix, err := netip.ParsePrefix(cidr)
+	code, valid := NormalizeCountry(country)
+	if err != nil || !valid {
+		return ipRange{}, false
+	}
+	prefix = prefix.Masked()
+	start := prefix.Addr().Unmap()
+	return ipRange{start: start, end: lastAddr(prefix), country: code}, true
+}
+
+func parseRange(startIP, endIP, country string) (ipRange, bool) {
+	start, err1 := netip.ParseAddr(startIP)
+	end, err2 := netip.ParseAddr(endIP)
+	code, valid := NormalizeCountry(country)
+	if err1 != nil || err2 != nil || !valid {
+		return ipRange{}, false
+	}
+	start, end = start.Unmap(), end.Unmap()
+	if start.Is4() != end.Is4() || end.Less(start) {
+		return ipRange{}, false
+	}
+	return ipRange{start: start, end: end, country: code}, true
+}
+
+// lastAddr 网段中的最后一个地址
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().Unmap().AsSlice()
+	bits := prefix.Bits()
+	if prefix.Addr().Is4In6() {
+		bits -= 96
+	}
+	for i := range b {
+		for bit := 0; bit < 8; bit++ {
+			if i*8+bit >= bits {
+				b[i] |= 0x80 >> bit
+			}
+		}
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// Lookup 返回 IP 所属国家/地区代码（ISO 3166-1 alpha-2，大写），未知时返回空
+func Lookup(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || len(ranges) == 0 {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	// 找到最后一个起始地址不大于 addr 的网段
+	i := sort.Search(len(ranges), func(i int) bool { return addr.Less(ranges[i].start) }) - 1
+	if i < 0 {
+		return ""
+	}
+	rg := ranges[i]
+	if rg.start.Is4() != addr.Is4() || rg.end.Less(addr) {
+		return ""
+	}
+	return rg.country
+}
+
+// NormalizeCountry 校验并规范化国家/地区代码（两位字母，转大写）；
+// 数据库和 CDN 中表示未知、匿名代理的 XX、ZZ、T1 等视为无效
+func NormalizeCountry(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return "", false
+	}
+	if code == "XX" || code == "ZZ" {
+		return "", false
+	}
+	return code, true
+}
+
+type ctxKey struct{}
+
+// NewContext 将请求来源的国家/地区代码放入 context
+func NewContext(ctx context.Context, country string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, country)
+}
+
+// CountryFromContext 获取请求来源的国家/地区代码，未知时返回空
+func CountryFromContext(ctx context.Context) string {
+	country, _ := ctx.Value(ctxKey{}).(string)
+	return country
+}
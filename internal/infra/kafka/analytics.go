@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// AnalyticsEvent 客户端行为埋点消息体（播放、暂停、完播、曝光），供统计与推荐消费
+type AnalyticsEvent struct {
+	Type        string    `json:"type"`
+	VideoID     int64     `json:"video_id"`
+	UserID      int64     `json:"user_id,omitempty"`
+	DeviceID    string    `json:"device_id,omitempty"`
+	SessionID   string    `json:"session_id,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	PositionMs  int64     `json:"position_ms,omitempty"`
+	WatchTimeMs int64     `json:"watch_time_ms,omitempty"`
+	ClientTime  time.Time `json:"client_time"`
+	ReceivedAt  time.Time `json:"received_at"`
+}
+
+// SendAnalyticsEvents 批量发送埋点事件（按视频分区，便于下游按视频聚合）
+func SendAnalyticsEvents(ctx context.Context, topic string, events []*AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ctx, span := startProduceSpan(ctx, topic)
+	defer span.End()
+
+	msgs := make([]kafka.Message, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal analytics event: %w", err)
+		}
+		msg := kafka.Message{
+			Topic: topic,
+			Key:   []byte(fmt.Sprintf("video-%d", event.VideoID)),
+			Value: payload,
+		}
+		injectTraceContext(ctx, &msg)
+		msgs = append(msgs, msg)
+	}
+
+	if err := producer.WriteMessages(ctx, msgs...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to send analytics events: %w", err)
+	}
+	return nil
+}
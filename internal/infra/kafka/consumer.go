@@ -8,11 +8,12 @@ import (
 	"vida-go/pkg/logger"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 )
 
-// ResultHandler 处理转码结果的回调函数
-type ResultHandler func(result *TranscodeResult) error
+// ResultHandler 处理转码结果的回调函数（ctx 携带上游 Trace 上下文）
+type ResultHandler func(ctx context.Context, result *TranscodeResult) error
 
 // StartTranscodeResultConsumer 启动转码结果消费者（阻塞，需在 goroutine 中运行）
 // ctx 取消后会自动停止
@@ -50,12 +51,18 @@ func StartTranscodeResultConsumer(ctx context.Context, brokers []string, topic,
 			continue
 		}
 
+		// 处理过程不受消费者关闭影响
+		msgCtx, span := StartConsumeSpan(context.WithoutCancel(ctx), &msg)
+
 		var result TranscodeResult
 		if err := json.Unmarshal(msg.Value, &result); err != nil {
 			logger.Error("Failed to unmarshal transcode result",
 				zap.Error(err),
 				zap.ByteString("value", msg.Value),
 			)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unmarshal failed")
+			span.End()
 			continue
 		}
 
@@ -64,11 +71,79 @@ func StartTranscodeResultConsumer(ctx context.Context, brokers []string, topic,
 			zap.String("status", result.Status),
 		)
 
-		if err := handler(&result); err != nil {
+		if err := handler(msgCtx, &result); err != nil {
 			logger.Error("Failed to handle transcode result",
 				zap.Int64("video_id", result.VideoID),
 				zap.Error(err),
 			)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartJSONConsumer 启动通用 JSON 消息消费者（阻塞，需在 goroutine 中运行）
+// 消息体反序列化为 T 后交给 handler 处理，ctx 取消后会自动停止
+func StartJSONConsumer[T any](ctx context.Context, brokers []string, topic, groupID string, handler func(ctx context.Context, msg *T) error) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       1,
+		MaxBytes:       10e6,
+		CommitInterval: time.Second,
+		StartOffset:    kafka.LastOffset,
+	})
+
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Error("Failed to close kafka consumer", zap.Error(err))
+		}
+		logger.Info("Kafka consumer stopped", zap.String("topic", topic))
+	}()
+
+	logger.Info("Kafka consumer started",
+		zap.String("topic", topic),
+		zap.String("group", groupID),
+	)
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("Failed to read kafka message", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		// 处理过程不受消费者关闭影响
+		msgCtx, span := StartConsumeSpan(context.WithoutCancel(ctx), &msg)
+
+		var value T
+		if err := json.Unmarshal(msg.Value, &value); err != nil {
+			logger.Error("Failed to unmarshal kafka message",
+				zap.String("topic", topic),
+				zap.Error(err),
+				zap.ByteString("value", msg.Value),
+			)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unmarshal failed")
+			span.End()
+			continue
+		}
+
+		if err := handler(msgCtx, &value); err != nil {
+			logger.Error("Failed to handle kafka message",
+				zap.String("topic", topic),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
 		}
+		span.End()
 	}
 }
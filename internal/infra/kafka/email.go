@@ -0,0 +1,24 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// EmailTask 邮件发送任务消息体（已渲染好的邮件内容）
+type EmailTask struct {
+	UserID  int64  `json:"user_id"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// SendEmailTask 发送邮件任务到 Kafka，由邮件消费者异步投递
+func SendEmailTask(ctx context.Context, topic string, task *EmailTask) error {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal email task: %w", err)
+	}
+	return SendRaw(ctx, topic, fmt.Sprintf("user-%d", task.UserID), payload)
+}
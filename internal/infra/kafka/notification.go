@@ -0,0 +1,28 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NotificationEvent 通知事件消息体（由业务服务产生，通知服务消费后落库并推送）
+type NotificationEvent struct {
+	Type        string    `json:"type"`
+	RecipientID int64     `json:"recipient_id"`
+	ActorID     int64     `json:"actor_id,omitempty"`
+	VideoID     *int64    `json:"video_id,omitempty"`
+	CommentID   *int64    `json:"comment_id,omitempty"`
+	Content     string    `json:"content,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SendNotificationEvent 发送通知事件到 Kafka（按接收人分区，保证同一用户的通知有序）
+func SendNotificationEvent(ctx context.Context, topic string, event *NotificationEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification event: %w", err)
+	}
+	return SendRaw(ctx, topic, fmt.Sprintf("user-%d", event.RecipientID), payload)
+}
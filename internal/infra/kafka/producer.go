@@ -10,34 +10,55 @@ import (
 	"vida-go/pkg/logger"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 )
 
-var producer *kafka.Writer
+var (
+	producer *kafka.Writer
+	brokers  []string
+)
 
 // TranscodeTask 转码任务消息体
 type TranscodeTask struct {
-	VideoID    int64  `json:"video_id"`
-	ObjectName string `json:"object_name"`
-	Bucket     string `json:"bucket"`
-	FileFormat string `json:"file_format"`
-	FileSize   int64  `json:"file_size"`
+	VideoID     int64  `json:"video_id"`
+	ObjectName  string `json:"object_name"`
+	Bucket      string `json:"bucket"`
+	FileFormat  string `json:"file_format"`
+	FileSize    int64  `json:"file_size"`
+	MaxDuration int    `json:"max_duration,omitempty"` // 时长上限（秒），0 表示不限制
+}
+
+// VideoFrame 转码时抽取的画面帧
+type VideoFrame struct {
+	URL    string `json:"url"`
+	Offset int    `json:"offset"`         // 在视频中的位置（秒）
+	Hash   uint64 `json:"hash,omitempty"` // 感知哈希（dHash），用于近似重复检测
 }
 
 // TranscodeResult 转码结果消息体
 type TranscodeResult struct {
-	VideoID  int64  `json:"video_id"`
-	Status   string `json:"status"`
-	PlayURL  string `json:"play_url,omitempty"`
-	CoverURL string `json:"cover_url,omitempty"`
-	Duration int    `json:"duration,omitempty"`
-	Width    int    `json:"width,omitempty"`
-	Height   int    `json:"height,omitempty"`
-	Error    string `json:"error,omitempty"`
+	VideoID  int64        `json:"video_id"`
+	Status   string       `json:"status"`
+	PlayURL  string       `json:"play_url,omitempty"`
+	CoverURL string       `json:"cover_url,omitempty"`
+	Frames   []VideoFrame `json:"frames,omitempty"` // 均匀抽取的画面帧
+	Duration int          `json:"duration,omitempty"`
+	Width    int          `json:"width,omitempty"`
+	Height   int          `json:"height,omitempty"`
+	Error    string       `json:"error,omitempty"`
+
+	// 转码产出的编码信息
+	VideoCodec    string  `json:"video_codec,omitempty"`
+	AudioCodec    string  `json:"audio_codec,omitempty"`
+	Bitrate       int64   `json:"bitrate,omitempty"` // bps
+	FrameRate     float64 `json:"frame_rate,omitempty"`
+	AudioChannels int     `json:"audio_channels,omitempty"`
 }
 
 // InitProducer 初始化 Kafka 生产者
 func InitProducer(cfg *config.KafkaConfig) error {
+	brokers = cfg.Brokers
 	producer = &kafka.Writer{
 		Addr:         kafka.TCP(cfg.Brokers...),
 		Balancer:     &kafka.LeastBytes{},
@@ -59,13 +80,19 @@ func SendTranscodeTask(ctx context.Context, topic string, task *TranscodeTask) e
 		return fmt.Errorf("failed to marshal transcode task: %w", err)
 	}
 
+	ctx, span := startProduceSpan(ctx, topic)
+	defer span.End()
+
 	msg := kafka.Message{
 		Topic: topic,
 		Key:   []byte(fmt.Sprintf("video-%d", task.VideoID)),
 		Value: payload,
 	}
+	injectTraceContext(ctx, &msg)
 
 	if err := producer.WriteMessages(ctx, msg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("failed to send transcode task: %w", err)
 	}
 
@@ -80,18 +107,42 @@ func SendTranscodeTask(ctx context.Context, topic string, task *TranscodeTask) e
 
 // SendRaw 发送原始消息到指定 topic
 func SendRaw(ctx context.Context, topic, key string, value []byte) error {
+	ctx, span := startProduceSpan(ctx, topic)
+	defer span.End()
+
 	msg := kafka.Message{
 		Topic: topic,
 		Key:   []byte(key),
 		Value: value,
 	}
+	injectTraceContext(ctx, &msg)
 
 	if err := producer.WriteMessages(ctx, msg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("failed to send kafka message: %w", err)
 	}
 	return nil
 }
 
+// Ping 检查 Kafka 连通性（任一 Broker 可连接即视为可用）
+func Ping(ctx context.Context) error {
+	if len(brokers) == 0 {
+		return fmt.Errorf("kafka producer not initialized")
+	}
+	var lastErr error
+	for _, addr := range brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		_ = conn.Close()
+		return nil
+	}
+	return lastErr
+}
+
 // CloseProducer 关闭生产者
 func CloseProducer() error {
 	if producer == nil {
@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RenditionTask 附加清晰度转码任务消息体，源文件为上传时的原始视频
+type RenditionTask struct {
+	VideoID    int64  `json:"video_id"`
+	Profile    string `json:"profile"`
+	Format     string `json:"format"` // mp4 / hls
+	Height     int    `json:"height"` // 目标高度，0 表示保持原分辨率
+	CRF        int    `json:"crf"`
+	Bucket     string `json:"bucket"`
+	ObjectName string `json:"object_name"`
+}
+
+// RenditionResult 附加清晰度转码结果消息体
+type RenditionResult struct {
+	VideoID int64  `json:"video_id"`
+	Profile string `json:"profile"`
+	Status  string `json:"status"` // ready / failed
+	URL     string `json:"url,omitempty"`
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SendRenditionTask 发送附加清晰度转码任务，同一视频的任务落在同一分区
+func SendRenditionTask(ctx context.Context, topic string, task *RenditionTask) error {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rendition task: %w", err)
+	}
+	return SendRaw(ctx, topic, fmt.Sprintf("video-%d", task.VideoID), payload)
+}
+
+// SendRenditionResult 发送附加清晰度转码结果
+func SendRenditionResult(ctx context.Context, topic string, result *RenditionResult) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rendition result: %w", err)
+	}
+	return SendRaw(ctx, topic, fmt.Sprintf("video-%d", result.VideoID), payload)
+}
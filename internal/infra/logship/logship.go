@@ -0,0 +1,202 @@
+package logship
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	sendTimeout  = 10 * time.Second
+	sendRetries  = 3
+	syncTimeout  = 5 * time.Second
+	retryBackoff = 200 * time.Millisecond
+)
+
+// sender 将一批 JSON 日志（每条一行，不含换行符）发送到下游
+type sender interface {
+	send(ctx context.Context, lines [][]byte) error
+	close() error
+}
+
+// Shipper 实现 zapcore.WriteSyncer：日志写入内存缓冲后立即返回，后台按条数或时间攒批投递，
+// 缓冲区满或下游多次重试仍失败时丢弃并计数，不阻塞业务
+type Shipper struct {
+	sender    sender
+	entries   chan []byte
+	flushReq  chan chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	batchSize int
+	interval  time.Duration
+	dropped   atomic.Int64
+}
+
+// New 按配置创建投递器并启动后台协程，brokers、hosts 未单独配置时复用 Kafka、Elasticsearch 的连接配置
+func New(cfg *config.LogShipConfig, kafkaCfg *config.KafkaConfig, esCfg *config.ElasticsearchConfig) (*Shipper, error) {
+	var (
+		snd sender
+		err error
+	)
+	switch cfg.Type {
+	case "kafka":
+		brokers := cfg.Brokers
+		if len(brokers) == 0 {
+			brokers = kafkaCfg.Brokers
+		}
+		snd, err = newKafkaSender(brokers, cfg.TopicName(), cfg.Batch())
+	case "elasticsearch":
+		hosts := cfg.Hosts
+		if len(hosts) == 0 {
+			hosts = esCfg.Hosts
+		}
+		snd, err = newESSender(hosts, cfg.IndexPrefix())
+	case "logstash":
+		snd, err = newLogstashSender(cfg.URL)
+	default:
+		err = fmt.Errorf("unsupported log ship type %q", cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Shipper{
+		sender:    snd,
+		entries:   make(chan []byte, cfg.Buffer()),
+		flushReq:  make(chan chan struct{}),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		batchSize: cfg.Batch(),
+		interval:  cfg.FlushInterval(),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Write 复制一条日志放入缓冲区，缓冲区满时直接丢弃
+func (s *Shipper) Write(p []byte) (int, error) {
+	line := bytes.TrimRight(p, "\n")
+	if len(line) == 0 {
+		return len(p), nil
+	}
+	select {
+	case s.entries <- append([]byte(nil), line...):
+	default:
+		s.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Sync 投递缓冲区中已有的日志，最多等待 syncTimeout（Fatal 退出前由 zap 调用）
+func (s *Shipper) Sync() error {
+	ack := make(chan struct{})
+	timer := time.NewTimer(syncTimeout)
+	defer timer.Stop()
+	select {
+	case s.flushReq <- ack:
+	case <-s.done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("log ship sync timeout")
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("log ship sync timeout")
+	}
+}
+
+// Close 投递剩余日志后停止后台协程并关闭下游连接
+func (s *Shipper) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		err = s.sender.close()
+	})
+	return err
+}
+
+// Dropped 返回累计丢弃的日志条数
+func (s *Shipper) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+func (s *Shipper) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, s.batchSize)
+	var reported int64
+	for {
+		select {
+		case line := <-s.entries:
+			batch = append(batch, line)
+			if len(batch) >= s.batchSize {
+				batch = s.flush(batch)
+			}
+		case <-ticker.C:
+			batch = s.flush(batch)
+			// 丢弃数只在增长时提示一次，避免下游故障期间刷屏
+			if n := s.dropped.Load(); n > reported {
+				logger.Warn("Log ship dropped entries", zap.Int64("dropped", n-reported), zap.Int64("total", n))
+				reported = n
+			}
+		case ack := <-s.flushReq:
+			batch = s.flush(s.drain(batch))
+			close(ack)
+		case <-s.stop:
+			s.flush(s.drain(batch))
+			return
+		}
+	}
+}
+
+// drain 取出缓冲区中当前所有日志
+func (s *Shipper) drain(batch [][]byte) [][]byte {
+	for {
+		select {
+		case line := <-s.entries:
+			batch = append(batch, line)
+		default:
+			return batch
+		}
+	}
+}
+
+// flush 按 batchSize 分批发送，失败时退避重试，仍失败则丢弃该批；返回清空后的切片以复用
+func (s *Shipper) flush(batch [][]byte) [][]byte {
+	for start := 0; start < len(batch); start += s.batchSize {
+		end := min(start+s.batchSize, len(batch))
+		chunk := batch[start:end]
+
+		var err error
+		for attempt := 0; attempt < sendRetries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(time.Duration(attempt) * retryBackoff)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+			err = s.sender.send(ctx, chunk)
+			cancel()
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			s.dropped.Add(int64(len(chunk)))
+		}
+	}
+	return batch[:0]
+}
@@ -0,0 +1,140 @@
+package logship
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaSender 每条日志作为一条消息写入主题
+type kafkaSender struct {
+	writer *kafka.Writer
+}
+
+func newKafkaSender(brokers []string, topic string, batchSize int) (*kafkaSender, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("log ship kafka brokers is empty")
+	}
+	return &kafkaSender{writer: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.LeastBytes{},
+		BatchSize:    batchSize,
+		BatchTimeout: 10 * time.Millisecond,
+		RequiredAcks: kafka.RequireOne,
+		Compression:  kafka.Snappy,
+	}}, nil
+}
+
+func (k *kafkaSender) send(ctx context.Context, lines [][]byte) error {
+	msgs := make([]kafka.Message, len(lines))
+	for i, line := range lines {
+		msgs[i] = kafka.Message{Value: line}
+	}
+	return k.writer.WriteMessages(ctx, msgs...)
+}
+
+func (k *kafkaSender) close() error {
+	return k.writer.Close()
+}
+
+// esSender 通过 Bulk API 写入按天滚动的索引（prefix-2006.01.02），独立客户端，不产生链路追踪
+type esSender struct {
+	client *elasticsearch.Client
+	prefix string
+}
+
+func newESSender(hosts []string, prefix string) (*esSender, error) {
+	addrs := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.TrimSpace(h)
+		if h != "" && !strings.HasPrefix(h, "http") {
+			h = "http://" + h
+		}
+		if h != "" {
+			addrs = append(addrs, h)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("log ship elasticsearch hosts is empty")
+	}
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: addrs})
+	if err != nil {
+		return nil, fmt.Errorf("create log ship elasticsearch client: %w", err)
+	}
+	return &esSender{client: client, prefix: prefix}, nil
+}
+
+func (e *esSender) send(ctx context.Context, lines [][]byte) error {
+	var body bytes.Buffer
+	for _, line := range lines {
+		body.WriteString(`{"index":{}}` + "\n")
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+	req := esapi.BulkRequest{
+		Index: e.prefix + "-" + time.Now().Format("2006.01.02"),
+		Body:  &body,
+	}
+	resp, err := req.Do(ctx, e.client)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.IsError() {
+		return fmt.Errorf("log ship bulk failed: %s", resp.String())
+	}
+	return nil
+}
+
+func (e *esSender) close() error {
+	return nil
+}
+
+// logstashSender 以 NDJSON 形式 POST 到 Logstash http input（codec 为 json_lines）
+type logstashSender struct {
+	url    string
+	client *http.Client
+}
+
+func newLogstashSender(url string) (*logstashSender, error) {
+	if url == "" {
+		return nil, fmt.Errorf("log ship logstash url is empty")
+	}
+	return &logstashSender{url: url, client: &http.Client{Timeout: sendTimeout}}, nil
+}
+
+func (l *logstashSender) send(ctx context.Context, lines [][]byte) error {
+	var body bytes.Buffer
+	for _, line := range lines {
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("log ship logstash status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (l *logstashSender) close() error {
+	return nil
+}
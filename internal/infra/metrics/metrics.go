@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// HTTPRequestDuration HTTP 请求耗时分布，route 为路由模板（未匹配路由记为 unmatched），避免标签基数过大
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "status"})
+
+	// HTTPSlowRequests 超过慢请求阈值的 HTTP 请求数
+	HTTPSlowRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_slow_requests_total",
+		Help: "HTTP requests exceeding the slow request threshold.",
+	}, []string{"method", "route"})
+
+	// SearchFallbacks ES 搜索失败、降级到数据库搜索的次数
+	SearchFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "search_fallback_total",
+		Help: "Video searches served by the database fallback because Elasticsearch failed.",
+	})
+
+	// SchedulerJobRuns 后台定时任务执行次数，status 为 success / failed / skipped（上一次仍在执行）
+	SchedulerJobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduler_job_runs_total",
+		Help: "Scheduled job runs by outcome.",
+	}, []string{"job", "status"})
+
+	// SchedulerJobDuration 后台定时任务执行耗时分布
+	SchedulerJobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scheduler_job_duration_seconds",
+		Help:    "Scheduled job run duration in seconds.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900, 1800, 3600},
+	}, []string{"job"})
+
+	// SchedulerJobLastSuccess 后台定时任务最近一次成功完成的 Unix 时间戳
+	SchedulerJobLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scheduler_job_last_success_timestamp_seconds",
+		Help: "Unix timestamp of the last successful run of each scheduled job.",
+	}, []string{"job"})
+)
+
+// Handler 以 Prometheus 文本格式输出所有指标（含 Go 运行时与进程指标）
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
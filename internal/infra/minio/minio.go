@@ -2,21 +2,30 @@ package minio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"strings"
 	"time"
 
 	"vida-go/internal/config"
+	"vida-go/internal/infra/tracing"
 	"vida-go/pkg/logger"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 var client *minio.Client
 
+// ErrObjectNotFound 对象不存在
+var ErrObjectNotFound = errors.New("minio object not found")
+
 // Init 初始化 MinIO 客户端并确保所有 Bucket 存在
 func Init(cfg *config.MinIOConfig) error {
 	var err error
@@ -32,15 +41,8 @@ func Init(cfg *config.MinIOConfig) error {
 	defer cancel()
 
 	for _, bucket := range cfg.Buckets {
-		exists, err := client.BucketExists(ctx, bucket)
-		if err != nil {
-			return fmt.Errorf("failed to check bucket %s: %w", bucket, err)
-		}
-		if !exists {
-			if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
-				return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
-			}
-			logger.Info("MinIO bucket created", zap.String("bucket", bucket))
+		if err := EnsureBucket(ctx, bucket); err != nil {
+			return err
 		}
 	}
 
@@ -62,18 +64,47 @@ func Init(cfg *config.MinIOConfig) error {
 	return nil
 }
 
+// EnsureBucket Bucket 不存在时创建
+func EnsureBucket(ctx context.Context, bucket string) error {
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket %s: %w", bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+		}
+		logger.Info("MinIO bucket created", zap.String("bucket", bucket))
+	}
+	return nil
+}
+
 // Get 获取 MinIO 客户端实例
 func Get() *minio.Client {
 	return client
 }
 
+// Ping 检查 MinIO 连通性
+func Ping(ctx context.Context) error {
+	if client == nil {
+		return fmt.Errorf("minio not initialized")
+	}
+	_, err := client.ListBuckets(ctx)
+	return err
+}
+
 // UploadFile 上传文件到指定 Bucket
 // 返回对象名（objectName）
 func UploadFile(ctx context.Context, bucket, objectName string, reader io.Reader, fileSize int64, contentType string) (string, error) {
+	ctx, span := startSpan(ctx, "minio.PutObject", bucket, objectName)
+	defer span.End()
+
 	_, err := client.PutObject(ctx, bucket, objectName, reader, fileSize, minio.PutObjectOptions{
 		ContentType: contentType,
 	})
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return "", fmt.Errorf("failed to upload to minio: %w", err)
 	}
 	return objectName, nil
@@ -81,6 +112,9 @@ func UploadFile(ctx context.Context, bucket, objectName string, reader io.Reader
 
 // GetPresignedURL 生成预签名下载 URL（有效期可配置）
 func GetPresignedURL(ctx context.Context, bucket, objectName string, expiry time.Duration) (string, error) {
+	ctx, span := startSpan(ctx, "minio.PresignedGetObject", bucket, objectName)
+	defer span.End()
+
 	reqParams := make(url.Values)
 	presignedURL, err := client.PresignedGetObject(ctx, bucket, objectName, expiry, reqParams)
 	if err != nil {
@@ -89,6 +123,150 @@ func GetPresignedURL(ctx context.Context, bucket, objectName string, expiry time
 	return presignedURL.String(), nil
 }
 
+// DownloadFile 下载对象到本地文件
+func DownloadFile(ctx context.Context, bucket, objectName, destPath string) error {
+	ctx, span := startSpan(ctx, "minio.FGetObject", bucket, objectName)
+	defer span.End()
+
+	if err := client.FGetObject(ctx, bucket, objectName, destPath, minio.GetObjectOptions{}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to download from minio: %w", err)
+	}
+	return nil
+}
+
+// CopyObject 在 MinIO 内复制对象，返回目标对象大小
+func CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string) (int64, error) {
+	ctx, span := startSpan(ctx, "minio.CopyObject", dstBucket, dstObject)
+	defer span.End()
+
+	info, err := client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: dstBucket, Object: dstObject},
+		minio.CopySrcOptions{Bucket: srcBucket, Object: srcObject},
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return 0, ErrObjectNotFound
+		}
+		return 0, fmt.Errorf("failed to copy minio object: %w", err)
+	}
+	return info.Size, nil
+}
+
+// OpenObject 打开对象用于读取，返回的 Object 支持 Seek/ReadAt，按需向 MinIO 发起 Range 请求。
+// 调用方负责 Close
+func OpenObject(ctx context.Context, bucket, objectName string) (*minio.Object, minio.ObjectInfo, error) {
+	ctx, span := startSpan(ctx, "minio.GetObject", bucket, objectName)
+	defer span.End()
+
+	obj, err := client.GetObject(ctx, bucket, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, minio.ObjectInfo{}, fmt.Errorf("failed to get object from minio: %w", err)
+	}
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, minio.ObjectInfo{}, ErrObjectNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, minio.ObjectInfo{}, fmt.Errorf("failed to stat minio object: %w", err)
+	}
+	return obj, info, nil
+}
+
+// ListObjects 递归列出 prefix 下的全部对象名
+func ListObjects(ctx context.Context, bucket, prefix string) ([]string, error) {
+	ctx, span := startSpan(ctx, "minio.ListObjects", bucket, prefix)
+	defer span.End()
+
+	var names []string
+	for obj := range client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			span.RecordError(obj.Err)
+			span.SetStatus(codes.Error, obj.Err.Error())
+			return nil, fmt.Errorf("failed to list minio objects: %w", obj.Err)
+		}
+		names = append(names, obj.Key)
+	}
+	return names, nil
+}
+
+// MoveObject 将对象移到另一个 Bucket（同名），storageClass 非空时按该存储类型写入；
+// 复制成功后删除源对象。源对象不存在时返回 ErrObjectNotFound
+func MoveObject(ctx context.Context, srcBucket, dstBucket, objectName, storageClass string) error {
+	ctx, span := startSpan(ctx, "minio.MoveObject", dstBucket, objectName)
+	defer span.End()
+
+	info, err := client.StatObject(ctx, srcBucket, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return ErrObjectNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to stat minio object: %w", err)
+	}
+
+	dst := minio.CopyDestOptions{Bucket: dstBucket, Object: objectName}
+	if storageClass != "" {
+		dst.ReplaceMetadata = true
+		dst.UserMetadata = map[string]string{
+			"Content-Type":        info.ContentType,
+			"X-Amz-Storage-Class": storageClass,
+		}
+	}
+	if _, err := client.CopyObject(ctx, dst, minio.CopySrcOptions{Bucket: srcBucket, Object: objectName}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to copy minio object: %w", err)
+	}
+	if err := client.RemoveObject(ctx, srcBucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to remove minio object: %w", err)
+	}
+	return nil
+}
+
+// RemoveObject 删除对象，对象不存在时不报错
+func RemoveObject(ctx context.Context, bucket, objectName string) error {
+	ctx, span := startSpan(ctx, "minio.RemoveObject", bucket, objectName)
+	defer span.End()
+
+	if err := client.RemoveObject(ctx, bucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to remove minio object: %w", err)
+	}
+	return nil
+}
+
+// ParsePublicURL 从 GetPublicURL 生成的地址中解析 Bucket 与对象名
+func ParsePublicURL(publicURL string) (bucket, objectName string, ok bool) {
+	u, err := url.Parse(publicURL)
+	if err != nil {
+		return "", "", false
+	}
+	bucket, objectName, ok = strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	return bucket, objectName, ok && bucket != "" && objectName != ""
+}
+
+// startSpan 为 MinIO 操作开启客户端 Span
+func startSpan(ctx context.Context, name, bucket, objectName string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("minio.bucket", bucket),
+			attribute.String("minio.object", objectName),
+		),
+	)
+}
+
 // GetPublicURL 生成公开访问 URL（需要 Bucket 设置为 public-read）
 func GetPublicURL(endpoint string, useSSL bool, bucket, objectName string) string {
 	scheme := "http"
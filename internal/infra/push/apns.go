@@ -0,0 +1,123 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"vida-go/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsProductionHost = "https://api.push.apple.com"
+	apnsSandboxHost    = "https://api.sandbox.push.apple.com"
+	apnsTokenTTL       = 50 * time.Minute // Apple 要求 20~60 分钟内刷新
+)
+
+// apnsSender 通过 APNs HTTP/2 接口推送，使用 .p8 密钥签发的令牌认证
+type apnsSender struct {
+	cfg    *config.APNsConfig
+	key    interface{}
+	host   string
+	client *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func newAPNsSender(cfg *config.APNsConfig) (*apnsSender, error) {
+	raw, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apns key: %w", err)
+	}
+	host := apnsSandboxHost
+	if cfg.Production {
+		host = apnsProductionHost
+	}
+	// 默认 Transport 通过 ALPN 协商 HTTP/2
+	return &apnsSender{cfg: cfg, key: key, host: host, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+func (s *apnsSender) Send(ctx context.Context, token string, msg *Message) error {
+	authToken, err := s.providerToken()
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{"title": msg.Title, "body": msg.Body},
+			"sound": "default",
+		},
+	}
+	for k, v := range msg.Data {
+		body[k] = v
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.host+"/3/device/"+token, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("authorization", "bearer "+authToken)
+	req.Header.Set("apns-topic", s.cfg.Topic)
+	req.Header.Set("apns-push-type", "alert")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("apns request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	_ = json.Unmarshal(raw, &apnsErr)
+	if resp.StatusCode == http.StatusGone || apnsErr.Reason == "BadDeviceToken" || apnsErr.Reason == "Unregistered" {
+		return ErrInvalidToken
+	}
+	return fmt.Errorf("apns returned %d: %s", resp.StatusCode, apnsErr.Reason)
+}
+
+// providerToken 返回缓存的 ES256 认证令牌，过期前重新签发
+func (s *apnsSender) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Since(s.issuedAt) < apnsTokenTTL {
+		return s.token, nil
+	}
+
+	now := time.Now()
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": s.cfg.TeamID,
+		"iat": now.Unix(),
+	})
+	t.Header["kid"] = s.cfg.KeyID
+	signed, err := t.SignedString(s.key)
+	if err != nil {
+		return "", err
+	}
+	s.token = signed
+	s.issuedAt = now
+	return s.token, nil
+}
@@ -0,0 +1,79 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// 设备平台
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+)
+
+// ErrInvalidToken 设备令牌已失效（应用卸载、令牌过期等），调用方应删除该令牌
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Message 推送消息
+type Message struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Sender 单个推送通道（FCM / APNs）
+type Sender interface {
+	Send(ctx context.Context, token string, msg *Message) error
+}
+
+var senders = map[string]Sender{}
+
+// Init 按配置初始化推送通道：Android 走 FCM，iOS 走 APNs
+func Init(cfg *config.PushConfig) error {
+	senders = map[string]Sender{}
+	if !cfg.Enabled {
+		logger.Info("Push delivery disabled")
+		return nil
+	}
+
+	if cfg.FCM.Enabled {
+		s, err := newFCMSender(&cfg.FCM)
+		if err != nil {
+			return fmt.Errorf("failed to init fcm sender: %w", err)
+		}
+		senders[PlatformAndroid] = s
+	}
+	if cfg.APNs.Enabled {
+		s, err := newAPNsSender(&cfg.APNs)
+		if err != nil {
+			return fmt.Errorf("failed to init apns sender: %w", err)
+		}
+		senders[PlatformIOS] = s
+	}
+
+	logger.Info("Push delivery initialized",
+		zap.Bool("fcm", cfg.FCM.Enabled),
+		zap.Bool("apns", cfg.APNs.Enabled),
+	)
+	return nil
+}
+
+// Enabled 是否有可用的推送通道
+func Enabled() bool {
+	return len(senders) > 0
+}
+
+// Send 向指定平台的设备发送推送
+func Send(ctx context.Context, platform, token string, msg *Message) error {
+	s, ok := senders[platform]
+	if !ok {
+		return fmt.Errorf("push platform %q not configured", platform)
+	}
+	return s.Send(ctx, token, msg)
+}
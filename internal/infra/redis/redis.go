@@ -8,16 +8,30 @@ import (
 	"vida-go/internal/config"
 	"vida-go/pkg/logger"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
 var Client *redis.Client
 
-// Init 初始化Redis客户端
+// embedded 本地开发时使用的进程内 Redis
+var embedded *miniredis.Miniredis
+
+// Init 初始化Redis客户端，配置 embedded 时启动进程内的内存 Redis
 func Init(cfg *config.RedisConfig) error {
+	addr := cfg.Addr()
+	if cfg.Embedded {
+		var err error
+		if embedded, err = miniredis.Run(); err != nil {
+			return fmt.Errorf("failed to start embedded redis: %w", err)
+		}
+		addr = embedded.Addr()
+		logger.Info("Embedded redis started", zap.String("addr", addr))
+	}
+
 	Client = redis.NewClient(&redis.Options{
-		Addr:     cfg.Addr(),
+		Addr:     addr,
 		Password: cfg.Password,
 		DB:       cfg.DB,
 		PoolSize: cfg.PoolSize,
@@ -32,7 +46,7 @@ func Init(cfg *config.RedisConfig) error {
 	}
 
 	logger.Info("Redis connected",
-		zap.String("addr", cfg.Addr()),
+		zap.String("addr", addr),
 		zap.Int("db", cfg.DB),
 		zap.Int("pool_size", cfg.PoolSize),
 	)
@@ -40,13 +54,25 @@ func Init(cfg *config.RedisConfig) error {
 	return nil
 }
 
+// Ping 检查Redis连通性
+func Ping(ctx context.Context) error {
+	if Client == nil {
+		return fmt.Errorf("redis not initialized")
+	}
+	return Client.Ping(ctx).Err()
+}
+
 // Close 关闭Redis连接
 func Close() error {
 	if Client == nil {
 		return nil
 	}
 	logger.Info("Redis connection closed")
-	return Client.Close()
+	err := Client.Close()
+	if embedded != nil {
+		embedded.Close()
+	}
+	return err
 }
 
 // Get 获取Redis客户端实例
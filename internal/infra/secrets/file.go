@@ -0,0 +1,38 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileProvider 从目录读取密钥，每个密钥一个文件，文件名为密钥名；
+// 用于云厂商密钥管理服务通过 CSI 驱动挂载的密钥以及 Docker/Kubernetes secrets
+type fileProvider struct {
+	dir string
+}
+
+func (f *fileProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	keys := []string{
+		KeyDatabasePassword, KeyRedisPassword, KeyMinIOAccessKey,
+		KeyMinIOSecretKey, KeyJWTSecret, KeyJWTKeyID,
+	}
+
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(filepath.Join(f.dir, key))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		values[key] = strings.TrimSpace(string(data))
+	}
+	return values, nil
+}
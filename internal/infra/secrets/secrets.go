@@ -0,0 +1,144 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/logger"
+	"vida-go/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// 密钥后端中的密钥名
+const (
+	KeyDatabasePassword = "database_password"
+	KeyRedisPassword    = "redis_password"
+	KeyMinIOAccessKey   = "minio_access_key"
+	KeyMinIOSecretKey   = "minio_secret_key"
+	KeyJWTSecret        = "jwt_secret"
+	KeyJWTKeyID         = "jwt_key_id"
+)
+
+// fetchTimeout 单次读取密钥的超时时间
+const fetchTimeout = 10 * time.Second
+
+// provider 密钥后端，返回密钥名到值的映射
+type provider interface {
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+var (
+	cfg *config.SecretsConfig
+	p   provider
+)
+
+// Init 初始化密钥后端，未配置 provider 时不启用
+func Init(c *config.SecretsConfig) error {
+	cfg = c
+	if !Enabled() {
+		return nil
+	}
+
+	switch c.Provider {
+	case "vault":
+		v, err := newVaultProvider(&c.Vault)
+		if err != nil {
+			return err
+		}
+		p = v
+	case "file":
+		if c.Dir == "" {
+			return fmt.Errorf("secrets dir is required for file provider")
+		}
+		p = &fileProvider{dir: c.Dir}
+	default:
+		return fmt.Errorf("unknown secrets provider: %s", c.Provider)
+	}
+
+	logger.Info("Secrets backend enabled", zap.String("provider", c.Provider))
+	return nil
+}
+
+// Enabled 是否启用密钥后端
+func Enabled() bool {
+	return cfg != nil && cfg.Enabled()
+}
+
+// Apply 从密钥后端读取数据库、Redis、MinIO、JWT 密钥并覆盖配置，
+// 需在初始化各基础设施客户端之前调用；后端中不存在的密钥保留配置文件中的值
+func Apply(ctx context.Context, c *config.Config) error {
+	if !Enabled() {
+		return nil
+	}
+
+	values, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	set := func(dst *string, key string) {
+		if v, ok := values[key]; ok && v != "" {
+			*dst = v
+		}
+	}
+	set(&c.Database.Password, KeyDatabasePassword)
+	set(&c.Redis.Password, KeyRedisPassword)
+	set(&c.MinIO.AccessKey, KeyMinIOAccessKey)
+	set(&c.MinIO.SecretKey, KeyMinIOSecretKey)
+	set(&c.JWT.Secret, KeyJWTSecret)
+	set(&c.JWT.KeyID, KeyJWTKeyID)
+
+	utils.SetSigningKey(c.JWT.KeyID, c.JWT.Secret)
+	logger.Info("Secrets loaded", zap.Int("count", len(values)))
+	return nil
+}
+
+// StartRotation 定期重新读取 JWT 签名密钥，密钥变化后新 Token 使用新密钥签名，
+// 旧密钥在一个 Token 有效期内仍可校验。阻塞直到 ctx 取消
+func StartRotation(ctx context.Context) {
+	if !Enabled() || cfg.RotateDuration() <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(cfg.RotateDuration())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rotateJWTKey(ctx)
+		}
+	}
+}
+
+func rotateJWTKey(ctx context.Context) {
+	values, err := fetch(ctx)
+	if err != nil {
+		logger.Warn("Refresh secrets failed", zap.Error(err))
+		return
+	}
+	secret := values[KeyJWTSecret]
+	if secret == "" {
+		return
+	}
+
+	if utils.SetSigningKey(values[KeyJWTKeyID], secret) {
+		logger.Info("JWT signing key rotated", zap.String("key_id", values[KeyJWTKeyID]))
+	}
+}
+
+func fetch(ctx context.Context) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	values, err := p.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch secrets from %s: %w", cfg.Provider, err)
+	}
+	return values, nil
+}
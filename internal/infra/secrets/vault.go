@@ -0,0 +1,80 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"vida-go/internal/config"
+)
+
+// vaultProvider 通过 HTTP API 读取 Vault KV v2 中的一条密钥
+type vaultProvider struct {
+	url       string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+func newVaultProvider(c *config.VaultConfig) (*vaultProvider, error) {
+	if c.Address == "" || c.Path == "" {
+		return nil, fmt.Errorf("vault address and path are required")
+	}
+	token := c.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("vault token is required")
+	}
+
+	return &vaultProvider{
+		url:       fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimRight(c.Address, "/"), c.MountPath(), strings.Trim(c.Path, "/")),
+		token:     token,
+		namespace: c.Namespace,
+		client:    &http.Client{Timeout: fetchTimeout},
+	}, nil
+}
+
+func (v *vaultProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode vault response: %w", err)
+	}
+
+	values := make(map[string]string, len(payload.Data.Data))
+	for k, val := range payload.Data.Data {
+		if s, ok := val.(string); ok {
+			values[k] = s
+		}
+	}
+	return values, nil
+}
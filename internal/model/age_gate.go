@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// 年龄限制标记来源
+const (
+	AgeRestrictionAuthor    = "author"
+	AgeRestrictionModerator = "moderator"
+)
+
+// AgeRestricted 视频是否设置了年龄限制
+func (v *Video) AgeRestricted() bool {
+	return v.AgeRestriction != ""
+}
+
+// AgeAt 用户在 now 时的周岁年龄，未设置出生日期返回 -1
+func (u *User) AgeAt(now time.Time) int {
+	if u.BirthDate == nil {
+		return -1
+	}
+	b := u.BirthDate.UTC()
+	now = now.UTC()
+	age := now.Year() - b.Year()
+	if now.Month() < b.Month() || (now.Month() == b.Month() && now.Day() < b.Day()) {
+		age--
+	}
+	return age
+}
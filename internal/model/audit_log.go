@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// AuditLog 审计日志（仅追加，不提供修改和删除）
+type AuditLog struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement;comment:审计日志ID" json:"id"`
+	ActorID    int64     `gorm:"not null;index:idx_audit_logs_actor_id;comment:操作人ID" json:"actor_id"`
+	ActorRole  string    `gorm:"size:32;not null;default:'';comment:操作人角色" json:"actor_role"`
+	Action     string    `gorm:"size:64;not null;index:idx_audit_logs_action;comment:操作类型" json:"action"`
+	TargetType string    `gorm:"size:32;not null;index:idx_audit_logs_target,priority:1;comment:目标类型" json:"target_type"`
+	TargetID   int64     `gorm:"not null;index:idx_audit_logs_target,priority:2;comment:目标ID" json:"target_id"`
+	Reason     string    `gorm:"size:500;not null;default:'';comment:操作原因" json:"reason"`
+	IP         string    `gorm:"size:64;not null;default:'';comment:操作来源IP" json:"ip"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index:idx_audit_logs_created_at;comment:操作时间" json:"created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
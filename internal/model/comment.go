@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Comment 评论模型
 type Comment struct {
@@ -10,9 +14,12 @@ type Comment struct {
 	Content   string    `gorm:"type:text;not null;comment:评论内容" json:"content"`
 	ParentID  *int64    `gorm:"index:idx_comments_parent_id;comment:父评论ID" json:"parent_id"`
 	LikeCount int64     `gorm:"default:0;comment:评论点赞数" json:"like_count"`
+	IsHidden  bool      `gorm:"not null;default:false;index:idx_comments_is_hidden;comment:是否被审核隐藏" json:"is_hidden"`
 	CreatedAt time.Time `gorm:"autoCreateTime;index:idx_comments_created_at;index:idx_composite_video_created,priority:2;comment:评论时间" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
 
+	DeletedAt gorm.DeletedAt `gorm:"index;comment:删除时间" json:"-"`
+
 	// 关联关系
 	User    User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Video   Video     `gorm:"foreignKey:VideoID" json:"video,omitempty"`
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// DeviceToken 移动端推送设备令牌
+type DeviceToken struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:设备ID" json:"id"`
+	UserID    int64     `gorm:"not null;index:idx_device_tokens_user_id;comment:用户ID" json:"user_id"`
+	Platform  string    `gorm:"size:16;not null;comment:平台（ios/android）" json:"platform"`
+	Token     string    `gorm:"size:512;not null;uniqueIndex;comment:推送令牌" json:"token"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:注册时间" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:最近活跃时间" json:"updated_at"`
+}
+
+func (DeviceToken) TableName() string {
+	return "device_tokens"
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// 发现页栏目
+const (
+	ExploreSectionFeatured    = "featured"     // 编辑推荐，只由推荐位组成
+	ExploreSectionTrending    = "trending"     // 热门视频
+	ExploreSectionNewCreators = "new_creators" // 新晋创作者
+	ExploreSectionCategory    = "category"     // 分类热门视频
+)
+
+// ExploreSlot 发现页推荐位：管理员指定的视频按 Position 排在栏目最前，仅在生效时间范围内展示
+type ExploreSlot struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement;comment:推荐位ID" json:"id"`
+	Section   string     `gorm:"size:20;not null;index:idx_explore_slots_section;comment:栏目（featured/trending/category）" json:"section"`
+	Category  string     `gorm:"size:50;not null;default:'';comment:分类名（分类栏目）" json:"category"`
+	VideoID   int64      `gorm:"not null;comment:视频ID" json:"video_id"`
+	Position  int        `gorm:"not null;default:0;comment:排序（越小越靠前）" json:"position"`
+	Note      string     `gorm:"size:200;not null;default:'';comment:备注" json:"note"`
+	StartsAt  *time.Time `gorm:"comment:开始展示时间（为空表示立即）" json:"starts_at"`
+	EndsAt    *time.Time `gorm:"comment:结束展示时间（为空表示不结束）" json:"ends_at"`
+	CreatedBy int64      `gorm:"not null;comment:创建者ID" json:"created_by"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (ExploreSlot) TableName() string {
+	return "explore_slots"
+}
@@ -2,11 +2,23 @@ package model
 
 import "time"
 
-// Favorite 点赞/收藏模型
+// 表态类型，like 为原有的点赞
+const (
+	ReactionLike  = "like"
+	ReactionLove  = "love"
+	ReactionLaugh = "laugh"
+	ReactionWow   = "wow"
+)
+
+// Reactions 全部表态类型
+var Reactions = []string{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow}
+
+// Favorite 点赞/表态模型，每个用户对每个视频只保留一种表态
 type Favorite struct {
 	ID        int64     `gorm:"primaryKey;autoIncrement;comment:点赞记录ID" json:"id"`
 	UserID    int64     `gorm:"not null;uniqueIndex:uq_user_video_favorite;index:idx_favorites_user_id;comment:点赞用户ID" json:"user_id"`
 	VideoID   int64     `gorm:"not null;uniqueIndex:uq_user_video_favorite;index:idx_favorites_video_id;comment:被点赞视频ID" json:"video_id"`
+	Reaction  string    `gorm:"size:20;not null;default:'like';comment:表态类型（like/love/laugh/wow）" json:"reaction"`
 	CreatedAt time.Time `gorm:"autoCreateTime;index:idx_favorites_created_at;comment:点赞时间" json:"created_at"`
 
 	// 关联关系
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// InviteCode 注册邀请码。InviterID 为邀请人，使用该码注册的用户记为由其邀请
+type InviteCode struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement;comment:邀请码ID" json:"id"`
+	Code      string     `gorm:"size:32;not null;uniqueIndex:uq_invite_codes_code;comment:邀请码" json:"code"`
+	InviterID int64      `gorm:"not null;index:idx_invite_codes_inviter_id;comment:邀请人ID" json:"inviter_id"`
+	CreatedBy int64      `gorm:"not null;comment:生成该码的管理员ID" json:"created_by"`
+	MaxUses   int        `gorm:"not null;default:1;comment:最多可使用次数（0 表示不限）" json:"max_uses"`
+	UsedCount int        `gorm:"not null;default:0;comment:已使用次数" json:"used_count"`
+	Note      string     `gorm:"size:200;not null;default:'';comment:备注" json:"note"`
+	ExpiresAt *time.Time `gorm:"comment:过期时间（为空表示不过期）" json:"expires_at"`
+	RevokedAt *time.Time `gorm:"comment:作废时间" json:"revoked_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+
+	Inviter User `gorm:"foreignKey:InviterID" json:"inviter,omitempty"`
+}
+
+func (InviteCode) TableName() string {
+	return "invite_codes"
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// 直播场次状态
+const (
+	LiveSessionLive  = "live"
+	LiveSessionEnded = "ended"
+)
+
+// LiveChannel 用户的直播频道。StreamKey 为推流码（仅本人可见，可重置），
+// PlaybackID 为公开的播放 ID，播放地址中不暴露推流码
+type LiveChannel struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement;comment:频道ID" json:"id"`
+	UserID     int64     `gorm:"not null;uniqueIndex:uq_live_channels_user_id;comment:主播ID" json:"user_id"`
+	StreamKey  string    `gorm:"size:64;not null;uniqueIndex:uq_live_channels_stream_key;comment:推流码" json:"-"`
+	PlaybackID string    `gorm:"size:32;not null;uniqueIndex:uq_live_channels_playback_id;comment:公开播放ID" json:"playback_id"`
+	Title      string    `gorm:"size:200;not null;default:'';comment:直播标题" json:"title"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+func (LiveChannel) TableName() string {
+	return "live_channels"
+}
+
+// LiveSession 一场直播，媒体服务器开播回调时创建，下播回调时结束；
+// 录像转码生成的普通视频记录在 VideoID
+type LiveSession struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement;comment:场次ID" json:"id"`
+	ChannelID  int64      `gorm:"not null;index:idx_live_sessions_channel_id;comment:频道ID" json:"channel_id"`
+	UserID     int64      `gorm:"not null;index:idx_live_sessions_user_id;comment:主播ID" json:"user_id"`
+	Title      string     `gorm:"size:200;not null;default:'';comment:直播标题" json:"title"`
+	PlaybackID string     `gorm:"size:32;not null;comment:公开播放ID" json:"playback_id"`
+	Status     string     `gorm:"size:20;not null;default:'live';index:idx_live_sessions_status;comment:状态" json:"status"`
+	ClientIP   string     `gorm:"size:45;not null;default:'';comment:推流端IP" json:"-"`
+	StartedAt  time.Time  `gorm:"not null;index:idx_live_sessions_started_at;comment:开播时间" json:"started_at"`
+	EndedAt    *time.Time `gorm:"comment:下播时间" json:"ended_at"`
+	VideoID    *int64     `gorm:"comment:录像生成的视频ID" json:"video_id"`
+
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (LiveSession) TableName() string {
+	return "live_sessions"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// LoginEvent 登录记录。Device 为设备标识（客户端上报的设备 ID，未上报时取 User-Agent 摘要），
+// 与历史记录比对判断是否为新设备、新地点登录
+type LoginEvent struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement;comment:记录ID" json:"id"`
+	UserID      int64      `gorm:"not null;index:idx_login_events_user_id;comment:用户ID" json:"user_id"`
+	IP          string     `gorm:"size:45;not null;default:'';comment:登录IP" json:"ip"`
+	UserAgent   string     `gorm:"size:500;not null;default:'';comment:User-Agent" json:"user_agent"`
+	Device      string     `gorm:"size:80;not null;default:'';comment:设备标识" json:"device"`
+	Country     string     `gorm:"size:2;not null;default:'';comment:IP 归属国家/地区" json:"country"`
+	NewDevice   bool       `gorm:"not null;default:false;comment:是否首次出现的设备" json:"new_device"`
+	NewLocation bool       `gorm:"not null;default:false;comment:是否首次出现的国家/地区" json:"new_location"`
+	ReportedAt  *time.Time `gorm:"comment:用户标记为非本人登录的时间" json:"reported_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime;index:idx_login_events_created_at;comment:登录时间" json:"created_at"`
+}
+
+func (LoginEvent) TableName() string {
+	return "login_events"
+}
+
+// Suspicious 是否为新设备或新地点登录
+func (e *LoginEvent) Suspicious() bool {
+	return e.NewDevice || e.NewLocation
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// MembershipTier 创作者设置的付费会员等级。Level 越高权益越多，高等级会员可以观看低等级的会员专属视频；
+// 下架后不能再加入，已有会员在到期前不受影响
+type MembershipTier struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement;comment:会员等级ID" json:"id"`
+	CreatorID   int64      `gorm:"not null;index:idx_membership_tiers_creator_id;comment:创作者ID" json:"creator_id"`
+	Level       int        `gorm:"not null;comment:等级（越大越高）" json:"level"`
+	Name        string     `gorm:"size:50;not null;comment:名称" json:"name"`
+	Description string     `gorm:"size:500;not null;default:'';comment:权益说明" json:"description"`
+	Price       int64      `gorm:"not null;comment:每期价格（金币）" json:"price"`
+	Badge       string     `gorm:"size:20;not null;default:'';comment:会员徽章" json:"badge"`
+	ArchivedAt  *time.Time `gorm:"comment:下架时间" json:"archived_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+func (MembershipTier) TableName() string {
+	return "membership_tiers"
+}
+
+// Membership 用户在某位创作者处的会员资格，每位创作者最多一条，ExpiresAt 之前有效
+type Membership struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:会员资格ID" json:"id"`
+	CreatorID int64     `gorm:"not null;uniqueIndex:uq_memberships_creator_user;comment:创作者ID" json:"creator_id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:uq_memberships_creator_user;index:idx_memberships_user_id;comment:会员用户ID" json:"user_id"`
+	TierID    int64     `gorm:"not null;index:idx_memberships_tier_id;comment:会员等级ID" json:"tier_id"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_memberships_expires_at;comment:到期时间" json:"expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:首次加入时间" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+
+	Tier MembershipTier `gorm:"foreignKey:TierID" json:"tier,omitempty"`
+	User User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (Membership) TableName() string {
+	return "memberships"
+}
+
+// IsActive 是否在有效期内
+func (m *Membership) IsActive(now time.Time) bool {
+	return now.Before(m.ExpiresAt)
+}
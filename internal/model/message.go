@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Conversation 私信会话（两名用户之间唯一，UserAID < UserBID）
+type Conversation struct {
+	ID                 int64      `gorm:"primaryKey;autoIncrement;comment:会话ID" json:"id"`
+	UserAID            int64      `gorm:"not null;uniqueIndex:idx_conversations_pair,priority:1;comment:参与者A（ID较小）" json:"user_a_id"`
+	UserBID            int64      `gorm:"not null;uniqueIndex:idx_conversations_pair,priority:2;index:idx_conversations_user_b;comment:参与者B（ID较大）" json:"user_b_id"`
+	UserAUnread        int64      `gorm:"not null;default:0;comment:参与者A未读数" json:"user_a_unread"`
+	UserBUnread        int64      `gorm:"not null;default:0;comment:参与者B未读数" json:"user_b_unread"`
+	LastMessageID      int64      `gorm:"not null;default:0;comment:最后一条消息ID" json:"last_message_id"`
+	LastSenderID       int64      `gorm:"not null;default:0;comment:最后一条消息发送者" json:"last_sender_id"`
+	LastMessageContent string     `gorm:"size:500;not null;default:'';comment:最后一条消息摘要" json:"last_message_content"`
+	LastMessageAt      *time.Time `gorm:"index:idx_conversations_last_message_at;comment:最后消息时间" json:"last_message_at"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (Conversation) TableName() string {
+	return "conversations"
+}
+
+// PeerID 返回会话中另一名参与者的 ID
+func (c *Conversation) PeerID(userID int64) int64 {
+	if c.UserAID == userID {
+		return c.UserBID
+	}
+	return c.UserAID
+}
+
+// HasMember 判断用户是否为会话参与者
+func (c *Conversation) HasMember(userID int64) bool {
+	return c.UserAID == userID || c.UserBID == userID
+}
+
+// UnreadFor 返回用户在该会话中的未读数
+func (c *Conversation) UnreadFor(userID int64) int64 {
+	if c.UserAID == userID {
+		return c.UserAUnread
+	}
+	return c.UserBUnread
+}
+
+// Message 私信消息
+type Message struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement;comment:消息ID" json:"id"`
+	ConversationID int64     `gorm:"not null;index:idx_messages_conversation,priority:1;comment:会话ID" json:"conversation_id"`
+	SenderID       int64     `gorm:"not null;comment:发送者ID" json:"sender_id"`
+	Content        string    `gorm:"type:text;not null;comment:消息内容（已过滤敏感词）" json:"content"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;comment:发送时间" json:"created_at"`
+}
+
+func (Message) TableName() string {
+	return "messages"
+}
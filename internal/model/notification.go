@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Notification 站内通知
+type Notification struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:通知ID" json:"id"`
+	UserID    int64     `gorm:"not null;index:idx_notifications_user_read,priority:1;comment:接收用户ID" json:"user_id"`
+	Type      string    `gorm:"size:32;not null;comment:通知类型" json:"type"`
+	ActorID   int64     `gorm:"not null;default:0;comment:触发用户ID（系统通知为0）" json:"actor_id"`
+	VideoID   *int64    `gorm:"comment:关联视频ID" json:"video_id"`
+	CommentID *int64    `gorm:"comment:关联评论ID" json:"comment_id"`
+	Content   string    `gorm:"size:500;not null;default:'';comment:通知内容" json:"content"`
+	IsRead    bool      `gorm:"not null;default:false;index:idx_notifications_user_read,priority:2;comment:是否已读" json:"is_read"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+
+	// 关联关系
+	Actor User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
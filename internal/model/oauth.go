@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// OAuthClient 第三方应用（OAuth2 客户端），由用户注册。密钥只保存 SHA-256 摘要
+type OAuthClient struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement;comment:应用ID" json:"id"`
+	ClientID     string     `gorm:"size:64;not null;uniqueIndex:uq_oauth_clients_client_id;comment:客户端标识" json:"client_id"`
+	SecretHash   string     `gorm:"size:64;not null;comment:客户端密钥摘要" json:"-"`
+	Name         string     `gorm:"size:100;not null;comment:应用名称" json:"name"`
+	OwnerID      int64      `gorm:"not null;index:idx_oauth_clients_owner_id;comment:注册应用的用户ID" json:"owner_id"`
+	RedirectURIs []string   `gorm:"type:text;serializer:json;comment:允许的授权回调地址" json:"redirect_uris"`
+	Scopes       []string   `gorm:"type:text;serializer:json;comment:应用可申请的权限范围" json:"scopes"`
+	RevokedAt    *time.Time `gorm:"comment:作废时间" json:"revoked_at"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// OAuthAuthorizationCode 授权码：用户同意授权后签发，只能换取一次访问令牌。授权码只保存 SHA-256 摘要
+type OAuthAuthorizationCode struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement;comment:授权码ID" json:"id"`
+	CodeHash      string     `gorm:"size:64;not null;uniqueIndex:uq_oauth_codes_code_hash;comment:授权码摘要" json:"-"`
+	ClientID      int64      `gorm:"not null;index:idx_oauth_codes_client_id;comment:应用ID" json:"client_id"`
+	UserID        int64      `gorm:"not null;comment:授权用户ID" json:"user_id"`
+	RedirectURI   string     `gorm:"size:500;not null;comment:授权回调地址" json:"redirect_uri"`
+	Scope         string     `gorm:"size:200;not null;comment:授权的权限范围（空格分隔）" json:"scope"`
+	CodeChallenge string     `gorm:"size:128;not null;default:'';comment:PKCE code_challenge（S256）" json:"-"`
+	ExpiresAt     time.Time  `gorm:"not null;comment:过期时间" json:"expires_at"`
+	UsedAt        *time.Time `gorm:"comment:换取令牌时间" json:"used_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
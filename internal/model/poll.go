@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// VideoPoll 视频投票，每个视频最多一个，到截止时间后不再接受投票
+type VideoPoll struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:投票ID" json:"id"`
+	VideoID   int64     `gorm:"not null;uniqueIndex:uq_video_polls_video_id;comment:视频ID" json:"video_id"`
+	Question  string    `gorm:"size:200;not null;comment:问题" json:"question"`
+	EndsAt    time.Time `gorm:"not null;comment:截止时间" json:"ends_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+
+	Options []VideoPollOption `gorm:"foreignKey:PollID" json:"options,omitempty"`
+}
+
+func (VideoPoll) TableName() string {
+	return "video_polls"
+}
+
+// IsClosed 是否已截止
+func (p *VideoPoll) IsClosed(now time.Time) bool {
+	return !now.Before(p.EndsAt)
+}
+
+// VideoPollOption 投票选项
+type VideoPollOption struct {
+	ID       int64  `gorm:"primaryKey;autoIncrement;comment:选项ID" json:"id"`
+	PollID   int64  `gorm:"not null;index:idx_video_poll_options_poll_id;comment:投票ID" json:"poll_id"`
+	Position int    `gorm:"not null;default:0;comment:排列顺序" json:"position"`
+	Text     string `gorm:"size:80;not null;comment:选项内容" json:"text"`
+}
+
+func (VideoPollOption) TableName() string {
+	return "video_poll_options"
+}
+
+// VideoPollVote 用户的投票记录，每人每个投票只能投一次
+type VideoPollVote struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:投票记录ID" json:"id"`
+	PollID    int64     `gorm:"not null;uniqueIndex:uq_video_poll_vote;comment:投票ID" json:"poll_id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:uq_video_poll_vote;index:idx_video_poll_votes_user_id;comment:用户ID" json:"user_id"`
+	OptionID  int64     `gorm:"not null;index:idx_video_poll_votes_option_id;comment:选项ID" json:"option_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:投票时间" json:"created_at"`
+}
+
+func (VideoPollVote) TableName() string {
+	return "video_poll_votes"
+}
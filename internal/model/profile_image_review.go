@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// 主页图片类型
+const (
+	ProfileImageAvatar     = "avatar"
+	ProfileImageBackground = "background"
+)
+
+// 主页图片审核状态
+const (
+	ProfileImagePending    = "pending"    // 待审核，用户主页仍展示旧图
+	ProfileImageApproved   = "approved"   // 已通过并上线
+	ProfileImageRejected   = "rejected"   // 已驳回，恢复为旧图
+	ProfileImageSuperseded = "superseded" // 审核前用户又上传了新图
+)
+
+// ProfileImageReview 头像、主页背景图的审核记录。新图先由 Agent 服务审核，
+// Agent 无法判定或未启用时等待版主处理；PreviousURL 为提交时正在使用的图片，驳回时恢复
+type ProfileImageReview struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement;comment:审核记录ID" json:"id"`
+	UserID      int64      `gorm:"not null;index:idx_profile_image_reviews_user_id;comment:用户ID" json:"user_id"`
+	Kind        string     `gorm:"size:20;not null;comment:图片类型 avatar/background" json:"kind"`
+	ImageURL    string     `gorm:"size:500;not null;comment:新图片地址" json:"image_url"`
+	PreviousURL *string    `gorm:"size:500;comment:提交时正在使用的图片地址" json:"previous_url"`
+	Status      string     `gorm:"size:20;not null;default:'pending';index:idx_profile_image_reviews_status;comment:审核状态" json:"status"`
+	Reason      string     `gorm:"size:500;not null;default:'';comment:驳回原因" json:"reason"`
+	ReviewedBy  *int64     `gorm:"comment:处理的版主ID（为空表示由 Agent 服务处理）" json:"reviewed_by"`
+	ReviewedAt  *time.Time `gorm:"comment:处理时间" json:"reviewed_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime;comment:提交时间" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (ProfileImageReview) TableName() string {
+	return "profile_image_reviews"
+}
+
+// Column 图片对应的用户表字段
+func (r *ProfileImageReview) Column() string {
+	return ProfileImageColumn(r.Kind)
+}
+
+// ProfileImageColumn 图片类型对应的用户表字段
+func ProfileImageColumn(kind string) string {
+	if kind == ProfileImageBackground {
+		return "background_image"
+	}
+	return "avatar"
+}
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// 举报对象类型
+const (
+	ReportTargetUser    = "user"
+	ReportTargetVideo   = "video"
+	ReportTargetComment = "comment"
+)
+
+// 举报处理状态
+const (
+	ReportStatusPending   = "pending"   // 待处理
+	ReportStatusResolved  = "resolved"  // 已处理（举报成立）
+	ReportStatusDismissed = "dismissed" // 已驳回
+)
+
+// 举报原因
+const (
+	ReportReasonSpam          = "spam"          // 垃圾信息、引流
+	ReportReasonHarassment    = "harassment"    // 骚扰、辱骂
+	ReportReasonHate          = "hate"          // 仇恨言论
+	ReportReasonImpersonation = "impersonation" // 冒充他人
+	ReportReasonScam          = "scam"          // 诈骗
+	ReportReasonInappropriate = "inappropriate" // 头像、昵称、简介违规
+	ReportReasonUnderage      = "underage"      // 疑似未成年人
+	ReportReasonOther         = "other"
+)
+
+// Report 用户举报。TargetUserID 为被举报内容所属的用户（举报用户本人时与 TargetID 相同），
+// 用于跨内容类型统计同一用户收到的举报
+type Report struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement;comment:举报ID" json:"id"`
+	ReporterID   int64      `gorm:"not null;uniqueIndex:uq_report_reporter_target,priority:1;comment:举报人ID" json:"reporter_id"`
+	TargetType   string     `gorm:"size:20;not null;uniqueIndex:uq_report_reporter_target,priority:2;comment:举报对象类型" json:"target_type"`
+	TargetID     int64      `gorm:"not null;uniqueIndex:uq_report_reporter_target,priority:3;comment:举报对象ID" json:"target_id"`
+	TargetUserID int64      `gorm:"not null;index:idx_reports_target_user_id;comment:被举报用户ID" json:"target_user_id"`
+	Reason       string     `gorm:"size:32;not null;comment:举报原因" json:"reason"`
+	Detail       string     `gorm:"size:500;not null;default:'';comment:补充说明" json:"detail"`
+	Status       string     `gorm:"size:20;not null;default:'pending';index:idx_reports_status;comment:处理状态" json:"status"`
+	Escalated    bool       `gorm:"not null;default:false;comment:是否已升级为优先审核" json:"escalated"`
+	ReviewedAt   *time.Time `gorm:"comment:处理时间" json:"reviewed_at"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime;index:idx_reports_created_at;comment:举报时间" json:"created_at"`
+
+	Reporter   User `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+	TargetUser User `gorm:"foreignKey:TargetUserID" json:"target_user,omitempty"`
+}
+
+func (Report) TableName() string {
+	return "reports"
+}
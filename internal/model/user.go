@@ -1,5 +1,11 @@
 package model
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 // User 用户模型
 type User struct {
 	ID              int64   `gorm:"primaryKey;autoIncrement;comment:用户标识" json:"id"`
@@ -12,7 +18,29 @@ type User struct {
 	Avatar          *string `gorm:"size:500;comment:用户头像" json:"avatar"`
 	BackgroundImage *string `gorm:"size:500;comment:主页背景" json:"background_image"`
 	UserRole        string  `gorm:"size:256;not null;default:'user';comment:用户角色" json:"user_role"`
-	IsDelete        int64   `gorm:"not null;default:0;comment:删除标识" json:"-"`
+	IsVerified      bool    `gorm:"not null;default:false;comment:是否认证用户" json:"is_verified"`
+
+	// 注册时使用的邀请码及其邀请人，未使用邀请码注册时为空
+	InviteCodeID *int64 `gorm:"comment:注册邀请码ID" json:"-"`
+	InvitedByID  *int64 `gorm:"index:idx_users_invited_by_id;comment:邀请人ID" json:"-"`
+
+	// 出生日期，用于年龄限制内容的访问判断；设置后仅管理员可修改
+	BirthDate *time.Time `gorm:"type:date;comment:出生日期" json:"-"`
+
+	// 用户确认存在非本人登录后置为 true，修改密码前不能再登录
+	PasswordResetRequired bool `gorm:"not null;default:false;comment:是否需要重置密码" json:"-"`
+
+	// 账号限制：截止时间之前生效，过期自动解除
+	SuspendedUntil *time.Time `gorm:"comment:封禁截止时间（封禁期间不能登录）" json:"-"`
+	SuspendReason  string     `gorm:"size:500;not null;default:'';comment:封禁原因" json:"-"`
+	MutedUntil     *time.Time `gorm:"comment:禁言截止时间（禁言期间不能发视频、评论）" json:"-"`
+	MuteReason     string     `gorm:"size:500;not null;default:'';comment:禁言原因" json:"-"`
+
+	// 法律保全：保全期间主页对外隐藏，账号及其视频、评论不可删除，直到管理员解除
+	LegalHoldAt     *time.Time `gorm:"index:idx_users_legal_hold_at;comment:法律保全开始时间" json:"-"`
+	LegalHoldReason string     `gorm:"size:500;not null;default:'';comment:法律保全原因" json:"-"`
+
+	DeletedAt gorm.DeletedAt `gorm:"index;comment:删除时间" json:"-"`
 
 	// 关联关系
 	Videos    []Video    `gorm:"foreignKey:AuthorID" json:"videos,omitempty"`
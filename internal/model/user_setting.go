@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// UserSetting 用户个人设置（每个用户一行，首次修改时创建）
+type UserSetting struct {
+	UserID int64 `gorm:"primaryKey;autoIncrement:false;comment:用户ID" json:"user_id"`
+
+	// 通知偏好：屏蔽的通知类型与屏蔽通知的视频
+	MutedNotificationTypes []string `gorm:"type:text;serializer:json;comment:屏蔽的通知类型" json:"muted_notification_types"`
+	MutedVideoIDs          []int64  `gorm:"type:text;serializer:json;comment:屏蔽通知的视频ID" json:"muted_video_ids"`
+
+	// 邮件通知：收件地址与开启的邮件类别
+	NotificationEmail  string   `gorm:"size:255;not null;default:'';comment:通知邮箱" json:"notification_email"`
+	EmailNotifications []string `gorm:"type:text;serializer:json;comment:开启的邮件通知类别" json:"email_notifications"`
+
+	// 不允许他人引用自己的视频合拍、拼接或二创
+	RemixDisabled bool `gorm:"not null;default:false;comment:禁止引用视频二创" json:"remix_disabled"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+func (UserSetting) TableName() string {
+	return "user_settings"
+}
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Video 视频模型
 type Video struct {
@@ -19,16 +23,162 @@ type Video struct {
 	ViewCount     int64      `gorm:"default:0;comment:播放量" json:"view_count"`
 	FavoriteCount int64      `gorm:"default:0;comment:点赞数" json:"favorite_count"`
 	CommentCount  int64      `gorm:"default:0;comment:评论数" json:"comment_count"`
-	PublishTime   *int64     `gorm:"index:idx_publish_time;comment:发布时间" json:"publish_time"`
+	PlayCount     int64      `gorm:"not null;default:0;comment:客户端上报的播放次数（按会话去重）" json:"play_count"`
+	CompleteCount int64      `gorm:"not null;default:0;comment:完播次数" json:"complete_count"`
+	WatchTimeMs   int64      `gorm:"not null;default:0;comment:累计观看时长（毫秒）" json:"watch_time_ms"`
+	PublishTime   *time.Time `gorm:"index:idx_publish_time;comment:发布时间" json:"publish_time"`
 	CreatedAt     time.Time  `gorm:"autoCreateTime;index:idx_videos_created_at;comment:创建时间" json:"created_at"`
 	UpdatedAt     time.Time  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
 
+	// 转码产出（默认播放文件）的编码信息，转码完成时由 ffprobe 探测
+	VideoCodec    string  `gorm:"size:20;not null;default:'';comment:视频编码" json:"video_codec"`
+	AudioCodec    string  `gorm:"size:20;not null;default:'';comment:音频编码" json:"audio_codec"`
+	Bitrate       int64   `gorm:"not null;default:0;comment:总码率（bps）" json:"bitrate"`
+	FrameRate     float64 `gorm:"not null;default:0;comment:帧率" json:"frame_rate"`
+	AudioChannels int     `gorm:"not null;default:0;comment:音频声道数" json:"audio_channels"`
+
+	// 各类型表态数，FavoriteCount 为全部表态的合计
+	LikeCount  int64 `gorm:"not null;default:0;comment:喜欢数" json:"like_count"`
+	LoveCount  int64 `gorm:"not null;default:0;comment:爱心数" json:"love_count"`
+	LaughCount int64 `gorm:"not null;default:0;comment:大笑数" json:"laugh_count"`
+	WowCount   int64 `gorm:"not null;default:0;comment:惊讶数" json:"wow_count"`
+
+	// AI 生成的摘要与关键时刻（发布后异步生成）
+	Summary    string      `gorm:"type:text;comment:视频摘要" json:"summary"`
+	KeyMoments []KeyMoment `gorm:"type:text;serializer:json;comment:关键时刻" json:"key_moments"`
+
+	// 地区限制：逗号分隔的国家/地区代码（ISO 3166-1 alpha-2），为空表示不限制。
+	// AllowedRegions 非空时仅这些地区可见，BlockedRegions 中的地区不可见（优先于 AllowedRegions）
+	AllowedRegions string `gorm:"size:1000;not null;default:'';comment:仅限可见的地区" json:"allowed_regions"`
+	BlockedRegions string `gorm:"size:1000;not null;default:'';comment:屏蔽的地区" json:"blocked_regions"`
+
+	// 年龄限制：author 为作者标记，moderator 为审核员标记（作者不可取消），为空表示不限制
+	AgeRestriction string `gorm:"size:20;not null;default:'';comment:年龄限制标记来源" json:"age_restriction"`
+
+	// 法律保全：保全期间内容保留、对外隐藏，作者不可修改或删除，直到管理员解除
+	LegalHoldAt     *time.Time `gorm:"index:idx_videos_legal_hold_at;comment:法律保全开始时间" json:"-"`
+	LegalHoldReason string     `gorm:"size:500;not null;default:'';comment:法律保全原因" json:"-"`
+
+	// 近似重复检测自动关联的原视频
+	DuplicateOfID *int64 `gorm:"index:idx_videos_duplicate_of_id;comment:原视频ID" json:"duplicate_of_id"`
+
+	// 合拍、拼接、二创时上传者引用的原视频
+	RemixOfID *int64 `gorm:"index:idx_videos_remix_of_id;comment:引用的原视频ID" json:"remix_of_id"`
+	RemixType string `gorm:"size:20;not null;default:'';comment:引用方式" json:"remix_type"`
+
+	// 置顶到作者主页的时间，每位作者最多置顶一个视频
+	PinnedAt *time.Time `gorm:"comment:置顶时间" json:"pinned_at"`
+
+	// 可见范围：私密视频仅作者及被授权的用户可见，不出现在视频流、搜索和作者主页中。
+	// ShareTokenHash 为分享链接令牌的 SHA-256 摘要，为空表示未开启链接分享
+	Visibility     string `gorm:"size:20;not null;default:'public';index:idx_videos_visibility;comment:可见范围" json:"visibility"`
+	ShareTokenHash string `gorm:"size:64;not null;default:'';comment:分享链接令牌摘要" json:"-"`
+
+	// 会员专属：仅该等级及以上等级的会员可以观看，其他人只能看到标题、封面等预览信息
+	MembersOnlyTierID *int64 `gorm:"index:idx_videos_members_only_tier_id;comment:会员专属视频要求的会员等级ID" json:"members_only_tier_id"`
+
+	// 付费推广：作者声明视频含商业合作时必须填写推广声明（如合作品牌），对观众展示为赞助内容
+	IsSponsored       bool   `gorm:"not null;default:false;index:idx_videos_is_sponsored;comment:是否付费推广" json:"is_sponsored"`
+	SponsorDisclosure string `gorm:"size:200;not null;default:'';comment:推广声明" json:"sponsor_disclosure"`
+
+	// 首映：预约首映的视频转码完成后处于 scheduled 状态，到首映时间由定时任务发布；
+	// 首映开始后的一个视频时长内为首映窗口，观众同步播放并可参与实时聊天
+	PremiereAt *time.Time `gorm:"index:idx_videos_premiere_at;comment:首映时间" json:"premiere_at"`
+
+	// 存储层级：长期无人观看的视频播放文件移入冷存储，播放时按需恢复
+	StorageTier          string     `gorm:"size:20;not null;default:'hot';index:idx_videos_storage_tier;comment:存储层级" json:"storage_tier"`
+	StorageTierChangedAt *time.Time `gorm:"comment:存储层级变更时间" json:"-"`
+
+	DeletedAt gorm.DeletedAt `gorm:"index;comment:删除时间" json:"-"`
+
 	// 关联关系
 	Author    User       `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
 	Favorites []Favorite `gorm:"foreignKey:VideoID" json:"favorites,omitempty"`
 	Comments  []Comment  `gorm:"foreignKey:VideoID" json:"comments,omitempty"`
 }
 
+// 引用原视频的方式
+const (
+	RemixTypeDuet   = "duet"   // 合拍：与原视频同屏
+	RemixTypeStitch = "stitch" // 拼接：截取原视频片段开头
+	RemixTypeRemix  = "remix"  // 二创
+)
+
+// 存储层级
+const (
+	StorageTierHot         = "hot"         // 标准存储，可直接播放
+	StorageTierArchiving   = "archiving"   // 正在移入冷存储
+	StorageTierCold        = "cold"        // 已在冷存储
+	StorageTierRehydrating = "rehydrating" // 正在从冷存储恢复
+)
+
+// 可见范围
+const (
+	VideoVisibilityPublic  = "public"  // 公开
+	VideoVisibilityPrivate = "private" // 私密：仅作者及被授权的用户可见
+)
+
+// IsPrivate 是否为私密视频
+func (v *Video) IsPrivate() bool {
+	return v.Visibility == VideoVisibilityPrivate
+}
+
+// 播放状态
+const (
+	PlaybackStateReady     = "ready"     // 可直接播放
+	PlaybackStatePreparing = "preparing" // 正在从冷存储恢复，稍后重试
+	PlaybackStatePremiere  = "premiere"  // 首映尚未开始，播放地址暂不返回
+)
+
+// PlaybackState 按存储层级返回播放状态；归档中的文件可能已部分移走，同样视为 preparing
+func (v *Video) PlaybackState() string {
+	if v.StorageTier == "" || v.StorageTier == StorageTierHot {
+		return PlaybackStateReady
+	}
+	return PlaybackStatePreparing
+}
+
+// 首映阶段
+const (
+	PremiereStateUpcoming = "upcoming" // 倒计时中
+	PremiereStateLive     = "live"     // 首映窗口内，观众同步播放
+	PremiereStateEnded    = "ended"    // 首映已结束，按普通视频播放
+)
+
+// PremiereEndsAt 首映窗口结束时间；未知时长（尚未转码完成）时按首映开始时间计
+func (v *Video) PremiereEndsAt() time.Time {
+	return v.PremiereAt.Add(time.Duration(v.Duration) * time.Second)
+}
+
+// PremiereState 返回 now 时刻的首映阶段，未预约首映时返回空串
+func (v *Video) PremiereState(now time.Time) string {
+	switch {
+	case v.PremiereAt == nil:
+		return ""
+	case now.Before(*v.PremiereAt):
+		return PremiereStateUpcoming
+	case now.Before(v.PremiereEndsAt()):
+		return PremiereStateLive
+	default:
+		return PremiereStateEnded
+	}
+}
+
+// KeyMoment 视频中的关键时刻
+type KeyMoment struct {
+	Time  int    `json:"time"` // 秒
+	Label string `json:"label"`
+}
+
 func (Video) TableName() string {
 	return "videos"
 }
+
+// PublishUnix 发布时间的 Unix 秒，兼容旧版 publish_time 字段，未发布返回 nil
+func (v *Video) PublishUnix() *int64 {
+	if v.PublishTime == nil {
+		return nil
+	}
+	t := v.PublishTime.Unix()
+	return &t
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// 授权方式：direct 为作者直接授权，link 为通过分享链接领取
+const (
+	VideoAccessViaDirect = "direct"
+	VideoAccessViaLink   = "link"
+)
+
+// VideoAccessGrant 私密视频的观看授权
+type VideoAccessGrant struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:授权记录ID" json:"id"`
+	VideoID   int64     `gorm:"not null;uniqueIndex:uq_video_access_grant;comment:视频ID" json:"video_id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:uq_video_access_grant;index:idx_video_access_grants_user_id;comment:被授权用户ID" json:"user_id"`
+	Via       string    `gorm:"size:20;not null;default:'direct';comment:授权方式（direct/link）" json:"via"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:授权时间" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (VideoAccessGrant) TableName() string {
+	return "video_access_grants"
+}
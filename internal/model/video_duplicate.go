@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// 近似重复记录状态
+const (
+	VideoDuplicateStatusPending   = "pending"   // 待审核
+	VideoDuplicateStatusLinked    = "linked"    // 相似度很高，已自动关联到原视频
+	VideoDuplicateStatusDismissed = "dismissed" // 审核后认为不是重复
+	VideoDuplicateStatusHidden    = "hidden"    // 审核后隐藏了重复视频
+)
+
+// VideoDuplicate 新发布视频与已有视频的近似重复记录
+type VideoDuplicate struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement;comment:记录ID" json:"id"`
+	VideoID       int64      `gorm:"not null;uniqueIndex:uq_video_duplicate;comment:新发布的视频ID" json:"video_id"`
+	DuplicateOfID int64      `gorm:"not null;uniqueIndex:uq_video_duplicate;index:idx_video_duplicates_duplicate_of;comment:疑似原视频ID" json:"duplicate_of_id"`
+	Similarity    float64    `gorm:"not null;comment:相似度（匹配的抽帧比例）" json:"similarity"`
+	Status        string     `gorm:"size:20;not null;default:'pending';index:idx_video_duplicates_status;comment:状态" json:"status"`
+	ReviewedAt    *time.Time `gorm:"comment:审核时间" json:"reviewed_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+
+	Video       Video `gorm:"foreignKey:VideoID" json:"video,omitempty"`
+	DuplicateOf Video `gorm:"foreignKey:DuplicateOfID" json:"duplicate_of,omitempty"`
+}
+
+func (VideoDuplicate) TableName() string {
+	return "video_duplicates"
+}
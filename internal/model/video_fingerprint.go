@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// VideoFingerprint 视频抽帧的感知哈希，每帧一条
+// 64 位哈希按 16 位拆成 4 段分别建索引：汉明距离不超过 3 的两个哈希至少有一段完全相同，
+// 查找相似帧时先按段取候选，再计算完整的汉明距离
+type VideoFingerprint struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:指纹ID" json:"id"`
+	VideoID   int64     `gorm:"not null;index:idx_video_fingerprints_video_id;comment:视频ID" json:"video_id"`
+	Offset    int       `gorm:"not null;default:0;comment:帧在视频中的位置（秒）" json:"offset"`
+	Hash      int64     `gorm:"not null;comment:dHash（按位存储为有符号整数）" json:"hash"`
+	Band0     int32     `gorm:"not null;index:idx_video_fingerprints_band0;comment:哈希第 0 段" json:"-"`
+	Band1     int32     `gorm:"not null;index:idx_video_fingerprints_band1;comment:哈希第 1 段" json:"-"`
+	Band2     int32     `gorm:"not null;index:idx_video_fingerprints_band2;comment:哈希第 2 段" json:"-"`
+	Band3     int32     `gorm:"not null;index:idx_video_fingerprints_band3;comment:哈希第 3 段" json:"-"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (VideoFingerprint) TableName() string {
+	return "video_fingerprints"
+}
+
+// NewVideoFingerprint 由哈希生成指纹记录（含分段）
+func NewVideoFingerprint(videoID int64, offset int, hash uint64) VideoFingerprint {
+	bands := FingerprintBands(hash)
+	return VideoFingerprint{
+		VideoID: videoID,
+		Offset:  offset,
+		Hash:    int64(hash),
+		Band0:   bands[0],
+		Band1:   bands[1],
+		Band2:   bands[2],
+		Band3:   bands[3],
+	}
+}
+
+// FingerprintBands 将哈希按 16 位拆成 4 段
+func FingerprintBands(hash uint64) [4]int32 {
+	var bands [4]int32
+	for i := range bands {
+		bands[i] = int32((hash >> (16 * i)) & 0xFFFF)
+	}
+	return bands
+}
@@ -0,0 +1,32 @@
+package model
+
+import "strings"
+
+// SplitRegions 将逗号分隔的地区代码拆分为列表，空字符串返回 nil
+func SplitRegions(regions string) []string {
+	if regions == "" {
+		return nil
+	}
+	return strings.Split(regions, ",")
+}
+
+// AvailableIn 视频在 country 是否可见。country 为空表示归属地未知：
+// 屏蔽列表不生效，设置了仅限地区的视频不可见
+func (v *Video) AvailableIn(country string) bool {
+	if v.AllowedRegions != "" && !containsRegion(v.AllowedRegions, country) {
+		return false
+	}
+	return !containsRegion(v.BlockedRegions, country)
+}
+
+func containsRegion(regions, country string) bool {
+	if country == "" {
+		return false
+	}
+	for _, r := range SplitRegions(regions) {
+		if r == country {
+			return true
+		}
+	}
+	return false
+}
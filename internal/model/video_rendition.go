@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// 附加清晰度状态
+const (
+	VideoRenditionStatusPending = "pending" // 已投递转码任务，等待结果
+	VideoRenditionStatusReady   = "ready"   // 已生成
+	VideoRenditionStatusFailed  = "failed"  // 转码失败，未超过最多尝试次数时补齐任务会重新投递
+)
+
+// VideoRendition 视频在某个转码档位（如 720p、hls）下的产物，用于记录每个视频的档位完整度
+type VideoRendition struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:记录ID" json:"id"`
+	VideoID   int64     `gorm:"not null;uniqueIndex:uq_video_rendition;comment:视频ID" json:"video_id"`
+	Profile   string    `gorm:"size:50;not null;uniqueIndex:uq_video_rendition;comment:档位名称" json:"profile"`
+	Status    string    `gorm:"size:20;not null;default:'pending';index:idx_video_renditions_status;comment:状态" json:"status"`
+	URL       string    `gorm:"size:500;comment:播放地址（hls 为 m3u8）" json:"url"`
+	Width     int       `gorm:"comment:宽度" json:"width"`
+	Height    int       `gorm:"comment:高度" json:"height"`
+	Attempts  int       `gorm:"not null;default:0;comment:已投递次数" json:"attempts"`
+	Error     string    `gorm:"size:500;comment:最近一次失败原因" json:"error"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+func (VideoRendition) TableName() string {
+	return "video_renditions"
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// VideoDailyStat 视频每日统计，按 UTC 日期聚合，点赞与评论为当日净增量
+type VideoDailyStat struct {
+	VideoID     int64     `gorm:"primaryKey;autoIncrement:false;comment:视频ID" json:"video_id"`
+	StatDate    time.Time `gorm:"primaryKey;type:date;comment:统计日期（UTC）" json:"stat_date"`
+	Views       int64     `gorm:"not null;default:0;comment:播放次数" json:"views"`
+	Likes       int64     `gorm:"not null;default:0;comment:点赞净增数" json:"likes"`
+	Comments    int64     `gorm:"not null;default:0;comment:评论净增数" json:"comments"`
+	Plays       int64     `gorm:"not null;default:0;comment:客户端上报的播放次数（按会话去重）" json:"plays"`
+	Completions int64     `gorm:"not null;default:0;comment:完播次数" json:"completions"`
+	WatchTimeMs int64     `gorm:"not null;default:0;comment:累计观看时长（毫秒）" json:"watch_time_ms"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+func (VideoDailyStat) TableName() string {
+	return "video_daily_stats"
+}
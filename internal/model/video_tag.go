@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// 标签类型
+const (
+	VideoTagKindTag      = "tag"
+	VideoTagKindCategory = "category"
+)
+
+// 标签来源：machine 为 AI 生成，author 为作者手动添加
+const (
+	VideoTagSourceMachine = "machine"
+	VideoTagSourceAuthor  = "author"
+)
+
+// VideoTag 视频标签与分类
+type VideoTag struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:标签记录ID" json:"id"`
+	VideoID   int64     `gorm:"not null;uniqueIndex:uq_video_tag;comment:视频ID" json:"video_id"`
+	Kind      string    `gorm:"size:20;not null;uniqueIndex:uq_video_tag;comment:类型（tag/category）" json:"kind"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex:uq_video_tag;index:idx_video_tags_name;comment:标签名" json:"name"`
+	Source    string    `gorm:"size:20;not null;default:'machine';comment:来源（machine/author）" json:"source"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (VideoTag) TableName() string {
+	return "video_tags"
+}
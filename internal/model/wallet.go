@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// 金币流水类型
+const (
+	WalletTxTipSent            = "tip_sent"            // 打赏支出
+	WalletTxTipReceived        = "tip_received"        // 收到打赏
+	WalletTxAdminAdjust        = "admin_adjust"        // 管理员调整
+	WalletTxMembershipPaid     = "membership_paid"     // 购买会员
+	WalletTxMembershipReceived = "membership_received" // 会员收入
+)
+
+// Wallet 用户金币钱包。余额只能通过记账修改，始终等于该用户全部流水金额之和
+type Wallet struct {
+	UserID    int64     `gorm:"primaryKey;autoIncrement:false;comment:用户ID" json:"user_id"`
+	Balance   int64     `gorm:"not null;default:0;comment:金币余额" json:"balance"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+func (Wallet) TableName() string {
+	return "wallets"
+}
+
+// WalletTransaction 金币流水，只增不改。一次转账（打赏、购买会员）产生付款方与收款方两条流水，共用同一个 TransferID；
+// RequestID 为发起方提供的幂等键，同一用户的同一幂等键只会记账一次
+type WalletTransaction struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement;comment:流水ID" json:"id"`
+	UserID         int64     `gorm:"not null;index:idx_wallet_tx_user_id;uniqueIndex:uq_wallet_tx_request;comment:用户ID" json:"user_id"`
+	Kind           string    `gorm:"size:20;not null;index:idx_wallet_tx_kind;comment:类型" json:"kind"`
+	Amount         int64     `gorm:"not null;comment:金额（入账为正，出账为负）" json:"amount"`
+	BalanceAfter   int64     `gorm:"not null;comment:记账后余额" json:"balance_after"`
+	TransferID     string    `gorm:"size:32;not null;index:idx_wallet_tx_transfer_id;comment:转账ID" json:"transfer_id"`
+	RequestID      *string   `gorm:"size:64;uniqueIndex:uq_wallet_tx_request;comment:幂等键" json:"request_id"`
+	CounterpartyID *int64    `gorm:"index:idx_wallet_tx_counterparty_id;comment:对方用户ID" json:"counterparty_id"`
+	VideoID        *int64    `gorm:"index:idx_wallet_tx_video_id;comment:打赏的视频ID" json:"video_id"`
+	OperatorID     *int64    `gorm:"comment:调整余额的管理员ID" json:"operator_id"`
+	Note           string    `gorm:"size:200;not null;default:'';comment:留言或调整原因" json:"note"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;index:idx_wallet_tx_created_at;comment:记账时间" json:"created_at"`
+}
+
+func (WalletTransaction) TableName() string {
+	return "wallet_transactions"
+}
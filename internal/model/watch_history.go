@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// WatchHistory 用户观看记录（每个用户每个视频一条，记录最近观看时间），来自客户端播放埋点
+type WatchHistory struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement;comment:观看记录ID" json:"id"`
+	UserID        int64     `gorm:"not null;uniqueIndex:uq_user_video_watch;comment:用户ID" json:"user_id"`
+	VideoID       int64     `gorm:"not null;uniqueIndex:uq_user_video_watch;index:idx_watch_histories_video_id;comment:视频ID" json:"video_id"`
+	LastWatchedAt time.Time `gorm:"not null;index:idx_watch_histories_last_watched_at;comment:最近观看时间" json:"last_watched_at"`
+	CreatedAt     time.Time `gorm:"autoCreateTime;comment:首次观看时间" json:"created_at"`
+}
+
+func (WatchHistory) TableName() string {
+	return "watch_histories"
+}
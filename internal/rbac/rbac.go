@@ -0,0 +1,66 @@
+package rbac
+
+// 用户角色（权限从低到高）
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Permission 权限标识
+type Permission string
+
+const (
+	PermModerateContent Permission = "content:moderate" // 隐藏/恢复视频与评论、查看审核队列
+	PermHandleReports   Permission = "reports:handle"   // 处理用户举报
+	PermManageUsers     Permission = "users:manage"     // 用户管理（删除、恢复、封禁、角色变更）
+)
+
+var rolePermissions = map[string]map[Permission]bool{
+	RoleUser: {},
+	RoleModerator: {
+		PermModerateContent: true,
+		PermHandleReports:   true,
+	},
+	RoleAdmin: {
+		PermModerateContent: true,
+		PermHandleReports:   true,
+		PermManageUsers:     true,
+	},
+}
+
+// HasPermission 判断角色是否拥有某项权限
+func HasPermission(role string, perm Permission) bool {
+	return rolePermissions[role][perm]
+}
+
+// IsValidRole 判断角色是否存在
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Scope 第三方应用（OAuth 客户端）的权限范围
+type Scope string
+
+const (
+	ScopeReadVideos    Scope = "read:videos"    // 浏览视频流、视频详情与搜索
+	ScopeWriteComments Scope = "write:comments" // 以用户身份发表、修改、删除评论
+)
+
+// scopeRequiresUser 需要用户授权（授权码模式）才能获得的 scope，客户端凭证模式不可申请
+var scopeRequiresUser = map[Scope]bool{
+	ScopeReadVideos:    false,
+	ScopeWriteComments: true,
+}
+
+// IsValidScope 判断 scope 是否存在
+func IsValidScope(scope Scope) bool {
+	_, ok := scopeRequiresUser[scope]
+	return ok
+}
+
+// ScopeRequiresUser 判断 scope 是否必须由用户授权
+func ScopeRequiresUser(scope Scope) bool {
+	return scopeRequiresUser[scope]
+}
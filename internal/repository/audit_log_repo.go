@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter 审计日志查询条件
+type AuditLogFilter struct {
+	ActorID    *int64
+	Action     *string
+	TargetType *string
+	TargetID   *int64
+	StartTime  *time.Time
+	EndTime    *time.Time
+}
+
+// AuditLogRepository 审计日志仓储（仅追加）
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create 追加一条审计日志
+func (r *AuditLogRepository) Create(ctx context.Context, log *model.AuditLog) error {
+	return conn(ctx, r.db).Create(log).Error
+}
+
+// List 按条件分页查询审计日志（按时间倒序）
+func (r *AuditLogRepository) List(ctx context.Context, filter *AuditLogFilter, skip, limit int) ([]model.AuditLog, int64, error) {
+	query := conn(ctx, r.db).Model(&model.AuditLog{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != nil && *filter.Action != "" {
+		query = query.Where("action = ?", *filter.Action)
+	}
+	if filter.TargetType != nil && *filter.TargetType != "" {
+		query = query.Where("target_type = ?", *filter.TargetType)
+	}
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("created_at >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("created_at <= ?", *filter.EndTime)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []model.AuditLog
+	err := query.Order("id DESC").Offset(skip).Limit(limit).Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
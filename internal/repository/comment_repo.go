@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"vida-go/internal/model"
 
 	"gorm.io/gorm"
@@ -14,22 +17,22 @@ func NewCommentRepository(db *gorm.DB) *CommentRepository {
 	return &CommentRepository{db: db}
 }
 
-func (r *CommentRepository) Create(comment *model.Comment) error {
-	return r.db.Create(comment).Error
+func (r *CommentRepository) Create(ctx context.Context, comment *model.Comment) error {
+	return conn(ctx, r.db).Create(comment).Error
 }
 
-func (r *CommentRepository) GetByID(id int64) (*model.Comment, error) {
+func (r *CommentRepository) GetByID(ctx context.Context, id int64) (*model.Comment, error) {
 	var comment model.Comment
-	err := r.db.First(&comment, id).Error
+	err := conn(ctx, r.db).First(&comment, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &comment, nil
 }
 
-func (r *CommentRepository) GetByIDWithUser(id int64) (*model.Comment, error) {
+func (r *CommentRepository) GetByIDWithUser(ctx context.Context, id int64) (*model.Comment, error) {
 	var comment model.Comment
-	err := r.db.Preload("User").First(&comment, id).Error
+	err := conn(ctx, r.db).Preload("User", withDeleted).First(&comment, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -37,8 +40,8 @@ func (r *CommentRepository) GetByIDWithUser(id int64) (*model.Comment, error) {
 }
 
 // Update 更新评论（仅作者本人）
-func (r *CommentRepository) Update(commentID, userID int64, content string) error {
-	result := r.db.Model(&model.Comment{}).
+func (r *CommentRepository) Update(ctx context.Context, commentID, userID int64, content string) error {
+	result := conn(ctx, r.db).Model(&model.Comment{}).
 		Where("id = ? AND user_id = ?", commentID, userID).
 		Update("content", content)
 	if result.Error != nil {
@@ -51,8 +54,8 @@ func (r *CommentRepository) Update(commentID, userID int64, content string) erro
 }
 
 // Delete 删除评论（仅作者本人）
-func (r *CommentRepository) Delete(commentID, userID int64) (bool, error) {
-	result := r.db.Where("id = ? AND user_id = ?", commentID, userID).Delete(&model.Comment{})
+func (r *CommentRepository) Delete(ctx context.Context, commentID, userID int64) (bool, error) {
+	result := conn(ctx, r.db).Where("id = ? AND user_id = ?", commentID, userID).Delete(&model.Comment{})
 	if result.Error != nil {
 		return false, result.Error
 	}
@@ -60,8 +63,8 @@ func (r *CommentRepository) Delete(commentID, userID int64) (bool, error) {
 }
 
 // ListByVideo 获取视频的评论列表（支持父评论筛选）
-func (r *CommentRepository) ListByVideo(videoID int64, parentID *int64, skip, limit int) ([]model.Comment, int64, error) {
-	query := r.db.Model(&model.Comment{}).Where("video_id = ?", videoID)
+func (r *CommentRepository) ListByVideo(ctx context.Context, videoID int64, parentID *int64, skip, limit int) ([]model.Comment, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Comment{}).Where("video_id = ? AND is_hidden = ?", videoID, false)
 
 	if parentID != nil {
 		query = query.Where("parent_id = ?", *parentID)
@@ -75,7 +78,7 @@ func (r *CommentRepository) ListByVideo(videoID int64, parentID *int64, skip, li
 	}
 
 	var comments []model.Comment
-	err := query.Preload("User").Order("created_at DESC").
+	err := query.Preload("User", withDeleted).Order("created_at DESC").
 		Offset(skip).Limit(limit).Find(&comments).Error
 	if err != nil {
 		return nil, 0, err
@@ -84,9 +87,74 @@ func (r *CommentRepository) ListByVideo(videoID int64, parentID *int64, skip, li
 	return comments, total, nil
 }
 
+// ListByVideoBefore 按 ID 倒序游标分页获取视频的一级评论
+func (r *CommentRepository) ListByVideoBefore(ctx context.Context, videoID, beforeID int64, limit int) ([]model.Comment, error) {
+	query := replica(r.db).WithContext(ctx).Where("video_id = ? AND parent_id IS NULL AND is_hidden = ?", videoID, false)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var comments []model.Comment
+	err := query.Preload("User", withDeleted).Order("id DESC").Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// ListRepliesAfter 按 ID 正序游标分页获取某条评论的回复，afterID 为 0 时从最早开始
+func (r *CommentRepository) ListRepliesAfter(ctx context.Context, parentID, afterID int64, limit int) ([]model.Comment, error) {
+	query := replica(r.db).WithContext(ctx).Where("parent_id = ? AND is_hidden = ?", parentID, false)
+	if afterID > 0 {
+		query = query.Where("id > ?", afterID)
+	}
+	var comments []model.Comment
+	err := query.Preload("User", withDeleted).Order("id ASC").Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// ListByUserBefore 按 ID 倒序游标分页获取用户的评论
+func (r *CommentRepository) ListByUserBefore(ctx context.Context, userID, beforeID int64, limit int) ([]model.Comment, error) {
+	query := replica(r.db).WithContext(ctx).Where("user_id = ?", userID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var comments []model.Comment
+	err := query.Preload("Video", withDeleted).Order("id DESC").Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// ListTopByVideos 批量获取多个视频的热门一级评论，每个视频最多 limit 条（按点赞数、时间倒序）
+func (r *CommentRepository) ListTopByVideos(ctx context.Context, videoIDs []int64, limit int) ([]model.Comment, error) {
+	if len(videoIDs) == 0 {
+		return nil, nil
+	}
+
+	ranked := replica(r.db).WithContext(ctx).Model(&model.Comment{}).
+		Select("comments.*, ROW_NUMBER() OVER (PARTITION BY video_id ORDER BY like_count DESC, created_at DESC) AS rn").
+		Where("video_id IN ? AND parent_id IS NULL AND is_hidden = ?", videoIDs, false)
+
+	var comments []model.Comment
+	err := replica(r.db).WithContext(ctx).Table("(?) AS ranked", ranked).
+		Where("rn <= ?", limit).
+		Order("video_id, rn").
+		Find(&comments).Error
+	return comments, err
+}
+
+// ListTopOnAuthorVideos 每周摘要：他人在 [since, until) 内对作者视频发表的热门一级评论（含评论者与视频），
+// 按点赞数、时间倒序
+func (r *CommentRepository) ListTopOnAuthorVideos(ctx context.Context, authorID int64, since, until time.Time, limit int) ([]model.Comment, error) {
+	videos := r.db.Model(&model.Video{}).Select("id").Where("author_id = ?", authorID)
+	var comments []model.Comment
+	err := replica(r.db).WithContext(ctx).
+		Where("video_id IN (?) AND parent_id IS NULL AND is_hidden = ? AND user_id <> ?", videos, false, authorID).
+		Where("created_at >= ? AND created_at < ?", since, until).
+		Preload("User", withDeleted).Preload("Video").
+		Order("like_count DESC").Order("created_at DESC").Limit(limit).
+		Find(&comments).Error
+	return comments, err
+}
+
 // ListReplies 获取某条评论的回复
-func (r *CommentRepository) ListReplies(parentID int64, skip, limit int) ([]model.Comment, int64, error) {
-	query := r.db.Model(&model.Comment{}).Where("parent_id = ?", parentID)
+func (r *CommentRepository) ListReplies(ctx context.Context, parentID int64, skip, limit int) ([]model.Comment, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Comment{}).Where("parent_id = ? AND is_hidden = ?", parentID, false)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -94,7 +162,7 @@ func (r *CommentRepository) ListReplies(parentID int64, skip, limit int) ([]mode
 	}
 
 	var comments []model.Comment
-	err := query.Preload("User").Order("created_at ASC").
+	err := query.Preload("User", withDeleted).Order("created_at ASC").
 		Offset(skip).Limit(limit).Find(&comments).Error
 	if err != nil {
 		return nil, 0, err
@@ -104,8 +172,8 @@ func (r *CommentRepository) ListReplies(parentID int64, skip, limit int) ([]mode
 }
 
 // ListByUser 获取用户的评论列表
-func (r *CommentRepository) ListByUser(userID int64, skip, limit int) ([]model.Comment, int64, error) {
-	query := r.db.Model(&model.Comment{}).Where("user_id = ?", userID)
+func (r *CommentRepository) ListByUser(ctx context.Context, userID int64, skip, limit int) ([]model.Comment, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Comment{}).Where("user_id = ?", userID)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -113,7 +181,7 @@ func (r *CommentRepository) ListByUser(userID int64, skip, limit int) ([]model.C
 	}
 
 	var comments []model.Comment
-	err := query.Preload("Video").Order("created_at DESC").
+	err := query.Preload("Video", withDeleted).Order("created_at DESC").
 		Offset(skip).Limit(limit).Find(&comments).Error
 	if err != nil {
 		return nil, 0, err
@@ -123,8 +191,38 @@ func (r *CommentRepository) ListByUser(userID int64, skip, limit int) ([]model.C
 }
 
 // CountReplies 统计某条评论的回复数
-func (r *CommentRepository) CountReplies(commentID int64) (int64, error) {
+func (r *CommentRepository) CountReplies(ctx context.Context, commentID int64) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Comment{}).Where("parent_id = ?", commentID).Count(&count).Error
+	err := conn(ctx, r.db).Model(&model.Comment{}).Where("parent_id = ? AND is_hidden = ?", commentID, false).Count(&count).Error
 	return count, err
 }
+
+// SetHidden 设置评论的隐藏状态，返回是否发生变化
+func (r *CommentRepository) SetHidden(ctx context.Context, commentID int64, hidden bool) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.Comment{}).
+		Where("id = ? AND is_hidden = ?", commentID, !hidden).
+		Update("is_hidden", hidden)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListHidden 获取被隐藏的评论（审核用）
+func (r *CommentRepository) ListHidden(ctx context.Context, skip, limit int) ([]model.Comment, int64, error) {
+	query := conn(ctx, r.db).Model(&model.Comment{}).Where("is_hidden = ?", true)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var comments []model.Comment
+	err := query.Preload("User", withDeleted).Order("updated_at DESC").
+		Offset(skip).Limit(limit).Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
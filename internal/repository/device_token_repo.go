@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceTokenRepository(db *gorm.DB) *DeviceTokenRepository {
+	return &DeviceTokenRepository{db: db}
+}
+
+// Upsert 注册设备令牌，令牌已存在时改绑到当前用户（同一设备换账号登录）
+func (r *DeviceTokenRepository) Upsert(ctx context.Context, device *model.DeviceToken) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "updated_at"}),
+	}).Create(device).Error
+}
+
+// ListByUser 获取用户的设备（最近活跃的在前）
+func (r *DeviceTokenRepository) ListByUser(ctx context.Context, userID int64) ([]model.DeviceToken, error) {
+	var devices []model.DeviceToken
+	err := conn(ctx, r.db).Where("user_id = ?", userID).Order("updated_at DESC").Find(&devices).Error
+	return devices, err
+}
+
+// Delete 删除用户的指定设备令牌
+func (r *DeviceTokenRepository) Delete(ctx context.Context, userID int64, token string) (bool, error) {
+	result := conn(ctx, r.db).Where("user_id = ? AND token = ?", userID, token).Delete(&model.DeviceToken{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteByIDs 按 ID 删除设备令牌（清理失效或超出上限的设备）
+func (r *DeviceTokenRepository) DeleteByIDs(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Where("id IN ?", ids).Delete(&model.DeviceToken{}).Error
+}
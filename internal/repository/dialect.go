@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 仓储层用到的 SQL 在不同数据库间的差异集中在这里，其余查询只使用通用 SQL
+
+const (
+	dialectPostgres = "postgres"
+	dialectSQLite   = "sqlite"
+)
+
+// likeOp 不区分大小写的模糊匹配运算符：PostgreSQL 使用 ILIKE，
+// MySQL 默认排序规则和 SQLite 下 LIKE 本身不区分大小写（SQLite 仅限 ASCII）
+func likeOp(db *gorm.DB) string {
+	if db.Dialector.Name() == dialectPostgres {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// utcDate 把时间列转换为 UTC 日期的表达式（MySQL 连接使用 loc=UTC，时间列按 UTC 存储；
+// SQLite 的 DATE() 会按时间字符串中的时区偏移换算为 UTC）。结果用 scanDate 解析
+func utcDate(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == dialectPostgres {
+		return "(" + column + " AT TIME ZONE 'UTC')::date"
+	}
+	return "DATE(" + column + ")"
+}
+
+// scanDate 解析 utcDate 的结果：SQLite 返回 2006-01-02 字符串，其余驱动返回的时间转为字符串后以日期开头
+func scanDate(s string) (time.Time, error) {
+	if len(s) < len(time.DateOnly) {
+		return time.Time{}, fmt.Errorf("invalid date: %q", s)
+	}
+	return time.Parse(time.DateOnly, s[:len(time.DateOnly)])
+}
+
+// excluded upsert 冲突时引用待插入行的列：PostgreSQL、SQLite 为 EXCLUDED.col，MySQL 为 VALUES(col)
+func excluded(db *gorm.DB, column string) string {
+	switch db.Dialector.Name() {
+	case dialectPostgres, dialectSQLite:
+		return "EXCLUDED." + column
+	default:
+		return "VALUES(" + column + ")"
+	}
+}
+
+// accumulate upsert 冲突时在已有值上累加待插入行的值
+func accumulate(db *gorm.DB, table, column string) clause.Expr {
+	return gorm.Expr(table + "." + column + " + " + excluded(db, column))
+}
+
+// halfLifeDecay 按 column 中的时间到 now 的时长计算指数衰减系数 0.5^(时长/halfLife)，时间为空时为 0。
+// halfLife 不大于 0 时不衰减；SQLite 默认不带 POWER 等数学函数，同样不衰减
+func halfLifeDecay(db *gorm.DB, column string, now time.Time, halfLife time.Duration) (clause.Expr, bool) {
+	var age string
+	switch {
+	case halfLife <= 0 || db.Dialector.Name() == dialectSQLite:
+		return clause.Expr{}, false
+	case db.Dialector.Name() == dialectPostgres:
+		age = "EXTRACT(EPOCH FROM (? - " + column + "))"
+	default:
+		age = "TIMESTAMPDIFF(SECOND, " + column + ", ?)"
+	}
+	return clause.Expr{
+		SQL:  "COALESCE(POWER(0.5, GREATEST(" + age + ", 0) / ?), 0)",
+		Vars: []interface{}{now, halfLife.Seconds()},
+	}, true
+}
+
+// sqlFloat 把配置中的数值写成 SQL 字面量
+func sqlFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
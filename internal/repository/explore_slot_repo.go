@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type ExploreSlotRepository struct {
+	db *gorm.DB
+}
+
+func NewExploreSlotRepository(db *gorm.DB) *ExploreSlotRepository {
+	return &ExploreSlotRepository{db: db}
+}
+
+// Create 创建推荐位
+func (r *ExploreSlotRepository) Create(ctx context.Context, slot *model.ExploreSlot) error {
+	return conn(ctx, r.db).Create(slot).Error
+}
+
+// Delete 删除推荐位
+func (r *ExploreSlotRepository) Delete(ctx context.Context, id int64) error {
+	result := conn(ctx, r.db).Delete(&model.ExploreSlot{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListActive 获取 now 时生效的推荐位，按栏目内排序
+func (r *ExploreSlotRepository) ListActive(ctx context.Context, now time.Time) ([]model.ExploreSlot, error) {
+	var slots []model.ExploreSlot
+	err := replica(r.db).WithContext(ctx).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("ends_at IS NULL OR ends_at > ?", now).
+		Order("position ASC, id ASC").
+		Find(&slots).Error
+	return slots, err
+}
+
+// List 分页获取全部推荐位（含未生效、已过期），按创建时间倒序
+func (r *ExploreSlotRepository) List(ctx context.Context, skip, limit int) ([]model.ExploreSlot, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.ExploreSlot{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var slots []model.ExploreSlot
+	err := query.Order("id DESC").Offset(skip).Limit(limit).Find(&slots).Error
+	return slots, total, err
+}
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"vida-go/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FavoriteRepository struct {
@@ -14,32 +18,60 @@ func NewFavoriteRepository(db *gorm.DB) *FavoriteRepository {
 	return &FavoriteRepository{db: db}
 }
 
-func (r *FavoriteRepository) Create(userID, videoID int64) (*model.Favorite, error) {
-	fav := &model.Favorite{UserID: userID, VideoID: videoID}
-	if err := r.db.Create(fav).Error; err != nil {
+func (r *FavoriteRepository) Create(ctx context.Context, userID, videoID int64, reaction string) (*model.Favorite, error) {
+	fav := &model.Favorite{UserID: userID, VideoID: videoID, Reaction: reaction}
+	if err := conn(ctx, r.db).Create(fav).Error; err != nil {
 		return nil, err
 	}
 	return fav, nil
 }
 
-func (r *FavoriteRepository) Delete(userID, videoID int64) (bool, error) {
-	result := r.db.Where("user_id = ? AND video_id = ?", userID, videoID).Delete(&model.Favorite{})
+// BulkCreate 批量导入点赞记录，已存在的记录跳过，返回实际插入的条数
+func (r *FavoriteRepository) BulkCreate(ctx context.Context, favorites []model.Favorite) (int64, error) {
+	if len(favorites) == 0 {
+		return 0, nil
+	}
+	result := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(&favorites, bulkInsertBatchSize)
+	return result.RowsAffected, result.Error
+}
+
+func (r *FavoriteRepository) Delete(ctx context.Context, userID, videoID int64) (bool, error) {
+	result := conn(ctx, r.db).Where("user_id = ? AND video_id = ?", userID, videoID).Delete(&model.Favorite{})
 	if result.Error != nil {
 		return false, result.Error
 	}
 	return result.RowsAffected > 0, nil
 }
 
-func (r *FavoriteRepository) Exists(userID, videoID int64) (bool, error) {
+// Get 获取用户对视频的表态记录
+func (r *FavoriteRepository) Get(ctx context.Context, userID, videoID int64) (*model.Favorite, error) {
+	var fav model.Favorite
+	err := conn(ctx, r.db).Where("user_id = ? AND video_id = ?", userID, videoID).First(&fav).Error
+	if err != nil {
+		return nil, err
+	}
+	return &fav, nil
+}
+
+// UpdateReaction 更换表态类型，记录已不是 from 时返回 false（并发更换）
+func (r *FavoriteRepository) UpdateReaction(ctx context.Context, id int64, from, to string) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.Favorite{}).
+		Where("id = ? AND reaction = ?", id, from).
+		Update("reaction", to)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *FavoriteRepository) Exists(ctx context.Context, userID, videoID int64) (bool, error) {
 	var count int64
-	err := r.db.Model(&model.Favorite{}).
+	err := conn(ctx, r.db).Model(&model.Favorite{}).
 		Where("user_id = ? AND video_id = ?", userID, videoID).Count(&count).Error
 	return count > 0, err
 }
 
 // ListByUser 获取用户的点赞列表
-func (r *FavoriteRepository) ListByUser(userID int64, skip, limit int) ([]model.Favorite, int64, error) {
-	query := r.db.Model(&model.Favorite{}).Where("user_id = ?", userID)
+func (r *FavoriteRepository) ListByUser(ctx context.Context, userID int64, skip, limit int) ([]model.Favorite, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Favorite{}).Where("user_id = ?", userID)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -55,8 +87,8 @@ func (r *FavoriteRepository) ListByUser(userID int64, skip, limit int) ([]model.
 }
 
 // ListByVideo 获取视频的点赞列表
-func (r *FavoriteRepository) ListByVideo(videoID int64, skip, limit int) ([]model.Favorite, int64, error) {
-	query := r.db.Model(&model.Favorite{}).Where("video_id = ?", videoID)
+func (r *FavoriteRepository) ListByVideo(ctx context.Context, videoID int64, skip, limit int) ([]model.Favorite, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Favorite{}).Where("video_id = ?", videoID)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -71,21 +103,14 @@ func (r *FavoriteRepository) ListByVideo(videoID int64, skip, limit int) ([]mode
 	return favorites, total, nil
 }
 
-// CountByVideo 统计视频的点赞数
-func (r *FavoriteRepository) CountByVideo(videoID int64) (int64, error) {
-	var count int64
-	err := r.db.Model(&model.Favorite{}).Where("video_id = ?", videoID).Count(&count).Error
-	return count, err
-}
-
 // BatchCheckFavorited 批量查询点赞状态
-func (r *FavoriteRepository) BatchCheckFavorited(userID int64, videoIDs []int64) (map[int64]bool, error) {
+func (r *FavoriteRepository) BatchCheckFavorited(ctx context.Context, userID int64, videoIDs []int64) (map[int64]bool, error) {
 	if len(videoIDs) == 0 {
 		return map[int64]bool{}, nil
 	}
 
 	var favVideoIDs []int64
-	err := r.db.Model(&model.Favorite{}).
+	err := conn(ctx, r.db).Model(&model.Favorite{}).
 		Where("user_id = ? AND video_id IN ?", userID, videoIDs).
 		Pluck("video_id", &favVideoIDs).Error
 	if err != nil {
@@ -104,9 +129,30 @@ func (r *FavoriteRepository) BatchCheckFavorited(userID int64, videoIDs []int64)
 	return result, nil
 }
 
+// BatchGetReactions 批量查询用户对视频的表态类型，未表态的视频不在结果中
+func (r *FavoriteRepository) BatchGetReactions(ctx context.Context, userID int64, videoIDs []int64) (map[int64]string, error) {
+	if len(videoIDs) == 0 {
+		return map[int64]string{}, nil
+	}
+
+	var favorites []model.Favorite
+	err := conn(ctx, r.db).Select("video_id", "reaction").
+		Where("user_id = ? AND video_id IN ?", userID, videoIDs).
+		Find(&favorites).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := make(map[int64]string, len(favorites))
+	for _, f := range favorites {
+		reactions[f.VideoID] = f.Reaction
+	}
+	return reactions, nil
+}
+
 // GetFavoritedVideoIDs 获取用户点赞的视频 ID 列表
-func (r *FavoriteRepository) GetFavoritedVideoIDs(userID int64, skip, limit int) ([]int64, int64, error) {
-	query := r.db.Model(&model.Favorite{}).Where("user_id = ?", userID)
+func (r *FavoriteRepository) GetFavoritedVideoIDs(ctx context.Context, userID int64, skip, limit int) ([]int64, int64, error) {
+	query := conn(ctx, r.db).Model(&model.Favorite{}).Where("user_id = ?", userID)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -117,3 +163,36 @@ func (r *FavoriteRepository) GetFavoritedVideoIDs(userID int64, skip, limit int)
 	err := query.Order("created_at DESC").Offset(skip).Limit(limit).Pluck("video_id", &ids).Error
 	return ids, total, err
 }
+
+// ListByUserBefore 按 ID 倒序游标分页获取用户的点赞记录
+func (r *FavoriteRepository) ListByUserBefore(ctx context.Context, userID, beforeID int64, limit int) ([]model.Favorite, error) {
+	query := replica(r.db).WithContext(ctx).Where("user_id = ?", userID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var favorites []model.Favorite
+	err := query.Order("id DESC").Limit(limit).Find(&favorites).Error
+	return favorites, err
+}
+
+// ListByVideoBefore 按 ID 倒序游标分页获取视频的点赞记录
+func (r *FavoriteRepository) ListByVideoBefore(ctx context.Context, videoID, beforeID int64, limit int) ([]model.Favorite, error) {
+	query := replica(r.db).WithContext(ctx).Where("video_id = ?", videoID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var favorites []model.Favorite
+	err := query.Order("id DESC").Limit(limit).Find(&favorites).Error
+	return favorites, err
+}
+
+// ListSince 按 ID 顺序分批读取 since 之后的点赞记录（afterID 为上一批最后一条的 ID）
+func (r *FavoriteRepository) ListSince(ctx context.Context, since time.Time, afterID int64, limit int) ([]model.Favorite, error) {
+	var favs []model.Favorite
+	err := replica(r.db).WithContext(ctx).
+		Where("created_at >= ? AND id > ?", since, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&favs).Error
+	return favs, err
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type InviteCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewInviteCodeRepository(db *gorm.DB) *InviteCodeRepository {
+	return &InviteCodeRepository{db: db}
+}
+
+// 邀请码列表筛选状态
+const (
+	InviteCodeStatusActive  = "active"  // 可用
+	InviteCodeStatusUsedUp  = "used_up" // 次数已用完
+	InviteCodeStatusExpired = "expired" // 已过期
+	InviteCodeStatusRevoked = "revoked" // 已作废
+)
+
+// InviteCodeFilter 邀请码筛选条件，零值表示不限
+type InviteCodeFilter struct {
+	Status    string
+	InviterID int64
+}
+
+// CreateBatch 批量创建邀请码
+func (r *InviteCodeRepository) CreateBatch(ctx context.Context, codes []model.InviteCode) error {
+	return conn(ctx, r.db).Create(&codes).Error
+}
+
+// GetByID 获取邀请码
+func (r *InviteCodeRepository) GetByID(ctx context.Context, id int64) (*model.InviteCode, error) {
+	var code model.InviteCode
+	err := conn(ctx, r.db).First(&code, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// GetByCode 根据邀请码查询
+func (r *InviteCodeRepository) GetByCode(ctx context.Context, code string) (*model.InviteCode, error) {
+	var invite model.InviteCode
+	err := conn(ctx, r.db).Where("code = ?", code).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// Consume 使用一次邀请码：仅在未作废、未过期且次数未用完时计数 +1，返回是否成功
+func (r *InviteCodeRepository) Consume(ctx context.Context, id int64, now time.Time) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.InviteCode{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Where("max_uses = 0 OR used_count < max_uses").
+		UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+	return result.RowsAffected > 0, result.Error
+}
+
+// Revoke 作废邀请码，已作废时返回 false
+func (r *InviteCodeRepository) Revoke(ctx context.Context, id int64) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.InviteCode{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// List 分页获取邀请码，附带邀请人
+func (r *InviteCodeRepository) List(ctx context.Context, filter *InviteCodeFilter, skip, limit int) ([]model.InviteCode, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.InviteCode{})
+	now := time.Now()
+	switch filter.Status {
+	case InviteCodeStatusActive:
+		query = query.Where("revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?) AND (max_uses = 0 OR used_count < max_uses)", now)
+	case InviteCodeStatusUsedUp:
+		query = query.Where("revoked_at IS NULL AND max_uses > 0 AND used_count >= max_uses")
+	case InviteCodeStatusExpired:
+		query = query.Where("revoked_at IS NULL AND expires_at <= ?", now)
+	case InviteCodeStatusRevoked:
+		query = query.Where("revoked_at IS NOT NULL")
+	}
+	if filter.InviterID != 0 {
+		query = query.Where("inviter_id = ?", filter.InviterID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var codes []model.InviteCode
+	err := query.Preload("Inviter", withDeleted).
+		Order("id DESC").
+		Offset(skip).Limit(limit).
+		Find(&codes).Error
+	return codes, total, err
+}
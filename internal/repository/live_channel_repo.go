@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type LiveChannelRepository struct {
+	db *gorm.DB
+}
+
+func NewLiveChannelRepository(db *gorm.DB) *LiveChannelRepository {
+	return &LiveChannelRepository{db: db}
+}
+
+// Create 创建直播频道
+func (r *LiveChannelRepository) Create(ctx context.Context, channel *model.LiveChannel) error {
+	return conn(ctx, r.db).Create(channel).Error
+}
+
+// GetByUser 获取用户的直播频道
+func (r *LiveChannelRepository) GetByUser(ctx context.Context, userID int64) (*model.LiveChannel, error) {
+	var channel model.LiveChannel
+	err := conn(ctx, r.db).Where("user_id = ?", userID).First(&channel).Error
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// GetByStreamKey 根据推流码查询频道
+func (r *LiveChannelRepository) GetByStreamKey(ctx context.Context, streamKey string) (*model.LiveChannel, error) {
+	var channel model.LiveChannel
+	err := conn(ctx, r.db).Where("stream_key = ?", streamKey).First(&channel).Error
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// Update 更新频道
+func (r *LiveChannelRepository) Update(ctx context.Context, id int64, updates map[string]interface{}) error {
+	return conn(ctx, r.db).Model(&model.LiveChannel{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type LiveSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewLiveSessionRepository(db *gorm.DB) *LiveSessionRepository {
+	return &LiveSessionRepository{db: db}
+}
+
+// Create 创建直播场次
+func (r *LiveSessionRepository) Create(ctx context.Context, session *model.LiveSession) error {
+	return conn(ctx, r.db).Create(session).Error
+}
+
+// GetByIDWithUser 获取直播场次，附带主播信息
+func (r *LiveSessionRepository) GetByIDWithUser(ctx context.Context, id int64) (*model.LiveSession, error) {
+	var session model.LiveSession
+	err := conn(ctx, r.db).Preload("User").First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// EndActive 结束频道正在进行的场次，返回结束的场次数
+func (r *LiveSessionRepository) EndActive(ctx context.Context, channelID int64, endedAt time.Time) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.LiveSession{}).
+		Where("channel_id = ? AND status = ?", channelID, model.LiveSessionLive).
+		Updates(map[string]interface{}{"status": model.LiveSessionEnded, "ended_at": endedAt})
+	return result.RowsAffected, result.Error
+}
+
+// GetLatestWithoutVideo 获取频道最近一场尚未生成录像视频的场次
+func (r *LiveSessionRepository) GetLatestWithoutVideo(ctx context.Context, channelID int64) (*model.LiveSession, error) {
+	var session model.LiveSession
+	err := conn(ctx, r.db).
+		Where("channel_id = ? AND video_id IS NULL", channelID).
+		Order("id DESC").
+		First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// SetVideo 记录录像生成的视频，已记录过时返回 false
+func (r *LiveSessionRepository) SetVideo(ctx context.Context, id, videoID int64) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.LiveSession{}).
+		Where("id = ? AND video_id IS NULL", id).
+		Update("video_id", videoID)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListLive 分页获取正在直播的场次，附带主播信息，最新开播的在前；跳过已删除或保全中的主播
+func (r *LiveSessionRepository) ListLive(ctx context.Context, skip, limit int) ([]model.LiveSession, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.LiveSession{}).
+		Joins("JOIN users ON users.id = live_sessions.user_id AND users.deleted_at IS NULL AND users.legal_hold_at IS NULL").
+		Where("live_sessions.status = ?", model.LiveSessionLive)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sessions []model.LiveSession
+	err := query.Preload("User").
+		Order("live_sessions.started_at DESC, live_sessions.id DESC").
+		Offset(skip).Limit(limit).
+		Find(&sessions).Error
+	return sessions, total, err
+}
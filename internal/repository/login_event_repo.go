@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type LoginEventRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginEventRepository(db *gorm.DB) *LoginEventRepository {
+	return &LoginEventRepository{db: db}
+}
+
+// Create 新增登录记录
+func (r *LoginEventRepository) Create(ctx context.Context, event *model.LoginEvent) error {
+	return conn(ctx, r.db).Create(event).Error
+}
+
+// GetByUser 获取用户的某条登录记录
+func (r *LoginEventRepository) GetByUser(ctx context.Context, userID, id int64) (*model.LoginEvent, error) {
+	var event model.LoginEvent
+	err := conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// HasAny 用户是否有过登录记录
+func (r *LoginEventRepository) HasAny(ctx context.Context, userID int64) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.LoginEvent{}).Where("user_id = ?", userID).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// HasDevice 用户是否用该设备登录过
+func (r *LoginEventRepository) HasDevice(ctx context.Context, userID int64, device string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.LoginEvent{}).Where("user_id = ? AND device = ?", userID, device).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// HasCountry 用户是否在该国家/地区登录过
+func (r *LoginEventRepository) HasCountry(ctx context.Context, userID int64, country string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.LoginEvent{}).Where("user_id = ? AND country = ?", userID, country).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// ListByUser 分页获取用户的登录记录，最近的在前
+func (r *LoginEventRepository) ListByUser(ctx context.Context, userID int64, skip, limit int) ([]model.LoginEvent, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.LoginEvent{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []model.LoginEvent
+	err := query.Order("id DESC").Offset(skip).Limit(limit).Find(&events).Error
+	return events, total, err
+}
+
+// MarkReported 标记为非本人登录，已标记过时返回 false
+func (r *LoginEventRepository) MarkReported(ctx context.Context, id int64) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.LoginEvent{}).
+		Where("id = ? AND reported_at IS NULL", id).
+		Update("reported_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
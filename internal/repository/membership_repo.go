@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type MembershipRepository struct {
+	db *gorm.DB
+}
+
+func NewMembershipRepository(db *gorm.DB) *MembershipRepository {
+	return &MembershipRepository{db: db}
+}
+
+// CreateTier 创建会员等级
+func (r *MembershipRepository) CreateTier(ctx context.Context, tier *model.MembershipTier) error {
+	return conn(ctx, r.db).Create(tier).Error
+}
+
+// GetTier 获取会员等级（含已下架）
+func (r *MembershipRepository) GetTier(ctx context.Context, id int64) (*model.MembershipTier, error) {
+	var tier model.MembershipTier
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&tier).Error; err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+// UpdateTier 更新会员等级并返回最新记录
+func (r *MembershipRepository) UpdateTier(ctx context.Context, id int64, updates map[string]interface{}) (*model.MembershipTier, error) {
+	if err := conn(ctx, r.db).Model(&model.MembershipTier{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	return r.GetTier(ctx, id)
+}
+
+// ListTiers 按等级从低到高列出创作者的会员等级，includeArchived 为 false 时只返回上架中的
+func (r *MembershipRepository) ListTiers(ctx context.Context, creatorID int64, includeArchived bool) ([]model.MembershipTier, error) {
+	query := replica(r.db).WithContext(ctx).Where("creator_id = ?", creatorID)
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	var tiers []model.MembershipTier
+	err := query.Order("level ASC").Order("id ASC").Find(&tiers).Error
+	return tiers, err
+}
+
+// GetMembership 获取用户在创作者处的会员资格（含已过期），附带会员等级
+func (r *MembershipRepository) GetMembership(ctx context.Context, creatorID, userID int64) (*model.Membership, error) {
+	var m model.Membership
+	err := conn(ctx, r.db).Preload("Tier").
+		Where("creator_id = ? AND user_id = ?", creatorID, userID).
+		First(&m).Error
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// SaveMembership 新建或更新会员资格
+func (r *MembershipRepository) SaveMembership(ctx context.Context, m *model.Membership) error {
+	return conn(ctx, r.db).Omit("Tier", "User").Save(m).Error
+}
+
+// ActiveLevel 用户在创作者处有效会员资格的等级，不是会员时返回 0
+func (r *MembershipRepository) ActiveLevel(ctx context.Context, creatorID, userID int64, now time.Time) (int, error) {
+	var level int
+	err := replica(r.db).WithContext(ctx).Table("memberships").
+		Select("membership_tiers.level").
+		Joins("JOIN membership_tiers ON membership_tiers.id = memberships.tier_id").
+		Where("memberships.creator_id = ? AND memberships.user_id = ? AND memberships.expires_at > ?", creatorID, userID, now).
+		Limit(1).Scan(&level).Error
+	return level, err
+}
+
+// ActiveBadges 批量获取用户在创作者处有效会员资格的徽章，不是会员或等级没有徽章的用户不返回
+func (r *MembershipRepository) ActiveBadges(ctx context.Context, creatorID int64, userIDs []int64, now time.Time) (map[int64]string, error) {
+	if len(userIDs) == 0 {
+		return map[int64]string{}, nil
+	}
+	var rows []struct {
+		UserID int64
+		Badge  string
+	}
+	err := replica(r.db).WithContext(ctx).Table("memberships").
+		Select("memberships.user_id, membership_tiers.badge").
+		Joins("JOIN membership_tiers ON membership_tiers.id = memberships.tier_id").
+		Where("memberships.creator_id = ? AND memberships.user_id IN ? AND memberships.expires_at > ? AND membership_tiers.badge <> ''", creatorID, userIDs, now).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	badges := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		badges[row.UserID] = row.Badge
+	}
+	return badges, nil
+}
+
+// ListMembers 分页列出创作者的有效会员（按加入时间倒序），tierID 不为空时只返回该等级
+func (r *MembershipRepository) ListMembers(ctx context.Context, creatorID int64, tierID *int64, now time.Time, skip, limit int) ([]model.Membership, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Membership{}).
+		Where("creator_id = ? AND expires_at > ?", creatorID, now)
+	if tierID != nil {
+		query = query.Where("tier_id = ?", *tierID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var members []model.Membership
+	err := query.Preload("Tier").Preload("User", withDeleted).
+		Order("id DESC").Offset(skip).Limit(limit).Find(&members).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return members, total, nil
+}
+
+// ListByUser 列出用户的有效会员资格（按到期时间正序）
+func (r *MembershipRepository) ListByUser(ctx context.Context, userID int64, now time.Time) ([]model.Membership, error) {
+	var members []model.Membership
+	err := replica(r.db).WithContext(ctx).Preload("Tier").
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Order("expires_at ASC").Find(&members).Error
+	return members, err
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MessageRepository struct {
+	db *gorm.DB
+}
+
+func NewMessageRepository(db *gorm.DB) *MessageRepository {
+	return &MessageRepository{db: db}
+}
+
+// GetOrCreateConversation 获取两名用户之间的会话，不存在则创建
+func (r *MessageRepository) GetOrCreateConversation(ctx context.Context, userA, userB int64) (*model.Conversation, error) {
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+	conv := &model.Conversation{UserAID: userA, UserBID: userB}
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(conv).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var existing model.Conversation
+	err = conn(ctx, r.db).Where("user_a_id = ? AND user_b_id = ?", userA, userB).First(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// GetConversation 根据 ID 获取会话
+func (r *MessageRepository) GetConversation(ctx context.Context, id int64) (*model.Conversation, error) {
+	var conv model.Conversation
+	if err := conn(ctx, r.db).First(&conv, id).Error; err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
+
+// AppendMessage 写入消息并更新会话摘要与接收方未读数（同一事务）
+func (r *MessageRepository) AppendMessage(ctx context.Context, conv *model.Conversation, msg *model.Message, preview string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(msg).Error; err != nil {
+			return err
+		}
+
+		unreadColumn := "user_b_unread"
+		if msg.SenderID == conv.UserBID {
+			unreadColumn = "user_a_unread"
+		}
+		return tx.Model(&model.Conversation{}).Where("id = ?", conv.ID).Updates(map[string]interface{}{
+			"last_message_id":      msg.ID,
+			"last_sender_id":       msg.SenderID,
+			"last_message_content": preview,
+			"last_message_at":      msg.CreatedAt,
+			unreadColumn:           gorm.Expr(unreadColumn + " + 1"),
+		}).Error
+	})
+}
+
+// ListConversations 获取用户的会话列表（按最后消息时间倒序，不含空会话）
+func (r *MessageRepository) ListConversations(ctx context.Context, userID int64, skip, limit int) ([]model.Conversation, int64, error) {
+	query := conn(ctx, r.db).Model(&model.Conversation{}).
+		Where("(user_a_id = ? OR user_b_id = ?) AND last_message_id > 0", userID, userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var convs []model.Conversation
+	err := query.Order("last_message_at DESC").Offset(skip).Limit(limit).Find(&convs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return convs, total, nil
+}
+
+// ListConversationsBefore 按（最后消息时间, ID）倒序游标分页获取会话，beforeAt 为 nil 时从最新开始
+func (r *MessageRepository) ListConversationsBefore(ctx context.Context, userID int64, beforeAt *time.Time, beforeID int64, limit int) ([]model.Conversation, error) {
+	query := conn(ctx, r.db).
+		Where("(user_a_id = ? OR user_b_id = ?) AND last_message_id > 0", userID, userID)
+	if beforeAt != nil {
+		query = query.Where("last_message_at < ? OR (last_message_at = ? AND id < ?)", *beforeAt, *beforeAt, beforeID)
+	}
+	var convs []model.Conversation
+	err := query.Order("last_message_at DESC, id DESC").Limit(limit).Find(&convs).Error
+	return convs, err
+}
+
+// ListMessages 按 ID 倒序分页获取会话消息，beforeID 为 0 时从最新开始
+func (r *MessageRepository) ListMessages(ctx context.Context, conversationID, beforeID int64, limit int) ([]model.Message, error) {
+	query := conn(ctx, r.db).Where("conversation_id = ?", conversationID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var messages []model.Message
+	err := query.Order("id DESC").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// MarkRead 清空用户在会话中的未读数
+func (r *MessageRepository) MarkRead(ctx context.Context, conv *model.Conversation, userID int64) error {
+	column := "user_a_unread"
+	if conv.UserBID == userID {
+		column = "user_b_unread"
+	}
+	return conn(ctx, r.db).Model(&model.Conversation{}).Where("id = ?", conv.ID).Update(column, 0).Error
+}
+
+// CountUnread 统计用户所有会话的未读消息总数
+func (r *MessageRepository) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	var total int64
+	err := conn(ctx, r.db).Model(&model.Conversation{}).
+		Select("COALESCE(SUM(CASE WHEN user_a_id = ? THEN user_a_unread ELSE user_b_unread END), 0)", userID).
+		Where("user_a_id = ? OR user_b_id = ?", userID, userID).
+		Scan(&total).Error
+	return total, err
+}
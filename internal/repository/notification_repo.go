@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n *model.Notification) error {
+	return conn(ctx, r.db).Create(n).Error
+}
+
+// ListByUser 分页查询用户的通知（按时间倒序，预加载触发用户）
+func (r *NotificationRepository) ListByUser(ctx context.Context, userID int64, unreadOnly bool, notifType string, skip, limit int) ([]model.Notification, int64, error) {
+	query := conn(ctx, r.db).Model(&model.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+	if notifType != "" {
+		query = query.Where("type = ?", notifType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []model.Notification
+	err := query.Preload("Actor", withDeleted).Order("id DESC").Offset(skip).Limit(limit).Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// ListByUserBefore 按 ID 倒序游标分页查询用户的通知
+func (r *NotificationRepository) ListByUserBefore(ctx context.Context, userID int64, unreadOnly bool, notifType string, beforeID int64, limit int) ([]model.Notification, error) {
+	query := conn(ctx, r.db).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+	if notifType != "" {
+		query = query.Where("type = ?", notifType)
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var notifications []model.Notification
+	err := query.Preload("Actor", withDeleted).Order("id DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+// CountUnread 统计用户未读通知数
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead 将用户的指定通知标记为已读，返回实际更新条数
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID int64, ids []int64) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.Notification{}).
+		Where("user_id = ? AND id IN ? AND is_read = ?", userID, ids, false).
+		Update("is_read", true)
+	return result.RowsAffected, result.Error
+}
+
+// MarkAllRead 将用户全部未读通知标记为已读，返回实际更新条数
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Update("is_read", true)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type OAuthClientRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepository(db *gorm.DB) *OAuthClientRepository {
+	return &OAuthClientRepository{db: db}
+}
+
+// Create 注册应用
+func (r *OAuthClientRepository) Create(ctx context.Context, client *model.OAuthClient) error {
+	return conn(ctx, r.db).Create(client).Error
+}
+
+// GetByClientID 根据 client_id 查询未作废的应用
+func (r *OAuthClientRepository) GetByClientID(ctx context.Context, clientID string) (*model.OAuthClient, error) {
+	var client model.OAuthClient
+	err := conn(ctx, r.db).Where("client_id = ? AND revoked_at IS NULL", clientID).First(&client).Error
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// ListByOwner 获取用户注册的应用（含已作废），按创建时间倒序
+func (r *OAuthClientRepository) ListByOwner(ctx context.Context, ownerID int64) ([]model.OAuthClient, error) {
+	var clients []model.OAuthClient
+	err := conn(ctx, r.db).Where("owner_id = ?", ownerID).Order("id DESC").Find(&clients).Error
+	return clients, err
+}
+
+// CountActiveByOwner 统计用户未作废的应用数
+func (r *OAuthClientRepository) CountActiveByOwner(ctx context.Context, ownerID int64) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.OAuthClient{}).
+		Where("owner_id = ? AND revoked_at IS NULL", ownerID).Count(&count).Error
+	return count, err
+}
+
+// Revoke 作废用户的应用，返回是否作废成功（不存在或已作废时为 false）
+func (r *OAuthClientRepository) Revoke(ctx context.Context, id, ownerID int64) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.OAuthClient{}).
+		Where("id = ? AND owner_id = ? AND revoked_at IS NULL", id, ownerID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type OAuthCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthCodeRepository(db *gorm.DB) *OAuthCodeRepository {
+	return &OAuthCodeRepository{db: db}
+}
+
+// Create 保存授权码
+func (r *OAuthCodeRepository) Create(ctx context.Context, code *model.OAuthAuthorizationCode) error {
+	return conn(ctx, r.db).Create(code).Error
+}
+
+// GetByHash 根据授权码摘要查询
+func (r *OAuthCodeRepository) GetByHash(ctx context.Context, codeHash string) (*model.OAuthAuthorizationCode, error) {
+	var code model.OAuthAuthorizationCode
+	err := conn(ctx, r.db).Where("code_hash = ?", codeHash).First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// Consume 使用授权码：仅在未使用且未过期时标记为已使用，返回是否成功
+func (r *OAuthCodeRepository) Consume(ctx context.Context, id int64, now time.Time) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.OAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", id, now).
+		Update("used_at", now)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PollRepository struct {
+	db *gorm.DB
+}
+
+func NewPollRepository(db *gorm.DB) *PollRepository {
+	return &PollRepository{db: db}
+}
+
+// Create 创建投票及其选项
+func (r *PollRepository) Create(ctx context.Context, poll *model.VideoPoll) error {
+	return conn(ctx, r.db).Create(poll).Error
+}
+
+// GetByVideo 获取视频的投票（含选项，按排列顺序）
+func (r *PollRepository) GetByVideo(ctx context.Context, videoID int64) (*model.VideoPoll, error) {
+	var poll model.VideoPoll
+	err := conn(ctx, r.db).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC").Order("id ASC")
+		}).
+		Where("video_id = ?", videoID).
+		First(&poll).Error
+	if err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+// UpdateEndsAt 修改截止时间
+func (r *PollRepository) UpdateEndsAt(ctx context.Context, pollID int64, endsAt time.Time) error {
+	return conn(ctx, r.db).Model(&model.VideoPoll{}).Where("id = ?", pollID).Update("ends_at", endsAt).Error
+}
+
+// Delete 删除投票及其选项和投票记录
+func (r *PollRepository) Delete(ctx context.Context, pollID int64) error {
+	db := conn(ctx, r.db)
+	if err := db.Where("poll_id = ?", pollID).Delete(&model.VideoPollVote{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("poll_id = ?", pollID).Delete(&model.VideoPollOption{}).Error; err != nil {
+		return err
+	}
+	return db.Delete(&model.VideoPoll{}, pollID).Error
+}
+
+// Vote 记录投票，返回是否新增；用户已投过票时不变
+func (r *PollRepository) Vote(ctx context.Context, vote *model.VideoPollVote) (bool, error) {
+	result := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(vote)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetUserVote 获取用户所投的选项ID，未投票返回 0
+func (r *PollRepository) GetUserVote(ctx context.Context, pollID, userID int64) (int64, error) {
+	var vote model.VideoPollVote
+	err := conn(ctx, r.db).Select("option_id").
+		Where("poll_id = ? AND user_id = ?", pollID, userID).
+		First(&vote).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return vote.OptionID, err
+}
+
+// CountVotes 按选项统计票数，没有票的选项不返回
+func (r *PollRepository) CountVotes(ctx context.Context, pollID int64) (map[int64]int64, error) {
+	var rows []struct {
+		OptionID int64
+		Count    int64
+	}
+	err := conn(ctx, r.db).Model(&model.VideoPollVote{}).
+		Select("option_id, COUNT(*) AS count").
+		Where("poll_id = ?", pollID).
+		Group("option_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		counts[row.OptionID] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type ProfileImageReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewProfileImageReviewRepository(db *gorm.DB) *ProfileImageReviewRepository {
+	return &ProfileImageReviewRepository{db: db}
+}
+
+// Create 创建审核记录
+func (r *ProfileImageReviewRepository) Create(ctx context.Context, review *model.ProfileImageReview) error {
+	return conn(ctx, r.db).Create(review).Error
+}
+
+// GetByID 获取审核记录
+func (r *ProfileImageReviewRepository) GetByID(ctx context.Context, id int64) (*model.ProfileImageReview, error) {
+	var review model.ProfileImageReview
+	err := conn(ctx, r.db).First(&review, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// SupersedePending 将用户该类型图片的待审核记录标记为已被替换
+func (r *ProfileImageReviewRepository) SupersedePending(ctx context.Context, userID int64, kind string) error {
+	return conn(ctx, r.db).Model(&model.ProfileImageReview{}).
+		Where("user_id = ? AND kind = ? AND status = ?", userID, kind, model.ProfileImagePending).
+		Update("status", model.ProfileImageSuperseded).Error
+}
+
+// Resolve 将处于 from 状态的记录更新为 to，记录已被处理（状态不是 from）时返回 false
+func (r *ProfileImageReviewRepository) Resolve(ctx context.Context, id int64, from, to string, reviewedBy *int64, reason string) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.ProfileImageReview{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{
+			"status":      to,
+			"reason":      reason,
+			"reviewed_by": reviewedBy,
+			"reviewed_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListPendingByUser 获取用户各类型图片的待审核记录
+func (r *ProfileImageReviewRepository) ListPendingByUser(ctx context.Context, userID int64) ([]model.ProfileImageReview, error) {
+	var reviews []model.ProfileImageReview
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND status = ?", userID, model.ProfileImagePending).
+		Find(&reviews).Error
+	return reviews, err
+}
+
+// List 按状态分页获取审核记录（status 为空表示不限），附带用户信息，最早提交的在前
+func (r *ProfileImageReviewRepository) List(ctx context.Context, status string, skip, limit int) ([]model.ProfileImageReview, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.ProfileImageReview{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reviews []model.ProfileImageReview
+	err := query.Preload("User", withDeleted).
+		Order("id ASC").
+		Offset(skip).Limit(limit).
+		Find(&reviews).Error
+	return reviews, total, err
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// purgeChunkSize 按 ID 列表更新时每条语句的 ID 数上限
+const purgeChunkSize = 500
+
+// purgeStep 清理时处理的一类关联记录：删除 table 中满足 where 的行，setNull 非空时改为将该列置空
+// （保留其他用户的数据）。where 中用 @ids 引用待清理的用户或视频 ID
+type purgeStep struct {
+	table   string
+	where   string
+	setNull string
+}
+
+// key 报告中的名称：删除为表名，置空为 表名.列名
+func (s purgeStep) key() string {
+	if s.setNull != "" {
+		return s.table + "." + s.setNull
+	}
+	return s.table
+}
+
+// videoPurgeSteps 永久删除视频前按顺序处理的关联记录，最后删除视频本身
+var videoPurgeSteps = []purgeStep{
+	{table: "notifications", where: "video_id IN @ids OR comment_id IN (SELECT id FROM comments WHERE video_id IN @ids)"},
+	{table: "comments", where: "video_id IN @ids AND parent_id IS NOT NULL", setNull: "parent_id"},
+	{table: "comments", where: "video_id IN @ids"},
+	{table: "favorites", where: "video_id IN @ids"},
+	{table: "watch_histories", where: "video_id IN @ids"},
+	{table: "video_daily_stats", where: "video_id IN @ids"},
+	{table: "video_renditions", where: "video_id IN @ids"},
+	{table: "video_fingerprints", where: "video_id IN @ids"},
+	{table: "video_duplicates", where: "video_id IN @ids OR duplicate_of_id IN @ids"},
+	{table: "video_tags", where: "video_id IN @ids"},
+	{table: "explore_slots", where: "video_id IN @ids"},
+	{table: "video_access_grants", where: "video_id IN @ids"},
+	{table: "video_poll_votes", where: "poll_id IN (SELECT id FROM video_polls WHERE video_id IN @ids)"},
+	{table: "video_poll_options", where: "poll_id IN (SELECT id FROM video_polls WHERE video_id IN @ids)"},
+	{table: "video_polls", where: "video_id IN @ids"},
+	{table: "live_sessions", where: "video_id IN @ids", setNull: "video_id"},
+	{table: "videos", where: "duplicate_of_id IN @ids", setNull: "duplicate_of_id"},
+	{table: "videos", where: "remix_of_id IN @ids", setNull: "remix_of_id"},
+	{table: "videos", where: "id IN @ids"},
+}
+
+// userPurgeSteps 永久删除用户前按顺序处理的关联记录（用户的视频需先清理），最后删除用户本身。
+// 审计日志与金币流水保留（余额清零）；评论下他人的回复保留，改为顶层评论
+var userPurgeSteps = []purgeStep{
+	{table: "notifications", where: "user_id IN @ids OR actor_id IN @ids OR comment_id IN (SELECT id FROM comments WHERE user_id IN @ids)"},
+	{table: "comments", where: "user_id IN @ids"},
+	{table: "favorites", where: "user_id IN @ids"},
+	{table: "relations", where: "follow_id IN @ids OR follower_id IN @ids"},
+	{table: "watch_histories", where: "user_id IN @ids"},
+	{table: "video_access_grants", where: "user_id IN @ids"},
+	{table: "video_poll_votes", where: "user_id IN @ids"},
+	{table: "messages", where: "conversation_id IN (SELECT id FROM conversations WHERE user_a_id IN @ids OR user_b_id IN @ids)"},
+	{table: "conversations", where: "user_a_id IN @ids OR user_b_id IN @ids"},
+	{table: "device_tokens", where: "user_id IN @ids"},
+	{table: "login_events", where: "user_id IN @ids"},
+	{table: "user_settings", where: "user_id IN @ids"},
+	{table: "wallets", where: "user_id IN @ids"},
+	{table: "memberships", where: "user_id IN @ids OR creator_id IN @ids"},
+	{table: "membership_tiers", where: "creator_id IN @ids"},
+	{table: "profile_image_reviews", where: "user_id IN @ids"},
+	{table: "oauth_authorization_codes", where: "user_id IN @ids OR client_id IN (SELECT id FROM oauth_clients WHERE owner_id IN @ids)"},
+	{table: "oauth_clients", where: "owner_id IN @ids"},
+	{table: "live_sessions", where: "user_id IN @ids"},
+	{table: "live_channels", where: "user_id IN @ids"},
+	{table: "reports", where: "reporter_id IN @ids OR target_user_id IN @ids"},
+	{table: "users", where: "invite_code_id IN (SELECT id FROM invite_codes WHERE inviter_id IN @ids)", setNull: "invite_code_id"},
+	{table: "users", where: "invited_by_id IN @ids", setNull: "invited_by_id"},
+	{table: "invite_codes", where: "inviter_id IN @ids"},
+	{table: "users", where: "id IN @ids"},
+}
+
+// PurgeRepository 永久删除软删除的用户、视频及其关联记录
+type PurgeRepository struct {
+	db *gorm.DB
+}
+
+func NewPurgeRepository(db *gorm.DB) *PurgeRepository {
+	return &PurgeRepository{db: db}
+}
+
+// ListPurgeableUsers 按 ID 正序返回 afterID 之后、删除时间早于 before 的用户，
+// 账号或其任一视频处于法律保全中时跳过
+func (r *PurgeRepository) ListPurgeableUsers(ctx context.Context, before time.Time, afterID int64, limit int) ([]model.User, error) {
+	var users []model.User
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id > ? AND deleted_at IS NOT NULL AND deleted_at < ? AND legal_hold_at IS NULL", afterID, before).
+		Where("NOT EXISTS (SELECT 1 FROM videos WHERE videos.author_id = users.id AND videos.legal_hold_at IS NOT NULL)").
+		Order("id ASC").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// ListPurgeableVideos 按 ID 正序返回 afterID 之后、删除时间早于 before 的视频，
+// 视频或作者处于法律保全中时跳过
+func (r *PurgeRepository) ListPurgeableVideos(ctx context.Context, before time.Time, afterID int64, limit int) ([]model.Video, error) {
+	var videos []model.Video
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id > ? AND deleted_at IS NOT NULL AND deleted_at < ? AND legal_hold_at IS NULL", afterID, before).
+		Where("NOT EXISTS (SELECT 1 FROM users WHERE users.id = videos.author_id AND users.legal_hold_at IS NOT NULL)").
+		Order("id ASC").Limit(limit).Find(&videos).Error
+	return videos, err
+}
+
+// ListVideosByAuthors 返回这些作者的全部视频（含软删除）
+func (r *PurgeRepository) ListVideosByAuthors(ctx context.Context, authorIDs []int64) ([]model.Video, error) {
+	var videos []model.Video
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("author_id IN ?", authorIDs).
+		Order("id ASC").Find(&videos).Error
+	return videos, err
+}
+
+// ListProfileImageURLs 返回这些用户提交过的头像、背景图地址（含审核中与已驳回的）
+func (r *PurgeRepository) ListProfileImageURLs(ctx context.Context, userIDs []int64) ([]string, error) {
+	var urls []string
+	err := r.db.WithContext(ctx).Model(&model.ProfileImageReview{}).
+		Where("user_id IN ?", userIDs).Distinct().Pluck("image_url", &urls).Error
+	return urls, err
+}
+
+// AffectedByVideos 返回清理这些视频后计数需要重新计算的用户（作者与点赞者）
+func (r *PurgeRepository) AffectedByVideos(ctx context.Context, videoIDs []int64) (userIDs []int64, err error) {
+	err = r.db.WithContext(ctx).Raw(
+		"SELECT user_id FROM favorites WHERE video_id IN @ids UNION SELECT author_id FROM videos WHERE id IN @ids",
+		sql.Named("ids", videoIDs),
+	).Scan(&userIDs).Error
+	return userIDs, err
+}
+
+// AffectedByUsers 返回清理这些用户后计数需要重新计算的视频（点赞、评论过的）与用户（关注关系另一方、被点赞视频的作者）
+func (r *PurgeRepository) AffectedByUsers(ctx context.Context, ids []int64) (videoIDs, userIDs []int64, err error) {
+	db := r.db.WithContext(ctx)
+	named := sql.Named("ids", ids)
+	err = db.Raw(
+		"SELECT video_id FROM favorites WHERE user_id IN @ids UNION SELECT video_id FROM comments WHERE user_id IN @ids",
+		named,
+	).Scan(&videoIDs).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	err = db.Raw(
+		"SELECT follow_id FROM relations WHERE follower_id IN @ids "+
+			"UNION SELECT follower_id FROM relations WHERE follow_id IN @ids "+
+			"UNION SELECT videos.author_id FROM favorites JOIN videos ON videos.id = favorites.video_id WHERE favorites.user_id IN @ids",
+		named,
+	).Scan(&userIDs).Error
+	return videoIDs, userIDs, err
+}
+
+// PurgeVideos 在一个事务中永久删除视频及其关联记录，返回各表删除（置空）的行数；
+// dryRun 时只统计不修改
+func (r *PurgeRepository) PurgeVideos(ctx context.Context, ids []int64, dryRun bool) (map[string]int64, error) {
+	return r.purge(ctx, ids, videoPurgeSteps, nil, dryRun)
+}
+
+// PurgeUsers 在一个事务中永久删除用户及其关联记录（用户的视频需先通过 PurgeVideos 清理），
+// 返回各表删除（置空）的行数；dryRun 时只统计不修改
+func (r *PurgeRepository) PurgeUsers(ctx context.Context, ids []int64, dryRun bool) (map[string]int64, error) {
+	return r.purge(ctx, ids, userPurgeSteps, detachReplies, dryRun)
+}
+
+func (r *PurgeRepository) purge(ctx context.Context, ids []int64, steps []purgeStep, before func(tx *gorm.DB, ids []int64, dryRun bool, counts map[string]int64) error, dryRun bool) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if len(ids) == 0 {
+		return counts, nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if before != nil {
+			if err := before(tx, ids, dryRun, counts); err != nil {
+				return err
+			}
+		}
+		for _, step := range steps {
+			n, err := runPurgeStep(tx, step, ids, dryRun)
+			if err != nil {
+				return fmt.Errorf("purge %s: %w", step.key(), err)
+			}
+			if n > 0 {
+				counts[step.key()] += n
+			}
+		}
+		if dryRun {
+			// 只读统计也放在事务中，回滚以免留下任何修改
+			return errDryRunRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRunRollback) {
+		return nil, err
+	}
+	return counts, nil
+}
+
+var errDryRunRollback = errors.New("dry run")
+
+func runPurgeStep(tx *gorm.DB, step purgeStep, ids []int64, dryRun bool) (int64, error) {
+	named := sql.Named("ids", ids)
+	if dryRun {
+		var n int64
+		err := tx.Table(step.table).Where(step.where, named).Count(&n).Error
+		return n, err
+	}
+	var res *gorm.DB
+	if step.setNull != "" {
+		res = tx.Exec(fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s", step.table, step.setNull, step.where), named)
+	} else {
+		res = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", step.table, step.where), named)
+	}
+	return res.RowsAffected, res.Error
+}
+
+// detachReplies 删除用户的评论前，把他人对这些评论的回复改为顶层评论。
+// MySQL 不允许在更新 comments 时子查询 comments，因此先取出评论 ID
+func detachReplies(tx *gorm.DB, userIDs []int64, dryRun bool, counts map[string]int64) error {
+	var commentIDs []int64
+	if err := tx.Model(&model.Comment{}).Unscoped().Where("user_id IN ?", userIDs).Pluck("id", &commentIDs).Error; err != nil {
+		return err
+	}
+	for start := 0; start < len(commentIDs); start += purgeChunkSize {
+		chunk := commentIDs[start:min(start+purgeChunkSize, len(commentIDs))]
+		query := tx.Model(&model.Comment{}).Unscoped().Where("parent_id IN ?", chunk)
+		var n int64
+		var err error
+		if dryRun {
+			err = query.Count(&n).Error
+		} else {
+			res := query.UpdateColumn("parent_id", nil)
+			n, err = res.RowsAffected, res.Error
+		}
+		if err != nil {
+			return fmt.Errorf("purge comments.parent_id: %w", err)
+		}
+		counts["comments.parent_id"] += n
+	}
+	return nil
+}
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"vida-go/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RelationRepository struct {
@@ -15,20 +19,33 @@ func NewRelationRepository(db *gorm.DB) *RelationRepository {
 }
 
 // Create 创建关注关系
-func (r *RelationRepository) Create(followerID, followID int64) (*model.Relation, error) {
+func (r *RelationRepository) Create(ctx context.Context, followerID, followID int64) (*model.Relation, error) {
 	relation := &model.Relation{
 		FollowerID: followerID,
 		FollowID:   followID,
 	}
-	if err := r.db.Create(relation).Error; err != nil {
+	if err := conn(ctx, r.db).Create(relation).Error; err != nil {
 		return nil, err
 	}
 	return relation, nil
 }
 
+// bulkInsertBatchSize 批量导入时单条 INSERT 的行数
+const bulkInsertBatchSize = 500
+
+// BulkCreate 批量导入关注关系，已存在的关系跳过，返回实际插入的条数
+func (r *RelationRepository) BulkCreate(ctx context.Context, relations []model.Relation) (int64, error) {
+	if len(relations) == 0 {
+		return 0, nil
+	}
+	result := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(&relations, bulkInsertBatchSize)
+	return result.RowsAffected, result.Error
+}
+
 // Delete 删除关注关系
-func (r *RelationRepository) Delete(followerID, followID int64) (bool, error) {
-	result := r.db.Where("follower_id = ? AND follow_id = ?", followerID, followID).
+func (r *RelationRepository) Delete(ctx context.Context, followerID, followID int64) (bool, error) {
+	result := conn(ctx, r.db).Where("follower_id = ? AND follow_id = ?", followerID, followID).
 		Delete(&model.Relation{})
 	if result.Error != nil {
 		return false, result.Error
@@ -37,18 +54,18 @@ func (r *RelationRepository) Delete(followerID, followID int64) (bool, error) {
 }
 
 // Exists 检查关注关系是否存在
-func (r *RelationRepository) Exists(followerID, followID int64) (bool, error) {
+func (r *RelationRepository) Exists(ctx context.Context, followerID, followID int64) (bool, error) {
 	var count int64
-	err := r.db.Model(&model.Relation{}).
+	err := conn(ctx, r.db).Model(&model.Relation{}).
 		Where("follower_id = ? AND follow_id = ?", followerID, followID).
 		Count(&count).Error
 	return count > 0, err
 }
 
 // GetFollowingList 获取用户的关注列表（分页）
-func (r *RelationRepository) GetFollowingList(userID int64, skip, limit int) ([]int64, error) {
+func (r *RelationRepository) GetFollowingList(ctx context.Context, userID int64, skip, limit int) ([]int64, error) {
 	var followIDs []int64
-	err := r.db.Model(&model.Relation{}).
+	err := replica(r.db).WithContext(ctx).Model(&model.Relation{}).
 		Where("follower_id = ?", userID).
 		Order("created_at DESC").
 		Offset(skip).Limit(limit).
@@ -57,9 +74,9 @@ func (r *RelationRepository) GetFollowingList(userID int64, skip, limit int) ([]
 }
 
 // GetFollowerList 获取用户的粉丝列表（分页）
-func (r *RelationRepository) GetFollowerList(userID int64, skip, limit int) ([]int64, error) {
+func (r *RelationRepository) GetFollowerList(ctx context.Context, userID int64, skip, limit int) ([]int64, error) {
 	var followerIDs []int64
-	err := r.db.Model(&model.Relation{}).
+	err := replica(r.db).WithContext(ctx).Model(&model.Relation{}).
 		Where("follow_id = ?", userID).
 		Order("created_at DESC").
 		Offset(skip).Limit(limit).
@@ -68,37 +85,69 @@ func (r *RelationRepository) GetFollowerList(userID int64, skip, limit int) ([]i
 }
 
 // CountFollowing 统计关注数
-func (r *RelationRepository) CountFollowing(userID int64) (int64, error) {
+func (r *RelationRepository) CountFollowing(ctx context.Context, userID int64) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Relation{}).Where("follower_id = ?", userID).Count(&count).Error
+	err := conn(ctx, r.db).Model(&model.Relation{}).Where("follower_id = ?", userID).Count(&count).Error
 	return count, err
 }
 
 // CountFollowers 统计粉丝数
-func (r *RelationRepository) CountFollowers(userID int64) (int64, error) {
+func (r *RelationRepository) CountFollowers(ctx context.Context, userID int64) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Relation{}).Where("follow_id = ?", userID).Count(&count).Error
+	err := conn(ctx, r.db).Model(&model.Relation{}).Where("follow_id = ?", userID).Count(&count).Error
 	return count, err
 }
 
+// DailyCount 按日期汇总的数量
+type DailyCount struct {
+	StatDate time.Time
+	Count    int64
+}
+
+// CountFollowersGainedByDay 按 UTC 日期统计 [from, to) 内新增且仍在关注的粉丝数
+func (r *RelationRepository) CountFollowersGainedByDay(ctx context.Context, userID int64, from, to time.Time) ([]DailyCount, error) {
+	var rows []struct {
+		StatDate string
+		Count    int64
+	}
+	err := conn(ctx, r.db).Model(&model.Relation{}).
+		Select(utcDate(r.db, "created_at")+" AS stat_date, COUNT(*) AS count").
+		Where("follow_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).
+		Group("stat_date").
+		Order("stat_date ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]DailyCount, 0, len(rows))
+	for _, row := range rows {
+		date, err := scanDate(row.StatDate)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, DailyCount{StatDate: date, Count: row.Count})
+	}
+	return counts, nil
+}
+
 // GetMutualFollowIDs 获取互相关注的用户 ID 列表（分页）
-func (r *RelationRepository) GetMutualFollowIDs(userID int64, skip, limit int) ([]int64, error) {
+func (r *RelationRepository) GetMutualFollowIDs(ctx context.Context, userID int64, skip, limit int) ([]int64, error) {
 	var mutualIDs []int64
 	// 子查询：我关注的人 ∩ 关注我的人
-	err := r.db.Raw(`
-		SELECT r1.follow_id FROM relations r1
-		INNER JOIN relations r2 ON r1.follow_id = r2.follower_id AND r2.follow_id = ?
-		WHERE r1.follower_id = ?
-		ORDER BY r1.created_at DESC
-		OFFSET ? LIMIT ?
-	`, userID, userID, skip, limit).Scan(&mutualIDs).Error
+	err := replica(r.db).WithContext(ctx).Table("relations r1").
+		Joins("INNER JOIN relations r2 ON r1.follow_id = r2.follower_id AND r2.follow_id = ?", userID).
+		Where("r1.follower_id = ?", userID).
+		Order("r1.created_at DESC").
+		Offset(skip).Limit(limit).
+		Pluck("r1.follow_id", &mutualIDs).Error
 	return mutualIDs, err
 }
 
 // CountMutualFollows 统计互相关注数
-func (r *RelationRepository) CountMutualFollows(userID int64) (int64, error) {
+func (r *RelationRepository) CountMutualFollows(ctx context.Context, userID int64) (int64, error) {
 	var count int64
-	err := r.db.Raw(`
+	err := replica(r.db).WithContext(ctx).Raw(`
 		SELECT COUNT(*) FROM relations r1
 		INNER JOIN relations r2 ON r1.follow_id = r2.follower_id AND r2.follow_id = ?
 		WHERE r1.follower_id = ?
@@ -107,13 +156,13 @@ func (r *RelationRepository) CountMutualFollows(userID int64) (int64, error) {
 }
 
 // BatchCheckFollowing 批量检查关注状态
-func (r *RelationRepository) BatchCheckFollowing(followerID int64, followIDs []int64) (map[int64]bool, error) {
+func (r *RelationRepository) BatchCheckFollowing(ctx context.Context, followerID int64, followIDs []int64) (map[int64]bool, error) {
 	if len(followIDs) == 0 {
 		return map[int64]bool{}, nil
 	}
 
 	var followedIDs []int64
-	err := r.db.Model(&model.Relation{}).
+	err := conn(ctx, r.db).Model(&model.Relation{}).
 		Where("follower_id = ? AND follow_id IN ?", followerID, followIDs).
 		Pluck("follow_id", &followedIDs).Error
 	if err != nil {
@@ -131,3 +180,39 @@ func (r *RelationRepository) BatchCheckFollowing(followerID int64, followIDs []i
 	}
 	return result, nil
 }
+
+// ListFollowingBefore 按关注记录 ID 倒序游标分页获取关注列表
+func (r *RelationRepository) ListFollowingBefore(ctx context.Context, userID, beforeID int64, limit int) ([]model.Relation, error) {
+	query := replica(r.db).WithContext(ctx).Where("follower_id = ?", userID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var relations []model.Relation
+	err := query.Order("id DESC").Limit(limit).Find(&relations).Error
+	return relations, err
+}
+
+// ListFollowersBefore 按关注记录 ID 倒序游标分页获取粉丝列表
+func (r *RelationRepository) ListFollowersBefore(ctx context.Context, userID, beforeID int64, limit int) ([]model.Relation, error) {
+	query := replica(r.db).WithContext(ctx).Where("follow_id = ?", userID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var relations []model.Relation
+	err := query.Order("id DESC").Limit(limit).Find(&relations).Error
+	return relations, err
+}
+
+// ListMutualBefore 按关注记录 ID 倒序游标分页获取互相关注列表（返回当前用户发起的关注记录）
+func (r *RelationRepository) ListMutualBefore(ctx context.Context, userID, beforeID int64, limit int) ([]model.Relation, error) {
+	query := replica(r.db).WithContext(ctx).Table("relations r1").
+		Select("r1.*").
+		Joins("INNER JOIN relations r2 ON r1.follow_id = r2.follower_id AND r2.follow_id = ?", userID).
+		Where("r1.follower_id = ?", userID)
+	if beforeID > 0 {
+		query = query.Where("r1.id < ?", beforeID)
+	}
+	var relations []model.Relation
+	err := query.Order("r1.id DESC").Limit(limit).Find(&relations).Error
+	return relations, err
+}
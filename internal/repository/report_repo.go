@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// ReportFilter 审核队列筛选条件，零值表示不限
+type ReportFilter struct {
+	Status       string
+	TargetType   string
+	TargetUserID int64
+	Escalated    bool // 只返回已升级的举报
+}
+
+// Create 新增举报，同一举报人对同一对象已举报过时返回 false
+func (r *ReportRepository) Create(ctx context.Context, report *model.Report) (bool, error) {
+	result := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetByID 获取举报
+func (r *ReportRepository) GetByID(ctx context.Context, id int64) (*model.Report, error) {
+	var report model.Report
+	err := conn(ctx, r.db).First(&report, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// CountReporters 统计 since 之后举报过该用户（本人及其视频、评论）的不同举报人数，驳回的举报不计入
+func (r *ReportRepository) CountReporters(ctx context.Context, targetUserID int64, since time.Time) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.Report{}).
+		Where("target_user_id = ? AND created_at >= ? AND status <> ?", targetUserID, since, model.ReportStatusDismissed).
+		Distinct("reporter_id").
+		Count(&count).Error
+	return count, err
+}
+
+// Escalate 将该用户所有待处理举报标记为已升级，返回新升级的条数
+func (r *ReportRepository) Escalate(ctx context.Context, targetUserID int64) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.Report{}).
+		Where("target_user_id = ? AND status = ? AND escalated = ?", targetUserID, model.ReportStatusPending, false).
+		Update("escalated", true)
+	return result.RowsAffected, result.Error
+}
+
+// List 分页获取举报，已升级的排在前面，附带举报人与被举报用户
+func (r *ReportRepository) List(ctx context.Context, filter *ReportFilter, skip, limit int) ([]model.Report, int64, error) {
+	query := conn(ctx, r.db).Model(&model.Report{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetUserID != 0 {
+		query = query.Where("target_user_id = ?", filter.TargetUserID)
+	}
+	if filter.Escalated {
+		query = query.Where("escalated = ?", true)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reports []model.Report
+	err := query.Preload("Reporter", withDeleted).Preload("TargetUser", withDeleted).
+		Order("escalated DESC").Order("id DESC").
+		Offset(skip).Limit(limit).
+		Find(&reports).Error
+	return reports, total, err
+}
+
+// UpdateStatus 处理举报：只更新仍为待处理的记录，返回是否更新成功
+func (r *ReportRepository) UpdateStatus(ctx context.Context, id int64, status string) (bool, error) {
+	now := time.Now()
+	result := conn(ctx, r.db).Model(&model.Report{}).
+		Where("id = ? AND status = ?", id, model.ReportStatusPending).
+		Updates(map[string]interface{}{"status": status, "reviewed_at": &now})
+	return result.RowsAffected > 0, result.Error
+}
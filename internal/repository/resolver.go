@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// replica 将查询路由到只读副本（未配置副本时仍走主库）。
+// 只用于能容忍复制延迟的列表类查询，写后立即读取的场景保持默认的主库
+func replica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Read)
+}
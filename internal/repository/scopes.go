@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// 删除约定：用户、视频、评论等实体使用 gorm.DeletedAt 软删除，GORM 会自动给
+// 通过模型发起的查询加上 deleted_at IS NULL，新增查询无需手写过滤条件；
+// 关注、点赞等关系记录没有独立的生命周期，取消时直接物理删除。
+// 手写 Table/Joins 连接实体表时不会自动过滤，需要显式加上 deleted_at IS NULL。
+
+// 需要包含已删除记录的查询显式调用 Unscoped()
+
+// withDeleted 用作 Preload 条件，关联对象包含已软删除的记录（如已注销作者的视频仍展示作者信息）：
+// Preload("Author", withDeleted)
+func withDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// availableIn 用作 Scopes 条件，只返回在 country 可见的视频，与 model.Video.AvailableIn 一致。
+// 地区代码固定为两位字母且以逗号分隔，LIKE 不会误匹配其他代码
+func availableIn(country string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if country == "" {
+			return db.Where("allowed_regions = ''")
+		}
+		pattern := "%" + country + "%"
+		return db.Where("(allowed_regions = '' OR allowed_regions LIKE ?) AND blocked_regions NOT LIKE ?", pattern, pattern)
+	}
+}
+
+// notOnLegalHold 用作 Scopes 条件，排除处于法律保全中的记录（视频、用户），用于对外展示的查询
+func notOnLegalHold(db *gorm.DB) *gorm.DB {
+	return db.Where("legal_hold_at IS NULL")
+}
+
+// publicOnly 用作 Scopes 条件，排除私密视频，用于视频流、搜索、作者主页等对外列表
+func publicOnly(db *gorm.DB) *gorm.DB {
+	return db.Where("visibility = ?", model.VideoVisibilityPublic)
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"vida-go/internal/model"
+)
+
+func TestTransactionRollsBackOnError(t *testing.T) {
+	db := newTestDB(t, &model.Wallet{})
+	txManager := NewTxManager(db)
+	walletRepo := NewWalletRepository(db)
+	ctx := context.Background()
+
+	errBoom := errors.New("boom")
+	err := txManager.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := walletRepo.Credit(ctx, 1, 100); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errBoom)
+	}
+
+	balance, err := walletRepo.GetBalance(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetBalance() error = %v", err)
+	}
+	if balance != 0 {
+		t.Errorf("balance after rollback = %d, want 0", balance)
+	}
+}
+
+func TestTransactionRollsBackOnPanic(t *testing.T) {
+	db := newTestDB(t, &model.Wallet{})
+	txManager := NewTxManager(db)
+	walletRepo := NewWalletRepository(db)
+	ctx := context.Background()
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("Transaction() did not re-panic")
+			}
+		}()
+		_ = txManager.Transaction(ctx, func(ctx context.Context) error {
+			if _, err := walletRepo.Credit(ctx, 1, 100); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	balance, err := walletRepo.GetBalance(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetBalance() error = %v", err)
+	}
+	if balance != 0 {
+		t.Errorf("balance after panic = %d, want 0", balance)
+	}
+}
+
+func TestTransactionNestedJoinsOuter(t *testing.T) {
+	db := newTestDB(t, &model.Wallet{})
+	txManager := NewTxManager(db)
+	walletRepo := NewWalletRepository(db)
+	ctx := context.Background()
+
+	errBoom := errors.New("boom")
+	err := txManager.Transaction(ctx, func(ctx context.Context) error {
+		// 内层事务成功提交不应生效：它复用外层事务，随外层一起回滚
+		if err := txManager.Transaction(ctx, func(ctx context.Context) error {
+			_, err := walletRepo.Credit(ctx, 1, 100)
+			return err
+		}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errBoom)
+	}
+
+	balance, err := walletRepo.GetBalance(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetBalance() error = %v", err)
+	}
+	if balance != 0 {
+		t.Errorf("balance after outer rollback = %d, want 0", balance)
+	}
+}
+
+func TestTransactionCommits(t *testing.T) {
+	db := newTestDB(t, &model.Wallet{})
+	txManager := NewTxManager(db)
+	walletRepo := NewWalletRepository(db)
+	ctx := context.Background()
+
+	err := txManager.Transaction(ctx, func(ctx context.Context) error {
+		_, err := walletRepo.Credit(ctx, 1, 100)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+
+	balance, err := walletRepo.GetBalance(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetBalance() error = %v", err)
+	}
+	if balance != 100 {
+		t.Errorf("balance = %d, want 100", balance)
+	}
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"vida-go/internal/model"
 
 	"gorm.io/gorm"
@@ -15,9 +18,9 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 }
 
 // GetByID 根据 ID 查询用户（排除已删除）
-func (r *UserRepository) GetByID(id int64) (*model.User, error) {
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("id = ? AND is_delete = 0", id).First(&user).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -25,9 +28,9 @@ func (r *UserRepository) GetByID(id int64) (*model.User, error) {
 }
 
 // GetByIDIncludeDeleted 根据 ID 查询用户（包含已删除，管理员用）
-func (r *UserRepository) GetByIDIncludeDeleted(id int64) (*model.User, error) {
+func (r *UserRepository) GetByIDIncludeDeleted(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("id = ?", id).First(&user).Error
+	err := conn(ctx, r.db).Unscoped().Where("id = ?", id).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -35,9 +38,19 @@ func (r *UserRepository) GetByIDIncludeDeleted(id int64) (*model.User, error) {
 }
 
 // GetByUsername 根据用户名查询用户（排除已删除）
-func (r *UserRepository) GetByUsername(username string) (*model.User, error) {
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("user_name = ? AND is_delete = 0", username).First(&user).Error
+	err := conn(ctx, r.db).Where("user_name = ?", username).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByUsernameIncludeDeleted 根据用户名查询用户（包含已删除，用于登录时区分已注销账号）
+func (r *UserRepository) GetByUsernameIncludeDeleted(ctx context.Context, username string) (*model.User, error) {
+	var user model.User
+	err := conn(ctx, r.db).Unscoped().Where("user_name = ?", username).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -45,26 +58,60 @@ func (r *UserRepository) GetByUsername(username string) (*model.User, error) {
 }
 
 // Create 创建用户
-func (r *UserRepository) Create(user *model.User) error {
-	return r.db.Create(user).Error
+func (r *UserRepository) Create(ctx context.Context, user *model.User) error {
+	return conn(ctx, r.db).Create(user).Error
 }
 
 // Update 更新用户字段（传入 map，只更新非零值字段）
-func (r *UserRepository) Update(id int64, updates map[string]interface{}) (*model.User, error) {
-	result := r.db.Model(&model.User{}).Where("id = ?", id).Updates(updates)
+func (r *UserRepository) Update(ctx context.Context, id int64, updates map[string]interface{}) (*model.User, error) {
+	result := conn(ctx, r.db).Model(&model.User{}).Where("id = ?", id).Updates(updates)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	if result.RowsAffected == 0 {
 		return nil, gorm.ErrRecordNotFound
 	}
-	return r.GetByIDIncludeDeleted(id)
+	return r.GetByIDIncludeDeleted(ctx, id)
+}
+
+// SwapImage 仅在用户 column 字段（avatar/background_image）仍为 from 时替换为 to，返回是否替换
+func (r *UserRepository) SwapImage(ctx context.Context, id int64, column, from string, to *string) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.User{}).
+		Where("id = ? AND "+column+" = ?", id, from).
+		Update(column, to)
+	return result.RowsAffected > 0, result.Error
+}
+
+// SoftDelete 软删除用户（设置 deleted_at）
+func (r *UserRepository) SoftDelete(ctx context.Context, id int64) error {
+	result := conn(ctx, r.db).Delete(&model.User{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Restore 恢复已软删除的用户
+func (r *UserRepository) Restore(ctx context.Context, id int64) error {
+	result := conn(ctx, r.db).Unscoped().Model(&model.User{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 // ExistsByUsername 检查用户名是否已存在
-func (r *UserRepository) ExistsByUsername(username string) (bool, error) {
+func (r *UserRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var count int64
-	err := r.db.Model(&model.User{}).Where("user_name = ? AND is_delete = 0", username).Count(&count).Error
+	err := conn(ctx, r.db).Model(&model.User{}).Where("user_name = ?", username).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -72,11 +119,11 @@ func (r *UserRepository) ExistsByUsername(username string) (bool, error) {
 }
 
 // ListWithFilters 带筛选条件的分页查询
-func (r *UserRepository) ListWithFilters(skip, limit int, username, userRole *string) ([]model.User, int64, error) {
-	query := r.db.Model(&model.User{}).Where("is_delete = 0")
+func (r *UserRepository) ListWithFilters(ctx context.Context, skip, limit int, username, userRole *string) ([]model.User, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.User{})
 
 	if username != nil && *username != "" {
-		query = query.Where("user_name ILIKE ?", "%"+*username+"%")
+		query = query.Where("user_name "+likeOp(r.db)+" ?", "%"+*username+"%")
 	}
 	if userRole != nil && *userRole != "" {
 		query = query.Where("user_role = ?", *userRole)
@@ -96,47 +143,169 @@ func (r *UserRepository) ListWithFilters(skip, limit int, username, userRole *st
 }
 
 // GetByIDs 批量查询用户
-func (r *UserRepository) GetByIDs(ids []int64) ([]model.User, error) {
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []int64) ([]model.User, error) {
 	if len(ids) == 0 {
 		return nil, nil
 	}
 	var users []model.User
-	err := r.db.Where("id IN ? AND is_delete = 0", ids).Find(&users).Error
+	err := conn(ctx, r.db).Where("id IN ?", ids).Find(&users).Error
 	return users, err
 }
 
+// ExistingIDs 返回 ids 中存在（未删除）的用户 ID
+func (r *UserRepository) ExistingIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var existing []int64
+	err := conn(ctx, r.db).Model(&model.User{}).Where("id IN ?", ids).Pluck("id", &existing).Error
+	return existing, err
+}
+
+// 按明细表计算的用户计数：获赞数为作者所有视频（含已删除）收到的点赞，点赞数为用户点赞过的视频数
+const (
+	userFollowCountSQL    = "(SELECT COUNT(*) FROM relations WHERE relations.follower_id = users.id)"
+	userFollowerCountSQL  = "(SELECT COUNT(*) FROM relations WHERE relations.follow_id = users.id)"
+	userTotalFavoritedSQL = "(SELECT COUNT(*) FROM favorites JOIN videos ON videos.id = favorites.video_id WHERE videos.author_id = users.id)"
+	userFavoriteCountSQL  = "(SELECT COUNT(*) FROM favorites WHERE favorites.user_id = users.id)"
+)
+
+// RecountFollowCounts 按关注关系表重新计算用户的关注数、粉丝数，返回修正的用户数
+func (r *UserRepository) RecountFollowCounts(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := conn(ctx, r.db).Model(&model.User{}).
+		Where("id IN ? AND (follow_count <> "+userFollowCountSQL+" OR follower_count <> "+userFollowerCountSQL+")", ids).
+		UpdateColumns(map[string]interface{}{
+			"follow_count":   gorm.Expr(userFollowCountSQL),
+			"follower_count": gorm.Expr(userFollowerCountSQL),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// RecountTotalFavorited 按点赞表重新计算用户的获赞数，返回修正的用户数
+func (r *UserRepository) RecountTotalFavorited(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := conn(ctx, r.db).Model(&model.User{}).
+		Where("id IN ? AND total_favorited <> "+userTotalFavoritedSQL, ids).
+		UpdateColumn("total_favorited", gorm.Expr(userTotalFavoritedSQL))
+	return result.RowsAffected, result.Error
+}
+
+// RecountFavoriteCounts 按点赞表重新计算用户的点赞数，返回修正的用户数
+func (r *UserRepository) RecountFavoriteCounts(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := conn(ctx, r.db).Model(&model.User{}).
+		Where("id IN ? AND favorite_count <> "+userFavoriteCountSQL, ids).
+		UpdateColumn("favorite_count", gorm.Expr(userFavoriteCountSQL))
+	return result.RowsAffected, result.Error
+}
+
+// ListIDsAfter 按 ID 正序返回 afterID 之后的用户 ID（分批遍历用）
+func (r *UserRepository) ListIDsAfter(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := conn(ctx, r.db).Model(&model.User{}).Where("id > ?", afterID).
+		Order("id ASC").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ListLegalHolds 分页获取处于法律保全中的用户，按保全时间倒序
+func (r *UserRepository) ListLegalHolds(ctx context.Context, skip, limit int) ([]model.User, int64, error) {
+	query := conn(ctx, r.db).Model(&model.User{}).Where("legal_hold_at IS NOT NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []model.User
+	err := query.Order("legal_hold_at DESC").Order("id DESC").
+		Offset(skip).Limit(limit).
+		Find(&users).Error
+	return users, total, err
+}
+
+// ListInvitees 分页获取由该用户邀请注册的用户（含已删除），后注册的在前
+func (r *UserRepository) ListInvitees(ctx context.Context, inviterID int64, skip, limit int) ([]model.User, int64, error) {
+	query := replica(r.db).WithContext(ctx).Unscoped().Model(&model.User{}).Where("invited_by_id = ?", inviterID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []model.User
+	err := query.Order("id DESC").Offset(skip).Limit(limit).Find(&users).Error
+	return users, total, err
+}
+
+// ListNewCreatorIDs 发现页：首个视频在 sincePublish 之后发布的作者，按粉丝数、获赞数倒序
+func (r *UserRepository) ListNewCreatorIDs(ctx context.Context, sincePublish time.Time, limit int) ([]int64, error) {
+	firstPublish := r.db.Model(&model.Video{}).
+		Select("author_id").
+		Where("status = ? AND publish_time IS NOT NULL", "published").
+		Group("author_id").
+		Having("MIN(publish_time) >= ?", sincePublish)
+
+	var ids []int64
+	err := replica(r.db).WithContext(ctx).Model(&model.User{}).
+		Where("id IN (?)", firstPublish).
+		Scopes(notOnLegalHold).
+		Order("follower_count DESC, total_favorited DESC, id DESC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 // IncrementFollowCount 关注数 +1
-func (r *UserRepository) IncrementFollowCount(id int64) error {
-	return r.db.Model(&model.User{}).Where("id = ?", id).
+func (r *UserRepository) IncrementFollowCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ?", id).
 		UpdateColumn("follow_count", gorm.Expr("follow_count + 1")).Error
 }
 
 // DecrementFollowCount 关注数 -1（不低于 0）
-func (r *UserRepository) DecrementFollowCount(id int64) error {
-	return r.db.Model(&model.User{}).Where("id = ? AND follow_count > 0", id).
+func (r *UserRepository) DecrementFollowCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ? AND follow_count > 0", id).
 		UpdateColumn("follow_count", gorm.Expr("follow_count - 1")).Error
 }
 
 // IncrementFollowerCount 粉丝数 +1
-func (r *UserRepository) IncrementFollowerCount(id int64) error {
-	return r.db.Model(&model.User{}).Where("id = ?", id).
+func (r *UserRepository) IncrementFollowerCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ?", id).
 		UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error
 }
 
 // DecrementFollowerCount 粉丝数 -1（不低于 0）
-func (r *UserRepository) DecrementFollowerCount(id int64) error {
-	return r.db.Model(&model.User{}).Where("id = ? AND follower_count > 0", id).
+func (r *UserRepository) DecrementFollowerCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ? AND follower_count > 0", id).
 		UpdateColumn("follower_count", gorm.Expr("follower_count - 1")).Error
 }
 
 // IncrementTotalFavorited 获赞数 +1（视频作者被点赞总数）
-func (r *UserRepository) IncrementTotalFavorited(id int64) error {
-	return r.db.Model(&model.User{}).Where("id = ?", id).
+func (r *UserRepository) IncrementTotalFavorited(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ?", id).
 		UpdateColumn("total_favorited", gorm.Expr("total_favorited + 1")).Error
 }
 
 // DecrementTotalFavorited 获赞数 -1（不低于 0）
-func (r *UserRepository) DecrementTotalFavorited(id int64) error {
-	return r.db.Model(&model.User{}).Where("id = ? AND total_favorited > 0", id).
+func (r *UserRepository) DecrementTotalFavorited(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ? AND total_favorited > 0", id).
 		UpdateColumn("total_favorited", gorm.Expr("total_favorited - 1")).Error
 }
+
+// IncrementFavoriteCount 点赞数 +1（用户点赞过的视频数）
+func (r *UserRepository) IncrementFavoriteCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ?", id).
+		UpdateColumn("favorite_count", gorm.Expr("favorite_count + 1")).Error
+}
+
+// DecrementFavoriteCount 点赞数 -1（不低于 0）
+func (r *UserRepository) DecrementFavoriteCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.User{}).Where("id = ? AND favorite_count > 0", id).
+		UpdateColumn("favorite_count", gorm.Expr("favorite_count - 1")).Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserSettingRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSettingRepository(db *gorm.DB) *UserSettingRepository {
+	return &UserSettingRepository{db: db}
+}
+
+// GetByUserID 查询用户设置，不存在时返回默认设置
+func (r *UserSettingRepository) GetByUserID(ctx context.Context, userID int64) (*model.UserSetting, error) {
+	var setting model.UserSetting
+	err := conn(ctx, r.db).Where("user_id = ?", userID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &model.UserSetting{UserID: userID}, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// Save 保存用户设置（不存在则创建）
+func (r *UserSettingRepository) Save(ctx context.Context, setting *model.UserSetting) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"muted_notification_types", "muted_video_ids",
+			"notification_email", "email_notifications",
+			"remix_disabled",
+			"updated_at",
+		}),
+	}).Create(setting).Error
+}
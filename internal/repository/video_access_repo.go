@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VideoAccessRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoAccessRepository(db *gorm.DB) *VideoAccessRepository {
+	return &VideoAccessRepository{db: db}
+}
+
+// Grant 批量授权，已授权的用户保持不变，返回新增的条数
+func (r *VideoAccessRepository) Grant(ctx context.Context, grants []model.VideoAccessGrant) (int64, error) {
+	if len(grants) == 0 {
+		return 0, nil
+	}
+	result := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&grants)
+	return result.RowsAffected, result.Error
+}
+
+// Revoke 取消授权，返回是否存在该授权
+func (r *VideoAccessRepository) Revoke(ctx context.Context, videoID, userID int64) (bool, error) {
+	result := conn(ctx, r.db).Where("video_id = ? AND user_id = ?", videoID, userID).Delete(&model.VideoAccessGrant{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Exists 用户是否被授权观看视频
+func (r *VideoAccessRepository) Exists(ctx context.Context, videoID, userID int64) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.VideoAccessGrant{}).
+		Where("video_id = ? AND user_id = ?", videoID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+// CountByVideo 统计视频的授权人数
+func (r *VideoAccessRepository) CountByVideo(ctx context.Context, videoID int64) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.VideoAccessGrant{}).Where("video_id = ?", videoID).Count(&count).Error
+	return count, err
+}
+
+// ListByVideo 分页获取视频的授权记录（含用户），按授权时间倒序
+func (r *VideoAccessRepository) ListByVideo(ctx context.Context, videoID int64, skip, limit int) ([]model.VideoAccessGrant, int64, error) {
+	query := conn(ctx, r.db).Model(&model.VideoAccessGrant{}).Where("video_id = ?", videoID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var grants []model.VideoAccessGrant
+	err := query.Preload("User", withDeleted).
+		Order("created_at DESC").Order("id DESC").
+		Offset(skip).Limit(limit).
+		Find(&grants).Error
+	return grants, total, err
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VideoDuplicateRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoDuplicateRepository(db *gorm.DB) *VideoDuplicateRepository {
+	return &VideoDuplicateRepository{db: db}
+}
+
+// Create 记录近似重复，同一对视频已存在记录时忽略
+func (r *VideoDuplicateRepository) Create(ctx context.Context, duplicate *model.VideoDuplicate) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(duplicate).Error
+}
+
+// GetByID 获取记录
+func (r *VideoDuplicateRepository) GetByID(ctx context.Context, id int64) (*model.VideoDuplicate, error) {
+	var duplicate model.VideoDuplicate
+	err := conn(ctx, r.db).First(&duplicate, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+// List 按状态分页获取记录（status 为空时返回全部），附带双方视频及作者
+func (r *VideoDuplicateRepository) List(ctx context.Context, status string, skip, limit int) ([]model.VideoDuplicate, int64, error) {
+	query := conn(ctx, r.db).Model(&model.VideoDuplicate{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var duplicates []model.VideoDuplicate
+	err := query.Preload("Video", withDeleted).Preload("Video.Author", withDeleted).
+		Preload("DuplicateOf", withDeleted).Preload("DuplicateOf.Author", withDeleted).
+		Order("id DESC").
+		Offset(skip).Limit(limit).
+		Find(&duplicates).Error
+	return duplicates, total, err
+}
+
+// UpdateStatus 审核处理：只更新仍为 fromStatus 的记录，返回是否更新成功
+func (r *VideoDuplicateRepository) UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string) (bool, error) {
+	now := time.Now()
+	result := conn(ctx, r.db).Model(&model.VideoDuplicate{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(map[string]interface{}{"status": toStatus, "reviewed_at": &now})
+	return result.RowsAffected > 0, result.Error
+}
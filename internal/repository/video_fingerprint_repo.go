@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// fingerprintCandidateLimit 单次候选帧查询上限，避免纯色等常见画面返回过多结果
+const fingerprintCandidateLimit = 5000
+
+type VideoFingerprintRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoFingerprintRepository(db *gorm.DB) *VideoFingerprintRepository {
+	return &VideoFingerprintRepository{db: db}
+}
+
+// CreateBatch 保存视频的抽帧指纹
+func (r *VideoFingerprintRepository) CreateBatch(ctx context.Context, fingerprints []model.VideoFingerprint) error {
+	if len(fingerprints) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Create(&fingerprints).Error
+}
+
+// FindCandidates 查找其他视频中与任一给定指纹至少有一段相同的帧
+func (r *VideoFingerprintRepository) FindCandidates(ctx context.Context, excludeVideoID int64, fingerprints []model.VideoFingerprint) ([]model.VideoFingerprint, error) {
+	if len(fingerprints) == 0 {
+		return nil, nil
+	}
+
+	var bands [4][]int32
+	for _, fp := range fingerprints {
+		bands[0] = append(bands[0], fp.Band0)
+		bands[1] = append(bands[1], fp.Band1)
+		bands[2] = append(bands[2], fp.Band2)
+		bands[3] = append(bands[3], fp.Band3)
+	}
+
+	var candidates []model.VideoFingerprint
+	err := conn(ctx, r.db).
+		Where("video_id <> ?", excludeVideoID).
+		Where(r.db.Where("band0 IN ?", bands[0]).
+			Or("band1 IN ?", bands[1]).
+			Or("band2 IN ?", bands[2]).
+			Or("band3 IN ?", bands[3])).
+		Limit(fingerprintCandidateLimit).
+		Find(&candidates).Error
+	return candidates, err
+}
+
+// DeleteByVideo 删除视频的全部指纹
+func (r *VideoFingerprintRepository) DeleteByVideo(ctx context.Context, videoID int64) error {
+	return conn(ctx, r.db).Where("video_id = ?", videoID).Delete(&model.VideoFingerprint{}).Error
+}
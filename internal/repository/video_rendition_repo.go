@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VideoRenditionRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoRenditionRepository(db *gorm.DB) *VideoRenditionRepository {
+	return &VideoRenditionRepository{db: db}
+}
+
+// RenditionStatusCount 某档位某状态下的视频数
+type RenditionStatusCount struct {
+	Profile string
+	Status  string
+	Count   int64
+}
+
+// ListByVideo 获取视频已记录的所有档位
+func (r *VideoRenditionRepository) ListByVideo(ctx context.Context, videoID int64) ([]model.VideoRendition, error) {
+	var renditions []model.VideoRendition
+	err := conn(ctx, r.db).Where("video_id = ?", videoID).Order("profile ASC").Find(&renditions).Error
+	return renditions, err
+}
+
+// GetByVideoAndProfile 获取视频某个档位
+func (r *VideoRenditionRepository) GetByVideoAndProfile(ctx context.Context, videoID int64, profile string) (*model.VideoRendition, error) {
+	var rendition model.VideoRendition
+	err := conn(ctx, r.db).Where("video_id = ? AND profile = ?", videoID, profile).First(&rendition).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rendition, nil
+}
+
+// ListByVideos 批量获取多个视频已记录的档位
+func (r *VideoRenditionRepository) ListByVideos(ctx context.Context, videoIDs []int64) ([]model.VideoRendition, error) {
+	var renditions []model.VideoRendition
+	if len(videoIDs) == 0 {
+		return renditions, nil
+	}
+	err := conn(ctx, r.db).Where("video_id IN ?", videoIDs).Find(&renditions).Error
+	return renditions, err
+}
+
+// MarkPending 投递转码任务前记录为 pending 并累加尝试次数，记录不存在时创建
+func (r *VideoRenditionRepository) MarkPending(ctx context.Context, videoID int64, profile string) error {
+	rendition := &model.VideoRendition{
+		VideoID:  videoID,
+		Profile:  profile,
+		Status:   model.VideoRenditionStatusPending,
+		Attempts: 1,
+	}
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "video_id"}, {Name: "profile"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"status":     model.VideoRenditionStatusPending,
+			"attempts":   gorm.Expr("video_renditions.attempts + 1"),
+			"error":      "",
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Create(rendition).Error
+}
+
+// UpdateResult 按转码结果更新档位记录
+func (r *VideoRenditionRepository) UpdateResult(ctx context.Context, videoID int64, profile string, updates map[string]interface{}) error {
+	return conn(ctx, r.db).Model(&model.VideoRendition{}).
+		Where("video_id = ? AND profile = ?", videoID, profile).
+		Updates(updates).Error
+}
+
+// CountByStatus 按档位、状态统计已发布视频数
+func (r *VideoRenditionRepository) CountByStatus(ctx context.Context) ([]RenditionStatusCount, error) {
+	var counts []RenditionStatusCount
+	err := conn(ctx, r.db).Model(&model.VideoRendition{}).
+		Select("video_renditions.profile, video_renditions.status, COUNT(*) AS count").
+		Joins("JOIN videos ON videos.id = video_renditions.video_id AND videos.status = ? AND videos.deleted_at IS NULL", "published").
+		Group("video_renditions.profile, video_renditions.status").
+		Scan(&counts).Error
+	return counts, err
+}
@@ -1,9 +1,16 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"vida-go/internal/config"
 	"vida-go/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type VideoRepository struct {
@@ -15,9 +22,9 @@ func NewVideoRepository(db *gorm.DB) *VideoRepository {
 }
 
 // GetByID 根据 ID 获取视频
-func (r *VideoRepository) GetByID(id int64) (*model.Video, error) {
+func (r *VideoRepository) GetByID(ctx context.Context, id int64) (*model.Video, error) {
 	var video model.Video
-	err := r.db.Where("id = ? AND status != 'deleted'", id).First(&video).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&video).Error
 	if err != nil {
 		return nil, err
 	}
@@ -25,9 +32,9 @@ func (r *VideoRepository) GetByID(id int64) (*model.Video, error) {
 }
 
 // GetByIDWithAuthor 根据 ID 获取视频（含作者信息）
-func (r *VideoRepository) GetByIDWithAuthor(id int64) (*model.Video, error) {
+func (r *VideoRepository) GetByIDWithAuthor(ctx context.Context, id int64) (*model.Video, error) {
 	var video model.Video
-	err := r.db.Preload("Author").Where("id = ? AND status != 'deleted'", id).First(&video).Error
+	err := conn(ctx, r.db).Preload("Author", withDeleted).Where("id = ?", id).First(&video).Error
 	if err != nil {
 		return nil, err
 	}
@@ -35,13 +42,13 @@ func (r *VideoRepository) GetByIDWithAuthor(id int64) (*model.Video, error) {
 }
 
 // GetByIDsWithAuthor 根据 ID 列表批量获取视频（含作者，保持 ID 顺序）
-func (r *VideoRepository) GetByIDsWithAuthor(ids []int64) ([]model.Video, error) {
+func (r *VideoRepository) GetByIDsWithAuthor(ctx context.Context, ids []int64) ([]model.Video, error) {
 	if len(ids) == 0 {
 		return nil, nil
 	}
 
 	var videos []model.Video
-	err := r.db.Preload("Author").Where("id IN ? AND status != 'deleted'", ids).Find(&videos).Error
+	err := conn(ctx, r.db).Preload("Author", withDeleted).Where("id IN ?", ids).Find(&videos).Error
 	if err != nil {
 		return nil, err
 	}
@@ -61,9 +68,9 @@ func (r *VideoRepository) GetByIDsWithAuthor(ids []int64) ([]model.Video, error)
 }
 
 // GetByIDAndAuthor 根据视频 ID + 作者 ID 查询（权限校验用）
-func (r *VideoRepository) GetByIDAndAuthor(videoID, authorID int64) (*model.Video, error) {
+func (r *VideoRepository) GetByIDAndAuthor(ctx context.Context, videoID, authorID int64) (*model.Video, error) {
 	var video model.Video
-	err := r.db.Where("id = ? AND author_id = ? AND status != 'deleted'", videoID, authorID).First(&video).Error
+	err := conn(ctx, r.db).Where("id = ? AND author_id = ?", videoID, authorID).First(&video).Error
 	if err != nil {
 		return nil, err
 	}
@@ -71,26 +78,44 @@ func (r *VideoRepository) GetByIDAndAuthor(videoID, authorID int64) (*model.Vide
 }
 
 // Create 创建视频记录
-func (r *VideoRepository) Create(video *model.Video) error {
-	return r.db.Create(video).Error
+func (r *VideoRepository) Create(ctx context.Context, video *model.Video) error {
+	return conn(ctx, r.db).Create(video).Error
 }
 
 // Update 更新视频字段
-func (r *VideoRepository) Update(id int64, updates map[string]interface{}) (*model.Video, error) {
-	result := r.db.Model(&model.Video{}).Where("id = ?", id).Updates(updates)
+func (r *VideoRepository) Update(ctx context.Context, id int64, updates map[string]interface{}) (*model.Video, error) {
+	result := conn(ctx, r.db).Model(&model.Video{}).Where("id = ?", id).Updates(updates)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	if result.RowsAffected == 0 {
 		return nil, gorm.ErrRecordNotFound
 	}
-	return r.GetByID(id)
+	return r.GetByID(ctx, id)
+}
+
+// Pin 将视频置顶到作者主页，同时取消该作者其他视频的置顶
+func (r *VideoRepository) Pin(ctx context.Context, authorID, videoID int64, at time.Time) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Video{}).
+			Where("author_id = ? AND id != ? AND pinned_at IS NOT NULL", authorID, videoID).
+			Update("pinned_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Video{}).Where("id = ?", videoID).Update("pinned_at", at).Error
+	})
+}
+
+// Unpin 取消视频置顶
+func (r *VideoRepository) Unpin(ctx context.Context, videoID int64) error {
+	return conn(ctx, r.db).Model(&model.Video{}).
+		Where("id = ? AND pinned_at IS NOT NULL", videoID).
+		Update("pinned_at", nil).Error
 }
 
-// SoftDelete 软删除（设置 status = 'deleted'）
-func (r *VideoRepository) SoftDelete(id int64) error {
-	result := r.db.Model(&model.Video{}).Where("id = ? AND status != 'deleted'", id).
-		Update("status", "deleted")
+// SoftDelete 软删除（设置 deleted_at）
+func (r *VideoRepository) SoftDelete(ctx context.Context, id int64) error {
+	result := conn(ctx, r.db).Delete(&model.Video{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -100,9 +125,27 @@ func (r *VideoRepository) SoftDelete(id int64) error {
 	return nil
 }
 
-// ListVideos 视频列表查询（分页、筛选、排序）
-func (r *VideoRepository) ListVideos(skip, limit int, authorID *int64, status *string, search *string, withAuthor bool) ([]model.Video, int64, error) {
-	query := r.db.Model(&model.Video{}).Where("status != 'deleted'")
+// CountByAuthorSince 统计作者在 since 之后创建的视频数（含已删除，避免删除后重新上传绕过限制）
+func (r *VideoRepository) CountByAuthorSince(ctx context.Context, authorID int64, since time.Time) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Unscoped().Model(&model.Video{}).
+		Where("author_id = ? AND created_at >= ?", authorID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// ListVideos 视频列表查询（分页、筛选、排序），按已发布筛选时不包含私密视频
+func (r *VideoRepository) ListVideos(ctx context.Context, skip, limit int, authorID *int64, status *string, search *string, withAuthor bool) ([]model.Video, int64, error) {
+	return r.listVideos(ctx, skip, limit, authorID, status, search, withAuthor, true)
+}
+
+// ListByAuthor 作者本人的视频列表，包含私密视频
+func (r *VideoRepository) ListByAuthor(ctx context.Context, authorID int64, status *string, skip, limit int) ([]model.Video, int64, error) {
+	return r.listVideos(ctx, skip, limit, &authorID, status, nil, false, false)
+}
+
+func (r *VideoRepository) listVideos(ctx context.Context, skip, limit int, authorID *int64, status *string, search *string, withAuthor, public bool) ([]model.Video, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{})
 
 	if authorID != nil {
 		query = query.Where("author_id = ?", *authorID)
@@ -110,11 +153,15 @@ func (r *VideoRepository) ListVideos(skip, limit int, authorID *int64, status *s
 	if status != nil && *status != "" {
 		query = query.Where("status = ?", *status)
 		if *status == "published" {
-			query = query.Where("play_url IS NOT NULL AND play_url != ''")
+			query = query.Where("play_url IS NOT NULL AND play_url != ''").Scopes(notOnLegalHold)
+			if public {
+				query = query.Scopes(publicOnly)
+			}
 		}
 	}
 	if search != nil && *search != "" {
-		query = query.Where("title ILIKE ? OR description ILIKE ?", "%"+*search+"%", "%"+*search+"%")
+		like := likeOp(r.db)
+		query = query.Where("title "+like+" ? OR description "+like+" ?", "%"+*search+"%", "%"+*search+"%")
 	}
 
 	var total int64
@@ -124,7 +171,7 @@ func (r *VideoRepository) ListVideos(skip, limit int, authorID *int64, status *s
 
 	findQuery := query.Order("created_at DESC").Offset(skip).Limit(limit)
 	if withAuthor {
-		findQuery = findQuery.Preload("Author")
+		findQuery = findQuery.Preload("Author", withDeleted)
 	}
 
 	var videos []model.Video
@@ -135,32 +182,479 @@ func (r *VideoRepository) ListVideos(skip, limit int, authorID *int64, status *s
 	return videos, total, nil
 }
 
+// GetPinnedByAuthor 获取作者置顶且对外可见的视频（含作者）
+func (r *VideoRepository) GetPinnedByAuthor(ctx context.Context, authorID int64) (*model.Video, error) {
+	var video model.Video
+	err := conn(ctx, r.db).Preload("Author", withDeleted).
+		Where("author_id = ? AND pinned_at IS NOT NULL AND status = ? AND play_url IS NOT NULL AND play_url != ''", authorID, "published").
+		Scopes(notOnLegalHold, publicOnly).
+		First(&video).Error
+	if err != nil {
+		return nil, err
+	}
+	return &video, nil
+}
+
+// ListPublishedByAuthor 作者主页：分页获取作者已发布的视频（含作者），置顶视频在最前，其余按创建时间倒序
+func (r *VideoRepository) ListPublishedByAuthor(ctx context.Context, authorID int64, skip, limit int) ([]model.Video, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{}).
+		Where("author_id = ? AND status = ? AND play_url IS NOT NULL AND play_url != ''", authorID, "published").
+		Scopes(notOnLegalHold, publicOnly)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var videos []model.Video
+	err := query.Order("CASE WHEN pinned_at IS NULL THEN 1 ELSE 0 END").Order("created_at DESC").
+		Offset(skip).Limit(limit).
+		Preload("Author", withDeleted).
+		Find(&videos).Error
+	return videos, total, err
+}
+
+// ListPublished 视频流：分页获取在 region 可见的已发布视频（含作者），按创建时间倒序
+func (r *VideoRepository) ListPublished(ctx context.Context, region string, skip, limit int) ([]model.Video, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{}).
+		Where("status = ? AND play_url IS NOT NULL AND play_url != ''", "published").
+		Scopes(notOnLegalHold, publicOnly, availableIn(region))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var videos []model.Video
+	err := query.Order("created_at DESC").Offset(skip).Limit(limit).
+		Preload("Author", withDeleted).
+		Find(&videos).Error
+	return videos, total, err
+}
+
+// ListFollowedPublishedSince 每周摘要：followerID 关注的作者在 [since, until) 内发布的公开视频（含作者），
+// 按播放量、发布时间倒序，同时返回总数
+func (r *VideoRepository) ListFollowedPublishedSince(ctx context.Context, followerID int64, since, until time.Time, limit int) ([]model.Video, int64, error) {
+	following := r.db.Model(&model.Relation{}).Select("follow_id").Where("follower_id = ?", followerID)
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{}).
+		Where("author_id IN (?) AND status = ? AND play_url IS NOT NULL AND play_url != ''", following, "published").
+		Where("publish_time >= ? AND publish_time < ?", since, until).
+		Scopes(notOnLegalHold, publicOnly)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var videos []model.Video
+	err := query.Order("view_count DESC").Order("publish_time DESC").Limit(limit).
+		Preload("Author", withDeleted).
+		Find(&videos).Error
+	return videos, total, err
+}
+
+// ListRemixes 分页获取引用了原视频且在 region 可见的已发布视频（含作者），按创建时间倒序
+func (r *VideoRepository) ListRemixes(ctx context.Context, sourceID int64, region string, skip, limit int) ([]model.Video, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{}).
+		Where("remix_of_id = ? AND status = ? AND play_url IS NOT NULL AND play_url != ''", sourceID, "published").
+		Scopes(notOnLegalHold, publicOnly, availableIn(region))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var videos []model.Video
+	err := query.Order("created_at DESC").Offset(skip).Limit(limit).
+		Preload("Author", withDeleted).
+		Find(&videos).Error
+	return videos, total, err
+}
+
+// hotScoreExpr 与 ES 搜索的热度一致（见 elasticsearch.hotScore 与 SearchService 的热度衰减，省略不影响排序的缩放）：
+// 互动热度按完播率加成（最多翻倍），另按累计观看分钟数加分，配置了半衰期时按发布时长衰减
+func hotScoreExpr(db *gorm.DB, now time.Time) clause.Expr {
+	w := config.GetHotScore()
+	expr := clause.Expr{SQL: "(view_count * " + sqlFloat(w.View()) +
+		" + favorite_count * " + sqlFloat(w.Favorite()) +
+		" + comment_count * " + sqlFloat(w.Comment()) + ") *" +
+		" (1 + CASE WHEN play_count <= 0 THEN 0 WHEN complete_count >= play_count THEN 1 ELSE complete_count * 1.0 / play_count END) +" +
+		" watch_time_ms / 60000.0 * " + sqlFloat(w.WatchMinute())}
+	if decay, ok := halfLifeDecay(db, "publish_time", now, w.HalfLife()); ok {
+		expr = clause.Expr{SQL: "(?) * ?", Vars: []interface{}{expr, decay}}
+	}
+	return expr
+}
+
+// hotOrder 按热度倒序，相同时按 ID 倒序。
+// 带 Expression 的 OrderBy 与其他排序条件合并时会被丢弃，须作为查询唯一的排序条件
+func hotOrder(db *gorm.DB) clause.OrderBy {
+	return clause.OrderBy{Expression: clause.Expr{SQL: "? DESC, id DESC", Vars: []interface{}{hotScoreExpr(db, time.Now())}}}
+}
+
+// ListHotIDs 发现页：按热度获取 sincePublish 之后发布的视频 ID，category 非空时只取该分类的视频。
+// 不按地区过滤，由调用方在展示时过滤
+func (r *VideoRepository) ListHotIDs(ctx context.Context, sincePublish time.Time, category string, limit int) ([]int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{}).
+		Where("status = ? AND play_url IS NOT NULL AND play_url != '' AND publish_time >= ?", "published", sincePublish).
+		Scopes(notOnLegalHold, publicOnly)
+	if category != "" {
+		query = query.Where("id IN (?)", r.db.Model(&model.VideoTag{}).Select("video_id").
+			Where("kind = ? AND name = ?", model.VideoTagKindCategory, category))
+	}
+
+	var ids []int64
+	err := query.Clauses(hotOrder(r.db)).Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// VideoSearchFilter 数据库搜索条件（ES 不可用时的降级搜索），只搜索已发布视频
+type VideoSearchFilter struct {
+	Query     string // 标题、描述模糊匹配
+	AuthorID  *int64
+	VideoID   *int64
+	StartTime *time.Time // 发布时间范围
+	EndTime   *time.Time
+	Sort      string // relevance（按创建时间）/ time（按发布时间）/ hot（按热度）
+	Region    string // 只返回在该国家/地区可见的视频（为空表示归属地未知）
+
+	MinHeight    *int // 画质筛选：最低高度与最低帧率
+	MinFrameRate *float64
+	Sponsored    *bool // 付费推广筛选，为空不筛选
+}
+
+// Search 按条件分页搜索已发布视频，排序与 ES 搜索保持一致（数据库无相关度，relevance 按创建时间倒序）
+func (r *VideoRepository) Search(ctx context.Context, filter *VideoSearchFilter, skip, limit int) ([]model.Video, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{}).
+		Where("status = ? AND play_url IS NOT NULL AND play_url != ''", "published").
+		Scopes(notOnLegalHold, publicOnly, availableIn(filter.Region))
+
+	if filter.AuthorID != nil {
+		query = query.Where("author_id = ?", *filter.AuthorID)
+	}
+	if filter.VideoID != nil {
+		query = query.Where("id = ?", *filter.VideoID)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("publish_time >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("publish_time <= ?", *filter.EndTime)
+	}
+	if filter.MinHeight != nil {
+		query = query.Where("height >= ?", *filter.MinHeight)
+	}
+	if filter.MinFrameRate != nil {
+		query = query.Where("frame_rate >= ?", *filter.MinFrameRate)
+	}
+	if filter.Sponsored != nil {
+		query = query.Where("is_sponsored = ?", *filter.Sponsored)
+	}
+	if filter.Query != "" {
+		like := likeOp(r.db)
+		query = query.Where("title "+like+" ? OR description "+like+" ?", "%"+filter.Query+"%", "%"+filter.Query+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	switch filter.Sort {
+	case "time":
+		query = query.Order("publish_time DESC").Order("id DESC")
+	case "hot":
+		query = query.Clauses(hotOrder(r.db))
+	default:
+		query = query.Order("created_at DESC").Order("id DESC")
+	}
+
+	var videos []model.Video
+	err := query.Offset(skip).Limit(limit).
+		Preload("Author", withDeleted).
+		Find(&videos).Error
+	return videos, total, err
+}
+
 // IncrementViewCount 观看数 +1
-func (r *VideoRepository) IncrementViewCount(id int64) error {
-	return r.db.Model(&model.Video{}).Where("id = ?", id).
+func (r *VideoRepository) IncrementViewCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.Video{}).Where("id = ?", id).
 		UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
 }
 
+// AddWatchMetrics 累加客户端上报的播放、完播次数与观看时长
+func (r *VideoRepository) AddWatchMetrics(ctx context.Context, id, plays, completions, watchTimeMs int64) error {
+	return conn(ctx, r.db).Model(&model.Video{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"play_count":     gorm.Expr("play_count + ?", plays),
+			"complete_count": gorm.Expr("complete_count + ?", completions),
+			"watch_time_ms":  gorm.Expr("watch_time_ms + ?", watchTimeMs),
+		}).Error
+}
+
+// UpdateSummary 保存 AI 生成的摘要与关键时刻（关键时刻按 JSON 序列化，需用结构体更新）
+func (r *VideoRepository) UpdateSummary(ctx context.Context, id int64, summary string, keyMoments []model.KeyMoment) error {
+	return conn(ctx, r.db).Model(&model.Video{ID: id}).
+		Select("summary", "key_moments", "updated_at").
+		Updates(&model.Video{Summary: summary, KeyMoments: keyMoments}).Error
+}
+
 // IncrementCommentCount 评论数 +1
-func (r *VideoRepository) IncrementCommentCount(id int64) error {
-	return r.db.Model(&model.Video{}).Where("id = ?", id).
+func (r *VideoRepository) IncrementCommentCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.Video{}).Where("id = ?", id).
 		UpdateColumn("comment_count", gorm.Expr("comment_count + 1")).Error
 }
 
 // DecrementCommentCount 评论数 -1
-func (r *VideoRepository) DecrementCommentCount(id int64) error {
-	return r.db.Model(&model.Video{}).Where("id = ? AND comment_count > 0", id).
+func (r *VideoRepository) DecrementCommentCount(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.Video{}).Where("id = ? AND comment_count > 0", id).
 		UpdateColumn("comment_count", gorm.Expr("comment_count - 1")).Error
 }
 
-// IncrementFavoriteCount 点赞数 +1
-func (r *VideoRepository) IncrementFavoriteCount(id int64) error {
-	return r.db.Model(&model.Video{}).Where("id = ?", id).
-		UpdateColumn("favorite_count", gorm.Expr("favorite_count + 1")).Error
+// reactionCountColumns 表态类型对应的视频计数列
+var reactionCountColumns = map[string]string{
+	model.ReactionLike:  "like_count",
+	model.ReactionLove:  "love_count",
+	model.ReactionLaugh: "laugh_count",
+	model.ReactionWow:   "wow_count",
+}
+
+func reactionCountColumn(reaction string) (string, error) {
+	column, ok := reactionCountColumns[reaction]
+	if !ok {
+		return "", fmt.Errorf("unknown reaction %q", reaction)
+	}
+	return column, nil
+}
+
+// decrementExpr 计数 -1，不低于 0
+func decrementExpr(column string) clause.Expr {
+	return gorm.Expr("CASE WHEN " + column + " > 0 THEN " + column + " - 1 ELSE 0 END")
+}
+
+// IncrementFavoriteCount 点赞数及对应表态类型的计数 +1
+func (r *VideoRepository) IncrementFavoriteCount(ctx context.Context, id int64, reaction string) error {
+	column, err := reactionCountColumn(reaction)
+	if err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Model(&model.Video{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"favorite_count": gorm.Expr("favorite_count + 1"),
+			column:           gorm.Expr(column + " + 1"),
+		}).Error
+}
+
+// DecrementFavoriteCount 点赞数及对应表态类型的计数 -1
+func (r *VideoRepository) DecrementFavoriteCount(ctx context.Context, id int64, reaction string) error {
+	column, err := reactionCountColumn(reaction)
+	if err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Model(&model.Video{}).Where("id = ? AND favorite_count > 0", id).
+		UpdateColumns(map[string]interface{}{
+			"favorite_count": gorm.Expr("favorite_count - 1"),
+			column:           decrementExpr(column),
+		}).Error
+}
+
+// SwitchReaction 用户更换表态类型：原类型计数 -1，新类型计数 +1，点赞总数不变
+func (r *VideoRepository) SwitchReaction(ctx context.Context, id int64, from, to string) error {
+	fromColumn, err := reactionCountColumn(from)
+	if err != nil {
+		return err
+	}
+	toColumn, err := reactionCountColumn(to)
+	if err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Model(&model.Video{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			fromColumn: decrementExpr(fromColumn),
+			toColumn:   gorm.Expr(toColumn + " + 1"),
+		}).Error
+}
+
+// GetAuthorIDs 返回 ids 中存在（未删除）的视频及其作者 ID（视频 ID -> 作者 ID）
+func (r *VideoRepository) GetAuthorIDs(ctx context.Context, ids []int64) (map[int64]int64, error) {
+	if len(ids) == 0 {
+		return map[int64]int64{}, nil
+	}
+	var videos []model.Video
+	if err := conn(ctx, r.db).Select("id", "author_id").Where("id IN ?", ids).Find(&videos).Error; err != nil {
+		return nil, err
+	}
+	authors := make(map[int64]int64, len(videos))
+	for _, v := range videos {
+		authors[v.ID] = v.AuthorID
+	}
+	return authors, nil
+}
+
+// 按明细表计算的视频计数：评论数不含被隐藏、已删除的评论
+const (
+	videoFavoriteCountSQL = "(SELECT COUNT(*) FROM favorites WHERE favorites.video_id = videos.id)"
+	videoCommentCountSQL  = "(SELECT COUNT(*) FROM comments WHERE comments.video_id = videos.id AND comments.is_hidden = ? AND comments.deleted_at IS NULL)"
+)
+
+// videoReactionCountSQL 按点赞表计算视频某种表态的数量
+func videoReactionCountSQL(reaction string) string {
+	return "(SELECT COUNT(*) FROM favorites WHERE favorites.video_id = videos.id AND favorites.reaction = '" + reaction + "')"
+}
+
+// RecountFavoriteCounts 按点赞表重新计算视频的点赞数及各类型表态数，返回修正的视频数
+func (r *VideoRepository) RecountFavoriteCounts(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	mismatch := []string{"favorite_count <> " + videoFavoriteCountSQL}
+	updates := map[string]interface{}{"favorite_count": gorm.Expr(videoFavoriteCountSQL)}
+	for _, reaction := range model.Reactions {
+		column := reactionCountColumns[reaction]
+		mismatch = append(mismatch, column+" <> "+videoReactionCountSQL(reaction))
+		updates[column] = gorm.Expr(videoReactionCountSQL(reaction))
+	}
+	result := conn(ctx, r.db).Model(&model.Video{}).
+		Where("id IN ? AND ("+strings.Join(mismatch, " OR ")+")", ids).
+		UpdateColumns(updates)
+	return result.RowsAffected, result.Error
+}
+
+// RecountCommentCounts 按评论表重新计算视频的评论数，返回修正的视频数
+func (r *VideoRepository) RecountCommentCounts(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := conn(ctx, r.db).Model(&model.Video{}).
+		Where("id IN ? AND comment_count <> "+videoCommentCountSQL, ids, false).
+		UpdateColumn("comment_count", gorm.Expr(videoCommentCountSQL, false))
+	return result.RowsAffected, result.Error
+}
+
+// ListIDsAfter 按 ID 正序返回 afterID 之后的视频 ID（分批遍历用）
+func (r *VideoRepository) ListIDsAfter(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := conn(ctx, r.db).Model(&model.Video{}).Where("id > ?", afterID).
+		Order("id ASC").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ListPublishedAfter 按 ID 正序返回 afterID 之后的已发布视频（只含定位原始文件所需的字段，分批遍历用）
+func (r *VideoRepository) ListPublishedAfter(ctx context.Context, afterID int64, limit int) ([]model.Video, error) {
+	var videos []model.Video
+	err := conn(ctx, r.db).Select("id", "author_id", "file_format", "width", "height").
+		Where("id > ? AND status = ?", afterID, "published").
+		Order("id ASC").Limit(limit).Find(&videos).Error
+	return videos, err
+}
+
+// CountByStatus 统计某状态的视频数
+func (r *VideoRepository) CountByStatus(ctx context.Context, status string) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.Video{}).Where("status = ?", status).Count(&count).Error
+	return count, err
+}
+
+// ListLegalHolds 分页获取处于法律保全中的视频（含作者），按保全时间倒序
+func (r *VideoRepository) ListLegalHolds(ctx context.Context, skip, limit int) ([]model.Video, int64, error) {
+	query := conn(ctx, r.db).Model(&model.Video{}).Where("legal_hold_at IS NOT NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var videos []model.Video
+	err := query.Preload("Author", withDeleted).
+		Order("legal_hold_at DESC").Order("id DESC").
+		Offset(skip).Limit(limit).
+		Find(&videos).Error
+	return videos, total, err
+}
+
+// ListVideosBefore 按 ID 倒序游标分页查询视频，beforeID 为 0 时从最新开始；
+// region 非 nil 时为对外列表，只返回在该国家/地区可见的公开视频
+func (r *VideoRepository) ListVideosBefore(ctx context.Context, beforeID int64, limit int, authorID *int64, status *string, region *string, withAuthor bool) ([]model.Video, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.Video{})
+	if region != nil {
+		query = query.Scopes(availableIn(*region), publicOnly)
+	}
+	if authorID != nil {
+		query = query.Where("author_id = ?", *authorID)
+	}
+	if status != nil {
+		query = query.Where("status = ?", *status)
+		if *status == "published" {
+			query = query.Scopes(notOnLegalHold)
+		}
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if withAuthor {
+		query = query.Preload("Author", withDeleted)
+	}
+
+	var videos []model.Video
+	err := query.Order("id DESC").Limit(limit).Find(&videos).Error
+	return videos, err
+}
+
+// ListArchiveCandidates 返回可归档到冷存储的视频：已发布、在标准存储、发布早于 cutoff，
+// 且 cutoff 之后没有任何播放记录（也未在 cutoff 之后恢复过）。按 ID 正序
+func (r *VideoRepository) ListArchiveCandidates(ctx context.Context, cutoff time.Time, limit int) ([]model.Video, error) {
+	var videos []model.Video
+	watched := conn(ctx, r.db).Model(&model.VideoDailyStat{}).Select("1").
+		Where("video_daily_stats.video_id = videos.id AND video_daily_stats.stat_date >= ?", cutoff.UTC().Truncate(24*time.Hour)).
+		Where("video_daily_stats.views > 0 OR video_daily_stats.plays > 0")
+	err := conn(ctx, r.db).
+		Where("status = ? AND storage_tier = ?", "published", model.StorageTierHot).
+		Where("publish_time < ?", cutoff).
+		Where("storage_tier_changed_at IS NULL OR storage_tier_changed_at < ?", cutoff).
+		Where("NOT EXISTS (?)", watched).
+		Order("id ASC").Limit(limit).Find(&videos).Error
+	return videos, err
+}
+
+// ListStaleStorageTransitions 返回停留在 archiving / rehydrating 且变更时间早于 before 的视频（中断后待续做）
+func (r *VideoRepository) ListStaleStorageTransitions(ctx context.Context, before time.Time, limit int) ([]model.Video, error) {
+	var videos []model.Video
+	err := conn(ctx, r.db).
+		Where("storage_tier IN ?", []string{model.StorageTierArchiving, model.StorageTierRehydrating}).
+		Where("storage_tier_changed_at IS NULL OR storage_tier_changed_at < ?", before).
+		Order("id ASC").Limit(limit).Find(&videos).Error
+	return videos, err
+}
+
+// CompareAndSetStorageTier 仅当存储层级为 from 时改为 to 并记录变更时间；changedBefore 非 nil 时
+// 还要求上次变更早于该时间（用于接管中断的迁移）。返回是否更新成功
+func (r *VideoRepository) CompareAndSetStorageTier(ctx context.Context, id int64, from, to string, changedBefore *time.Time) (bool, error) {
+	query := conn(ctx, r.db).Model(&model.Video{}).Where("id = ? AND storage_tier = ?", id, from)
+	if changedBefore != nil {
+		query = query.Where("storage_tier_changed_at IS NULL OR storage_tier_changed_at < ?", *changedBefore)
+	}
+	res := query.UpdateColumns(map[string]interface{}{
+		"storage_tier":            to,
+		"storage_tier_changed_at": time.Now(),
+	})
+	return res.RowsAffected > 0, res.Error
+}
+
+// ListDuePremieres 返回首映时间已到（早于等于 now）、仍处于 scheduled 状态的视频，按首映时间正序
+func (r *VideoRepository) ListDuePremieres(ctx context.Context, now time.Time, limit int) ([]model.Video, error) {
+	var videos []model.Video
+	err := conn(ctx, r.db).
+		Where("status = ? AND premiere_at <= ?", "scheduled", now).
+		Order("premiere_at ASC").Order("id ASC").Limit(limit).Find(&videos).Error
+	return videos, err
 }
 
-// DecrementFavoriteCount 点赞数 -1
-func (r *VideoRepository) DecrementFavoriteCount(id int64) error {
-	return r.db.Model(&model.Video{}).Where("id = ? AND favorite_count > 0", id).
-		UpdateColumn("favorite_count", gorm.Expr("favorite_count - 1")).Error
+// StartPremiere 仅当视频仍为 scheduled 时发布，发布时间记为首映时间。返回是否更新成功
+func (r *VideoRepository) StartPremiere(ctx context.Context, id int64) (bool, error) {
+	res := conn(ctx, r.db).Model(&model.Video{}).Where("id = ? AND status = ?", id, "scheduled").
+		Updates(map[string]interface{}{
+			"status":       "published",
+			"publish_time": gorm.Expr("premiere_at"),
+		})
+	return res.RowsAffected > 0, res.Error
 }
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VideoStatRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoStatRepository(db *gorm.DB) *VideoStatRepository {
+	return &VideoStatRepository{db: db}
+}
+
+// StatDate 统计日期：时间按 UTC 截断到天
+func StatDate(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// IncrementViews 当日播放数 +1
+func (r *VideoStatRepository) IncrementViews(ctx context.Context, videoID int64) error {
+	return r.increment(ctx, &model.VideoDailyStat{VideoID: videoID, Views: 1})
+}
+
+// AddLikes 当日点赞净增数（取消点赞传 -1）
+func (r *VideoStatRepository) AddLikes(ctx context.Context, videoID, delta int64) error {
+	return r.increment(ctx, &model.VideoDailyStat{VideoID: videoID, Likes: delta})
+}
+
+// AddComments 当日评论净增数（删除评论传 -1）
+func (r *VideoStatRepository) AddComments(ctx context.Context, videoID, delta int64) error {
+	return r.increment(ctx, &model.VideoDailyStat{VideoID: videoID, Comments: delta})
+}
+
+// AddWatch 累加客户端上报的播放、完播次数与观看时长
+func (r *VideoStatRepository) AddWatch(ctx context.Context, videoID, plays, completions, watchTimeMs int64) error {
+	return r.increment(ctx, &model.VideoDailyStat{VideoID: videoID, Plays: plays, Completions: completions, WatchTimeMs: watchTimeMs})
+}
+
+// increment 按 (video_id, stat_date) upsert，各计数列在已有值上累加
+func (r *VideoStatRepository) increment(ctx context.Context, delta *model.VideoDailyStat) error {
+	delta.StatDate = StatDate(time.Now())
+	const table = "video_daily_stats"
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "video_id"}, {Name: "stat_date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"views":         accumulate(r.db, table, "views"),
+			"likes":         accumulate(r.db, table, "likes"),
+			"comments":      accumulate(r.db, table, "comments"),
+			"plays":         accumulate(r.db, table, "plays"),
+			"completions":   accumulate(r.db, table, "completions"),
+			"watch_time_ms": accumulate(r.db, table, "watch_time_ms"),
+			"updated_at":    gorm.Expr(excluded(r.db, "updated_at")),
+		}),
+	}).Create(delta).Error
+}
+
+// ListRange 查询视频在 [from, to] 日期范围内的统计，按日期升序
+func (r *VideoStatRepository) ListRange(ctx context.Context, videoID int64, from, to time.Time) ([]model.VideoDailyStat, error) {
+	var stats []model.VideoDailyStat
+	err := replica(r.db).WithContext(ctx).
+		Where("video_id = ? AND stat_date BETWEEN ? AND ?", videoID, StatDate(from), StatDate(to)).
+		Order("stat_date ASC").
+		Find(&stats).Error
+	return stats, err
+}
+
+// DailyTotal 按日期汇总的计数
+type DailyTotal struct {
+	StatDate time.Time
+	Views    int64
+	Likes    int64
+	Comments int64
+}
+
+// DailyTotalsByAuthor 汇总作者所有视频在 [from, to] 内每日的播放、点赞、评论
+func (r *VideoStatRepository) DailyTotalsByAuthor(ctx context.Context, authorID int64, from, to time.Time) ([]DailyTotal, error) {
+	var totals []DailyTotal
+	err := replica(r.db).WithContext(ctx).
+		Table("video_daily_stats AS s").
+		Select("s.stat_date, SUM(s.views) AS views, SUM(s.likes) AS likes, SUM(s.comments) AS comments").
+		Joins("JOIN videos v ON v.id = s.video_id").
+		Where("v.author_id = ? AND s.stat_date BETWEEN ? AND ?", authorID, StatDate(from), StatDate(to)).
+		Group("s.stat_date").
+		Order("s.stat_date ASC").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// VideoTotal 单个视频在时间范围内的汇总
+type VideoTotal struct {
+	VideoID     int64
+	Views       int64
+	Likes       int64
+	Comments    int64
+	Plays       int64
+	Completions int64
+	WatchTimeMs int64
+}
+
+// TopVideosByAuthor 作者在 [from, to] 内播放数最高的视频（不含已删除）
+func (r *VideoStatRepository) TopVideosByAuthor(ctx context.Context, authorID int64, from, to time.Time, limit int) ([]VideoTotal, error) {
+	var totals []VideoTotal
+	err := replica(r.db).WithContext(ctx).
+		Table("video_daily_stats AS s").
+		Select("s.video_id, SUM(s.views) AS views, SUM(s.likes) AS likes, SUM(s.comments) AS comments, "+
+			"SUM(s.plays) AS plays, SUM(s.completions) AS completions, SUM(s.watch_time_ms) AS watch_time_ms").
+		Joins("JOIN videos v ON v.id = s.video_id").
+		Where("v.author_id = ? AND v.deleted_at IS NULL AND s.stat_date BETWEEN ? AND ?", authorID, StatDate(from), StatDate(to)).
+		Group("s.video_id").
+		Order("views DESC, s.video_id DESC").
+		Limit(limit).
+		Scan(&totals).Error
+	return totals, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VideoTagRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoTagRepository(db *gorm.DB) *VideoTagRepository {
+	return &VideoTagRepository{db: db}
+}
+
+// ListByVideo 获取视频的全部标签与分类
+func (r *VideoTagRepository) ListByVideo(ctx context.Context, videoID int64) ([]model.VideoTag, error) {
+	var tags []model.VideoTag
+	err := conn(ctx, r.db).
+		Where("video_id = ?", videoID).
+		Order("id ASC").
+		Find(&tags).Error
+	return tags, err
+}
+
+// ReplaceMachineTags 用新生成的结果替换视频的 AI 标签，作者添加的同名标签保持不变
+func (r *VideoTagRepository) ReplaceMachineTags(ctx context.Context, videoID int64, tags []model.VideoTag) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("video_id = ? AND source = ?", videoID, model.VideoTagSourceMachine).
+			Delete(&model.VideoTag{}).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	})
+}
+
+// ReplaceAll 用作者编辑后的结果替换视频的全部标签
+func (r *VideoTagRepository) ReplaceAll(ctx context.Context, videoID int64, tags []model.VideoTag) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("video_id = ?", videoID).Delete(&model.VideoTag{}).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		return tx.Create(&tags).Error
+	})
+}
+
+// TopCategories 获取 sincePublish 之后发布的视频中最常见的分类
+func (r *VideoTagRepository) TopCategories(ctx context.Context, sincePublish time.Time, limit int) ([]string, error) {
+	var names []string
+	err := replica(r.db).WithContext(ctx).Model(&model.VideoTag{}).
+		Joins("JOIN videos ON videos.id = video_tags.video_id").
+		Where("video_tags.kind = ? AND videos.status = ? AND videos.publish_time >= ? AND videos.deleted_at IS NULL",
+			model.VideoTagKindCategory, "published", sincePublish).
+		Group("video_tags.name").
+		Order("COUNT(*) DESC").Order("video_tags.name ASC").
+		Limit(limit).
+		Pluck("video_tags.name", &names).Error
+	return names, err
+}
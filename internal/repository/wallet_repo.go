@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WalletTransactionFilter 金币流水查询条件，为空的条件不过滤
+type WalletTransactionFilter struct {
+	UserID     *int64
+	Kind       *string
+	VideoID    *int64
+	TransferID *string
+}
+
+type WalletRepository struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) *WalletRepository {
+	return &WalletRepository{db: db}
+}
+
+// GetBalance 获取用户余额，没有钱包时为 0
+func (r *WalletRepository) GetBalance(ctx context.Context, userID int64) (int64, error) {
+	var wallet model.Wallet
+	err := conn(ctx, r.db).Where("user_id = ?", userID).First(&wallet).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return wallet.Balance, err
+}
+
+// Credit 增加余额（没有钱包时创建），返回记账后余额；须在事务中调用
+func (r *WalletRepository) Credit(ctx context.Context, userID, amount int64) (int64, error) {
+	db := conn(ctx, r.db)
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Wallet{UserID: userID}).Error; err != nil {
+		return 0, err
+	}
+	err := db.Model(&model.Wallet{}).Where("user_id = ?", userID).
+		Update("balance", gorm.Expr("balance + ?", amount)).Error
+	if err != nil {
+		return 0, err
+	}
+	return r.GetBalance(ctx, userID)
+}
+
+// Debit 余额充足时扣减并返回记账后余额，余额不足时返回 false 且不修改；须在事务中调用。
+// 扣减是带条件的单条 UPDATE，并发扣款不会使余额变为负数
+func (r *WalletRepository) Debit(ctx context.Context, userID, amount int64) (int64, bool, error) {
+	result := conn(ctx, r.db).Model(&model.Wallet{}).
+		Where("user_id = ? AND balance >= ?", userID, amount).
+		Update("balance", gorm.Expr("balance - ?", amount))
+	if result.Error != nil || result.RowsAffected == 0 {
+		return 0, false, result.Error
+	}
+	balance, err := r.GetBalance(ctx, userID)
+	return balance, err == nil, err
+}
+
+// CreateTransactions 写入流水
+func (r *WalletRepository) CreateTransactions(ctx context.Context, txs []model.WalletTransaction) error {
+	return conn(ctx, r.db).Create(&txs).Error
+}
+
+// GetByRequestID 按用户与幂等键查询已记账的流水
+func (r *WalletRepository) GetByRequestID(ctx context.Context, userID int64, requestID string) (*model.WalletTransaction, error) {
+	var tx model.WalletTransaction
+	err := conn(ctx, r.db).Where("user_id = ? AND request_id = ?", userID, requestID).First(&tx).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// ListTransactions 按条件分页查询流水（按时间倒序）
+func (r *WalletRepository) ListTransactions(ctx context.Context, filter *WalletTransactionFilter, skip, limit int) ([]model.WalletTransaction, int64, error) {
+	query := replica(r.db).WithContext(ctx).Model(&model.WalletTransaction{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Kind != nil && *filter.Kind != "" {
+		query = query.Where("kind = ?", *filter.Kind)
+	}
+	if filter.VideoID != nil {
+		query = query.Where("video_id = ?", *filter.VideoID)
+	}
+	if filter.TransferID != nil && *filter.TransferID != "" {
+		query = query.Where("transfer_id = ?", *filter.TransferID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var txs []model.WalletTransaction
+	err := query.Order("id DESC").Offset(skip).Limit(limit).Find(&txs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return txs, total, nil
+}
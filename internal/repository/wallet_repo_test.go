@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+func TestWalletDebit(t *testing.T) {
+	db := newTestDB(t, &model.Wallet{})
+	walletRepo := NewWalletRepository(db)
+	ctx := context.Background()
+
+	if _, err := walletRepo.Credit(ctx, 1, 50); err != nil {
+		t.Fatalf("Credit() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		amount      int64
+		wantOK      bool
+		wantBalance int64
+	}{
+		{name: "insufficient balance", amount: 80, wantOK: false, wantBalance: 50},
+		{name: "partial", amount: 30, wantOK: true, wantBalance: 20},
+		{name: "exact balance", amount: 20, wantOK: true, wantBalance: 0},
+		{name: "empty wallet", amount: 1, wantOK: false, wantBalance: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok, err := walletRepo.Debit(ctx, 1, tt.amount)
+			if err != nil {
+				t.Fatalf("Debit() error = %v", err)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("Debit() ok = %v, want %v", ok, tt.wantOK)
+			}
+			balance, err := walletRepo.GetBalance(ctx, 1)
+			if err != nil {
+				t.Fatalf("GetBalance() error = %v", err)
+			}
+			if balance != tt.wantBalance {
+				t.Errorf("balance = %d, want %d", balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestWalletDebitWithoutWallet(t *testing.T) {
+	db := newTestDB(t, &model.Wallet{})
+	walletRepo := NewWalletRepository(db)
+
+	_, ok, err := walletRepo.Debit(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("Debit() error = %v", err)
+	}
+	if ok {
+		t.Error("Debit() from a missing wallet succeeded")
+	}
+}
+
+func TestWalletRequestIDRecordedOnce(t *testing.T) {
+	db := newTestDB(t, &model.WalletTransaction{})
+	walletRepo := NewWalletRepository(db)
+	ctx := context.Background()
+
+	requestID := "req-1"
+	first := []model.WalletTransaction{{UserID: 1, Kind: model.WalletTxTipSent, Amount: -10, TransferID: "t1", RequestID: &requestID}}
+	if err := walletRepo.CreateTransactions(ctx, first); err != nil {
+		t.Fatalf("CreateTransactions() error = %v", err)
+	}
+
+	replay := []model.WalletTransaction{{UserID: 1, Kind: model.WalletTxTipSent, Amount: -10, TransferID: "t2", RequestID: &requestID}}
+	if err := walletRepo.CreateTransactions(ctx, replay); err == nil {
+		t.Fatal("CreateTransactions() with a reused request_id succeeded")
+	}
+
+	// 幂等键按用户区分，其他用户可以使用相同的值
+	other := []model.WalletTransaction{{UserID: 2, Kind: model.WalletTxTipSent, Amount: -10, TransferID: "t3", RequestID: &requestID}}
+	if err := walletRepo.CreateTransactions(ctx, other); err != nil {
+		t.Fatalf("CreateTransactions() for another user error = %v", err)
+	}
+
+	tx, err := walletRepo.GetByRequestID(ctx, 1, requestID)
+	if err != nil {
+		t.Fatalf("GetByRequestID() error = %v", err)
+	}
+	if tx.TransferID != "t1" {
+		t.Errorf("GetByRequestID() transfer_id = %q, want %q", tx.TransferID, "t1")
+	}
+
+	if _, err := walletRepo.GetByRequestID(ctx, 1, "missing"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetByRequestID(missing) error = %v, want ErrRecordNotFound", err)
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WatchHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewWatchHistoryRepository(db *gorm.DB) *WatchHistoryRepository {
+	return &WatchHistoryRepository{db: db}
+}
+
+// Touch 记录一次观看，已存在时只更新最近观看时间
+func (r *WatchHistoryRepository) Touch(ctx context.Context, userID, videoID int64, watchedAt time.Time) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "video_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"last_watched_at": watchedAt}),
+	}).Create(&model.WatchHistory{UserID: userID, VideoID: videoID, LastWatchedAt: watchedAt}).Error
+}
+
+// ListSince 按 ID 顺序分批读取 since 之后有观看的记录（afterID 为上一批最后一条的 ID）
+func (r *WatchHistoryRepository) ListSince(ctx context.Context, since time.Time, afterID int64, limit int) ([]model.WatchHistory, error) {
+	var histories []model.WatchHistory
+	err := replica(r.db).WithContext(ctx).
+		Where("last_watched_at >= ? AND id > ?", since, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&histories).Error
+	return histories, err
+}
+
+// RecentVideoIDs 用户最近观看的视频 ID
+func (r *WatchHistoryRepository) RecentVideoIDs(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := conn(ctx, r.db).Model(&model.WatchHistory{}).
+		Where("user_id = ?", userID).
+		Order("last_watched_at DESC").
+		Limit(limit).
+		Pluck("video_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+
+	vidav1 "vida-go/api/proto/vida/v1"
+	"vida-go/internal/api/dto"
+	"vida-go/internal/service"
+	"vida-go/pkg/utils"
+)
+
+// AuthServer 认证相关 gRPC 接口
+type AuthServer struct {
+	vidav1.UnimplementedAuthServiceServer
+	authService *service.AuthService
+}
+
+func NewAuthServer(authService *service.AuthService) *AuthServer {
+	return &AuthServer{authService: authService}
+}
+
+// Login 用户名密码登录
+func (s *AuthServer) Login(ctx context.Context, req *vidav1.LoginRequest) (*vidav1.LoginResponse, error) {
+	if req.GetUsername() == "" || req.GetPassword() == "" {
+		return nil, invalidArgument(ctx, "请求参数无效")
+	}
+
+	data, err := s.authService.Login(ctx, &dto.LoginRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &vidav1.LoginResponse{
+		Token:     data.Token,
+		TokenType: data.TokenType,
+		ExpiresIn: int32(data.ExpiresIn),
+		User:      toPBUser(&data.User),
+	}, nil
+}
+
+// VerifyToken 校验访问令牌
+func (s *AuthServer) VerifyToken(ctx context.Context, req *vidav1.VerifyTokenRequest) (*vidav1.VerifyTokenResponse, error) {
+	claims, err := utils.ParseToken(req.GetToken())
+	if err != nil {
+		return nil, unauthenticated(ctx, "无效或过期的认证令牌")
+	}
+	// 调用方据此以该用户身份执行操作，无法区分模拟登录，也无法限制敏感操作，因此不接受模拟登录令牌
+	if claims.IsImpersonation() {
+		return nil, permissionDenied(ctx, "模拟登录期间不能执行该操作")
+	}
+	return &vidav1.VerifyTokenResponse{UserId: claims.UserID}, nil
+}
+
+// GetCurrentUser 获取当前令牌对应的用户
+func (s *AuthServer) GetCurrentUser(ctx context.Context, _ *vidav1.GetCurrentUserRequest) (*vidav1.User, error) {
+	userID, _ := currentUserID(ctx)
+	user, err := s.authService.GetCurrentUser(ctx, userID)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toPBUser(user), nil
+}
+
+// GetUser 按 ID 获取用户
+func (s *AuthServer) GetUser(ctx context.Context, req *vidav1.GetUserRequest) (*vidav1.User, error) {
+	if req.GetUserId() <= 0 {
+		return nil, invalidArgument(ctx, "无效的用户ID")
+	}
+	user, err := s.authService.GetCurrentUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toPBUser(user), nil
+}
+
+func toPBUser(user *dto.UserInfo) *vidav1.User {
+	return &vidav1.User{
+		Id:              user.ID,
+		UserName:        user.Username,
+		Avatar:          user.Avatar,
+		BackgroundImage: user.BackgroundImage,
+		UserRole:        user.UserRole,
+		FollowCount:     user.FollowCount,
+		FollowerCount:   user.FollowerCount,
+		TotalFavorited:  user.TotalFavorited,
+	}
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/service"
+	"vida-go/pkg/i18n"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceErrorCodes Service 层错误到 gRPC 状态码的映射
+var serviceErrorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{service.ErrUserNotFound, codes.NotFound},
+	{service.ErrUserDeleted, codes.NotFound},
+	{service.ErrInvalidCredential, codes.Unauthenticated},
+	{service.ErrUserSuspended, codes.PermissionDenied},
+	{service.ErrPasswordResetRequired, codes.PermissionDenied},
+	{service.ErrVideoNotFound, codes.NotFound},
+}
+
+// toStatus 将 Service 层错误转换为 gRPC 状态，未知错误记录日志并返回 Internal
+func toStatus(ctx context.Context, err error) error {
+	for _, m := range serviceErrorCodes {
+		if errors.Is(err, m.err) {
+			return status.Error(m.code, i18n.T(localeFromContext(ctx), err.Error()))
+		}
+	}
+	logger.FromContext(ctx).Error("gRPC handler failed", zap.Error(err))
+	return status.Error(codes.Internal, i18n.T(localeFromContext(ctx), "操作失败，请稍后重试"))
+}
+
+// unauthenticated 返回本地化的认证失败错误
+func unauthenticated(ctx context.Context, message string) error {
+	return status.Error(codes.Unauthenticated, i18n.T(localeFromContext(ctx), message))
+}
+
+// permissionDenied 返回本地化的权限错误
+func permissionDenied(ctx context.Context, message string) error {
+	return status.Error(codes.PermissionDenied, i18n.T(localeFromContext(ctx), message))
+}
+
+// invalidArgument 返回本地化的参数错误
+func invalidArgument(ctx context.Context, message string) error {
+	return status.Error(codes.InvalidArgument, i18n.T(localeFromContext(ctx), message))
+}
+
+// normalizePage 分页参数规则与 HTTP 接口的 parsePagination 一致
+func normalizePage(page, pageSize int32) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return int(page), int(pageSize)
+}
@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"vida-go/internal/infra/tracing"
+	"vida-go/internal/rbac"
+	"vida-go/internal/service"
+	"vida-go/pkg/i18n"
+	"vida-go/pkg/logger"
+	"vida-go/pkg/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type contextKey int
+
+const (
+	userIDKey contextKey = iota
+	impersonatorIDKey
+	localeKey
+)
+
+// maxRequestIDLen 外部传入的请求 ID 超长时视为无效，重新生成
+const maxRequestIDLen = 128
+
+// publicMethods 无需认证即可调用的方法，其余方法与 HTTP 接口一样要求 Bearer Token
+var publicMethods = map[string]bool{
+	"/vida.v1.AuthService/Login":       true,
+	"/vida.v1.AuthService/VerifyToken": true,
+}
+
+// RecoveryInterceptor 捕获 panic，返回 Internal 错误，对应 Gin 的 Recovery 中间件
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Panic recovered",
+					zap.Any("error", r),
+					zap.String("method", info.FullMethod),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				err = status.Error(codes.Internal, "Internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// LoggerInterceptor 记录每次调用的方法、状态码与耗时，对应 Gin 的 Logger 中间件；
+// 同时分配请求 ID（沿用 x-request-id 元数据），与方法名一起挂到请求级 Logger 上
+func LoggerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		requestID := firstMetadata(ctx, "x-request-id")
+		if requestID == "" || len(requestID) > maxRequestIDLen {
+			requestID = uuid.NewString()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestID))
+		ctx = logger.WithFields(ctx,
+			zap.String("request_id", requestID),
+			zap.String("route", info.FullMethod),
+		)
+		resp, err := handler(ctx, req)
+
+		ip := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			ip = p.Addr.String()
+		}
+		logger.FromContext(ctx).Info("gRPC Request",
+			zap.String("code", status.Code(err).String()),
+			zap.String("ip", ip),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("trace_id", tracing.TraceID(ctx)),
+		)
+		return resp, err
+	}
+}
+
+// LocaleInterceptor 根据 accept-language 元数据解析语言，对应 Gin 的 Locale 中间件
+func LocaleInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		lang := i18n.Match(firstMetadata(ctx, "accept-language"))
+		return handler(context.WithValue(ctx, localeKey, lang), req)
+	}
+}
+
+// AuthInterceptor 从 authorization 元数据中解析 Bearer Token，对应 Gin 的 AuthRequired 中间件；
+// 管理员模拟登录的调用与 HTTP 接口一样在日志中标出管理员，并逐次记入审计日志
+func AuthInterceptor(auditService *service.AuditService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		token := bearerToken(firstMetadata(ctx, "authorization"))
+		if token == "" {
+			return nil, unauthenticated(ctx, "缺少认证令牌")
+		}
+		claims, err := utils.ParseToken(token)
+		if err != nil {
+			return nil, unauthenticated(ctx, "无效或过期的认证令牌")
+		}
+		if !claims.IsImpersonation() {
+			ctx = logger.WithFields(ctx, zap.Int64("user_id", claims.UserID))
+			return handler(context.WithValue(ctx, userIDKey, claims.UserID), req)
+		}
+
+		ctx = logger.WithFields(ctx, zap.Int64("user_id", claims.UserID), zap.Int64("impersonator_id", claims.ImpersonatorID))
+		ctx = context.WithValue(context.WithValue(ctx, userIDKey, claims.UserID), impersonatorIDKey, claims.ImpersonatorID)
+		resp, err := handler(ctx, req)
+		// 调用日志由外层 LoggerInterceptor 输出，拿不到这里附加的字段，单独记录一条
+		logger.FromContext(ctx).Info("Impersonated gRPC call", zap.String("code", status.Code(err).String()))
+		recordImpersonatedCall(ctx, auditService, info.FullMethod, err)
+		return resp, err
+	}
+}
+
+// recordImpersonatedCall 记录模拟登录期间的调用，操作人为实际操作的管理员，与 HTTP 的审计记录格式一致
+func recordImpersonatedCall(ctx context.Context, auditService *service.AuditService, method string, err error) {
+	userID, _ := currentUserID(ctx)
+	impersonatorID, _ := currentImpersonatorID(ctx)
+	ip := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+	}
+	auditService.Record(ctx, &service.AuditEntry{
+		ActorID:    impersonatorID,
+		ActorRole:  rbac.RoleAdmin,
+		Action:     service.AuditActionImpersonatedCall,
+		TargetType: service.AuditTargetUser,
+		TargetID:   userID,
+		Reason:     fmt.Sprintf("gRPC %s %s", method, status.Code(err)),
+		IP:         ip,
+	})
+}
+
+// currentUserID 获取认证拦截器写入的当前用户 ID
+func currentUserID(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDKey).(int64)
+	return userID, ok
+}
+
+// currentImpersonatorID 当前调用为管理员模拟登录时返回管理员 ID
+func currentImpersonatorID(ctx context.Context) (int64, bool) {
+	impersonatorID, ok := ctx.Value(impersonatorIDKey).(int64)
+	return impersonatorID, ok
+}
+
+// localeFromContext 获取 LocaleInterceptor 解析出的请求语言
+func localeFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(localeKey).(string)
+	return lang
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// bearerToken 从 "Bearer xxx" 形式的值中提取 Token
+func bearerToken(value string) string {
+	parts := strings.SplitN(value, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
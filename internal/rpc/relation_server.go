@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+
+	vidav1 "vida-go/api/proto/vida/v1"
+	"vida-go/internal/api/dto"
+	"vida-go/internal/service"
+)
+
+// maxBatchUserIDs 单次批量查询关注状态的用户数上限，与 HTTP 接口一致
+const maxBatchUserIDs = 100
+
+// RelationServer 关注关系 gRPC 接口
+type RelationServer struct {
+	vidav1.UnimplementedRelationServiceServer
+	relationService *service.RelationService
+}
+
+func NewRelationServer(relationService *service.RelationService) *RelationServer {
+	return &RelationServer{relationService: relationService}
+}
+
+// IsFollowing 查询关注状态
+func (s *RelationServer) IsFollowing(ctx context.Context, req *vidav1.IsFollowingRequest) (*vidav1.IsFollowingResponse, error) {
+	if req.GetFollowerId() <= 0 || req.GetUserId() <= 0 {
+		return nil, invalidArgument(ctx, "无效的用户ID")
+	}
+	following, err := s.relationService.GetFollowStatus(ctx, req.GetFollowerId(), req.GetUserId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &vidav1.IsFollowingResponse{Following: following}, nil
+}
+
+// BatchIsFollowing 批量查询关注状态
+func (s *RelationServer) BatchIsFollowing(ctx context.Context, req *vidav1.BatchIsFollowingRequest) (*vidav1.BatchIsFollowingResponse, error) {
+	if req.GetFollowerId() <= 0 {
+		return nil, invalidArgument(ctx, "无效的用户ID")
+	}
+	if len(req.GetUserIds()) == 0 || len(req.GetUserIds()) > maxBatchUserIDs {
+		return nil, invalidArgument(ctx, "请求参数无效")
+	}
+	statusMap, err := s.relationService.BatchCheckFollowStatus(ctx, req.GetFollowerId(), req.GetUserIds())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &vidav1.BatchIsFollowingResponse{Following: statusMap}, nil
+}
+
+// ListFollowers 分页获取粉丝列表
+func (s *RelationServer) ListFollowers(ctx context.Context, req *vidav1.ListRelationsRequest) (*vidav1.ListRelationsResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, invalidArgument(ctx, "无效的用户ID")
+	}
+	page, pageSize := normalizePage(req.GetPage(), req.GetPageSize())
+	data, err := s.relationService.GetFollowerList(ctx, req.GetUserId(), page, pageSize)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toPBRelationList(data), nil
+}
+
+// ListFollowing 分页获取关注列表
+func (s *RelationServer) ListFollowing(ctx context.Context, req *vidav1.ListRelationsRequest) (*vidav1.ListRelationsResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, invalidArgument(ctx, "无效的用户ID")
+	}
+	page, pageSize := normalizePage(req.GetPage(), req.GetPageSize())
+	data, err := s.relationService.GetFollowingList(ctx, req.GetUserId(), page, pageSize)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toPBRelationList(data), nil
+}
+
+func toPBRelationList(data *dto.RelationListData) *vidav1.ListRelationsResponse {
+	users := make([]*vidav1.RelationUser, 0, len(data.Users))
+	for _, u := range data.Users {
+		users = append(users, &vidav1.RelationUser{
+			Id:            u.ID,
+			UserName:      u.Username,
+			Avatar:        u.Avatar,
+			FollowCount:   u.FollowCount,
+			FollowerCount: u.FollowerCount,
+		})
+	}
+	return &vidav1.ListRelationsResponse{
+		Users:      users,
+		Total:      data.Total,
+		Page:       int32(data.Page),
+		PageSize:   int32(data.PageSize),
+		TotalPages: data.TotalPages,
+	}
+}
@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	vidav1 "vida-go/api/proto/vida/v1"
+	"vida-go/internal/service"
+
+	"google.golang.org/grpc"
+)
+
+// NewServer 创建 gRPC 服务器并注册认证、视频、关注关系服务
+// 拦截器顺序与 Gin 中间件一致：Recovery -> Logger -> Locale -> Auth
+func NewServer(authServer *AuthServer, videoServer *VideoServer, relationServer *RelationServer, auditService *service.AuditService) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			RecoveryInterceptor(),
+			LoggerInterceptor(),
+			LocaleInterceptor(),
+			AuthInterceptor(auditService),
+		),
+	)
+
+	vidav1.RegisterAuthServiceServer(server, authServer)
+	vidav1.RegisterVideoServiceServer(server, videoServer)
+	vidav1.RegisterRelationServiceServer(server, relationServer)
+	return server
+}
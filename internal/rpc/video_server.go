@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+
+	vidav1 "vida-go/api/proto/vida/v1"
+	"vida-go/internal/api/dto"
+	"vida-go/internal/service"
+)
+
+// maxBatchVideoIDs 单次批量查询的视频数上限
+const maxBatchVideoIDs = 100
+
+// VideoServer 视频元数据 gRPC 接口
+type VideoServer struct {
+	vidav1.UnimplementedVideoServiceServer
+	videoService *service.VideoService
+}
+
+func NewVideoServer(videoService *service.VideoService) *VideoServer {
+	return &VideoServer{videoService: videoService}
+}
+
+// GetVideo 获取单个视频
+func (s *VideoServer) GetVideo(ctx context.Context, req *vidav1.GetVideoRequest) (*vidav1.Video, error) {
+	if req.GetVideoId() <= 0 {
+		return nil, invalidArgument(ctx, "无效的视频ID")
+	}
+	video, err := s.videoService.GetInfo(ctx, req.GetVideoId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toPBVideo(video), nil
+}
+
+// BatchGetVideos 批量获取视频
+func (s *VideoServer) BatchGetVideos(ctx context.Context, req *vidav1.BatchGetVideosRequest) (*vidav1.BatchGetVideosResponse, error) {
+	if len(req.GetVideoIds()) > maxBatchVideoIDs {
+		return nil, invalidArgument(ctx, "请求参数无效")
+	}
+	videos, err := s.videoService.BatchGetInfo(ctx, req.GetVideoIds())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	items := make([]*vidav1.Video, 0, len(videos))
+	for i := range videos {
+		items = append(items, toPBVideo(&videos[i]))
+	}
+	return &vidav1.BatchGetVideosResponse{Videos: items}, nil
+}
+
+// ListUserVideos 分页获取用户已发布的视频
+func (s *VideoServer) ListUserVideos(ctx context.Context, req *vidav1.ListUserVideosRequest) (*vidav1.ListVideosResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, invalidArgument(ctx, "无效的用户ID")
+	}
+	page, pageSize := normalizePage(req.GetPage(), req.GetPageSize())
+	data, err := s.videoService.GetUserPublishedVideos(ctx, req.GetUserId(), page, pageSize)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	items := make([]*vidav1.Video, 0, len(data.Videos))
+	for i := range data.Videos {
+		items = append(items, toPBVideo(&data.Videos[i]))
+	}
+	return &vidav1.ListVideosResponse{
+		Videos:     items,
+		Total:      data.Total,
+		Page:       int32(data.Page),
+		PageSize:   int32(data.PageSize),
+		TotalPages: data.TotalPages,
+	}, nil
+}
+
+func toPBVideo(video *dto.VideoInfo) *vidav1.Video {
+	pb := &vidav1.Video{
+		Id:            video.ID,
+		AuthorId:      video.AuthorID,
+		Title:         video.Title,
+		Description:   video.Description,
+		PlayUrl:       video.PlayURL,
+		CoverUrl:      video.CoverURL,
+		Duration:      int32(video.Duration),
+		FileSize:      video.FileSize,
+		FileFormat:    video.FileFormat,
+		Width:         int32(video.Width),
+		Height:        int32(video.Height),
+		Status:        video.Status,
+		ViewCount:     video.ViewCount,
+		FavoriteCount: video.FavoriteCount,
+		CommentCount:  video.CommentCount,
+		PublishTime:   video.PublishTime,
+		CreatedAt:     video.CreatedAt.Unix(),
+		UpdatedAt:     video.UpdatedAt.Unix(),
+	}
+	if video.Author != nil {
+		pb.Author = &vidav1.Author{
+			Id:       video.Author.ID,
+			UserName: video.Author.Username,
+			Avatar:   video.Author.Avatar,
+		}
+	}
+	return pb
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func bitsOf(values ...int) uint64 {
+	var bits uint64
+	for _, v := range values {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  string
+		bounds fieldBounds
+		want   uint64
+	}{
+		{name: "single value", field: "5", bounds: minuteBounds, want: bitsOf(5)},
+		{name: "list", field: "1,15,30", bounds: minuteBounds, want: bitsOf(1, 15, 30)},
+		{name: "range", field: "9-12", bounds: hourBounds, want: bitsOf(9, 10, 11, 12)},
+		{name: "range with step", field: "0-10/5", bounds: minuteBounds, want: bitsOf(0, 5, 10)},
+		{name: "star with step", field: "*/20", bounds: minuteBounds, want: bitsOf(0, 20, 40)},
+		{name: "start with step runs to max", field: "5/15", bounds: minuteBounds, want: bitsOf(5, 20, 35, 50)},
+		{name: "step larger than range", field: "1-3/5", bounds: domBounds, want: bitsOf(1)},
+		{name: "star month", field: "*", bounds: monthBounds, want: bitsOf(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)},
+		{name: "list of ranges", field: "1-2,6-7", bounds: dowBounds, want: bitsOf(1, 2, 6, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseField(tt.field, tt.bounds)
+			if err != nil {
+				t.Fatalf("parseField(%q) error: %v", tt.field, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseField(%q) = %b, want %b", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"10-5 * * * *",
+		"*/0 * * * *",
+		"*/-1 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"1- * * * *",
+		"@every 500ms",
+		"@every soon",
+		"@fortnightly",
+	}
+	for _, spec := range specs {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2026-03-04 是周三
+	from := time.Date(2026, 3, 4, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 4, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 15, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, 3, 5, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		// 日与周都有限定时满足其一即可
+		{"0 0 15 * 5", time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 1h", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"@every 10m", time.Date(2026, 3, 4, 10, 10, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.spec, err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNextNoMatch(t *testing.T) {
+	s, err := Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("Next for Feb 31 = %s, want zero time", got)
+	}
+}
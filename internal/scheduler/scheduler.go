@@ -0,0 +1,294 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"vida-go/internal/infra/metrics"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// 任务执行结果
+const (
+	StatusRunning = "running"
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+const (
+	tickClaimTTL  = time.Hour        // 领取某个执行时间点的标记保留时长，远大于实例间的时钟偏差
+	leaseTTL      = time.Minute      // 执行中租约的有效期，执行期间定期续期，实例崩溃后自动释放
+	leaseRenew    = 20 * time.Second // 租约续期间隔
+	statusTimeout = 5 * time.Second
+)
+
+var ErrDuplicateJob = errors.New("scheduler: job already registered")
+
+// 仅当租约仍属于自己时才删除，避免误删其他实例在租约过期后拿到的租约
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// renewScript 仅当租约仍属于自己时续期
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// JobFunc 任务的一次执行，返回错误时记为失败
+type JobFunc func(ctx context.Context) error
+
+// RunInfo 任务最近一次执行的情况，保存在 Redis 中，任一实例都能查到
+type RunInfo struct {
+	Instance   string     `json:"instance"`
+	Status     string     `json:"status"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// JobStatus 任务的调度配置与最近一次执行情况
+type JobStatus struct {
+	Name      string
+	Spec      string
+	NextRunAt *time.Time
+	Running   bool
+	LastRun   *RunInfo
+}
+
+type job struct {
+	name     string
+	spec     string
+	schedule Schedule
+	fn       JobFunc
+
+	mu      sync.Mutex
+	nextRun time.Time
+}
+
+// Scheduler 按 cron 表达式执行后台任务。每个实例都按相同的表达式计算执行时间，
+// 通过 Redis 领取每个执行时间点，并用执行中租约保证同一任务在集群内不会并发执行；
+// 上一次执行尚未结束时跳过本次
+type Scheduler struct {
+	client   *redis.Client
+	instance string
+
+	mu   sync.RWMutex
+	jobs []*job
+}
+
+func New(client *redis.Client) *Scheduler {
+	host, _ := os.Hostname()
+	return &Scheduler{client: client, instance: fmt.Sprintf("%s-%d", host, os.Getpid())}
+}
+
+func tickKey(name string, at time.Time) string {
+	return fmt.Sprintf("scheduler:tick:%s:%d", name, at.Unix())
+}
+
+func leaseKey(name string) string {
+	return "scheduler:lease:" + name
+}
+
+func lastRunKey(name string) string {
+	return "scheduler:last:" + name
+}
+
+// Register 注册任务，须在 Run 之前调用
+func (s *Scheduler) Register(name, spec string, fn JobFunc) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("scheduler: job %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range s.jobs {
+		if j.name == name {
+			return fmt.Errorf("%w: %s", ErrDuplicateJob, name)
+		}
+	}
+	s.jobs = append(s.jobs, &job{name: name, spec: spec, schedule: schedule, fn: fn})
+	return nil
+}
+
+// Run 启动所有已注册的任务（阻塞，ctx 取消后等待执行中的任务退出）
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.RLock()
+	jobs := s.jobs
+	s.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, j)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	for {
+		next := j.schedule.Next(time.Now())
+		if next.IsZero() {
+			logger.FromContext(ctx).Warn("Scheduled job has no next run", zap.String("job", j.name))
+			return
+		}
+		j.mu.Lock()
+		j.nextRun = next
+		j.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// 同一执行时间点只由最先领取到的实例执行
+		claimed, err := s.client.SetNX(ctx, tickKey(j.name, next), s.instance, tickClaimTTL).Result()
+		if err != nil {
+			logger.FromContext(ctx).Warn("Claim scheduled job failed", zap.String("job", j.name), zap.Error(err))
+			continue
+		}
+		if claimed {
+			s.execute(ctx, j)
+		}
+	}
+}
+
+// execute 持有执行中租约时运行一次任务，并记录结果与指标
+func (s *Scheduler) execute(ctx context.Context, j *job) {
+	acquired, err := s.client.SetNX(ctx, leaseKey(j.name), s.instance, leaseTTL).Result()
+	if err != nil {
+		logger.FromContext(ctx).Warn("Acquire job lease failed", zap.String("job", j.name), zap.Error(err))
+		return
+	}
+	if !acquired {
+		metrics.SchedulerJobRuns.WithLabelValues(j.name, StatusSkipped).Inc()
+		logger.FromContext(ctx).Info("Scheduled job skipped, previous run still in progress", zap.String("job", j.name))
+		return
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.renewLease(runCtx, j.name)
+	defer releaseScript.Run(context.WithoutCancel(ctx), s.client, []string{leaseKey(j.name)}, s.instance)
+
+	run := &RunInfo{Instance: s.instance, Status: StatusRunning, StartedAt: time.Now()}
+	s.saveLastRun(ctx, j.name, run)
+
+	err = s.call(runCtx, j)
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	run.Status = StatusSuccess
+	if err != nil {
+		run.Status = StatusFailed
+		run.Error = err.Error()
+		logger.FromContext(ctx).Error("Scheduled job failed", zap.String("job", j.name), zap.Error(err))
+	} else {
+		metrics.SchedulerJobLastSuccess.WithLabelValues(j.name).Set(float64(finished.Unix()))
+	}
+	metrics.SchedulerJobRuns.WithLabelValues(j.name, run.Status).Inc()
+	metrics.SchedulerJobDuration.WithLabelValues(j.name).Observe(finished.Sub(run.StartedAt).Seconds())
+	s.saveLastRun(ctx, j.name, run)
+}
+
+// call 执行任务函数，panic 记为失败
+func (s *Scheduler) call(ctx context.Context, j *job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return j.fn(ctx)
+}
+
+func (s *Scheduler) renewLease(ctx context.Context, name string) {
+	ticker := time.NewTicker(leaseRenew)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := renewScript.Run(ctx, s.client, []string{leaseKey(name)}, s.instance, leaseTTL.Milliseconds()).Err(); err != nil && ctx.Err() == nil {
+				logger.FromContext(ctx).Warn("Renew job lease failed", zap.String("job", name), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Scheduler) saveLastRun(ctx context.Context, name string, run *RunInfo) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusTimeout)
+	defer cancel()
+
+	data, err := json.Marshal(run)
+	if err == nil {
+		err = s.client.Set(ctx, lastRunKey(name), data, 0).Err()
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn("Save job status failed", zap.String("job", name), zap.Error(err))
+	}
+}
+
+// Status 按注册顺序返回各任务的调度配置与最近一次执行情况
+func (s *Scheduler) Status(ctx context.Context) ([]JobStatus, error) {
+	s.mu.RLock()
+	jobs := s.jobs
+	s.mu.RUnlock()
+	if len(jobs) == 0 {
+		return []JobStatus{}, nil
+	}
+
+	pipe := s.client.Pipeline()
+	lastCmds := make([]*redis.StringCmd, len(jobs))
+	leaseCmds := make([]*redis.IntCmd, len(jobs))
+	for i, j := range jobs {
+		lastCmds[i] = pipe.Get(ctx, lastRunKey(j.name))
+		leaseCmds[i] = pipe.Exists(ctx, leaseKey(j.name))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	statuses := make([]JobStatus, 0, len(jobs))
+	for i, j := range jobs {
+		status := JobStatus{Name: j.name, Spec: j.spec, Running: leaseCmds[i].Val() > 0}
+		j.mu.Lock()
+		if !j.nextRun.IsZero() {
+			next := j.nextRun
+			status.NextRunAt = &next
+		}
+		j.mu.Unlock()
+
+		if data, err := lastCmds[i].Bytes(); err == nil {
+			var run RunInfo
+			if json.Unmarshal(data, &run) == nil {
+				status.LastRun = &run
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAgeRestrictionLocked = errors.New("该视频的年龄限制由审核员设置，无法取消")
+	ErrBirthDateLocked      = errors.New("出生日期设置后不可修改，如有错误请联系管理员")
+	ErrInvalidBirthDate     = errors.New("出生日期无效")
+	ErrAlreadyAgeRestricted = errors.New("视频已设置年龄限制")
+	ErrNotAgeRestricted     = errors.New("视频未设置年龄限制")
+)
+
+const birthDateLayout = "2006-01-02"
+
+// ageGateCode 判断观看者能否观看年龄限制内容，可以观看时返回空字符串，否则返回受限原因
+func ageGateCode(ctx context.Context, userRepo *repository.UserRepository, viewerID int64) (string, error) {
+	if viewerID == 0 {
+		return dto.RestrictedCodeLoginRequired, nil
+	}
+	user, err := userRepo.GetByID(ctx, viewerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.RestrictedCodeLoginRequired, nil
+		}
+		return "", err
+	}
+	age := user.AgeAt(time.Now())
+	switch {
+	case age < 0:
+		return dto.RestrictedCodeBirthDateRequired, nil
+	case age < config.GetAgeGate().MinimumAge():
+		return dto.RestrictedCodeUnderage, nil
+	}
+	return "", nil
+}
+
+func restrictedPlaceholder(code string) *dto.RestrictedPlaceholder {
+	minAge := config.GetAgeGate().MinimumAge()
+	var msg string
+	switch code {
+	case dto.RestrictedCodeLoginRequired:
+		msg = fmt.Sprintf("该视频仅限 %d 岁及以上用户观看，请登录后查看", minAge)
+	case dto.RestrictedCodeBirthDateRequired:
+		msg = fmt.Sprintf("该视频仅限 %d 岁及以上用户观看，请先在个人资料中填写出生日期", minAge)
+	default:
+		msg = fmt.Sprintf("该视频仅限 %d 岁及以上用户观看", minAge)
+	}
+	return &dto.RestrictedPlaceholder{Reason: "age_restricted", Code: code, MinAge: minAge, Message: msg}
+}
+
+// lazyAgeGate 列表中出现他人的年龄限制视频时才查询观看者信息，且只查询一次
+type lazyAgeGate struct {
+	userRepo *repository.UserRepository
+	viewerID int64
+	checked  bool
+	code     string
+}
+
+func (g *lazyAgeGate) restricted(ctx context.Context, authorID int64) (*dto.RestrictedPlaceholder, error) {
+	if g.viewerID != 0 && authorID == g.viewerID {
+		return nil, nil
+	}
+	if !g.checked {
+		code, err := ageGateCode(ctx, g.userRepo, g.viewerID)
+		if err != nil {
+			return nil, err
+		}
+		g.code, g.checked = code, true
+	}
+	if g.code == "" {
+		return nil, nil
+	}
+	return restrictedPlaceholder(g.code), nil
+}
+
+// parseBirthDate 解析出生日期，不能晚于今天
+func parseBirthDate(s string) (time.Time, error) {
+	t, err := time.Parse(birthDateLayout, s)
+	if err != nil || t.After(time.Now()) {
+		return time.Time{}, ErrInvalidBirthDate
+	}
+	return t, nil
+}
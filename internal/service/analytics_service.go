@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+var ErrAnalyticsIdentityRequired = errors.New("未登录时需提供设备ID")
+
+// 客户端时间的可接受范围：离线缓存的事件最多补报 24 小时，时钟超前最多 5 分钟
+const (
+	analyticsMaxEventAge   = 24 * time.Hour
+	analyticsMaxClockAhead = 5 * time.Minute
+)
+
+// 观众留存：按播放进度 10% 为一档，0 档表示开始播放，10 档表示完播
+const (
+	retentionBuckets  = 10
+	retentionReachTTL = 6 * time.Hour // 同一会话的最远进度记录保留时长
+)
+
+// retentionScript 仅在会话的最远进度推进时，为新跨过的每一档计数 +1，保证每个会话每档只计一次
+var retentionScript = redis.NewScript(`
+local old = tonumber(redis.call('GET', KEYS[1]) or '-1')
+local new = tonumber(ARGV[1])
+if new <= old then return 0 end
+redis.call('SET', KEYS[1], new, 'EX', ARGV[2])
+for b = old + 1, new do redis.call('HINCRBY', KEYS[2], tostring(b), 1) end
+return new - old
+`)
+
+// AnalyticsService 客户端埋点：接入时校验后转发到 Kafka analytics topic，消费端聚合留存与观看时长
+type AnalyticsService struct {
+	videoRepo        *repository.VideoRepository
+	statRepo         *repository.VideoStatRepository
+	watchHistoryRepo *repository.WatchHistoryRepository
+	client           *redis.Client
+}
+
+func NewAnalyticsService(
+	videoRepo *repository.VideoRepository,
+	statRepo *repository.VideoStatRepository,
+	watchHistoryRepo *repository.WatchHistoryRepository,
+	client *redis.Client,
+) *AnalyticsService {
+	return &AnalyticsService{videoRepo: videoRepo, statRepo: statRepo, watchHistoryRepo: watchHistoryRepo, client: client}
+}
+
+// 视频 ID 作为 hash tag，保证同一视频的 key 落在同一个 slot，便于脚本原子更新
+func retentionKey(videoID int64) string {
+	return fmt.Sprintf("analytics:retention:{%d}", videoID)
+}
+
+func retentionReachKey(videoID int64, viewer string) string {
+	return fmt.Sprintf("analytics:reach:{%d}:%s", videoID, viewer)
+}
+
+// Ingest 接收一批埋点事件，userID 为 0 表示未登录（此时需要设备ID）
+func (s *AnalyticsService) Ingest(ctx context.Context, userID int64, req *dto.AnalyticsEventBatchRequest) (*dto.AnalyticsEventBatchResult, error) {
+	if userID == 0 && req.DeviceID == "" {
+		return nil, ErrAnalyticsIdentityRequired
+	}
+
+	now := time.Now()
+	events := make([]*infraKafka.AnalyticsEvent, 0, len(req.Events))
+	for _, e := range req.Events {
+		clientTime := time.UnixMilli(e.ClientTime)
+		if clientTime.Before(now.Add(-analyticsMaxEventAge)) || clientTime.After(now.Add(analyticsMaxClockAhead)) {
+			continue
+		}
+		events = append(events, &infraKafka.AnalyticsEvent{
+			Type:        e.Type,
+			VideoID:     e.VideoID,
+			UserID:      userID,
+			DeviceID:    req.DeviceID,
+			SessionID:   e.SessionID,
+			Source:      e.Source,
+			PositionMs:  e.PositionMs,
+			WatchTimeMs: e.WatchTimeMs,
+			ClientTime:  clientTime,
+			ReceivedAt:  now,
+		})
+	}
+	result := &dto.AnalyticsEventBatchResult{
+		Accepted: len(events),
+		Dropped:  len(req.Events) - len(events),
+	}
+
+	topic := config.GetKafka().Topics["analytics"]
+	if topic == "" {
+		logger.FromContext(ctx).Debug("Analytics topic not configured, events dropped", zap.Int("count", len(events)))
+		return result, nil
+	}
+	if err := infraKafka.SendAnalyticsEvents(ctx, topic, events); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// HandleEvent 消费埋点事件：更新观众留存曲线，并累计播放、完播次数与观看时长
+// 播放与完播按会话去重：会话首次出现记一次播放，首次到达 100% 记一次完播
+func (s *AnalyticsService) HandleEvent(ctx context.Context, event *infraKafka.AnalyticsEvent) error {
+	bucket, ok := s.retentionBucket(ctx, event)
+	if !ok {
+		return nil
+	}
+
+	started := event.Type == dto.AnalyticsEventPlay
+	completed := event.Type == dto.AnalyticsEventComplete
+	if viewer := analyticsViewer(event); viewer != "" && s.client != nil {
+		keys := []string{retentionReachKey(event.VideoID, viewer), retentionKey(event.VideoID)}
+		advanced, err := retentionScript.Run(ctx, s.client, keys, bucket, int(retentionReachTTL.Seconds())).Int()
+		if err != nil {
+			return err
+		}
+		started = advanced == bucket+1
+		completed = bucket == retentionBuckets && advanced > 0
+	}
+
+	var plays, completions int64
+	if started {
+		plays = 1
+		// 登录用户的观看记录用于协同过滤推荐
+		if event.UserID > 0 {
+			if err := s.watchHistoryRepo.Touch(ctx, event.UserID, event.VideoID, event.ClientTime); err != nil {
+				logger.FromContext(ctx).Warn("Record watch history failed", zap.Int64("video_id", event.VideoID), zap.Error(err))
+			}
+		}
+	}
+	if completed {
+		completions = 1
+	}
+	if plays == 0 && completions == 0 && event.WatchTimeMs == 0 {
+		return nil
+	}
+
+	if err := s.statRepo.AddWatch(ctx, event.VideoID, plays, completions, event.WatchTimeMs); err != nil {
+		return err
+	}
+	return s.videoRepo.AddWatchMetrics(ctx, event.VideoID, plays, completions, event.WatchTimeMs)
+}
+
+// retentionBucket 事件对应的播放进度档位，曝光等与播放进度无关的事件返回 false
+func (s *AnalyticsService) retentionBucket(ctx context.Context, event *infraKafka.AnalyticsEvent) (int, bool) {
+	switch event.Type {
+	case dto.AnalyticsEventPlay:
+		return 0, true
+	case dto.AnalyticsEventComplete:
+		return retentionBuckets, true
+	case dto.AnalyticsEventPause:
+		video, err := s.videoRepo.GetByID(ctx, event.VideoID)
+		if err != nil || video.Duration <= 0 {
+			return 0, false
+		}
+		bucket := int(event.PositionMs * retentionBuckets / (int64(video.Duration) * 1000))
+		return min(bucket, retentionBuckets), true
+	}
+	return 0, false
+}
+
+// analyticsViewer 识别同一次观看：优先会话ID，其次用户或设备
+func analyticsViewer(event *infraKafka.AnalyticsEvent) string {
+	switch {
+	case event.SessionID != "":
+		return "s:" + event.SessionID
+	case event.UserID > 0:
+		return "u:" + strconv.FormatInt(event.UserID, 10)
+	case event.DeviceID != "":
+		return "d:" + event.DeviceID
+	}
+	return ""
+}
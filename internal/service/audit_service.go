@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// 审计操作类型
+const (
+	AuditActionUserDelete       = "user.delete"
+	AuditActionUserRestore      = "user.restore"
+	AuditActionUserSetAdmin     = "user.set_admin"
+	AuditActionUserUpdate       = "user.update"
+	AuditActionVideoDelete      = "video.delete"
+	AuditActionCommentDelete    = "comment.delete"
+	AuditActionUserSuspend      = "user.suspend"
+	AuditActionUserUnsuspend    = "user.unsuspend"
+	AuditActionUserMute         = "user.mute"
+	AuditActionUserUnmute       = "user.unmute"
+	AuditActionUserSetRole      = "user.set_role"
+	AuditActionVideoHide        = "video.hide"
+	AuditActionVideoUnhide      = "video.unhide"
+	AuditActionCommentHide      = "comment.hide"
+	AuditActionCommentUnhide    = "comment.unhide"
+	AuditActionDuplicateDismiss = "duplicate.dismiss"
+	AuditActionDuplicateHide    = "duplicate.hide"
+	AuditActionRelationImport   = "relation.import"
+	AuditActionFavoriteImport   = "favorite.import"
+	AuditActionReportResolve    = "report.resolve"
+	AuditActionReportDismiss    = "report.dismiss"
+	AuditActionVideoSetRegions  = "video.set_regions"
+	AuditActionVideoLegalHold   = "video.legal_hold"
+	AuditActionVideoHoldRelease = "video.legal_release"
+	AuditActionUserLegalHold    = "user.legal_hold"
+	AuditActionUserHoldRelease  = "user.legal_release"
+	AuditActionVideoAgeRestrict = "video.age_restrict"
+	AuditActionVideoAgeLift     = "video.age_unrestrict"
+	AuditActionInviteCreate     = "invite.create"
+	AuditActionInviteRevoke     = "invite.revoke"
+	AuditActionImageApprove     = "profile_image.approve"
+	AuditActionImageReject      = "profile_image.reject"
+	AuditActionSlotCreate       = "explore_slot.create"
+	AuditActionSlotDelete       = "explore_slot.delete"
+	AuditActionWalletAdjust     = "wallet.adjust"
+	AuditActionUserImpersonate  = "user.impersonate"
+	AuditActionImpersonatedCall = "user.impersonated_request" // 模拟登录期间的每个请求
+)
+
+// 审计目标类型
+const (
+	AuditTargetUser           = "user"
+	AuditTargetVideo          = "video"
+	AuditTargetComment        = "comment"
+	AuditTargetVideoDuplicate = "video_duplicate"
+	AuditTargetRelation       = "relation"
+	AuditTargetFavorite       = "favorite"
+	AuditTargetReport         = "report"
+	AuditTargetInviteCode     = "invite_code"
+	AuditTargetProfileImage   = "profile_image"
+	AuditTargetExploreSlot    = "explore_slot"
+)
+
+// AuditEntry 一条待记录的审计事件
+type AuditEntry struct {
+	ActorID    int64
+	ActorRole  string
+	Action     string
+	TargetType string
+	TargetID   int64
+	Reason     string
+	IP         string
+}
+
+type AuditService struct {
+	auditRepo *repository.AuditLogRepository
+}
+
+func NewAuditService(auditRepo *repository.AuditLogRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// Record 记录审计事件
+// 操作本身已完成，写入失败只记录错误日志，不影响业务结果
+func (s *AuditService) Record(ctx context.Context, entry *AuditEntry) {
+	log := &model.AuditLog{
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Reason:     entry.Reason,
+		IP:         entry.IP,
+	}
+	if err := s.auditRepo.Create(context.WithoutCancel(ctx), log); err != nil {
+		logger.FromContext(ctx).Error("Record audit log failed",
+			zap.String("action", entry.Action),
+			zap.Int64("actor_id", entry.ActorID),
+			zap.Int64("target_id", entry.TargetID),
+			zap.Error(err),
+		)
+	}
+}
+
+// List 分页查询审计日志（管理员）
+func (s *AuditService) List(ctx context.Context, query *dto.AuditLogQuery, page, pageSize int) (*dto.PaginatedData, error) {
+	filter := &repository.AuditLogFilter{
+		ActorID:    query.ActorID,
+		Action:     query.Action,
+		TargetType: query.TargetType,
+		TargetID:   query.TargetID,
+	}
+	if query.StartTime != nil {
+		t := time.Unix(*query.StartTime, 0)
+		filter.StartTime = &t
+	}
+	if query.EndTime != nil {
+		t := time.Unix(*query.EndTime, 0)
+		filter.EndTime = &t
+	}
+
+	skip := (page - 1) * pageSize
+	logs, total, err := s.auditRepo.List(ctx, filter, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.AuditLogInfo, 0, len(logs))
+	for i := range logs {
+		items = append(items, toAuditLogInfo(&logs[i]))
+	}
+
+	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
+
+	return &dto.PaginatedData{
+		Items: items,
+		Meta: dto.PaginationMeta{
+			Page:       page,
+			PageSize:   pageSize,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func toAuditLogInfo(l *model.AuditLog) dto.AuditLogInfo {
+	return dto.AuditLogInfo{
+		ID:         l.ID,
+		ActorID:    l.ActorID,
+		ActorRole:  l.ActorRole,
+		Action:     l.Action,
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID,
+		Reason:     l.Reason,
+		IP:         l.IP,
+		CreatedAt:  l.CreatedAt,
+	}
+}
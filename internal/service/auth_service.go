@@ -1,14 +1,18 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"vida-go/internal/api/dto"
 	"vida-go/internal/config"
 	"vida-go/internal/model"
+	"vida-go/internal/rbac"
 	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
 	"vida-go/pkg/utils"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -17,19 +21,47 @@ var (
 	ErrUsernameExists    = errors.New("用户名已存在")
 	ErrInvalidCredential = errors.New("用户名或密码错误")
 	ErrUserDeleted       = errors.New("该用户已被删除")
+	ErrUserNoPermission  = errors.New("没有权限修改该用户信息")
+
+	ErrPasswordResetRequired = errors.New("账号存在非本人登录，请在已登录的设备上修改密码后再登录")
+	ErrWrongPassword         = errors.New("原密码错误")
+	ErrCannotImpersonate     = errors.New("不能模拟登录自己或管理员、审核员账号")
 )
 
 type AuthService struct {
-	userRepo *repository.UserRepository
+	userRepo            *repository.UserRepository
+	loginHistoryService *LoginHistoryService
+	inviteService       *InviteService
+	profileImageService *ProfileImageService
+	txManager           *repository.TxManager
 }
 
-func NewAuthService(userRepo *repository.UserRepository) *AuthService {
-	return &AuthService{userRepo: userRepo}
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	loginHistoryService *LoginHistoryService,
+	inviteService *InviteService,
+	profileImageService *ProfileImageService,
+	txManager *repository.TxManager,
+) *AuthService {
+	return &AuthService{
+		userRepo:            userRepo,
+		loginHistoryService: loginHistoryService,
+		inviteService:       inviteService,
+		profileImageService: profileImageService,
+		txManager:           txManager,
+	}
 }
 
-// Register 用户注册
-func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.UserInfo, error) {
-	exists, err := s.userRepo.ExistsByUsername(req.Username)
+// Register 用户注册。开启邀请注册时必须提供有效邀请码；未开启时填写的邀请码同样会校验并记录邀请关系
+func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.UserInfo, error) {
+	if req.InviteCode == "" && config.GetRegistration().InviteOnly {
+		return nil, ErrInviteCodeRequired
+	}
+	if err := validateUsername(req.Username, false); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userRepo.ExistsByUsername(ctx, req.Username)
 	if err != nil {
 		return nil, err
 	}
@@ -48,23 +80,54 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.UserInfo, error)
 	}
 
 	user := &model.User{
-		UserName:        req.Username,
-		Password:        hashedPassword,
-		Avatar:          req.Avatar,
-		BackgroundImage: req.BackgroundImage,
-		UserRole:        role,
+		UserName: req.Username,
+		Password: hashedPassword,
+		UserRole: role,
+	}
+	// 开启图片审核时，注册填写的头像、背景图与修改资料一样先进入待审核状态
+	reviewImages := make(map[string]string)
+	for kind, url := range map[string]*string{model.ProfileImageAvatar: req.Avatar, model.ProfileImageBackground: req.BackgroundImage} {
+		if url == nil {
+			continue
+		}
+		if config.GetAgent().ModerateProfileImages && *url != "" {
+			reviewImages[kind] = *url
+			continue
+		}
+		if kind == model.ProfileImageAvatar {
+			user.Avatar = url
+		} else {
+			user.BackgroundImage = url
+		}
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if req.InviteCode != "" {
+			invite, err := s.inviteService.redeem(ctx, req.InviteCode)
+			if err != nil {
+				return err
+			}
+			user.InviteCodeID = &invite.ID
+			user.InvitedByID = &invite.InviterID
+		}
+		return s.userRepo.Create(ctx, user)
+	})
+	if err != nil {
 		return nil, err
 	}
+	syncUserToES(ctx, user)
 
+	for kind, url := range reviewImages {
+		if err := s.profileImageService.Submit(ctx, user, kind, url); err != nil {
+			return nil, err
+		}
+	}
 	return toUserInfo(user), nil
 }
 
 // Login 用户登录，返回 token 数据
-func (s *AuthService) Login(req *dto.LoginRequest) (*dto.TokenData, error) {
-	user, err := s.userRepo.GetByUsername(req.Username)
+func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.TokenData, error) {
+	user, err := s.userRepo.GetByUsernameIncludeDeleted(ctx, req.Username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrInvalidCredential
@@ -72,7 +135,7 @@ func (s *AuthService) Login(req *dto.LoginRequest) (*dto.TokenData, error) {
 		return nil, err
 	}
 
-	if user.IsDelete != 0 {
+	if user.DeletedAt.Valid {
 		return nil, ErrUserDeleted
 	}
 
@@ -80,11 +143,22 @@ func (s *AuthService) Login(req *dto.LoginRequest) (*dto.TokenData, error) {
 		return nil, ErrInvalidCredential
 	}
 
+	if err := checkSuspended(user); err != nil {
+		return nil, err
+	}
+	if user.PasswordResetRequired {
+		return nil, ErrPasswordResetRequired
+	}
+
 	token, err := utils.GenerateToken(user.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.loginHistoryService.Record(ctx, user.ID, &req.Client); err != nil {
+		logger.FromContext(ctx).Warn("Record login event failed", zap.Int64("user_id", user.ID), zap.Error(err))
+	}
+
 	expireSeconds := int(config.GetJWT().ExpireHours) * 3600
 
 	return &dto.TokenData{
@@ -95,9 +169,64 @@ func (s *AuthService) Login(req *dto.LoginRequest) (*dto.TokenData, error) {
 	}, nil
 }
 
+// ChangePassword 修改密码，同时解除因非本人登录导致的强制改密状态
+func (s *AuthService) ChangePassword(ctx context.Context, userID int64, req *dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if !utils.VerifyPassword(req.OldPassword, user.Password) {
+		return ErrWrongPassword
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+	_, err = s.userRepo.Update(ctx, userID, map[string]interface{}{
+		"password":                hashedPassword,
+		"password_reset_required": false,
+	})
+	return err
+}
+
+// Impersonate 为管理员签发以目标用户身份访问的短期令牌，用于排查账号问题。
+// 不能模拟自己或其他有管理权限的账号
+func (s *AuthService) Impersonate(ctx context.Context, adminID, userID int64) (*dto.TokenData, error) {
+	if adminID == userID {
+		return nil, ErrCannotImpersonate
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.UserRole != rbac.RoleUser {
+		return nil, ErrCannotImpersonate
+	}
+
+	ttl := config.GetJWT().ImpersonationDuration()
+	token, err := utils.GenerateImpersonationToken(user.ID, adminID, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TokenData{
+		Token:     token,
+		TokenType: "bearer",
+		ExpiresIn: int(ttl.Seconds()),
+		User:      *toUserInfo(user),
+	}, nil
+}
+
 // GetCurrentUser 根据用户 ID 获取用户信息
-func (s *AuthService) GetCurrentUser(userID int64) (*dto.UserInfo, error) {
-	user, err := s.userRepo.GetByID(userID)
+func (s *AuthService) GetCurrentUser(ctx context.Context, userID int64) (*dto.UserInfo, error) {
+	user, err := s.userRepo.GetByIDIncludeDeleted(ctx, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
@@ -105,7 +234,7 @@ func (s *AuthService) GetCurrentUser(userID int64) (*dto.UserInfo, error) {
 		return nil, err
 	}
 
-	if user.IsDelete != 0 {
+	if user.DeletedAt.Valid {
 		return nil, ErrUserDeleted
 	}
 
@@ -113,7 +242,7 @@ func (s *AuthService) GetCurrentUser(userID int64) (*dto.UserInfo, error) {
 }
 
 func toUserInfo(user *model.User) *dto.UserInfo {
-	return &dto.UserInfo{
+	info := &dto.UserInfo{
 		ID:              user.ID,
 		Username:        user.UserName,
 		Avatar:          user.Avatar,
@@ -122,5 +251,19 @@ func toUserInfo(user *model.User) *dto.UserInfo {
 		FollowCount:     user.FollowCount,
 		FollowerCount:   user.FollowerCount,
 		TotalFavorited:  user.TotalFavorited,
+		FavoriteCount:   user.FavoriteCount,
+	}
+	if user.BirthDate != nil {
+		birthDate := user.BirthDate.Format(birthDateLayout)
+		info.BirthDate = &birthDate
+	}
+	if restrictionActive(user.SuspendedUntil) {
+		info.SuspendedUntil = user.SuspendedUntil
+		info.SuspendReason = user.SuspendReason
+	}
+	if restrictionActive(user.MutedUntil) {
+		info.MutedUntil = user.MutedUntil
+		info.MuteReason = user.MuteReason
 	}
+	return info
 }
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"vida-go/internal/config"
+	infraMinio "vida-go/internal/infra/minio"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// storageTransitionTimeout 迁移（归档或恢复）停留超过该时长视为中断，由归档任务或下一次播放请求接管
+const storageTransitionTimeout = 30 * time.Minute
+
+// ErrStreamPreparing 视频在冷存储中，已触发恢复
+var ErrStreamPreparing = errors.New("视频正在从归档存储中恢复，请稍后重试")
+
+// ColdStorageService 冷存储归档：长期无人观看的视频播放文件（默认文件与各清晰度）移入冷存储 Bucket，
+// 封面与预览帧保留在原处；有人请求播放时按需恢复。对象在两个 Bucket 中同名，恢复后地址不变
+type ColdStorageService struct {
+	videoRepo *repository.VideoRepository
+}
+
+func NewColdStorageService(videoRepo *repository.VideoRepository) *ColdStorageService {
+	return &ColdStorageService{videoRepo: videoRepo}
+}
+
+// ColdStorageResult 一轮归档任务的结果
+type ColdStorageResult struct {
+	Archived int
+	Resumed  int
+	Failed   int
+}
+
+// RunArchive 定时任务：确保冷存储 Bucket 存在后归档一轮
+func (s *ColdStorageService) RunArchive(ctx context.Context, cfg *config.ColdStorageConfig) error {
+	if err := infraMinio.EnsureBucket(ctx, cfg.ColdBucket()); err != nil {
+		return fmt.Errorf("prepare cold storage bucket: %w", err)
+	}
+
+	start := time.Now()
+	result, err := s.Archive(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if result.Archived > 0 || result.Resumed > 0 || result.Failed > 0 {
+		logger.FromContext(ctx).Info("Cold storage archive finished",
+			zap.Int("archived", result.Archived),
+			zap.Int("resumed", result.Resumed),
+			zap.Int("failed", result.Failed),
+			zap.Duration("duration", time.Since(start)))
+	}
+	return nil
+}
+
+// Archive 先接管中断的迁移，再归档一批超过 UnwatchedDays 天无人观看的视频。
+// 单个视频失败时停留在 archiving，超时后由下一轮续做
+func (s *ColdStorageService) Archive(ctx context.Context, cfg *config.ColdStorageConfig) (*ColdStorageResult, error) {
+	result := &ColdStorageResult{}
+
+	staleBefore := time.Now().Add(-storageTransitionTimeout)
+	stale, err := s.videoRepo.ListStaleStorageTransitions(ctx, staleBefore, cfg.Batch())
+	if err != nil {
+		return nil, err
+	}
+	for i := range stale {
+		video := &stale[i]
+		claimed, err := s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, video.StorageTier, video.StorageTier, &staleBefore)
+		if err != nil {
+			return result, err
+		}
+		if !claimed {
+			continue
+		}
+		if video.StorageTier == model.StorageTierRehydrating {
+			err = s.rehydrate(ctx, video)
+		} else {
+			err = s.archive(ctx, video, cfg)
+		}
+		if err != nil {
+			result.Failed++
+			logger.FromContext(ctx).Warn("Resume storage transition failed",
+				zap.Int64("video_id", video.ID), zap.String("tier", video.StorageTier), zap.Error(err))
+			continue
+		}
+		result.Resumed++
+	}
+
+	cutoff := time.Now().Add(-cfg.UnwatchedFor())
+	candidates, err := s.videoRepo.ListArchiveCandidates(ctx, cutoff, cfg.Batch())
+	if err != nil {
+		return result, err
+	}
+	for i := range candidates {
+		video := &candidates[i]
+		if _, _, ok := videoObjectPrefix(video); !ok {
+			continue
+		}
+		claimed, err := s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, model.StorageTierHot, model.StorageTierArchiving, &cutoff)
+		if err != nil {
+			return result, err
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.archive(ctx, video, cfg); err != nil {
+			result.Failed++
+			logger.FromContext(ctx).Warn("Archive video failed", zap.Int64("video_id", video.ID), zap.Error(err))
+			continue
+		}
+		result.Archived++
+	}
+	return result, nil
+}
+
+// RequestRehydrate 视频在冷存储（或迁移中断）时触发后台恢复，返回是否仍需等待恢复完成。
+// 同一视频只有一个请求能抢到恢复，其余请求直接返回 true
+func (s *ColdStorageService) RequestRehydrate(ctx context.Context, video *model.Video) bool {
+	if video.PlaybackState() == model.PlaybackStateReady {
+		return false
+	}
+
+	var claimed bool
+	var err error
+	if video.StorageTier == model.StorageTierCold {
+		claimed, err = s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, model.StorageTierCold, model.StorageTierRehydrating, nil)
+	} else {
+		staleBefore := time.Now().Add(-storageTransitionTimeout)
+		claimed, err = s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, video.StorageTier, model.StorageTierRehydrating, &staleBefore)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Request video rehydration failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		return true
+	}
+	if claimed {
+		go func(video model.Video) {
+			bgCtx := context.WithoutCancel(ctx)
+			if err := s.rehydrate(bgCtx, &video); err != nil {
+				logger.FromContext(bgCtx).Warn("Rehydrate video failed", zap.Int64("video_id", video.ID), zap.Error(err))
+			}
+		}(*video)
+	}
+	return true
+}
+
+// archive 将播放文件移入冷存储后标记为 cold
+func (s *ColdStorageService) archive(ctx context.Context, video *model.Video, cfg *config.ColdStorageConfig) error {
+	bucket, prefix, ok := videoObjectPrefix(video)
+	if !ok {
+		_, err := s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, model.StorageTierArchiving, model.StorageTierHot, nil)
+		return err
+	}
+	objects, err := infraMinio.ListObjects(ctx, bucket, prefix)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if !isPlaybackObject(prefix, object) {
+			continue
+		}
+		if err := infraMinio.MoveObject(ctx, bucket, cfg.ColdBucket(), object, cfg.StorageClass); err != nil && !errors.Is(err, infraMinio.ErrObjectNotFound) {
+			return fmt.Errorf("move %s: %w", object, err)
+		}
+	}
+	if _, err := s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, model.StorageTierArchiving, model.StorageTierCold, nil); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Video archived to cold storage", zap.Int64("video_id", video.ID))
+	return nil
+}
+
+// rehydrate 将冷存储中的播放文件移回原 Bucket 后标记为 hot
+func (s *ColdStorageService) rehydrate(ctx context.Context, video *model.Video) error {
+	bucket, prefix, ok := videoObjectPrefix(video)
+	if !ok {
+		_, err := s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, model.StorageTierRehydrating, model.StorageTierHot, nil)
+		return err
+	}
+	coldBucket := config.GetColdStorage().ColdBucket()
+	objects, err := infraMinio.ListObjects(ctx, coldBucket, prefix)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if err := infraMinio.MoveObject(ctx, coldBucket, bucket, object, "STANDARD"); err != nil && !errors.Is(err, infraMinio.ErrObjectNotFound) {
+			return fmt.Errorf("move %s: %w", object, err)
+		}
+	}
+	if _, err := s.videoRepo.CompareAndSetStorageTier(ctx, video.ID, model.StorageTierRehydrating, model.StorageTierHot, nil); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Video rehydrated from cold storage", zap.Int64("video_id", video.ID))
+	return nil
+}
+
+// videoObjectPrefix 由播放地址得到视频文件所在的 Bucket 与目录；只处理转码产出的 videos/{id}/ 目录，
+// 其他来源的播放地址不归档
+func videoObjectPrefix(video *model.Video) (bucket, prefix string, ok bool) {
+	bucket, object, ok := infraMinio.ParsePublicURL(video.PlayURL)
+	prefix = fmt.Sprintf("videos/%d/", video.ID)
+	if !ok || path.Dir(object)+"/" != prefix {
+		return "", "", false
+	}
+	return bucket, prefix, true
+}
+
+// isPlaybackObject 封面与预览帧在列表页、进度条中展示，不归档
+func isPlaybackObject(prefix, object string) bool {
+	name := strings.TrimPrefix(object, prefix)
+	return name != "cover.jpg" && !strings.HasPrefix(name, "frames/")
+}
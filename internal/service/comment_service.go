@@ -1,42 +1,92 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"vida-go/internal/api/dto"
+	"vida-go/internal/eventbus"
 	"vida-go/internal/model"
 	"vida-go/internal/repository"
+	"vida-go/pkg/cursor"
+	"vida-go/pkg/logger"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 var (
-	ErrCommentNotFound    = errors.New("评论不存在")
+	ErrCommentNotFound     = errors.New("评论不存在")
 	ErrCommentNoPermission = errors.New("没有权限操作该评论")
-	ErrParentNotFound     = errors.New("父评论不存在")
+	ErrParentNotFound      = errors.New("父评论不存在")
 	ErrParentVideoMismatch = errors.New("父评论不属于该视频")
 )
 
 type CommentService struct {
-	commentRepo *repository.CommentRepository
-	videoRepo   *repository.VideoRepository
+	commentRepo    *repository.CommentRepository
+	videoRepo      *repository.VideoRepository
+	userRepo       *repository.UserRepository
+	statRepo       *repository.VideoStatRepository
+	membershipRepo *repository.MembershipRepository
+	accessRepo     *repository.VideoAccessRepository
+	eventBus       *eventbus.Bus
+	txManager      *repository.TxManager
 }
 
-func NewCommentService(commentRepo *repository.CommentRepository, videoRepo *repository.VideoRepository) *CommentService {
-	return &CommentService{commentRepo: commentRepo, videoRepo: videoRepo}
+func NewCommentService(commentRepo *repository.CommentRepository, videoRepo *repository.VideoRepository, userRepo *repository.UserRepository, statRepo *repository.VideoStatRepository, membershipRepo *repository.MembershipRepository, accessRepo *repository.VideoAccessRepository, eventBus *eventbus.Bus, txManager *repository.TxManager) *CommentService {
+	return &CommentService{commentRepo: commentRepo, videoRepo: videoRepo, userRepo: userRepo, statRepo: statRepo, membershipRepo: membershipRepo, accessRepo: accessRepo, eventBus: eventBus, txManager: txManager}
 }
 
-// Create 发表评论
-func (s *CommentService) Create(userID, videoID int64, req *dto.CommentCreateRequest) (*dto.CommentInfo, error) {
-	if _, err := s.videoRepo.GetByID(videoID); err != nil {
+// viewableVideo 获取观看者可见的视频，私密视频对作者及被授权用户以外的人返回 ErrVideoNotFound
+func (s *CommentService) viewableVideo(ctx context.Context, videoID, viewerID int64) (*model.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrVideoNotFound
 		}
 		return nil, err
 	}
+	if ok, err := canViewVideo(ctx, s.accessRepo, video, viewerID); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrVideoNotFound
+	}
+	return video, nil
+}
+
+// viewableComment 获取评论，所属视频对观看者不可见时返回 ErrCommentNotFound
+func (s *CommentService) viewableComment(ctx context.Context, commentID, viewerID int64) (*model.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.viewableVideo(ctx, comment.VideoID, viewerID); err != nil {
+		if errors.Is(err, ErrVideoNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// Create 发表评论
+func (s *CommentService) Create(ctx context.Context, userID, videoID int64, req *dto.CommentCreateRequest) (*dto.CommentInfo, error) {
+	if err := ensureNotMuted(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	video, err := s.viewableVideo(ctx, videoID, userID)
+	if err != nil {
+		return nil, err
+	}
 
+	var parent *model.Comment
 	if req.ParentID != nil {
-		parent, err := s.commentRepo.GetByID(*req.ParentID)
+		parent, err = s.commentRepo.GetByID(ctx, *req.ParentID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, ErrParentNotFound
@@ -55,25 +105,49 @@ func (s *CommentService) Create(userID, videoID int64, req *dto.CommentCreateReq
 		ParentID: req.ParentID,
 	}
 
-	if err := s.commentRepo.Create(comment); err != nil {
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.commentRepo.Create(ctx, comment); err != nil {
+			return err
+		}
+		if err := s.videoRepo.IncrementCommentCount(ctx, videoID); err != nil {
+			return err
+		}
+		return s.statRepo.AddComments(ctx, videoID, 1)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	_ = s.videoRepo.IncrementCommentCount(videoID)
+	event := &eventbus.CommentCreated{
+		CommentID:     comment.ID,
+		VideoID:       videoID,
+		VideoAuthorID: video.AuthorID,
+		UserID:        userID,
+		ParentID:      comment.ParentID,
+		Content:       comment.Content,
+		CreatedAt:     comment.CreatedAt,
+	}
+	if parent != nil {
+		event.ParentUserID = parent.UserID
+	}
+	s.eventBus.Publish(ctx, event)
 
 	return toCommentInfo(comment, 0), nil
 }
 
 // Update 更新评论
-func (s *CommentService) Update(commentID, userID int64, req *dto.CommentUpdateRequest) (*dto.CommentInfo, error) {
-	if err := s.commentRepo.Update(commentID, userID, req.Content); err != nil {
+func (s *CommentService) Update(ctx context.Context, commentID, userID int64, req *dto.CommentUpdateRequest) (*dto.CommentInfo, error) {
+	if err := checkUserLegalHold(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if err := s.commentRepo.Update(ctx, commentID, userID, req.Content); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrCommentNoPermission
 		}
 		return nil, err
 	}
 
-	comment, err := s.commentRepo.GetByID(commentID)
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +156,8 @@ func (s *CommentService) Update(commentID, userID int64, req *dto.CommentUpdateR
 }
 
 // Delete 删除评论
-func (s *CommentService) Delete(commentID, userID int64) (int64, error) {
-	comment, err := s.commentRepo.GetByID(commentID)
+func (s *CommentService) Delete(ctx context.Context, commentID, userID int64) (int64, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return 0, ErrCommentNotFound
@@ -93,70 +167,155 @@ func (s *CommentService) Delete(commentID, userID int64) (int64, error) {
 
 	videoID := comment.VideoID
 
-	deleted, err := s.commentRepo.Delete(commentID, userID)
-	if err != nil {
+	// 评论者或所在视频处于法律保全中时评论也需保留
+	if err := checkUserLegalHold(ctx, s.userRepo, comment.UserID); err != nil {
 		return 0, err
 	}
-	if !deleted {
-		return 0, ErrCommentNoPermission
+	if video, err := s.videoRepo.GetByID(ctx, videoID); err == nil && video.LegalHoldAt != nil {
+		return 0, ErrLegalHold
 	}
 
-	_ = s.videoRepo.DecrementCommentCount(videoID)
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		deleted, err := s.commentRepo.Delete(ctx, commentID, userID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return ErrCommentNoPermission
+		}
+
+		// 隐藏时已扣减过评论数
+		if comment.IsHidden {
+			return nil
+		}
+		if err := s.videoRepo.DecrementCommentCount(ctx, videoID); err != nil {
+			return err
+		}
+		return s.statRepo.AddComments(ctx, videoID, -1)
+	})
+	if err != nil {
+		return 0, err
+	}
 
 	return videoID, nil
 }
 
-// ListByVideo 获取视频评论列表
-func (s *CommentService) ListByVideo(videoID int64, parentID *int64, page, pageSize int) (*dto.CommentListData, error) {
-	if _, err := s.videoRepo.GetByID(videoID); err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrVideoNotFound
-		}
+// ListByVideo 获取视频评论列表，私密视频仅作者及被授权用户可查看
+func (s *CommentService) ListByVideo(ctx context.Context, videoID, viewerID int64, parentID *int64, page, pageSize int) (*dto.CommentListData, error) {
+	if _, err := s.viewableVideo(ctx, videoID, viewerID); err != nil {
 		return nil, err
 	}
 
 	skip := (page - 1) * pageSize
-	comments, total, err := s.commentRepo.ListByVideo(videoID, parentID, skip, pageSize)
+	comments, total, err := s.commentRepo.ListByVideo(ctx, videoID, parentID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.buildCommentListData(comments, total, page, pageSize, false)
+	return s.buildCommentListData(ctx, comments, total, page, pageSize, false)
 }
 
 // ListReplies 获取评论的回复列表
-func (s *CommentService) ListReplies(commentID int64, page, pageSize int) (*dto.CommentListData, error) {
-	if _, err := s.commentRepo.GetByID(commentID); err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrCommentNotFound
-		}
+func (s *CommentService) ListReplies(ctx context.Context, commentID, viewerID int64, page, pageSize int) (*dto.CommentListData, error) {
+	if _, err := s.viewableComment(ctx, commentID, viewerID); err != nil {
 		return nil, err
 	}
 
 	skip := (page - 1) * pageSize
-	comments, total, err := s.commentRepo.ListReplies(commentID, skip, pageSize)
+	comments, total, err := s.commentRepo.ListReplies(ctx, commentID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.buildCommentListData(comments, total, page, pageSize, false)
+	return s.buildCommentListData(ctx, comments, total, page, pageSize, false)
 }
 
 // ListByUser 获取用户的评论列表
-func (s *CommentService) ListByUser(userID int64, page, pageSize int) (*dto.CommentListData, error) {
+func (s *CommentService) ListByUser(ctx context.Context, userID int64, page, pageSize int) (*dto.CommentListData, error) {
 	skip := (page - 1) * pageSize
-	comments, total, err := s.commentRepo.ListByUser(userID, skip, pageSize)
+	comments, total, err := s.commentRepo.ListByUser(ctx, userID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.buildCommentListData(comments, total, page, pageSize, true)
+	return s.buildCommentListData(ctx, comments, total, page, pageSize, true)
 }
 
-func (s *CommentService) buildCommentListData(comments []model.Comment, total int64, page, pageSize int, includeVideoTitle bool) (*dto.CommentListData, error) {
+// ListByVideoByCursor 游标分页获取视频的一级评论（按时间倒序）
+func (s *CommentService) ListByVideoByCursor(ctx context.Context, videoID, viewerID int64, after string, limit int) (*dto.CursorList[dto.CommentInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.viewableVideo(ctx, videoID, viewerID); err != nil {
+		return nil, err
+	}
+	comments, err := s.commentRepo.ListByVideoBefore(ctx, videoID, c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildCommentCursorList(ctx, comments, limit, false)
+}
+
+// ListRepliesByCursor 游标分页获取评论的回复（按时间正序）
+func (s *CommentService) ListRepliesByCursor(ctx context.Context, commentID, viewerID int64, after string, limit int) (*dto.CursorList[dto.CommentInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.viewableComment(ctx, commentID, viewerID); err != nil {
+		return nil, err
+	}
+	comments, err := s.commentRepo.ListRepliesAfter(ctx, commentID, c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildCommentCursorList(ctx, comments, limit, false)
+}
+
+// ListByUserByCursor 游标分页获取用户的评论
+func (s *CommentService) ListByUserByCursor(ctx context.Context, userID int64, after string, limit int) (*dto.CursorList[dto.CommentInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.commentRepo.ListByUserBefore(ctx, userID, c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildCommentCursorList(ctx, comments, limit, true)
+}
+
+func (s *CommentService) buildCommentCursorList(ctx context.Context, comments []model.Comment, limit int, includeVideoTitle bool) (*dto.CursorList[dto.CommentInfo], error) {
+	comments, hasMore := trimPage(comments, limit)
+	data, err := s.buildCommentListData(ctx, comments, 0, 1, limit, includeVideoTitle)
+	if err != nil {
+		return nil, err
+	}
+	list := &dto.CursorList[dto.CommentInfo]{Items: data.Comments, HasMore: hasMore}
+	if hasMore {
+		list.NextCursor = cursor.Encode(cursor.New(comments[len(comments)-1].ID))
+	}
+	return list, nil
+}
+
+// TopCommentsByVideos 批量获取多个视频的热门一级评论，每个视频最多 limit 条
+func (s *CommentService) TopCommentsByVideos(ctx context.Context, videoIDs []int64, limit int) (map[int64][]dto.CommentInfo, error) {
+	comments, err := s.commentRepo.ListTopByVideos(ctx, videoIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64][]dto.CommentInfo, len(videoIDs))
+	for i := range comments {
+		result[comments[i].VideoID] = append(result[comments[i].VideoID], *toCommentInfo(&comments[i], 0))
+	}
+	return result, nil
+}
+
+func (s *CommentService) buildCommentListData(ctx context.Context, comments []model.Comment, total int64, page, pageSize int, includeVideoTitle bool) (*dto.CommentListData, error) {
 	items := make([]dto.CommentInfo, 0, len(comments))
 	for i := range comments {
-		repliesCount, _ := s.commentRepo.CountReplies(comments[i].ID)
+		repliesCount, _ := s.commentRepo.CountReplies(ctx, comments[i].ID)
 		info := toCommentInfo(&comments[i], repliesCount)
 
 		if comments[i].User.ID != 0 {
@@ -170,6 +329,9 @@ func (s *CommentService) buildCommentListData(comments []model.Comment, total in
 
 		items = append(items, *info)
 	}
+	if !includeVideoTitle {
+		s.attachMemberBadges(ctx, items)
+	}
 
 	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 
@@ -182,6 +344,29 @@ func (s *CommentService) buildCommentListData(comments []model.Comment, total in
 	}, nil
 }
 
+// attachMemberBadges 同一视频下的评论显示评论者在视频作者处的会员徽章，查询失败时不显示
+func (s *CommentService) attachMemberBadges(ctx context.Context, items []dto.CommentInfo) {
+	if len(items) == 0 {
+		return
+	}
+	video, err := s.videoRepo.GetByID(ctx, items[0].VideoID)
+	if err != nil {
+		return
+	}
+	userIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		userIDs = append(userIDs, item.UserID)
+	}
+	badges, err := s.membershipRepo.ActiveBadges(ctx, video.AuthorID, userIDs, time.Now())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Load member badges failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		return
+	}
+	for i := range items {
+		items[i].MemberBadge = badges[items[i].UserID]
+	}
+}
+
 func toCommentInfo(c *model.Comment, repliesCount int64) *dto.CommentInfo {
 	return &dto.CommentInfo{
 		ID:           c.ID,
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// lazyMemberGate 列表中出现他人的会员专属视频时才查询会员等级，每个等级、每位创作者只查询一次
+type lazyMemberGate struct {
+	membershipRepo *repository.MembershipRepository
+	viewerID       int64
+	now            time.Time
+	tiers          map[int64]*model.MembershipTier
+	levels         map[int64]int
+}
+
+func newMemberGate(membershipRepo *repository.MembershipRepository, viewerID int64) *lazyMemberGate {
+	return &lazyMemberGate{
+		membershipRepo: membershipRepo,
+		viewerID:       viewerID,
+		now:            time.Now(),
+		tiers:          make(map[int64]*model.MembershipTier),
+		levels:         make(map[int64]int),
+	}
+}
+
+// restricted 观看者不能观看作者的会员专属视频时返回占位说明，作者本人不受限制
+func (g *lazyMemberGate) restricted(ctx context.Context, authorID, tierID int64) (*dto.RestrictedPlaceholder, error) {
+	if g.viewerID != 0 && authorID == g.viewerID {
+		return nil, nil
+	}
+	tier, ok := g.tiers[tierID]
+	if !ok {
+		var err error
+		tier, err = g.membershipRepo.GetTier(ctx, tierID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		g.tiers[tierID] = tier
+	}
+	if tier == nil {
+		return nil, nil
+	}
+	if g.viewerID == 0 {
+		return membersOnlyPlaceholder(dto.RestrictedCodeLoginRequired, tier), nil
+	}
+
+	level, ok := g.levels[authorID]
+	if !ok {
+		var err error
+		level, err = g.membershipRepo.ActiveLevel(ctx, authorID, g.viewerID, g.now)
+		if err != nil {
+			return nil, err
+		}
+		g.levels[authorID] = level
+	}
+	if level >= tier.Level {
+		return nil, nil
+	}
+	return membersOnlyPlaceholder(dto.RestrictedCodeMembershipRequired, tier), nil
+}
+
+func membersOnlyPlaceholder(code string, tier *model.MembershipTier) *dto.RestrictedPlaceholder {
+	msg := fmt.Sprintf("该视频仅限「%s」及以上等级的会员观看", tier.Name)
+	if code == dto.RestrictedCodeLoginRequired {
+		msg += "，请登录后查看"
+	}
+	return &dto.RestrictedPlaceholder{
+		Reason:  "members_only",
+		Code:    code,
+		Tier:    &dto.MembersOnlyTier{ID: tier.ID, Level: tier.Level, Name: tier.Name, Price: tier.Price},
+		Message: msg,
+	}
+}
+
+// ApplyContentGate 对观看者不满足年龄条件的年龄限制视频，清空播放地址、封面、简介等内容并附带占位说明；
+// 对不是会员或会员等级不够的会员专属视频，保留标题、封面、简介作为预览，清空播放地址与摘要并附带占位说明。
+// 作者本人不受限制；viewerID 为 0 表示未登录
+func (s *VideoService) ApplyContentGate(ctx context.Context, viewerID int64, videos []dto.VideoInfo) error {
+	return applyContentGate(ctx, s.userRepo, s.memberRepo, viewerID, videos)
+}
+
+// applyContentGate 供返回视频信息的各个服务共用，规则见 VideoService.ApplyContentGate
+func applyContentGate(ctx context.Context, userRepo *repository.UserRepository, membershipRepo *repository.MembershipRepository, viewerID int64, videos []dto.VideoInfo) error {
+	ages := &lazyAgeGate{userRepo: userRepo, viewerID: viewerID}
+	members := newMemberGate(membershipRepo, viewerID)
+	for i := range videos {
+		v := &videos[i]
+		if v.AgeRestricted {
+			placeholder, err := ages.restricted(ctx, v.AuthorID)
+			if err != nil {
+				return err
+			}
+			if placeholder != nil {
+				v.PlayURL, v.CoverURL, v.Description, v.Summary, v.KeyMoments = "", "", "", "", nil
+				v.Restricted = placeholder
+				continue
+			}
+		}
+		if v.MembersOnlyTierID != nil {
+			placeholder, err := members.restricted(ctx, v.AuthorID, *v.MembersOnlyTierID)
+			if err != nil {
+				return err
+			}
+			if placeholder != nil {
+				v.PlayURL, v.Summary, v.KeyMoments = "", "", nil
+				v.Restricted = placeholder
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyContentGate 对搜索结果（含作者卡片中的视频）执行年龄限制与会员专属限制，规则同 VideoService.ApplyContentGate
+func (s *SearchService) ApplyContentGate(ctx context.Context, viewerID int64, data *dto.SearchVideoData) error {
+	ages := &lazyAgeGate{userRepo: s.userRepo, viewerID: viewerID}
+	members := newMemberGate(s.membershipRepo, viewerID)
+	apply := func(videos []dto.SearchVideoInfo) error {
+		for i := range videos {
+			v := &videos[i]
+			if v.AgeRestricted {
+				placeholder, err := ages.restricted(ctx, v.AuthorID)
+				if err != nil {
+					return err
+				}
+				if placeholder != nil {
+					v.PlayURL, v.CoverURL, v.Description, v.Highlight = "", "", "", nil
+					v.Restricted = placeholder
+					continue
+				}
+			}
+			if v.MembersOnlyTierID != nil {
+				placeholder, err := members.restricted(ctx, v.AuthorID, *v.MembersOnlyTierID)
+				if err != nil {
+					return err
+				}
+				if placeholder != nil {
+					v.PlayURL = ""
+					v.Restricted = placeholder
+				}
+			}
+		}
+		return nil
+	}
+	if err := apply(data.Videos); err != nil {
+		return err
+	}
+	if data.Author != nil {
+		return apply(data.Author.TopVideos)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"vida-go/internal/config"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// CounterService 冗余计数修复。视频的 favorite_count、comment_count 与用户的 follow_count、
+// follower_count、total_favorited 是所有接口返回计数的唯一来源，写入时与明细在同一事务中更新；
+// 修复任务定期按明细表重新计算，修正历史数据、手工改库等造成的偏差
+type CounterService struct {
+	videoRepo *repository.VideoRepository
+	userRepo  *repository.UserRepository
+}
+
+func NewCounterService(videoRepo *repository.VideoRepository, userRepo *repository.UserRepository) *CounterService {
+	return &CounterService{videoRepo: videoRepo, userRepo: userRepo}
+}
+
+// CounterRepairResult 一次修复中各计数被修正的记录数
+type CounterRepairResult struct {
+	FavoriteCounts int64
+	CommentCounts  int64
+	FollowCounts   int64
+	TotalFavorited int64
+	UserFavorites  int64
+}
+
+// RunRepair 定时任务：修复一轮计数并记录结果
+func (s *CounterService) RunRepair(ctx context.Context, cfg *config.CounterRepairConfig) error {
+	start := time.Now()
+	result, err := s.Repair(ctx, cfg.Batch())
+	if err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Counters repaired",
+		zap.Int64("favorite_counts", result.FavoriteCounts),
+		zap.Int64("comment_counts", result.CommentCounts),
+		zap.Int64("follow_counts", result.FollowCounts),
+		zap.Int64("total_favorited", result.TotalFavorited),
+		zap.Int64("user_favorite_counts", result.UserFavorites),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// Repair 分批按明细表重新计算所有视频、用户的计数，只更新有偏差的记录
+func (s *CounterService) Repair(ctx context.Context, batch int) (*CounterRepairResult, error) {
+	result := &CounterRepairResult{}
+
+	for afterID := int64(0); ; {
+		ids, err := s.videoRepo.ListIDsAfter(ctx, afterID, batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		n, err := s.videoRepo.RecountFavoriteCounts(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		result.FavoriteCounts += n
+		if n, err = s.videoRepo.RecountCommentCounts(ctx, ids); err != nil {
+			return nil, err
+		}
+		result.CommentCounts += n
+		afterID = ids[len(ids)-1]
+	}
+
+	for afterID := int64(0); ; {
+		ids, err := s.userRepo.ListIDsAfter(ctx, afterID, batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		n, err := s.userRepo.RecountFollowCounts(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		result.FollowCounts += n
+		if n, err = s.userRepo.RecountTotalFavorited(ctx, ids); err != nil {
+			return nil, err
+		}
+		result.TotalFavorited += n
+		if n, err = s.userRepo.RecountFavoriteCounts(ctx, ids); err != nil {
+			return nil, err
+		}
+		result.UserFavorites += n
+		afterID = ids[len(ids)-1]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/eventbus"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	creatorTopVideosLimit   = 10
+	creatorOverviewCacheTTL = 10 * time.Minute
+)
+
+// CreatorAnalyticsService 创作者数据看板：汇总每日统计、粉丝增长与留存曲线，概览结果缓存在 Redis
+type CreatorAnalyticsService struct {
+	statRepo     *repository.VideoStatRepository
+	relationRepo *repository.RelationRepository
+	videoRepo    *repository.VideoRepository
+	userRepo     *repository.UserRepository
+	client       *redis.Client
+}
+
+func NewCreatorAnalyticsService(
+	statRepo *repository.VideoStatRepository,
+	relationRepo *repository.RelationRepository,
+	videoRepo *repository.VideoRepository,
+	userRepo *repository.UserRepository,
+	client *redis.Client,
+) *CreatorAnalyticsService {
+	return &CreatorAnalyticsService{
+		statRepo:     statRepo,
+		relationRepo: relationRepo,
+		videoRepo:    videoRepo,
+		userRepo:     userRepo,
+		client:       client,
+	}
+}
+
+func creatorOverviewCacheKey(userID int64, days int) string {
+	return fmt.Sprintf("analytics:creator:%d:overview:%d", userID, days)
+}
+
+// GetOverview 获取创作者最近 days 天（含今天）的数据概览
+func (s *CreatorAnalyticsService) GetOverview(ctx context.Context, userID int64, days int) (*dto.CreatorOverviewData, error) {
+	key := creatorOverviewCacheKey(userID, days)
+	if s.client != nil {
+		if cached, err := s.client.Get(ctx, key).Bytes(); err == nil {
+			var data dto.CreatorOverviewData
+			if json.Unmarshal(cached, &data) == nil {
+				return &data, nil
+			}
+		}
+	}
+
+	data, err := s.buildOverview(ctx, userID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.client != nil {
+		if payload, err := json.Marshal(data); err == nil {
+			if err := s.client.Set(ctx, key, payload, creatorOverviewCacheTTL).Err(); err != nil {
+				logger.FromContext(ctx).Warn("Cache creator overview failed", zap.Int64("user_id", userID), zap.Error(err))
+			}
+		}
+	}
+	return data, nil
+}
+
+// OnUserFollowed 订阅关注事件：被关注的创作者粉丝数变化，清除其概览缓存
+func (s *CreatorAnalyticsService) OnUserFollowed(ctx context.Context, event *eventbus.UserFollowed) error {
+	return s.invalidateOverview(ctx, event.FolloweeID)
+}
+
+// OnVideoPublished 订阅视频发布事件：作者的作品列表变化，清除其概览缓存
+func (s *CreatorAnalyticsService) OnVideoPublished(ctx context.Context, event *eventbus.VideoPublished) error {
+	return s.invalidateOverview(ctx, event.AuthorID)
+}
+
+// invalidateOverview 清除创作者所有时间范围的概览缓存
+func (s *CreatorAnalyticsService) invalidateOverview(ctx context.Context, userID int64) error {
+	if s.client == nil {
+		return nil
+	}
+	iter := s.client.Scan(ctx, 0, fmt.Sprintf("analytics:creator:%d:overview:*", userID), 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.client.Del(ctx, keys...).Err()
+}
+
+func (s *CreatorAnalyticsService) buildOverview(ctx context.Context, userID int64, days int) (*dto.CreatorOverviewData, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	to := repository.StatDate(time.Now())
+	from := to.AddDate(0, 0, -(days - 1))
+
+	totals, err := s.statRepo.DailyTotalsByAuthor(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	gains, err := s.relationRepo.CountFollowersGainedByDay(ctx, userID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	top, err := s.statRepo.TopVideosByAuthor(ctx, userID, from, to, creatorTopVideosLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalByDate := make(map[string]repository.DailyTotal, len(totals))
+	for _, t := range totals {
+		totalByDate[t.StatDate.Format(time.DateOnly)] = t
+	}
+	gainByDate := make(map[string]int64, len(gains))
+	for _, g := range gains {
+		gainByDate[g.StatDate.Format(time.DateOnly)] = g.Count
+	}
+
+	data := &dto.CreatorOverviewData{
+		Days:          days,
+		FollowerCount: user.FollowerCount,
+		Daily:         make([]dto.CreatorDailyInfo, 0, days),
+		GeneratedAt:   time.Now().Unix(),
+	}
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format(time.DateOnly)
+		t := totalByDate[date]
+		day := dto.CreatorDailyInfo{
+			Date:            date,
+			Views:           t.Views,
+			Likes:           t.Likes,
+			Comments:        t.Comments,
+			FollowersGained: gainByDate[date],
+		}
+		data.TotalViews += day.Views
+		data.TotalLikes += day.Likes
+		data.TotalComments += day.Comments
+		data.FollowersGained += day.FollowersGained
+		data.Daily = append(data.Daily, day)
+	}
+
+	data.TopVideos, err = s.buildTopVideos(ctx, top)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *CreatorAnalyticsService) buildTopVideos(ctx context.Context, top []repository.VideoTotal) ([]dto.CreatorTopVideo, error) {
+	ids := make([]int64, len(top))
+	for i, t := range top {
+		ids[i] = t.VideoID
+	}
+	videos, err := s.videoRepo.GetByIDsWithAuthor(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]int, len(videos))
+	for i := range videos {
+		byID[videos[i].ID] = i
+	}
+
+	result := make([]dto.CreatorTopVideo, 0, len(top))
+	for _, t := range top {
+		i, ok := byID[t.VideoID]
+		if !ok {
+			continue
+		}
+		result = append(result, dto.CreatorTopVideo{
+			VideoID:        t.VideoID,
+			Title:          videos[i].Title,
+			CoverURL:       videos[i].CoverURL,
+			Views:          t.Views,
+			Likes:          t.Likes,
+			Comments:       t.Comments,
+			AvgWatchTimeMs: average(t.WatchTimeMs, t.Plays),
+			CompletionRate: ratio(t.Completions, t.Plays),
+		})
+	}
+	return result, nil
+}
+
+// GetRetention 获取视频的观众留存曲线，仅作者本人可查看
+func (s *CreatorAnalyticsService) GetRetention(ctx context.Context, videoID, currentUserID int64) (*dto.VideoRetentionData, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.AuthorID != currentUserID {
+		return nil, ErrVideoNoPermission
+	}
+
+	counts := map[string]string{}
+	if s.client != nil {
+		counts, err = s.client.HGetAll(ctx, retentionKey(videoID)).Result()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data := &dto.VideoRetentionData{
+		VideoID: videoID,
+		Points:  make([]dto.RetentionPoint, 0, retentionBuckets+1),
+	}
+	data.Starts, _ = strconv.ParseInt(counts["0"], 10, 64)
+	for b := 0; b <= retentionBuckets; b++ {
+		viewers, _ := strconv.ParseInt(counts[strconv.Itoa(b)], 10, 64)
+		point := dto.RetentionPoint{Percent: b * 100 / retentionBuckets, Viewers: viewers}
+		if data.Starts > 0 {
+			point.Rate = float64(viewers) / float64(data.Starts)
+		}
+		data.Points = append(data.Points, point)
+	}
+	return data, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"errors"
+
+	"vida-go/pkg/cursor"
+)
+
+var ErrInvalidCursor = errors.New("无效的分页游标")
+
+// decodeCursor 解析客户端传回的游标
+func decodeCursor(s string) (cursor.Cursor, error) {
+	c, err := cursor.Decode(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// trimPage 查询时多取一条用于判断是否还有下一页，返回截断后的结果
+func trimPage[T any](rows []T, limit int) ([]T, bool) {
+	if len(rows) > limit {
+		return rows[:limit], true
+	}
+	return rows, false
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"vida-go/internal/config"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// DigestCheckInterval 检查是否到了发送时间的默认间隔
+const DigestCheckInterval = 10 * time.Minute
+
+const (
+	digestStateTTL     = 8 * 24 * time.Hour
+	digestSendWindow   = 24 * time.Hour // 错过发送时间后仍补发的时长
+	digestPeriod       = 7 * 24 * time.Hour
+	digestCommentRunes = 80
+)
+
+// DigestService 每周摘要：到每周发送时间后分批遍历用户，汇总上一周关注的创作者发布的新视频
+// 与他人在自己视频下的热门评论，以站内通知发送，开启了 weekly_digest 邮件的用户同时收到邮件。
+// 没有内容的用户不发送；屏蔽了 digest 通知且未开启邮件的用户跳过。
+// 处理进度记录在 Redis 中，中途重启后从上次的位置继续，同一周不会重复发送
+type DigestService struct {
+	userRepo            *repository.UserRepository
+	videoRepo           *repository.VideoRepository
+	commentRepo         *repository.CommentRepository
+	settingRepo         *repository.UserSettingRepository
+	notificationService *NotificationService
+	emailService        *EmailService
+	client              *redis.Client
+}
+
+func NewDigestService(userRepo *repository.UserRepository, videoRepo *repository.VideoRepository, commentRepo *repository.CommentRepository, settingRepo *repository.UserSettingRepository, notificationService *NotificationService, emailService *EmailService, client *redis.Client) *DigestService {
+	return &DigestService{
+		userRepo:            userRepo,
+		videoRepo:           videoRepo,
+		commentRepo:         commentRepo,
+		settingRepo:         settingRepo,
+		notificationService: notificationService,
+		emailService:        emailService,
+		client:              client,
+	}
+}
+
+// digestPeriodEnd 返回 now 之前（含）最近一次的发送时间
+func digestPeriodEnd(now time.Time, weekday time.Weekday, hour int) time.Time {
+	end := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	end = end.AddDate(0, 0, -((int(now.Weekday()) - int(weekday) + 7) % 7))
+	if end.After(now) {
+		end = end.AddDate(0, 0, -7)
+	}
+	return end
+}
+
+func digestKey(until time.Time, suffix string) string {
+	return fmt.Sprintf("digest:weekly:%s:%s", until.Format("20060102"), suffix)
+}
+
+// RunDigest 定时任务：检查是否到了发送时间，到了则从上次的进度继续发送本周摘要
+func (s *DigestService) RunDigest(ctx context.Context, cfg *config.DigestConfig) error {
+	now := time.Now()
+	until := digestPeriodEnd(now, cfg.SendWeekday(), cfg.SendHour())
+	if now.Sub(until) > digestSendWindow {
+		return nil
+	}
+	doneKey, cursorKey := digestKey(until, "done"), digestKey(until, "cursor")
+
+	if n, err := s.client.Exists(ctx, doneKey).Result(); err != nil || n > 0 {
+		return err
+	}
+	afterID, err := s.client.Get(ctx, cursorKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("get digest cursor: %w", err)
+	}
+
+	since := until.Add(-digestPeriod)
+	sent := 0
+	for ctx.Err() == nil {
+		ids, err := s.userRepo.ListIDsAfter(ctx, afterID, cfg.Batch())
+		if err != nil {
+			return fmt.Errorf("list digest recipients: %w", err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		for _, userID := range ids {
+			ok, err := s.SendDigest(ctx, userID, since, until, cfg)
+			if err != nil {
+				logger.FromContext(ctx).Warn("Send weekly digest failed", zap.Int64("user_id", userID), zap.Error(err))
+			}
+			if ok {
+				sent++
+			}
+		}
+		afterID = ids[len(ids)-1]
+
+		if err := s.client.Set(ctx, cursorKey, afterID, digestStateTTL).Err(); err != nil {
+			return fmt.Errorf("save digest cursor: %w", err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.client.Set(ctx, doneKey, 1, digestStateTTL)
+	logger.FromContext(ctx).Info("Weekly digests sent",
+		zap.Time("since", since), zap.Time("until", until), zap.Int("sent", sent))
+	return nil
+}
+
+// SendDigest 汇总用户在 [since, until) 内的摘要并发送，没有内容或用户已关闭摘要时返回 false
+func (s *DigestService) SendDigest(ctx context.Context, userID int64, since, until time.Time, cfg *config.DigestConfig) (bool, error) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	inApp := !slices.Contains(setting.MutedNotificationTypes, NotificationTypeDigest)
+	email := setting.NotificationEmail != "" && slices.Contains(setting.EmailNotifications, EmailKindWeeklyDigest)
+	if !inApp && !email {
+		return false, nil
+	}
+
+	videos, videoCount, err := s.videoRepo.ListFollowedPublishedSince(ctx, userID, since, until, cfg.VideoLimit())
+	if err != nil {
+		return false, err
+	}
+	comments, err := s.commentRepo.ListTopOnAuthorVideos(ctx, userID, since, until, cfg.CommentLimit())
+	if err != nil {
+		return false, err
+	}
+	if videoCount == 0 && len(comments) == 0 {
+		return false, nil
+	}
+
+	if inApp {
+		s.notificationService.Emit(ctx, &infraKafka.NotificationEvent{
+			Type:        NotificationTypeDigest,
+			RecipientID: userID,
+			Content:     digestSummary(videoCount, len(comments)),
+		})
+	}
+	if email {
+		videoItems := make([]map[string]string, 0, len(videos))
+		for i := range videos {
+			videoItems = append(videoItems, map[string]string{"Title": videos[i].Title, "Author": videos[i].Author.UserName})
+		}
+		commentItems := make([]map[string]string, 0, len(comments))
+		for i := range comments {
+			commentItems = append(commentItems, map[string]string{
+				"User":       comments[i].User.UserName,
+				"VideoTitle": comments[i].Video.Title,
+				"Content":    truncateRunes(comments[i].Content, digestCommentRunes),
+			})
+		}
+		s.emailService.Notify(ctx, userID, EmailKindWeeklyDigest, "weekly_digest", map[string]interface{}{
+			"From":       since.Format("2006-01-02"),
+			"To":         until.Add(-time.Second).Format("2006-01-02"),
+			"Videos":     videoItems,
+			"VideoCount": videoCount,
+			"MoreVideos": videoCount - int64(len(videoItems)),
+			"Comments":   commentItems,
+		})
+	}
+	return true, nil
+}
+
+// digestSummary 站内通知中的摘要内容
+func digestSummary(videoCount int64, commentCount int) string {
+	parts := make([]string, 0, 2)
+	if videoCount > 0 {
+		parts = append(parts, fmt.Sprintf("你关注的创作者发布了 %d 个新视频", videoCount))
+	}
+	if commentCount > 0 {
+		parts = append(parts, fmt.Sprintf("你的视频收到了 %d 条热门评论", commentCount))
+	}
+	return "本周摘要：" + strings.Join(parts, "，")
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"math/bits"
+	"sort"
+
+	"vida-go/internal/config"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// DuplicateService 近似重复视频检测：保存新视频的抽帧指纹，并与已有视频比较
+type DuplicateService struct {
+	fingerprintRepo *repository.VideoFingerprintRepository
+	duplicateRepo   *repository.VideoDuplicateRepository
+	videoRepo       *repository.VideoRepository
+}
+
+func NewDuplicateService(
+	fingerprintRepo *repository.VideoFingerprintRepository,
+	duplicateRepo *repository.VideoDuplicateRepository,
+	videoRepo *repository.VideoRepository,
+) *DuplicateService {
+	return &DuplicateService{fingerprintRepo: fingerprintRepo, duplicateRepo: duplicateRepo, videoRepo: videoRepo}
+}
+
+// duplicateMatch 与某个已有视频的比较结果
+type duplicateMatch struct {
+	videoID    int64
+	similarity float64
+}
+
+// Check 保存视频指纹并查找最相似的已有视频：相似度达到审核阈值时记录待审核，
+// 达到关联阈值时自动关联到原视频。检测失败只记录日志，不影响发布
+func (s *DuplicateService) Check(ctx context.Context, video *model.Video, frames []infraKafka.VideoFrame) {
+	cfg := config.GetDuplicate()
+	if !cfg.Enabled {
+		return
+	}
+
+	fingerprints := make([]model.VideoFingerprint, 0, len(frames))
+	for _, f := range frames {
+		if f.Hash != 0 {
+			fingerprints = append(fingerprints, model.NewVideoFingerprint(video.ID, f.Offset, f.Hash))
+		}
+	}
+	if len(fingerprints) == 0 {
+		return
+	}
+
+	match, err := s.findBestMatch(ctx, video.ID, fingerprints, cfg.Distance())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Find duplicate videos failed", zap.Int64("video_id", video.ID), zap.Error(err))
+	}
+
+	// 转码结果可能被重复投递，先删除旧指纹保证幂等
+	if err := s.fingerprintRepo.DeleteByVideo(ctx, video.ID); err != nil {
+		logger.FromContext(ctx).Warn("Delete video fingerprints failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		return
+	}
+	if err := s.fingerprintRepo.CreateBatch(ctx, fingerprints); err != nil {
+		logger.FromContext(ctx).Warn("Save video fingerprints failed", zap.Int64("video_id", video.ID), zap.Error(err))
+	}
+
+	if match == nil || match.similarity < cfg.FlagThreshold() {
+		return
+	}
+
+	status := model.VideoDuplicateStatusPending
+	if match.similarity >= cfg.LinkThreshold() {
+		status = model.VideoDuplicateStatusLinked
+	}
+	if err := s.duplicateRepo.Create(ctx, &model.VideoDuplicate{
+		VideoID:       video.ID,
+		DuplicateOfID: match.videoID,
+		Similarity:    match.similarity,
+		Status:        status,
+	}); err != nil {
+		logger.FromContext(ctx).Warn("Save video duplicate failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		return
+	}
+	if status == model.VideoDuplicateStatusLinked {
+		if _, err := s.videoRepo.Update(ctx, video.ID, map[string]interface{}{"duplicate_of_id": match.videoID}); err != nil {
+			logger.FromContext(ctx).Warn("Link duplicate video failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		}
+	}
+
+	logger.FromContext(ctx).Info("Near-duplicate video detected",
+		zap.Int64("video_id", video.ID),
+		zap.Int64("duplicate_of", match.videoID),
+		zap.Float64("similarity", match.similarity),
+		zap.String("status", status),
+	)
+}
+
+// findBestMatch 找出匹配帧比例最高的更早发布的视频，已删除的视频不参与比较
+func (s *DuplicateService) findBestMatch(ctx context.Context, videoID int64, fingerprints []model.VideoFingerprint, maxDistance int) (*duplicateMatch, error) {
+	candidates, err := s.fingerprintRepo.FindCandidates(ctx, videoID, fingerprints)
+	if err != nil {
+		return nil, err
+	}
+
+	byVideo := make(map[int64][]uint64)
+	for _, c := range candidates {
+		if c.VideoID < videoID {
+			byVideo[c.VideoID] = append(byVideo[c.VideoID], uint64(c.Hash))
+		}
+	}
+
+	var matches []duplicateMatch
+	for candidateID, hashes := range byVideo {
+		matched := 0
+		for _, fp := range fingerprints {
+			for _, h := range hashes {
+				if bits.OnesCount64(uint64(fp.Hash)^h) <= maxDistance {
+					matched++
+					break
+				}
+			}
+		}
+		if matched > 0 {
+			matches = append(matches, duplicateMatch{videoID: candidateID, similarity: float64(matched) / float64(len(fingerprints))})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].similarity != matches[j].similarity {
+			return matches[i].similarity > matches[j].similarity
+		}
+		return matches[i].videoID < matches[j].videoID
+	})
+
+	for _, m := range matches {
+		if _, err := s.videoRepo.GetByID(ctx, m.videoID); err != nil {
+			continue
+		}
+		return &m, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	infraEmail "vida-go/internal/infra/email"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// 邮件通知类别（用户需逐项开启）
+const (
+	EmailKindFollowerDigest = "follower_digest"
+	EmailKindVideoPublished = "video_published"
+	EmailKindModeration     = "moderation"
+	EmailKindWeeklyDigest   = "weekly_digest"
+)
+
+const (
+	emailSendTimeout        = 30 * time.Second
+	followerDigestPending   = "email:follower_digest:pending"
+	followerDigestMaxListed = 10 // 摘要中最多列出的粉丝数
+)
+
+var ErrEmailRequired = errors.New("开启邮件通知需要填写邮箱地址")
+
+// EmailService 邮件通知：按用户设置过滤后渲染模板，经 Kafka 异步发送
+type EmailService struct {
+	userRepo    *repository.UserRepository
+	settingRepo *repository.UserSettingRepository
+	client      *redis.Client
+}
+
+func NewEmailService(userRepo *repository.UserRepository, settingRepo *repository.UserSettingRepository, client *redis.Client) *EmailService {
+	return &EmailService{userRepo: userRepo, settingRepo: settingRepo, client: client}
+}
+
+func followerDigestKey(userID int64) string {
+	return fmt.Sprintf("email:follower_digest:%d", userID)
+}
+
+// Notify 向开启了该类别邮件的用户发送模板邮件（尽力而为）
+func (s *EmailService) Notify(ctx context.Context, userID int64, kind, templateName string, data map[string]interface{}) {
+	if !infraEmail.Enabled() {
+		return
+	}
+	to, ok := s.subscribedAddress(ctx, userID, kind)
+	if !ok {
+		return
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Get email recipient failed", zap.Int64("user_id", userID), zap.Error(err))
+		return
+	}
+	data["Username"] = user.UserName
+
+	subject, body, err := infraEmail.Render(templateName, data)
+	if err != nil {
+		logger.FromContext(ctx).Error("Render email failed", zap.String("template", templateName), zap.Error(err))
+		return
+	}
+
+	task := &infraKafka.EmailTask{UserID: userID, To: to, Subject: subject, Body: body}
+	ctx = context.WithoutCancel(ctx)
+	if topic := config.GetKafka().Topics["email"]; topic != "" {
+		err := infraKafka.SendEmailTask(ctx, topic, task)
+		if err == nil {
+			return
+		}
+		logger.FromContext(ctx).Warn("Queue email failed, sending directly", zap.Int64("user_id", userID), zap.Error(err))
+	}
+
+	go func() {
+		if err := s.Deliver(ctx, task); err != nil {
+			logger.FromContext(ctx).Error("Send email failed", zap.Int64("user_id", userID), zap.Error(err))
+		}
+	}()
+}
+
+// Deliver 实际发送邮件（Kafka 消费端调用）
+func (s *EmailService) Deliver(ctx context.Context, task *infraKafka.EmailTask) error {
+	ctx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+	defer cancel()
+	return infraEmail.Send(ctx, task.To, task.Subject, task.Body)
+}
+
+// AddFollowerToDigest 记录新粉丝，由 SendFollowerDigests 定期汇总成一封邮件
+func (s *EmailService) AddFollowerToDigest(ctx context.Context, userID, followerID int64) {
+	if !infraEmail.Enabled() {
+		return
+	}
+	if _, ok := s.subscribedAddress(ctx, userID, EmailKindFollowerDigest); !ok {
+		return
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.SAdd(ctx, followerDigestKey(userID), followerID)
+	pipe.SAdd(ctx, followerDigestPending, userID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.FromContext(ctx).Warn("Record follower digest failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
+// SendFollowerDigests 定时任务：为所有待汇总的用户发送新粉丝摘要邮件
+func (s *EmailService) SendFollowerDigests(ctx context.Context) error {
+	for ctx.Err() == nil {
+		userID, err := s.client.SPop(ctx, followerDigestPending).Int64()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return nil
+			}
+			return err
+		}
+
+		// 读取并清空该用户的待汇总粉丝
+		pipe := s.client.TxPipeline()
+		membersCmd := pipe.SMembers(ctx, followerDigestKey(userID))
+		pipe.Del(ctx, followerDigestKey(userID))
+		if _, err := pipe.Exec(ctx); err != nil {
+			logger.FromContext(ctx).Warn("Read follower digest failed", zap.Int64("user_id", userID), zap.Error(err))
+			continue
+		}
+
+		var followerIDs []int64
+		if err := membersCmd.ScanSlice(&followerIDs); err != nil || len(followerIDs) == 0 {
+			continue
+		}
+
+		listed := followerIDs
+		if len(listed) > followerDigestMaxListed {
+			listed = listed[:followerDigestMaxListed]
+		}
+		users, err := s.userRepo.GetByIDs(ctx, listed)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Get digest followers failed", zap.Int64("user_id", userID), zap.Error(err))
+			continue
+		}
+		names := make([]string, 0, len(users))
+		for i := range users {
+			names = append(names, users[i].UserName)
+		}
+
+		s.Notify(ctx, userID, EmailKindFollowerDigest, "follower_digest", map[string]interface{}{
+			"Count":     len(followerIDs),
+			"Followers": names,
+			"More":      len(followerIDs) - len(names),
+		})
+	}
+	return nil
+}
+
+// GetPreferences 获取邮件通知设置
+func (s *EmailService) GetPreferences(ctx context.Context, userID int64) (*dto.EmailPreferences, error) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toEmailPreferences(setting.NotificationEmail, setting.EmailNotifications), nil
+}
+
+// UpdatePreferences 更新邮件通知设置（整体替换）
+func (s *EmailService) UpdatePreferences(ctx context.Context, userID int64, req *dto.EmailPreferencesUpdateRequest) (*dto.EmailPreferences, error) {
+	if len(req.Kinds) > 0 && req.Email == "" {
+		return nil, ErrEmailRequired
+	}
+
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	kinds := slices.Clone(req.Kinds)
+	slices.Sort(kinds)
+	setting.NotificationEmail = req.Email
+	setting.EmailNotifications = slices.Compact(kinds)
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return toEmailPreferences(setting.NotificationEmail, setting.EmailNotifications), nil
+}
+
+// subscribedAddress 返回用户开启了该类别邮件时的收件地址
+func (s *EmailService) subscribedAddress(ctx context.Context, userID int64, kind string) (string, bool) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Get email preferences failed", zap.Int64("user_id", userID), zap.Error(err))
+		return "", false
+	}
+	if setting.NotificationEmail == "" || !slices.Contains(setting.EmailNotifications, kind) {
+		return "", false
+	}
+	return setting.NotificationEmail, true
+}
+
+func toEmailPreferences(address string, kinds []string) *dto.EmailPreferences {
+	if kinds == nil {
+		kinds = []string{}
+	}
+	return &dto.EmailPreferences{Email: address, Kinds: kinds}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// 用户事件类型
+const (
+	EventTypeUploadStatus = "upload.status"
+	EventTypeNotification = "notification"
+	EventTypeMessage      = "message"
+)
+
+const (
+	userEventStreamMaxLen = 1000               // 每个用户保留的最近事件数
+	userEventStreamTTL    = 7 * 24 * time.Hour // 无新事件时自动过期
+)
+
+// EventService 用户事件流（基于 Redis Stream）
+// 事件 ID 即 Stream 消息 ID，客户端断线后可凭最后收到的 ID 续传
+type EventService struct {
+	client *redis.Client
+}
+
+func NewEventService(client *redis.Client) *EventService {
+	return &EventService{client: client}
+}
+
+func userEventStreamKey(userID int64) string {
+	return fmt.Sprintf("events:user:%d", userID)
+}
+
+// Publish 向用户事件流追加一条事件（尽力而为，失败只记录日志）
+func (s *EventService) Publish(ctx context.Context, userID int64, eventType string, payload interface{}) {
+	if s.client == nil {
+		return
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.FromContext(ctx).Error("Marshal user event failed", zap.String("type", eventType), zap.Error(err))
+		return
+	}
+
+	key := userEventStreamKey(userID)
+	ctx = context.WithoutCancel(ctx)
+	pipe := s.client.TxPipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: userEventStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"type": eventType, "data": data},
+	})
+	pipe.Expire(ctx, key, userEventStreamTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.FromContext(ctx).Warn("Publish user event failed",
+			zap.Int64("user_id", userID), zap.String("type", eventType), zap.Error(err))
+	}
+}
+
+// LatestID 返回用户事件流当前最新的事件 ID（流为空时返回 "0-0"）
+func (s *EventService) LatestID(ctx context.Context, userID int64) (string, error) {
+	msgs, err := s.client.XRevRangeN(ctx, userEventStreamKey(userID), "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(msgs) == 0 {
+		return "0-0", nil
+	}
+	return msgs[0].ID, nil
+}
+
+// Read 读取 afterID 之后的事件，没有新事件时最多阻塞 block 时长
+func (s *EventService) Read(ctx context.Context, userID int64, afterID string, block time.Duration) ([]dto.UserEvent, error) {
+	streams, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{userEventStreamKey(userID), afterID},
+		Count:   100,
+		Block:   block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var events []dto.UserEvent
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			eventType, _ := msg.Values["type"].(string)
+			data, _ := msg.Values["data"].(string)
+			events = append(events, dto.UserEvent{
+				ID:   msg.ID,
+				Type: eventType,
+				Data: json.RawMessage(data),
+			})
+		}
+	}
+	return events, nil
+}
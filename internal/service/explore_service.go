@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/infra/geoip"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const exploreSnapshotKey = "explore:snapshot"
+
+var (
+	ErrExploreSlotNotFound = errors.New("推荐位不存在")
+	ErrInvalidExploreSlot  = errors.New("推荐位的结束时间必须晚于开始时间")
+)
+
+// exploreSnapshot 后台任务挑选出的发现页内容，只保存 ID，展示时再加载最新的视频与用户信息
+type exploreSnapshot struct {
+	Trending    []int64           `json:"trending"`
+	NewCreators []int64           `json:"new_creators"`
+	Categories  []exploreCategory `json:"categories"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type exploreCategory struct {
+	Name     string  `json:"name"`
+	VideoIDs []int64 `json:"video_ids"`
+}
+
+// ExploreService 发现页：后台任务定期挑选热门视频、新晋创作者与各分类热门视频存入 Redis，
+// 请求时合并管理员设置的推荐位，并按观看者所在地区、年龄限制过滤
+type ExploreService struct {
+	videoRepo    *repository.VideoRepository
+	tagRepo      *repository.VideoTagRepository
+	userRepo     *repository.UserRepository
+	slotRepo     *repository.ExploreSlotRepository
+	videoService *VideoService
+	userService  *UserService
+	client       *redis.Client
+}
+
+func NewExploreService(
+	videoRepo *repository.VideoRepository,
+	tagRepo *repository.VideoTagRepository,
+	userRepo *repository.UserRepository,
+	slotRepo *repository.ExploreSlotRepository,
+	videoService *VideoService,
+	userService *UserService,
+	client *redis.Client,
+) *ExploreService {
+	return &ExploreService{
+		videoRepo:    videoRepo,
+		tagRepo:      tagRepo,
+		userRepo:     userRepo,
+		slotRepo:     slotRepo,
+		videoService: videoService,
+		userService:  userService,
+		client:       client,
+	}
+}
+
+// RunCuration 定时任务：重新挑选一轮发现页内容
+func (s *ExploreService) RunCuration(ctx context.Context, cfg *config.ExploreConfig) error {
+	if s.client == nil {
+		return nil
+	}
+
+	start := time.Now()
+	snapshot, err := s.curate(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	s.saveSnapshot(ctx, cfg, snapshot)
+	logger.FromContext(ctx).Info("Explore page curated",
+		zap.Int("trending", len(snapshot.Trending)),
+		zap.Int("new_creators", len(snapshot.NewCreators)),
+		zap.Int("categories", len(snapshot.Categories)),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// curate 挑选发现页内容。多取一些视频，展示时按地区、可见性过滤后仍能填满栏目
+func (s *ExploreService) curate(ctx context.Context, cfg *config.ExploreConfig) (*exploreSnapshot, error) {
+	now := time.Now()
+	since := now.Add(-cfg.TrendingWindow())
+	limit := 2 * cfg.Size()
+
+	snapshot := &exploreSnapshot{UpdatedAt: now}
+	var err error
+	if snapshot.Trending, err = s.videoRepo.ListHotIDs(ctx, since, "", limit); err != nil {
+		return nil, err
+	}
+	if snapshot.NewCreators, err = s.userRepo.ListNewCreatorIDs(ctx, now.Add(-cfg.NewCreatorWindow()), cfg.Size()); err != nil {
+		return nil, err
+	}
+
+	categories := cfg.Categories
+	if len(categories) == 0 {
+		if categories, err = s.tagRepo.TopCategories(ctx, since, cfg.CategoryLimit()); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range categories {
+		ids, err := s.videoRepo.ListHotIDs(ctx, since, name, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			snapshot.Categories = append(snapshot.Categories, exploreCategory{Name: name, VideoIDs: ids})
+		}
+	}
+	return snapshot, nil
+}
+
+// 快照保留三个周期，某次挑选失败时继续使用上一次的结果
+func (s *ExploreService) saveSnapshot(ctx context.Context, cfg *config.ExploreConfig, snapshot *exploreSnapshot) {
+	if s.client == nil {
+		return
+	}
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return
+	}
+	if err := s.client.Set(ctx, exploreSnapshotKey, payload, 3*cfg.Interval()).Err(); err != nil {
+		logger.FromContext(ctx).Warn("Save explore snapshot failed", zap.Error(err))
+	}
+}
+
+// loadSnapshot 读取最近一次挑选结果，尚未挑选或 Redis 不可用时当场挑选
+func (s *ExploreService) loadSnapshot(ctx context.Context, cfg *config.ExploreConfig) (*exploreSnapshot, error) {
+	if s.client != nil {
+		if cached, err := s.client.Get(ctx, exploreSnapshotKey).Bytes(); err == nil {
+			var snapshot exploreSnapshot
+			if json.Unmarshal(cached, &snapshot) == nil {
+				return &snapshot, nil
+			}
+		}
+	}
+
+	snapshot, err := s.curate(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	s.saveSnapshot(ctx, cfg, snapshot)
+	return snapshot, nil
+}
+
+// GetExplore 获取发现页，viewerID 为 0 表示未登录
+func (s *ExploreService) GetExplore(ctx context.Context, viewerID int64) (*dto.ExploreData, error) {
+	cfg := config.GetExplore()
+	snapshot, err := s.loadSnapshot(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := s.slotRepo.ListActive(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// 推荐位按栏目（分类栏目按分类名）分组，排在挑选结果之前
+	pinned := make(map[string][]int64)
+	var slotCategories []string
+	for _, slot := range slots {
+		key := slot.Section
+		if slot.Section == model.ExploreSectionCategory {
+			key += ":" + slot.Category
+			if len(pinned[key]) == 0 {
+				slotCategories = append(slotCategories, slot.Category)
+			}
+		}
+		pinned[key] = append(pinned[key], slot.VideoID)
+	}
+
+	type videoSection struct {
+		key, category string
+		ids           []int64
+	}
+	sections := []videoSection{
+		{key: model.ExploreSectionFeatured, ids: pinned[model.ExploreSectionFeatured]},
+		{key: model.ExploreSectionTrending, ids: append(pinned[model.ExploreSectionTrending], snapshot.Trending...)},
+	}
+	curated := make(map[string]bool, len(snapshot.Categories))
+	for _, c := range snapshot.Categories {
+		curated[c.Name] = true
+		key := model.ExploreSectionCategory + ":" + c.Name
+		sections = append(sections, videoSection{key: model.ExploreSectionCategory, category: c.Name, ids: append(pinned[key], c.VideoIDs...)})
+	}
+	for _, name := range slotCategories {
+		if !curated[name] {
+			sections = append(sections, videoSection{key: model.ExploreSectionCategory, category: name, ids: pinned[model.ExploreSectionCategory+":"+name]})
+		}
+	}
+
+	var allIDs []int64
+	for _, sec := range sections {
+		allIDs = append(allIDs, sec.ids...)
+	}
+	videos, err := s.visibleVideos(ctx, uniqueIDs(allIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dto.ExploreData{Sections: []dto.ExploreSection{}, Categories: []string{}, UpdatedAt: &snapshot.UpdatedAt}
+	appendVideos := func(sec videoSection) error {
+		items := make([]dto.VideoInfo, 0, cfg.Size())
+		for _, id := range uniqueIDs(sec.ids) {
+			if len(items) == cfg.Size() {
+				break
+			}
+			if v, ok := videos[id]; ok {
+				items = append(items, *toVideoInfo(v, true))
+			}
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		if err := s.videoService.ApplyContentGate(ctx, viewerID, items); err != nil {
+			return err
+		}
+		if viewerID > 0 {
+			if err := s.videoService.FillViewerState(ctx, viewerID, items); err != nil {
+				return err
+			}
+		}
+		data.Sections = append(data.Sections, dto.ExploreSection{Key: sec.key, Category: sec.category, Videos: items})
+		if sec.category != "" {
+			data.Categories = append(data.Categories, sec.category)
+		}
+		return nil
+	}
+
+	for _, sec := range sections[:2] {
+		if err := appendVideos(sec); err != nil {
+			return nil, err
+		}
+	}
+	creators, err := s.userService.BatchGetBriefs(ctx, viewerID, snapshot.NewCreators)
+	if err != nil {
+		return nil, err
+	}
+	if len(creators) > 0 {
+		data.Sections = append(data.Sections, dto.ExploreSection{Key: model.ExploreSectionNewCreators, Creators: creators})
+	}
+	for _, sec := range sections[2:] {
+		if err := appendVideos(sec); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// visibleVideos 加载视频并过滤掉未发布、已隐藏、保全中或在观看者所在地区不可见的视频
+func (s *ExploreService) visibleVideos(ctx context.Context, ids []int64) (map[int64]*model.Video, error) {
+	list, err := s.videoRepo.GetByIDsWithAuthor(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	country := geoip.CountryFromContext(ctx)
+	videos := make(map[int64]*model.Video, len(list))
+	for i := range list {
+		v := &list[i]
+		if v.Status != "published" || v.PlayURL == "" || v.LegalHoldAt != nil || v.IsPrivate() || !v.AvailableIn(country) {
+			continue
+		}
+		videos[v.ID] = v
+	}
+	return videos, nil
+}
+
+// CreateSlot 管理员创建推荐位
+func (s *ExploreService) CreateSlot(ctx context.Context, adminID int64, req *dto.ExploreSlotCreateRequest) (*dto.ExploreSlotInfo, error) {
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return nil, ErrInvalidExploreSlot
+	}
+	if _, err := s.videoRepo.GetByID(ctx, req.VideoID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+
+	slot := &model.ExploreSlot{
+		Section:   req.Section,
+		VideoID:   req.VideoID,
+		Position:  req.Position,
+		Note:      req.Note,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		CreatedBy: adminID,
+	}
+	if req.Section == model.ExploreSectionCategory {
+		slot.Category = req.Category
+	}
+	if err := s.slotRepo.Create(ctx, slot); err != nil {
+		return nil, err
+	}
+	info := toExploreSlotInfo(slot)
+	return &info, nil
+}
+
+// ListSlots 分页获取推荐位（含未生效、已过期）
+func (s *ExploreService) ListSlots(ctx context.Context, page, pageSize int) (*dto.ExploreSlotListData, error) {
+	skip := (page - 1) * pageSize
+	slots, total, err := s.slotRepo.List(ctx, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ExploreSlotInfo, 0, len(slots))
+	for i := range slots {
+		items = append(items, toExploreSlotInfo(&slots[i]))
+	}
+
+	return &dto.ExploreSlotListData{
+		Slots:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// DeleteSlot 删除推荐位，立即生效
+func (s *ExploreService) DeleteSlot(ctx context.Context, slotID int64) error {
+	if err := s.slotRepo.Delete(ctx, slotID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrExploreSlotNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func toExploreSlotInfo(slot *model.ExploreSlot) dto.ExploreSlotInfo {
+	return dto.ExploreSlotInfo{
+		ID:        slot.ID,
+		Section:   slot.Section,
+		Category:  slot.Category,
+		VideoID:   slot.VideoID,
+		Position:  slot.Position,
+		Note:      slot.Note,
+		StartsAt:  slot.StartsAt,
+		EndsAt:    slot.EndsAt,
+		CreatedBy: slot.CreatedBy,
+		CreatedAt: slot.CreatedAt,
+	}
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"vida-go/internal/api/dto"
+	infraKafka "vida-go/internal/infra/kafka"
 	"vida-go/internal/model"
 	"vida-go/internal/repository"
+	"vida-go/pkg/cursor"
 
 	"gorm.io/gorm"
 )
@@ -16,88 +19,195 @@ var (
 )
 
 type FavoriteService struct {
-	favoriteRepo *repository.FavoriteRepository
-	videoRepo    *repository.VideoRepository
-	userRepo     *repository.UserRepository
+	favoriteRepo        *repository.FavoriteRepository
+	videoRepo           *repository.VideoRepository
+	userRepo            *repository.UserRepository
+	statRepo            *repository.VideoStatRepository
+	accessRepo          *repository.VideoAccessRepository
+	membershipRepo      *repository.MembershipRepository
+	notificationService *NotificationService
+	txManager           *repository.TxManager
 }
 
-func NewFavoriteService(favoriteRepo *repository.FavoriteRepository, videoRepo *repository.VideoRepository, userRepo *repository.UserRepository) *FavoriteService {
-	return &FavoriteService{favoriteRepo: favoriteRepo, videoRepo: videoRepo, userRepo: userRepo}
+func NewFavoriteService(favoriteRepo *repository.FavoriteRepository, videoRepo *repository.VideoRepository, userRepo *repository.UserRepository, statRepo *repository.VideoStatRepository, accessRepo *repository.VideoAccessRepository, membershipRepo *repository.MembershipRepository, notificationService *NotificationService, txManager *repository.TxManager) *FavoriteService {
+	return &FavoriteService{favoriteRepo: favoriteRepo, videoRepo: videoRepo, userRepo: userRepo, statRepo: statRepo, accessRepo: accessRepo, membershipRepo: membershipRepo, notificationService: notificationService, txManager: txManager}
 }
 
-// Favorite 点赞视频
-func (s *FavoriteService) Favorite(userID, videoID int64) (*dto.FavoriteInfo, int64, error) {
-	video, err := s.videoRepo.GetByID(videoID)
+// viewableVideos 过滤掉观看者无权查看的私密视频（如点赞后被作者设为私密或撤销了授权）
+func (s *FavoriteService) viewableVideos(ctx context.Context, viewerID int64, videos []model.Video) ([]model.Video, error) {
+	visible := videos[:0]
+	for i := range videos {
+		ok, err := canViewVideo(ctx, s.accessRepo, &videos[i], viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			visible = append(visible, videos[i])
+		}
+	}
+	return visible, nil
+}
+
+// Favorite 点赞视频，reaction 为表态类型（为空时为 like）。已用其他类型表态时改为新的类型，
+// 点赞总数不变，也不再通知作者
+func (s *FavoriteService) Favorite(ctx context.Context, userID, videoID int64, reaction string) (*dto.FavoriteInfo, int64, error) {
+	if reaction == "" {
+		reaction = model.ReactionLike
+	}
+	video, err := s.videoRepo.GetByID(ctx, videoID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, 0, ErrVideoNotFound
 		}
 		return nil, 0, err
 	}
+	if ok, err := canViewVideo(ctx, s.accessRepo, video, userID); err != nil {
+		return nil, 0, err
+	} else if !ok {
+		return nil, 0, ErrVideoNotFound
+	}
 
-	exists, err := s.favoriteRepo.Exists(userID, videoID)
-	if err != nil {
+	existing, err := s.favoriteRepo.Get(ctx, userID, videoID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, 0, err
 	}
-	if exists {
-		return nil, 0, ErrAlreadyFavorited
+	if existing != nil {
+		if existing.Reaction == reaction {
+			return nil, 0, ErrAlreadyFavorited
+		}
+		return s.switchReaction(ctx, existing, reaction)
 	}
 
-	fav, err := s.favoriteRepo.Create(userID, videoID)
+	// 点赞记录与视频点赞数、每日统计、作者获赞数、用户点赞数在同一事务中更新
+	var fav *model.Favorite
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if fav, err = s.favoriteRepo.Create(ctx, userID, videoID, reaction); err != nil {
+			return err
+		}
+		if err := s.videoRepo.IncrementFavoriteCount(ctx, videoID, reaction); err != nil {
+			return err
+		}
+		if err := s.statRepo.AddLikes(ctx, videoID, 1); err != nil {
+			return err
+		}
+		if err := s.userRepo.IncrementFavoriteCount(ctx, userID); err != nil {
+			return err
+		}
+		return s.userRepo.IncrementTotalFavorited(ctx, video.AuthorID)
+	})
 	if err != nil {
 		return nil, 0, err
 	}
 
-	_ = s.videoRepo.IncrementFavoriteCount(videoID)
-	_ = s.userRepo.IncrementTotalFavorited(video.AuthorID)
-
-	totalFav, _ := s.favoriteRepo.CountByVideo(videoID)
+	s.notificationService.Emit(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeLike,
+		RecipientID: video.AuthorID,
+		ActorID:     userID,
+		VideoID:     &videoID,
+	})
 
-	return toFavoriteInfo(fav), totalFav, nil
+	return toFavoriteInfo(fav), s.favoriteCount(ctx, videoID), nil
 }
 
-// Unfavorite 取消点赞
-func (s *FavoriteService) Unfavorite(userID, videoID int64) (int64, error) {
-	video, _ := s.videoRepo.GetByID(videoID)
-	deleted, err := s.favoriteRepo.Delete(userID, videoID)
+// switchReaction 更换已有表态的类型，并发更换时以先完成的为准
+func (s *FavoriteService) switchReaction(ctx context.Context, fav *model.Favorite, reaction string) (*dto.FavoriteInfo, int64, error) {
+	err := s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		updated, err := s.favoriteRepo.UpdateReaction(ctx, fav.ID, fav.Reaction, reaction)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrAlreadyFavorited
+		}
+		return s.videoRepo.SwitchReaction(ctx, fav.VideoID, fav.Reaction, reaction)
+	})
 	if err != nil {
-		return 0, err
-	}
-	if !deleted {
-		return 0, ErrNotFavorited
+		return nil, 0, err
 	}
+	fav.Reaction = reaction
+	return toFavoriteInfo(fav), s.favoriteCount(ctx, fav.VideoID), nil
+}
 
-	_ = s.videoRepo.DecrementFavoriteCount(videoID)
-	if video != nil {
-		_ = s.userRepo.DecrementTotalFavorited(video.AuthorID)
+// Unfavorite 取消点赞（任意类型的表态）
+func (s *FavoriteService) Unfavorite(ctx context.Context, userID, videoID int64) (int64, error) {
+	video, _ := s.videoRepo.GetByID(ctx, videoID)
+	err := s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		fav, err := s.favoriteRepo.Get(ctx, userID, videoID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFavorited
+			}
+			return err
+		}
+		deleted, err := s.favoriteRepo.Delete(ctx, userID, videoID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return ErrNotFavorited
+		}
+
+		if err := s.videoRepo.DecrementFavoriteCount(ctx, videoID, fav.Reaction); err != nil {
+			return err
+		}
+		if err := s.statRepo.AddLikes(ctx, videoID, -1); err != nil {
+			return err
+		}
+		if err := s.userRepo.DecrementFavoriteCount(ctx, userID); err != nil {
+			return err
+		}
+		if video != nil {
+			return s.userRepo.DecrementTotalFavorited(ctx, video.AuthorID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	totalFav, _ := s.favoriteRepo.CountByVideo(videoID)
-	return totalFav, nil
+	return s.favoriteCount(ctx, videoID), nil
 }
 
-// GetStatus 查询点赞状态
-func (s *FavoriteService) GetStatus(userID, videoID int64) (bool, int64, error) {
-	if _, err := s.videoRepo.GetByID(videoID); err != nil {
+// GetStatus 查询当前用户的表态状态及视频各类型表态数
+func (s *FavoriteService) GetStatus(ctx context.Context, userID, videoID int64) (*dto.FavoriteStatus, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, 0, ErrVideoNotFound
+			return nil, ErrVideoNotFound
 		}
-		return false, 0, err
+		return nil, err
 	}
 
-	isFav, err := s.favoriteRepo.Exists(userID, videoID)
-	if err != nil {
-		return false, 0, err
+	status := &dto.FavoriteStatus{
+		VideoID:        videoID,
+		TotalFavorites: video.FavoriteCount,
+		Reactions:      toReactionCounts(video),
+	}
+	fav, err := s.favoriteRepo.Get(ctx, userID, videoID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
 	}
+	if fav != nil {
+		status.IsFavorited = true
+		status.Reaction = fav.Reaction
+	}
+	return status, nil
+}
 
-	total, _ := s.favoriteRepo.CountByVideo(videoID)
-	return isFav, total, nil
+// favoriteCount 点赞、取消点赞后视频的点赞数（videos.favorite_count，与视频详情、列表中的口径一致）
+func (s *FavoriteService) favoriteCount(ctx context.Context, videoID int64) int64 {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return 0
+	}
+	return video.FavoriteCount
 }
 
 // ListByUser 获取用户点赞列表
-func (s *FavoriteService) ListByUser(userID int64, page, pageSize int) (*dto.FavoriteListData, error) {
+func (s *FavoriteService) ListByUser(ctx context.Context, userID int64, page, pageSize int) (*dto.FavoriteListData, error) {
 	skip := (page - 1) * pageSize
-	favorites, total, err := s.favoriteRepo.ListByUser(userID, skip, pageSize)
+	favorites, total, err := s.favoriteRepo.ListByUser(ctx, userID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -105,8 +215,8 @@ func (s *FavoriteService) ListByUser(userID int64, page, pageSize int) (*dto.Fav
 }
 
 // ListByVideo 获取视频点赞列表
-func (s *FavoriteService) ListByVideo(videoID int64, page, pageSize int) (*dto.FavoriteListData, error) {
-	if _, err := s.videoRepo.GetByID(videoID); err != nil {
+func (s *FavoriteService) ListByVideo(ctx context.Context, videoID int64, page, pageSize int) (*dto.FavoriteListData, error) {
+	if _, err := s.videoRepo.GetByID(ctx, videoID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrVideoNotFound
 		}
@@ -114,7 +224,7 @@ func (s *FavoriteService) ListByVideo(videoID int64, page, pageSize int) (*dto.F
 	}
 
 	skip := (page - 1) * pageSize
-	favorites, total, err := s.favoriteRepo.ListByVideo(videoID, skip, pageSize)
+	favorites, total, err := s.favoriteRepo.ListByVideo(ctx, videoID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -122,30 +232,33 @@ func (s *FavoriteService) ListByVideo(videoID int64, page, pageSize int) (*dto.F
 }
 
 // BatchCheckStatus 批量查询点赞状态
-func (s *FavoriteService) BatchCheckStatus(userID int64, videoIDs []int64) (map[int64]bool, error) {
-	return s.favoriteRepo.BatchCheckFavorited(userID, videoIDs)
+func (s *FavoriteService) BatchCheckStatus(ctx context.Context, userID int64, videoIDs []int64) (map[int64]bool, error) {
+	return s.favoriteRepo.BatchCheckFavorited(ctx, userID, videoIDs)
 }
 
 // GetFavoritedVideoIDs 获取用户点赞的视频 ID 列表
-func (s *FavoriteService) GetFavoritedVideoIDs(userID int64, page, pageSize int) ([]int64, int64, error) {
+func (s *FavoriteService) GetFavoritedVideoIDs(ctx context.Context, userID int64, page, pageSize int) ([]int64, int64, error) {
 	skip := (page - 1) * pageSize
-	return s.favoriteRepo.GetFavoritedVideoIDs(userID, skip, pageSize)
+	return s.favoriteRepo.GetFavoritedVideoIDs(ctx, userID, skip, pageSize)
 }
 
 // GetFavoritedVideos 获取用户点赞的视频详情列表
-func (s *FavoriteService) GetFavoritedVideos(userID int64, page, pageSize int) (*dto.VideoListData, error) {
+func (s *FavoriteService) GetFavoritedVideos(ctx context.Context, userID int64, page, pageSize int) (*dto.VideoListData, error) {
 	skip := (page - 1) * pageSize
-	videoIDs, total, err := s.favoriteRepo.GetFavoritedVideoIDs(userID, skip, pageSize)
+	videoIDs, total, err := s.favoriteRepo.GetFavoritedVideoIDs(ctx, userID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 	if len(videoIDs) == 0 {
 		return &dto.VideoListData{Videos: []dto.VideoInfo{}, Total: total, Page: page, PageSize: pageSize}, nil
 	}
-	videos, err := s.videoRepo.GetByIDsWithAuthor(videoIDs)
+	videos, err := s.videoRepo.GetByIDsWithAuthor(ctx, videoIDs)
 	if err != nil {
 		return nil, err
 	}
+	if videos, err = s.viewableVideos(ctx, userID, videos); err != nil {
+		return nil, err
+	}
 	items := make([]dto.VideoInfo, 0, len(videos))
 	for i := range videos {
 		info := dto.VideoInfo{
@@ -154,7 +267,11 @@ func (s *FavoriteService) GetFavoritedVideos(userID int64, page, pageSize int) (
 			PlayURL: videos[i].PlayURL, CoverURL: videos[i].CoverURL,
 			Status: videos[i].Status, ViewCount: videos[i].ViewCount,
 			FavoriteCount: videos[i].FavoriteCount, CommentCount: videos[i].CommentCount,
+			Reactions: toReactionCounts(&videos[i]),
 			CreatedAt: videos[i].CreatedAt,
+
+			MembersOnlyTierID: videos[i].MembersOnlyTierID,
+			AgeRestricted:     videos[i].AgeRestricted(),
 		}
 		if videos[i].Author.ID != 0 {
 			info.Author = &dto.AuthorBrief{
@@ -163,15 +280,85 @@ func (s *FavoriteService) GetFavoritedVideos(userID int64, page, pageSize int) (
 		}
 		items = append(items, info)
 	}
+	// 点赞后视频被设置年龄限制，或会员到期、等级不够的会员专属视频，不再返回播放地址
+	if err := applyContentGate(ctx, s.userRepo, s.membershipRepo, userID, items); err != nil {
+		return nil, err
+	}
 	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 	return &dto.VideoListData{Videos: items, Total: total, Page: page, PageSize: pageSize, TotalPages: totalPages}, nil
 }
 
+// ListFavoritedVideosByCursor 游标分页获取用户点赞的视频（按点赞时间倒序）
+func (s *FavoriteService) ListFavoritedVideosByCursor(ctx context.Context, userID int64, after string, limit int) (*dto.CursorList[dto.VideoInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	favorites, err := s.favoriteRepo.ListByUserBefore(ctx, userID, c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	favorites, hasMore := trimPage(favorites, limit)
+
+	videoIDs := make([]int64, 0, len(favorites))
+	for i := range favorites {
+		videoIDs = append(videoIDs, favorites[i].VideoID)
+	}
+	videos, err := s.videoRepo.GetByIDsWithAuthor(ctx, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	if videos, err = s.viewableVideos(ctx, userID, videos); err != nil {
+		return nil, err
+	}
+
+	list := &dto.CursorList[dto.VideoInfo]{Items: make([]dto.VideoInfo, 0, len(videos)), HasMore: hasMore}
+	for i := range videos {
+		list.Items = append(list.Items, *toVideoInfo(&videos[i], true))
+	}
+	if err := applyContentGate(ctx, s.userRepo, s.membershipRepo, userID, list.Items); err != nil {
+		return nil, err
+	}
+	if hasMore {
+		list.NextCursor = cursor.Encode(cursor.New(favorites[len(favorites)-1].ID))
+	}
+	return list, nil
+}
+
+// ListByVideoByCursor 游标分页获取视频的点赞记录
+func (s *FavoriteService) ListByVideoByCursor(ctx context.Context, videoID int64, after string, limit int) (*dto.CursorList[dto.FavoriteInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.videoRepo.GetByID(ctx, videoID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	favorites, err := s.favoriteRepo.ListByVideoBefore(ctx, videoID, c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	favorites, hasMore := trimPage(favorites, limit)
+
+	list := &dto.CursorList[dto.FavoriteInfo]{Items: make([]dto.FavoriteInfo, 0, len(favorites)), HasMore: hasMore}
+	for i := range favorites {
+		list.Items = append(list.Items, *toFavoriteInfo(&favorites[i]))
+	}
+	if hasMore {
+		list.NextCursor = cursor.Encode(cursor.New(favorites[len(favorites)-1].ID))
+	}
+	return list, nil
+}
+
 func toFavoriteInfo(f *model.Favorite) *dto.FavoriteInfo {
 	return &dto.FavoriteInfo{
 		ID:        f.ID,
 		UserID:    f.UserID,
 		VideoID:   f.VideoID,
+		Reaction:  f.Reaction,
 		CreatedAt: f.CreatedAt,
 	}
 }
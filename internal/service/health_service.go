@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/infra/database"
+	infraES "vida-go/internal/infra/elasticsearch"
+	infraKafka "vida-go/internal/infra/kafka"
+	infraMinio "vida-go/internal/infra/minio"
+	infraRedis "vida-go/internal/infra/redis"
+)
+
+const (
+	ReadinessReady    = "ready"
+	ReadinessDegraded = "degraded"
+	ReadinessNotReady = "not_ready"
+
+	healthCheckTimeout = 2 * time.Second
+)
+
+// dependencyCheck 依赖检查项
+// critical 为 true 时该依赖不可用会导致服务整体不可用；否则仅标记对应功能降级
+type dependencyCheck struct {
+	name     string
+	critical bool
+	degraded string
+	ping     func(ctx context.Context) error
+}
+
+type HealthService struct {
+	checks []dependencyCheck
+}
+
+func NewHealthService() *HealthService {
+	return &HealthService{
+		checks: []dependencyCheck{
+			{name: "postgres", critical: true, ping: database.Ping},
+			{name: "redis", degraded: "cache degraded", ping: infraRedis.Ping},
+			{name: "minio", degraded: "upload degraded", ping: infraMinio.Ping},
+			{name: "kafka", degraded: "upload degraded", ping: infraKafka.Ping},
+			{name: "elasticsearch", degraded: "search degraded", ping: infraES.Ping},
+		},
+	}
+}
+
+// Readiness 并发检查所有依赖，汇总就绪状态与降级项
+func (s *HealthService) Readiness(ctx context.Context) *dto.ReadinessData {
+	results := make([]dto.DependencyStatus, len(s.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func(i int, check dependencyCheck) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	status := ReadinessReady
+	degraded := make([]string, 0)
+	seen := make(map[string]bool)
+	for i, r := range results {
+		if r.Status == "up" {
+			continue
+		}
+		if s.checks[i].critical {
+			status = ReadinessNotReady
+			continue
+		}
+		if status == ReadinessReady {
+			status = ReadinessDegraded
+		}
+		if flag := s.checks[i].degraded; flag != "" && !seen[flag] {
+			seen[flag] = true
+			degraded = append(degraded, flag)
+		}
+	}
+
+	return &dto.ReadinessData{
+		Status:       status,
+		Degraded:     degraded,
+		Dependencies: results,
+		Timestamp:    time.Now().Format(time.RFC3339),
+	}
+}
+
+func runCheck(ctx context.Context, check dependencyCheck) dto.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.ping(ctx)
+	result := dto.DependencyStatus{
+		Name:      check.name,
+		Status:    "up",
+		Critical:  check.critical,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = "down"
+		result.Error = err.Error()
+	}
+	return result
+}
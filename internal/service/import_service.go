@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+)
+
+// ImportService 从其他平台迁移数据：批量导入关注关系与点赞记录。
+// 导入不发送通知、不计入每日统计，导入后按明细表重新计算相关计数
+type ImportService struct {
+	relationRepo *repository.RelationRepository
+	favoriteRepo *repository.FavoriteRepository
+	userRepo     *repository.UserRepository
+	videoRepo    *repository.VideoRepository
+	txManager    *repository.TxManager
+}
+
+func NewImportService(
+	relationRepo *repository.RelationRepository,
+	favoriteRepo *repository.FavoriteRepository,
+	userRepo *repository.UserRepository,
+	videoRepo *repository.VideoRepository,
+	txManager *repository.TxManager,
+) *ImportService {
+	return &ImportService{
+		relationRepo: relationRepo,
+		favoriteRepo: favoriteRepo,
+		userRepo:     userRepo,
+		videoRepo:    videoRepo,
+		txManager:    txManager,
+	}
+}
+
+// ImportRelations 批量导入关注关系，跳过关注自己、用户不存在或已删除的记录，
+// 已存在的关系不重复写入；导入后重新计算相关用户的关注数、粉丝数
+func (s *ImportService) ImportRelations(ctx context.Context, items []dto.RelationImportItem) (*dto.ImportResult, error) {
+	ids := make([]int64, 0, len(items)*2)
+	for _, item := range items {
+		ids = append(ids, item.FollowerID, item.FollowID)
+	}
+	existing, err := s.userRepo.ExistingIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[int64]bool, len(existing))
+	for _, id := range existing {
+		users[id] = true
+	}
+
+	type key struct{ follower, follow int64 }
+	seen := make(map[key]bool, len(items))
+	affected := make(map[int64]bool)
+	relations := make([]model.Relation, 0, len(items))
+	for _, item := range items {
+		k := key{item.FollowerID, item.FollowID}
+		if k.follower == k.follow || !users[k.follower] || !users[k.follow] || seen[k] {
+			continue
+		}
+		seen[k] = true
+		affected[k.follower] = true
+		affected[k.follow] = true
+		relations = append(relations, model.Relation{
+			FollowerID: k.follower,
+			FollowID:   k.follow,
+			CreatedAt:  importTime(item.CreatedAt),
+		})
+	}
+
+	var imported int64
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if imported, err = s.relationRepo.BulkCreate(ctx, relations); err != nil {
+			return err
+		}
+		_, err = s.userRepo.RecountFollowCounts(ctx, slices.Collect(maps.Keys(affected)))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newImportResult(len(items), imported), nil
+}
+
+// ImportFavorites 批量导入点赞记录，跳过用户或视频不存在、已删除的记录，
+// 已存在的记录不重复写入；导入后重新计算相关视频的点赞数与作者的获赞数
+func (s *ImportService) ImportFavorites(ctx context.Context, items []dto.FavoriteImportItem) (*dto.ImportResult, error) {
+	userIDs := make([]int64, 0, len(items))
+	videoIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		userIDs = append(userIDs, item.UserID)
+		videoIDs = append(videoIDs, item.VideoID)
+	}
+	existing, err := s.userRepo.ExistingIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[int64]bool, len(existing))
+	for _, id := range existing {
+		users[id] = true
+	}
+	authors, err := s.videoRepo.GetAuthorIDs(ctx, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ user, video int64 }
+	seen := make(map[key]bool, len(items))
+	affectedVideos := make(map[int64]bool)
+	affectedAuthors := make(map[int64]bool)
+	affectedUsers := make(map[int64]bool)
+	favorites := make([]model.Favorite, 0, len(items))
+	for _, item := range items {
+		k := key{item.UserID, item.VideoID}
+		authorID, ok := authors[k.video]
+		if !ok || !users[k.user] || seen[k] {
+			continue
+		}
+		seen[k] = true
+		affectedVideos[k.video] = true
+		affectedAuthors[authorID] = true
+		affectedUsers[k.user] = true
+		favorites = append(favorites, model.Favorite{
+			UserID:    k.user,
+			VideoID:   k.video,
+			CreatedAt: importTime(item.CreatedAt),
+		})
+	}
+
+	var imported int64
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if imported, err = s.favoriteRepo.BulkCreate(ctx, favorites); err != nil {
+			return err
+		}
+		if _, err := s.videoRepo.RecountFavoriteCounts(ctx, slices.Collect(maps.Keys(affectedVideos))); err != nil {
+			return err
+		}
+		if _, err := s.userRepo.RecountTotalFavorited(ctx, slices.Collect(maps.Keys(affectedAuthors))); err != nil {
+			return err
+		}
+		_, err = s.userRepo.RecountFavoriteCounts(ctx, slices.Collect(maps.Keys(affectedUsers)))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newImportResult(len(items), imported), nil
+}
+
+func newImportResult(total int, imported int64) *dto.ImportResult {
+	return &dto.ImportResult{
+		Total:    total,
+		Imported: imported,
+		Skipped:  int64(total) - imported,
+	}
+}
+
+// importTime 原平台记录的时间，未提供时返回零值（写入时取当前时间）
+func importTime(unix int64) time.Time {
+	if unix <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
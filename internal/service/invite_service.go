@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInviteCodeRequired  = errors.New("当前仅支持邀请注册，请填写邀请码")
+	ErrInvalidInviteCode   = errors.New("邀请码无效或已失效")
+	ErrInviteCodeNotFound  = errors.New("邀请码不存在")
+	ErrInviteCodeRevoked   = errors.New("邀请码已作废")
+	ErrInvalidInviteStatus = errors.New("无效的邀请码状态")
+	ErrInviterNotFound     = errors.New("邀请人不存在")
+)
+
+const (
+	inviteCodeAlphabet      = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 去掉易混淆的 0/O、1/I
+	inviteCodeLength        = 10
+	inviteCodeDefaultMaxUse = 1
+)
+
+// InviteService 注册邀请码：管理员批量生成、作废邀请码，注册时校验并计数，记录邀请关系
+type InviteService struct {
+	inviteRepo *repository.InviteCodeRepository
+	userRepo   *repository.UserRepository
+}
+
+func NewInviteService(inviteRepo *repository.InviteCodeRepository, userRepo *repository.UserRepository) *InviteService {
+	return &InviteService{inviteRepo: inviteRepo, userRepo: userRepo}
+}
+
+// CreateCodes 批量生成邀请码，邀请人默认为当前管理员
+func (s *InviteService) CreateCodes(ctx context.Context, adminID int64, req *dto.InviteCodeCreateRequest) ([]dto.InviteCodeInfo, error) {
+	inviterID := adminID
+	if req.InviterID != 0 {
+		inviterID = req.InviterID
+	}
+	inviter, err := s.userRepo.GetByID(ctx, inviterID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInviterNotFound
+		}
+		return nil, err
+	}
+
+	count := max(req.Count, 1)
+	maxUses := inviteCodeDefaultMaxUse
+	if req.MaxUses != nil {
+		maxUses = *req.MaxUses
+	}
+	var expiresAt *time.Time
+	if req.ExpiresInHours > 0 {
+		t := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		expiresAt = &t
+	}
+
+	codes := make([]model.InviteCode, count)
+	for i := range codes {
+		code, err := generateInviteCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = model.InviteCode{
+			Code:      code,
+			InviterID: inviter.ID,
+			CreatedBy: adminID,
+			MaxUses:   maxUses,
+			Note:      req.Note,
+			ExpiresAt: expiresAt,
+		}
+	}
+	if err := s.inviteRepo.CreateBatch(ctx, codes); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	items := make([]dto.InviteCodeInfo, 0, len(codes))
+	for i := range codes {
+		codes[i].Inviter = *inviter
+		items = append(items, toInviteCodeInfo(&codes[i], now))
+	}
+	return items, nil
+}
+
+// ListCodes 分页获取邀请码
+func (s *InviteService) ListCodes(ctx context.Context, filter *repository.InviteCodeFilter, page, pageSize int) (*dto.InviteCodeListData, error) {
+	switch filter.Status {
+	case "", repository.InviteCodeStatusActive, repository.InviteCodeStatusUsedUp,
+		repository.InviteCodeStatusExpired, repository.InviteCodeStatusRevoked:
+	default:
+		return nil, ErrInvalidInviteStatus
+	}
+
+	skip := (page - 1) * pageSize
+	codes, total, err := s.inviteRepo.List(ctx, filter, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	items := make([]dto.InviteCodeInfo, 0, len(codes))
+	for i := range codes {
+		items = append(items, toInviteCodeInfo(&codes[i], now))
+	}
+
+	return &dto.InviteCodeListData{
+		Codes:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// RevokeCode 作废邀请码，已使用该码注册的用户不受影响
+func (s *InviteService) RevokeCode(ctx context.Context, codeID int64) error {
+	if _, err := s.inviteRepo.GetByID(ctx, codeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInviteCodeNotFound
+		}
+		return err
+	}
+	revoked, err := s.inviteRepo.Revoke(ctx, codeID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrInviteCodeRevoked
+	}
+	return nil
+}
+
+// ListInvitees 分页获取由该用户邀请注册的用户
+func (s *InviteService) ListInvitees(ctx context.Context, inviterID int64, page, pageSize int) (*dto.InviteeListData, error) {
+	if _, err := s.userRepo.GetByIDIncludeDeleted(ctx, inviterID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	skip := (page - 1) * pageSize
+	users, total, err := s.userRepo.ListInvitees(ctx, inviterID, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.InviteeInfo, 0, len(users))
+	for i := range users {
+		u := &users[i]
+		info := dto.InviteeInfo{
+			UserBriefInfo: toUserBriefInfo(u),
+			Deleted:       u.DeletedAt.Valid,
+		}
+		if u.InviteCodeID != nil {
+			info.InviteCodeID = *u.InviteCodeID
+		}
+		items = append(items, info)
+	}
+
+	return &dto.InviteeListData{
+		Users:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// redeem 注册时使用邀请码，需与创建用户在同一事务中调用
+func (s *InviteService) redeem(ctx context.Context, code string) (*model.InviteCode, error) {
+	invite, err := s.inviteRepo.GetByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidInviteCode
+		}
+		return nil, err
+	}
+	consumed, err := s.inviteRepo.Consume(ctx, invite.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidInviteCode
+	}
+	return invite, nil
+}
+
+func generateInviteCode() (string, error) {
+	var b strings.Builder
+	n := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for range inviteCodeLength {
+		i, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(inviteCodeAlphabet[i.Int64()])
+	}
+	return b.String(), nil
+}
+
+func inviteCodeStatus(code *model.InviteCode, now time.Time) string {
+	switch {
+	case code.RevokedAt != nil:
+		return repository.InviteCodeStatusRevoked
+	case code.ExpiresAt != nil && !now.Before(*code.ExpiresAt):
+		return repository.InviteCodeStatusExpired
+	case code.MaxUses > 0 && code.UsedCount >= code.MaxUses:
+		return repository.InviteCodeStatusUsedUp
+	}
+	return repository.InviteCodeStatusActive
+}
+
+func toInviteCodeInfo(code *model.InviteCode, now time.Time) dto.InviteCodeInfo {
+	return dto.InviteCodeInfo{
+		ID:        code.ID,
+		Code:      code.Code,
+		Inviter:   toUserBriefInfo(&code.Inviter),
+		CreatedBy: code.CreatedBy,
+		MaxUses:   code.MaxUses,
+		UsedCount: code.UsedCount,
+		Status:    inviteCodeStatus(code, now),
+		Note:      code.Note,
+		ExpiresAt: code.ExpiresAt,
+		RevokedAt: code.RevokedAt,
+		CreatedAt: code.CreatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/scheduler"
+)
+
+// JobService 后台定时任务的运行状态查询
+type JobService struct {
+	scheduler *scheduler.Scheduler
+}
+
+func NewJobService(scheduler *scheduler.Scheduler) *JobService {
+	return &JobService{scheduler: scheduler}
+}
+
+// List 按注册顺序返回本实例启用的定时任务及其在集群中最近一次执行的情况
+func (s *JobService) List(ctx context.Context) (*dto.JobListData, error) {
+	statuses, err := s.scheduler.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]dto.JobInfo, 0, len(statuses))
+	for _, st := range statuses {
+		info := dto.JobInfo{Name: st.Name, Schedule: st.Spec, NextRunAt: st.NextRunAt, Running: st.Running}
+		if run := st.LastRun; run != nil {
+			info.LastRun = &dto.JobRunInfo{
+				Instance:   run.Instance,
+				Status:     run.Status,
+				StartedAt:  run.StartedAt,
+				FinishedAt: run.FinishedAt,
+				DurationMs: run.DurationMs,
+				Error:      run.Error,
+			}
+		}
+		jobs = append(jobs, info)
+	}
+	return &dto.JobListData{Jobs: jobs}, nil
+}
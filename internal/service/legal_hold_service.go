@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"vida-go/internal/api/dto"
+	infraES "vida-go/internal/infra/elasticsearch"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrLegalHold            = errors.New("内容处于法律保全中，无法修改或删除")
+	ErrAlreadyOnLegalHold   = errors.New("已处于法律保全中")
+	ErrNotOnLegalHold       = errors.New("未处于法律保全中")
+	ErrInvalidLegalHoldType = errors.New("无效的保全对象类型")
+)
+
+// 法律保全对象类型
+const (
+	LegalHoldTargetVideo = "video"
+	LegalHoldTargetUser  = "user"
+)
+
+// LegalHoldService 法律保全：为合规调查冻结视频或账号，内容保留、对外隐藏，
+// 作者和用户本人不能修改或删除，直到管理员解除
+type LegalHoldService struct {
+	videoRepo *repository.VideoRepository
+	userRepo  *repository.UserRepository
+	userCache *UserCache
+}
+
+func NewLegalHoldService(videoRepo *repository.VideoRepository, userRepo *repository.UserRepository, userCache *UserCache) *LegalHoldService {
+	return &LegalHoldService{videoRepo: videoRepo, userRepo: userRepo, userCache: userCache}
+}
+
+// HoldVideo 保全视频，并从搜索索引中移除
+func (s *LegalHoldService) HoldVideo(ctx context.Context, videoID int64, reason string) (*dto.LegalHoldInfo, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.LegalHoldAt != nil {
+		return nil, ErrAlreadyOnLegalHold
+	}
+
+	video, err = s.videoRepo.Update(ctx, videoID, map[string]interface{}{"legal_hold_at": time.Now(), "legal_hold_reason": reason})
+	if err != nil {
+		return nil, err
+	}
+	if err := infraES.DeleteVideo(ctx, videoID); err != nil {
+		logger.FromContext(ctx).Warn("Remove held video from ES failed", zap.Int64("video_id", videoID), zap.Error(err))
+	}
+	return toVideoLegalHoldInfo(video), nil
+}
+
+// ReleaseVideo 解除视频保全，已发布的公开视频重新写入搜索索引
+func (s *LegalHoldService) ReleaseVideo(ctx context.Context, videoID int64) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+	if video.LegalHoldAt == nil {
+		return ErrNotOnLegalHold
+	}
+
+	if _, err := s.videoRepo.Update(ctx, videoID, map[string]interface{}{"legal_hold_at": nil, "legal_hold_reason": ""}); err != nil {
+		return err
+	}
+	if video.Status == "published" && !video.IsPrivate() {
+		if video, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID); err == nil {
+			if err := infraES.SyncVideo(ctx, video, video.Author.UserName); err != nil {
+				logger.FromContext(ctx).Warn("Sync released video to ES failed", zap.Int64("video_id", videoID), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+// HoldUser 保全账号：主页对外隐藏并从用户搜索中移除，账号及其视频、评论不可删除
+func (s *LegalHoldService) HoldUser(ctx context.Context, userID int64, reason string) (*dto.LegalHoldInfo, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.LegalHoldAt != nil {
+		return nil, ErrAlreadyOnLegalHold
+	}
+
+	user, err = s.userRepo.Update(ctx, userID, map[string]interface{}{"legal_hold_at": time.Now(), "legal_hold_reason": reason})
+	if err != nil {
+		return nil, err
+	}
+	s.userCache.Invalidate(ctx, userID)
+	removeUserFromES(ctx, userID)
+	return toUserLegalHoldInfo(user), nil
+}
+
+// ReleaseUser 解除账号保全
+func (s *LegalHoldService) ReleaseUser(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if user.LegalHoldAt == nil {
+		return ErrNotOnLegalHold
+	}
+
+	user, err = s.userRepo.Update(ctx, userID, map[string]interface{}{"legal_hold_at": nil, "legal_hold_reason": ""})
+	if err != nil {
+		return err
+	}
+	s.userCache.Invalidate(ctx, userID)
+	syncUserToES(ctx, user)
+	return nil
+}
+
+// ListLegalHolds 分页获取处于保全中的视频或账号
+func (s *LegalHoldService) ListLegalHolds(ctx context.Context, targetType string, page, pageSize int) (*dto.LegalHoldListData, error) {
+	skip := (page - 1) * pageSize
+	var (
+		items []dto.LegalHoldInfo
+		total int64
+	)
+	switch targetType {
+	case LegalHoldTargetVideo:
+		videos, n, err := s.videoRepo.ListLegalHolds(ctx, skip, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		total = n
+		items = make([]dto.LegalHoldInfo, 0, len(videos))
+		for i := range videos {
+			items = append(items, *toVideoLegalHoldInfo(&videos[i]))
+		}
+	case LegalHoldTargetUser:
+		users, n, err := s.userRepo.ListLegalHolds(ctx, skip, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		total = n
+		items = make([]dto.LegalHoldInfo, 0, len(users))
+		for i := range users {
+			items = append(items, *toUserLegalHoldInfo(&users[i]))
+		}
+	default:
+		return nil, ErrInvalidLegalHoldType
+	}
+
+	return &dto.LegalHoldListData{
+		Holds:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// checkVideoLegalHold 视频或其作者处于保全中时返回 ErrLegalHold
+func checkVideoLegalHold(ctx context.Context, userRepo *repository.UserRepository, video *model.Video) error {
+	if video.LegalHoldAt != nil {
+		return ErrLegalHold
+	}
+	return checkUserLegalHold(ctx, userRepo, video.AuthorID)
+}
+
+// checkUserLegalHold 账号处于保全中时返回 ErrLegalHold
+func checkUserLegalHold(ctx context.Context, userRepo *repository.UserRepository, userID int64) error {
+	user, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.LegalHoldAt != nil {
+		return ErrLegalHold
+	}
+	return nil
+}
+
+func toVideoLegalHoldInfo(video *model.Video) *dto.LegalHoldInfo {
+	info := &dto.LegalHoldInfo{
+		TargetType: LegalHoldTargetVideo,
+		TargetID:   video.ID,
+		Title:      video.Title,
+		AuthorID:   video.AuthorID,
+		Reason:     video.LegalHoldReason,
+	}
+	if video.LegalHoldAt != nil {
+		info.HeldAt = *video.LegalHoldAt
+	}
+	return info
+}
+
+func toUserLegalHoldInfo(user *model.User) *dto.LegalHoldInfo {
+	info := &dto.LegalHoldInfo{
+		TargetType: LegalHoldTargetUser,
+		TargetID:   user.ID,
+		Title:      user.UserName,
+		Reason:     user.LegalHoldReason,
+	}
+	if user.LegalHoldAt != nil {
+		info.HeldAt = *user.LegalHoldAt
+	}
+	return info
+}
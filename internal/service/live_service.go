@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidStreamKey    = errors.New("推流码无效")
+	ErrLiveSessionNotFound = errors.New("直播不存在")
+)
+
+// LiveService 直播：为用户签发推流码，处理媒体服务器的开播、下播、录制完成回调，
+// 维护直播场次，下播后将录像转码为普通视频
+type LiveService struct {
+	channelRepo  *repository.LiveChannelRepository
+	sessionRepo  *repository.LiveSessionRepository
+	userRepo     *repository.UserRepository
+	videoService *VideoService
+}
+
+func NewLiveService(
+	channelRepo *repository.LiveChannelRepository,
+	sessionRepo *repository.LiveSessionRepository,
+	userRepo *repository.UserRepository,
+	videoService *VideoService,
+) *LiveService {
+	return &LiveService{
+		channelRepo:  channelRepo,
+		sessionRepo:  sessionRepo,
+		userRepo:     userRepo,
+		videoService: videoService,
+	}
+}
+
+// GetChannel 获取当前用户的直播频道，首次获取时创建并签发推流码
+func (s *LiveService) GetChannel(ctx context.Context, userID int64) (*dto.LiveChannelInfo, error) {
+	channel, err := s.getOrCreateChannel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toLiveChannelInfo(channel), nil
+}
+
+// UpdateChannel 修改直播标题，下次开播生效
+func (s *LiveService) UpdateChannel(ctx context.Context, userID int64, req *dto.LiveChannelUpdateRequest) (*dto.LiveChannelInfo, error) {
+	channel, err := s.getOrCreateChannel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(req.Title)
+	if err := s.channelRepo.Update(ctx, channel.ID, map[string]interface{}{"title": title}); err != nil {
+		return nil, err
+	}
+	channel.Title = title
+	return toLiveChannelInfo(channel), nil
+}
+
+// RotateStreamKey 重置推流码，旧推流码立即失效（不影响正在进行的直播）
+func (s *LiveService) RotateStreamKey(ctx context.Context, userID int64) (*dto.LiveChannelInfo, error) {
+	channel, err := s.getOrCreateChannel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.channelRepo.Update(ctx, channel.ID, map[string]interface{}{"stream_key": key}); err != nil {
+		return nil, err
+	}
+	channel.StreamKey = key
+	return toLiveChannelInfo(channel), nil
+}
+
+func (s *LiveService) getOrCreateChannel(ctx context.Context, userID int64) (*model.LiveChannel, error) {
+	channel, err := s.channelRepo.GetByUser(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.createChannel(ctx, userID)
+	}
+	return channel, err
+}
+
+func (s *LiveService) createChannel(ctx context.Context, userID int64) (*model.LiveChannel, error) {
+	key, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+	playbackID, err := randomHex(12)
+	if err != nil {
+		return nil, err
+	}
+	channel := &model.LiveChannel{UserID: userID, StreamKey: key, PlaybackID: playbackID}
+	if err := s.channelRepo.Create(ctx, channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// OnPublish 开播回调：校验推流码与主播状态（封禁、禁言时拒绝推流），创建直播场次。
+// 上一场未收到下播回调时先将其结束
+func (s *LiveService) OnPublish(ctx context.Context, req *dto.LiveCallbackRequest) error {
+	channel, err := s.channelByKey(ctx, req.StreamKey())
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetByID(ctx, channel.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidStreamKey
+		}
+		return err
+	}
+	if err := checkSuspended(user); err != nil {
+		return err
+	}
+	if restrictionActive(user.MutedUntil) {
+		return &RestrictionError{Kind: ErrUserMuted, Until: *user.MutedUntil, Reason: user.MuteReason}
+	}
+
+	now := time.Now()
+	if _, err := s.sessionRepo.EndActive(ctx, channel.ID, now); err != nil {
+		return err
+	}
+	session := &model.LiveSession{
+		ChannelID:  channel.ID,
+		UserID:     channel.UserID,
+		Title:      channel.Title,
+		PlaybackID: channel.PlaybackID,
+		Status:     model.LiveSessionLive,
+		ClientIP:   req.ClientIP(),
+		StartedAt:  now,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Live session started", zap.Int64("session_id", session.ID), zap.Int64("user_id", channel.UserID))
+	return nil
+}
+
+// OnUnpublish 下播回调：结束频道正在进行的场次
+func (s *LiveService) OnUnpublish(ctx context.Context, req *dto.LiveCallbackRequest) error {
+	channel, err := s.channelByKey(ctx, req.StreamKey())
+	if err != nil {
+		return err
+	}
+	_, err = s.sessionRepo.EndActive(ctx, channel.ID, time.Now())
+	return err
+}
+
+// OnRecord 录制完成回调：将录像创建为视频并投递转码，关联到最近一场尚未生成视频的场次
+func (s *LiveService) OnRecord(ctx context.Context, req *dto.LiveCallbackRequest) error {
+	channel, err := s.channelByKey(ctx, req.StreamKey())
+	if err != nil {
+		return err
+	}
+	recording := strings.TrimPrefix(req.Recording(), "/")
+	if !config.GetLive().AutoVOD || recording == "" {
+		return nil
+	}
+
+	session, err := s.sessionRepo.GetLatestWithoutVideo(ctx, channel.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLiveSessionNotFound
+		}
+		return err
+	}
+
+	title := session.Title
+	if title == "" {
+		title = "直播回放 " + session.StartedAt.Format("2006-01-02 15:04")
+	}
+	video, err := s.videoService.CreateFromRecording(ctx, session.UserID, title, recording)
+	if err != nil {
+		return err
+	}
+	if _, err := s.sessionRepo.SetVideo(ctx, session.ID, video.ID); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Live recording submitted for transcoding",
+		zap.Int64("session_id", session.ID), zap.Int64("video_id", video.ID))
+	return nil
+}
+
+// Feed 分页获取正在直播的场次
+func (s *LiveService) Feed(ctx context.Context, page, pageSize int) (*dto.LiveSessionListData, error) {
+	skip := (page - 1) * pageSize
+	sessions, total, err := s.sessionRepo.ListLive(ctx, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.LiveSessionInfo, 0, len(sessions))
+	for i := range sessions {
+		items = append(items, toLiveSessionInfo(&sessions[i]))
+	}
+
+	return &dto.LiveSessionListData{
+		Sessions:   items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// GetSession 获取直播场次，主播已删除或处于法律保全时不可见
+func (s *LiveService) GetSession(ctx context.Context, sessionID int64) (*dto.LiveSessionInfo, error) {
+	session, err := s.sessionRepo.GetByIDWithUser(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLiveSessionNotFound
+		}
+		return nil, err
+	}
+	if session.User.ID == 0 || session.User.LegalHoldAt != nil {
+		return nil, ErrLiveSessionNotFound
+	}
+	info := toLiveSessionInfo(session)
+	return &info, nil
+}
+
+func (s *LiveService) channelByKey(ctx context.Context, streamKey string) (*model.LiveChannel, error) {
+	if streamKey == "" {
+		return nil, ErrInvalidStreamKey
+	}
+	channel, err := s.channelRepo.GetByStreamKey(ctx, streamKey)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidStreamKey
+		}
+		return nil, err
+	}
+	return channel, nil
+}
+
+func toLiveChannelInfo(channel *model.LiveChannel) *dto.LiveChannelInfo {
+	cfg := config.GetLive()
+	info := &dto.LiveChannelInfo{
+		Title:      channel.Title,
+		StreamKey:  channel.StreamKey,
+		RTMPURL:    cfg.RTMPURL,
+		PlaybackID: channel.PlaybackID,
+		PlayURL:    livePlayURL(channel.PlaybackID),
+	}
+	if cfg.SRTURLTemplate != "" {
+		info.SRTURL = strings.ReplaceAll(cfg.SRTURLTemplate, "{stream_key}", channel.StreamKey)
+	}
+	return info
+}
+
+func toLiveSessionInfo(session *model.LiveSession) dto.LiveSessionInfo {
+	info := dto.LiveSessionInfo{
+		ID:        session.ID,
+		User:      toUserBriefInfo(&session.User),
+		Title:     session.Title,
+		Status:    session.Status,
+		StartedAt: session.StartedAt,
+		EndedAt:   session.EndedAt,
+		VideoID:   session.VideoID,
+	}
+	if session.Status == model.LiveSessionLive {
+		info.PlayURL = livePlayURL(session.PlaybackID)
+	}
+	return info
+}
+
+func livePlayURL(playbackID string) string {
+	return strings.ReplaceAll(config.GetLive().PlayURLTemplate, "{playback_id}", playbackID)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/infra/geoip"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrLoginEventNotFound   = errors.New("登录记录不存在")
+	ErrLoginAlreadyReported = errors.New("该登录记录已标记为非本人登录")
+)
+
+// LoginHistoryService 登录历史：记录每次登录的 IP、设备与归属地，
+// 新设备或新地点登录时通知用户，用户确认非本人登录后强制修改密码
+type LoginHistoryService struct {
+	loginRepo           *repository.LoginEventRepository
+	userRepo            *repository.UserRepository
+	notificationService *NotificationService
+}
+
+func NewLoginHistoryService(loginRepo *repository.LoginEventRepository, userRepo *repository.UserRepository, notificationService *NotificationService) *LoginHistoryService {
+	return &LoginHistoryService{loginRepo: loginRepo, userRepo: userRepo, notificationService: notificationService}
+}
+
+// Record 记录一次成功登录。首次登录不做比对；之后出现未用过的设备或国家/地区时发送系统通知
+func (s *LoginHistoryService) Record(ctx context.Context, userID int64, client *dto.LoginClient) error {
+	event := &model.LoginEvent{
+		UserID:    userID,
+		IP:        client.IP,
+		UserAgent: truncateRunes(client.UserAgent, 500),
+		Device:    loginDevice(client),
+		Country:   geoip.CountryFromContext(ctx),
+	}
+
+	seen, err := s.loginRepo.HasAny(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if seen {
+		known, err := s.loginRepo.HasDevice(ctx, userID, event.Device)
+		if err != nil {
+			return err
+		}
+		event.NewDevice = !known
+		if event.Country != "" {
+			known, err := s.loginRepo.HasCountry(ctx, userID, event.Country)
+			if err != nil {
+				return err
+			}
+			event.NewLocation = !known
+		}
+	}
+
+	if err := s.loginRepo.Create(ctx, event); err != nil {
+		return err
+	}
+	if event.Suspicious() {
+		s.notificationService.EmitSystem(ctx, userID, suspiciousLoginMessage(event))
+	}
+	return nil
+}
+
+// ListMyLogins 分页获取当前用户的登录记录
+func (s *LoginHistoryService) ListMyLogins(ctx context.Context, userID int64, page, pageSize int) (*dto.LoginEventListData, error) {
+	skip := (page - 1) * pageSize
+	events, total, err := s.loginRepo.ListByUser(ctx, userID, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.LoginEventInfo, 0, len(events))
+	for i := range events {
+		e := &events[i]
+		items = append(items, dto.LoginEventInfo{
+			ID:          e.ID,
+			IP:          e.IP,
+			UserAgent:   e.UserAgent,
+			Country:     e.Country,
+			NewDevice:   e.NewDevice,
+			NewLocation: e.NewLocation,
+			ReportedAt:  e.ReportedAt,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+
+	return &dto.LoginEventListData{
+		Logins:     items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// ReportLogin 用户将某次登录标记为非本人操作，账号需修改密码后才能再次登录
+func (s *LoginHistoryService) ReportLogin(ctx context.Context, userID, eventID int64) error {
+	if _, err := s.loginRepo.GetByUser(ctx, userID, eventID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLoginEventNotFound
+		}
+		return err
+	}
+
+	marked, err := s.loginRepo.MarkReported(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return ErrLoginAlreadyReported
+	}
+
+	_, err = s.userRepo.Update(ctx, userID, map[string]interface{}{"password_reset_required": true})
+	return err
+}
+
+// loginDevice 设备标识：优先使用客户端上报的设备 ID，否则取 User-Agent 摘要
+func loginDevice(client *dto.LoginClient) string {
+	if client.DeviceID != "" {
+		return "id:" + truncateRunes(client.DeviceID, 64)
+	}
+	sum := sha256.Sum256([]byte(client.UserAgent))
+	return "ua:" + hex.EncodeToString(sum[:16])
+}
+
+func suspiciousLoginMessage(event *model.LoginEvent) string {
+	what := "设备"
+	switch {
+	case event.NewDevice && event.NewLocation:
+		what = "设备和地点"
+	case event.NewLocation:
+		what = "地点"
+	}
+	where := event.IP
+	if event.Country != "" {
+		where += "，" + event.Country
+	}
+	return fmt.Sprintf("您的账号于 %s 在新的%s登录（%s）。如非本人操作，请在登录记录中标记为非本人登录并修改密码",
+		time.Now().Format("2006-01-02 15:04"), what, where)
+}
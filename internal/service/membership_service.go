@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMembershipTierNotFound  = errors.New("会员等级不存在")
+	ErrMembershipTierArchived  = errors.New("该会员等级已下架")
+	ErrMembershipTierLimit     = errors.New("上架的会员等级数量已达上限")
+	ErrMembershipLevelTaken    = errors.New("已有相同等级的会员等级")
+	ErrCannotJoinOwnMembership = errors.New("不能加入自己的会员")
+)
+
+// MembershipService 创作者会员：创作者设置付费会员等级，用户用金币按期购买，会员可观看对应等级及以下的会员专属视频
+type MembershipService struct {
+	membershipRepo *repository.MembershipRepository
+	userRepo       *repository.UserRepository
+	walletService  *WalletService
+	txManager      *repository.TxManager
+}
+
+func NewMembershipService(membershipRepo *repository.MembershipRepository, userRepo *repository.UserRepository, walletService *WalletService, txManager *repository.TxManager) *MembershipService {
+	return &MembershipService{membershipRepo: membershipRepo, userRepo: userRepo, walletService: walletService, txManager: txManager}
+}
+
+// CreateTier 创作者新增会员等级，上架中的等级不能重复
+func (s *MembershipService) CreateTier(ctx context.Context, creatorID int64, req *dto.MembershipTierCreateRequest) (*dto.MembershipTierInfo, error) {
+	tiers, err := s.membershipRepo.ListTiers(ctx, creatorID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(tiers) >= config.GetMembership().TierLimit() {
+		return nil, ErrMembershipTierLimit
+	}
+	for _, t := range tiers {
+		if t.Level == req.Level {
+			return nil, ErrMembershipLevelTaken
+		}
+	}
+
+	tier := &model.MembershipTier{
+		CreatorID:   creatorID,
+		Level:       req.Level,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Price:       req.Price,
+		Badge:       strings.TrimSpace(req.Badge),
+	}
+	if err := s.membershipRepo.CreateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+	info := toMembershipTierInfo(tier)
+	return &info, nil
+}
+
+// UpdateTier 创作者修改会员等级的名称、说明、价格与徽章
+func (s *MembershipService) UpdateTier(ctx context.Context, creatorID, tierID int64, req *dto.MembershipTierUpdateRequest) (*dto.MembershipTierInfo, error) {
+	tier, err := s.ownTier(ctx, creatorID, tierID)
+	if err != nil {
+		return nil, err
+	}
+	if tier.ArchivedAt != nil {
+		return nil, ErrMembershipTierArchived
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		updates["description"] = strings.TrimSpace(*req.Description)
+	}
+	if req.Price != nil {
+		updates["price"] = *req.Price
+	}
+	if req.Badge != nil {
+		updates["badge"] = strings.TrimSpace(*req.Badge)
+	}
+	if len(updates) == 0 {
+		return nil, ErrNoFieldsToUpdate
+	}
+
+	tier, err = s.membershipRepo.UpdateTier(ctx, tierID, updates)
+	if err != nil {
+		return nil, err
+	}
+	info := toMembershipTierInfo(tier)
+	return &info, nil
+}
+
+// ArchiveTier 创作者下架会员等级：不能再加入或续费，已有会员到期前不受影响，
+// 设为该等级专属的视频仍按该等级限制观看
+func (s *MembershipService) ArchiveTier(ctx context.Context, creatorID, tierID int64) error {
+	tier, err := s.ownTier(ctx, creatorID, tierID)
+	if err != nil {
+		return err
+	}
+	if tier.ArchivedAt != nil {
+		return ErrMembershipTierArchived
+	}
+	_, err = s.membershipRepo.UpdateTier(ctx, tierID, map[string]interface{}{"archived_at": time.Now()})
+	return err
+}
+
+// ListTiers 创作者的会员等级及当前用户的会员资格；创作者本人查看时包含已下架的等级
+func (s *MembershipService) ListTiers(ctx context.Context, creatorID, viewerID int64) (*dto.MembershipTierListData, error) {
+	if _, err := s.userRepo.GetByID(ctx, creatorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	tiers, err := s.membershipRepo.ListTiers(ctx, creatorID, viewerID == creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dto.MembershipTierListData{
+		Tiers:      make([]dto.MembershipTierInfo, 0, len(tiers)),
+		PeriodDays: int(config.GetMembership().Period() / (24 * time.Hour)),
+	}
+	for i := range tiers {
+		data.Tiers = append(data.Tiers, toMembershipTierInfo(&tiers[i]))
+	}
+
+	if viewerID != 0 && viewerID != creatorID {
+		m, err := s.membershipRepo.GetMembership(ctx, creatorID, viewerID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if m != nil && m.IsActive(time.Now()) {
+			info := toMembershipInfo(m)
+			data.Membership = &info
+		}
+	}
+	return data, nil
+}
+
+// Join 用金币加入或续费会员：续费同一等级从当前到期时间顺延一期；
+// 换到其他等级或会员已过期时从现在起算一期，原等级剩余时长不退还。
+// 同一用户重复提交同一 request_id 时不再扣款，返回当前的会员资格
+func (s *MembershipService) Join(ctx context.Context, userID, tierID int64, requestID string) (*dto.MembershipInfo, error) {
+	tier, err := s.membershipRepo.GetTier(ctx, tierID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMembershipTierNotFound
+		}
+		return nil, err
+	}
+	if info, err := s.replayJoin(ctx, userID, tier.CreatorID, requestID); info != nil || err != nil {
+		return info, err
+	}
+	if tier.CreatorID == userID {
+		return nil, ErrCannotJoinOwnMembership
+	}
+	if tier.ArchivedAt != nil {
+		return nil, ErrMembershipTierArchived
+	}
+	if _, err := s.userRepo.GetByID(ctx, tier.CreatorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMembershipTierNotFound
+		}
+		return nil, err
+	}
+
+	period := config.GetMembership().Period()
+	var membership *model.Membership
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		// 扣款会锁住付款方的钱包，同一用户的并发购买在此串行，之后读取的会员资格是最新的
+		_, err := s.walletService.Transfer(ctx, &WalletTransfer{
+			FromID:       userID,
+			ToID:         tier.CreatorID,
+			Amount:       tier.Price,
+			SentKind:     model.WalletTxMembershipPaid,
+			ReceivedKind: model.WalletTxMembershipReceived,
+			RequestID:    requestID,
+			Note:         tier.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		existing, err := s.membershipRepo.GetMembership(ctx, tier.CreatorID, userID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			membership = &model.Membership{CreatorID: tier.CreatorID, UserID: userID, TierID: tier.ID, ExpiresAt: now.Add(period)}
+		case err != nil:
+			return err
+		case existing.TierID == tier.ID && existing.IsActive(now):
+			membership = existing
+			membership.ExpiresAt = membership.ExpiresAt.Add(period)
+		default:
+			membership = existing
+			membership.TierID = tier.ID
+			membership.ExpiresAt = now.Add(period)
+		}
+		return s.membershipRepo.SaveMembership(ctx, membership)
+	})
+	if err != nil {
+		// 并发提交同一 request_id 时后到的事务因唯一索引冲突回滚，按重复提交处理
+		if !errors.Is(err, ErrWalletInsufficient) {
+			if info, rerr := s.replayJoin(ctx, userID, tier.CreatorID, requestID); info != nil || rerr != nil {
+				return info, rerr
+			}
+		}
+		return nil, err
+	}
+
+	membership.Tier = *tier
+	info := toMembershipInfo(membership)
+	return &info, nil
+}
+
+// replayJoin request_id 已记账时返回当前的会员资格；同一 request_id 用于其他交易时返回 ErrWalletRequestConflict
+func (s *MembershipService) replayJoin(ctx context.Context, userID, creatorID int64, requestID string) (*dto.MembershipInfo, error) {
+	tx, err := s.walletService.FindByRequestID(ctx, userID, requestID)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	if tx.Kind != model.WalletTxMembershipPaid || tx.CounterpartyID == nil || *tx.CounterpartyID != creatorID {
+		return nil, ErrWalletRequestConflict
+	}
+	m, err := s.membershipRepo.GetMembership(ctx, creatorID, userID)
+	if err != nil {
+		return nil, err
+	}
+	info := toMembershipInfo(m)
+	return &info, nil
+}
+
+// ListMine 当前用户的有效会员资格
+func (s *MembershipService) ListMine(ctx context.Context, userID int64) (*dto.MembershipListData, error) {
+	memberships, err := s.membershipRepo.ListByUser(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	data := &dto.MembershipListData{Memberships: make([]dto.MembershipInfo, 0, len(memberships))}
+	for i := range memberships {
+		data.Memberships = append(data.Memberships, toMembershipInfo(&memberships[i]))
+	}
+	return data, nil
+}
+
+// ListMembers 创作者分页查看自己的有效会员，可按等级筛选
+func (s *MembershipService) ListMembers(ctx context.Context, creatorID int64, tierID *int64, page, pageSize int) (*dto.MemberListData, error) {
+	if tierID != nil {
+		if _, err := s.ownTier(ctx, creatorID, *tierID); err != nil {
+			return nil, err
+		}
+	}
+	skip := (page - 1) * pageSize
+	members, total, err := s.membershipRepo.ListMembers(ctx, creatorID, tierID, time.Now(), skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.MemberInfo, 0, len(members))
+	for i := range members {
+		m := &members[i]
+		items = append(items, dto.MemberInfo{
+			User:      toUserBriefInfo(&m.User),
+			TierID:    m.TierID,
+			TierName:  m.Tier.Name,
+			ExpiresAt: m.ExpiresAt,
+			JoinedAt:  m.CreatedAt,
+		})
+	}
+
+	return &dto.MemberListData{
+		Members:    items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// ownTier 获取创作者本人的会员等级
+func (s *MembershipService) ownTier(ctx context.Context, creatorID, tierID int64) (*model.MembershipTier, error) {
+	tier, err := s.membershipRepo.GetTier(ctx, tierID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMembershipTierNotFound
+		}
+		return nil, err
+	}
+	if tier.CreatorID != creatorID {
+		return nil, ErrMembershipTierNotFound
+	}
+	return tier, nil
+}
+
+func toMembershipTierInfo(tier *model.MembershipTier) dto.MembershipTierInfo {
+	return dto.MembershipTierInfo{
+		ID:          tier.ID,
+		CreatorID:   tier.CreatorID,
+		Level:       tier.Level,
+		Name:        tier.Name,
+		Description: tier.Description,
+		Price:       tier.Price,
+		Badge:       tier.Badge,
+		ArchivedAt:  tier.ArchivedAt,
+		CreatedAt:   tier.CreatedAt,
+	}
+}
+
+func toMembershipInfo(m *model.Membership) dto.MembershipInfo {
+	return dto.MembershipInfo{
+		CreatorID: m.CreatorID,
+		Tier:      toMembershipTierInfo(&m.Tier),
+		ExpiresAt: m.ExpiresAt,
+		JoinedAt:  m.CreatedAt,
+	}
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/cursor"
+	"vida-go/pkg/sensitive"
+
+	"gorm.io/gorm"
+)
+
+const conversationPreviewMaxRunes = 100
+
+var (
+	ErrCannotMessageSelf    = errors.New("不能给自己发私信")
+	ErrNotMutualFollow      = errors.New("仅互相关注的用户之间可以发私信")
+	ErrConversationNotFound = errors.New("会话不存在")
+)
+
+// MessageService 私信：仅互相关注的用户之间可以发送，消息经用户事件流实时投递
+type MessageService struct {
+	messageRepo  *repository.MessageRepository
+	relationRepo *repository.RelationRepository
+	userRepo     *repository.UserRepository
+	eventService *EventService
+}
+
+func NewMessageService(messageRepo *repository.MessageRepository, relationRepo *repository.RelationRepository, userRepo *repository.UserRepository, eventService *EventService) *MessageService {
+	return &MessageService{messageRepo: messageRepo, relationRepo: relationRepo, userRepo: userRepo, eventService: eventService}
+}
+
+// Send 发送私信，内容中的敏感词会被替换为 *
+func (s *MessageService) Send(ctx context.Context, senderID, recipientID int64, content string) (*dto.MessageInfo, error) {
+	if senderID == recipientID {
+		return nil, ErrCannotMessageSelf
+	}
+	if err := ensureNotMuted(ctx, s.userRepo, senderID); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, recipientID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	mutual, err := s.isMutualFollow(ctx, senderID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if !mutual {
+		return nil, ErrNotMutualFollow
+	}
+
+	conv, err := s.messageRepo.GetOrCreateConversation(ctx, senderID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, _ = sensitive.Mask(content)
+	msg := &model.Message{ConversationID: conv.ID, SenderID: senderID, Content: content}
+	if err := s.messageRepo.AppendMessage(ctx, conv, msg, truncateRunes(content, conversationPreviewMaxRunes)); err != nil {
+		return nil, err
+	}
+
+	info := toMessageInfo(msg)
+	s.eventService.Publish(ctx, recipientID, EventTypeMessage, info)
+	return info, nil
+}
+
+// ListConversations 获取当前用户的会话列表
+func (s *MessageService) ListConversations(ctx context.Context, userID int64, page, pageSize int) (*dto.ConversationListData, error) {
+	skip := (page - 1) * pageSize
+	convs, total, err := s.messageRepo.ListConversations(ctx, userID, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.buildConversationInfos(ctx, userID, convs)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
+
+	return &dto.ConversationListData{
+		Conversations: items,
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+		TotalPages:    totalPages,
+	}, nil
+}
+
+// ListConversationsByCursor 游标分页获取当前用户的会话列表
+func (s *MessageService) ListConversationsByCursor(ctx context.Context, userID int64, after string, limit int) (*dto.CursorList[dto.ConversationInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	convs, err := s.messageRepo.ListConversationsBefore(ctx, userID, c.Time(), c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	convs, hasMore := trimPage(convs, limit)
+
+	items, err := s.buildConversationInfos(ctx, userID, convs)
+	if err != nil {
+		return nil, err
+	}
+	list := &dto.CursorList[dto.ConversationInfo]{Items: items, HasMore: hasMore}
+	if hasMore {
+		// 列表只包含有消息的会话，LastMessageAt 必定存在
+		last := convs[len(convs)-1]
+		list.NextCursor = cursor.Encode(cursor.NewWithTime(*last.LastMessageAt, last.ID))
+	}
+	return list, nil
+}
+
+func (s *MessageService) buildConversationInfos(ctx context.Context, userID int64, convs []model.Conversation) ([]dto.ConversationInfo, error) {
+	peerIDs := make([]int64, 0, len(convs))
+	for i := range convs {
+		peerIDs = append(peerIDs, convs[i].PeerID(userID))
+	}
+	users, err := s.userRepo.GetByIDs(ctx, peerIDs)
+	if err != nil {
+		return nil, err
+	}
+	peers := make(map[int64]*dto.AuthorBrief, len(users))
+	for i := range users {
+		peers[users[i].ID] = &dto.AuthorBrief{ID: users[i].ID, Username: users[i].UserName, Avatar: users[i].Avatar}
+	}
+
+	items := make([]dto.ConversationInfo, 0, len(convs))
+	for i := range convs {
+		peerID := convs[i].PeerID(userID)
+		peer := peers[peerID]
+		if peer == nil {
+			peer = &dto.AuthorBrief{ID: peerID}
+		}
+		items = append(items, dto.ConversationInfo{
+			ID:                 convs[i].ID,
+			Peer:               peer,
+			LastSenderID:       convs[i].LastSenderID,
+			LastMessageContent: convs[i].LastMessageContent,
+			LastMessageAt:      convs[i].LastMessageAt,
+			UnreadCount:        convs[i].UnreadFor(userID),
+		})
+	}
+
+	return items, nil
+}
+
+// ListMessages 获取会话中的消息（仅会话参与者可查看）
+func (s *MessageService) ListMessages(ctx context.Context, userID, conversationID, beforeID int64, limit int) (*dto.MessageListData, error) {
+	if _, err := s.getConversation(ctx, userID, conversationID); err != nil {
+		return nil, err
+	}
+
+	// 多取一条用于判断是否还有更早的消息
+	messages, err := s.messageRepo.ListMessages(ctx, conversationID, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dto.MessageListData{Messages: make([]dto.MessageInfo, 0, len(messages))}
+	if len(messages) > limit {
+		messages = messages[:limit]
+		data.HasMore = true
+	}
+	for i := range messages {
+		data.Messages = append(data.Messages, *toMessageInfo(&messages[i]))
+	}
+	if data.HasMore {
+		data.NextBeforeID = messages[len(messages)-1].ID
+	}
+	return data, nil
+}
+
+// ListMessagesByCursor 游标分页获取会话消息（按 ID 倒序）
+func (s *MessageService) ListMessagesByCursor(ctx context.Context, userID, conversationID int64, after string, limit int) (*dto.CursorList[dto.MessageInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.ListMessages(ctx, userID, conversationID, c.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	list := &dto.CursorList[dto.MessageInfo]{Items: data.Messages, HasMore: data.HasMore}
+	if data.HasMore {
+		list.NextCursor = cursor.Encode(cursor.New(data.NextBeforeID))
+	}
+	return list, nil
+}
+
+// MarkRead 将会话标记为已读
+func (s *MessageService) MarkRead(ctx context.Context, userID, conversationID int64) error {
+	conv, err := s.getConversation(ctx, userID, conversationID)
+	if err != nil {
+		return err
+	}
+	return s.messageRepo.MarkRead(ctx, conv, userID)
+}
+
+// UnreadCount 获取私信未读总数
+func (s *MessageService) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	return s.messageRepo.CountUnread(ctx, userID)
+}
+
+func (s *MessageService) getConversation(ctx context.Context, userID, conversationID int64) (*model.Conversation, error) {
+	conv, err := s.messageRepo.GetConversation(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, err
+	}
+	// 非参与者与不存在一样处理，避免泄露会话信息
+	if !conv.HasMember(userID) {
+		return nil, ErrConversationNotFound
+	}
+	return conv, nil
+}
+
+func (s *MessageService) isMutualFollow(ctx context.Context, a, b int64) (bool, error) {
+	following, err := s.relationRepo.Exists(ctx, a, b)
+	if err != nil || !following {
+		return false, err
+	}
+	return s.relationRepo.Exists(ctx, b, a)
+}
+
+func toMessageInfo(m *model.Message) *dto.MessageInfo {
+	return &dto.MessageInfo{
+		ID:             m.ID,
+		ConversationID: m.ConversationID,
+		SenderID:       m.SenderID,
+		Content:        m.Content,
+		CreatedAt:      m.CreatedAt,
+	}
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/api/dto"
+	infraES "vida-go/internal/infra/elasticsearch"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVideoNotHideable       = errors.New("只有已发布的视频可以隐藏")
+	ErrVideoNotHidden         = errors.New("视频未被隐藏")
+	ErrCommentHidden          = errors.New("评论已被隐藏")
+	ErrCommentNotHidden       = errors.New("评论未被隐藏")
+	ErrDuplicateNotFound      = errors.New("重复记录不存在")
+	ErrDuplicateResolved      = errors.New("该重复记录已处理")
+	ErrInvalidDuplicateStatus = errors.New("无效的重复记录状态")
+)
+
+// ModerationService 内容审核：隐藏/恢复视频与评论，处理近似重复视频
+type ModerationService struct {
+	videoRepo           *repository.VideoRepository
+	commentRepo         *repository.CommentRepository
+	duplicateRepo       *repository.VideoDuplicateRepository
+	notificationService *NotificationService
+	txManager           *repository.TxManager
+}
+
+func NewModerationService(
+	videoRepo *repository.VideoRepository,
+	commentRepo *repository.CommentRepository,
+	duplicateRepo *repository.VideoDuplicateRepository,
+	notificationService *NotificationService,
+	txManager *repository.TxManager,
+) *ModerationService {
+	return &ModerationService{
+		videoRepo:           videoRepo,
+		commentRepo:         commentRepo,
+		duplicateRepo:       duplicateRepo,
+		notificationService: notificationService,
+		txManager:           txManager,
+	}
+}
+
+// HideVideo 隐藏已发布视频，并从搜索索引中移除
+func (s *ModerationService) HideVideo(ctx context.Context, videoID int64) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+	if video.Status != "published" {
+		return ErrVideoNotHideable
+	}
+
+	if _, err := s.videoRepo.Update(ctx, videoID, map[string]interface{}{"status": VideoStatusHidden}); err != nil {
+		return err
+	}
+
+	if err := infraES.DeleteVideo(ctx, videoID); err != nil {
+		logger.FromContext(ctx).Warn("Remove hidden video from ES failed", zap.Int64("video_id", videoID), zap.Error(err))
+	}
+
+	s.notificationService.Emit(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeSystem,
+		RecipientID: video.AuthorID,
+		VideoID:     &videoID,
+		Content:     "您的视频《" + video.Title + "》因违反社区规范已被隐藏",
+	})
+	return nil
+}
+
+// UnhideVideo 恢复被隐藏的视频，并重新写入搜索索引
+func (s *ModerationService) UnhideVideo(ctx context.Context, videoID int64) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+	if video.Status != VideoStatusHidden {
+		return ErrVideoNotHidden
+	}
+
+	if _, err := s.videoRepo.Update(ctx, videoID, map[string]interface{}{"status": "published"}); err != nil {
+		return err
+	}
+
+	if video, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID); err == nil && video.LegalHoldAt == nil && !video.IsPrivate() {
+		if err := infraES.SyncVideo(ctx, video, video.Author.UserName); err != nil {
+			logger.FromContext(ctx).Warn("Sync unhidden video to ES failed", zap.Int64("video_id", videoID), zap.Error(err))
+		}
+	}
+
+	s.notificationService.Emit(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeSystem,
+		RecipientID: video.AuthorID,
+		VideoID:     &videoID,
+		Content:     "您的视频《" + video.Title + "》已恢复展示",
+	})
+	return nil
+}
+
+// AgeRestrictVideo 审核员为视频设置年龄限制，作者不能取消。作者已自行设置时改为审核员设置
+func (s *ModerationService) AgeRestrictVideo(ctx context.Context, videoID int64) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+	if video.AgeRestriction == model.AgeRestrictionModerator {
+		return ErrAlreadyAgeRestricted
+	}
+
+	if _, err := s.videoRepo.Update(ctx, videoID, map[string]interface{}{"age_restriction": model.AgeRestrictionModerator}); err != nil {
+		return err
+	}
+
+	s.notificationService.Emit(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeSystem,
+		RecipientID: video.AuthorID,
+		VideoID:     &videoID,
+		Content:     "您的视频《" + video.Title + "》已被设置为年龄限制内容",
+	})
+	return nil
+}
+
+// AgeUnrestrictVideo 取消视频的年龄限制（无论由作者还是审核员设置）
+func (s *ModerationService) AgeUnrestrictVideo(ctx context.Context, videoID int64) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+	if !video.AgeRestricted() {
+		return ErrNotAgeRestricted
+	}
+
+	_, err = s.videoRepo.Update(ctx, videoID, map[string]interface{}{"age_restriction": ""})
+	return err
+}
+
+// HideComment 隐藏评论（不再出现在评论列表中）
+func (s *ModerationService) HideComment(ctx context.Context, commentID int64) error {
+	return s.setCommentHidden(ctx, commentID, true)
+}
+
+// UnhideComment 恢复被隐藏的评论
+func (s *ModerationService) UnhideComment(ctx context.Context, commentID int64) error {
+	return s.setCommentHidden(ctx, commentID, false)
+}
+
+func (s *ModerationService) setCommentHidden(ctx context.Context, commentID int64, hidden bool) error {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		changed, err := s.commentRepo.SetHidden(ctx, commentID, hidden)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			if hidden {
+				return ErrCommentHidden
+			}
+			return ErrCommentNotHidden
+		}
+
+		// 隐藏的评论不计入视频评论数
+		if hidden {
+			return s.videoRepo.DecrementCommentCount(ctx, comment.VideoID)
+		}
+		return s.videoRepo.IncrementCommentCount(ctx, comment.VideoID)
+	})
+	if err != nil {
+		return err
+	}
+
+	content := "您的评论已恢复展示"
+	if hidden {
+		content = "您的评论因违反社区规范已被隐藏"
+	}
+	s.notificationService.Emit(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeSystem,
+		RecipientID: comment.UserID,
+		VideoID:     &comment.VideoID,
+		CommentID:   &commentID,
+		Content:     content,
+	})
+	return nil
+}
+
+// ListHiddenVideos 审核队列：被隐藏的视频
+func (s *ModerationService) ListHiddenVideos(ctx context.Context, page, pageSize int) (*dto.VideoListData, error) {
+	skip := (page - 1) * pageSize
+	status := VideoStatusHidden
+	videos, total, err := s.videoRepo.ListVideos(ctx, skip, pageSize, nil, &status, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return buildVideoListData(videos, total, page, pageSize, true), nil
+}
+
+// ListHiddenComments 审核队列：被隐藏的评论
+func (s *ModerationService) ListHiddenComments(ctx context.Context, page, pageSize int) (*dto.CommentListData, error) {
+	skip := (page - 1) * pageSize
+	comments, total, err := s.commentRepo.ListHidden(ctx, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.CommentInfo, 0, len(comments))
+	for i := range comments {
+		info := toCommentInfo(&comments[i], 0)
+		if comments[i].User.ID != 0 {
+			info.Username = &comments[i].User.UserName
+			info.Avatar = comments[i].User.Avatar
+		}
+		items = append(items, *info)
+	}
+
+	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
+
+	return &dto.CommentListData{
+		Comments:   items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// ListDuplicates 审核队列：近似重复视频，status 为空时返回全部
+func (s *ModerationService) ListDuplicates(ctx context.Context, status string, page, pageSize int) (*dto.VideoDuplicateListData, error) {
+	switch status {
+	case "", model.VideoDuplicateStatusPending, model.VideoDuplicateStatusLinked,
+		model.VideoDuplicateStatusDismissed, model.VideoDuplicateStatusHidden:
+	default:
+		return nil, ErrInvalidDuplicateStatus
+	}
+
+	skip := (page - 1) * pageSize
+	duplicates, total, err := s.duplicateRepo.List(ctx, status, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.VideoDuplicateInfo, 0, len(duplicates))
+	for i := range duplicates {
+		d := &duplicates[i]
+		items = append(items, dto.VideoDuplicateInfo{
+			ID:          d.ID,
+			Video:       *toVideoInfo(&d.Video, true),
+			DuplicateOf: *toVideoInfo(&d.DuplicateOf, true),
+			Similarity:  d.Similarity,
+			Status:      d.Status,
+			ReviewedAt:  d.ReviewedAt,
+			CreatedAt:   d.CreatedAt,
+		})
+	}
+
+	return &dto.VideoDuplicateListData{
+		Duplicates: items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// DismissDuplicate 审核后认定不是重复
+func (s *ModerationService) DismissDuplicate(ctx context.Context, duplicateID int64) error {
+	_, err := s.resolveDuplicate(ctx, duplicateID, model.VideoDuplicateStatusDismissed)
+	return err
+}
+
+// HideDuplicate 审核后认定为重复，隐藏新发布的视频
+func (s *ModerationService) HideDuplicate(ctx context.Context, duplicateID int64) error {
+	duplicate, err := s.getPendingDuplicate(ctx, duplicateID)
+	if err != nil {
+		return err
+	}
+	if err := s.HideVideo(ctx, duplicate.VideoID); err != nil && !errors.Is(err, ErrVideoNotHideable) {
+		return err
+	}
+	_, err = s.resolveDuplicate(ctx, duplicateID, model.VideoDuplicateStatusHidden)
+	return err
+}
+
+func (s *ModerationService) getPendingDuplicate(ctx context.Context, duplicateID int64) (*model.VideoDuplicate, error) {
+	duplicate, err := s.duplicateRepo.GetByID(ctx, duplicateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDuplicateNotFound
+		}
+		return nil, err
+	}
+	if duplicate.Status != model.VideoDuplicateStatusPending {
+		return nil, ErrDuplicateResolved
+	}
+	return duplicate, nil
+}
+
+// resolveDuplicate 将待审核记录改为 status，已被处理时返回 ErrDuplicateResolved
+func (s *ModerationService) resolveDuplicate(ctx context.Context, duplicateID int64, status string) (*model.VideoDuplicate, error) {
+	duplicate, err := s.getPendingDuplicate(ctx, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.duplicateRepo.UpdateStatus(ctx, duplicateID, model.VideoDuplicateStatusPending, status)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrDuplicateResolved
+	}
+	return duplicate, nil
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/eventbus"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/cursor"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxMutedVideos 每个用户最多屏蔽通知的视频数
+const maxMutedVideos = 200
+
+var ErrTooManyMutedVideos = errors.New("屏蔽通知的视频数量已达上限")
+
+// 通知类型
+const (
+	NotificationTypeLike    = "like"
+	NotificationTypeComment = "comment"
+	NotificationTypeReply   = "reply"
+	NotificationTypeFollow  = "follow"
+	NotificationTypeTip     = "tip"
+	NotificationTypeDigest  = "digest"
+	NotificationTypeSystem  = "system"
+)
+
+// NotificationService 站内通知：业务服务通过 Emit 投递事件到 Kafka，消费端落库并实时推送
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	settingRepo      *repository.UserSettingRepository
+	videoRepo        *repository.VideoRepository
+	eventService     *EventService
+	emailService     *EmailService
+	pushService      *PushService
+}
+
+func NewNotificationService(notificationRepo *repository.NotificationRepository, settingRepo *repository.UserSettingRepository, videoRepo *repository.VideoRepository, eventService *EventService, emailService *EmailService, pushService *PushService) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		settingRepo:      settingRepo,
+		videoRepo:        videoRepo,
+		eventService:     eventService,
+		emailService:     emailService,
+		pushService:      pushService,
+	}
+}
+
+// Emit 投递通知事件（尽力而为，不影响主流程）
+// 未配置通知 topic 或 Kafka 发送失败时直接在本进程内处理
+func (s *NotificationService) Emit(ctx context.Context, event *infraKafka.NotificationEvent) {
+	if event.RecipientID == 0 || event.RecipientID == event.ActorID {
+		return
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	if topic := config.GetKafka().Topics["notification"]; topic != "" {
+		err := infraKafka.SendNotificationEvent(ctx, topic, event)
+		if err == nil {
+			return
+		}
+		logger.FromContext(ctx).Warn("Send notification event failed, delivering directly",
+			zap.String("type", event.Type), zap.Int64("recipient_id", event.RecipientID), zap.Error(err))
+	}
+
+	if err := s.HandleEvent(ctx, event); err != nil {
+		logger.FromContext(ctx).Error("Deliver notification failed",
+			zap.String("type", event.Type), zap.Int64("recipient_id", event.RecipientID), zap.Error(err))
+	}
+}
+
+// EmitSystem 投递系统通知
+func (s *NotificationService) EmitSystem(ctx context.Context, recipientID int64, content string) {
+	s.Emit(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeSystem,
+		RecipientID: recipientID,
+		Content:     content,
+	})
+}
+
+// OnUserFollowed 订阅关注事件：通知被关注的用户（直接落库推送，不再经通知 topic 转发）
+func (s *NotificationService) OnUserFollowed(ctx context.Context, event *eventbus.UserFollowed) error {
+	return s.HandleEvent(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeFollow,
+		RecipientID: event.FolloweeID,
+		ActorID:     event.FollowerID,
+		CreatedAt:   event.FollowedAt,
+	})
+}
+
+// OnCommentCreated 订阅评论事件：通知被回复的评论作者与视频作者（同一人只通知一次）
+func (s *NotificationService) OnCommentCreated(ctx context.Context, event *eventbus.CommentCreated) error {
+	notify := func(notificationType string, recipientID int64) error {
+		return s.HandleEvent(ctx, &infraKafka.NotificationEvent{
+			Type:        notificationType,
+			RecipientID: recipientID,
+			ActorID:     event.UserID,
+			VideoID:     &event.VideoID,
+			CommentID:   &event.CommentID,
+			Content:     event.Content,
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+	if event.ParentUserID != 0 {
+		if err := notify(NotificationTypeReply, event.ParentUserID); err != nil {
+			return err
+		}
+		if event.ParentUserID == event.VideoAuthorID {
+			return nil
+		}
+	}
+	return notify(NotificationTypeComment, event.VideoAuthorID)
+}
+
+// systemMessage 拼接系统通知内容，原因为空时省略
+func systemMessage(msg, reason string) string {
+	if reason == "" {
+		return msg
+	}
+	return msg + "，原因：" + reason
+}
+
+// HandleEvent 消费通知事件：写入通知表并推送到用户事件流
+func (s *NotificationService) HandleEvent(ctx context.Context, event *infraKafka.NotificationEvent) error {
+	if event.RecipientID == 0 || event.RecipientID == event.ActorID {
+		return nil
+	}
+
+	setting, err := s.settingRepo.GetByUserID(ctx, event.RecipientID)
+	if err != nil {
+		return err
+	}
+	if isNotificationMuted(setting, event) {
+		return nil
+	}
+
+	n := &model.Notification{
+		UserID:    event.RecipientID,
+		Type:      event.Type,
+		ActorID:   event.ActorID,
+		VideoID:   event.VideoID,
+		CommentID: event.CommentID,
+		Content:   event.Content,
+		CreatedAt: event.CreatedAt,
+	}
+	if err := s.notificationRepo.Create(ctx, n); err != nil {
+		return err
+	}
+
+	s.eventService.Publish(ctx, event.RecipientID, EventTypeNotification, toNotificationInfo(n))
+	s.pushService.NotifyUser(ctx, n)
+
+	// 邮件通知：新粉丝按周期汇总，系统通知（封禁、禁言、内容隐藏等审核结果）即时发送
+	switch event.Type {
+	case NotificationTypeFollow:
+		s.emailService.AddFollowerToDigest(ctx, event.RecipientID, event.ActorID)
+	case NotificationTypeSystem:
+		s.emailService.Notify(ctx, event.RecipientID, EmailKindModeration, "moderation_decision", map[string]interface{}{
+			"Message": event.Content,
+		})
+	}
+	return nil
+}
+
+// List 获取当前用户的通知列表
+func (s *NotificationService) List(ctx context.Context, userID int64, unreadOnly bool, notifType string, page, pageSize int) (*dto.NotificationListData, error) {
+	skip := (page - 1) * pageSize
+	notifications, total, err := s.notificationRepo.ListByUser(ctx, userID, unreadOnly, notifType, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.NotificationInfo, 0, len(notifications))
+	for i := range notifications {
+		items = append(items, *toNotificationInfo(&notifications[i]))
+	}
+
+	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
+
+	return &dto.NotificationListData{
+		Notifications: items,
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+		TotalPages:    totalPages,
+	}, nil
+}
+
+// ListByCursor 游标分页获取当前用户的通知
+func (s *NotificationService) ListByCursor(ctx context.Context, userID int64, unreadOnly bool, notifType string, after string, limit int) (*dto.CursorList[dto.NotificationInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := s.notificationRepo.ListByUserBefore(ctx, userID, unreadOnly, notifType, c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	notifications, hasMore := trimPage(notifications, limit)
+
+	list := &dto.CursorList[dto.NotificationInfo]{Items: make([]dto.NotificationInfo, 0, len(notifications)), HasMore: hasMore}
+	for i := range notifications {
+		list.Items = append(list.Items, *toNotificationInfo(&notifications[i]))
+	}
+	if hasMore {
+		list.NextCursor = cursor.Encode(cursor.New(notifications[len(notifications)-1].ID))
+	}
+	return list, nil
+}
+
+// UnreadCount 获取未读通知数
+func (s *NotificationService) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	return s.notificationRepo.CountUnread(ctx, userID)
+}
+
+// MarkRead 标记指定通知为已读
+func (s *NotificationService) MarkRead(ctx context.Context, userID int64, ids []int64) (*dto.NotificationMarkReadData, error) {
+	updated, err := s.notificationRepo.MarkRead(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return s.markReadResult(ctx, userID, updated)
+}
+
+// MarkAllRead 标记全部通知为已读
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int64) (*dto.NotificationMarkReadData, error) {
+	updated, err := s.notificationRepo.MarkAllRead(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.markReadResult(ctx, userID, updated)
+}
+
+func (s *NotificationService) markReadResult(ctx context.Context, userID, updated int64) (*dto.NotificationMarkReadData, error) {
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.NotificationMarkReadData{Updated: updated, UnreadCount: unread}, nil
+}
+
+// GetPreferences 获取通知偏好
+func (s *NotificationService) GetPreferences(ctx context.Context, userID int64) (*dto.NotificationPreferences, error) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationPreferences(setting), nil
+}
+
+// UpdateMutedTypes 设置屏蔽的通知类型（整体替换）
+func (s *NotificationService) UpdateMutedTypes(ctx context.Context, userID int64, types []string) (*dto.NotificationPreferences, error) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(types)
+	setting.MutedNotificationTypes = slices.Compact(types)
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return toNotificationPreferences(setting), nil
+}
+
+// MuteVideo 不再接收某个视频相关的通知（点赞、评论、回复）
+func (s *NotificationService) MuteVideo(ctx context.Context, userID, videoID int64) (*dto.NotificationPreferences, error) {
+	if _, err := s.videoRepo.GetByID(ctx, videoID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(setting.MutedVideoIDs, videoID) {
+		return toNotificationPreferences(setting), nil
+	}
+	if len(setting.MutedVideoIDs) >= maxMutedVideos {
+		return nil, ErrTooManyMutedVideos
+	}
+
+	setting.MutedVideoIDs = append(setting.MutedVideoIDs, videoID)
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return toNotificationPreferences(setting), nil
+}
+
+// UnmuteVideo 恢复接收某个视频相关的通知
+func (s *NotificationService) UnmuteVideo(ctx context.Context, userID, videoID int64) (*dto.NotificationPreferences, error) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.Index(setting.MutedVideoIDs, videoID)
+	if idx < 0 {
+		return toNotificationPreferences(setting), nil
+	}
+
+	setting.MutedVideoIDs = slices.Delete(setting.MutedVideoIDs, idx, idx+1)
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return toNotificationPreferences(setting), nil
+}
+
+// isNotificationMuted 系统通知不受屏蔽设置影响
+func isNotificationMuted(setting *model.UserSetting, event *infraKafka.NotificationEvent) bool {
+	if event.Type == NotificationTypeSystem {
+		return false
+	}
+	if slices.Contains(setting.MutedNotificationTypes, event.Type) {
+		return true
+	}
+	return event.VideoID != nil && slices.Contains(setting.MutedVideoIDs, *event.VideoID)
+}
+
+func toNotificationPreferences(setting *model.UserSetting) *dto.NotificationPreferences {
+	prefs := &dto.NotificationPreferences{
+		MutedTypes:    setting.MutedNotificationTypes,
+		MutedVideoIDs: setting.MutedVideoIDs,
+	}
+	if prefs.MutedTypes == nil {
+		prefs.MutedTypes = []string{}
+	}
+	if prefs.MutedVideoIDs == nil {
+		prefs.MutedVideoIDs = []int64{}
+	}
+	return prefs
+}
+
+func toNotificationInfo(n *model.Notification) *dto.NotificationInfo {
+	info := &dto.NotificationInfo{
+		ID:        n.ID,
+		Type:      n.Type,
+		VideoID:   n.VideoID,
+		CommentID: n.CommentID,
+		Content:   n.Content,
+		IsRead:    n.IsRead,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.Actor.ID != 0 {
+		info.Actor = &dto.AuthorBrief{ID: n.Actor.ID, Username: n.Actor.UserName, Avatar: n.Actor.Avatar}
+	} else if n.ActorID != 0 {
+		info.Actor = &dto.AuthorBrief{ID: n.ActorID}
+	}
+	return info
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/model"
+	"vida-go/internal/rbac"
+	"vida-go/internal/repository"
+	"vida-go/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOAuthClientNotFound = errors.New("应用不存在或已作废")
+	ErrOAuthClientLimit    = errors.New("注册的应用数已达上限")
+	ErrInvalidOAuthScope   = errors.New("申请的权限范围无效或超出应用登记的范围")
+	ErrInvalidRedirectURI  = errors.New("回调地址未在应用中登记")
+)
+
+// OAuth 授权类型
+const (
+	OAuthGrantClientCredentials = "client_credentials"
+	OAuthGrantAuthorizationCode = "authorization_code"
+)
+
+// OAuthError 令牌接口按 RFC 6749 返回的错误
+type OAuthError struct {
+	Code        string // invalid_request / invalid_client / invalid_grant / invalid_scope / unsupported_grant_type
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func oauthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// OAuthService 第三方应用接入：用户注册应用，应用以客户端凭证或用户授权的授权码换取带 scope 的访问令牌
+type OAuthService struct {
+	clientRepo *repository.OAuthClientRepository
+	codeRepo   *repository.OAuthCodeRepository
+	userRepo   *repository.UserRepository
+}
+
+func NewOAuthService(clientRepo *repository.OAuthClientRepository, codeRepo *repository.OAuthCodeRepository, userRepo *repository.UserRepository) *OAuthService {
+	return &OAuthService{clientRepo: clientRepo, codeRepo: codeRepo, userRepo: userRepo}
+}
+
+// CreateClient 注册应用，返回的 client_secret 只展示这一次
+func (s *OAuthService) CreateClient(ctx context.Context, ownerID int64, req *dto.OAuthClientCreateRequest) (*dto.OAuthClientCreatedInfo, error) {
+	count, err := s.clientRepo.CountActiveByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(config.GetOAuth().ClientLimit()) {
+		return nil, ErrOAuthClientLimit
+	}
+
+	clientID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	redirectURIs := req.RedirectURIs
+	if redirectURIs == nil {
+		redirectURIs = []string{}
+	}
+	client := &model.OAuthClient{
+		ClientID:     clientID,
+		SecretHash:   hashOAuthSecret(secret),
+		Name:         strings.TrimSpace(req.Name),
+		OwnerID:      ownerID,
+		RedirectURIs: redirectURIs,
+		Scopes:       uniqueStrings(req.Scopes),
+	}
+	if err := s.clientRepo.Create(ctx, client); err != nil {
+		return nil, err
+	}
+
+	return &dto.OAuthClientCreatedInfo{OAuthClientInfo: toOAuthClientInfo(client), ClientSecret: secret}, nil
+}
+
+// ListClients 获取用户注册的应用
+func (s *OAuthService) ListClients(ctx context.Context, ownerID int64) ([]dto.OAuthClientInfo, error) {
+	clients, err := s.clientRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dto.OAuthClientInfo, 0, len(clients))
+	for i := range clients {
+		items = append(items, toOAuthClientInfo(&clients[i]))
+	}
+	return items, nil
+}
+
+// RevokeClient 作废应用，之后不再签发新令牌，已签发的令牌在有效期内仍可使用
+func (s *OAuthService) RevokeClient(ctx context.Context, ownerID, id int64) error {
+	revoked, err := s.clientRepo.Revoke(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrOAuthClientNotFound
+	}
+	return nil
+}
+
+// GetAuthorization 校验授权请求，返回授权确认页需要展示的应用与权限
+func (s *OAuthService) GetAuthorization(ctx context.Context, req *dto.OAuthAuthorizeRequest) (*dto.OAuthAuthorizeInfo, error) {
+	client, redirectURI, scopes, err := s.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &dto.OAuthAuthorizeInfo{
+		ClientID:    client.ClientID,
+		Name:        client.Name,
+		RedirectURI: redirectURI,
+		Scopes:      scopes,
+	}
+	if owner, err := s.userRepo.GetByID(ctx, client.OwnerID); err == nil {
+		info.Owner = toUserBriefInfo(owner)
+	}
+	return info, nil
+}
+
+// Authorize 用户同意授权，签发一次性授权码
+func (s *OAuthService) Authorize(ctx context.Context, userID int64, req *dto.OAuthAuthorizeRequest) (*dto.OAuthAuthorizeResult, error) {
+	client, redirectURI, scopes, err := s.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+	ttl := config.GetOAuth().CodeTTL()
+	record := &model.OAuthAuthorizationCode{
+		CodeHash:      hashOAuthSecret(code),
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectURI:   redirectURI,
+		Scope:         strings.Join(scopes, " "),
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     time.Now().Add(ttl),
+	}
+	if err := s.codeRepo.Create(ctx, record); err != nil {
+		return nil, err
+	}
+
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		return nil, ErrInvalidRedirectURI
+	}
+	query := target.Query()
+	query.Set("code", code)
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	target.RawQuery = query.Encode()
+
+	return &dto.OAuthAuthorizeResult{
+		Code:        code,
+		State:       req.State,
+		RedirectURL: target.String(),
+		ExpiresIn:   int(ttl.Seconds()),
+	}, nil
+}
+
+// checkAuthorizeRequest 校验应用、回调地址与申请的 scope。应用只登记了一个回调地址时可省略 redirect_uri
+func (s *OAuthService) checkAuthorizeRequest(ctx context.Context, req *dto.OAuthAuthorizeRequest) (*model.OAuthClient, string, []string, error) {
+	client, err := s.clientRepo.GetByClientID(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", nil, ErrOAuthClientNotFound
+		}
+		return nil, "", nil, err
+	}
+
+	redirectURI := req.RedirectURI
+	if redirectURI == "" && len(client.RedirectURIs) == 1 {
+		redirectURI = client.RedirectURIs[0]
+	}
+	if redirectURI == "" || !slices.Contains(client.RedirectURIs, redirectURI) {
+		return nil, "", nil, ErrInvalidRedirectURI
+	}
+
+	scopes, ok := parseOAuthScopes(req.Scope, client.Scopes)
+	if !ok {
+		return nil, "", nil, ErrInvalidOAuthScope
+	}
+	return client, redirectURI, scopes, nil
+}
+
+// Token 令牌接口：校验客户端凭证后按授权类型签发访问令牌，错误均为 *OAuthError
+func (s *OAuthService) Token(ctx context.Context, req *dto.OAuthTokenRequest) (*dto.OAuthTokenResponse, error) {
+	client, err := s.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID int64
+	var scopes []string
+	switch req.GrantType {
+	case OAuthGrantClientCredentials:
+		// 不代表任何用户，只能获得无需用户授权的 scope
+		allowed := slices.DeleteFunc(slices.Clone(client.Scopes), func(scope string) bool {
+			return rbac.ScopeRequiresUser(rbac.Scope(scope))
+		})
+		var ok bool
+		if scopes, ok = parseOAuthScopes(req.Scope, allowed); !ok || len(scopes) == 0 {
+			return nil, oauthError("invalid_scope", "客户端凭证模式只能申请无需用户授权的权限范围")
+		}
+	case OAuthGrantAuthorizationCode:
+		if userID, scopes, err = s.redeemCode(ctx, client, req); err != nil {
+			return nil, err
+		}
+	case "":
+		return nil, oauthError("invalid_request", "缺少 grant_type")
+	default:
+		return nil, oauthError("unsupported_grant_type", "不支持的授权类型")
+	}
+
+	ttl := config.GetOAuth().AccessTokenTTL()
+	scope := strings.Join(scopes, " ")
+	token, err := utils.GenerateOAuthToken(userID, client.ClientID, scope, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.OAuthTokenResponse{
+		AccessToken: token,
+		TokenType:   "bearer",
+		ExpiresIn:   int(ttl.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+func (s *OAuthService) authenticateClient(ctx context.Context, clientID, secret string) (*model.OAuthClient, error) {
+	if clientID == "" || secret == "" {
+		return nil, oauthError("invalid_client", "缺少客户端凭证")
+	}
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, oauthError("invalid_client", "客户端凭证无效")
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashOAuthSecret(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, oauthError("invalid_client", "客户端凭证无效")
+	}
+	return client, nil
+}
+
+// redeemCode 使用授权码：校验所属应用、回调地址与 PKCE，授权用户被封禁时拒绝
+func (s *OAuthService) redeemCode(ctx context.Context, client *model.OAuthClient, req *dto.OAuthTokenRequest) (int64, []string, error) {
+	invalid := oauthError("invalid_grant", "授权码无效、已使用或已过期")
+	if req.Code == "" {
+		return 0, nil, oauthError("invalid_request", "缺少 code")
+	}
+	code, err := s.codeRepo.GetByHash(ctx, hashOAuthSecret(req.Code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil, invalid
+		}
+		return 0, nil, err
+	}
+	if code.ClientID != client.ID || (req.RedirectURI != "" && req.RedirectURI != code.RedirectURI) {
+		return 0, nil, invalid
+	}
+	if code.CodeChallenge != "" && !verifyCodeChallenge(req.CodeVerifier, code.CodeChallenge) {
+		return 0, nil, oauthError("invalid_grant", "code_verifier 校验失败")
+	}
+
+	consumed, err := s.codeRepo.Consume(ctx, code.ID, time.Now())
+	if err != nil {
+		return 0, nil, err
+	}
+	if !consumed {
+		return 0, nil, invalid
+	}
+
+	user, err := s.userRepo.GetByID(ctx, code.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil, invalid
+		}
+		return 0, nil, err
+	}
+	if err := checkSuspended(user); err != nil {
+		return 0, nil, oauthError("invalid_grant", err.Error())
+	}
+	return user.ID, strings.Fields(code.Scope), nil
+}
+
+// parseOAuthScopes 解析空格分隔的 scope，为空时取 allowed 全部；出现无效或不在 allowed 中的 scope 时返回 false
+func parseOAuthScopes(scope string, allowed []string) ([]string, bool) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		return slices.Clone(allowed), true
+	}
+	for _, s := range requested {
+		if !rbac.IsValidScope(rbac.Scope(s)) || !slices.Contains(allowed, s) {
+			return nil, false
+		}
+	}
+	return uniqueStrings(requested), true
+}
+
+// verifyCodeChallenge PKCE S256：BASE64URL(SHA256(code_verifier)) == code_challenge
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if verifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// hashOAuthSecret 客户端密钥、授权码均为高熵随机串，只保存 SHA-256 摘要
+func hashOAuthSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func uniqueStrings(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func toOAuthClientInfo(client *model.OAuthClient) dto.OAuthClientInfo {
+	return dto.OAuthClientInfo{
+		ID:           client.ID,
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectURIs,
+		Scopes:       client.Scopes,
+		RevokedAt:    client.RevokedAt,
+		CreatedAt:    client.CreatedAt,
+	}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/infra/geoip"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPollNotFound      = errors.New("该视频没有投票")
+	ErrPollExists        = errors.New("该视频已有投票")
+	ErrPollClosed        = errors.New("投票已截止")
+	ErrPollAlreadyVoted  = errors.New("您已经投过票了")
+	ErrPollOptionInvalid = errors.New("无效的投票选项")
+	ErrPollInvalid       = errors.New("投票问题和选项不能为空，选项不能重复")
+)
+
+// PollService 视频投票：作者为视频附加一个限时投票，观看者每人投一票，结果实时统计
+type PollService struct {
+	pollRepo   *repository.PollRepository
+	videoRepo  *repository.VideoRepository
+	accessRepo *repository.VideoAccessRepository
+	txManager  *repository.TxManager
+}
+
+func NewPollService(pollRepo *repository.PollRepository, videoRepo *repository.VideoRepository, accessRepo *repository.VideoAccessRepository, txManager *repository.TxManager) *PollService {
+	return &PollService{pollRepo: pollRepo, videoRepo: videoRepo, accessRepo: accessRepo, txManager: txManager}
+}
+
+// Create 作者为视频创建投票，每个视频最多一个
+func (s *PollService) Create(ctx context.Context, videoID, authorID int64, req *dto.VideoPollCreateRequest) (*dto.VideoPollInfo, error) {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, authorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNoPermission
+		}
+		return nil, err
+	}
+	if video.LegalHoldAt != nil {
+		return nil, ErrLegalHold
+	}
+	if _, err := s.pollRepo.GetByVideo(ctx, videoID); err == nil {
+		return nil, ErrPollExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		return nil, ErrPollInvalid
+	}
+	seen := make(map[string]bool, len(req.Options))
+	options := make([]model.VideoPollOption, 0, len(req.Options))
+	for i, text := range req.Options {
+		text = strings.TrimSpace(text)
+		if text == "" || seen[text] {
+			return nil, ErrPollInvalid
+		}
+		seen[text] = true
+		options = append(options, model.VideoPollOption{Position: i, Text: text})
+	}
+
+	poll := &model.VideoPoll{
+		VideoID:  videoID,
+		Question: question,
+		EndsAt:   time.Now().Add(time.Duration(req.Duration) * time.Minute),
+		Options:  options,
+	}
+	if err := s.pollRepo.Create(ctx, poll); err != nil {
+		return nil, err
+	}
+	return loadPollInfo(ctx, s.pollRepo, poll, authorID)
+}
+
+// Get 获取视频的投票及实时结果
+func (s *PollService) Get(ctx context.Context, videoID, viewerID int64) (*dto.VideoPollInfo, error) {
+	if err := s.checkViewable(ctx, videoID, viewerID); err != nil {
+		return nil, err
+	}
+	poll, err := s.getPoll(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return loadPollInfo(ctx, s.pollRepo, poll, viewerID)
+}
+
+// Vote 投票，每人只能投一次且不能更改
+func (s *PollService) Vote(ctx context.Context, videoID, userID, optionID int64) (*dto.VideoPollInfo, error) {
+	if err := s.checkViewable(ctx, videoID, userID); err != nil {
+		return nil, err
+	}
+	poll, err := s.getPoll(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsClosed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+	valid := false
+	for _, opt := range poll.Options {
+		if opt.ID == optionID {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrPollOptionInvalid
+	}
+
+	created, err := s.pollRepo.Vote(ctx, &model.VideoPollVote{PollID: poll.ID, UserID: userID, OptionID: optionID})
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrPollAlreadyVoted
+	}
+	return loadPollInfo(ctx, s.pollRepo, poll, userID)
+}
+
+// Close 作者提前结束投票
+func (s *PollService) Close(ctx context.Context, videoID, authorID int64) (*dto.VideoPollInfo, error) {
+	poll, err := s.authorPoll(ctx, videoID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if poll.IsClosed(now) {
+		return nil, ErrPollClosed
+	}
+	if err := s.pollRepo.UpdateEndsAt(ctx, poll.ID, now); err != nil {
+		return nil, err
+	}
+	poll.EndsAt = now
+	return loadPollInfo(ctx, s.pollRepo, poll, authorID)
+}
+
+// Delete 作者删除投票及全部投票记录
+func (s *PollService) Delete(ctx context.Context, videoID, authorID int64) error {
+	poll, err := s.authorPoll(ctx, videoID, authorID)
+	if err != nil {
+		return err
+	}
+	return s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		return s.pollRepo.Delete(ctx, poll.ID)
+	})
+}
+
+// checkViewable 视频对当前用户不可见时返回错误，规则与视频详情一致
+func (s *PollService) checkViewable(ctx context.Context, videoID, viewerID int64) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+	if video.Status == VideoStatusHidden || video.LegalHoldAt != nil {
+		return ErrVideoNotFound
+	}
+	if video.AuthorID == viewerID {
+		return nil
+	}
+	if video.Status != "published" {
+		return ErrVideoNotFound
+	}
+	if ok, err := canViewVideo(ctx, s.accessRepo, video, viewerID); err != nil {
+		return err
+	} else if !ok {
+		return ErrVideoNotFound
+	}
+	if !video.AvailableIn(geoip.CountryFromContext(ctx)) {
+		return ErrVideoRegionRestricted
+	}
+	return nil
+}
+
+// authorPoll 获取作者本人视频的投票
+func (s *PollService) authorPoll(ctx context.Context, videoID, authorID int64) (*model.VideoPoll, error) {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, authorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNoPermission
+		}
+		return nil, err
+	}
+	if video.LegalHoldAt != nil {
+		return nil, ErrLegalHold
+	}
+	return s.getPoll(ctx, videoID)
+}
+
+func (s *PollService) getPoll(ctx context.Context, videoID int64) (*model.VideoPoll, error) {
+	poll, err := s.pollRepo.GetByVideo(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, err
+	}
+	return poll, nil
+}
+
+// fillPoll 为视频详情附带投票及实时结果
+func (s *VideoService) fillPoll(ctx context.Context, viewerID int64, info *dto.VideoInfo) error {
+	poll, err := s.pollRepo.GetByVideo(ctx, info.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	info.Poll, err = loadPollInfo(ctx, s.pollRepo, poll, viewerID)
+	return err
+}
+
+// loadPollInfo 统计投票结果并填充当前用户所投的选项
+func loadPollInfo(ctx context.Context, pollRepo *repository.PollRepository, poll *model.VideoPoll, viewerID int64) (*dto.VideoPollInfo, error) {
+	counts, err := pollRepo.CountVotes(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &dto.VideoPollInfo{
+		ID:       poll.ID,
+		VideoID:  poll.VideoID,
+		Question: poll.Question,
+		Options:  make([]dto.VideoPollOptionInfo, 0, len(poll.Options)),
+		EndsAt:   poll.EndsAt,
+		Closed:   poll.IsClosed(time.Now()),
+	}
+	for _, opt := range poll.Options {
+		info.Options = append(info.Options, dto.VideoPollOptionInfo{ID: opt.ID, Text: opt.Text, Votes: counts[opt.ID]})
+		info.TotalVotes += counts[opt.ID]
+	}
+
+	if viewerID != 0 {
+		optionID, err := pollRepo.GetUserVote(ctx, poll.ID, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if optionID != 0 {
+			info.VotedOptionID = &optionID
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/eventbus"
+	"vida-go/internal/infra/geoip"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+	"vida-go/pkg/sensitive"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPremiereDisabled      = errors.New("首映功能未开启")
+	ErrPremiereNotFound      = errors.New("该视频没有预约首映")
+	ErrPremiereNotAllowed    = errors.New("只能为尚未发布的视频预约首映")
+	ErrPremiereTimeInvalid   = errors.New("首映时间不在可预约的范围内")
+	ErrPremiereStarted       = errors.New("首映已开始")
+	ErrPremiereScheduled     = errors.New("视频已预约首映，请先取消首映")
+	ErrPremiereChatClosed    = errors.New("首映聊天室未开放")
+	ErrPremiereChatInvalid   = errors.New("聊天内容不能为空或过长")
+	ErrPremiereChatRateLimit = errors.New("发送过于频繁，请稍后再试")
+)
+
+// 首映聊天室事件类型
+const (
+	PremiereEventStarted = "premiere.started"
+	PremiereEventChat    = "chat"
+)
+
+const (
+	premiereMinLead         = 5 * time.Minute // 首映时间至少晚于当前时间
+	premiereJobBatch        = 100
+	premiereChatMaxRunes    = 200
+	premiereChatInterval    = time.Second // 同一用户在同一首映中发言的最小间隔
+	premiereChatHistorySize = 50
+	premiereChatStreamLen   = 1000
+	premiereChatStreamTTL   = 24 * time.Hour
+)
+
+// premiereVisibleStatuses 观众可以看到首映倒计时页的视频状态（转码完成前也可预告）
+var premiereVisibleStatuses = map[string]bool{
+	"pending": true, "transcoding": true, VideoStatusScheduled: true, "published": true,
+}
+
+// PremiereService 首映：作者为尚未发布的视频预约首映时间，转码完成后视频处于 scheduled 状态，
+// 到首映时间由定时任务发布。首映窗口内观众按服务器时间同步播放，并通过首映聊天室实时聊天；
+// 聊天消息与首映开始事件写入每个首映独立的 Redis Stream，多实例部署时各实例读取同一个流
+type PremiereService struct {
+	videoRepo    *repository.VideoRepository
+	userRepo     *repository.UserRepository
+	accessRepo   *repository.VideoAccessRepository
+	eventBus     *eventbus.Bus
+	eventService *EventService
+	emailService *EmailService
+	client       *redis.Client
+}
+
+func NewPremiereService(
+	videoRepo *repository.VideoRepository,
+	userRepo *repository.UserRepository,
+	accessRepo *repository.VideoAccessRepository,
+	eventBus *eventbus.Bus,
+	eventService *EventService,
+	emailService *EmailService,
+	client *redis.Client,
+) *PremiereService {
+	return &PremiereService{
+		videoRepo:    videoRepo,
+		userRepo:     userRepo,
+		accessRepo:   accessRepo,
+		eventBus:     eventBus,
+		eventService: eventService,
+		emailService: emailService,
+		client:       client,
+	}
+}
+
+func premiereChatStreamKey(videoID int64) string {
+	return fmt.Sprintf("premiere:chat:%d", videoID)
+}
+
+func premiereChatRateKey(videoID, userID int64) string {
+	return fmt.Sprintf("premiere:chat:rate:%d:%d", videoID, userID)
+}
+
+// Schedule 作者为尚未发布的视频预约（或修改）首映时间
+func (s *PremiereService) Schedule(ctx context.Context, videoID, authorID int64, at time.Time) (*dto.PremiereInfo, error) {
+	cfg := config.GetPremiere()
+	if !cfg.Enabled {
+		return nil, ErrPremiereDisabled
+	}
+	video, err := s.authorVideo(ctx, videoID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	switch video.Status {
+	case "pending", "transcoding", VideoStatusScheduled:
+	default:
+		return nil, ErrPremiereNotAllowed
+	}
+	now := time.Now()
+	if at.Before(now.Add(premiereMinLead)) || at.After(now.Add(cfg.MaxLead())) {
+		return nil, ErrPremiereTimeInvalid
+	}
+
+	video, err = s.videoRepo.Update(ctx, videoID, map[string]interface{}{"premiere_at": at})
+	if err != nil {
+		return nil, err
+	}
+	return s.toPremiereInfo(video, time.Now()), nil
+}
+
+// Cancel 作者取消首映：已转码等待首映的视频立即发布，尚未转码完成的视频转码后按普通视频发布
+func (s *PremiereService) Cancel(ctx context.Context, videoID, authorID int64) error {
+	video, err := s.authorVideo(ctx, videoID, authorID)
+	if err != nil {
+		return err
+	}
+	if video.PremiereAt == nil {
+		return ErrPremiereNotFound
+	}
+	if video.Status == "published" {
+		return ErrPremiereStarted
+	}
+
+	updates := map[string]interface{}{"premiere_at": nil}
+	if video.Status == VideoStatusScheduled {
+		updates["status"] = "published"
+		updates["publish_time"] = time.Now()
+	}
+	if _, err := s.videoRepo.Update(ctx, videoID, updates); err != nil {
+		return err
+	}
+	if video.Status == VideoStatusScheduled {
+		s.onPublished(ctx, video)
+	}
+	return nil
+}
+
+// Get 获取首映倒计时页信息；首映中时返回所有观众同步的播放位置
+func (s *PremiereService) Get(ctx context.Context, videoID, viewerID int64) (*dto.PremiereInfo, error) {
+	video, err := s.viewableVideo(ctx, videoID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPremiereInfo(video, time.Now()), nil
+}
+
+// SendChat 在首映聊天室发言（首映开始前的等候时段及首映中开放）
+func (s *PremiereService) SendChat(ctx context.Context, videoID, userID int64, text string) (*dto.PremiereChatMessage, error) {
+	video, err := s.viewableVideo(ctx, videoID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.toPremiereInfo(video, time.Now()).ChatOpen {
+		return nil, ErrPremiereChatClosed
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > premiereChatMaxRunes {
+		return nil, ErrPremiereChatInvalid
+	}
+	if err := ensureNotMuted(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	acquired, err := s.client.SetNX(ctx, premiereChatRateKey(videoID, userID), 1, premiereChatInterval).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrPremiereChatRateLimit
+	}
+
+	text, _ = sensitive.Mask(text)
+	msg := &dto.PremiereChatMessage{
+		User:      toUserBriefInfo(user),
+		Text:      text,
+		CreatedAt: time.Now(),
+	}
+	id, err := s.publish(ctx, videoID, PremiereEventChat, msg)
+	if err != nil {
+		return nil, err
+	}
+	msg.ID = id
+	return msg, nil
+}
+
+// RecentEvents 返回首映聊天室最近的事件（正序）及当前最新的事件 ID，新连接据此续读
+func (s *PremiereService) RecentEvents(ctx context.Context, videoID int64) ([]dto.UserEvent, string, error) {
+	msgs, err := s.client.XRevRangeN(ctx, premiereChatStreamKey(videoID), "+", "-", premiereChatHistorySize).Result()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(msgs) == 0 {
+		return nil, "0-0", nil
+	}
+	events := make([]dto.UserEvent, 0, len(msgs))
+	for i := len(msgs) - 1; i >= 0; i-- {
+		events = append(events, toStreamEvent(msgs[i]))
+	}
+	return events, msgs[0].ID, nil
+}
+
+// ReadEvents 读取首映聊天室 afterID 之后的事件，没有新事件时最多阻塞 block 时长
+func (s *PremiereService) ReadEvents(ctx context.Context, videoID int64, afterID string, block time.Duration) ([]dto.UserEvent, error) {
+	streams, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{premiereChatStreamKey(videoID), afterID},
+		Count:   100,
+		Block:   block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var events []dto.UserEvent
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			events = append(events, toStreamEvent(msg))
+		}
+	}
+	return events, nil
+}
+
+// StartDuePremieres 发布首映时间已到的视频，发布时间记为首映时间，并通知首映聊天室。
+// 发布时校验视频仍为 scheduled，多实例同时执行时每个视频只会被发布一次
+func (s *PremiereService) StartDuePremieres(ctx context.Context) error {
+	videos, err := s.videoRepo.ListDuePremieres(ctx, time.Now(), premiereJobBatch)
+	if err != nil {
+		return err
+	}
+	for i := range videos {
+		video := &videos[i]
+		started, err := s.videoRepo.StartPremiere(ctx, video.ID)
+		if err != nil {
+			return err
+		}
+		if !started {
+			continue
+		}
+		video.Status = "published"
+		video.PublishTime = video.PremiereAt
+		s.onPublished(ctx, video)
+		if _, err := s.publish(ctx, video.ID, PremiereEventStarted, s.toPremiereInfo(video, time.Now())); err != nil {
+			logger.FromContext(ctx).Warn("Publish premiere started event failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		}
+		logger.FromContext(ctx).Info("Premiere started", zap.Int64("video_id", video.ID))
+	}
+	return nil
+}
+
+// onPublished 等待首映的视频发布后：发布视频发布事件，通知作者
+func (s *PremiereService) onPublished(ctx context.Context, video *model.Video) {
+	s.eventBus.Publish(ctx, &eventbus.VideoPublished{VideoID: video.ID, AuthorID: video.AuthorID, PublishedAt: time.Now()})
+	s.eventService.Publish(ctx, video.AuthorID, EventTypeUploadStatus, &dto.UploadStatusEvent{
+		VideoID:  video.ID,
+		Status:   "published",
+		PlayURL:  video.PlayURL,
+		CoverURL: video.CoverURL,
+	})
+	s.emailService.Notify(ctx, video.AuthorID, EmailKindVideoPublished, "video_published", map[string]interface{}{
+		"Title": video.Title,
+	})
+}
+
+// publish 向首映聊天室追加一条事件，返回事件 ID
+func (s *PremiereService) publish(ctx context.Context, videoID int64, eventType string, payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	key := premiereChatStreamKey(videoID)
+	pipe := s.client.TxPipeline()
+	add := pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: premiereChatStreamLen,
+		Approx: true,
+		Values: map[string]interface{}{"type": eventType, "data": data},
+	})
+	pipe.Expire(ctx, key, premiereChatStreamTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return add.Val(), nil
+}
+
+// authorVideo 获取作者本人的视频，处于法律保全中时不可修改
+func (s *PremiereService) authorVideo(ctx context.Context, videoID, authorID int64) (*model.Video, error) {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, authorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNoPermission
+		}
+		return nil, err
+	}
+	if err := checkVideoLegalHold(ctx, s.userRepo, video); err != nil {
+		return nil, err
+	}
+	return video, nil
+}
+
+// viewableVideo 获取当前用户可见的首映视频，私密视频仅作者及被授权的用户可见
+func (s *PremiereService) viewableVideo(ctx context.Context, videoID, viewerID int64) (*model.Video, error) {
+	video, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.Status == VideoStatusHidden || video.LegalHoldAt != nil {
+		return nil, ErrVideoNotFound
+	}
+	if video.PremiereAt == nil {
+		return nil, ErrPremiereNotFound
+	}
+	if video.AuthorID == viewerID {
+		return video, nil
+	}
+	if !premiereVisibleStatuses[video.Status] {
+		return nil, ErrVideoNotFound
+	}
+	if ok, err := canViewVideo(ctx, s.accessRepo, video, viewerID); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrVideoNotFound
+	}
+	if !video.AvailableIn(geoip.CountryFromContext(ctx)) {
+		return nil, ErrVideoRegionRestricted
+	}
+	return video, nil
+}
+
+// toPremiereInfo 按服务器时间计算首映阶段与同步播放位置。首映时间已到但定时任务尚未发布时
+// 仍视为倒计时（即将开始）
+func (s *PremiereService) toPremiereInfo(video *model.Video, now time.Time) *dto.PremiereInfo {
+	info := &dto.PremiereInfo{
+		VideoID:    video.ID,
+		Title:      video.Title,
+		CoverURL:   video.CoverURL,
+		Duration:   video.Duration,
+		PremiereAt: *video.PremiereAt,
+		EndsAt:     video.PremiereEndsAt(),
+		State:      video.PremiereState(now),
+		ServerTime: now,
+	}
+	if video.Status != "published" {
+		info.State = model.PremiereStateUpcoming
+	}
+	switch info.State {
+	case model.PremiereStateUpcoming:
+		if d := video.PremiereAt.Sub(now); d > 0 {
+			info.StartsIn = int64(d.Round(time.Second) / time.Second)
+		}
+		info.ChatOpen = !now.Before(video.PremiereAt.Add(-config.GetPremiere().Lobby()))
+	case model.PremiereStateLive:
+		info.Position = now.Sub(*video.PremiereAt).Seconds()
+		info.ChatOpen = true
+	}
+	if video.Author.ID != 0 {
+		info.Author = &dto.AuthorBrief{ID: video.Author.ID, Username: video.Author.UserName, Avatar: video.Author.Avatar}
+	}
+	return info
+}
+
+// toStreamEvent 将首映聊天室的 Stream 消息转换为事件
+func toStreamEvent(msg redis.XMessage) dto.UserEvent {
+	eventType, _ := msg.Values["type"].(string)
+	data, _ := msg.Values["data"].(string)
+	return dto.UserEvent{ID: msg.ID, Type: eventType, Data: json.RawMessage(data)}
+}
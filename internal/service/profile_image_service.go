@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	infraAgent "vida-go/internal/infra/agent"
+	"vida-go/internal/model"
+	"vida-go/internal/rbac"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const profileImageReviewTimeout = time.Minute
+
+var (
+	ErrProfileImageReviewNotFound = errors.New("图片审核记录不存在")
+	ErrProfileImageReviewed       = errors.New("该图片已处理")
+	ErrInvalidProfileImageStatus  = errors.New("无效的图片审核状态")
+)
+
+// ProfileImageService 头像、主页背景图审核：新图片先进入待审核状态，主页继续展示旧图；
+// Agent 服务审核通过后上线，驳回时保留旧图并通知用户。Agent 无法判定时由版主处理，
+// 版主也可以驳回已上线的图片，此时恢复为提交前的图片
+type ProfileImageService struct {
+	reviewRepo          *repository.ProfileImageReviewRepository
+	userRepo            *repository.UserRepository
+	userCache           *UserCache
+	notificationService *NotificationService
+	txManager           *repository.TxManager
+}
+
+func NewProfileImageService(
+	reviewRepo *repository.ProfileImageReviewRepository,
+	userRepo *repository.UserRepository,
+	userCache *UserCache,
+	notificationService *NotificationService,
+	txManager *repository.TxManager,
+) *ProfileImageService {
+	return &ProfileImageService{
+		reviewRepo:          reviewRepo,
+		userRepo:            userRepo,
+		userCache:           userCache,
+		notificationService: notificationService,
+		txManager:           txManager,
+	}
+}
+
+// NeedsReview 新图片是否需要先审核：清空图片和管理员设置的图片直接生效
+func (s *ProfileImageService) NeedsReview(currentUser *dto.UserInfo, url string) bool {
+	return config.GetAgent().ModerateProfileImages && url != "" &&
+		!rbac.HasPermission(currentUser.UserRole, rbac.PermManageUsers)
+}
+
+// Submit 提交新图片等待审核，替换该类型尚未审核完的图片，并异步交给 Agent 服务审核
+func (s *ProfileImageService) Submit(ctx context.Context, user *model.User, kind, url string) error {
+	review := &model.ProfileImageReview{
+		UserID:      user.ID,
+		Kind:        kind,
+		ImageURL:    url,
+		PreviousURL: user.Avatar,
+	}
+	if kind == model.ProfileImageBackground {
+		review.PreviousURL = user.BackgroundImage
+	}
+
+	err := s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.reviewRepo.SupersedePending(ctx, user.ID, kind); err != nil {
+			return err
+		}
+		return s.reviewRepo.Create(ctx, review)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.autoReview(ctx, review)
+	return nil
+}
+
+// Discard 图片被直接修改（清空或管理员设置）时，作废该类型待审核的图片
+func (s *ProfileImageService) Discard(ctx context.Context, userID int64, kind string) error {
+	if !config.GetAgent().ModerateProfileImages {
+		return nil
+	}
+	return s.reviewRepo.SupersedePending(ctx, userID, kind)
+}
+
+// FillPending 为本人的用户信息附带审核中的图片
+func (s *ProfileImageService) FillPending(ctx context.Context, info *dto.UserFullInfo) error {
+	reviews, err := s.reviewRepo.ListPendingByUser(ctx, info.ID)
+	if err != nil {
+		return err
+	}
+	for i := range reviews {
+		url := reviews[i].ImageURL
+		if reviews[i].Kind == model.ProfileImageBackground {
+			info.PendingBackgroundImage = &url
+		} else {
+			info.PendingAvatar = &url
+		}
+	}
+	return nil
+}
+
+// autoReview 异步调用 Agent 服务审核，无法判定或调用失败时保持待审核，等待版主处理
+func (s *ProfileImageService) autoReview(ctx context.Context, review *model.ProfileImageReview) {
+	if !infraAgent.Enabled() {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), profileImageReviewTimeout)
+		defer cancel()
+		log := logger.FromContext(ctx).With(zap.Int64("review_id", review.ID), zap.Int64("user_id", review.UserID))
+
+		result, err := infraAgent.ModerateImage(ctx, &infraAgent.ImageRequest{
+			UserID: review.UserID,
+			Kind:   review.Kind,
+			URL:    review.ImageURL,
+		})
+		if err != nil {
+			log.Warn("Moderate profile image failed", zap.Error(err))
+			return
+		}
+
+		switch result.Decision {
+		case infraAgent.ImageDecisionApprove:
+			err = s.approve(ctx, review, nil)
+		case infraAgent.ImageDecisionReject:
+			err = s.reject(ctx, review, nil, cutRunes(result.Reason, 500))
+		default:
+			log.Info("Profile image needs manual review", zap.String("decision", result.Decision))
+			return
+		}
+		if err != nil && !errors.Is(err, ErrProfileImageReviewed) {
+			log.Warn("Resolve profile image review failed", zap.Error(err))
+		}
+	}()
+}
+
+// ListReviews 按状态分页获取审核记录，默认待审核
+func (s *ProfileImageService) ListReviews(ctx context.Context, status string, page, pageSize int) (*dto.ProfileImageReviewListData, error) {
+	switch status {
+	case "":
+		status = model.ProfileImagePending
+	case model.ProfileImagePending, model.ProfileImageApproved, model.ProfileImageRejected, model.ProfileImageSuperseded:
+	default:
+		return nil, ErrInvalidProfileImageStatus
+	}
+
+	skip := (page - 1) * pageSize
+	reviews, total, err := s.reviewRepo.List(ctx, status, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ProfileImageReviewInfo, 0, len(reviews))
+	for i := range reviews {
+		r := &reviews[i]
+		items = append(items, dto.ProfileImageReviewInfo{
+			ID:          r.ID,
+			User:        toUserBriefInfo(&r.User),
+			Kind:        r.Kind,
+			ImageURL:    r.ImageURL,
+			PreviousURL: r.PreviousURL,
+			Status:      r.Status,
+			Reason:      r.Reason,
+			ReviewedBy:  r.ReviewedBy,
+			ReviewedAt:  r.ReviewedAt,
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+
+	return &dto.ProfileImageReviewListData{
+		Reviews:    items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// Approve 版主通过待审核的图片
+func (s *ProfileImageService) Approve(ctx context.Context, reviewID, moderatorID int64) error {
+	review, err := s.getReview(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	return s.approve(ctx, review, &moderatorID)
+}
+
+// Reject 版主驳回图片：待审核的图片直接作废，已上线的图片恢复为提交前的图片
+func (s *ProfileImageService) Reject(ctx context.Context, reviewID, moderatorID int64, reason string) error {
+	review, err := s.getReview(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	return s.reject(ctx, review, &moderatorID, reason)
+}
+
+func (s *ProfileImageService) getReview(ctx context.Context, reviewID int64) (*model.ProfileImageReview, error) {
+	review, err := s.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProfileImageReviewNotFound
+		}
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *ProfileImageService) approve(ctx context.Context, review *model.ProfileImageReview, reviewedBy *int64) error {
+	err := s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		ok, err := s.reviewRepo.Resolve(ctx, review.ID, model.ProfileImagePending, model.ProfileImageApproved, reviewedBy, "")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrProfileImageReviewed
+		}
+		_, err = s.userRepo.Update(ctx, review.UserID, map[string]interface{}{review.Column(): review.ImageURL})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.userCache.Invalidate(ctx, review.UserID)
+	return nil
+}
+
+func (s *ProfileImageService) reject(ctx context.Context, review *model.ProfileImageReview, reviewedBy *int64, reason string) error {
+	from := review.Status
+	if from != model.ProfileImagePending && from != model.ProfileImageApproved {
+		return ErrProfileImageReviewed
+	}
+
+	reverted := false
+	err := s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		ok, err := s.reviewRepo.Resolve(ctx, review.ID, from, model.ProfileImageRejected, reviewedBy, reason)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrProfileImageReviewed
+		}
+		if from != model.ProfileImageApproved {
+			return nil
+		}
+		// 用户之后又换过图片时不覆盖
+		reverted, err = s.userRepo.SwapImage(ctx, review.UserID, review.Column(), review.ImageURL, review.PreviousURL)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if reverted {
+		s.userCache.Invalidate(ctx, review.UserID)
+	}
+
+	s.notificationService.EmitSystem(ctx, review.UserID, profileImageRejectedMessage(review.Kind, reason))
+	return nil
+}
+
+func profileImageRejectedMessage(kind, reason string) string {
+	what := "头像"
+	if kind == model.ProfileImageBackground {
+		what = "主页背景图"
+	}
+	msg := "您上传的" + what + "未通过审核，已恢复为原来的" + what
+	if reason != "" {
+		msg += "。原因：" + reason
+	}
+	return msg
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"vida-go/internal/api/dto"
+)
+
+// ProfileService 用户主页：聚合公开信息、关注状态、置顶视频与最新视频，减少客户端请求次数
+type ProfileService struct {
+	userService     *UserService
+	relationService *RelationService
+	videoService    *VideoService
+}
+
+func NewProfileService(userService *UserService, relationService *RelationService, videoService *VideoService) *ProfileService {
+	return &ProfileService{
+		userService:     userService,
+		relationService: relationService,
+		videoService:    videoService,
+	}
+}
+
+// GetHome 获取用户主页，viewerID 为 0 表示未登录
+func (s *ProfileService) GetHome(ctx context.Context, userID, viewerID int64, page, pageSize int) (*dto.ProfileHomeData, error) {
+	user, err := s.userService.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data := &dto.ProfileHomeData{User: user}
+
+	if viewerID > 0 && viewerID != userID {
+		following, err := s.relationService.GetFollowStatus(ctx, viewerID, userID)
+		if err != nil {
+			return nil, err
+		}
+		data.IsFollowing = &following
+	}
+
+	data.PinnedVideo, err = s.videoService.GetPinnedVideo(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.Videos, err = s.videoService.GetUserLatestVideos(ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	videos := data.Videos.Videos
+	if data.PinnedVideo != nil {
+		videos = append([]dto.VideoInfo{*data.PinnedVideo}, videos...)
+	}
+	if err := s.videoService.ApplyContentGate(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+	if viewerID > 0 {
+		if err := s.videoService.FillViewerState(ctx, viewerID, videos); err != nil {
+			return nil, err
+		}
+	}
+	if data.PinnedVideo != nil {
+		data.PinnedVideo = &videos[0]
+		copy(data.Videos.Videos, videos[1:])
+	}
+	return data, nil
+}
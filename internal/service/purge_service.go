@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"vida-go/internal/config"
+	infraES "vida-go/internal/infra/elasticsearch"
+	infraMinio "vida-go/internal/infra/minio"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// publicVideoBucket 转码产出（播放文件、封面、预览帧、各清晰度）所在的 Bucket
+const publicVideoBucket = "public-videos"
+
+// profileImageBuckets 用户上传的头像、背景图所在的 Bucket，不在其中的图片地址（如外部链接）不删除
+var profileImageBuckets = map[string]bool{"user-avatars": true, "user-banners": true}
+
+// PurgeService 软删除数据清理：删除超过保留期的用户、视频被永久删除，连同关联记录、
+// 搜索索引文档与对象存储文件（原始文件、转码产出、冷存储归档、头像背景图）。
+// 先删外部数据再删数据库记录，中途失败时下次执行会重新选中并续做
+type PurgeService struct {
+	purgeRepo *repository.PurgeRepository
+	videoRepo *repository.VideoRepository
+	userRepo  *repository.UserRepository
+}
+
+func NewPurgeService(purgeRepo *repository.PurgeRepository, videoRepo *repository.VideoRepository, userRepo *repository.UserRepository) *PurgeService {
+	return &PurgeService{purgeRepo: purgeRepo, videoRepo: videoRepo, userRepo: userRepo}
+}
+
+// PurgeReport 一次清理的报告；DryRun 时各数量为将被清理的数量
+type PurgeReport struct {
+	DryRun     bool             `json:"dry_run"`
+	Before     time.Time        `json:"before"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	UserIDs    []int64          `json:"user_ids"`
+	VideoIDs   []int64          `json:"video_ids"`
+	Rows       map[string]int64 `json:"rows"`
+	Objects    int              `json:"objects"`
+	SearchDocs int              `json:"search_docs"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// RunPurge 定时任务：清理一轮，配置了报告目录时写入报告（清理出错时也写入已完成部分）
+func (s *PurgeService) RunPurge(ctx context.Context, cfg *config.PurgeConfig) error {
+	report, err := s.Purge(ctx, cfg.Retention(), cfg.Batch(), cfg.DryRun)
+	if cfg.ReportDir != "" {
+		if path, err := WritePurgeReport(cfg.ReportDir, report); err != nil {
+			logger.FromContext(ctx).Warn("Write purge report failed", zap.Error(err))
+		} else {
+			logger.FromContext(ctx).Info("Purge report written", zap.String("path", path))
+		}
+	}
+	return err
+}
+
+// Purge 永久删除软删除超过 retention 的用户（连同其全部视频）与视频，处于法律保全中的跳过。
+// 出错时停止并返回已完成部分的报告
+func (s *PurgeService) Purge(ctx context.Context, retention time.Duration, batch int, dryRun bool) (*PurgeReport, error) {
+	report := &PurgeReport{
+		DryRun:    dryRun,
+		Before:    time.Now().Add(-retention),
+		StartedAt: time.Now(),
+		UserIDs:   []int64{},
+		VideoIDs:  []int64{},
+		Rows:      make(map[string]int64),
+	}
+	err := s.purge(ctx, report, batch)
+	report.FinishedAt = time.Now()
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	logger.FromContext(ctx).Info("Purge soft-deleted records finished",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("users", len(report.UserIDs)),
+		zap.Int("videos", len(report.VideoIDs)),
+		zap.Any("rows", report.Rows),
+		zap.Int("objects", report.Objects),
+		zap.Int("search_docs", report.SearchDocs),
+		zap.Duration("duration", report.FinishedAt.Sub(report.StartedAt)))
+	return report, err
+}
+
+func (s *PurgeService) purge(ctx context.Context, report *PurgeReport, batch int) error {
+	var afterID int64
+	for {
+		users, err := s.purgeRepo.ListPurgeableUsers(ctx, report.Before, afterID, batch)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			break
+		}
+		if err := s.purgeUsers(ctx, report, users); err != nil {
+			return err
+		}
+		afterID = users[len(users)-1].ID
+	}
+
+	purgedUsers := make(map[int64]bool, len(report.UserIDs))
+	for _, id := range report.UserIDs {
+		purgedUsers[id] = true
+	}
+
+	afterID = 0
+	for {
+		videos, err := s.purgeRepo.ListPurgeableVideos(ctx, report.Before, afterID, batch)
+		if err != nil {
+			return err
+		}
+		if len(videos) == 0 {
+			return nil
+		}
+		afterID = videos[len(videos)-1].ID
+		// dry run 时随作者统计过的视频仍在库中，不重复统计
+		pending := videos[:0]
+		for _, v := range videos {
+			if !purgedUsers[v.AuthorID] {
+				pending = append(pending, v)
+			}
+		}
+		if err := s.purgeVideos(ctx, report, pending); err != nil {
+			return err
+		}
+	}
+}
+
+// purgeUsers 先清理用户的全部视频，再删除头像背景图、搜索文档与用户记录，最后重新计算受影响的计数
+func (s *PurgeService) purgeUsers(ctx context.Context, report *PurgeReport, users []model.User) error {
+	ids := make([]int64, len(users))
+	for i := range users {
+		ids[i] = users[i].ID
+	}
+
+	videos, err := s.purgeRepo.ListVideosByAuthors(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if err := s.purgeVideos(ctx, report, videos); err != nil {
+		return err
+	}
+
+	images, err := s.purgeRepo.ListProfileImageURLs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range users {
+		if users[i].Avatar != nil {
+			images = append(images, *users[i].Avatar)
+		}
+		if users[i].BackgroundImage != nil {
+			images = append(images, *users[i].BackgroundImage)
+		}
+	}
+	seen := make(map[string]bool, len(images))
+	for _, url := range images {
+		bucket, object, ok := infraMinio.ParsePublicURL(url)
+		if !ok || !profileImageBuckets[bucket] || seen[url] {
+			continue
+		}
+		seen[url] = true
+		if !report.DryRun {
+			if err := infraMinio.RemoveObject(ctx, bucket, object); err != nil {
+				return err
+			}
+		}
+		report.Objects++
+	}
+
+	if infraES.Get() != nil {
+		if !report.DryRun {
+			for _, id := range ids {
+				if err := infraES.DeleteUser(ctx, id); err != nil {
+					return fmt.Errorf("delete user %d from search index: %w", id, err)
+				}
+			}
+		}
+		report.SearchDocs += len(ids)
+	}
+
+	videoIDs, userIDs, err := s.purgeRepo.AffectedByUsers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	counts, err := s.purgeRepo.PurgeUsers(ctx, ids, report.DryRun)
+	if err != nil {
+		return err
+	}
+	mergeCounts(report.Rows, counts)
+	report.UserIDs = append(report.UserIDs, ids...)
+
+	if report.DryRun {
+		return nil
+	}
+	return s.recount(ctx, videoIDs, userIDs)
+}
+
+// purgeVideos 删除视频的对象存储文件、搜索文档与数据库记录，最后重新计算受影响的计数
+func (s *PurgeService) purgeVideos(ctx context.Context, report *PurgeReport, videos []model.Video) error {
+	if len(videos) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(videos))
+	for i := range videos {
+		ids[i] = videos[i].ID
+	}
+
+	for i := range videos {
+		n, err := purgeVideoObjects(ctx, &videos[i], report.DryRun)
+		if err != nil {
+			return fmt.Errorf("remove objects of video %d: %w", videos[i].ID, err)
+		}
+		report.Objects += n
+	}
+
+	if infraES.Get() != nil {
+		if !report.DryRun {
+			for _, id := range ids {
+				if err := infraES.DeleteVideo(ctx, id); err != nil {
+					return fmt.Errorf("delete video %d from search index: %w", id, err)
+				}
+			}
+		}
+		report.SearchDocs += len(ids)
+	}
+
+	userIDs, err := s.purgeRepo.AffectedByVideos(ctx, ids)
+	if err != nil {
+		return err
+	}
+	counts, err := s.purgeRepo.PurgeVideos(ctx, ids, report.DryRun)
+	if err != nil {
+		return err
+	}
+	mergeCounts(report.Rows, counts)
+	report.VideoIDs = append(report.VideoIDs, ids...)
+
+	if report.DryRun {
+		return nil
+	}
+	return s.recount(ctx, nil, userIDs)
+}
+
+// recount 清理点赞、评论、关注记录后重新计算相关视频与用户的冗余计数
+func (s *PurgeService) recount(ctx context.Context, videoIDs, userIDs []int64) error {
+	if _, err := s.videoRepo.RecountFavoriteCounts(ctx, videoIDs); err != nil {
+		return err
+	}
+	if _, err := s.videoRepo.RecountCommentCounts(ctx, videoIDs); err != nil {
+		return err
+	}
+	if _, err := s.userRepo.RecountFollowCounts(ctx, userIDs); err != nil {
+		return err
+	}
+	if _, err := s.userRepo.RecountTotalFavorited(ctx, userIDs); err != nil {
+		return err
+	}
+	_, err := s.userRepo.RecountFavoriteCounts(ctx, userIDs)
+	return err
+}
+
+// purgeVideoObjects 删除（dryRun 时统计）视频的原始文件、转码产出与冷存储归档，返回对象数
+func purgeVideoObjects(ctx context.Context, video *model.Video, dryRun bool) (int, error) {
+	type location struct{ bucket, object string }
+	var objects []location
+	if video.FileFormat != "" {
+		raw := rawObjectName(video.AuthorID, video.ID, video.FileFormat)
+		names, err := infraMinio.ListObjects(ctx, rawVideoBucket, raw)
+		if err != nil {
+			return 0, err
+		}
+		for _, name := range names {
+			if name == raw {
+				objects = append(objects, location{rawVideoBucket, raw})
+			}
+		}
+	}
+
+	prefix := fmt.Sprintf("videos/%d/", video.ID)
+	for _, bucket := range []string{publicVideoBucket, config.GetColdStorage().ColdBucket()} {
+		names, err := infraMinio.ListObjects(ctx, bucket, prefix)
+		if err != nil {
+			return 0, err
+		}
+		for _, name := range names {
+			objects = append(objects, location{bucket, name})
+		}
+	}
+
+	if !dryRun {
+		for _, o := range objects {
+			if err := infraMinio.RemoveObject(ctx, o.bucket, o.object); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(objects), nil
+}
+
+func mergeCounts(dst, src map[string]int64) {
+	for k, v := range src {
+		dst[k] += v
+	}
+}
+
+// WritePurgeReport 将报告写入 dir/purge-<UTC 时间>.json，返回文件路径
+func WritePurgeReport(dir string, report *PurgeReport) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "purge-"+report.StartedAt.UTC().Format("20060102T150405Z")+".json")
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"vida-go/internal/api/dto"
+	infraPush "vida-go/internal/infra/push"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxDevicesPerUser = 10
+	pushSendTimeout   = 15 * time.Second
+	pushBodyMaxRunes  = 100
+)
+
+var ErrDeviceNotFound = errors.New("设备不存在")
+
+// PushService 移动端推送：管理设备令牌，并将通知推送到用户的所有设备
+type PushService struct {
+	deviceRepo *repository.DeviceTokenRepository
+	userRepo   *repository.UserRepository
+}
+
+func NewPushService(deviceRepo *repository.DeviceTokenRepository, userRepo *repository.UserRepository) *PushService {
+	return &PushService{deviceRepo: deviceRepo, userRepo: userRepo}
+}
+
+// RegisterDevice 注册设备令牌，超出上限时移除最久未活跃的设备
+func (s *PushService) RegisterDevice(ctx context.Context, userID int64, req *dto.DeviceRegisterRequest) (*dto.DeviceInfo, error) {
+	device := &model.DeviceToken{UserID: userID, Platform: req.Platform, Token: req.Token}
+	if err := s.deviceRepo.Upsert(ctx, device); err != nil {
+		return nil, err
+	}
+
+	devices, err := s.deviceRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) > maxDevicesPerUser {
+		stale := make([]int64, 0, len(devices)-maxDevicesPerUser)
+		for _, d := range devices[maxDevicesPerUser:] {
+			stale = append(stale, d.ID)
+		}
+		_ = s.deviceRepo.DeleteByIDs(ctx, stale)
+	}
+
+	for i := range devices {
+		if devices[i].Token == req.Token {
+			return toDeviceInfo(&devices[i]), nil
+		}
+	}
+	return toDeviceInfo(device), nil
+}
+
+// UnregisterDevice 注销设备令牌（退出登录时调用）
+func (s *PushService) UnregisterDevice(ctx context.Context, userID int64, token string) error {
+	deleted, err := s.deviceRepo.Delete(ctx, userID, token)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+// ListDevices 获取当前用户已注册的设备
+func (s *PushService) ListDevices(ctx context.Context, userID int64) ([]dto.DeviceInfo, error) {
+	devices, err := s.deviceRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dto.DeviceInfo, 0, len(devices))
+	for i := range devices {
+		items = append(items, *toDeviceInfo(&devices[i]))
+	}
+	return items, nil
+}
+
+// NotifyUser 将互动通知推送到用户的所有设备（异步、尽力而为）
+func (s *PushService) NotifyUser(ctx context.Context, n *model.Notification) {
+	if !infraPush.Enabled() {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, pushSendTimeout)
+		defer cancel()
+
+		devices, err := s.deviceRepo.ListByUser(ctx, n.UserID)
+		if err != nil || len(devices) == 0 {
+			return
+		}
+		msg := s.buildMessage(ctx, n)
+		if msg == nil {
+			return
+		}
+
+		var invalid []int64
+		for _, d := range devices {
+			err := infraPush.Send(ctx, d.Platform, d.Token, msg)
+			if errors.Is(err, infraPush.ErrInvalidToken) {
+				invalid = append(invalid, d.ID)
+			} else if err != nil {
+				logger.FromContext(ctx).Warn("Push notification failed",
+					zap.Int64("user_id", n.UserID), zap.String("platform", d.Platform), zap.Error(err))
+			}
+		}
+		if err := s.deviceRepo.DeleteByIDs(ctx, invalid); err != nil {
+			logger.FromContext(ctx).Warn("Remove invalid push tokens failed", zap.Int64("user_id", n.UserID), zap.Error(err))
+		}
+	}()
+}
+
+// buildMessage 生成推送文案，仅推送点赞、评论、回复、关注
+func (s *PushService) buildMessage(ctx context.Context, n *model.Notification) *infraPush.Message {
+	actorName := "有人"
+	if actor, err := s.userRepo.GetByID(ctx, n.ActorID); err == nil {
+		actorName = actor.UserName
+	}
+
+	msg := &infraPush.Message{
+		Title: "Vida",
+		Data: map[string]string{
+			"notification_id": strconv.FormatInt(n.ID, 10),
+			"type":            n.Type,
+		},
+	}
+	if n.VideoID != nil {
+		msg.Data["video_id"] = strconv.FormatInt(*n.VideoID, 10)
+	}
+
+	switch n.Type {
+	case NotificationTypeLike:
+		msg.Body = actorName + " 赞了你的视频"
+	case NotificationTypeComment:
+		msg.Body = actorName + " 评论了你的视频：" + truncateRunes(n.Content, pushBodyMaxRunes)
+	case NotificationTypeReply:
+		msg.Body = actorName + " 回复了你的评论：" + truncateRunes(n.Content, pushBodyMaxRunes)
+	case NotificationTypeFollow:
+		msg.Body = actorName + " 关注了你"
+	case NotificationTypeTip:
+		msg.Body = actorName + " " + truncateRunes(n.Content, pushBodyMaxRunes)
+	default:
+		return nil
+	}
+	return msg
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max]) + "…"
+}
+
+func toDeviceInfo(d *model.DeviceToken) *dto.DeviceInfo {
+	return &dto.DeviceInfo{
+		ID:        d.ID,
+		Platform:  d.Platform,
+		Token:     d.Token,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
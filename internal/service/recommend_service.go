@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	similarityLoadBatch     = 5000
+	similarityMaxItems      = 200 // 单个用户参与计算的最多视频数（取最近的），避免重度用户主导结果并控制计算量
+	similarityWriteBatch    = 500
+	favoriteSignalWeight    = 2.0 // 点赞比观看更能代表偏好
+	watchSignalWeight       = 1.0
+	forYouSeedLimit         = 20 // 为你推荐取最近点赞、观看各多少个视频作为种子
+	forYouCandidatesPerSeed = 50
+)
+
+func similarVideosKey(videoID int64) string {
+	return fmt.Sprintf("recommend:similar:%d", videoID)
+}
+
+// RecommendService 基于物品的协同过滤：定期由共同点赞、共同观看计算视频相似度，存入 Redis 供相关视频与为你推荐使用
+type RecommendService struct {
+	favoriteRepo     *repository.FavoriteRepository
+	watchHistoryRepo *repository.WatchHistoryRepository
+	videoRepo        *repository.VideoRepository
+	client           *redis.Client
+}
+
+func NewRecommendService(
+	favoriteRepo *repository.FavoriteRepository,
+	watchHistoryRepo *repository.WatchHistoryRepository,
+	videoRepo *repository.VideoRepository,
+	client *redis.Client,
+) *RecommendService {
+	return &RecommendService{
+		favoriteRepo:     favoriteRepo,
+		watchHistoryRepo: watchHistoryRepo,
+		videoRepo:        videoRepo,
+		client:           client,
+	}
+}
+
+// RunSimilarity 定时任务：重新计算一轮相似度
+func (s *RecommendService) RunSimilarity(ctx context.Context, cfg *config.RecommendConfig) error {
+	start := time.Now()
+	// 结果保留两个周期，某次计算失败时仍可使用上一次的结果
+	count, err := s.BuildSimilarities(ctx, time.Now().Add(-cfg.Lookback()), cfg.K(), 2*cfg.Interval())
+	if err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Video similarities built", zap.Int("videos", count), zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// userSignals 单个用户的偏好信号，按时间顺序追加，同一视频取最大权重
+type userSignals struct {
+	index   map[int64]int
+	videos  []int64
+	weights []float64
+}
+
+func (u *userSignals) add(videoID int64, weight float64) {
+	if i, ok := u.index[videoID]; ok {
+		u.weights[i] = math.Max(u.weights[i], weight)
+		return
+	}
+	u.index[videoID] = len(u.videos)
+	u.videos = append(u.videos, videoID)
+	u.weights = append(u.weights, weight)
+}
+
+// BuildSimilarities 计算 since 之后的点赞、观看记录中视频两两之间的余弦相似度，
+// 每个视频保留最相似的 topK 个写入 Redis，返回写入的视频数
+func (s *RecommendService) BuildSimilarities(ctx context.Context, since time.Time, topK int, ttl time.Duration) (int, error) {
+	users := make(map[int64]*userSignals)
+	signal := func(userID, videoID int64, weight float64) {
+		u, ok := users[userID]
+		if !ok {
+			u = &userSignals{index: make(map[int64]int)}
+			users[userID] = u
+		}
+		u.add(videoID, weight)
+	}
+
+	for afterID := int64(0); ; {
+		favs, err := s.favoriteRepo.ListSince(ctx, since, afterID, similarityLoadBatch)
+		if err != nil {
+			return 0, err
+		}
+		for _, f := range favs {
+			signal(f.UserID, f.VideoID, favoriteSignalWeight)
+		}
+		if len(favs) < similarityLoadBatch {
+			break
+		}
+		afterID = favs[len(favs)-1].ID
+	}
+	for afterID := int64(0); ; {
+		histories, err := s.watchHistoryRepo.ListSince(ctx, since, afterID, similarityLoadBatch)
+		if err != nil {
+			return 0, err
+		}
+		for _, h := range histories {
+			signal(h.UserID, h.VideoID, watchSignalWeight)
+		}
+		if len(histories) < similarityLoadBatch {
+			break
+		}
+		afterID = histories[len(histories)-1].ID
+	}
+
+	// 共现矩阵与各视频的向量模长
+	norms := make(map[int64]float64)
+	cooccur := make(map[int64]map[int64]float64)
+	for _, u := range users {
+		videos, weights := u.videos, u.weights
+		if n := len(videos); n > similarityMaxItems {
+			videos, weights = videos[n-similarityMaxItems:], weights[n-similarityMaxItems:]
+		}
+		for i, a := range videos {
+			norms[a] += weights[i] * weights[i]
+			for j := i + 1; j < len(videos); j++ {
+				b, w := videos[j], weights[i]*weights[j]
+				if cooccur[a] == nil {
+					cooccur[a] = make(map[int64]float64)
+				}
+				if cooccur[b] == nil {
+					cooccur[b] = make(map[int64]float64)
+				}
+				cooccur[a][b] += w
+				cooccur[b][a] += w
+			}
+		}
+	}
+
+	pipe := s.client.Pipeline()
+	written := 0
+	for a, neighbors := range cooccur {
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+
+		members := make([]redis.Z, 0, len(neighbors))
+		for b, w := range neighbors {
+			members = append(members, redis.Z{Score: w / math.Sqrt(norms[a]*norms[b]), Member: b})
+		}
+		sort.Slice(members, func(i, j int) bool { return members[i].Score > members[j].Score })
+		if len(members) > topK {
+			members = members[:topK]
+		}
+
+		key := similarVideosKey(a)
+		pipe.Del(ctx, key)
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, ttl)
+		written++
+
+		if written%similarityWriteBatch == 0 {
+			if _, err := pipe.Exec(ctx); err != nil {
+				return written, err
+			}
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return written, err
+	}
+	return written, nil
+}
+
+// GetRelated 获取与视频相似的已发布视频，相似结果不足时用同作者的其他视频补齐
+func (s *RecommendService) GetRelated(ctx context.Context, videoID int64, limit int) (*dto.VideoRecommendData, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.Status == VideoStatusHidden {
+		return nil, ErrVideoNotFound
+	}
+
+	members, err := s.client.ZRevRange(ctx, similarVideosKey(videoID), 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	exclude := map[int64]bool{videoID: true}
+	videos, err := s.loadPublished(ctx, parseIDMembers(members), exclude, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(videos) < limit {
+		status := "published"
+		more, _, err := s.videoRepo.ListVideos(ctx, 0, limit+1, &video.AuthorID, &status, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		videos = appendUnseen(videos, more, exclude, limit)
+	}
+	return toVideoRecommendData(videos), nil
+}
+
+// GetForYou 为你推荐：以用户最近点赞、观看的视频为种子，累加相似度排序，排除已看过和自己的视频，不足时用最新视频补齐
+func (s *RecommendService) GetForYou(ctx context.Context, userID int64, limit int) (*dto.VideoRecommendData, error) {
+	favorited, _, err := s.favoriteRepo.GetFavoritedVideoIDs(ctx, userID, 0, forYouSeedLimit)
+	if err != nil {
+		return nil, err
+	}
+	watched, err := s.watchHistoryRepo.RecentVideoIDs(ctx, userID, forYouSeedLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	seeds := uniqueIDs(append(favorited, watched...))
+	exclude := make(map[int64]bool, len(seeds))
+	for _, id := range seeds {
+		exclude[id] = true
+	}
+
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.ZSliceCmd, len(seeds))
+	for i, id := range seeds {
+		cmds[i] = pipe.ZRevRangeWithScores(ctx, similarVideosKey(id), 0, forYouCandidatesPerSeed-1)
+	}
+	if len(seeds) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+	}
+
+	scores := make(map[int64]float64)
+	for _, cmd := range cmds {
+		for _, z := range cmd.Val() {
+			id, err := strconv.ParseInt(fmt.Sprint(z.Member), 10, 64)
+			if err != nil || exclude[id] {
+				continue
+			}
+			scores[id] += z.Score
+		}
+	}
+	candidates := make([]int64, 0, len(scores))
+	for id := range scores {
+		candidates = append(candidates, id)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if scores[candidates[i]] != scores[candidates[j]] {
+			return scores[candidates[i]] > scores[candidates[j]]
+		}
+		return candidates[i] > candidates[j]
+	})
+	if len(candidates) > 2*limit {
+		candidates = candidates[:2*limit]
+	}
+
+	videos, err := s.loadPublished(ctx, candidates, exclude, limit, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(videos) < limit {
+		status := "published"
+		latest, _, err := s.videoRepo.ListVideos(ctx, 0, 2*limit, nil, &status, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		videos = appendUnseen(videos, filterAuthor(latest, userID), exclude, limit)
+	}
+	return toVideoRecommendData(videos), nil
+}
+
+// loadPublished 按 ids 顺序加载已发布视频，跳过 exclude 中及 excludeAuthors 发布的视频，加载的视频会加入 exclude
+func (s *RecommendService) loadPublished(ctx context.Context, ids []int64, exclude map[int64]bool, limit int, excludeAuthors ...int64) ([]model.Video, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	rows, err := s.videoRepo.GetByIDsWithAuthor(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	published := make([]model.Video, 0, len(rows))
+	for _, v := range rows {
+		if v.Status == "published" && v.PlayURL != "" && !v.IsPrivate() {
+			published = append(published, v)
+		}
+	}
+	for _, authorID := range excludeAuthors {
+		published = filterAuthor(published, authorID)
+	}
+	return appendUnseen(nil, published, exclude, limit), nil
+}
+
+func appendUnseen(videos, more []model.Video, exclude map[int64]bool, limit int) []model.Video {
+	for _, v := range more {
+		if len(videos) >= limit {
+			break
+		}
+		if exclude[v.ID] {
+			continue
+		}
+		exclude[v.ID] = true
+		videos = append(videos, v)
+	}
+	return videos
+}
+
+func filterAuthor(videos []model.Video, authorID int64) []model.Video {
+	result := videos[:0:0]
+	for _, v := range videos {
+		if v.AuthorID != authorID {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func parseIDMembers(members []string) []int64 {
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		if id, err := strconv.ParseInt(m, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func toVideoRecommendData(videos []model.Video) *dto.VideoRecommendData {
+	data := &dto.VideoRecommendData{Videos: make([]dto.VideoInfo, 0, len(videos))}
+	for i := range videos {
+		data.Videos = append(data.Videos, *toVideoInfo(&videos[i], true))
+	}
+	return data
+}
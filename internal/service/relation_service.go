@@ -1,11 +1,15 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"vida-go/internal/api/dto"
+	"vida-go/internal/eventbus"
 	"vida-go/internal/model"
 	"vida-go/internal/repository"
+	"vida-go/pkg/cursor"
 
 	"gorm.io/gorm"
 )
@@ -19,23 +23,27 @@ var (
 type RelationService struct {
 	relationRepo *repository.RelationRepository
 	userRepo     *repository.UserRepository
+	eventBus     *eventbus.Bus
+	txManager    *repository.TxManager
 }
 
-func NewRelationService(relationRepo *repository.RelationRepository, userRepo *repository.UserRepository) *RelationService {
+func NewRelationService(relationRepo *repository.RelationRepository, userRepo *repository.UserRepository, eventBus *eventbus.Bus, txManager *repository.TxManager) *RelationService {
 	return &RelationService{
 		relationRepo: relationRepo,
 		userRepo:     userRepo,
+		eventBus:     eventBus,
+		txManager:    txManager,
 	}
 }
 
 // Follow 关注用户
-func (s *RelationService) Follow(currentUserID, targetUserID int64) (*dto.FollowResult, error) {
+func (s *RelationService) Follow(ctx context.Context, currentUserID, targetUserID int64) (*dto.FollowResult, error) {
 	if currentUserID == targetUserID {
 		return nil, ErrCannotFollowSelf
 	}
 
 	// 检查目标用户是否存在
-	if _, err := s.userRepo.GetByID(targetUserID); err != nil {
+	if _, err := s.userRepo.GetByID(ctx, targetUserID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -43,7 +51,7 @@ func (s *RelationService) Follow(currentUserID, targetUserID int64) (*dto.Follow
 	}
 
 	// 检查是否已关注
-	exists, err := s.relationRepo.Exists(currentUserID, targetUserID)
+	exists, err := s.relationRepo.Exists(ctx, currentUserID, targetUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -51,18 +59,29 @@ func (s *RelationService) Follow(currentUserID, targetUserID int64) (*dto.Follow
 		return nil, ErrAlreadyFollowed
 	}
 
-	// 创建关注关系
-	if _, err := s.relationRepo.Create(currentUserID, targetUserID); err != nil {
+	// 创建关注关系并更新计数
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := s.relationRepo.Create(ctx, currentUserID, targetUserID); err != nil {
+			return err
+		}
+		if err := s.userRepo.IncrementFollowCount(ctx, currentUserID); err != nil {
+			return err
+		}
+		return s.userRepo.IncrementFollowerCount(ctx, targetUserID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// 更新计数
-	_ = s.userRepo.IncrementFollowCount(currentUserID)
-	_ = s.userRepo.IncrementFollowerCount(targetUserID)
+	s.eventBus.Publish(ctx, &eventbus.UserFollowed{
+		FollowerID: currentUserID,
+		FolloweeID: targetUserID,
+		FollowedAt: time.Now(),
+	})
 
 	// 获取更新后的计数
-	follower, _ := s.userRepo.GetByID(currentUserID)
-	target, _ := s.userRepo.GetByID(targetUserID)
+	follower, _ := s.userRepo.GetByID(ctx, currentUserID)
+	target, _ := s.userRepo.GetByID(ctx, targetUserID)
 
 	result := &dto.FollowResult{
 		FollowerID: currentUserID,
@@ -79,21 +98,28 @@ func (s *RelationService) Follow(currentUserID, targetUserID int64) (*dto.Follow
 }
 
 // Unfollow 取消关注
-func (s *RelationService) Unfollow(currentUserID, targetUserID int64) (*dto.FollowResult, error) {
-	deleted, err := s.relationRepo.Delete(currentUserID, targetUserID)
+func (s *RelationService) Unfollow(ctx context.Context, currentUserID, targetUserID int64) (*dto.FollowResult, error) {
+	err := s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		deleted, err := s.relationRepo.Delete(ctx, currentUserID, targetUserID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return ErrNotFollowed
+		}
+
+		// 更新计数
+		if err := s.userRepo.DecrementFollowCount(ctx, currentUserID); err != nil {
+			return err
+		}
+		return s.userRepo.DecrementFollowerCount(ctx, targetUserID)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if !deleted {
-		return nil, ErrNotFollowed
-	}
-
-	// 更新计数
-	_ = s.userRepo.DecrementFollowCount(currentUserID)
-	_ = s.userRepo.DecrementFollowerCount(targetUserID)
 
-	follower, _ := s.userRepo.GetByID(currentUserID)
-	target, _ := s.userRepo.GetByID(targetUserID)
+	follower, _ := s.userRepo.GetByID(ctx, currentUserID)
+	target, _ := s.userRepo.GetByID(ctx, targetUserID)
 
 	result := &dto.FollowResult{
 		FollowerID: currentUserID,
@@ -110,8 +136,8 @@ func (s *RelationService) Unfollow(currentUserID, targetUserID int64) (*dto.Foll
 }
 
 // GetFollowingList 获取关注列表
-func (s *RelationService) GetFollowingList(userID int64, page, pageSize int) (*dto.RelationListData, error) {
-	if _, err := s.userRepo.GetByID(userID); err != nil {
+func (s *RelationService) GetFollowingList(ctx context.Context, userID int64, page, pageSize int) (*dto.RelationListData, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -119,17 +145,17 @@ func (s *RelationService) GetFollowingList(userID int64, page, pageSize int) (*d
 	}
 
 	skip := (page - 1) * pageSize
-	followIDs, err := s.relationRepo.GetFollowingList(userID, skip, pageSize)
+	followIDs, err := s.relationRepo.GetFollowingList(ctx, userID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	total, err := s.relationRepo.CountFollowing(userID)
+	total, err := s.relationRepo.CountFollowing(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	users, err := s.userRepo.GetByIDs(followIDs)
+	users, err := s.userRepo.GetByIDs(ctx, followIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +164,8 @@ func (s *RelationService) GetFollowingList(userID int64, page, pageSize int) (*d
 }
 
 // GetFollowerList 获取粉丝列表
-func (s *RelationService) GetFollowerList(userID int64, page, pageSize int) (*dto.RelationListData, error) {
-	if _, err := s.userRepo.GetByID(userID); err != nil {
+func (s *RelationService) GetFollowerList(ctx context.Context, userID int64, page, pageSize int) (*dto.RelationListData, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -147,17 +173,17 @@ func (s *RelationService) GetFollowerList(userID int64, page, pageSize int) (*dt
 	}
 
 	skip := (page - 1) * pageSize
-	followerIDs, err := s.relationRepo.GetFollowerList(userID, skip, pageSize)
+	followerIDs, err := s.relationRepo.GetFollowerList(ctx, userID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	total, err := s.relationRepo.CountFollowers(userID)
+	total, err := s.relationRepo.CountFollowers(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	users, err := s.userRepo.GetByIDs(followerIDs)
+	users, err := s.userRepo.GetByIDs(ctx, followerIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -166,24 +192,24 @@ func (s *RelationService) GetFollowerList(userID int64, page, pageSize int) (*dt
 }
 
 // GetFollowStatus 查询关注状态
-func (s *RelationService) GetFollowStatus(currentUserID, targetUserID int64) (bool, error) {
-	return s.relationRepo.Exists(currentUserID, targetUserID)
+func (s *RelationService) GetFollowStatus(ctx context.Context, currentUserID, targetUserID int64) (bool, error) {
+	return s.relationRepo.Exists(ctx, currentUserID, targetUserID)
 }
 
 // GetMutualFollows 获取互相关注列表
-func (s *RelationService) GetMutualFollows(userID int64, page, pageSize int) (*dto.RelationListData, error) {
+func (s *RelationService) GetMutualFollows(ctx context.Context, userID int64, page, pageSize int) (*dto.RelationListData, error) {
 	skip := (page - 1) * pageSize
-	mutualIDs, err := s.relationRepo.GetMutualFollowIDs(userID, skip, pageSize)
+	mutualIDs, err := s.relationRepo.GetMutualFollowIDs(ctx, userID, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	total, err := s.relationRepo.CountMutualFollows(userID)
+	total, err := s.relationRepo.CountMutualFollows(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	users, err := s.userRepo.GetByIDs(mutualIDs)
+	users, err := s.userRepo.GetByIDs(ctx, mutualIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -192,8 +218,8 @@ func (s *RelationService) GetMutualFollows(userID int64, page, pageSize int) (*d
 }
 
 // BatchCheckFollowStatus 批量查询关注状态
-func (s *RelationService) BatchCheckFollowStatus(currentUserID int64, targetIDs []int64) (map[int64]bool, error) {
-	return s.relationRepo.BatchCheckFollowing(currentUserID, targetIDs)
+func (s *RelationService) BatchCheckFollowStatus(ctx context.Context, currentUserID int64, targetIDs []int64) (map[int64]bool, error) {
+	return s.relationRepo.BatchCheckFollowing(ctx, currentUserID, targetIDs)
 }
 
 // buildRelationListData 构建关注/粉丝列表响应，按 orderedIDs 排序
@@ -228,3 +254,60 @@ func buildRelationListData(users []model.User, orderedIDs []int64, total int64,
 		TotalPages: totalPages,
 	}
 }
+
+// ListFollowingByCursor 游标分页获取关注列表
+func (s *RelationService) ListFollowingByCursor(ctx context.Context, userID int64, after string, limit int) (*dto.CursorList[dto.RelationUserInfo], error) {
+	return s.listRelationsByCursor(ctx, userID, after, limit, s.relationRepo.ListFollowingBefore, func(r *model.Relation) int64 { return r.FollowID })
+}
+
+// ListFollowersByCursor 游标分页获取粉丝列表
+func (s *RelationService) ListFollowersByCursor(ctx context.Context, userID int64, after string, limit int) (*dto.CursorList[dto.RelationUserInfo], error) {
+	return s.listRelationsByCursor(ctx, userID, after, limit, s.relationRepo.ListFollowersBefore, func(r *model.Relation) int64 { return r.FollowerID })
+}
+
+// ListMutualByCursor 游标分页获取互相关注列表
+func (s *RelationService) ListMutualByCursor(ctx context.Context, userID int64, after string, limit int) (*dto.CursorList[dto.RelationUserInfo], error) {
+	return s.listRelationsByCursor(ctx, userID, after, limit, s.relationRepo.ListMutualBefore, func(r *model.Relation) int64 { return r.FollowID })
+}
+
+func (s *RelationService) listRelationsByCursor(
+	ctx context.Context,
+	userID int64,
+	after string,
+	limit int,
+	fetch func(ctx context.Context, userID, beforeID int64, limit int) ([]model.Relation, error),
+	peerOf func(r *model.Relation) int64,
+) (*dto.CursorList[dto.RelationUserInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	relations, err := fetch(ctx, userID, c.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	relations, hasMore := trimPage(relations, limit)
+
+	peerIDs := make([]int64, 0, len(relations))
+	for i := range relations {
+		peerIDs = append(peerIDs, peerOf(&relations[i]))
+	}
+	users, err := s.userRepo.GetByIDs(ctx, peerIDs)
+	if err != nil {
+		return nil, err
+	}
+	data := buildRelationListData(users, peerIDs, 0, 1, limit)
+
+	list := &dto.CursorList[dto.RelationUserInfo]{Items: data.Users, HasMore: hasMore}
+	if hasMore {
+		list.NextCursor = cursor.Encode(cursor.New(relations[len(relations)-1].ID))
+	}
+	return list, nil
+}
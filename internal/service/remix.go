@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/infra/geoip"
+	"vida-go/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// remixPreviewSize 视频详情中附带的最新引用视频数量
+const remixPreviewSize = 6
+
+var (
+	ErrRemixSourceNotFound = errors.New("引用的原视频不存在")
+	ErrRemixNotAllowed     = errors.New("原视频作者不允许合拍或二创")
+)
+
+// resolveRemixSource 校验上传时引用的原视频：须已发布、对上传者可见，且原作者允许引用（本人的视频不受限制）；
+// 他人的私密视频不可引用
+func (s *VideoService) resolveRemixSource(ctx context.Context, uploaderID, sourceID int64) (*model.Video, error) {
+	source, err := s.videoRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRemixSourceNotFound
+		}
+		return nil, err
+	}
+	if source.Status != "published" || source.LegalHoldAt != nil {
+		return nil, ErrRemixSourceNotFound
+	}
+	if source.AuthorID == uploaderID {
+		return source, nil
+	}
+	if source.IsPrivate() || !source.AvailableIn(geoip.CountryFromContext(ctx)) {
+		return nil, ErrRemixSourceNotFound
+	}
+
+	setting, err := s.settingRepo.GetByUserID(ctx, source.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if setting.RemixDisabled {
+		return nil, ErrRemixNotAllowed
+	}
+	return source, nil
+}
+
+// ListRemixes 分页获取引用了该视频的已发布视频
+func (s *VideoService) ListRemixes(ctx context.Context, videoID, viewerID int64, page, pageSize int) (*dto.VideoListData, error) {
+	if _, err := s.GetInfo(ctx, videoID); err != nil {
+		return nil, err
+	}
+
+	skip := (page - 1) * pageSize
+	videos, total, err := s.videoRepo.ListRemixes(ctx, videoID, geoip.CountryFromContext(ctx), skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	data := buildVideoListData(videos, total, page, pageSize, true)
+	if err := s.ApplyContentGate(ctx, viewerID, data.Videos); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// fillRemixes 为视频详情附带引用数量与最新的引用视频
+func (s *VideoService) fillRemixes(ctx context.Context, viewerID int64, info *dto.VideoInfo) error {
+	videos, total, err := s.videoRepo.ListRemixes(ctx, info.ID, geoip.CountryFromContext(ctx), 0, remixPreviewSize)
+	if err != nil {
+		return err
+	}
+	info.RemixCount = &total
+	info.Remixes = make([]dto.VideoInfo, 0, len(videos))
+	for i := range videos {
+		info.Remixes = append(info.Remixes, *toVideoInfo(&videos[i], true))
+	}
+	return s.ApplyContentGate(ctx, viewerID, info.Remixes)
+}
+
+// GetRemixSettings 获取是否允许他人引用自己的视频
+func (s *VideoService) GetRemixSettings(ctx context.Context, userID int64) (*dto.RemixSettings, error) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RemixSettings{AllowRemix: !setting.RemixDisabled}, nil
+}
+
+// UpdateRemixSettings 设置是否允许他人引用自己的视频，已发布的引用视频不受影响
+func (s *VideoService) UpdateRemixSettings(ctx context.Context, userID int64, req *dto.RemixSettingsUpdateRequest) (*dto.RemixSettings, error) {
+	setting, err := s.settingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	setting.RemixDisabled = !*req.AllowRemix
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return &dto.RemixSettings{AllowRemix: !setting.RemixDisabled}, nil
+}
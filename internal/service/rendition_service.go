@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const maxRenditionErrorLen = 500
+
+// RenditionService 附加清晰度管理：补齐任务为已发布视频投递缺少的档位，
+// 消费转码结果并记录每个视频各档位的完整度
+type RenditionService struct {
+	renditionRepo *repository.VideoRenditionRepository
+	videoRepo     *repository.VideoRepository
+}
+
+func NewRenditionService(renditionRepo *repository.VideoRenditionRepository, videoRepo *repository.VideoRepository) *RenditionService {
+	return &RenditionService{renditionRepo: renditionRepo, videoRepo: videoRepo}
+}
+
+// RenditionBackfillResult 一轮补齐的统计
+type RenditionBackfillResult struct {
+	Scanned  int // 扫描的已发布视频数
+	Enqueued int // 投递的转码任务数
+	Failed   int // 投递失败的任务数
+}
+
+type renditionKey struct {
+	videoID int64
+	profile string
+}
+
+// RunBackfill 定时任务：补齐一轮附加清晰度，未配置档位时不执行
+func (s *RenditionService) RunBackfill(ctx context.Context, cfg *config.RenditionConfig) error {
+	if len(cfg.Profiles) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	result, err := s.Backfill(ctx, cfg)
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Renditions backfilled",
+		zap.Int("scanned", result.Scanned),
+		zap.Int("enqueued", result.Enqueued),
+		zap.Int("failed", result.Failed),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// Backfill 按 ID 遍历已发布视频，为缺少的档位（从未投递、失败未超过尝试次数、等待超时）投递转码任务，
+// 按 rate_per_minute 限速，达到 max_per_run 后结束本轮
+func (s *RenditionService) Backfill(ctx context.Context, cfg *config.RenditionConfig) (*RenditionBackfillResult, error) {
+	result := &RenditionBackfillResult{}
+	topic := config.GetKafka().Topics["video_rendition"]
+	if topic == "" {
+		return result, errors.New("kafka topic video_rendition not configured")
+	}
+
+	backfill := &cfg.Backfill
+	limiter := time.NewTicker(time.Minute / time.Duration(backfill.Rate()))
+	defer limiter.Stop()
+	staleBefore := time.Now().Add(-backfill.PendingTTL())
+
+	for afterID := int64(0); ; {
+		videos, err := s.videoRepo.ListPublishedAfter(ctx, afterID, backfill.Batch())
+		if err != nil {
+			return result, err
+		}
+		if len(videos) == 0 {
+			return result, nil
+		}
+		afterID = videos[len(videos)-1].ID
+		result.Scanned += len(videos)
+
+		ids := make([]int64, len(videos))
+		for i, v := range videos {
+			ids[i] = v.ID
+		}
+		renditions, err := s.renditionRepo.ListByVideos(ctx, ids)
+		if err != nil {
+			return result, err
+		}
+		existing := make(map[renditionKey]*model.VideoRendition, len(renditions))
+		for i := range renditions {
+			existing[renditionKey{renditions[i].VideoID, renditions[i].Profile}] = &renditions[i]
+		}
+
+		for i := range videos {
+			for _, profile := range cfg.Profiles {
+				rendition := existing[renditionKey{videos[i].ID, profile.Name}]
+				if !needsRendition(rendition, backfill.Attempts(), staleBefore) {
+					continue
+				}
+				if result.Enqueued >= backfill.MaxTasks() {
+					return result, nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return result, ctx.Err()
+				case <-limiter.C:
+				}
+				if err := s.enqueue(ctx, topic, &videos[i], profile); err != nil {
+					logger.FromContext(ctx).Warn("Enqueue rendition task failed",
+						zap.Int64("video_id", videos[i].ID), zap.String("profile", profile.Name), zap.Error(err))
+					result.Failed++
+					continue
+				}
+				result.Enqueued++
+			}
+		}
+	}
+}
+
+// needsRendition 判断档位是否需要（重新）投递
+func needsRendition(rendition *model.VideoRendition, maxAttempts int, staleBefore time.Time) bool {
+	if rendition == nil {
+		return true
+	}
+	if rendition.Attempts >= maxAttempts {
+		return false
+	}
+	switch rendition.Status {
+	case model.VideoRenditionStatusFailed:
+		return true
+	case model.VideoRenditionStatusPending:
+		// 任务或结果消息丢失时重新投递
+		return rendition.UpdatedAt.Before(staleBefore)
+	}
+	return false
+}
+
+func (s *RenditionService) enqueue(ctx context.Context, topic string, video *model.Video, profile config.RenditionProfile) error {
+	if err := s.renditionRepo.MarkPending(ctx, video.ID, profile.Name); err != nil {
+		return err
+	}
+	task := &infraKafka.RenditionTask{
+		VideoID:    video.ID,
+		Profile:    profile.Name,
+		Format:     profile.Format,
+		Height:     profile.Height,
+		CRF:        profile.Quality(),
+		Bucket:     rawVideoBucket,
+		ObjectName: rawObjectName(video.AuthorID, video.ID, video.FileFormat),
+	}
+	if err := infraKafka.SendRenditionTask(ctx, topic, task); err != nil {
+		_ = s.renditionRepo.UpdateResult(ctx, video.ID, profile.Name, map[string]interface{}{
+			"status": model.VideoRenditionStatusFailed,
+			"error":  truncateRenditionError(err.Error()),
+		})
+		return err
+	}
+	return nil
+}
+
+// HandleResult 处理 Kafka 消费者收到的附加清晰度转码结果
+func (s *RenditionService) HandleResult(ctx context.Context, result *infraKafka.RenditionResult) error {
+	updates := map[string]interface{}{"status": model.VideoRenditionStatusFailed, "error": truncateRenditionError(result.Error)}
+	if result.Status == model.VideoRenditionStatusReady {
+		updates = map[string]interface{}{
+			"status": model.VideoRenditionStatusReady,
+			"url":    result.URL,
+			"width":  result.Width,
+			"height": result.Height,
+			"error":  "",
+		}
+	}
+	if err := s.renditionRepo.UpdateResult(ctx, result.VideoID, result.Profile, updates); err != nil {
+		return fmt.Errorf("update rendition %d/%s failed: %w", result.VideoID, result.Profile, err)
+	}
+
+	logger.FromContext(ctx).Info("Video rendition result processed",
+		zap.Int64("video_id", result.VideoID),
+		zap.String("profile", result.Profile),
+		zap.String("status", result.Status),
+	)
+	return nil
+}
+
+// GetVideoRenditions 获取视频各配置档位的状态，未投递过的档位为 missing
+func (s *RenditionService) GetVideoRenditions(ctx context.Context, videoID int64) (*dto.VideoRenditionsData, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	renditions, err := s.renditionRepo.ListByVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	byProfile := make(map[string]*model.VideoRendition, len(renditions))
+	for i := range renditions {
+		byProfile[renditions[i].Profile] = &renditions[i]
+	}
+
+	data := &dto.VideoRenditionsData{
+		VideoID:  videoID,
+		Complete: true,
+		Media: dto.VideoMediaInfo{
+			SourceFormat:  video.FileFormat,
+			Duration:      video.Duration,
+			Width:         video.Width,
+			Height:        video.Height,
+			VideoCodec:    video.VideoCodec,
+			AudioCodec:    video.AudioCodec,
+			Bitrate:       video.Bitrate,
+			FrameRate:     video.FrameRate,
+			AudioChannels: video.AudioChannels,
+		},
+		Renditions: []dto.VideoRenditionInfo{},
+	}
+	for _, profile := range config.GetRendition().Profiles {
+		info := dto.VideoRenditionInfo{Profile: profile.Name, Format: profile.Format, Status: dto.RenditionMissing}
+		if r, ok := byProfile[profile.Name]; ok {
+			info.Status = r.Status
+			info.URL = r.URL
+			info.Width = r.Width
+			info.Height = r.Height
+			info.Attempts = r.Attempts
+			info.Error = r.Error
+			info.UpdatedAt = &r.UpdatedAt
+		}
+		if info.Status != model.VideoRenditionStatusReady {
+			data.Complete = false
+		}
+		data.Renditions = append(data.Renditions, info)
+	}
+	return data, nil
+}
+
+// Summary 统计各配置档位在已发布视频中的覆盖情况
+func (s *RenditionService) Summary(ctx context.Context) (*dto.RenditionSummaryData, error) {
+	published, err := s.videoRepo.CountByStatus(ctx, "published")
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.renditionRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dto.RenditionSummaryData{Published: published, Profiles: []dto.RenditionProfileSummary{}}
+	for _, profile := range config.GetRendition().Profiles {
+		summary := dto.RenditionProfileSummary{Profile: profile.Name, Format: profile.Format}
+		for _, c := range counts {
+			if c.Profile != profile.Name {
+				continue
+			}
+			switch c.Status {
+			case model.VideoRenditionStatusReady:
+				summary.Ready = c.Count
+			case model.VideoRenditionStatusPending:
+				summary.Pending = c.Count
+			case model.VideoRenditionStatusFailed:
+				summary.Failed = c.Count
+			}
+		}
+		summary.Missing = max(published-summary.Ready-summary.Pending-summary.Failed, 0)
+		if published > 0 {
+			summary.Completeness = float64(summary.Ready) / float64(published)
+		}
+		data.Profiles = append(data.Profiles, summary)
+	}
+	return data, nil
+}
+
+func truncateRenditionError(msg string) string {
+	if len(msg) > maxRenditionErrorLen {
+		return strings.ToValidUTF8(msg[:maxRenditionErrorLen], "")
+	}
+	return msg
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCannotReportSelf    = errors.New("不能举报自己")
+	ErrReportExists        = errors.New("您已举报过该用户，请等待处理")
+	ErrReportNotFound      = errors.New("举报不存在")
+	ErrReportResolved      = errors.New("该举报已处理")
+	ErrInvalidReportStatus = errors.New("无效的举报状态")
+	ErrInvalidReportTarget = errors.New("无效的举报对象类型")
+)
+
+// ReportService 用户举报：举报记录进入审核队列，同一用户被多人举报时升级为优先审核
+type ReportService struct {
+	reportRepo *repository.ReportRepository
+	userRepo   *repository.UserRepository
+}
+
+func NewReportService(reportRepo *repository.ReportRepository, userRepo *repository.UserRepository) *ReportService {
+	return &ReportService{reportRepo: reportRepo, userRepo: userRepo}
+}
+
+// ReportUser 举报用户（账号本身，如冒充、骚扰、违规资料），与视频、评论举报分开记录
+func (s *ReportService) ReportUser(ctx context.Context, reporterID, targetID int64, req *dto.ReportUserRequest) error {
+	if reporterID == targetID {
+		return ErrCannotReportSelf
+	}
+	if _, err := s.userRepo.GetByID(ctx, targetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	created, err := s.reportRepo.Create(ctx, &model.Report{
+		ReporterID:   reporterID,
+		TargetType:   model.ReportTargetUser,
+		TargetID:     targetID,
+		TargetUserID: targetID,
+		Reason:       req.Reason,
+		Detail:       strings.TrimSpace(req.Detail),
+		Status:       model.ReportStatusPending,
+	})
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrReportExists
+	}
+
+	s.checkEscalation(ctx, targetID)
+	return nil
+}
+
+// checkEscalation 窗口内举报该用户（本人及其视频、评论）的不同举报人达到阈值时，
+// 将其所有待处理举报升级为优先审核。失败只记录日志，不影响举报本身
+func (s *ReportService) checkEscalation(ctx context.Context, targetUserID int64) {
+	cfg := config.GetReport()
+	reporters, err := s.reportRepo.CountReporters(ctx, targetUserID, time.Now().Add(-cfg.EscalationWindow()))
+	if err != nil {
+		logger.FromContext(ctx).Warn("Count reporters failed", zap.Int64("target_user_id", targetUserID), zap.Error(err))
+		return
+	}
+	if reporters < int64(cfg.EscalationThreshold()) {
+		return
+	}
+
+	escalated, err := s.reportRepo.Escalate(ctx, targetUserID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Escalate reports failed", zap.Int64("target_user_id", targetUserID), zap.Error(err))
+		return
+	}
+	if escalated > 0 {
+		logger.FromContext(ctx).Warn("User reports escalated",
+			zap.Int64("target_user_id", targetUserID),
+			zap.Int64("reporters", reporters),
+			zap.Int64("escalated", escalated),
+		)
+	}
+}
+
+// ListReports 审核队列：举报列表，已升级的排在前面
+func (s *ReportService) ListReports(ctx context.Context, filter *repository.ReportFilter, page, pageSize int) (*dto.ReportListData, error) {
+	switch filter.Status {
+	case "", model.ReportStatusPending, model.ReportStatusResolved, model.ReportStatusDismissed:
+	default:
+		return nil, ErrInvalidReportStatus
+	}
+	switch filter.TargetType {
+	case "", model.ReportTargetUser, model.ReportTargetVideo, model.ReportTargetComment:
+	default:
+		return nil, ErrInvalidReportTarget
+	}
+
+	skip := (page - 1) * pageSize
+	reports, total, err := s.reportRepo.List(ctx, filter, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ReportInfo, 0, len(reports))
+	for i := range reports {
+		r := &reports[i]
+		items = append(items, dto.ReportInfo{
+			ID:         r.ID,
+			TargetType: r.TargetType,
+			TargetID:   r.TargetID,
+			TargetUser: toUserBriefInfo(&r.TargetUser),
+			Reporter:   toUserBriefInfo(&r.Reporter),
+			Reason:     r.Reason,
+			Detail:     r.Detail,
+			Status:     r.Status,
+			Escalated:  r.Escalated,
+			ReviewedAt: r.ReviewedAt,
+			CreatedAt:  r.CreatedAt,
+		})
+	}
+
+	return &dto.ReportListData{
+		Reports:    items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// ResolveReport 举报成立（对被举报用户的处置通过封禁、禁言等接口单独进行）
+func (s *ReportService) ResolveReport(ctx context.Context, reportID int64) error {
+	return s.review(ctx, reportID, model.ReportStatusResolved)
+}
+
+// DismissReport 驳回举报，驳回的举报不再计入升级统计
+func (s *ReportService) DismissReport(ctx context.Context, reportID int64) error {
+	return s.review(ctx, reportID, model.ReportStatusDismissed)
+}
+
+func (s *ReportService) review(ctx context.Context, reportID int64, status string) error {
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReportNotFound
+		}
+		return err
+	}
+	if report.Status != model.ReportStatusPending {
+		return ErrReportResolved
+	}
+
+	updated, err := s.reportRepo.UpdateStatus(ctx, reportID, status)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrReportResolved
+	}
+	return nil
+}
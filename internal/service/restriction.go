@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserSuspended = errors.New("账号已被封禁")
+	ErrUserMuted     = errors.New("账号已被禁言")
+)
+
+// RestrictionError 账号限制错误，携带截止时间和原因
+// errors.Is 可匹配 ErrUserSuspended / ErrUserMuted
+type RestrictionError struct {
+	Kind   error
+	Until  time.Time
+	Reason string
+}
+
+func (e *RestrictionError) Error() string {
+	return fmt.Sprintf("%s，解除时间: %s", e.Kind.Error(), e.Until.Format(time.RFC3339))
+}
+
+func (e *RestrictionError) Unwrap() error {
+	return e.Kind
+}
+
+// restrictionActive 限制是否仍在有效期内
+func restrictionActive(until *time.Time) bool {
+	return until != nil && time.Now().Before(*until)
+}
+
+// checkSuspended 封禁期间返回 RestrictionError
+func checkSuspended(user *model.User) error {
+	if restrictionActive(user.SuspendedUntil) {
+		return &RestrictionError{Kind: ErrUserSuspended, Until: *user.SuspendedUntil, Reason: user.SuspendReason}
+	}
+	return nil
+}
+
+// ensureNotMuted 校验用户当前未被禁言（发视频、评论前调用）
+func ensureNotMuted(ctx context.Context, userRepo *repository.UserRepository, userID int64) error {
+	user, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if restrictionActive(user.MutedUntil) {
+		return &RestrictionError{Kind: ErrUserMuted, Until: *user.MutedUntil, Reason: user.MuteReason}
+	}
+	return nil
+}
@@ -5,29 +5,42 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"vida-go/internal/api/dto"
 	"vida-go/internal/config"
+	"vida-go/internal/eventbus"
 	infraES "vida-go/internal/infra/elasticsearch"
+	"vida-go/internal/infra/geoip"
+	"vida-go/internal/infra/metrics"
 	"vida-go/internal/model"
 	"vida-go/internal/repository"
 	"vida-go/pkg/logger"
 
+	"github.com/agnivade/levenshtein"
 	"go.uber.org/zap"
 )
 
+const (
+	authorCardTopVideos = 3
+	authorSyncBatch     = 500
+)
+
 type SearchService struct {
-	videoRepo *repository.VideoRepository
+	videoRepo      *repository.VideoRepository
+	userRepo       *repository.UserRepository
+	membershipRepo *repository.MembershipRepository
 }
 
-func NewSearchService(videoRepo *repository.VideoRepository) *SearchService {
-	return &SearchService{videoRepo: videoRepo}
+func NewSearchService(videoRepo *repository.VideoRepository, userRepo *repository.UserRepository, membershipRepo *repository.MembershipRepository) *SearchService {
+	return &SearchService{videoRepo: videoRepo, userRepo: userRepo, membershipRepo: membershipRepo}
 }
 
-// SearchVideos 搜索视频（ES 优先，失败则降级到 DB）
-func (s *SearchService) SearchVideos(req *dto.SearchVideoRequest) (*dto.SearchVideoData, error) {
+// SearchVideos 搜索视频（ES 优先，失败则降级到 DB，结果标记 degraded 并计入 search_fallback_total）
+func (s *SearchService) SearchVideos(ctx context.Context, req *dto.SearchVideoRequest) (*dto.SearchVideoData, error) {
 	if req.Page < 1 {
 		req.Page = 1
 	}
@@ -35,28 +48,139 @@ func (s *SearchService) SearchVideos(req *dto.SearchVideoRequest) (*dto.SearchVi
 		req.PageSize = 20
 	}
 
-	data, err := s.searchFromES(req)
+	data, err := s.searchFromES(ctx, req)
 	if err != nil {
-		logger.Warn("ES search failed, fallback to DB", zap.Error(err))
-		return s.searchFromDB(req)
+		logger.FromContext(ctx).Warn("ES search failed, fallback to DB", zap.Error(err))
+		metrics.SearchFallbacks.Inc()
+		data, err = s.searchFromDB(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		data.Degraded = true
+		data.Unavailable = []string{dto.SearchCapabilityHighlight, dto.SearchCapabilityRelevance, dto.SearchCapabilityAnalyzer}
+	}
+
+	if q := strings.TrimSpace(req.Q); q != "" && req.Page == 1 && req.AuthorID == nil {
+		data.Author = s.matchAuthor(ctx, q)
 	}
 	return data, nil
 }
 
-func (s *SearchService) searchFromES(req *dto.SearchVideoRequest) (*dto.SearchVideoData, error) {
+// matchAuthor 查找用户名与关键词相同或非常接近的作者，组装主页卡片；ES 不可用时只做精确匹配。
+// 匹配失败不影响视频搜索结果
+func (s *SearchService) matchAuthor(ctx context.Context, q string) *dto.SearchAuthorCard {
+	userID, err := s.searchAuthorFromES(ctx, q)
+	if err != nil {
+		user, err := s.userRepo.GetByUsername(ctx, q)
+		if err != nil {
+			return nil
+		}
+		userID = user.ID
+	}
+	if userID == 0 {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user.LegalHoldAt != nil || !isCloseUserName(q, user.UserName) {
+		return nil
+	}
+
+	videos, _, err := s.videoRepo.Search(ctx, &repository.VideoSearchFilter{AuthorID: &user.ID, Sort: "hot", Region: geoip.CountryFromContext(ctx)}, 0, authorCardTopVideos)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Get author top videos failed", zap.Int64("user_id", user.ID), zap.Error(err))
+	}
+	return &dto.SearchAuthorCard{
+		UserBriefInfo:  toUserBriefInfo(user),
+		FollowerCount:  user.FollowerCount,
+		TotalFavorited: user.TotalFavorited,
+		ExactMatch:     strings.EqualFold(q, user.UserName),
+		TopVideos:      s.buildSearchData(videos, nil, 0, 1, authorCardTopVideos).Videos,
+	}
+}
+
+// searchAuthorFromES 在 users 索引中查找最匹配的用户：精确匹配（不区分大小写）优先，其次按编辑距离模糊匹配
+func (s *SearchService) searchAuthorFromES(ctx context.Context, q string) (int64, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{
+						"user_name.keyword": map[string]interface{}{"value": strings.ToLower(q), "boost": 10},
+					}},
+					map[string]interface{}{"match": map[string]interface{}{
+						"user_name": map[string]interface{}{"query": q, "fuzziness": "AUTO"},
+					}},
+				},
+				"minimum_should_match": 1,
+			},
+		},
+		"_source": []string{"id"},
+		"size":    1,
+	}
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := infraES.Search(ctx, infraES.UsersIndexName(), bytes.NewReader(queryJSON))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.IsError() {
+		return 0, fmt.Errorf("ES user search error: %s", resp.String())
+	}
+
+	var esResp struct {
+		Hits struct {
+			Hits []struct {
+				Source struct {
+					ID int64 `json:"id"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&esResp); err != nil {
+		return 0, err
+	}
+	if len(esResp.Hits.Hits) == 0 {
+		return 0, nil
+	}
+	return esResp.Hits.Hits[0].Source.ID, nil
+}
+
+// isCloseUserName 关键词与用户名相同（不区分大小写），或编辑距离足够小：
+// 6 个字符以内允许差 1 个字符，更长的允许差 2 个
+func isCloseUserName(q, userName string) bool {
+	a, b := strings.ToLower(q), strings.ToLower(userName)
+	if a == b {
+		return true
+	}
+	maxDistance := 1
+	if utf8.RuneCountInString(a) > 6 {
+		maxDistance = 2
+	}
+	return levenshtein.ComputeDistance(a, b) <= maxDistance
+}
+
+func (s *SearchService) searchFromES(ctx context.Context, req *dto.SearchVideoRequest) (*dto.SearchVideoData, error) {
 	cfg := config.GetElasticsearch()
 	indexName := cfg.Index["videos"]
 	if indexName == "" {
 		indexName = "videos"
 	}
 
-	query := s.buildESQuery(req)
+	query := s.buildESQuery(req, geoip.CountryFromContext(ctx))
 	queryJSON, err := json.Marshal(query)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	resp, err := infraES.Search(ctx, indexName, bytes.NewReader(queryJSON))
@@ -75,7 +199,7 @@ func (s *SearchService) searchFromES(req *dto.SearchVideoRequest) (*dto.SearchVi
 				Value int64 `json:"value"`
 			} `json:"total"`
 			Hits []struct {
-				Source   struct {
+				Source struct {
 					ID int64 `json:"id"`
 				} `json:"_source"`
 				Highlight map[string][]string `json:"highlight"`
@@ -101,7 +225,7 @@ func (s *SearchService) searchFromES(req *dto.SearchVideoRequest) (*dto.SearchVi
 		return s.buildSearchData(nil, highlights, total, req.Page, req.PageSize), nil
 	}
 
-	videos, err := s.videoRepo.GetByIDsWithAuthor(videoIDs)
+	videos, err := s.videoRepo.GetByIDsWithAuthor(ctx, videoIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +237,8 @@ func (s *SearchService) searchFromES(req *dto.SearchVideoRequest) (*dto.SearchVi
 
 	ordered := make([]model.Video, 0, len(videoIDs))
 	for _, id := range videoIDs {
-		if v, ok := videoMap[id]; ok {
+		// 索引同步有延迟，可见范围以数据库为准
+		if v, ok := videoMap[id]; ok && !v.IsPrivate() {
 			ordered = append(ordered, *v)
 		}
 	}
@@ -121,10 +246,12 @@ func (s *SearchService) searchFromES(req *dto.SearchVideoRequest) (*dto.SearchVi
 	return s.buildSearchData(ordered, highlights, total, req.Page, req.PageSize), nil
 }
 
-func (s *SearchService) buildESQuery(req *dto.SearchVideoRequest) map[string]interface{} {
+// buildESQuery 构建搜索请求，只返回在 region 可见的视频（规则同 model.Video.AvailableIn）
+func (s *SearchService) buildESQuery(req *dto.SearchVideoRequest, region string) map[string]interface{} {
 	boolQ := map[string]interface{}{
 		"filter": []interface{}{
 			map[string]interface{}{"term": map[string]interface{}{"status": "published"}},
+			regionFilter(region),
 		},
 		"must": []interface{}{},
 	}
@@ -135,9 +262,9 @@ func (s *SearchService) buildESQuery(req *dto.SearchVideoRequest) map[string]int
 			boolQ["should"] = []interface{}{
 				map[string]interface{}{
 					"multi_match": map[string]interface{}{
-						"query":  q,
-						"fields": []string{"title^3", "description^1"},
-						"type":   "best_fields",
+						"query":    q,
+						"fields":   []string{"title^3", "description^1"},
+						"type":     "best_fields",
 						"operator": "or",
 					},
 				},
@@ -147,10 +274,10 @@ func (s *SearchService) buildESQuery(req *dto.SearchVideoRequest) map[string]int
 			boolQ["must"] = append(boolQ["must"].([]interface{}),
 				map[string]interface{}{
 					"multi_match": map[string]interface{}{
-						"query":  q,
-						"fields": []string{"title^3", "description^1"},
-						"type":   "best_fields",
-						"operator": "or",
+						"query":                q,
+						"fields":               []string{"title^3", "description^1"},
+						"type":                 "best_fields",
+						"operator":             "or",
 						"minimum_should_match": "50%",
 					},
 				},
@@ -177,13 +304,30 @@ func (s *SearchService) buildESQuery(req *dto.SearchVideoRequest) map[string]int
 		boolQ["filter"] = append(boolQ["filter"].([]interface{}),
 			map[string]interface{}{"range": map[string]interface{}{"publish_time": rangeQ}})
 	}
+	if req.MinHeight != nil {
+		boolQ["filter"] = append(boolQ["filter"].([]interface{}),
+			map[string]interface{}{"range": map[string]interface{}{"height": map[string]interface{}{"gte": *req.MinHeight}}})
+	}
+	if req.MinFrameRate != nil {
+		boolQ["filter"] = append(boolQ["filter"].([]interface{}),
+			map[string]interface{}{"range": map[string]interface{}{"frame_rate": map[string]interface{}{"gte": *req.MinFrameRate}}})
+	}
+	// 旧文档没有 is_sponsored 字段，排除推广视频时用 must_not 以保留这些文档
+	if req.Sponsored != nil {
+		sponsored := map[string]interface{}{"term": map[string]interface{}{"is_sponsored": true}}
+		if *req.Sponsored {
+			boolQ["filter"] = append(boolQ["filter"].([]interface{}), sponsored)
+		} else {
+			boolQ["must_not"] = []interface{}{sponsored}
+		}
+	}
 
 	sortConfig := []interface{}{}
 	switch req.Sort {
 	case "time":
 		sortConfig = append(sortConfig, map[string]interface{}{"publish_time": map[string]string{"order": "desc"}})
 	case "hot":
-		sortConfig = append(sortConfig, map[string]interface{}{"hot_score": map[string]string{"order": "desc"}})
+		sortConfig = append(sortConfig, hotSort(time.Now()))
 	default:
 		sortConfig = append(sortConfig, map[string]interface{}{"_score": map[string]string{"order": "desc"}})
 		sortConfig = append(sortConfig, map[string]interface{}{"publish_time": map[string]string{"order": "desc"}})
@@ -232,8 +376,14 @@ func (s *SearchService) buildSearchData(videos []model.Video, highlights map[int
 			ViewCount:     v.ViewCount,
 			FavoriteCount: v.FavoriteCount,
 			CommentCount:  v.CommentCount,
-			PublishTime:   v.PublishTime,
+			PublishTime:   v.PublishUnix(),
+			PublishedAt:   v.PublishTime,
 			Highlight:     highlights[v.ID],
+			AgeRestricted: v.AgeRestricted(),
+
+			MembersOnlyTierID: v.MembersOnlyTierID,
+			IsSponsored:       v.IsSponsored,
+			SponsorDisclosure: v.SponsorDisclosure,
 		}
 		items = append(items, info)
 	}
@@ -248,52 +398,103 @@ func (s *SearchService) buildSearchData(videos []model.Video, highlights map[int
 	}
 }
 
-func (s *SearchService) searchFromDB(req *dto.SearchVideoRequest) (*dto.SearchVideoData, error) {
-	skip := (req.Page - 1) * req.PageSize
-	status := "published"
+// hotDecayScript 按发布时长衰减 hot_score：0.5^(时长/半衰期)。
+// publish_time 在新索引中为 date、旧索引中为 long（Unix 秒），两种映射都要支持
+const hotDecayScript = `
+if (doc['publish_time'].size() == 0) { return 0; }
+def t = doc['publish_time'].value;
+long sec = t instanceof Number ? ((Number) t).longValue() : t.toEpochSecond();
+double age = Math.max(0.0, (double) (((Number) params.now).longValue() - sec));
+return doc['hot_score'].value * Math.pow(0.5, age / ((Number) params.half_life).doubleValue());`
+
+// hotSort 热度排序，配置了半衰期时在查询时按发布时长衰减（hot_score 本身不含衰减）
+func hotSort(now time.Time) map[string]interface{} {
+	halfLife := config.GetHotScore().HalfLife()
+	if halfLife <= 0 {
+		return map[string]interface{}{"hot_score": map[string]string{"order": "desc"}}
+	}
+	return map[string]interface{}{
+		"_script": map[string]interface{}{
+			"type":  "number",
+			"order": "desc",
+			"script": map[string]interface{}{
+				"source": hotDecayScript,
+				"params": map[string]interface{}{
+					"now":       now.Unix(),
+					"half_life": halfLife.Seconds(),
+				},
+			},
+		},
+	}
+}
 
-	var authorID *int64
-	if req.AuthorID != nil {
-		authorID = req.AuthorID
+// regionFilter 未设置仅限地区或 region 在仅限地区内，且 region 不在屏蔽地区内
+func regionFilter(region string) map[string]interface{} {
+	noAllowList := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must_not": map[string]interface{}{"exists": map[string]interface{}{"field": "allowed_regions"}},
+		},
 	}
-	var search *string
-	if strings.TrimSpace(req.Q) != "" {
-		q := strings.TrimSpace(req.Q)
-		search = &q
+	if region == "" {
+		return noAllowList
 	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []interface{}{
+				noAllowList,
+				map[string]interface{}{"term": map[string]interface{}{"allowed_regions": region}},
+			},
+			"minimum_should_match": 1,
+			"must_not":             map[string]interface{}{"term": map[string]interface{}{"blocked_regions": region}},
+		},
+	}
+}
 
-	videos, total, err := s.videoRepo.ListVideos(skip, req.PageSize, authorID, &status, search, true)
-	if err != nil {
-		return nil, err
+// unixTime 把 Unix 秒查询参数转换为时间
+func unixTime(sec *int64) *time.Time {
+	if sec == nil {
+		return nil
 	}
+	t := time.Unix(*sec, 0)
+	return &t
+}
 
-	if req.VideoID != nil {
-		filtered := make([]model.Video, 0)
-		for i := range videos {
-			if videos[i].ID == *req.VideoID {
-				filtered = append(filtered, videos[i])
-				total = 1
-				break
-			}
-		}
-		videos = filtered
+func (s *SearchService) searchFromDB(ctx context.Context, req *dto.SearchVideoRequest) (*dto.SearchVideoData, error) {
+	skip := (req.Page - 1) * req.PageSize
+	filter := &repository.VideoSearchFilter{
+		Query:     strings.TrimSpace(req.Q),
+		AuthorID:  req.AuthorID,
+		VideoID:   req.VideoID,
+		StartTime: unixTime(req.StartTime),
+		EndTime:   unixTime(req.EndTime),
+		Sort:      req.Sort,
+		Region:    geoip.CountryFromContext(ctx),
+
+		MinHeight:    req.MinHeight,
+		MinFrameRate: req.MinFrameRate,
+		Sponsored:    req.Sponsored,
 	}
 
-	if req.Sort == "hot" {
-		// 简单按热度排序（已在 DB 层可扩展）
-		// 这里 ListVideos 默认按 created_at，如需 hot 需在 repo 加排序
+	videos, total, err := s.videoRepo.Search(ctx, filter, skip, req.PageSize)
+	if err != nil {
+		return nil, err
 	}
 
 	return s.buildSearchData(videos, nil, total, req.Page, req.PageSize), nil
 }
 
-// SyncVideoToES 同步单个视频到 ES（转码完成后调用）
-func (s *SearchService) SyncVideoToES(videoID int64) error {
-	video, err := s.videoRepo.GetByIDWithAuthor(videoID)
+// OnVideoPublished 订阅视频发布事件：写入搜索索引
+func (s *SearchService) OnVideoPublished(ctx context.Context, event *eventbus.VideoPublished) error {
+	return s.SyncVideoToES(ctx, event.VideoID)
+}
+
+// SyncVideoToES 同步单个视频到 ES
+func (s *SearchService) SyncVideoToES(ctx context.Context, videoID int64) error {
+	video, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID)
 	if err != nil {
 		return err
 	}
-	if video.Status != "published" {
+	if video.Status != "published" || video.LegalHoldAt != nil || video.IsPrivate() {
 		return nil
 	}
 
@@ -302,16 +503,61 @@ func (s *SearchService) SyncVideoToES(videoID int64) error {
 		authorName = video.Author.UserName
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	return infraES.SyncVideo(ctx, video, authorName)
 }
 
+// SyncUsersToES 分批同步所有用户到 ES users 索引
+func (s *SearchService) SyncUsersToES(ctx context.Context) (success, failed int, err error) {
+	for afterID := int64(0); ; {
+		ids, err := s.userRepo.ListIDsAfter(ctx, afterID, authorSyncBatch)
+		if err != nil {
+			return success, failed, err
+		}
+		if len(ids) == 0 {
+			return success, failed, nil
+		}
+		afterID = ids[len(ids)-1]
+
+		users, err := s.userRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			return success, failed, err
+		}
+		// 保全中的账号不出现在用户搜索中
+		users = slices.DeleteFunc(users, func(u model.User) bool { return u.LegalHoldAt != nil })
+		ok, bad, err := infraES.BulkSyncUsers(ctx, users)
+		if err != nil {
+			return success, failed + len(users), err
+		}
+		success += ok
+		failed += bad
+	}
+}
+
+// syncUserToES 注册、改名、恢复后更新 users 索引，失败只记录日志（可通过全量同步修复）
+func syncUserToES(ctx context.Context, user *model.User) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := infraES.SyncUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Warn("Sync user to ES failed", zap.Int64("user_id", user.ID), zap.Error(err))
+	}
+}
+
+// removeUserFromES 删除用户后从 users 索引移除，失败只记录日志
+func removeUserFromES(ctx context.Context, userID int64) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := infraES.DeleteUser(ctx, userID); err != nil {
+		logger.FromContext(ctx).Warn("Remove user from ES failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
 // SyncVideosToES 同步所有已发布视频到 ES
-func (s *SearchService) SyncVideosToES() (success, failed int, err error) {
+func (s *SearchService) SyncVideosToES(ctx context.Context) (success, failed int, err error) {
 	status := "published"
-	videos, _, err := s.videoRepo.ListVideos(0, 10000, nil, &status, nil, true)
+	videos, _, err := s.videoRepo.ListVideos(ctx, 0, 10000, nil, &status, nil, true)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -327,7 +573,7 @@ func (s *SearchService) SyncVideosToES() (success, failed int, err error) {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
 	return infraES.BulkSyncVideos(ctx, videos, authorNames)
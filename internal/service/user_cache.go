@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const userBriefCacheTTL = 10 * time.Minute
+
+// UserCache 用户简要信息缓存（Redis），只缓存与查看者无关的字段
+// 缓存读写失败时降级为直接查库，不影响接口可用性
+type UserCache struct {
+	client *redis.Client
+}
+
+func NewUserCache(client *redis.Client) *UserCache {
+	return &UserCache{client: client}
+}
+
+func userBriefCacheKey(userID int64) string {
+	return fmt.Sprintf("user:brief:%d", userID)
+}
+
+// GetBriefs 批量读取缓存，返回命中的用户与未命中的 ID
+func (c *UserCache) GetBriefs(ctx context.Context, ids []int64) (map[int64]dto.UserBriefInfo, []int64) {
+	hits := make(map[int64]dto.UserBriefInfo, len(ids))
+	if c.client == nil || len(ids) == 0 {
+		return hits, ids
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = userBriefCacheKey(id)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		logger.FromContext(ctx).Warn("Get user cache failed", zap.Error(err))
+		return hits, ids
+	}
+
+	var misses []int64
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			misses = append(misses, ids[i])
+			continue
+		}
+		var info dto.UserBriefInfo
+		if err := json.Unmarshal([]byte(s), &info); err != nil {
+			misses = append(misses, ids[i])
+			continue
+		}
+		hits[ids[i]] = info
+	}
+	return hits, misses
+}
+
+// SetBriefs 写入缓存（尽力而为，失败只记录日志）
+func (c *UserCache) SetBriefs(ctx context.Context, users []dto.UserBriefInfo) {
+	if c.client == nil || len(users) == 0 {
+		return
+	}
+	pipe := c.client.Pipeline()
+	for _, u := range users {
+		data, err := json.Marshal(u)
+		if err != nil {
+			continue
+		}
+		pipe.Set(ctx, userBriefCacheKey(u.ID), data, userBriefCacheTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.FromContext(ctx).Warn("Set user cache failed", zap.Error(err))
+	}
+}
+
+// Invalidate 用户名、头像、认证状态变更或用户被删除时清除缓存
+func (c *UserCache) Invalidate(ctx context.Context, userID int64) {
+	if c.client == nil {
+		return
+	}
+	if err := c.client.Del(context.WithoutCancel(ctx), userBriefCacheKey(userID)).Err(); err != nil {
+		logger.FromContext(ctx).Warn("Invalidate user cache failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
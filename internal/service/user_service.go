@@ -1,44 +1,140 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"vida-go/internal/api/dto"
 	"vida-go/internal/model"
+	"vida-go/internal/rbac"
 	"vida-go/internal/repository"
 
 	"gorm.io/gorm"
 )
 
+var ErrInvalidRole = errors.New("无效的用户角色")
+
 type UserService struct {
-	userRepo *repository.UserRepository
+	userRepo            *repository.UserRepository
+	relationRepo        *repository.RelationRepository
+	userCache           *UserCache
+	notificationService *NotificationService
+	profileImageService *ProfileImageService
 }
 
-func NewUserService(userRepo *repository.UserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(userRepo *repository.UserRepository, relationRepo *repository.RelationRepository, userCache *UserCache, notificationService *NotificationService, profileImageService *ProfileImageService) *UserService {
+	return &UserService{
+		userRepo:            userRepo,
+		relationRepo:        relationRepo,
+		userCache:           userCache,
+		notificationService: notificationService,
+		profileImageService: profileImageService,
+	}
 }
 
-// GetUserByID 获取用户信息
-func (s *UserService) GetUserByID(id int64) (*dto.UserFullInfo, error) {
-	user, err := s.userRepo.GetByID(id)
+// GetUserByID 获取用户信息（本人或管理员），附带审核中的头像、背景图
+func (s *UserService) GetUserByID(ctx context.Context, id int64) (*dto.UserFullInfo, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
+	info := toUserFullInfo(user)
+	if err := s.profileImageService.FillPending(ctx, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetProfile 获取公开主页信息，处于法律保全中的账号对外不可见
+func (s *UserService) GetProfile(ctx context.Context, id int64) (*dto.UserFullInfo, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.LegalHoldAt != nil {
+		return nil, ErrUserNotFound
+	}
 	return toUserFullInfo(user), nil
 }
 
+// GetUsersByIDs 批量获取用户信息，已删除或不存在的用户不在结果中
+func (s *UserService) GetUsersByIDs(ctx context.Context, ids []int64) (map[int64]*dto.UserFullInfo, error) {
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]*dto.UserFullInfo, len(users))
+	for i := range users {
+		result[users[i].ID] = toUserFullInfo(&users[i])
+	}
+	return result, nil
+}
+
+// BatchGetBriefs 批量获取用户简要信息，按请求顺序返回，已删除或不存在的用户跳过
+// viewerID 大于 0 时附带关注状态
+func (s *UserService) BatchGetBriefs(ctx context.Context, viewerID int64, ids []int64) ([]dto.UserBriefInfo, error) {
+	ids = uniqueIDs(ids)
+
+	users, misses := s.userCache.GetBriefs(ctx, ids)
+	if len(misses) > 0 {
+		rows, err := s.userRepo.GetByIDs(ctx, misses)
+		if err != nil {
+			return nil, err
+		}
+		loaded := make([]dto.UserBriefInfo, len(rows))
+		for i := range rows {
+			loaded[i] = toUserBriefInfo(&rows[i])
+			users[rows[i].ID] = loaded[i]
+		}
+		s.userCache.SetBriefs(ctx, loaded)
+	}
+
+	var following map[int64]bool
+	if viewerID > 0 {
+		var err error
+		following, err = s.relationRepo.BatchCheckFollowing(ctx, viewerID, ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]dto.UserBriefInfo, 0, len(users))
+	for _, id := range ids {
+		info, ok := users[id]
+		if !ok {
+			continue
+		}
+		if following != nil {
+			isFollowing := following[id]
+			info.IsFollowing = &isFollowing
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
 // UpdateUser 更新用户信息（本人或管理员）
-func (s *UserService) UpdateUser(targetID int64, currentUser *dto.UserInfo, req *dto.UserUpdateRequest) (*dto.UserFullInfo, error) {
-	if currentUser.ID != targetID && currentUser.UserRole != "admin" {
-		return nil, errors.New("没有权限修改该用户信息")
+func (s *UserService) UpdateUser(ctx context.Context, targetID int64, currentUser *dto.UserInfo, req *dto.UserUpdateRequest) (*dto.UserFullInfo, error) {
+	if currentUser.ID != targetID && !rbac.HasPermission(currentUser.UserRole, rbac.PermManageUsers) {
+		return nil, ErrUserNoPermission
+	}
+	if err := checkUserLegalHold(ctx, s.userRepo, targetID); err != nil {
+		return nil, err
 	}
 
 	updates := make(map[string]interface{})
 	if req.Username != nil {
-		exists, err := s.userRepo.ExistsByUsername(*req.Username)
+		if err := validateUsername(*req.Username, rbac.HasPermission(currentUser.UserRole, rbac.PermManageUsers)); err != nil {
+			return nil, err
+		}
+		exists, err := s.userRepo.ExistsByUsername(ctx, *req.Username)
 		if err != nil {
 			return nil, err
 		}
@@ -47,54 +143,133 @@ func (s *UserService) UpdateUser(targetID int64, currentUser *dto.UserInfo, req
 		}
 		updates["user_name"] = *req.Username
 	}
-	if req.Avatar != nil {
-		updates["avatar"] = *req.Avatar
+	// 需要审核的新图片在其他字段更新成功后提交，审核通过前主页仍展示旧图
+	reviewImages := make(map[string]string)
+	var directImages []string
+	for kind, url := range map[string]*string{model.ProfileImageAvatar: req.Avatar, model.ProfileImageBackground: req.BackgroundImage} {
+		if url == nil {
+			continue
+		}
+		if s.profileImageService.NeedsReview(currentUser, *url) {
+			reviewImages[kind] = *url
+			continue
+		}
+		updates[model.ProfileImageColumn(kind)] = *url
+		directImages = append(directImages, kind)
 	}
-	if req.BackgroundImage != nil {
-		updates["background_image"] = *req.BackgroundImage
+	if req.BirthDate != nil {
+		birthDate, err := s.checkBirthDateUpdate(ctx, targetID, currentUser, *req.BirthDate)
+		if err != nil {
+			return nil, err
+		}
+		updates["birth_date"] = birthDate
 	}
 
-	if len(updates) == 0 {
-		return s.GetUserByID(targetID)
+	if len(updates) == 0 && len(reviewImages) == 0 {
+		return s.GetUserByID(ctx, targetID)
 	}
 
-	user, err := s.userRepo.Update(targetID, updates)
+	var user *model.User
+	var err error
+	if len(updates) > 0 {
+		user, err = s.userRepo.Update(ctx, targetID, updates)
+	} else {
+		user, err = s.userRepo.GetByID(ctx, targetID)
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
-	return toUserFullInfo(user), nil
+	if len(updates) > 0 {
+		s.userCache.Invalidate(ctx, targetID)
+	}
+	if req.Username != nil {
+		syncUserToES(ctx, user)
+	}
+
+	for _, kind := range directImages {
+		if err := s.profileImageService.Discard(ctx, targetID, kind); err != nil {
+			return nil, err
+		}
+	}
+	for kind, url := range reviewImages {
+		if err := s.profileImageService.Submit(ctx, user, kind, url); err != nil {
+			return nil, err
+		}
+	}
+	info := toUserFullInfo(user)
+	if err := s.profileImageService.FillPending(ctx, info); err != nil {
+		return nil, err
+	}
+	return info, nil
 }
 
-// SoftDeleteUser 软删除用户（管理员）
-func (s *UserService) SoftDeleteUser(userID int64) error {
-	_, err := s.userRepo.Update(userID, map[string]interface{}{"is_delete": 1})
+// checkBirthDateUpdate 校验出生日期：不能晚于今天，已设置过的只有管理员可以修改
+func (s *UserService) checkBirthDateUpdate(ctx context.Context, targetID int64, currentUser *dto.UserInfo, value string) (time.Time, error) {
+	birthDate, err := parseBirthDate(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if rbac.HasPermission(currentUser.UserRole, rbac.PermManageUsers) {
+		return birthDate, nil
+	}
+	user, err := s.userRepo.GetByID(ctx, targetID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, ErrUserNotFound
+		}
+		return time.Time{}, err
+	}
+	if user.BirthDate != nil && user.BirthDate.Format(birthDateLayout) != birthDate.Format(birthDateLayout) {
+		return time.Time{}, ErrBirthDateLocked
+	}
+	return birthDate, nil
+}
+
+// SoftDeleteUser 软删除用户（管理员）
+func (s *UserService) SoftDeleteUser(ctx context.Context, userID int64) error {
+	if err := checkUserLegalHold(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if err := s.userRepo.SoftDelete(ctx, userID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrUserNotFound
 		}
 		return err
 	}
+	s.userCache.Invalidate(ctx, userID)
+	removeUserFromES(ctx, userID)
 	return nil
 }
 
 // RestoreUser 恢复已删除用户（管理员）
-func (s *UserService) RestoreUser(userID int64) error {
-	_, err := s.userRepo.Update(userID, map[string]interface{}{"is_delete": 0})
-	if err != nil {
+func (s *UserService) RestoreUser(ctx context.Context, userID int64) error {
+	if err := s.userRepo.Restore(ctx, userID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrUserNotFound
 		}
 		return err
 	}
+	s.userCache.Invalidate(ctx, userID)
+	if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+		syncUserToES(ctx, user)
+	}
 	return nil
 }
 
 // SetAdminRole 设置管理员角色（管理员）
-func (s *UserService) SetAdminRole(userID int64) (*dto.UserFullInfo, error) {
-	user, err := s.userRepo.Update(userID, map[string]interface{}{"user_role": "admin"})
+func (s *UserService) SetAdminRole(ctx context.Context, userID int64) (*dto.UserFullInfo, error) {
+	return s.SetRole(ctx, userID, rbac.RoleAdmin)
+}
+
+// SetRole 设置用户角色（管理员）
+func (s *UserService) SetRole(ctx context.Context, userID int64, role string) (*dto.UserFullInfo, error) {
+	if !rbac.IsValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+	user, err := s.userRepo.Update(ctx, userID, map[string]interface{}{"user_role": role})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
@@ -104,10 +279,63 @@ func (s *UserService) SetAdminRole(userID int64) (*dto.UserFullInfo, error) {
 	return toUserFullInfo(user), nil
 }
 
+// SuspendUser 封禁用户（管理员），封禁期间不能登录
+func (s *UserService) SuspendUser(ctx context.Context, userID int64, duration time.Duration, reason string) (*dto.UserRestrictionInfo, error) {
+	until := time.Now().Add(duration)
+	info, err := s.updateRestriction(ctx, userID, map[string]interface{}{"suspended_until": until, "suspend_reason": reason})
+	if err != nil {
+		return nil, err
+	}
+	s.notificationService.EmitSystem(ctx, userID, systemMessage("您的账号已被封禁至 "+until.Format("2006-01-02 15:04"), reason))
+	return info, nil
+}
+
+// UnsuspendUser 解除封禁（管理员）
+func (s *UserService) UnsuspendUser(ctx context.Context, userID int64) (*dto.UserRestrictionInfo, error) {
+	info, err := s.updateRestriction(ctx, userID, map[string]interface{}{"suspended_until": nil, "suspend_reason": ""})
+	if err != nil {
+		return nil, err
+	}
+	s.notificationService.EmitSystem(ctx, userID, "您的账号封禁已解除")
+	return info, nil
+}
+
+// MuteUser 禁言用户（管理员），禁言期间不能上传视频和发表评论
+func (s *UserService) MuteUser(ctx context.Context, userID int64, duration time.Duration, reason string) (*dto.UserRestrictionInfo, error) {
+	until := time.Now().Add(duration)
+	info, err := s.updateRestriction(ctx, userID, map[string]interface{}{"muted_until": until, "mute_reason": reason})
+	if err != nil {
+		return nil, err
+	}
+	s.notificationService.EmitSystem(ctx, userID, systemMessage("您已被禁言至 "+until.Format("2006-01-02 15:04"), reason))
+	return info, nil
+}
+
+// UnmuteUser 解除禁言（管理员）
+func (s *UserService) UnmuteUser(ctx context.Context, userID int64) (*dto.UserRestrictionInfo, error) {
+	info, err := s.updateRestriction(ctx, userID, map[string]interface{}{"muted_until": nil, "mute_reason": ""})
+	if err != nil {
+		return nil, err
+	}
+	s.notificationService.EmitSystem(ctx, userID, "您的禁言已解除")
+	return info, nil
+}
+
+func (s *UserService) updateRestriction(ctx context.Context, userID int64, updates map[string]interface{}) (*dto.UserRestrictionInfo, error) {
+	user, err := s.userRepo.Update(ctx, userID, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return toUserRestrictionInfo(user), nil
+}
+
 // ListUsers 获取用户列表（管理员，带筛选和分页）
-func (s *UserService) ListUsers(page, pageSize int, username, userRole *string) (*dto.PaginatedData, error) {
+func (s *UserService) ListUsers(ctx context.Context, page, pageSize int, username, userRole *string) (*dto.PaginatedData, error) {
 	skip := (page - 1) * pageSize
-	users, total, err := s.userRepo.ListWithFilters(skip, pageSize, username, userRole)
+	users, total, err := s.userRepo.ListWithFilters(ctx, skip, pageSize, username, userRole)
 	if err != nil {
 		return nil, err
 	}
@@ -130,6 +358,19 @@ func (s *UserService) ListUsers(page, pageSize int, username, userRole *string)
 	}, nil
 }
 
+func toUserRestrictionInfo(user *model.User) *dto.UserRestrictionInfo {
+	info := &dto.UserRestrictionInfo{UserID: user.ID}
+	if restrictionActive(user.SuspendedUntil) {
+		info.SuspendedUntil = user.SuspendedUntil
+		info.SuspendReason = user.SuspendReason
+	}
+	if restrictionActive(user.MutedUntil) {
+		info.MutedUntil = user.MutedUntil
+		info.MuteReason = user.MuteReason
+	}
+	return info
+}
+
 func toUserFullInfo(user *model.User) *dto.UserFullInfo {
 	return &dto.UserFullInfo{
 		ID:              user.ID,
@@ -141,5 +382,28 @@ func toUserFullInfo(user *model.User) *dto.UserFullInfo {
 		FollowerCount:   user.FollowerCount,
 		TotalFavorited:  user.TotalFavorited,
 		FavoriteCount:   user.FavoriteCount,
+		IsVerified:      user.IsVerified,
+	}
+}
+
+func toUserBriefInfo(user *model.User) dto.UserBriefInfo {
+	return dto.UserBriefInfo{
+		ID:         user.ID,
+		Username:   user.UserName,
+		Avatar:     user.Avatar,
+		IsVerified: user.IsVerified,
+	}
+}
+
+// uniqueIDs 去重并保持原有顺序
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
 	}
+	return result
 }
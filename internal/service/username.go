@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/sensitive"
+)
+
+var (
+	ErrUsernameReserved      = errors.New("该用户名为系统保留，请更换")
+	ErrUsernameInappropriate = errors.New("用户名包含不当内容，请更换")
+)
+
+// reservedUsernames 与路由、系统账号同名的保留用户名（按 sensitive.Normalize 归一化后整体比较）
+var reservedUsernames = []string{
+	"admin", "administrator", "root", "system", "sysadmin", "superuser", "moderator", "mod", "staff",
+	"support", "help", "official", "security", "abuse", "noreply", "postmaster", "webmaster",
+	"api", "auth", "login", "logout", "register", "signup", "signin", "me", "my", "user", "users",
+	"video", "videos", "search", "feed", "explore", "trending", "settings", "notifications", "messages",
+	"moderation", "creator", "events", "graphql", "health", "metrics", "docs", "swagger", "static",
+	"v1", "v2", "null", "undefined", "anonymous", "guest", "everyone", "vida",
+}
+
+// protectedUsernameWords 用户名中不能包含的词，防止冒充官方账号（如 vida_official、admin2）
+var protectedUsernameWords = []string{"admin", "moderator", "official", "vida"}
+
+// validateUsername 校验用户名：不能与保留名相同、不能冒充官方账号、不能包含敏感词。
+// 比较前统一归一化，"Adm1n"、"ａｄｍｉｎ"、"аdmin"（西里尔字母 а）等形近写法均视为 admin。
+// allowReserved 为 true 时（管理员为官方账号改名）跳过保留名校验
+func validateUsername(name string, allowReserved bool) error {
+	if sensitive.ContainsFolded(name) {
+		return ErrUsernameInappropriate
+	}
+	if allowReserved {
+		return nil
+	}
+
+	folded := sensitive.Normalize(name)
+	for _, list := range [][]string{reservedUsernames, config.GetUsername().Reserved} {
+		for _, reserved := range list {
+			if folded == sensitive.Normalize(reserved) {
+				return ErrUsernameReserved
+			}
+		}
+	}
+	for _, word := range protectedUsernameWords {
+		if strings.Contains(folded, sensitive.Normalize(word)) {
+			return ErrUsernameReserved
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"vida-go/internal/config"
+	"vida-go/pkg/sensitive"
+)
+
+// loadUsernameTestConfig 加载只含额外保留用户名的配置，并设置测试用的敏感词库
+func loadUsernameTestConfig(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("username:\n  reserved: [\"streamer\"]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := sensitive.Init(""); err != nil {
+		t.Fatal(err)
+	}
+	sensitive.Add("shit")
+	t.Cleanup(func() { _ = sensitive.Init("") })
+}
+
+func TestValidateUsername(t *testing.T) {
+	loadUsernameTestConfig(t)
+
+	tests := []struct {
+		name          string
+		username      string
+		allowReserved bool
+		want          error
+	}{
+		{name: "ordinary", username: "alice", want: nil},
+		{name: "reserved", username: "admin", want: ErrUsernameReserved},
+		{name: "reserved upper case", username: "ADMIN", want: ErrUsernameReserved},
+		{name: "reserved leetspeak", username: "Adm1n", want: ErrUsernameReserved},
+		{name: "reserved full width", username: "ａｄｍｉｎ", want: ErrUsernameReserved},
+		{name: "reserved cyrillic a", username: "аdmin", want: ErrUsernameReserved},
+		{name: "reserved greek o", username: "rοot", want: ErrUsernameReserved},
+		{name: "reserved with accents", username: "ròót", want: ErrUsernameReserved},
+		{name: "reserved with separators", username: "s.u-p_p o r t", want: ErrUsernameReserved},
+		{name: "reserved l and 1 folded", username: "HE1P", want: ErrUsernameReserved},
+		{name: "reserved from config", username: "Str3amer", want: ErrUsernameReserved},
+		{name: "protected word", username: "vida_official", want: ErrUsernameReserved},
+		{name: "protected word homoglyph", username: "the_аdm1n2", want: ErrUsernameReserved},
+		{name: "contains reserved but not protected", username: "rootbeer", want: nil},
+		{name: "sensitive word", username: "5h1t", want: ErrUsernameInappropriate},
+		{name: "sensitive word with separators", username: "s-h-i-t", want: ErrUsernameInappropriate},
+		{name: "admin may use reserved", username: "admin", allowReserved: true, want: nil},
+		{name: "admin still blocked by sensitive word", username: "shit", allowReserved: true, want: ErrUsernameInappropriate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateUsername(tt.username, tt.allowReserved); !errors.Is(err, tt.want) {
+				t.Errorf("validateUsername(%q, %v) = %v, want %v", tt.username, tt.allowReserved, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVideoAccessSelf    = errors.New("不能授权给作者本人")
+	ErrVideoGrantLimit    = errors.New("授权人数已达上限")
+	ErrVideoGrantNotFound = errors.New("该用户未被授权")
+	ErrShareLinkInvalid   = errors.New("分享链接无效或已失效")
+)
+
+const (
+	// MaxVideoGrantees 每个视频最多授权的用户数
+	MaxVideoGrantees = 500
+
+	shareTokenBytes = 24
+)
+
+// VideoAccessService 私密视频授权：作者可将视频授权给指定用户，或生成分享链接由登录用户领取观看权限
+type VideoAccessService struct {
+	videoRepo      *repository.VideoRepository
+	userRepo       *repository.UserRepository
+	accessRepo     *repository.VideoAccessRepository
+	membershipRepo *repository.MembershipRepository
+}
+
+func NewVideoAccessService(videoRepo *repository.VideoRepository, userRepo *repository.UserRepository, accessRepo *repository.VideoAccessRepository, membershipRepo *repository.MembershipRepository) *VideoAccessService {
+	return &VideoAccessService{videoRepo: videoRepo, userRepo: userRepo, accessRepo: accessRepo, membershipRepo: membershipRepo}
+}
+
+// ListGrants 作者分页查看视频的被授权用户
+func (s *VideoAccessService) ListGrants(ctx context.Context, videoID, authorID int64, page, pageSize int) (*dto.VideoAccessListData, error) {
+	video, err := s.authorVideo(ctx, videoID, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, total, err := s.accessRepo.ListByVideo(ctx, videoID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dto.VideoAccessGrantInfo, 0, len(grants))
+	for i := range grants {
+		items = append(items, dto.VideoAccessGrantInfo{
+			User:      toUserBriefInfo(&grants[i].User),
+			Via:       grants[i].Via,
+			GrantedAt: grants[i].CreatedAt,
+		})
+	}
+	return &dto.VideoAccessListData{
+		Visibility: video.Visibility,
+		ShareLink:  video.ShareTokenHash != "",
+		Grants:     items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// Grant 作者授权指定用户观看视频，不存在的用户忽略
+func (s *VideoAccessService) Grant(ctx context.Context, videoID, authorID int64, userIDs []int64) (*dto.VideoAccessGrantResult, error) {
+	if _, err := s.authorVideo(ctx, videoID, authorID); err != nil {
+		return nil, err
+	}
+	userIDs = uniqueIDs(userIDs)
+	for _, id := range userIDs {
+		if id == authorID {
+			return nil, ErrVideoAccessSelf
+		}
+	}
+
+	existing, err := s.userRepo.ExistingIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.accessRepo.CountByVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	// 已授权的用户会重复计入，按上限从严判断
+	if count+int64(len(existing)) > MaxVideoGrantees {
+		return nil, ErrVideoGrantLimit
+	}
+
+	grants := make([]model.VideoAccessGrant, 0, len(existing))
+	for _, id := range existing {
+		grants = append(grants, model.VideoAccessGrant{VideoID: videoID, UserID: id, Via: model.VideoAccessViaDirect})
+	}
+	granted, err := s.accessRepo.Grant(ctx, grants)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.VideoAccessGrantResult{Granted: granted}, nil
+}
+
+// Revoke 作者取消用户的观看权限（包括通过分享链接领取的）
+func (s *VideoAccessService) Revoke(ctx context.Context, videoID, authorID, userID int64) error {
+	if _, err := s.authorVideo(ctx, videoID, authorID); err != nil {
+		return err
+	}
+	found, err := s.accessRepo.Revoke(ctx, videoID, userID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrVideoGrantNotFound
+	}
+	return nil
+}
+
+// CreateShareLink 生成分享链接令牌，已有的链接随之失效；已领取权限的用户不受影响
+func (s *VideoAccessService) CreateShareLink(ctx context.Context, videoID, authorID int64) (*dto.VideoShareLinkData, error) {
+	if _, err := s.authorVideo(ctx, videoID, authorID); err != nil {
+		return nil, err
+	}
+	token, err := randomHex(shareTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.videoRepo.Update(ctx, videoID, map[string]interface{}{"share_token_hash": hashShareToken(token)}); err != nil {
+		return nil, err
+	}
+	return &dto.VideoShareLinkData{VideoID: videoID, Token: token}, nil
+}
+
+// RevokeShareLink 关闭链接分享；已领取权限的用户需单独取消
+func (s *VideoAccessService) RevokeShareLink(ctx context.Context, videoID, authorID int64) error {
+	if _, err := s.authorVideo(ctx, videoID, authorID); err != nil {
+		return err
+	}
+	_, err := s.videoRepo.Update(ctx, videoID, map[string]interface{}{"share_token_hash": ""})
+	return err
+}
+
+// RedeemShareLink 登录用户凭分享链接令牌领取视频的观看权限
+func (s *VideoAccessService) RedeemShareLink(ctx context.Context, videoID, userID int64, token string) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShareLinkInvalid
+		}
+		return nil, err
+	}
+	if video.Status == VideoStatusHidden || video.LegalHoldAt != nil || video.ShareTokenHash == "" ||
+		subtle.ConstantTimeCompare([]byte(video.ShareTokenHash), []byte(hashShareToken(token))) != 1 {
+		return nil, ErrShareLinkInvalid
+	}
+
+	if video.AuthorID != userID {
+		if count, err := s.accessRepo.CountByVideo(ctx, videoID); err != nil {
+			return nil, err
+		} else if count >= MaxVideoGrantees {
+			return nil, ErrVideoGrantLimit
+		}
+		grant := model.VideoAccessGrant{VideoID: videoID, UserID: userID, Via: model.VideoAccessViaLink}
+		if _, err := s.accessRepo.Grant(ctx, []model.VideoAccessGrant{grant}); err != nil {
+			return nil, err
+		}
+	}
+	// 分享链接只授予观看权限，年龄限制与会员专属限制照常生效
+	infos := []dto.VideoInfo{*toVideoInfo(video, true)}
+	if err := applyContentGate(ctx, s.userRepo, s.membershipRepo, userID, infos); err != nil {
+		return nil, err
+	}
+	return &infos[0], nil
+}
+
+// authorVideo 获取作者本人的视频，非作者返回 ErrVideoNoPermission
+func (s *VideoAccessService) authorVideo(ctx context.Context, videoID, authorID int64) (*model.Video, error) {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, authorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNoPermission
+		}
+		return nil, err
+	}
+	return video, nil
+}
+
+// canViewVideo 公开视频所有人可见；私密视频仅作者及被授权的用户可见
+func canViewVideo(ctx context.Context, accessRepo *repository.VideoAccessRepository, video *model.Video, viewerID int64) (bool, error) {
+	if !video.IsPrivate() || (viewerID != 0 && video.AuthorID == viewerID) {
+		return true, nil
+	}
+	if viewerID == 0 {
+		return false, nil
+	}
+	return accessRepo.Exists(ctx, video.ID, viewerID)
+}
+
+// hashShareToken 分享链接令牌为高熵随机串，只保存 SHA-256 摘要
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
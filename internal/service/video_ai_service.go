@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/config"
+	infraAgent "vida-go/internal/infra/agent"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	machineTagLimit      = 10 // 每个视频保留的 AI 标签数
+	machineCategoryLimit = 3
+	videoTagMaxLength    = 50
+	summaryMaxLength     = 500
+	keyMomentLimit       = 8
+	keyMomentLabelLength = 50
+	videoAITimeout       = 2 * time.Minute
+	askTimeout           = 2 * time.Minute
+)
+
+var (
+	ErrAgentUnavailable = errors.New("AI 服务暂不可用")
+	ErrAskRateLimited   = errors.New("提问过于频繁，请稍后再试")
+	ErrAskQuotaExceeded = errors.New("今日提问次数已用完")
+)
+
+// VideoAIService 调用 Agent 服务为视频生成标签、摘要与关键时刻，并提供视频问答
+type VideoAIService struct {
+	videoRepo      *repository.VideoRepository
+	videoTagRepo   *repository.VideoTagRepository
+	userRepo       *repository.UserRepository
+	accessRepo     *repository.VideoAccessRepository
+	membershipRepo *repository.MembershipRepository
+	client         *redis.Client
+}
+
+func NewVideoAIService(videoRepo *repository.VideoRepository, videoTagRepo *repository.VideoTagRepository, userRepo *repository.UserRepository, accessRepo *repository.VideoAccessRepository, membershipRepo *repository.MembershipRepository, client *redis.Client) *VideoAIService {
+	return &VideoAIService{videoRepo: videoRepo, videoTagRepo: videoTagRepo, userRepo: userRepo, accessRepo: accessRepo, membershipRepo: membershipRepo, client: client}
+}
+
+// OnPublished 视频发布后异步生成 AI 标签、摘要与关键时刻，不阻塞转码结果处理
+func (s *VideoAIService) OnPublished(ctx context.Context, video *model.Video, frames []infraKafka.VideoFrame) {
+	cfg := config.GetAgent()
+	if !infraAgent.Enabled() || (!cfg.AutoTag && !cfg.AutoSummary) {
+		return
+	}
+
+	req := videoAgentRequest(video, frames)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), videoAITimeout)
+		defer cancel()
+		if cfg.AutoTag {
+			if err := s.GenerateTags(ctx, req); err != nil {
+				logger.FromContext(ctx).Warn("Generate video tags failed", zap.Int64("video_id", video.ID), zap.Error(err))
+			}
+		}
+		if cfg.AutoSummary {
+			if err := s.GenerateSummary(ctx, req); err != nil {
+				logger.FromContext(ctx).Warn("Generate video summary failed", zap.Int64("video_id", video.ID), zap.Error(err))
+			}
+		}
+	}()
+}
+
+func videoAgentRequest(video *model.Video, frames []infraKafka.VideoFrame) *infraAgent.VideoRequest {
+	req := &infraAgent.VideoRequest{
+		VideoID:     video.ID,
+		Title:       video.Title,
+		Description: video.Description,
+		Duration:    video.Duration,
+		Frames:      make([]infraAgent.Frame, 0, len(frames)),
+	}
+	for _, f := range frames {
+		req.Frames = append(req.Frames, infraAgent.Frame{URL: f.URL, Time: f.Offset})
+	}
+	return req
+}
+
+// GenerateTags 将标题、描述和抽帧发送给 Agent 服务，结果保存为 AI 标签（替换之前的 AI 标签）
+func (s *VideoAIService) GenerateTags(ctx context.Context, req *infraAgent.VideoRequest) error {
+	suggestion, err := infraAgent.SuggestTags(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	tags := buildVideoTags(req.VideoID, model.VideoTagKindTag, model.VideoTagSourceMachine, suggestion.Tags, machineTagLimit)
+	tags = append(tags, buildVideoTags(req.VideoID, model.VideoTagKindCategory, model.VideoTagSourceMachine, suggestion.Categories, machineCategoryLimit)...)
+	if err := s.videoTagRepo.ReplaceMachineTags(ctx, req.VideoID, tags); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Video tags generated", zap.Int64("video_id", req.VideoID), zap.Int("count", len(tags)))
+	return nil
+}
+
+// GenerateSummary 生成视频摘要与关键时刻并保存到视频上，超出时长或说明为空的关键时刻会被丢弃
+func (s *VideoAIService) GenerateSummary(ctx context.Context, req *infraAgent.VideoRequest) error {
+	result, err := infraAgent.Summarize(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	summary := cutRunes(strings.TrimSpace(result.Summary), summaryMaxLength)
+	moments := make([]model.KeyMoment, 0, len(result.KeyMoments))
+	seen := make(map[int]bool, len(result.KeyMoments))
+	for _, m := range result.KeyMoments {
+		label := cutRunes(strings.TrimSpace(m.Label), keyMomentLabelLength)
+		if label == "" || m.Time < 0 || m.Time > req.Duration || seen[m.Time] {
+			continue
+		}
+		seen[m.Time] = true
+		moments = append(moments, model.KeyMoment{Time: m.Time, Label: label})
+	}
+	sort.Slice(moments, func(i, j int) bool { return moments[i].Time < moments[j].Time })
+	if len(moments) > keyMomentLimit {
+		moments = moments[:keyMomentLimit]
+	}
+
+	if err := s.videoRepo.UpdateSummary(ctx, req.VideoID, summary, moments); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Video summary generated", zap.Int64("video_id", req.VideoID), zap.Int("key_moments", len(moments)))
+	return nil
+}
+
+// PrepareAsk 校验视频与提问配额并组装问答上下文，返回今日剩余提问次数
+func (s *VideoAIService) PrepareAsk(ctx context.Context, videoID, userID int64, question string) (*infraAgent.AskRequest, int, error) {
+	if !infraAgent.Enabled() {
+		return nil, 0, ErrAgentUnavailable
+	}
+	video, err := s.getVisibleVideo(ctx, videoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if video.Status != "published" {
+		return nil, 0, ErrVideoNotFound
+	}
+	// 摘要与关键时刻会发送给 Agent 并随回答返回，观看者须能看到视频的完整内容
+	if err := authorizeViewer(ctx, s.userRepo, s.accessRepo, s.membershipRepo, video, userID); err != nil {
+		return nil, 0, err
+	}
+
+	remaining, err := s.consumeAskQuota(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tags, err := s.videoTagRepo.ListByVideo(ctx, videoID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req := &infraAgent.AskRequest{
+		VideoID:     video.ID,
+		UserID:      userID,
+		Question:    strings.TrimSpace(question),
+		Title:       video.Title,
+		Description: video.Description,
+		Duration:    video.Duration,
+		Summary:     video.Summary,
+		KeyMoments:  make([]infraAgent.KeyMoment, 0, len(video.KeyMoments)),
+		Tags:        make([]string, 0, len(tags)),
+	}
+	for _, m := range video.KeyMoments {
+		req.KeyMoments = append(req.KeyMoments, infraAgent.KeyMoment{Time: m.Time, Label: m.Label})
+	}
+	for _, t := range tags {
+		req.Tags = append(req.Tags, t.Name)
+	}
+	return req, remaining, nil
+}
+
+// Ask 将问题转发给 Agent 服务，流式回调回复片段
+func (s *VideoAIService) Ask(ctx context.Context, req *infraAgent.AskRequest, onChunk func(content string) error) error {
+	ctx, cancel := context.WithTimeout(ctx, askTimeout)
+	defer cancel()
+	return infraAgent.AskStream(ctx, req, onChunk)
+}
+
+// consumeAskQuota 按分钟限流、按天（UTC）计配额，被限流的请求不占用当天配额
+// Redis 不可用时放行，避免问答功能整体不可用
+func (s *VideoAIService) consumeAskQuota(ctx context.Context, userID int64) (int, error) {
+	cfg := config.GetAgent()
+	now := time.Now().UTC()
+
+	minuteKey := fmt.Sprintf("agent:ask:minute:%d:%d", userID, now.Unix()/60)
+	count, err := s.incrWithTTL(ctx, minuteKey, time.Minute)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Check ask rate limit failed", zap.Int64("user_id", userID), zap.Error(err))
+		return cfg.AskDailyLimit(), nil
+	}
+	if count > int64(cfg.AskMinuteLimit()) {
+		return 0, ErrAskRateLimited
+	}
+
+	dayKey := fmt.Sprintf("agent:ask:day:%d:%s", userID, now.Format(time.DateOnly))
+	count, err = s.incrWithTTL(ctx, dayKey, 25*time.Hour)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Check ask quota failed", zap.Int64("user_id", userID), zap.Error(err))
+		return cfg.AskDailyLimit(), nil
+	}
+	if count > int64(cfg.AskDailyLimit()) {
+		return 0, ErrAskQuotaExceeded
+	}
+	return cfg.AskDailyLimit() - int(count), nil
+}
+
+func (s *VideoAIService) incrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	pipe := s.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// GetTags 获取视频标签与分类
+func (s *VideoAIService) GetTags(ctx context.Context, videoID int64) (*dto.VideoTagsData, error) {
+	if _, err := s.getVisibleVideo(ctx, videoID); err != nil {
+		return nil, err
+	}
+	return s.tagsData(ctx, videoID)
+}
+
+// UpdateTags 作者编辑标签与分类：保留下来的 AI 标签仍标记为 machine，新增的标记为 author
+func (s *VideoAIService) UpdateTags(ctx context.Context, videoID, userID int64, req *dto.VideoTagsUpdateRequest) (*dto.VideoTagsData, error) {
+	video, err := s.getVisibleVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.AuthorID != userID {
+		return nil, ErrVideoNoPermission
+	}
+
+	existing, err := s.videoTagRepo.ListByVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[string]string, len(existing))
+	for _, t := range existing {
+		sources[t.Kind+":"+t.Name] = t.Source
+	}
+
+	tags := buildVideoTags(videoID, model.VideoTagKindTag, model.VideoTagSourceAuthor, req.Tags, len(req.Tags))
+	tags = append(tags, buildVideoTags(videoID, model.VideoTagKindCategory, model.VideoTagSourceAuthor, req.Categories, len(req.Categories))...)
+	for i := range tags {
+		if source, ok := sources[tags[i].Kind+":"+tags[i].Name]; ok {
+			tags[i].Source = source
+		}
+	}
+
+	if err := s.videoTagRepo.ReplaceAll(ctx, videoID, tags); err != nil {
+		return nil, err
+	}
+	return s.tagsData(ctx, videoID)
+}
+
+func (s *VideoAIService) getVisibleVideo(ctx context.Context, videoID int64) (*model.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.Status == VideoStatusHidden {
+		return nil, ErrVideoNotFound
+	}
+	return video, nil
+}
+
+func (s *VideoAIService) tagsData(ctx context.Context, videoID int64) (*dto.VideoTagsData, error) {
+	tags, err := s.videoTagRepo.ListByVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dto.VideoTagsData{
+		VideoID:    videoID,
+		Tags:       []dto.VideoTagInfo{},
+		Categories: []dto.VideoTagInfo{},
+	}
+	for _, t := range tags {
+		info := dto.VideoTagInfo{Name: t.Name, Source: t.Source}
+		if t.Kind == model.VideoTagKindCategory {
+			data.Categories = append(data.Categories, info)
+		} else {
+			data.Tags = append(data.Tags, info)
+		}
+	}
+	return data, nil
+}
+
+// buildVideoTags 规范化标签名（去空白和 # 前缀、转小写、去重、截断长度），最多保留 limit 个
+func buildVideoTags(videoID int64, kind, source string, names []string, limit int) []model.VideoTag {
+	seen := make(map[string]bool, len(names))
+	tags := make([]model.VideoTag, 0, min(len(names), limit))
+	for _, name := range names {
+		if len(tags) >= limit {
+			break
+		}
+		name = cutRunes(strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(name), "#"))), videoTagMaxLength)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, model.VideoTag{VideoID: videoID, Kind: kind, Name: name, Source: source})
+	}
+	return tags
+}
+
+// cutRunes 按字符截断（不加省略号）
+func cutRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
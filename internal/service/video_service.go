@@ -5,14 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
+	"sort"
+	"strings"
 	"time"
 
 	"vida-go/internal/api/dto"
 	"vida-go/internal/config"
+	"vida-go/internal/eventbus"
+	infraES "vida-go/internal/infra/elasticsearch"
+	"vida-go/internal/infra/geoip"
 	infraKafka "vida-go/internal/infra/kafka"
 	infraMinio "vida-go/internal/infra/minio"
 	"vida-go/internal/model"
 	"vida-go/internal/repository"
+	"vida-go/pkg/cursor"
 	"vida-go/pkg/logger"
 
 	"go.uber.org/zap"
@@ -23,20 +30,94 @@ var (
 	ErrVideoNotFound     = errors.New("视频不存在")
 	ErrVideoNoPermission = errors.New("没有权限操作该视频")
 	ErrNoFieldsToUpdate  = errors.New("没有需要更新的字段")
+	ErrVideoHidden       = errors.New("视频已被隐藏，无法修改状态")
+	ErrUnsupportedFormat = errors.New("不支持的文件格式")
+	ErrInvalidFileSize   = errors.New("文件大小无效")
+	ErrDailyUploadLimit  = errors.New("今日上传数量已达上限")
+	ErrVideoNotPublished = errors.New("只能置顶已发布的视频")
+
+	ErrVideoRegionRestricted = errors.New("该视频在您所在的国家或地区不可观看")
+
+	ErrSponsorDisclosureRequired = errors.New("付费推广视频必须填写推广声明")
 )
 
+// VideoStatusHidden 被审核隐藏的视频状态，不出现在视频流、搜索和详情中
+const VideoStatusHidden = "hidden"
+
+// VideoStatusScheduled 已转码、等待首映的视频状态，首映开始时由首映任务发布
+const VideoStatusScheduled = "scheduled"
+
 const rawVideoBucket = "raw-videos"
 
 type VideoService struct {
-	videoRepo *repository.VideoRepository
+	videoRepo    *repository.VideoRepository
+	userRepo     *repository.UserRepository
+	favoriteRepo *repository.FavoriteRepository
+	relationRepo *repository.RelationRepository
+	statRepo     *repository.VideoStatRepository
+	settingRepo  *repository.UserSettingRepository
+	accessRepo   *repository.VideoAccessRepository
+	pollRepo     *repository.PollRepository
+	memberRepo   *repository.MembershipRepository
+	eventService *EventService
+	emailService *EmailService
+	aiService    *VideoAIService
+	dupService   *DuplicateService
+	coldStorage  *ColdStorageService
+	eventBus     *eventbus.Bus
 }
 
-func NewVideoService(videoRepo *repository.VideoRepository) *VideoService {
-	return &VideoService{videoRepo: videoRepo}
+func NewVideoService(
+	videoRepo *repository.VideoRepository,
+	userRepo *repository.UserRepository,
+	favoriteRepo *repository.FavoriteRepository,
+	relationRepo *repository.RelationRepository,
+	statRepo *repository.VideoStatRepository,
+	settingRepo *repository.UserSettingRepository,
+	accessRepo *repository.VideoAccessRepository,
+	pollRepo *repository.PollRepository,
+	memberRepo *repository.MembershipRepository,
+	eventService *EventService,
+	emailService *EmailService,
+	aiService *VideoAIService,
+	dupService *DuplicateService,
+	coldStorage *ColdStorageService,
+	eventBus *eventbus.Bus,
+) *VideoService {
+	return &VideoService{
+		videoRepo:    videoRepo,
+		userRepo:     userRepo,
+		favoriteRepo: favoriteRepo,
+		relationRepo: relationRepo,
+		statRepo:     statRepo,
+		settingRepo:  settingRepo,
+		accessRepo:   accessRepo,
+		pollRepo:     pollRepo,
+		memberRepo:   memberRepo,
+		eventService: eventService,
+		emailService: emailService,
+		aiService:    aiService,
+		dupService:   dupService,
+		coldStorage:  coldStorage,
+		eventBus:     eventBus,
+	}
 }
 
 // Upload 上传视频：MinIO 存储 + Kafka 转码任务
-func (s *VideoService) Upload(authorID int64, req *dto.VideoUploadRequest, fileReader io.Reader, fileSize int64, fileFormat string) (*dto.VideoInfo, error) {
+func (s *VideoService) Upload(ctx context.Context, authorID int64, req *dto.VideoUploadRequest, fileReader io.Reader, fileSize int64, fileFormat string) (*dto.VideoInfo, error) {
+	if err := ensureNotMuted(ctx, s.userRepo, authorID); err != nil {
+		return nil, err
+	}
+	disclosure, err := sponsorDisclosure(req.IsSponsored, req.SponsorDisclosure)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := s.checkUploadLimit(ctx, authorID, fileSize, fileFormat)
+	if err != nil {
+		return nil, err
+	}
+	fileFormat = strings.ToLower(fileFormat)
+
 	video := &model.Video{
 		AuthorID:    authorID,
 		Title:       req.Title,
@@ -44,50 +125,155 @@ func (s *VideoService) Upload(authorID int64, req *dto.VideoUploadRequest, fileR
 		Status:      "pending",
 		FileSize:    fileSize,
 		FileFormat:  fileFormat,
+
+		IsSponsored:       req.IsSponsored,
+		SponsorDisclosure: disclosure,
+	}
+	if req.RemixOfID != 0 {
+		source, err := s.resolveRemixSource(ctx, authorID, req.RemixOfID)
+		if err != nil {
+			return nil, err
+		}
+		video.RemixOfID = &source.ID
+		video.RemixType = req.RemixType
+		if video.RemixType == "" {
+			video.RemixType = model.RemixTypeRemix
+		}
 	}
 
-	if err := s.videoRepo.Create(video); err != nil {
+	if err := s.videoRepo.Create(ctx, video); err != nil {
 		return nil, err
 	}
 
-	objectName := fmt.Sprintf("%d/%d.%s", authorID, video.ID, fileFormat)
+	objectName := rawObjectName(authorID, video.ID, fileFormat)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
 	contentType := "video/" + fileFormat
 	if _, err := infraMinio.UploadFile(ctx, rawVideoBucket, objectName, fileReader, fileSize, contentType); err != nil {
-		logger.Error("Upload to MinIO failed, rolling back video record",
+		logger.FromContext(ctx).Error("Upload to MinIO failed, rolling back video record",
 			zap.Int64("video_id", video.ID), zap.Error(err))
-		_ = s.videoRepo.SoftDelete(video.ID)
+		_ = s.videoRepo.SoftDelete(ctx, video.ID)
 		return nil, fmt.Errorf("上传文件失败: %w", err)
 	}
 
+	if err := s.enqueueTranscode(ctx, video, objectName, limit.MaxDuration); err != nil {
+		return nil, err
+	}
+	return toVideoInfo(video, false), nil
+}
+
+// CreateFromRecording 将直播录像（raw-videos 中的对象）创建为视频并投递转码，转码完成后按普通视频发布
+func (s *VideoService) CreateFromRecording(ctx context.Context, authorID int64, title, recordingObject string) (*model.Video, error) {
+	fileFormat := strings.TrimPrefix(strings.ToLower(path.Ext(recordingObject)), ".")
+	if fileFormat == "" {
+		fileFormat = "flv"
+	}
+
+	video := &model.Video{
+		AuthorID:   authorID,
+		Title:      title,
+		Status:     "pending",
+		FileFormat: fileFormat,
+	}
+	if err := s.videoRepo.Create(ctx, video); err != nil {
+		return nil, err
+	}
+
+	// 复制到与上传视频相同的位置，补齐附加清晰度时从这里读取原始文件
+	objectName := rawObjectName(authorID, video.ID, fileFormat)
+	size, err := infraMinio.CopyObject(ctx, rawVideoBucket, recordingObject, rawVideoBucket, objectName)
+	if err != nil {
+		_ = s.videoRepo.SoftDelete(ctx, video.ID)
+		return nil, fmt.Errorf("复制直播录像失败: %w", err)
+	}
+	video.FileSize = size
+	_, _ = s.videoRepo.Update(ctx, video.ID, map[string]interface{}{"file_size": size})
+
+	if err := s.enqueueTranscode(ctx, video, objectName, 0); err != nil {
+		return nil, err
+	}
+	return video, nil
+}
+
+// enqueueTranscode 投递转码任务并将视频置为 transcoding，投递失败时置为 upload_failed
+func (s *VideoService) enqueueTranscode(ctx context.Context, video *model.Video, objectName string, maxDuration int) error {
 	cfg := config.GetKafka()
 	transcodeTopic := cfg.Topics["video_transcode"]
 
 	task := &infraKafka.TranscodeTask{
-		VideoID:    video.ID,
-		ObjectName: objectName,
-		Bucket:     rawVideoBucket,
-		FileFormat: fileFormat,
-		FileSize:   fileSize,
+		VideoID:     video.ID,
+		ObjectName:  objectName,
+		Bucket:      rawVideoBucket,
+		FileFormat:  video.FileFormat,
+		FileSize:    video.FileSize,
+		MaxDuration: maxDuration,
 	}
 
 	if err := infraKafka.SendTranscodeTask(ctx, transcodeTopic, task); err != nil {
-		logger.Error("Send transcode task failed", zap.Int64("video_id", video.ID), zap.Error(err))
-		_, _ = s.videoRepo.Update(video.ID, map[string]interface{}{"status": "upload_failed"})
-		return nil, fmt.Errorf("提交转码任务失败: %w", err)
+		logger.FromContext(ctx).Error("Send transcode task failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		_, _ = s.videoRepo.Update(ctx, video.ID, map[string]interface{}{"status": "upload_failed"})
+		return fmt.Errorf("提交转码任务失败: %w", err)
 	}
 
-	_, _ = s.videoRepo.Update(video.ID, map[string]interface{}{"status": "transcoding"})
+	_, _ = s.videoRepo.Update(ctx, video.ID, map[string]interface{}{"status": "transcoding"})
 	video.Status = "transcoding"
 
-	return toVideoInfo(video, false), nil
+	s.eventService.Publish(ctx, video.AuthorID, EventTypeUploadStatus, &dto.UploadStatusEvent{
+		VideoID: video.ID,
+		Status:  video.Status,
+	})
+	return nil
+}
+
+// rawObjectName 原始视频在 raw-videos 中的对象名，转码及补齐附加清晰度时都从原始文件读取
+func rawObjectName(authorID, videoID int64, fileFormat string) string {
+	return fmt.Sprintf("%d/%d.%s", authorID, videoID, fileFormat)
+}
+
+// UploadLimitFor 返回用户按角色适用的上传限制，上传接口在读取请求体前据此拒绝超出大小的文件
+func (s *VideoService) UploadLimitFor(ctx context.Context, userID int64) (config.UploadLimit, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return config.UploadLimit{}, ErrUserNotFound
+		}
+		return config.UploadLimit{}, err
+	}
+	return config.GetUpload().LimitFor(user.UserRole, user.IsVerified), nil
+}
+
+// checkUploadLimit 按上传者的角色校验文件格式、大小和当天上传数量
+func (s *VideoService) checkUploadLimit(ctx context.Context, authorID int64, fileSize int64, fileFormat string) (config.UploadLimit, error) {
+	limit, err := s.UploadLimitFor(ctx, authorID)
+	if err != nil {
+		return limit, err
+	}
+
+	if !limit.AllowsFormat(fileFormat) {
+		return limit, fmt.Errorf("%w，支持: %s", ErrUnsupportedFormat, strings.Join(limit.Formats, ", "))
+	}
+	if fileSize <= 0 || fileSize > limit.MaxSize() {
+		return limit, fmt.Errorf("%w（不能为空，最大 %dMB）", ErrInvalidFileSize, limit.MaxSizeMB)
+	}
+
+	if limit.DailyCount > 0 {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		count, err := s.videoRepo.CountByAuthorSince(ctx, authorID, today)
+		if err != nil {
+			return limit, err
+		}
+		if count >= int64(limit.DailyCount) {
+			return limit, fmt.Errorf("%w（每天最多 %d 个）", ErrDailyUploadLimit, limit.DailyCount)
+		}
+	}
+	return limit, nil
 }
 
 // HandleTranscodeResult 处理 Kafka 消费者收到的转码结果
-func (s *VideoService) HandleTranscodeResult(result *infraKafka.TranscodeResult) error {
+func (s *VideoService) HandleTranscodeResult(ctx context.Context, result *infraKafka.TranscodeResult) error {
 	updates := map[string]interface{}{
 		"status": result.Status,
 	}
@@ -98,16 +284,46 @@ func (s *VideoService) HandleTranscodeResult(result *infraKafka.TranscodeResult)
 		updates["duration"] = result.Duration
 		updates["width"] = result.Width
 		updates["height"] = result.Height
-		now := time.Now().Unix()
-		updates["publish_time"] = now
+		updates["video_codec"] = result.VideoCodec
+		updates["audio_codec"] = result.AudioCodec
+		updates["bitrate"] = result.Bitrate
+		updates["frame_rate"] = result.FrameRate
+		updates["audio_channels"] = result.AudioChannels
+		updates["publish_time"] = time.Now()
+
+		// 预约了首映的视频暂不发布，由首映任务在首映时间发布
+		if existing, err := s.videoRepo.GetByID(ctx, result.VideoID); err == nil &&
+			existing.PremiereAt != nil && time.Now().Before(*existing.PremiereAt) {
+			updates["status"] = VideoStatusScheduled
+			delete(updates, "publish_time")
+		}
 	}
 
-	_, err := s.videoRepo.Update(result.VideoID, updates)
+	video, err := s.videoRepo.Update(ctx, result.VideoID, updates)
 	if err != nil {
 		return fmt.Errorf("update video %d after transcode failed: %w", result.VideoID, err)
 	}
 
-	logger.Info("Video transcode result processed",
+	s.eventService.Publish(ctx, video.AuthorID, EventTypeUploadStatus, &dto.UploadStatusEvent{
+		VideoID:  video.ID,
+		Status:   video.Status,
+		PlayURL:  result.PlayURL,
+		CoverURL: result.CoverURL,
+		Error:    result.Error,
+	})
+
+	if result.Status == "published" {
+		if video.Status == "published" {
+			s.eventBus.Publish(ctx, &eventbus.VideoPublished{VideoID: video.ID, AuthorID: video.AuthorID, PublishedAt: time.Now()})
+			s.emailService.Notify(ctx, video.AuthorID, EmailKindVideoPublished, "video_published", map[string]interface{}{
+				"Title": video.Title,
+			})
+		}
+		s.aiService.OnPublished(ctx, video, result.Frames)
+		s.dupService.Check(ctx, video, result.Frames)
+	}
+
+	logger.FromContext(ctx).Info("Video transcode result processed",
 		zap.Int64("video_id", result.VideoID),
 		zap.String("status", result.Status),
 	)
@@ -115,32 +331,136 @@ func (s *VideoService) HandleTranscodeResult(result *infraKafka.TranscodeResult)
 	return nil
 }
 
-// GetDetail 获取视频详情（自动增加观看次数）
-func (s *VideoService) GetDetail(videoID int64) (*dto.VideoInfo, error) {
-	video, err := s.videoRepo.GetByIDWithAuthor(videoID)
+// GetDetail 获取视频详情（自动增加观看次数），视频在请求来源地区不可见时返回 ErrVideoRegionRestricted（作者本人不受限制）；
+// 私密视频对作者及被授权用户以外的人返回 ErrVideoNotFound
+func (s *VideoService) GetDetail(ctx context.Context, videoID, viewerID int64) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrVideoNotFound
 		}
 		return nil, err
 	}
+	if video.Status == VideoStatusHidden || video.LegalHoldAt != nil {
+		return nil, ErrVideoNotFound
+	}
+	if ok, err := canViewVideo(ctx, s.accessRepo, video, viewerID); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrVideoNotFound
+	}
+	if video.AuthorID != viewerID && !video.AvailableIn(geoip.CountryFromContext(ctx)) {
+		return nil, ErrVideoRegionRestricted
+	}
 
 	if video.Status == "published" {
-		_ = s.videoRepo.IncrementViewCount(videoID)
+		_ = s.videoRepo.IncrementViewCount(ctx, videoID)
+		_ = s.statRepo.IncrementViews(ctx, videoID)
 		video.ViewCount++
+		// 打开详情即开始恢复冷存储中的文件，缩短随后播放的等待
+		s.coldStorage.RequestRehydrate(ctx, video)
 	}
 
+	infos := []dto.VideoInfo{*toVideoInfo(video, true)}
+	if video.Status == VideoStatusScheduled && video.AuthorID != viewerID {
+		// 首映前不提前放出播放地址，观众通过首映倒计时页等待
+		infos[0].PlayURL = ""
+		infos[0].PlaybackState = model.PlaybackStatePremiere
+	}
+	if err := s.ApplyContentGate(ctx, viewerID, infos); err != nil {
+		return nil, err
+	}
+	if err := s.fillRemixes(ctx, viewerID, &infos[0]); err != nil {
+		return nil, err
+	}
+	if err := s.fillPoll(ctx, viewerID, &infos[0]); err != nil {
+		return nil, err
+	}
+	return &infos[0], nil
+}
+
+// GetInfo 获取视频元数据（不增加观看次数，供内部服务调用），私密视频视为不存在
+func (s *VideoService) GetInfo(ctx context.Context, videoID int64) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.Status == VideoStatusHidden || video.LegalHoldAt != nil || video.IsPrivate() {
+		return nil, ErrVideoNotFound
+	}
+	return toVideoInfo(video, true), nil
+}
+
+// BatchGetInfo 批量获取视频元数据，按 videoIDs 顺序返回，跳过不存在、已隐藏、保全中或私密的视频
+func (s *VideoService) BatchGetInfo(ctx context.Context, videoIDs []int64) ([]dto.VideoInfo, error) {
+	videos, err := s.videoRepo.GetByIDsWithAuthor(ctx, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dto.VideoInfo, 0, len(videos))
+	for i := range videos {
+		if videos[i].Status == VideoStatusHidden || videos[i].LegalHoldAt != nil || videos[i].IsPrivate() {
+			continue
+		}
+		items = append(items, *toVideoInfo(&videos[i], true))
+	}
+	return items, nil
+}
+
+// GetUserPublishedVideos 获取指定用户已发布的视频列表，置顶视频在最前
+func (s *VideoService) GetUserPublishedVideos(ctx context.Context, userID int64, page, pageSize int) (*dto.VideoListData, error) {
+	skip := (page - 1) * pageSize
+	videos, total, err := s.videoRepo.ListPublishedByAuthor(ctx, userID, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return buildVideoListData(videos, total, page, pageSize, true), nil
+}
+
+// GetUserLatestVideos 获取指定用户最新发布的视频列表（按创建时间倒序，不区分置顶）
+func (s *VideoService) GetUserLatestVideos(ctx context.Context, userID int64, page, pageSize int) (*dto.VideoListData, error) {
+	skip := (page - 1) * pageSize
+	status := "published"
+	videos, total, err := s.videoRepo.ListVideos(ctx, skip, pageSize, &userID, &status, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return buildVideoListData(videos, total, page, pageSize, true), nil
+}
+
+// GetPinnedVideo 获取用户置顶的视频，未置顶时返回 nil
+func (s *VideoService) GetPinnedVideo(ctx context.Context, userID int64) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.GetPinnedByAuthor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
 	return toVideoInfo(video, true), nil
 }
 
 // Update 更新视频信息（仅作者本人）
-func (s *VideoService) Update(videoID, currentUserID int64, req *dto.VideoUpdateRequest) (*dto.VideoInfo, error) {
-	if _, err := s.videoRepo.GetByIDAndAuthor(videoID, currentUserID); err != nil {
+func (s *VideoService) Update(ctx context.Context, videoID, currentUserID int64, req *dto.VideoUpdateRequest) (*dto.VideoInfo, error) {
+	existing, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, currentUserID)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrVideoNoPermission
 		}
 		return nil, err
 	}
+	if err := checkVideoLegalHold(ctx, s.userRepo, existing); err != nil {
+		return nil, err
+	}
+	if existing.Status == VideoStatusHidden && req.Status != nil {
+		return nil, ErrVideoHidden
+	}
+	if existing.Status == VideoStatusScheduled && req.Status != nil {
+		return nil, ErrPremiereScheduled
+	}
 
 	updates := make(map[string]interface{})
 	if req.Title != nil {
@@ -152,12 +472,55 @@ func (s *VideoService) Update(videoID, currentUserID int64, req *dto.VideoUpdate
 	if req.Status != nil {
 		updates["status"] = *req.Status
 	}
+	if req.Visibility != nil {
+		updates["visibility"] = *req.Visibility
+	}
+	if req.AgeRestricted != nil {
+		switch {
+		case *req.AgeRestricted && !existing.AgeRestricted():
+			updates["age_restriction"] = model.AgeRestrictionAuthor
+		case !*req.AgeRestricted && existing.AgeRestriction == model.AgeRestrictionModerator:
+			return nil, ErrAgeRestrictionLocked
+		case !*req.AgeRestricted:
+			updates["age_restriction"] = ""
+		}
+	}
+	if req.MembersOnlyTierID != nil {
+		if *req.MembersOnlyTierID == 0 {
+			updates["members_only_tier_id"] = nil
+		} else {
+			tier, err := s.memberRepo.GetTier(ctx, *req.MembersOnlyTierID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			// 只能设为自己上架中的会员等级
+			if tier == nil || tier.CreatorID != currentUserID || tier.ArchivedAt != nil {
+				return nil, ErrMembershipTierNotFound
+			}
+			updates["members_only_tier_id"] = tier.ID
+		}
+	}
+	if req.IsSponsored != nil || req.SponsorDisclosure != nil {
+		sponsored, disclosure := existing.IsSponsored, existing.SponsorDisclosure
+		if req.IsSponsored != nil {
+			sponsored = *req.IsSponsored
+		}
+		if req.SponsorDisclosure != nil {
+			disclosure = *req.SponsorDisclosure
+		}
+		disclosure, err := sponsorDisclosure(sponsored, disclosure)
+		if err != nil {
+			return nil, err
+		}
+		updates["is_sponsored"] = sponsored
+		updates["sponsor_disclosure"] = disclosure
+	}
 
 	if len(updates) == 0 {
 		return nil, ErrNoFieldsToUpdate
 	}
 
-	video, err := s.videoRepo.Update(videoID, updates)
+	video, err := s.videoRepo.Update(ctx, videoID, updates)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrVideoNotFound
@@ -165,19 +528,158 @@ func (s *VideoService) Update(videoID, currentUserID int64, req *dto.VideoUpdate
 		return nil, err
 	}
 
+	// 私密视频不出现在搜索中，可见范围或付费推广标记变化时同步索引
+	if video.Visibility != existing.Visibility || video.IsSponsored != existing.IsSponsored {
+		s.syncVisibilityToES(ctx, video)
+	}
+
 	return toVideoInfo(video, false), nil
 }
 
-// Delete 软删除视频（仅作者本人）
-func (s *VideoService) Delete(videoID, currentUserID int64) error {
-	if _, err := s.videoRepo.GetByIDAndAuthor(videoID, currentUserID); err != nil {
+// sponsorDisclosure 校验付费推广声明：推广视频必须填写，非推广视频不保留声明
+func sponsorDisclosure(sponsored bool, disclosure string) (string, error) {
+	if !sponsored {
+		return "", nil
+	}
+	disclosure = strings.TrimSpace(disclosure)
+	if disclosure == "" {
+		return "", ErrSponsorDisclosureRequired
+	}
+	return disclosure, nil
+}
+
+// syncVisibilityToES 私密视频从搜索索引中移除，改为公开的已发布视频重新写入
+func (s *VideoService) syncVisibilityToES(ctx context.Context, video *model.Video) {
+	if video.IsPrivate() {
+		if err := infraES.DeleteVideo(ctx, video.ID); err != nil {
+			logger.FromContext(ctx).Warn("Remove private video from ES failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		}
+		return
+	}
+	if video.Status != "published" || video.LegalHoldAt != nil {
+		return
+	}
+	if withAuthor, err := s.videoRepo.GetByIDWithAuthor(ctx, video.ID); err == nil {
+		if err := infraES.SyncVideo(ctx, withAuthor, withAuthor.Author.UserName); err != nil {
+			logger.FromContext(ctx).Warn("Sync public video to ES failed", zap.Int64("video_id", video.ID), zap.Error(err))
+		}
+	}
+}
+
+// Pin 作者将已发布的视频置顶到主页，替换之前置顶的视频
+func (s *VideoService) Pin(ctx context.Context, videoID, currentUserID int64) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, currentUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNoPermission
+		}
+		return nil, err
+	}
+	if err := checkVideoLegalHold(ctx, s.userRepo, video); err != nil {
+		return nil, err
+	}
+	if video.Status != "published" {
+		return nil, ErrVideoNotPublished
+	}
+
+	now := time.Now()
+	if err := s.videoRepo.Pin(ctx, currentUserID, videoID, now); err != nil {
+		return nil, err
+	}
+	video.PinnedAt = &now
+	return toVideoInfo(video, false), nil
+}
+
+// Unpin 作者取消视频置顶
+func (s *VideoService) Unpin(ctx context.Context, videoID, currentUserID int64) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, currentUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNoPermission
+		}
+		return nil, err
+	}
+	if err := s.videoRepo.Unpin(ctx, videoID); err != nil {
+		return nil, err
+	}
+	video.PinnedAt = nil
+	return toVideoInfo(video, false), nil
+}
+
+// UpdateRegions 作者设置视频的地区限制
+func (s *VideoService) UpdateRegions(ctx context.Context, videoID, currentUserID int64, req *dto.VideoRegionsRequest) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, currentUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNoPermission
+		}
+		return nil, err
+	}
+	if err := checkVideoLegalHold(ctx, s.userRepo, video); err != nil {
+		return nil, err
+	}
+	return s.setRegions(ctx, videoID, req)
+}
+
+// SetRegions 管理员设置视频的地区限制（如版权、合规要求）
+func (s *VideoService) SetRegions(ctx context.Context, videoID int64, req *dto.VideoRegionsRequest) (*dto.VideoInfo, error) {
+	return s.setRegions(ctx, videoID, req)
+}
+
+func (s *VideoService) setRegions(ctx context.Context, videoID int64, req *dto.VideoRegionsRequest) (*dto.VideoInfo, error) {
+	video, err := s.videoRepo.Update(ctx, videoID, map[string]interface{}{
+		"allowed_regions": joinRegions(req.AllowedRegions),
+		"blocked_regions": joinRegions(req.BlockedRegions),
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+
+	// 搜索按索引中的地区列表过滤，需要重新同步
+	if video.Status == "published" && !video.IsPrivate() {
+		if withAuthor, err := s.videoRepo.GetByIDWithAuthor(ctx, videoID); err == nil {
+			if err := infraES.SyncVideo(ctx, withAuthor, withAuthor.Author.UserName); err != nil {
+				logger.FromContext(ctx).Warn("Sync video regions to ES failed", zap.Int64("video_id", videoID), zap.Error(err))
+			}
+		}
+	}
+
+	return toVideoInfo(video, false), nil
+}
+
+// joinRegions 规范化（大写、去重、排序）后以逗号拼接
+func joinRegions(regions []string) string {
+	seen := make(map[string]bool, len(regions))
+	codes := make([]string, 0, len(regions))
+	for _, r := range regions {
+		code, ok := geoip.NormalizeCountry(r)
+		if !ok || seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return strings.Join(codes, ",")
+}
+
+// Delete 软删除视频（仅作者本人），视频或作者处于法律保全中时不可删除
+func (s *VideoService) Delete(ctx context.Context, videoID, currentUserID int64) error {
+	video, err := s.videoRepo.GetByIDAndAuthor(ctx, videoID, currentUserID)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrVideoNoPermission
 		}
 		return err
 	}
+	if err := checkVideoLegalHold(ctx, s.userRepo, video); err != nil {
+		return err
+	}
 
-	if err := s.videoRepo.SoftDelete(videoID); err != nil {
+	if err := s.videoRepo.SoftDelete(ctx, videoID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrVideoNotFound
 		}
@@ -186,27 +688,94 @@ func (s *VideoService) Delete(videoID, currentUserID int64) error {
 	return nil
 }
 
-// GetFeed 获取视频流（已发布，含作者信息，不需要登录）
-func (s *VideoService) GetFeed(page, pageSize int) (*dto.VideoListData, error) {
+// GetFeed 获取视频流（已发布，含作者信息，不需要登录），不包含在请求来源地区不可见的视频
+func (s *VideoService) GetFeed(ctx context.Context, page, pageSize int) (*dto.VideoListData, error) {
 	skip := (page - 1) * pageSize
-	status := "published"
-	videos, total, err := s.videoRepo.ListVideos(skip, pageSize, nil, &status, nil, true)
+	videos, total, err := s.videoRepo.ListPublished(ctx, geoip.CountryFromContext(ctx), skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 	return buildVideoListData(videos, total, page, pageSize, true), nil
 }
 
+// FillViewerState 批量填充当前用户对视频的点赞状态与对作者的关注状态
+func (s *VideoService) FillViewerState(ctx context.Context, viewerID int64, videos []dto.VideoInfo) error {
+	if len(videos) == 0 {
+		return nil
+	}
+
+	videoIDs := make([]int64, len(videos))
+	authorIDs := make([]int64, 0, len(videos))
+	for i := range videos {
+		videoIDs[i] = videos[i].ID
+		if videos[i].AuthorID != viewerID {
+			authorIDs = append(authorIDs, videos[i].AuthorID)
+		}
+	}
+
+	reactions, err := s.favoriteRepo.BatchGetReactions(ctx, viewerID, videoIDs)
+	if err != nil {
+		return err
+	}
+	following, err := s.relationRepo.BatchCheckFollowing(ctx, viewerID, uniqueIDs(authorIDs))
+	if err != nil {
+		return err
+	}
+
+	for i := range videos {
+		reaction := reactions[videos[i].ID]
+		isFavorited := reaction != ""
+		isFollowing := following[videos[i].AuthorID]
+		videos[i].IsFavorited = &isFavorited
+		videos[i].IsFollowing = &isFollowing
+		videos[i].Reaction = reaction
+	}
+	return nil
+}
+
 // GetMyVideos 获取当前用户的视频列表
-func (s *VideoService) GetMyVideos(userID int64, page, pageSize int, status *string) (*dto.VideoListData, error) {
+func (s *VideoService) GetMyVideos(ctx context.Context, userID int64, page, pageSize int, status *string) (*dto.VideoListData, error) {
 	skip := (page - 1) * pageSize
-	videos, total, err := s.videoRepo.ListVideos(skip, pageSize, &userID, status, nil, false)
+	videos, total, err := s.videoRepo.ListByAuthor(ctx, userID, status, skip, pageSize)
 	if err != nil {
 		return nil, err
 	}
 	return buildVideoListData(videos, total, page, pageSize, false), nil
 }
 
+// ListFeedByCursor 游标分页获取已发布视频流，不包含在请求来源地区不可见的视频
+func (s *VideoService) ListFeedByCursor(ctx context.Context, after string, limit int) (*dto.CursorList[dto.VideoInfo], error) {
+	status := "published"
+	region := geoip.CountryFromContext(ctx)
+	return s.listVideosByCursor(ctx, after, limit, nil, &status, &region)
+}
+
+// ListMyVideosByCursor 游标分页获取当前用户的视频
+func (s *VideoService) ListMyVideosByCursor(ctx context.Context, userID int64, status *string, after string, limit int) (*dto.CursorList[dto.VideoInfo], error) {
+	return s.listVideosByCursor(ctx, after, limit, &userID, status, nil)
+}
+
+func (s *VideoService) listVideosByCursor(ctx context.Context, after string, limit int, authorID *int64, status, region *string) (*dto.CursorList[dto.VideoInfo], error) {
+	c, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	videos, err := s.videoRepo.ListVideosBefore(ctx, c.ID, limit+1, authorID, status, region, true)
+	if err != nil {
+		return nil, err
+	}
+	videos, hasMore := trimPage(videos, limit)
+
+	list := &dto.CursorList[dto.VideoInfo]{Items: make([]dto.VideoInfo, 0, len(videos)), HasMore: hasMore}
+	for i := range videos {
+		list.Items = append(list.Items, *toVideoInfo(&videos[i], true))
+	}
+	if hasMore {
+		list.NextCursor = cursor.Encode(cursor.New(videos[len(videos)-1].ID))
+	}
+	return list, nil
+}
+
 // toVideoInfo 将 model.Video 转换为 dto.VideoInfo
 func toVideoInfo(video *model.Video, includeAuthor bool) *dto.VideoInfo {
 	info := &dto.VideoInfo{
@@ -225,9 +794,37 @@ func toVideoInfo(video *model.Video, includeAuthor bool) *dto.VideoInfo {
 		ViewCount:     video.ViewCount,
 		FavoriteCount: video.FavoriteCount,
 		CommentCount:  video.CommentCount,
-		PublishTime:   video.PublishTime,
+		PublishTime:   video.PublishUnix(),
+		PublishedAt:   video.PublishTime,
 		CreatedAt:     video.CreatedAt,
 		UpdatedAt:     video.UpdatedAt,
+		Summary:       video.Summary,
+		DuplicateOfID: video.DuplicateOfID,
+		RemixOfID:     video.RemixOfID,
+		RemixType:     video.RemixType,
+		PremiereAt:    video.PremiereAt,
+
+		AllowedRegions: model.SplitRegions(video.AllowedRegions),
+		BlockedRegions: model.SplitRegions(video.BlockedRegions),
+
+		MembersOnlyTierID: video.MembersOnlyTierID,
+		IsSponsored:       video.IsSponsored,
+		SponsorDisclosure: video.SponsorDisclosure,
+
+		AgeRestricted: video.AgeRestricted(),
+		Reactions:     toReactionCounts(video),
+		IsPinned:      video.PinnedAt != nil,
+		Visibility:    video.Visibility,
+		PlaybackState: video.PlaybackState(),
+
+		VideoCodec:    video.VideoCodec,
+		AudioCodec:    video.AudioCodec,
+		Bitrate:       video.Bitrate,
+		FrameRate:     video.FrameRate,
+		AudioChannels: video.AudioChannels,
+	}
+	for _, m := range video.KeyMoments {
+		info.KeyMoments = append(info.KeyMoments, dto.KeyMomentInfo{Time: m.Time, Label: m.Label})
 	}
 
 	if includeAuthor && video.Author.ID != 0 {
@@ -241,6 +838,15 @@ func toVideoInfo(video *model.Video, includeAuthor bool) *dto.VideoInfo {
 	return info
 }
 
+func toReactionCounts(video *model.Video) dto.ReactionCounts {
+	return dto.ReactionCounts{
+		Like:  video.LikeCount,
+		Love:  video.LoveCount,
+		Laugh: video.LaughCount,
+		Wow:   video.WowCount,
+	}
+}
+
 func buildVideoListData(videos []model.Video, total int64, page, pageSize int, includeAuthor bool) *dto.VideoListData {
 	items := make([]dto.VideoInfo, 0, len(videos))
 	for i := range videos {
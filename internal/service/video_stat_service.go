@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// MaxVideoStatDays 单次查询的最大天数
+const MaxVideoStatDays = 90
+
+type VideoStatService struct {
+	statRepo  *repository.VideoStatRepository
+	videoRepo *repository.VideoRepository
+}
+
+func NewVideoStatService(statRepo *repository.VideoStatRepository, videoRepo *repository.VideoRepository) *VideoStatService {
+	return &VideoStatService{statRepo: statRepo, videoRepo: videoRepo}
+}
+
+// GetVideoStats 获取视频最近 days 天（含今天）的每日统计，仅作者本人可查看
+func (s *VideoStatService) GetVideoStats(ctx context.Context, videoID, currentUserID int64, days int) (*dto.VideoStatsData, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.AuthorID != currentUserID {
+		return nil, ErrVideoNoPermission
+	}
+
+	to := repository.StatDate(time.Now())
+	from := to.AddDate(0, 0, -(days - 1))
+	stats, err := s.statRepo.ListRange(ctx, videoID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]*model.VideoDailyStat, len(stats))
+	for i := range stats {
+		byDate[stats[i].StatDate.Format(time.DateOnly)] = &stats[i]
+	}
+
+	data := &dto.VideoStatsData{
+		VideoID: videoID,
+		Days:    days,
+		Daily:   make([]dto.VideoDailyStatInfo, 0, days),
+	}
+	var completions int64
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format(time.DateOnly)
+		day := dto.VideoDailyStatInfo{Date: date}
+		if st, ok := byDate[date]; ok {
+			day.Views = st.Views
+			day.Likes = st.Likes
+			day.Comments = st.Comments
+			day.Plays = st.Plays
+			day.WatchTimeMs = st.WatchTimeMs
+			day.AvgWatchTimeMs = average(st.WatchTimeMs, st.Plays)
+			day.CompletionRate = ratio(st.Completions, st.Plays)
+			completions += st.Completions
+		}
+		data.TotalViews += day.Views
+		data.TotalLikes += day.Likes
+		data.TotalComments += day.Comments
+		data.TotalPlays += day.Plays
+		data.TotalWatchTimeMs += day.WatchTimeMs
+		data.Daily = append(data.Daily, day)
+	}
+	data.AvgWatchTimeMs = average(data.TotalWatchTimeMs, data.TotalPlays)
+	data.CompletionRate = ratio(completions, data.TotalPlays)
+	return data, nil
+}
+
+func average(total, count int64) int64 {
+	if count == 0 {
+		return 0
+	}
+	return total / count
+}
+
+// ratio 比例，上限为 1（离线补报等情况下完播数可能略多于播放数）
+func ratio(part, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return min(float64(part)/float64(total), 1)
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/infra/geoip"
+	infraKafka "vida-go/internal/infra/kafka"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+	"vida-go/pkg/sensitive"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWalletInsufficient    = errors.New("金币余额不足")
+	ErrWalletRequestConflict = errors.New("request_id 已用于其他交易")
+	ErrCannotTipSelf         = errors.New("不能打赏自己的视频")
+)
+
+// WalletService 金币钱包：余额与流水在同一个事务中记账，每笔交易带幂等键，重试不会重复扣款
+type WalletService struct {
+	walletRepo          *repository.WalletRepository
+	videoRepo           *repository.VideoRepository
+	userRepo            *repository.UserRepository
+	accessRepo          *repository.VideoAccessRepository
+	notificationService *NotificationService
+	txManager           *repository.TxManager
+}
+
+func NewWalletService(walletRepo *repository.WalletRepository, videoRepo *repository.VideoRepository, userRepo *repository.UserRepository, accessRepo *repository.VideoAccessRepository, notificationService *NotificationService, txManager *repository.TxManager) *WalletService {
+	return &WalletService{
+		walletRepo:          walletRepo,
+		videoRepo:           videoRepo,
+		userRepo:            userRepo,
+		accessRepo:          accessRepo,
+		notificationService: notificationService,
+		txManager:           txManager,
+	}
+}
+
+// GetWallet 获取当前用户的钱包
+func (s *WalletService) GetWallet(ctx context.Context, userID int64) (*dto.WalletInfo, error) {
+	balance, err := s.walletRepo.GetBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.WalletInfo{Balance: balance}, nil
+}
+
+// ListTransactions 按条件分页查询流水，用户查询自己的流水时由调用方限定 UserID
+func (s *WalletService) ListTransactions(ctx context.Context, query *dto.WalletTransactionQuery, page, pageSize int) (*dto.WalletTransactionListData, error) {
+	filter := &repository.WalletTransactionFilter{
+		UserID:     query.UserID,
+		Kind:       query.Kind,
+		VideoID:    query.VideoID,
+		TransferID: query.TransferID,
+	}
+	skip := (page - 1) * pageSize
+	txs, total, err := s.walletRepo.ListTransactions(ctx, filter, skip, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.WalletTransactionInfo, 0, len(txs))
+	for i := range txs {
+		items = append(items, toWalletTransactionInfo(&txs[i]))
+	}
+
+	return &dto.WalletTransactionListData{
+		Transactions: items,
+		Total:        total,
+		Page:         page,
+		PageSize:     pageSize,
+		TotalPages:   (total + int64(pageSize) - 1) / int64(pageSize),
+	}, nil
+}
+
+// Tip 打赏视频：从打赏者余额扣款并记入作者钱包。
+// 同一用户重复提交同一 request_id 时不再扣款，直接返回首次的记账结果
+func (s *WalletService) Tip(ctx context.Context, videoID, userID int64, req *dto.TipRequest) (*dto.TipResult, error) {
+	if result, err := s.replayTip(ctx, videoID, userID, req); result != nil || err != nil {
+		return result, err
+	}
+
+	video, err := s.tippableVideo(ctx, videoID, userID)
+	if err != nil {
+		return nil, err
+	}
+	message, _ := sensitive.Mask(strings.TrimSpace(req.Message))
+	authorID := video.AuthorID
+
+	sent, err := s.Transfer(ctx, &WalletTransfer{
+		FromID:       userID,
+		ToID:         authorID,
+		Amount:       req.Amount,
+		SentKind:     model.WalletTxTipSent,
+		ReceivedKind: model.WalletTxTipReceived,
+		RequestID:    req.RequestID,
+		VideoID:      &videoID,
+		Note:         message,
+	})
+	if err != nil {
+		// 并发提交同一 request_id 时后到的事务因唯一索引冲突回滚，按重复提交处理
+		if !errors.Is(err, ErrWalletInsufficient) {
+			if result, rerr := s.replayTip(ctx, videoID, userID, req); result != nil || rerr != nil {
+				return result, rerr
+			}
+		}
+		return nil, err
+	}
+
+	s.notificationService.Emit(ctx, &infraKafka.NotificationEvent{
+		Type:        NotificationTypeTip,
+		RecipientID: authorID,
+		ActorID:     userID,
+		VideoID:     &videoID,
+		Content:     tipNotificationContent(req.Amount, message),
+	})
+
+	return &dto.TipResult{Transaction: toWalletTransactionInfo(sent), Balance: sent.BalanceAfter}, nil
+}
+
+// WalletTransfer 一笔用户间转账，付款方与收款方各记一条流水，幂等键记在付款方流水上
+type WalletTransfer struct {
+	FromID       int64
+	ToID         int64
+	Amount       int64
+	SentKind     string
+	ReceivedKind string
+	RequestID    string
+	VideoID      *int64
+	Note         string
+}
+
+// Transfer 转账并返回付款方流水，余额不足时返回 ErrWalletInsufficient。
+// ctx 已在事务中时加入该事务，调用方可以把转账与自己的写操作放在同一事务中
+func (s *WalletService) Transfer(ctx context.Context, t *WalletTransfer) (*model.WalletTransaction, error) {
+	transferID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	var sent model.WalletTransaction
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		var senderBalance, receiverBalance int64
+		debit := func() error {
+			balance, ok, err := s.walletRepo.Debit(ctx, t.FromID, t.Amount)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return ErrWalletInsufficient
+			}
+			senderBalance = balance
+			return nil
+		}
+		credit := func() (err error) {
+			receiverBalance, err = s.walletRepo.Credit(ctx, t.ToID, t.Amount)
+			return err
+		}
+		// 按用户ID顺序加锁，避免相向转账的并发事务死锁
+		steps := []func() error{debit, credit}
+		if t.ToID < t.FromID {
+			steps = []func() error{credit, debit}
+		}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+
+		txs := []model.WalletTransaction{
+			{
+				UserID:         t.FromID,
+				Kind:           t.SentKind,
+				Amount:         -t.Amount,
+				BalanceAfter:   senderBalance,
+				TransferID:     transferID,
+				RequestID:      &t.RequestID,
+				CounterpartyID: &t.ToID,
+				VideoID:        t.VideoID,
+				Note:           t.Note,
+			},
+			{
+				UserID:         t.ToID,
+				Kind:           t.ReceivedKind,
+				Amount:         t.Amount,
+				BalanceAfter:   receiverBalance,
+				TransferID:     transferID,
+				CounterpartyID: &t.FromID,
+				VideoID:        t.VideoID,
+				Note:           t.Note,
+			},
+		}
+		if err := s.walletRepo.CreateTransactions(ctx, txs); err != nil {
+			return err
+		}
+		sent = txs[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sent, nil
+}
+
+// FindByRequestID 查询用户以 request_id 记账的流水，没有时返回 nil
+func (s *WalletService) FindByRequestID(ctx context.Context, userID int64, requestID string) (*model.WalletTransaction, error) {
+	tx, err := s.walletRepo.GetByRequestID(ctx, userID, requestID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return tx, err
+}
+
+// replayTip request_id 已记账时返回首次的结果；同一 request_id 用于不同的交易时返回 ErrWalletRequestConflict
+func (s *WalletService) replayTip(ctx context.Context, videoID, userID int64, req *dto.TipRequest) (*dto.TipResult, error) {
+	tx, err := s.walletRepo.GetByRequestID(ctx, userID, req.RequestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if tx.Kind != model.WalletTxTipSent || tx.VideoID == nil || *tx.VideoID != videoID || tx.Amount != -req.Amount {
+		return nil, ErrWalletRequestConflict
+	}
+	balance, err := s.walletRepo.GetBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TipResult{Transaction: toWalletTransactionInfo(tx), Balance: balance}, nil
+}
+
+// tippableVideo 可打赏的视频：对打赏者可见的已发布视频，不能打赏自己的视频
+func (s *WalletService) tippableVideo(ctx context.Context, videoID, userID int64) (*model.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	if video.Status != "published" || video.LegalHoldAt != nil {
+		return nil, ErrVideoNotFound
+	}
+	if video.AuthorID == userID {
+		return nil, ErrCannotTipSelf
+	}
+	if ok, err := canViewVideo(ctx, s.accessRepo, video, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrVideoNotFound
+	}
+	if !video.AvailableIn(geoip.CountryFromContext(ctx)) {
+		return nil, ErrVideoRegionRestricted
+	}
+	if _, err := s.userRepo.GetByID(ctx, video.AuthorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	return video, nil
+}
+
+// Adjust 管理员调整用户余额（补偿、冲正等），扣减后余额不能为负。
+// 同一用户的同一 request_id 只会调整一次
+func (s *WalletService) Adjust(ctx context.Context, adminID, userID int64, req *dto.WalletAdjustRequest) (*dto.WalletTransactionInfo, error) {
+	if info, err := s.replayAdjust(ctx, userID, req); info != nil || err != nil {
+		return info, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	transferID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+
+	tx := model.WalletTransaction{
+		UserID:     userID,
+		Kind:       model.WalletTxAdminAdjust,
+		Amount:     req.Amount,
+		TransferID: transferID,
+		RequestID:  &req.RequestID,
+		OperatorID: &adminID,
+		Note:       reason,
+	}
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if req.Amount > 0 {
+			balance, err := s.walletRepo.Credit(ctx, userID, req.Amount)
+			if err != nil {
+				return err
+			}
+			tx.BalanceAfter = balance
+		} else {
+			balance, ok, err := s.walletRepo.Debit(ctx, userID, -req.Amount)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return ErrWalletInsufficient
+			}
+			tx.BalanceAfter = balance
+		}
+		txs := []model.WalletTransaction{tx}
+		if err := s.walletRepo.CreateTransactions(ctx, txs); err != nil {
+			return err
+		}
+		tx = txs[0]
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrWalletInsufficient) {
+			if info, rerr := s.replayAdjust(ctx, userID, req); info != nil || rerr != nil {
+				return info, rerr
+			}
+		}
+		return nil, err
+	}
+
+	s.notificationService.EmitSystem(ctx, userID, systemMessage(fmt.Sprintf("您的金币余额已调整 %+d，当前余额 %d", tx.Amount, tx.BalanceAfter), reason))
+
+	info := toWalletTransactionInfo(&tx)
+	return &info, nil
+}
+
+// replayAdjust request_id 已记账时返回首次的流水，规则同 replayTip
+func (s *WalletService) replayAdjust(ctx context.Context, userID int64, req *dto.WalletAdjustRequest) (*dto.WalletTransactionInfo, error) {
+	tx, err := s.walletRepo.GetByRequestID(ctx, userID, req.RequestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if tx.Kind != model.WalletTxAdminAdjust || tx.Amount != req.Amount {
+		return nil, ErrWalletRequestConflict
+	}
+	info := toWalletTransactionInfo(tx)
+	return &info, nil
+}
+
+// tipNotificationContent 打赏通知内容，附带打赏留言
+func tipNotificationContent(amount int64, message string) string {
+	content := fmt.Sprintf("打赏了你的视频 %d 金币", amount)
+	if message != "" {
+		content += "：" + message
+	}
+	return content
+}
+
+func toWalletTransactionInfo(tx *model.WalletTransaction) dto.WalletTransactionInfo {
+	info := dto.WalletTransactionInfo{
+		ID:             tx.ID,
+		UserID:         tx.UserID,
+		Kind:           tx.Kind,
+		Amount:         tx.Amount,
+		BalanceAfter:   tx.BalanceAfter,
+		TransferID:     tx.TransferID,
+		CounterpartyID: tx.CounterpartyID,
+		VideoID:        tx.VideoID,
+		OperatorID:     tx.OperatorID,
+		Note:           tx.Note,
+		CreatedAt:      tx.CreatedAt,
+	}
+	if tx.RequestID != nil {
+		info.RequestID = *tx.RequestID
+	}
+	return info
+}
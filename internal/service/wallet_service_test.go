@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"vida-go/internal/api/dto"
+	"vida-go/internal/model"
+	"vida-go/internal/repository"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestWalletService 基于临时 SQLite 数据库创建钱包服务，只用到钱包仓储与事务管理器
+func newTestWalletService(t *testing.T) (*WalletService, *repository.WalletRepository) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&model.Wallet{}, &model.WalletTransaction{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql db: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	walletRepo := repository.NewWalletRepository(db)
+	return NewWalletService(walletRepo, nil, nil, nil, nil, repository.NewTxManager(db)), walletRepo
+}
+
+func assertBalance(t *testing.T, walletRepo *repository.WalletRepository, userID, want int64) {
+	t.Helper()
+	balance, err := walletRepo.GetBalance(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetBalance(%d) error = %v", userID, err)
+	}
+	if balance != want {
+		t.Errorf("balance of user %d = %d, want %d", userID, balance, want)
+	}
+}
+
+func TestWalletTransferInsufficientRollsBack(t *testing.T) {
+	walletService, walletRepo := newTestWalletService(t)
+	ctx := context.Background()
+	if _, err := walletRepo.Credit(ctx, 2, 50); err != nil {
+		t.Fatalf("Credit() error = %v", err)
+	}
+
+	// 收款方ID较小时先入账再扣款，扣款失败须连同入账一起回滚
+	_, err := walletService.Transfer(ctx, &WalletTransfer{
+		FromID: 2, ToID: 1, Amount: 80,
+		SentKind: model.WalletTxTipSent, ReceivedKind: model.WalletTxTipReceived, RequestID: "r1",
+	})
+	if !errors.Is(err, ErrWalletInsufficient) {
+		t.Fatalf("Transfer() error = %v, want ErrWalletInsufficient", err)
+	}
+	assertBalance(t, walletRepo, 1, 0)
+	assertBalance(t, walletRepo, 2, 50)
+
+	tx, err := walletService.FindByRequestID(ctx, 2, "r1")
+	if err != nil {
+		t.Fatalf("FindByRequestID() error = %v", err)
+	}
+	if tx != nil {
+		t.Error("failed transfer left a transaction behind")
+	}
+}
+
+func TestWalletTipReplaysRequestID(t *testing.T) {
+	walletService, walletRepo := newTestWalletService(t)
+	ctx := context.Background()
+	if _, err := walletRepo.Credit(ctx, 1, 100); err != nil {
+		t.Fatalf("Credit() error = %v", err)
+	}
+
+	videoID := int64(10)
+	transfer := &WalletTransfer{
+		FromID: 1, ToID: 2, Amount: 30,
+		SentKind: model.WalletTxTipSent, ReceivedKind: model.WalletTxTipReceived,
+		RequestID: "tip-1", VideoID: &videoID,
+	}
+	sent, err := walletService.Transfer(ctx, transfer)
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+
+	// 重复提交同一 request_id：返回首次的流水，不再扣款
+	result, err := walletService.Tip(ctx, videoID, 1, &dto.TipRequest{Amount: 30, RequestID: "tip-1"})
+	if err != nil {
+		t.Fatalf("Tip() replay error = %v", err)
+	}
+	if result.Transaction.ID != sent.ID {
+		t.Errorf("Tip() replay transaction = %d, want %d", result.Transaction.ID, sent.ID)
+	}
+	if result.Balance != 70 {
+		t.Errorf("Tip() replay balance = %d, want 70", result.Balance)
+	}
+	assertBalance(t, walletRepo, 1, 70)
+	assertBalance(t, walletRepo, 2, 30)
+
+	// 同一 request_id 用于不同金额或视频
+	if _, err := walletService.Tip(ctx, videoID, 1, &dto.TipRequest{Amount: 40, RequestID: "tip-1"}); !errors.Is(err, ErrWalletRequestConflict) {
+		t.Errorf("Tip() with another amount error = %v, want ErrWalletRequestConflict", err)
+	}
+	if _, err := walletService.Tip(ctx, videoID+1, 1, &dto.TipRequest{Amount: 30, RequestID: "tip-1"}); !errors.Is(err, ErrWalletRequestConflict) {
+		t.Errorf("Tip() on another video error = %v, want ErrWalletRequestConflict", err)
+	}
+
+	// 绕过重放检查直接再次转账时由唯一索引拦截，整笔转账回滚
+	if _, err := walletService.Transfer(ctx, transfer); err == nil {
+		t.Fatal("Transfer() with a reused request_id succeeded")
+	}
+	assertBalance(t, walletRepo, 1, 70)
+	assertBalance(t, walletRepo, 2, 30)
+}
@@ -0,0 +1,179 @@
+package transcode
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"vida-go/internal/config"
+	infraKafka "vida-go/internal/infra/kafka"
+	infraMinio "vida-go/internal/infra/minio"
+	"vida-go/internal/infra/tracing"
+	"vida-go/pkg/logger"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const hlsSegmentSeconds = 6
+
+// HandleRendition 处理附加清晰度转码任务：从原始视频转出指定档位（mp4 或 HLS），
+// 上传到 public-videos 的 videos/{id}/{profile} 下并发送结果消息
+func HandleRendition(ctx context.Context, task *infraKafka.RenditionTask) error {
+	ctx, span := tracing.Tracer().Start(ctx, "transcode.HandleRendition",
+		trace.WithAttributes(attribute.Int64("video.id", task.VideoID), attribute.String("rendition.profile", task.Profile)))
+	defer span.End()
+
+	taskDir := filepath.Join(workDir, fmt.Sprintf("%d-%s", task.VideoID, task.Profile))
+	if err := os.MkdirAll(taskDir, 0755); err != nil {
+		return sendRenditionFailure(ctx, task, fmt.Errorf("create work dir: %w", err))
+	}
+	defer os.RemoveAll(taskDir)
+
+	logger.Info("Rendition task started",
+		zap.Int64("video_id", task.VideoID),
+		zap.String("profile", task.Profile),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+
+	srcFile := filepath.Join(taskDir, "raw"+filepath.Ext(task.ObjectName))
+	if err := infraMinio.DownloadFile(ctx, task.Bucket, task.ObjectName, srcFile); err != nil {
+		return sendRenditionFailure(ctx, task, fmt.Errorf("download from minio: %w", err))
+	}
+
+	outDir := filepath.Join(taskDir, "out")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return sendRenditionFailure(ctx, task, fmt.Errorf("create output dir: %w", err))
+	}
+
+	var entry string
+	switch task.Format {
+	case "mp4":
+		entry = "video.mp4"
+	case "hls":
+		entry = "index.m3u8"
+	default:
+		return sendRenditionFailure(ctx, task, fmt.Errorf("unsupported rendition format %q", task.Format))
+	}
+	if err := transcodeRendition(ctx, task, srcFile, outDir, entry); err != nil {
+		return sendRenditionFailure(ctx, task, err)
+	}
+
+	probe, err := probeVideo(filepath.Join(outDir, entry))
+	if err != nil {
+		logger.Warn("Probe rendition failed", zap.Error(err))
+	}
+
+	// 上传输出目录下的所有文件（HLS 为播放列表与分片）
+	prefix := fmt.Sprintf("videos/%d/%s/", task.VideoID, task.Profile)
+	files, err := os.ReadDir(outDir)
+	if err != nil {
+		return sendRenditionFailure(ctx, task, fmt.Errorf("read output dir: %w", err))
+	}
+	for _, f := range files {
+		if err := uploadToMinIO(ctx, publicBucket, prefix+f.Name(), filepath.Join(outDir, f.Name()), renditionContentType(f.Name())); err != nil {
+			return sendRenditionFailure(ctx, task, fmt.Errorf("upload %s: %w", f.Name(), err))
+		}
+	}
+
+	minioCfg := config.GetMinIO()
+	return sendRenditionResult(ctx, &infraKafka.RenditionResult{
+		VideoID: task.VideoID,
+		Profile: task.Profile,
+		Status:  "ready",
+		URL:     infraMinio.GetPublicURL(minioCfg.Endpoint, minioCfg.UseSSL, publicBucket, prefix+entry),
+		Width:   probe.Width,
+		Height:  probe.Height,
+	})
+}
+
+// transcodeRendition H.264 + AAC，高度缩放到 task.Height（源视频更低时保持不变），HLS 按固定时长切片
+func transcodeRendition(ctx context.Context, task *infraKafka.RenditionTask, srcFile, outDir, entry string) error {
+	_, span := tracing.Tracer().Start(ctx, "ffmpeg.rendition")
+	defer span.End()
+
+	args := []string{"-i", srcFile}
+	if task.Height > 0 {
+		args = append(args, "-vf", fmt.Sprintf("scale=-2:'min(%d,ih)'", task.Height))
+	}
+	args = append(args,
+		"-c:v", "libx264",
+		"-preset", "medium",
+		"-crf", strconv.Itoa(task.CRF),
+		"-c:a", "aac",
+		"-b:a", "128k",
+	)
+	if task.Format == "hls" {
+		args = append(args,
+			"-f", "hls",
+			"-hls_time", strconv.Itoa(hlsSegmentSeconds),
+			"-hls_playlist_type", "vod",
+			"-hls_segment_filename", filepath.Join(outDir, "segment_%04d.ts"),
+		)
+	} else {
+		args = append(args, "-movflags", "+faststart")
+	}
+	args = append(args, "-y", filepath.Join(outDir, entry))
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("ffmpeg rendition failed: %w\noutput: %s", err, string(output))
+	}
+	return nil
+}
+
+func renditionContentType(name string) string {
+	switch filepath.Ext(name) {
+	case ".m3u8":
+		return "application/vnd.apple.mpegurl"
+	case ".ts":
+		return "video/mp2t"
+	case ".mp4":
+		return "video/mp4"
+	}
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+func sendRenditionResult(ctx context.Context, result *infraKafka.RenditionResult) error {
+	topic := config.GetKafka().Topics["video_rendition_result"]
+
+	// 与任务自身的超时解耦，确保失败结果也能发出
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	return infraKafka.SendRenditionResult(ctx, topic, result)
+}
+
+func sendRenditionFailure(ctx context.Context, task *infraKafka.RenditionTask, originalErr error) error {
+	logger.Error("Rendition task failed",
+		zap.Int64("video_id", task.VideoID),
+		zap.String("profile", task.Profile),
+		zap.Error(originalErr))
+	trace.SpanFromContext(ctx).SetStatus(codes.Error, originalErr.Error())
+
+	result := &infraKafka.RenditionResult{
+		VideoID: task.VideoID,
+		Profile: task.Profile,
+		Status:  "failed",
+		Error:   originalErr.Error(),
+	}
+	if err := sendRenditionResult(ctx, result); err != nil {
+		logger.Error("Failed to send rendition failure result", zap.Error(err))
+		return err
+	}
+	return originalErr
+}
@@ -4,24 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"vida-go/internal/config"
 	infraKafka "vida-go/internal/infra/kafka"
 	infraMinio "vida-go/internal/infra/minio"
+	"vida-go/internal/infra/tracing"
 	"vida-go/pkg/logger"
 
-	"github.com/minio/minio-go/v7"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 const (
 	publicBucket = "public-videos"
 	workDir      = "/tmp/vida-transcode"
+	frameSamples = 8 // 供 AI 打标签、生成摘要与关键时刻使用的抽帧数量
 )
 
 // HandleTask 处理一个转码任务的完整流程：
@@ -29,11 +35,18 @@ const (
 //  2. FFmpeg 转码为 mp4 (H.264 + AAC)
 //  3. FFmpeg 截取封面图
 //  4. 上传转码结果到 MinIO public-videos bucket
-//  5. 发送转码结果消息到 Kafka
-func HandleTask(task *infraKafka.TranscodeTask) error {
+//  5. 按时长均匀抽取若干帧并计算感知哈希，供 AI 分析与近似重复检测使用
+//  6. 发送转码结果消息到 Kafka
+//
+// ctx 携带 Kafka 消息中恢复的 Trace 上下文，结果消息会继续向下游传递
+func HandleTask(ctx context.Context, task *infraKafka.TranscodeTask) error {
+	ctx, span := tracing.Tracer().Start(ctx, "transcode.HandleTask",
+		trace.WithAttributes(attribute.Int64("video.id", task.VideoID)))
+	defer span.End()
+
 	taskDir := filepath.Join(workDir, fmt.Sprintf("%d", task.VideoID))
 	if err := os.MkdirAll(taskDir, 0755); err != nil {
-		return sendFailure(task.VideoID, fmt.Errorf("create work dir: %w", err))
+		return sendFailure(ctx, task.VideoID, fmt.Errorf("create work dir: %w", err))
 	}
 	defer os.RemoveAll(taskDir)
 
@@ -47,20 +60,27 @@ func HandleTask(task *infraKafka.TranscodeTask) error {
 	)
 
 	// 1. 从 MinIO 下载原始视频
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
-	if err := downloadFromMinIO(ctx, task.Bucket, task.ObjectName, srcFile); err != nil {
-		return sendFailure(task.VideoID, fmt.Errorf("download from minio: %w", err))
+	if err := infraMinio.DownloadFile(ctx, task.Bucket, task.ObjectName, srcFile); err != nil {
+		return sendFailure(ctx, task.VideoID, fmt.Errorf("download from minio: %w", err))
+	}
+
+	// 按上传者的时长上限校验原始视频，探测失败时交给转码环节处理
+	if task.MaxDuration > 0 {
+		if src, err := probeVideo(srcFile); err == nil && src.Duration > task.MaxDuration {
+			return sendFailure(ctx, task.VideoID, fmt.Errorf("video duration %ds exceeds limit %ds", src.Duration, task.MaxDuration))
+		}
 	}
 
 	// 2. FFmpeg 转码
-	if err := transcodeVideo(srcFile, dstFile); err != nil {
-		return sendFailure(task.VideoID, fmt.Errorf("transcode: %w", err))
+	if err := transcodeVideo(ctx, srcFile, dstFile); err != nil {
+		return sendFailure(ctx, task.VideoID, fmt.Errorf("transcode: %w", err))
 	}
 
 	// 3. 截取封面
-	if err := extractCover(dstFile, coverFile); err != nil {
+	if err := extractCover(ctx, dstFile, coverFile); err != nil {
 		logger.Warn("Extract cover failed, skipping", zap.Error(err))
 	}
 
@@ -75,7 +95,7 @@ func HandleTask(task *infraKafka.TranscodeTask) error {
 	coverObjectName := fmt.Sprintf("videos/%d/cover.jpg", task.VideoID)
 
 	if err := uploadToMinIO(ctx, publicBucket, videoObjectName, dstFile, "video/mp4"); err != nil {
-		return sendFailure(task.VideoID, fmt.Errorf("upload video: %w", err))
+		return sendFailure(ctx, task.VideoID, fmt.Errorf("upload video: %w", err))
 	}
 
 	var coverURL string
@@ -91,41 +111,34 @@ func HandleTask(task *infraKafka.TranscodeTask) error {
 	minioCfg := config.GetMinIO()
 	playURL := infraMinio.GetPublicURL(minioCfg.Endpoint, minioCfg.UseSSL, publicBucket, videoObjectName)
 
-	// 6. 发送转码结果
+	// 6. 抽帧（失败不影响发布）
+	frames := sampleFrames(ctx, task.VideoID, dstFile, taskDir, probe.Duration)
+
+	// 7. 发送转码结果
 	result := &infraKafka.TranscodeResult{
 		VideoID:  task.VideoID,
 		Status:   "published",
 		PlayURL:  playURL,
 		CoverURL: coverURL,
+		Frames:   frames,
 		Duration: probe.Duration,
 		Width:    probe.Width,
 		Height:   probe.Height,
-	}
 
-	return sendResult(result)
-}
-
-func downloadFromMinIO(ctx context.Context, bucket, objectName, destPath string) error {
-	client := infraMinio.Get()
-	obj, err := client.GetObject(ctx, bucket, objectName, minio.GetObjectOptions{})
-	if err != nil {
-		return err
-	}
-	defer obj.Close()
-
-	f, err := os.Create(destPath)
-	if err != nil {
-		return err
+		VideoCodec:    probe.VideoCodec,
+		AudioCodec:    probe.AudioCodec,
+		Bitrate:       probe.Bitrate,
+		FrameRate:     probe.FrameRate,
+		AudioChannels: probe.AudioChannels,
 	}
-	defer f.Close()
 
-	if _, err := f.ReadFrom(obj); err != nil {
-		return err
-	}
-	return nil
+	return sendResult(ctx, result)
 }
 
-func transcodeVideo(srcFile, dstFile string) error {
+func transcodeVideo(ctx context.Context, srcFile, dstFile string) error {
+	_, span := tracing.Tracer().Start(ctx, "ffmpeg.transcode")
+	defer span.End()
+
 	// H.264 + AAC, 分辨率保持不变, 中等质量 CRF 23
 	args := []string{
 		"-i", srcFile,
@@ -142,6 +155,7 @@ func transcodeVideo(srcFile, dstFile string) error {
 	cmd := exec.Command("ffmpeg", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("ffmpeg transcode failed: %w\noutput: %s", err, string(output))
 	}
 
@@ -149,7 +163,10 @@ func transcodeVideo(srcFile, dstFile string) error {
 	return nil
 }
 
-func extractCover(videoFile, coverFile string) error {
+func extractCover(ctx context.Context, videoFile, coverFile string) error {
+	_, span := tracing.Tracer().Start(ctx, "ffmpeg.extract_cover")
+	defer span.End()
+
 	// 截取第 1 秒的画面作为封面
 	args := []string{
 		"-i", videoFile,
@@ -168,10 +185,74 @@ func extractCover(videoFile, coverFile string) error {
 	return nil
 }
 
+// sampleFrames 在视频时长的 1/(n+1)、2/(n+1)... 处各截取一帧上传到 MinIO，返回成功上传的帧
+func sampleFrames(ctx context.Context, videoID int64, videoFile, taskDir string, duration int) []infraKafka.VideoFrame {
+	if duration <= 0 {
+		return nil
+	}
+
+	minioCfg := config.GetMinIO()
+	var frames []infraKafka.VideoFrame
+	for i := 1; i <= frameSamples; i++ {
+		offset := float64(duration) * float64(i) / float64(frameSamples+1)
+		frameFile := filepath.Join(taskDir, fmt.Sprintf("frame_%d.jpg", i))
+		if err := extractFrame(ctx, videoFile, frameFile, offset); err != nil {
+			logger.Warn("Extract frame failed", zap.Int64("video_id", videoID), zap.Error(err))
+			continue
+		}
+
+		hash, err := frameHash(frameFile)
+		if err != nil {
+			logger.Warn("Hash frame failed", zap.Int64("video_id", videoID), zap.Error(err))
+		}
+
+		objectName := fmt.Sprintf("videos/%d/frames/%d.jpg", videoID, i)
+		if err := uploadToMinIO(ctx, publicBucket, objectName, frameFile, "image/jpeg"); err != nil {
+			logger.Warn("Upload frame failed", zap.Int64("video_id", videoID), zap.Error(err))
+			continue
+		}
+		frames = append(frames, infraKafka.VideoFrame{
+			URL:    infraMinio.GetPublicURL(minioCfg.Endpoint, minioCfg.UseSSL, publicBucket, objectName),
+			Offset: int(offset),
+			Hash:   hash,
+		})
+	}
+	return frames
+}
+
+func extractFrame(ctx context.Context, videoFile, frameFile string, offset float64) error {
+	_, span := tracing.Tracer().Start(ctx, "ffmpeg.extract_frame")
+	defer span.End()
+
+	// 缩放到宽 640，控制上传与模型输入的体积
+	args := []string{
+		"-ss", strconv.FormatFloat(offset, 'f', 2, 64),
+		"-i", videoFile,
+		"-vframes", "1",
+		"-vf", "scale=640:-2",
+		"-q:v", "4",
+		"-y",
+		frameFile,
+	}
+
+	cmd := exec.Command("ffmpeg", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg extract frame failed: %w\noutput: %s", err, string(output))
+	}
+	return nil
+}
+
+// videoProbe ffprobe 探测到的视频信息；字段为零值表示未探测到
 type videoProbe struct {
-	Duration int
-	Width    int
-	Height   int
+	Duration      int
+	Width         int
+	Height        int
+	VideoCodec    string
+	AudioCodec    string
+	Bitrate       int64   // 总码率（bps）
+	FrameRate     float64 // 帧率（fps）
+	AudioChannels int
 }
 
 func probeVideo(videoFile string) (*videoProbe, error) {
@@ -191,12 +272,18 @@ func probeVideo(videoFile string) (*videoProbe, error) {
 
 	var data struct {
 		Streams []struct {
-			Width    int    `json:"width"`
-			Height   int    `json:"height"`
-			Duration string `json:"duration"`
+			CodecType    string `json:"codec_type"`
+			CodecName    string `json:"codec_name"`
+			Width        int    `json:"width"`
+			Height       int    `json:"height"`
+			Duration     string `json:"duration"`
+			AvgFrameRate string `json:"avg_frame_rate"`
+			RFrameRate   string `json:"r_frame_rate"`
+			Channels     int    `json:"channels"`
 		} `json:"streams"`
 		Format struct {
 			Duration string `json:"duration"`
+			BitRate  string `json:"bit_rate"`
 		} `json:"format"`
 	}
 
@@ -211,18 +298,46 @@ func probeVideo(videoFile string) (*videoProbe, error) {
 			probe.Duration = int(dur)
 		}
 	}
+	if data.Format.BitRate != "" {
+		if rate, err := strconv.ParseInt(data.Format.BitRate, 10, 64); err == nil {
+			probe.Bitrate = rate
+		}
+	}
 
+	// 取第一路视频流与第一路音频流
 	for _, s := range data.Streams {
-		if s.Width > 0 && s.Height > 0 {
+		switch {
+		case s.CodecType == "video" && probe.VideoCodec == "" && s.Width > 0 && s.Height > 0:
+			probe.VideoCodec = s.CodecName
 			probe.Width = s.Width
 			probe.Height = s.Height
-			break
+			probe.FrameRate = parseFrameRate(s.AvgFrameRate)
+			if probe.FrameRate == 0 {
+				probe.FrameRate = parseFrameRate(s.RFrameRate)
+			}
+		case s.CodecType == "audio" && probe.AudioCodec == "":
+			probe.AudioCodec = s.CodecName
+			probe.AudioChannels = s.Channels
 		}
 	}
 
 	return probe, nil
 }
 
+// parseFrameRate 解析 ffprobe 的分数形式帧率（如 30000/1001），保留两位小数；无法解析（如 0/0）时返回 0
+func parseFrameRate(rate string) float64 {
+	num, den, ok := strings.Cut(rate, "/")
+	if !ok {
+		den = "1"
+	}
+	n, err1 := strconv.ParseFloat(num, 64)
+	d, err2 := strconv.ParseFloat(den, 64)
+	if err1 != nil || err2 != nil || n <= 0 || d <= 0 {
+		return 0
+	}
+	return math.Round(n/d*100) / 100
+}
+
 func uploadToMinIO(ctx context.Context, bucket, objectName, filePath, contentType string) error {
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -239,7 +354,7 @@ func uploadToMinIO(ctx context.Context, bucket, objectName, filePath, contentTyp
 	return err
 }
 
-func sendResult(result *infraKafka.TranscodeResult) error {
+func sendResult(ctx context.Context, result *infraKafka.TranscodeResult) error {
 	cfg := config.GetKafka()
 	topic := cfg.Topics["video_uploaded"]
 
@@ -248,14 +363,16 @@ func sendResult(result *infraKafka.TranscodeResult) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// 与任务自身的超时解耦，确保失败结果也能发出
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
 	defer cancel()
 
 	return infraKafka.SendRaw(ctx, topic, fmt.Sprintf("video-%d", result.VideoID), payload)
 }
 
-func sendFailure(videoID int64, originalErr error) error {
+func sendFailure(ctx context.Context, videoID int64, originalErr error) error {
 	logger.Error("Transcode task failed", zap.Int64("video_id", videoID), zap.Error(originalErr))
+	trace.SpanFromContext(ctx).SetStatus(codes.Error, originalErr.Error())
 
 	result := &infraKafka.TranscodeResult{
 		VideoID: videoID,
@@ -263,7 +380,7 @@ func sendFailure(videoID int64, originalErr error) error {
 		Error:   originalErr.Error(),
 	}
 
-	if err := sendResult(result); err != nil {
+	if err := sendResult(ctx, result); err != nil {
 		logger.Error("Failed to send failure result", zap.Error(err))
 		return err
 	}
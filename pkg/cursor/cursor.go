@@ -0,0 +1,58 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalid 游标无法解析
+var ErrInvalid = errors.New("invalid cursor")
+
+// Cursor 游标分页位置，记录上一页最后一条记录的 ID 及排序时间（按时间排序的列表才需要）
+type Cursor struct {
+	ID int64 `json:"i"`
+	At int64 `json:"t,omitempty"` // Unix 微秒
+}
+
+// New 创建仅按 ID 定位的游标
+func New(id int64) Cursor {
+	return Cursor{ID: id}
+}
+
+// NewWithTime 创建按（时间, ID）定位的游标
+func NewWithTime(at time.Time, id int64) Cursor {
+	return Cursor{ID: id, At: at.UnixMicro()}
+}
+
+// Time 返回游标中的排序时间，未设置时返回 nil
+func (c Cursor) Time() *time.Time {
+	if c.At == 0 {
+		return nil
+	}
+	t := time.UnixMicro(c.At)
+	return &t
+}
+
+// Encode 编码为对客户端不透明的字符串
+func Encode(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode 解析客户端传回的游标，空字符串表示从第一页开始
+func Decode(s string) (Cursor, error) {
+	var c Cursor
+	if s == "" {
+		return c, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalid
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 || c.At < 0 {
+		return Cursor{}, ErrInvalid
+	}
+	return c, nil
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// 源语言：代码中的提示文案均以简体中文书写，并直接作为翻译目录的 key
+const SourceLanguage = "zh-CN"
+
+// ContextKey gin.Context 中保存当前请求语言的 key
+const ContextKey = "locale"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var (
+	catalogs        = map[string]map[string]string{}
+	supported       = []string{SourceLanguage}
+	matcher         = language.NewMatcher([]language.Tag{language.MustParse(SourceLanguage)})
+	matcherLangs    = []string{SourceLanguage}
+	defaultLanguage = SourceLanguage
+)
+
+// Init 加载内置的翻译目录，defaultLang 为无法匹配 Accept-Language 时的回退语言
+func Init(defaultLang string) error {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		return fmt.Errorf("read locales: %w", err)
+	}
+
+	for _, entry := range entries {
+		lang := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		data, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read locale %s: %w", lang, err)
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("parse locale %s: %w", lang, err)
+		}
+		catalogs[lang] = messages
+		supported = append(supported, lang)
+	}
+
+	if defaultLang == "" {
+		defaultLang = SourceLanguage
+	}
+	if defaultLang != SourceLanguage && catalogs[defaultLang] == nil {
+		return fmt.Errorf("unsupported default language: %s", defaultLang)
+	}
+	defaultLanguage = defaultLang
+
+	// 匹配器的第一个语言即为回退语言
+	langs := []string{defaultLanguage}
+	for _, lang := range supported {
+		if lang != defaultLanguage {
+			langs = append(langs, lang)
+		}
+	}
+	tags := make([]language.Tag, len(langs))
+	for i, lang := range langs {
+		tags[i] = language.MustParse(lang)
+	}
+	matcher = language.NewMatcher(tags)
+	matcherLangs = langs
+	return nil
+}
+
+// Match 根据 Accept-Language 请求头选出最合适的已支持语言
+func Match(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return defaultLanguage
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return defaultLanguage
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return defaultLanguage
+	}
+	return matcherLangs[index]
+}
+
+// T 将源语言文案翻译为目标语言，找不到翻译时原样返回
+// 对 "前缀: 详情" 形式的动态文案，只翻译前缀部分
+func T(lang, message string) string {
+	if lang == "" || lang == SourceLanguage {
+		return message
+	}
+	catalog := catalogs[lang]
+	if catalog == nil {
+		return message
+	}
+	if translated, ok := catalog[message]; ok {
+		return translated
+	}
+	if prefix, detail, found := strings.Cut(message, ": "); found {
+		if translated, ok := catalog[prefix]; ok {
+			return translated + ": " + detail
+		}
+	}
+	return message
+}
@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// base 不带 CallerSkip 的全局 Logger，请求级 Logger 由它派生，调用位置指向实际打日志的代码
+var base = zap.NewNop()
+
+// NewContext 返回携带 l 的 ctx
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// WithFields 在 ctx 携带的 Logger 上追加字段（如请求 ID、用户 ID），返回新的 ctx
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(fields...))
+}
+
+// FromContext 返回 ctx 携带的请求级 Logger，日志自动带上中间件写入的请求 ID、用户 ID、路由等字段；
+// ctx 中没有时返回全局 Logger
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return base
+}
@@ -5,69 +5,107 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Logger 全局日志实例
 var Logger *zap.Logger
 
-// Init 初始化日志系统
-func Init(level, format, output, filePath string) error {
+var (
+	core     zapcore.Core  // 当前所有输出的组合，AddSink 在其上追加
+	minLevel zapcore.Level // Init 设置的日志级别，AddSink 未指定级别时沿用
+)
+
+// Rotation 日志文件的切割与保留策略，数值为 0 时使用默认值或不限制
+type Rotation struct {
+	MaxSizeMB  int  // 单个文件达到该大小（MB）后切割，默认 100
+	MaxAgeDays int  // 旧文件保留天数，0 表示不按时间清理
+	MaxBackups int  // 旧文件保留个数，0 表示不按个数清理
+	Compress   bool // 是否 gzip 压缩旧文件
+}
+
+// Init 初始化日志系统，output 为 stdout、file 或 both（同时输出到控制台和文件），
+// 输出到文件时按 rotation 切割、压缩和清理旧文件
+func Init(level, format, output, filePath string, rotation Rotation) error {
 	// 设置日志级别
-	var zapLevel zapcore.Level
+	zapLevel := parseLevel(level)
+	minLevel = zapLevel
+
+	// 设置输出位置
+	var cores []zapcore.Core
+	if output == "file" || output == "both" {
+		// 输出到文件（按大小切割，目录不存在时自动创建）
+		file := &lumberjack.Logger{
+			Filename:   filePath,
+			MaxSize:    rotation.MaxSizeMB,
+			MaxAge:     rotation.MaxAgeDays,
+			MaxBackups: rotation.MaxBackups,
+			Compress:   rotation.Compress,
+		}
+		cores = append(cores, zapcore.NewCore(newEncoder(format, false), zapcore.AddSync(file), zapLevel))
+	}
+	if output != "file" {
+		// 输出到控制台
+		cores = append(cores, zapcore.NewCore(newEncoder(format, true), zapcore.AddSync(os.Stdout), zapLevel))
+	}
+
+	// 创建核心
+	core = zapcore.NewTee(cores...)
+	build()
+
+	return nil
+}
+
+// AddSink 追加一个 JSON 格式的日志输出（如集中式日志投递），level 为空时沿用 Init 的级别；
+// 需在 Init 之后、业务开始打日志之前调用
+func AddSink(ws zapcore.WriteSyncer, level string) {
+	lvl := minLevel
+	if level != "" {
+		lvl = parseLevel(level)
+	}
+	core = zapcore.NewTee(core, zapcore.NewCore(newEncoder("json", false), ws, lvl))
+	build()
+}
+
+// build 基于 core 创建全局 Logger（便捷方法多一层调用，需跳过一层栈帧）
+func build() {
+	base = zap.New(core, zap.AddCaller())
+	Logger = base.WithOptions(zap.AddCallerSkip(1))
+}
+
+func parseLevel(level string) zapcore.Level {
 	switch level {
 	case "debug":
-		zapLevel = zapcore.DebugLevel
-	case "info":
-		zapLevel = zapcore.InfoLevel
+		return zapcore.DebugLevel
 	case "warn":
-		zapLevel = zapcore.WarnLevel
+		return zapcore.WarnLevel
 	case "error":
-		zapLevel = zapcore.ErrorLevel
+		return zapcore.ErrorLevel
 	default:
-		zapLevel = zapcore.InfoLevel
+		return zapcore.InfoLevel
 	}
+}
 
+// newEncoder 创建编码器，console 格式输出到终端时级别带颜色
+func newEncoder(format string, colored bool) zapcore.Encoder {
 	// 设置编码器配置
 	var encoderConfig zapcore.EncoderConfig
 	if format == "json" {
 		encoderConfig = zap.NewProductionEncoderConfig()
 	} else {
 		encoderConfig = zap.NewDevelopmentEncoderConfig()
-		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // 彩色输出
+		if colored {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // 彩色输出
+		}
 	}
 
 	// 设置时间格式
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	// 设置编码器
-	var encoder zapcore.Encoder
 	if format == "json" {
-		encoder = zapcore.NewJSONEncoder(encoderConfig)
-	} else {
-		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+		return zapcore.NewJSONEncoder(encoderConfig)
 	}
-
-	// 设置输出位置
-	var writeSyncer zapcore.WriteSyncer
-	if output == "file" {
-		// 输出到文件
-		file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			return err
-		}
-		writeSyncer = zapcore.AddSync(file)
-	} else {
-		// 输出到控制台
-		writeSyncer = zapcore.AddSync(os.Stdout)
-	}
-
-	// 创建核心
-	core := zapcore.NewCore(encoder, writeSyncer, zapLevel)
-
-	// 创建Logger
-	Logger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
-
-	return nil
+	return zapcore.NewConsoleEncoder(encoderConfig)
 }
 
 // Sync 刷新日志缓冲区
@@ -0,0 +1,38 @@
+package sensitive
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// lookalikes 常见的形近字符，统一折叠为同一个小写拉丁字母。
+// l 与 i、1 互相混淆，统一折叠为 i
+var lookalikes = map[rune]rune{
+	'0': 'o', '1': 'i', '!': 'i', '|': 'i', 'l': 'i', '3': 'e', '4': 'a', '@': 'a',
+	'5': 's', '$': 's', '¢': 'c', '7': 't', '8': 'b', '9': 'g',
+	// 西里尔、希腊字母中与拉丁字母同形的字符
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p',
+	'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'ո': 'n',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+}
+
+// Normalize 将文本折叠为用于比对的规范形式：NFKC 规范化（全角转半角等）、去除组合附加符号（é → e）、
+// 转小写、形近字符折叠（0 → o、а → a 等），并去掉空白、标点等分隔符
+func Normalize(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(norm.NFKC.String(text)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		if m, ok := lookalikes[r]; ok {
+			r = m
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
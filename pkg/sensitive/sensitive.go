@@ -0,0 +1,118 @@
+package sensitive
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// node 敏感词前缀树节点（按小写字符匹配，忽略英文大小写）
+type node struct {
+	children map[rune]*node
+	end      bool
+}
+
+var (
+	root = &node{children: map[rune]*node{}}
+	// folded 按 Normalize 归一化后的词库，用于用户名等需要防止形近字绕过的场景
+	folded = &node{children: map[rune]*node{}}
+)
+
+// Init 从词库文件加载敏感词（每行一个，# 开头为注释），path 为空时不过滤
+func Init(path string) error {
+	root = &node{children: map[rune]*node{}}
+	folded = &node{children: map[rune]*node{}}
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open sensitive words file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		Add(word)
+	}
+	return scanner.Err()
+}
+
+// Add 添加一个敏感词
+func Add(word string) {
+	insert(root, strings.ToLower(word))
+	if f := Normalize(word); f != "" {
+		insert(folded, f)
+	}
+}
+
+func insert(n *node, word string) {
+	for _, r := range word {
+		child, ok := n.children[r]
+		if !ok {
+			child = &node{children: map[rune]*node{}}
+			n.children[r] = child
+		}
+		n = child
+	}
+	n.end = true
+}
+
+// ContainsFolded 文本归一化（见 Normalize）后是否包含敏感词，能识别 "5h1t"、"s-h-i-t" 等变体
+func ContainsFolded(text string) bool {
+	runes := []rune(Normalize(text))
+	for i := range runes {
+		n := folded
+		for j := i; j < len(runes); j++ {
+			child, ok := n.children[runes[j]]
+			if !ok {
+				break
+			}
+			n = child
+			if n.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Mask 将文本中的敏感词替换为等长的 *，返回处理后的文本及是否命中
+func Mask(text string) (string, bool) {
+	runes := []rune(text)
+	hit := false
+	for i := 0; i < len(runes); {
+		// 从 i 开始寻找最长匹配
+		n, matched := root, 0
+		for j := i; j < len(runes); j++ {
+			child, ok := n.children[unicode.ToLower(runes[j])]
+			if !ok {
+				break
+			}
+			n = child
+			if n.end {
+				matched = j - i + 1
+			}
+		}
+		if matched == 0 {
+			i++
+			continue
+		}
+		for k := i; k < i+matched; k++ {
+			runes[k] = '*'
+		}
+		hit = true
+		i += matched
+	}
+	if !hit {
+		return text, false
+	}
+	return string(runes), true
+}
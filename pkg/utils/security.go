@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"vida-go/internal/config"
@@ -16,12 +19,104 @@ var (
 	ErrExpiredToken = errors.New("token has expired")
 )
 
-// Claims 自定义 JWT Claims
+// Claims 自定义 JWT Claims。第三方应用的 OAuth 令牌带有 ClientID 与 Scope，
+// 客户端凭证模式签发的令牌不代表任何用户，UserID 为 0；
+// 管理员模拟登录签发的令牌带有 ImpersonatorID
 type Claims struct {
-	UserID int64 `json:"user_id"`
+	UserID         int64  `json:"user_id"`
+	ClientID       string `json:"client_id,omitempty"`
+	Scope          string `json:"scope,omitempty"` // 空格分隔
+	ImpersonatorID int64  `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsOAuth 是否为第三方应用的 OAuth 令牌
+func (c *Claims) IsOAuth() bool {
+	return c.ClientID != ""
+}
+
+// IsImpersonation 是否为管理员模拟登录的令牌
+func (c *Claims) IsImpersonation() bool {
+	return c.ImpersonatorID != 0
+}
+
+// signingKey JWT 签名密钥
+type signingKey struct {
+	id        string
+	secret    []byte
+	expiresAt time.Time // 轮换后保留到该时间，用于校验轮换前签发的 Token
+}
+
+var (
+	keyMu       sync.RWMutex
+	currentKey  *signingKey
+	retiredKeys = make(map[string]*signingKey)
+)
+
+// SetSigningKey 设置 JWT 签名密钥，之后签发的 Token 使用新密钥；
+// 旧密钥在一个 Token 有效期内仍可用于校验。keyID 为空时由密钥计算，返回密钥是否变化
+func SetSigningKey(keyID, secret string) bool {
+	if keyID == "" {
+		keyID = deriveKeyID(secret)
+	}
+
+	keyMu.Lock()
+	defer keyMu.Unlock()
+
+	if currentKey != nil && currentKey.id == keyID && string(currentKey.secret) == secret {
+		return false
+	}
+	now := time.Now()
+	if currentKey != nil {
+		currentKey.expiresAt = now.Add(config.GetJWT().ExpireDuration())
+		retiredKeys[currentKey.id] = currentKey
+	}
+	for id, k := range retiredKeys {
+		if id == keyID || now.After(k.expiresAt) {
+			delete(retiredKeys, id)
+		}
+	}
+	currentKey = &signingKey{id: keyID, secret: []byte(secret)}
+	return true
+}
+
+// deriveKeyID 由密钥计算 ID，避免在 Token 中暴露密钥
+func deriveKeyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+// signingKeyNow 返回当前签名密钥，未设置时使用配置文件中的密钥
+func signingKeyNow() *signingKey {
+	keyMu.RLock()
+	key := currentKey
+	keyMu.RUnlock()
+	if key != nil {
+		return key
+	}
+
+	jwtCfg := config.GetJWT()
+	SetSigningKey(jwtCfg.KeyID, jwtCfg.Secret)
+	keyMu.RLock()
+	defer keyMu.RUnlock()
+	return currentKey
+}
+
+// verifyingKey 按 Token 头部的 kid 查找校验密钥，没有 kid 的旧 Token 使用当前密钥
+func verifyingKey(keyID string) ([]byte, bool) {
+	current := signingKeyNow()
+	if keyID == "" || keyID == current.id {
+		return current.secret, true
+	}
+
+	keyMu.RLock()
+	defer keyMu.RUnlock()
+	if k, ok := retiredKeys[keyID]; ok && time.Now().Before(k.expiresAt) {
+		return k.secret, true
+	}
+	return nil, false
+}
+
 // HashPassword 使用 bcrypt 对密码进行哈希
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -38,19 +133,31 @@ func VerifyPassword(password, hash string) bool {
 
 // GenerateToken 生成 JWT Token
 func GenerateToken(userID int64) (string, error) {
-	jwtCfg := config.GetJWT()
+	return signToken(Claims{UserID: userID}, config.GetJWT().ExpireDuration())
+}
+
+// GenerateOAuthToken 为第三方应用生成带 scope 的访问令牌，userID 为 0 表示不代表任何用户
+func GenerateOAuthToken(userID int64, clientID, scope string, ttl time.Duration) (string, error) {
+	return signToken(Claims{UserID: userID, ClientID: clientID, Scope: scope}, ttl)
+}
+
+// GenerateImpersonationToken 为管理员生成以 userID 身份访问的短期令牌
+func GenerateImpersonationToken(userID, impersonatorID int64, ttl time.Duration) (string, error) {
+	return signToken(Claims{UserID: userID, ImpersonatorID: impersonatorID}, ttl)
+}
 
-	claims := Claims{
-		UserID: userID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(jwtCfg.ExpireDuration())),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    config.GetApp().Name,
-		},
+func signToken(claims Claims, ttl time.Duration) (string, error) {
+	key := signingKeyNow()
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Issuer:    config.GetApp().Name,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(jwtCfg.Secret))
+	token.Header["kid"] = key.id
+	tokenString, err := token.SignedString(key.secret)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -58,15 +165,30 @@ func GenerateToken(userID int64) (string, error) {
 	return tokenString, nil
 }
 
-// ParseToken 解析并验证 JWT Token，返回 Claims
+// ParseToken 解析并验证用户登录签发的 JWT Token，返回 Claims。第三方应用的 OAuth 令牌视为无效
 func ParseToken(tokenString string) (*Claims, error) {
-	jwtCfg := config.GetJWT()
+	claims, err := ParseAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.IsOAuth() {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
 
+// ParseAccessToken 解析并验证 JWT Token，包括第三方应用的 OAuth 令牌，由调用方校验 scope
+func ParseAccessToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(jwtCfg.Secret), nil
+		keyID, _ := token.Header["kid"].(string)
+		secret, ok := verifyingKey(keyID)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %s", keyID)
+		}
+		return secret, nil
 	})
 
 	if err != nil {
@@ -0,0 +1,18 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// 构建信息，编译时通过 -ldflags "-X vida-go/pkg/version.Version=..." 注入
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// String 返回 --version 输出的版本信息
+func String(name string) string {
+	return fmt.Sprintf("%s %s (commit %s, built %s, %s)", name, Version, Commit, BuildTime, runtime.Version())
+}